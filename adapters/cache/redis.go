@@ -20,6 +20,8 @@ type CacheLayer interface {
 	HSetAll(ctx context.Context, key string, value interface{}, expiry time.Duration) error
 	HGet(ctx context.Context, key, field string) (string, error)
 	HGetAll(ctx context.Context, key string) (map[string]interface{}, error)
+	Publish(ctx context.Context, channel string, message interface{}) error
+	Subscribe(ctx context.Context, channel string) *redis.PubSub
 }
 
 // Set: set a key/value
@@ -136,6 +138,22 @@ func (c *Cache) HGetAll(ctx context.Context, key string) (map[string]interface{}
 	return response, err
 }
 
+// Publish: publish a message on a channel
+func (c *Cache) Publish(ctx context.Context, channel string, message interface{}) error {
+	channel = keyPrefix + ":" + channel
+	value, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return c.Client.Publish(ctx, channel, value).Err()
+}
+
+// Subscribe: subscribe to the messages published on a channel
+func (c *Cache) Subscribe(ctx context.Context, channel string) *redis.PubSub {
+	channel = keyPrefix + ":" + channel
+	return c.Client.Subscribe(ctx, channel)
+}
+
 // ExpireAt: set expiry time for a key
 func (c *Cache) ExpireAt(ctx context.Context, key string, expire time.Duration) error {
 	cmd := c.Client.Expire(ctx, key, expire)
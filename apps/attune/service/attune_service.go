@@ -374,16 +374,72 @@ func (attuneService *AttuneService) SyncDataToLisByOmsOrderId(ctx context.Contex
 func (attuneService *AttuneService) SyncDataToLis(ctx context.Context, omsOrderId string, labId uint, sampleIds []uint,
 	sampleIdBarcodeMap map[uint]string) (string, *commonStructures.CommonError) {
 
+	attunePayloadMeta, orderDetails, attunePayload, cErr := attuneService.getSyncDataToLisPayload(ctx, omsOrderId,
+		labId, sampleIds, sampleIdBarcodeMap)
+	if cErr != nil {
+		return "", cErr
+	}
+
+	cErr = attuneService.AttuneClient.SyncDataToAttune(ctx, attunePayloadMeta.LabId, attunePayload)
+	if cErr != nil {
+		attuneService.SendSlackMessageIfLisSyncFailed(ctx, attunePayloadMeta.AttuneTestSampleMap,
+			attunePayload.TestDetailsList, attunePayloadMeta.PatientName, orderDetails)
+		return "", cErr
+	}
+
+	return attunePayloadMeta.VisitId, nil
+}
+
+// GetLisOrderRequest builds the new order for the given samples without sending it to Attune, so that other
+// LIS adapters can register the same visit, tests and barcodes.
+func (attuneService *AttuneService) GetLisOrderRequest(ctx context.Context, omsOrderId string, labId uint,
+	sampleIds []uint, sampleIdBarcodeMap map[uint]string) (commonStructures.LisOrderRequest,
+	*commonStructures.CommonError) {
+	attunePayloadMeta, _, _, cErr := attuneService.getSyncDataToLisPayload(ctx, omsOrderId, labId, sampleIds,
+		sampleIdBarcodeMap)
+	if cErr != nil {
+		return commonStructures.LisOrderRequest{}, cErr
+	}
+
+	lisOrderRequest := commonStructures.LisOrderRequest{
+		VisitId:       attunePayloadMeta.VisitId,
+		PatientId:     attunePayloadMeta.PatientId,
+		PatientName:   attunePayloadMeta.PatientName,
+		PatientGender: attunePayloadMeta.PatientGender,
+		PatientDob:    attunePayloadMeta.PatientDobAt,
+		CollectedAt:   attunePayloadMeta.CollectedAt,
+	}
+	for _, testDetails := range attunePayloadMeta.AttuneTestDetailsList {
+		barcodes := []string{}
+		for _, sampleDetails := range testDetails.BarcodeNo {
+			barcodes = append(barcodes, sampleDetails.SID)
+		}
+		lisOrderRequest.Tests = append(lisOrderRequest.Tests, commonStructures.LisOrderTest{
+			TestId:   uint(testDetails.TestID),
+			TestCode: testDetails.TestCode,
+			TestName: testDetails.TestName,
+			TestType: testDetails.TestType,
+			Barcodes: barcodes,
+		})
+	}
+	return lisOrderRequest, nil
+}
+
+func (attuneService *AttuneService) getSyncDataToLisPayload(ctx context.Context, omsOrderId string, labId uint,
+	sampleIds []uint, sampleIdBarcodeMap map[uint]string) (structures.AttunePayloadMeta, commonModels.OrderDetails,
+	commonStructures.AttuneSyncDataToLisRequest, *commonStructures.CommonError) {
+
 	attunePayloadMeta := structures.AttunePayloadMeta{}
+	attunePayload := commonStructures.AttuneSyncDataToLisRequest{}
 	var cErr *commonStructures.CommonError
 	attunePayloadMeta.AttuneTestSampleMap, cErr = attuneService.GetAttuneTestSampleMapByOmsOrderId(omsOrderId,
 		sampleIds, sampleIdBarcodeMap)
 	if cErr != nil {
-		return "", cErr
+		return attunePayloadMeta, commonModels.OrderDetails{}, attunePayload, cErr
 	}
 	orderDetails, patientDetails, cErr := attuneService.AttuneDao.GetOrderDetailsAndPatientDetailsByOmsOrderId(omsOrderId)
 	if cErr != nil {
-		return "", cErr
+		return attunePayloadMeta, orderDetails, attunePayload, cErr
 	}
 
 	attunePayloadMeta.SrfId = orderDetails.SrfId
@@ -396,7 +452,7 @@ func (attuneService *AttuneService) SyncDataToLis(ctx context.Context, omsOrderI
 	attunePayloadMeta.AttuneTestDetailsList, cErr = attuneService.GetTestDetailsListForSyncingToAttune(omsOrderId,
 		sampleIds, labId, attunePayloadMeta.TestBarcodeMap)
 	if cErr != nil {
-		return "", cErr
+		return attunePayloadMeta, orderDetails, attunePayload, cErr
 	}
 
 	attunePayloadMeta.PatientSalutation = commonUtils.GetSalutationByGender(patientDetails.Gender)
@@ -407,6 +463,10 @@ func (attuneService *AttuneService) SyncDataToLis(ctx context.Context, omsOrderI
 		patientDetails.SystemPatientId)
 
 	loc, _ := time.LoadLocation(commonConstants.LocalTimeZoneLocation)
+	attunePayloadMeta.PatientDobAt = patientDetails.Dob
+	if attunePayloadMeta.PatientDobAt == nil {
+		attunePayloadMeta.PatientDobAt = patientDetails.ExpectedDob
+	}
 	attunePayloadMeta.PatientDob = getPatientFormattedDob(patientDetails)
 
 	// Fetch Visit Id
@@ -416,7 +476,7 @@ func (attuneService *AttuneService) SyncDataToLis(ctx context.Context, omsOrderI
 	fomattedCollectedAt := ""
 	collectedAt, cErr := attuneService.AttuneDao.GetSampleCollectedAtBySampleIds(sampleIds)
 	if cErr != nil {
-		return "", cErr
+		return attunePayloadMeta, orderDetails, attunePayload, cErr
 	}
 	if collectedAt != nil {
 		fomattedCollectedAt = (*collectedAt).In(loc).Format(commonConstants.DateTimeInSecLayout)
@@ -424,20 +484,10 @@ func (attuneService *AttuneService) SyncDataToLis(ctx context.Context, omsOrderI
 		fomattedCollectedAt = time.Now().In(loc).Format(commonConstants.DateTimeInSecLayout)
 	}
 	attunePayloadMeta.SampleCollectedAt = fomattedCollectedAt
+	attunePayloadMeta.CollectedAt = collectedAt
 
-	attunePayload, cErr := getAttunePayloadForSyncingData(attunePayloadMeta)
-	if cErr != nil {
-		return "", cErr
-	}
-
-	cErr = attuneService.AttuneClient.SyncDataToAttune(ctx, attunePayloadMeta.LabId, attunePayload)
-	if cErr != nil {
-		attuneService.SendSlackMessageIfLisSyncFailed(ctx, attunePayloadMeta.AttuneTestSampleMap,
-			attunePayload.TestDetailsList, patientDetails.Name, orderDetails)
-		return "", cErr
-	}
-
-	return attunePayloadMeta.VisitId, nil
+	attunePayload, cErr = getAttunePayloadForSyncingData(attunePayloadMeta)
+	return attunePayloadMeta, orderDetails, attunePayload, cErr
 }
 
 func (attuneService *AttuneService) CancelLisSyncData(ctx context.Context, testDetails []commonModels.TestDetail,
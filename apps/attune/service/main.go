@@ -32,6 +32,8 @@ type AttuneServiceInterface interface {
 	SyncDataToLisByOmsOrderId(ctx context.Context, omsOrderId string, labId uint, samples []commonModels.Sample,
 		samplesMetadata []commonModels.SampleMetadata, sampleIdBarcodeMap map[uint]string) (
 		string, *commonStructures.CommonError)
+	GetLisOrderRequest(ctx context.Context, omsOrderId string, labId uint, sampleIds []uint,
+		sampleIdBarcodeMap map[uint]string) (commonStructures.LisOrderRequest, *commonStructures.CommonError)
 	ModifyLisDataPostSyncByOrderId(ctx context.Context, omsOrderId string, labId uint,
 		testDetail commonModels.TestDetail, sample commonStructures.SampleInfo) *commonStructures.CommonError
 	CancelLisSyncData(ctx context.Context, testDetails []commonModels.TestDetail,
@@ -1,6 +1,8 @@
 package structures
 
 import (
+	"time"
+
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
 )
 
@@ -11,8 +13,10 @@ type AttunePayloadMeta struct {
 	PatientNumber         string
 	PatientId             string
 	PatientDob            string
+	PatientDobAt          *time.Time
 	VisitId               string
 	SampleCollectedAt     string
+	CollectedAt           *time.Time
 	LabId                 uint
 	TotalTestsAmount      int
 	SrfId                 string
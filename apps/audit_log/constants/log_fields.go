@@ -1,5 +1,9 @@
 package constants
 
+import (
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+)
+
 // Audit Log Fields contains the fields for the sample audit log
 const (
 	LogFieldVisitID          = "visit_id"
@@ -18,3 +22,60 @@ const (
 	LogFieldCollectLaterReason = "collect_later_reason"
 	LogFieldRejectingLab       = "rejecting_lab"
 )
+
+// Audit Log Fields contains the fields for the task, test and investigation result audit logs
+const (
+	LogFieldDoctorTat           = "doctor_tat"
+	LogFieldCompletedAt         = "completed_at"
+	LogFieldReportStatus        = "report_status"
+	LogFieldReportSentAt        = "report_sent_at"
+	LogFieldIsDuplicate         = "is_duplicate"
+	LogFieldProcessingLabID     = "processing_lab_id"
+	LogFieldInvestigationValue  = "investigation_value"
+	LogFieldInvestigationStatus = "investigation_status"
+	LogFieldAbnormality         = "abnormality"
+	LogFieldIsCritical          = "is_critical"
+	LogFieldUom                 = "uom"
+	LogFieldReferenceRangeText  = "reference_range_text"
+	LogFieldApprovedBy          = "approved_by"
+	LogFieldDescription         = "description"
+	LogFieldRemarkType          = "remark_type"
+	LogFieldCoAuthorizedTo      = "co_authorized_to"
+	LogFieldCoAuthorizedAt      = "co_authorized_at"
+	LogFieldTestName            = "test_name"
+	LogFieldInvestigationName   = "investigation_name"
+)
+
+// Audit Log Entity Types are the sources of the entries of the order timeline
+const (
+	LogEntitySample         = "samples"
+	LogEntitySampleMetadata = "sample_metadata"
+)
+
+// AuditedFieldsByEntityType contains the fields compared between consecutive snapshots of every audited table
+var AuditedFieldsByEntityType = map[string][]string{
+	commonConstants.TableTasks: {
+		LogFieldStatus, LogFieldLabID, LogFieldDoctorTat, LogFieldCompletedAt, LogFieldDeletedAt,
+	},
+	commonConstants.TableTestDetails: {
+		LogFieldStatus, LogFieldReportStatus, LogFieldReportSentAt, LogFieldIsDuplicate, LogFieldProcessingLabID,
+		LogFieldDeletedAt,
+	},
+	commonConstants.TableInvestigationResults: {
+		LogFieldInvestigationValue, LogFieldInvestigationStatus, LogFieldAbnormality, LogFieldIsCritical,
+		LogFieldUom, LogFieldReferenceRangeText, LogFieldApprovedBy, LogFieldDeletedAt,
+	},
+	commonConstants.TableRemarks: {
+		LogFieldDescription, LogFieldRemarkType, LogFieldDeletedAt,
+	},
+	commonConstants.TableCoAuthorizedPathologists: {
+		LogFieldCoAuthorizedTo, LogFieldCoAuthorizedAt, LogFieldDeletedAt,
+	},
+}
+
+// AuditEntityNameFields contains the field that names the audited row in the timeline
+var AuditEntityNameFields = map[string]string{
+	commonConstants.TableTestDetails:          LogFieldTestName,
+	commonConstants.TableInvestigationResults: LogFieldInvestigationName,
+	commonConstants.TableRemarks:              LogFieldRemarkType,
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
 	commonUtils "github.com/Orange-Health/citadel/common/utils"
 )
 
@@ -27,3 +28,52 @@ func (auditLogController *AuditLog) GetLogsByOrderId(c *gin.Context) {
 
 	c.JSON(http.StatusOK, logs)
 }
+
+// @Summary		Get Order Timeline
+// @Description	Get the changes to the samples, task, tests, investigation results, remarks and co-authorizations
+// @Description	of an order as a single timeline, latest change first
+// @Tags			audit_logs
+// @Produce		json
+// @Param			orderId	path		string					true	"Order ID"
+// @Success		200		{object}	[]structures.TimelineLog	"Timeline"
+// @Failure		400,500	{object}	structures.CommonError		"Common API Response"
+// @Router			/api/v1/audit-logs/orders/{orderId}/timeline [get]
+func (auditLogController *AuditLog) GetTimelineByOrderId(c *gin.Context) {
+	omsOrderId := c.Param("orderId")
+	if omsOrderId == "" {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_ORDER_ID)
+		return
+	}
+
+	timelineLogs, cErr := auditLogController.AuditLogService.GetTimelineByOrderId(omsOrderId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, timelineLogs)
+}
+
+// @Summary		Get Task Timeline
+// @Description	Get the timeline of the order of a task
+// @Tags			audit_logs
+// @Produce		json
+// @Param			taskId		path		int						true	"Task ID"
+// @Success		200			{object}	[]structures.TimelineLog	"Timeline"
+// @Failure		400,404,500	{object}	structures.CommonError		"Common API Response"
+// @Router			/api/v1/audit-logs/tasks/{taskId}/timeline [get]
+func (auditLogController *AuditLog) GetTimelineByTaskId(c *gin.Context) {
+	taskId := commonUtils.ConvertStringToUint(c.Param("taskId"))
+	if taskId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_TASK_ID)
+		return
+	}
+
+	timelineLogs, cErr := auditLogController.AuditLogService.GetTimelineByTaskId(taskId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, timelineLogs)
+}
@@ -9,6 +9,8 @@ import (
 type DataLayer interface {
 	GetSampleAuditLogs(omsOrderId string) ([]commonModels.SamplesAudit, *commonStructures.CommonError)
 	GetSampleMetadataAuditLogs(omsOrderId string) ([]commonModels.SampleMetadataAudit, *commonStructures.CommonError)
+	GetAuditLogs(omsOrderId string) ([]commonModels.AuditLog, *commonStructures.CommonError)
+	GetTaskById(taskId uint) (commonModels.Task, *commonStructures.CommonError)
 }
 
 func (auditLogDao *AuditLogDao) GetSampleAuditLogs(omsOrderId string) ([]commonModels.SamplesAudit, *commonStructures.CommonError) {
@@ -26,3 +28,19 @@ func (auditLogDao *AuditLogDao) GetSampleMetadataAuditLogs(omsOrderId string) ([
 	}
 	return sampleMetadataAudits, nil
 }
+
+func (auditLogDao *AuditLogDao) GetAuditLogs(omsOrderId string) ([]commonModels.AuditLog, *commonStructures.CommonError) {
+	auditLogs := []commonModels.AuditLog{}
+	if err := auditLogDao.Db.Where("oms_order_id = ?", omsOrderId).Find(&auditLogs).Error; err != nil {
+		return auditLogs, commonUtils.HandleORMError(err)
+	}
+	return auditLogs, nil
+}
+
+func (auditLogDao *AuditLogDao) GetTaskById(taskId uint) (commonModels.Task, *commonStructures.CommonError) {
+	task := commonModels.Task{}
+	if err := auditLogDao.Db.Where("id = ?", taskId).First(&task).Error; err != nil {
+		return task, commonUtils.HandleORMError(err)
+	}
+	return task, nil
+}
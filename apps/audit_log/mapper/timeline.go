@@ -0,0 +1,153 @@
+package mapper
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/Orange-Health/citadel/apps/audit_log/constants"
+	"github.com/Orange-Health/citadel/apps/audit_log/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// MapAuditLogsToTimelineLogs diffs the consecutive snapshots of every audited row on the fields audited for its
+// table, the same way the sample audit logs are diffed.
+func MapAuditLogsToTimelineLogs(auditLogs []commonModels.AuditLog,
+	usernameMap map[uint]string) []structures.TimelineLog {
+	// Sort the audit logs by log timestamp in descending order so that every snapshot is compared with the one
+	// before it
+	slices.SortFunc(auditLogs, func(a, b commonModels.AuditLog) int {
+		if a.LogTimestamp.After(b.LogTimestamp) {
+			return -1
+		} else if a.LogTimestamp.Before(b.LogTimestamp) {
+			return 1
+		}
+		return cmp.Compare(b.LogID, a.LogID)
+	})
+
+	entityKeys := []string{}
+	entityAuditLogsMap := make(map[string][]commonModels.AuditLog)
+	for _, auditLog := range auditLogs {
+		entityKey := fmt.Sprintf("%s:%d", auditLog.EntityType, auditLog.EntityId)
+		if _, exists := entityAuditLogsMap[entityKey]; !exists {
+			entityKeys = append(entityKeys, entityKey)
+		}
+		entityAuditLogsMap[entityKey] = append(entityAuditLogsMap[entityKey], auditLog)
+	}
+
+	timelineLogs := []structures.TimelineLog{}
+	for _, entityKey := range entityKeys {
+		entityAuditLogs := entityAuditLogsMap[entityKey]
+		snapshots := []map[string]interface{}{}
+		for _, auditLog := range entityAuditLogs {
+			snapshots = append(snapshots, getRowSnapshot(auditLog.RowData))
+		}
+
+		for index, auditLog := range entityAuditLogs {
+			entityName := getSnapshotValue(snapshots[index], constants.AuditEntityNameFields[auditLog.EntityType])
+			if index < len(entityAuditLogs)-1 {
+				for _, fieldName := range constants.AuditedFieldsByEntityType[auditLog.EntityType] {
+					oldValue := getSnapshotValue(snapshots[index+1], fieldName)
+					newValue := getSnapshotValue(snapshots[index], fieldName)
+					if oldValue == newValue {
+						continue
+					}
+					timelineLogs = append(timelineLogs, getTimelineLogStruct(auditLog, entityName, fieldName,
+						oldValue, newValue, usernameMap))
+				}
+			} else {
+				timelineLogs = append(timelineLogs, getTimelineLogStruct(auditLog, entityName, "", "", "",
+					usernameMap))
+			}
+		}
+	}
+
+	return timelineLogs
+}
+
+// MapSampleLogsToTimelineLogs flattens the grouped sample audit logs into timeline logs.
+func MapSampleLogsToTimelineLogs(sampleLogs map[uint]structures.SampleLogBody) []structures.TimelineLog {
+	timelineLogs := []structures.TimelineLog{}
+	for sampleId, sampleLogBody := range sampleLogs {
+		for _, dbLogs := range sampleLogBody.Logs {
+			for _, dbLog := range dbLogs {
+				entityType := constants.LogEntitySample
+				if isSampleMetadataLogField(dbLog.FieldName) {
+					entityType = constants.LogEntitySampleMetadata
+				}
+				timelineLogs = append(timelineLogs, structures.TimelineLog{
+					EntityType:   entityType,
+					EntityId:     sampleId,
+					Operation:    dbLog.Operation,
+					FieldName:    dbLog.FieldName,
+					OldValue:     dbLog.OldValue,
+					NewValue:     dbLog.NewValue,
+					UserName:     dbLog.UserName,
+					LogTimestamp: dbLog.LogTimestamp,
+				})
+			}
+		}
+	}
+	return timelineLogs
+}
+
+// SortTimelineLogs sorts the timeline with the latest change first.
+func SortTimelineLogs(timelineLogs []structures.TimelineLog) {
+	slices.SortStableFunc(timelineLogs, func(a, b structures.TimelineLog) int {
+		if a.LogTimestamp.After(b.LogTimestamp) {
+			return -1
+		} else if a.LogTimestamp.Before(b.LogTimestamp) {
+			return 1
+		}
+		return 0
+	})
+}
+
+func isSampleMetadataLogField(fieldName string) bool {
+	return fieldName == constants.LogFieldNotReceivedReason || fieldName == constants.LogFieldCollectLaterReason ||
+		fieldName == constants.LogFieldRejectingLab
+}
+
+func getRowSnapshot(rowData string) map[string]interface{} {
+	snapshot := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(rowData)))
+	decoder.UseNumber()
+	_ = decoder.Decode(&snapshot)
+	return snapshot
+}
+
+func getSnapshotValue(snapshot map[string]interface{}, fieldName string) string {
+	if fieldName == "" {
+		return ""
+	}
+	switch value := snapshot[fieldName].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func getTimelineLogStruct(auditLog commonModels.AuditLog, entityName, fieldName, oldValue, newValue string,
+	userNameMap map[uint]string) structures.TimelineLog {
+	return structures.TimelineLog{
+		EntityType:   auditLog.EntityType,
+		EntityId:     auditLog.EntityId,
+		EntityName:   entityName,
+		Operation:    auditLog.LogAction,
+		FieldName:    fieldName,
+		OldValue:     oldValue,
+		NewValue:     newValue,
+		UserName:     getUserName(auditLog.UserId, userNameMap),
+		LogTimestamp: auditLog.LogTimestamp,
+	}
+}
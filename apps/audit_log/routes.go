@@ -10,4 +10,6 @@ func RouteHandler(router *gin.RouterGroup) {
 	auditLogController := controller.InitAuditLogController()
 
 	router.GET("/orders/:orderId", auditLogController.GetLogsByOrderId)
+	router.GET("/orders/:orderId/timeline", auditLogController.GetTimelineByOrderId)
+	router.GET("/tasks/:taskId/timeline", auditLogController.GetTimelineByTaskId)
 }
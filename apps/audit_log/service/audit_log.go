@@ -8,9 +8,45 @@ import (
 
 type AuditLogServiceInterface interface {
 	GetLogsByOrderId(omsOrderId string) (map[uint]structures.SampleLogBody, *commonStructures.CommonError)
+	GetTimelineByOrderId(omsOrderId string) ([]structures.TimelineLog, *commonStructures.CommonError)
+	GetTimelineByTaskId(taskId uint) ([]structures.TimelineLog, *commonStructures.CommonError)
 }
 
 func (auditLogService *AuditLogService) GetLogsByOrderId(omsOrderId string) (map[uint]structures.SampleLogBody, *commonStructures.CommonError) {
+	userIdToUserNameMap, _ := auditLogService.UserService.GetUserIdNameMap()
+	return auditLogService.getSampleLogsByOrderId(omsOrderId, userIdToUserNameMap)
+}
+
+// GetTimelineByOrderId merges the changes to the samples, task, tests, investigation results, remarks and
+// co-authorizations of an order into a single timeline, latest change first.
+func (auditLogService *AuditLogService) GetTimelineByOrderId(omsOrderId string) ([]structures.TimelineLog,
+	*commonStructures.CommonError) {
+	userIdToUserNameMap, _ := auditLogService.UserService.GetUserIdNameMap()
+	sampleLogs, cErr := auditLogService.getSampleLogsByOrderId(omsOrderId, userIdToUserNameMap)
+	if cErr != nil {
+		return nil, cErr
+	}
+	auditLogs, cErr := auditLogService.AuditLogDao.GetAuditLogs(omsOrderId)
+	if cErr != nil {
+		return nil, cErr
+	}
+	timelineLogs := mapper.MapSampleLogsToTimelineLogs(sampleLogs)
+	timelineLogs = append(timelineLogs, mapper.MapAuditLogsToTimelineLogs(auditLogs, userIdToUserNameMap)...)
+	mapper.SortTimelineLogs(timelineLogs)
+	return timelineLogs, nil
+}
+
+func (auditLogService *AuditLogService) GetTimelineByTaskId(taskId uint) ([]structures.TimelineLog,
+	*commonStructures.CommonError) {
+	task, cErr := auditLogService.AuditLogDao.GetTaskById(taskId)
+	if cErr != nil {
+		return nil, cErr
+	}
+	return auditLogService.GetTimelineByOrderId(task.OmsOrderId)
+}
+
+func (auditLogService *AuditLogService) getSampleLogsByOrderId(omsOrderId string,
+	userIdToUserNameMap map[uint]string) (map[uint]structures.SampleLogBody, *commonStructures.CommonError) {
 	sampleAuditLogs, cErr := auditLogService.AuditLogDao.GetSampleAuditLogs(omsOrderId)
 	if cErr != nil {
 		return nil, cErr
@@ -19,7 +55,6 @@ func (auditLogService *AuditLogService) GetLogsByOrderId(omsOrderId string) (map
 	if cErr != nil {
 		return nil, cErr
 	}
-	userIdToUserNameMap, _ := auditLogService.UserService.GetUserIdNameMap()
 	sampleAuditDbLogs, sampleVialTypeMap, sampleParentMap := mapper.MapSampleAuditLogs(sampleAuditLogs, userIdToUserNameMap)
 	sampleAuditMetaDbLogs := mapper.MapSampleMetadataAuditLogs(sampleMetadataAuditLogs, userIdToUserNameMap)
 	mergedLogs := mapper.MergeAndGroupSampleAuditDbLogsByTimestamp(sampleAuditDbLogs, sampleAuditMetaDbLogs, sampleVialTypeMap, sampleParentMap)
@@ -140,3 +140,30 @@ type SampleLogBody struct {
 	VialTypeId uint                        `json:"vial_type_id"`
 	Logs       map[time.Time][]SampleDBLog `json:"logs"`
 }
+
+// @swagger:model TimelineLog
+type TimelineLog struct {
+	// The audited entity - samples, sample_metadata, tasks, test_details, investigation_results, remarks or
+	// co_authorized_pathologists.
+	// example: "investigation_results"
+	EntityType string `json:"entity_type"`
+	// example: 1
+	EntityId uint `json:"entity_id"`
+	// The name of the entity, e.g. the investigation or test name.
+	// example: "Hemoglobin"
+	EntityName string `json:"entity_name"`
+	// The operation applied - INS, UPD, DEL
+	// example: "UPD"
+	Operation string `json:"operation"`
+	// The column updated
+	// example: "investigation_value"
+	FieldName string `json:"field_name"`
+	// example: "12.1"
+	OldValue string `json:"old_value"`
+	// example: "12.4"
+	NewValue string `json:"new_value"`
+	// example: "John Doe"
+	UserName string `json:"user_name"`
+	// example: "2026-10-18T09:00:00Z"
+	LogTimestamp time.Time `json:"log_timestamp"`
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/auto_verification/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructs "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Auto Verification Rules
+// @Description	Get Auto Verification Rules, optionally filtered by lab, master test and master investigation
+// @Tags			auto-verification
+// @Produce		json
+// @Param			lab_id					query		int									false	"Lab ID"
+// @Param			master_test_id			query		int									false	"Master Test ID"
+// @Param			master_investigation_id	query		int									false	"Master Investigation ID"
+// @Success		200						{object}	[]structures.AutoVerificationRule	"Auto Verification Rules"
+// @Failure		400,404,500				{object}	structures.CommonAPIResponse		"Common API Response"
+// @Router			/api/v1/auto-verification/rules [get]
+func (autoVerificationController *AutoVerification) GetAutoVerificationRules(c *gin.Context) {
+	filter := structures.AutoVerificationRulesFilter{
+		LabId:                 commonUtils.ConvertStringToUint(c.Query("lab_id")),
+		MasterTestId:          commonUtils.ConvertStringToUint(c.Query("master_test_id")),
+		MasterInvestigationId: commonUtils.ConvertStringToUint(c.Query("master_investigation_id")),
+	}
+
+	rules, cErr := autoVerificationController.AutoVerificationService.GetAutoVerificationRules(filter)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Summary		Create Auto Verification Rule
+// @Description	Create an Auto Verification Rule
+// @Tags			auto-verification
+// @Accept			json
+// @Produce		json
+// @Param			rule		body		structures.AutoVerificationRuleRequest	true	"Auto Verification Rule"
+// @Success		200			{object}	structures.AutoVerificationRule			"Auto Verification Rule"
+// @Failure		400,500		{object}	structures.CommonAPIResponse			"Common API Response"
+// @Router			/api/v1/auto-verification/rules [post]
+func (autoVerificationController *AutoVerification) CreateAutoVerificationRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleRequest := structures.AutoVerificationRuleRequest{}
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, cErr := autoVerificationController.AutoVerificationService.CreateAutoVerificationRule(c.Request.Context(),
+		ruleRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary		Update Auto Verification Rule
+// @Description	Update an Auto Verification Rule
+// @Tags			auto-verification
+// @Accept			json
+// @Produce		json
+// @Param			ruleId		path		int										true	"Auto Verification Rule ID"
+// @Param			rule		body		structures.AutoVerificationRuleRequest	true	"Auto Verification Rule"
+// @Success		200			{object}	structures.AutoVerificationRule			"Auto Verification Rule"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse			"Common API Response"
+// @Router			/api/v1/auto-verification/rules/{ruleId} [put]
+func (autoVerificationController *AutoVerification) UpdateAutoVerificationRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleId := commonUtils.ConvertStringToUint(c.Param("ruleId"))
+	if ruleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_AUTO_VERIFICATION_RULE_ID)
+		return
+	}
+
+	ruleRequest := structures.AutoVerificationRuleRequest{}
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, cErr := autoVerificationController.AutoVerificationService.UpdateAutoVerificationRule(c.Request.Context(),
+		ruleId, ruleRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary		Delete Auto Verification Rule
+// @Description	Delete an Auto Verification Rule
+// @Tags			auto-verification
+// @Produce		json
+// @Param			ruleId		path		int								true	"Auto Verification Rule ID"
+// @Success		200			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/auto-verification/rules/{ruleId} [delete]
+func (autoVerificationController *AutoVerification) DeleteAutoVerificationRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleId := commonUtils.ConvertStringToUint(c.Param("ruleId"))
+	if ruleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_AUTO_VERIFICATION_RULE_ID)
+		return
+	}
+
+	cErr = autoVerificationController.AutoVerificationService.DeleteAutoVerificationRule(c.Request.Context(), ruleId,
+		userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructs.CommonAPIResponse{
+		Message: commonConstants.DONE_RESPONSE,
+	})
+}
+
+// @Summary		Dry Run Auto Verification
+// @Description	Evaluate the configured auto verification rules, or the rules of the request, against the saved
+// @Description	investigations of a task without updating them
+// @Tags			auto-verification
+// @Accept			json
+// @Produce		json
+// @Param			dry_run		body		structures.DryRunRequest				true	"Dry Run Request"
+// @Success		200			{object}	[]structures.DryRunInvestigationResult	"Dry Run Results"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse			"Common API Response"
+// @Router			/api/v1/auto-verification/dry-run [post]
+func (autoVerificationController *AutoVerification) DryRunAutoVerification(c *gin.Context) {
+	dryRunRequest := structures.DryRunRequest{}
+	if err := c.ShouldBindJSON(&dryRunRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dryRunResults, cErr := autoVerificationController.AutoVerificationService.DryRunAutoVerification(
+		c.Request.Context(), dryRunRequest)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, dryRunResults)
+}
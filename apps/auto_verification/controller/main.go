@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/auto_verification/service"
+)
+
+type AutoVerification struct {
+	AutoVerificationService service.AutoVerificationServiceInterface
+}
+
+func InitAutoVerificationController() *AutoVerification {
+	return &AutoVerification{
+		AutoVerificationService: service.InitializeAutoVerificationService(),
+	}
+}
@@ -0,0 +1,167 @@
+package dao
+
+import (
+	"github.com/Orange-Health/citadel/apps/auto_verification/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetAutoVerificationRuleById(ruleId uint) (commonModels.AutoVerificationRule, *commonStructures.CommonError)
+	GetAutoVerificationRules(filter structures.AutoVerificationRulesFilter) (
+		[]commonModels.AutoVerificationRule, *commonStructures.CommonError)
+	GetActiveAutoVerificationRules() ([]commonModels.AutoVerificationRule, *commonStructures.CommonError)
+	GetTaskInvestigationDetails(taskId uint) ([]structures.TaskInvestigationDetails, *commonStructures.CommonError)
+	GetTaskPatientDetails(taskId uint) (structures.TaskPatientDetails, *commonStructures.CommonError)
+
+	CreateAutoVerificationRule(rule commonModels.AutoVerificationRule) (
+		commonModels.AutoVerificationRule, *commonStructures.CommonError)
+	UpdateAutoVerificationRule(rule commonModels.AutoVerificationRule) (
+		commonModels.AutoVerificationRule, *commonStructures.CommonError)
+	DeleteAutoVerificationRule(ruleId, userId uint) *commonStructures.CommonError
+}
+
+func (autoVerificationDao *AutoVerificationDao) GetAutoVerificationRuleById(ruleId uint) (
+	commonModels.AutoVerificationRule, *commonStructures.CommonError) {
+
+	rule := commonModels.AutoVerificationRule{}
+	if err := autoVerificationDao.Db.Where("id = ?", ruleId).First(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (autoVerificationDao *AutoVerificationDao) GetAutoVerificationRules(
+	filter structures.AutoVerificationRulesFilter) ([]commonModels.AutoVerificationRule, *commonStructures.CommonError) {
+
+	rules := []commonModels.AutoVerificationRule{}
+	query := autoVerificationDao.Db
+	if filter.LabId != 0 {
+		query = query.Where("lab_id = ?", filter.LabId)
+	}
+	if filter.MasterTestId != 0 {
+		query = query.Where("master_test_id = ?", filter.MasterTestId)
+	}
+	if filter.MasterInvestigationId != 0 {
+		query = query.Where("master_investigation_id = ?", filter.MasterInvestigationId)
+	}
+	if err := query.Order("id").Find(&rules).Error; err != nil {
+		return rules, commonUtils.HandleORMError(err)
+	}
+
+	return rules, nil
+}
+
+func (autoVerificationDao *AutoVerificationDao) GetActiveAutoVerificationRules() (
+	[]commonModels.AutoVerificationRule, *commonStructures.CommonError) {
+
+	rules := []commonModels.AutoVerificationRule{}
+	if err := autoVerificationDao.Db.Where("is_active = ?", true).Order("id").Find(&rules).Error; err != nil {
+		return rules, commonUtils.HandleORMError(err)
+	}
+
+	return rules, nil
+}
+
+func (autoVerificationDao *AutoVerificationDao) GetTaskInvestigationDetails(taskId uint) (
+	[]structures.TaskInvestigationDetails, *commonStructures.CommonError) {
+
+	taskInvestigationDetails := []structures.TaskInvestigationDetails{}
+	selectStrings := []string{
+		"investigation_results.id as id",
+		"investigation_results.master_investigation_id as master_investigation_id",
+		"investigation_results.investigation_name as investigation_name",
+		"investigation_results.investigation_value as investigation_value",
+		"investigation_results.lis_code as lis_code",
+		"investigation_results.method_type as method_type",
+		"investigation_results.abnormality as abnormality",
+		"investigation_results.entered_at as entered_at",
+		"investigation_results.is_auto_approved as is_auto_approved",
+		"investigation_results.approval_source as approval_source",
+		"investigation_results.auto_approval_failure_reason as auto_approval_failure_reason",
+		"test_details.master_test_id as master_test_id",
+		"investigation_results_metadata.qc_status as qc_status",
+		"investigation_results_metadata.westgard_status as westgard_status",
+	}
+
+	if err := autoVerificationDao.Db.Table(commonConstants.TableInvestigationResults).
+		Joins("INNER JOIN test_details ON test_details.id = investigation_results.test_details_id").
+		Joins("LEFT JOIN investigation_results_metadata ON "+
+			"investigation_results_metadata.investigation_result_id = investigation_results.id AND "+
+			"investigation_results_metadata.deleted_at IS NULL").
+		Select(selectStrings).
+		Where("test_details.task_id = ?", taskId).
+		Where("investigation_results.deleted_at IS NULL").
+		Where("test_details.deleted_at IS NULL").
+		Scan(&taskInvestigationDetails).Error; err != nil {
+		return taskInvestigationDetails, commonUtils.HandleORMError(err)
+	}
+
+	return taskInvestigationDetails, nil
+}
+
+func (autoVerificationDao *AutoVerificationDao) GetTaskPatientDetails(taskId uint) (
+	structures.TaskPatientDetails, *commonStructures.CommonError) {
+
+	taskPatientDetails := structures.TaskPatientDetails{}
+	selectStrings := []string{
+		"tasks.lab_id as lab_id",
+		"tasks.city_code as city_code",
+		"patient_details.dob as patient_dob",
+		"patient_details.expected_dob as patient_expected_dob",
+		"patient_details.gender as patient_gender",
+		"patient_details.is_pregnant as patient_is_pregnant",
+		"patient_details.trimester as patient_trimester",
+	}
+
+	if err := autoVerificationDao.Db.Table(commonConstants.TableTasks).
+		Joins("INNER JOIN patient_details ON patient_details.id = tasks.patient_details_id").
+		Select(selectStrings).
+		Where("tasks.id = ?", taskId).
+		Scan(&taskPatientDetails).Error; err != nil {
+		return taskPatientDetails, commonUtils.HandleORMError(err)
+	}
+
+	return taskPatientDetails, nil
+}
+
+func (autoVerificationDao *AutoVerificationDao) CreateAutoVerificationRule(rule commonModels.AutoVerificationRule) (
+	commonModels.AutoVerificationRule, *commonStructures.CommonError) {
+
+	if err := autoVerificationDao.Db.Create(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (autoVerificationDao *AutoVerificationDao) UpdateAutoVerificationRule(rule commonModels.AutoVerificationRule) (
+	commonModels.AutoVerificationRule, *commonStructures.CommonError) {
+
+	if err := autoVerificationDao.Db.Save(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (autoVerificationDao *AutoVerificationDao) DeleteAutoVerificationRule(ruleId,
+	userId uint) *commonStructures.CommonError {
+
+	currentTime := commonUtils.GetCurrentTime()
+	ruleUpdates := map[string]interface{}{
+		"deleted_by": userId,
+		"updated_by": userId,
+		"deleted_at": currentTime,
+		"updated_at": currentTime,
+	}
+	if err := autoVerificationDao.Db.Model(&commonModels.AutoVerificationRule{}).Where("id = ?", ruleId).
+		Updates(ruleUpdates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type AutoVerificationDao struct {
+	Db *gorm.DB
+}
+
+func InitializeAutoVerificationDao() DataLayer {
+	return &AutoVerificationDao{
+		Db: psql.GetDbInstance(),
+	}
+}
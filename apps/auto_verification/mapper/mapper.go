@@ -0,0 +1,72 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"github.com/Orange-Health/citadel/apps/auto_verification/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapAutoVerificationRule(rule commonModels.AutoVerificationRule) structures.AutoVerificationRule {
+	return structures.AutoVerificationRule{
+		Id:                    rule.Id,
+		Name:                  rule.Name,
+		RuleType:              rule.RuleType,
+		LabId:                 rule.LabId,
+		MasterTestId:          rule.MasterTestId,
+		MasterInvestigationId: rule.MasterInvestigationId,
+		Parameters:            GetAutoVerificationRuleParameters(rule),
+		IsActive:              rule.IsActive,
+	}
+}
+
+func MapAutoVerificationRules(rules []commonModels.AutoVerificationRule) []structures.AutoVerificationRule {
+	autoVerificationRules := []structures.AutoVerificationRule{}
+	for _, rule := range rules {
+		autoVerificationRules = append(autoVerificationRules, MapAutoVerificationRule(rule))
+	}
+	return autoVerificationRules
+}
+
+func MapAutoVerificationRuleRequest(rule commonModels.AutoVerificationRule,
+	ruleRequest structures.AutoVerificationRuleRequest, userId uint) commonModels.AutoVerificationRule {
+	if rule.Id == 0 {
+		rule.CreatedBy = userId
+		rule.IsActive = true
+	}
+	rule.Name = ruleRequest.Name
+	rule.RuleType = ruleRequest.RuleType
+	rule.LabId = ruleRequest.LabId
+	rule.MasterTestId = ruleRequest.MasterTestId
+	rule.MasterInvestigationId = ruleRequest.MasterInvestigationId
+	parameters, _ := json.Marshal(ruleRequest.Parameters)
+	rule.Parameters = string(parameters)
+	if ruleRequest.IsActive != nil {
+		rule.IsActive = *ruleRequest.IsActive
+	}
+	rule.UpdatedBy = userId
+	return rule
+}
+
+// GetAutoVerificationRuleParameters parses the parameters stored on the rule. Unparsable parameters are
+// treated as empty, which the evaluation of every rule type handles.
+func GetAutoVerificationRuleParameters(rule commonModels.AutoVerificationRule) structures.AutoVerificationRuleParameters {
+	parameters := structures.AutoVerificationRuleParameters{}
+	if rule.Parameters != "" {
+		_ = json.Unmarshal([]byte(rule.Parameters), &parameters)
+	}
+	return parameters
+}
+
+// MapAutoVerificationResultToMetadata records the evaluated rules on the investigation result metadata.
+func MapAutoVerificationResultToMetadata(result structures.AutoVerificationResult,
+	metadata commonModels.InvestigationResultMetadata) commonModels.InvestigationResultMetadata {
+	metadata.AutoVerificationEvaluations = ""
+	if len(result.Evaluations) > 0 {
+		evaluations, err := json.Marshal(result.Evaluations)
+		if err == nil {
+			metadata.AutoVerificationEvaluations = string(evaluations)
+		}
+	}
+	return metadata
+}
@@ -0,0 +1,17 @@
+package autoVerification
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/auto_verification/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	autoVerificationController := controller.InitAutoVerificationController()
+
+	router.GET("/rules", autoVerificationController.GetAutoVerificationRules)
+	router.POST("/rules", autoVerificationController.CreateAutoVerificationRule)
+	router.PUT("/rules/:ruleId", autoVerificationController.UpdateAutoVerificationRule)
+	router.DELETE("/rules/:ruleId", autoVerificationController.DeleteAutoVerificationRule)
+	router.POST("/dry-run", autoVerificationController.DryRunAutoVerification)
+}
@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/auto_verification/mapper"
+	"github.com/Orange-Health/citadel/apps/auto_verification/structures"
+	deltaCheckStructures "github.com/Orange-Health/citadel/apps/delta_check/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type AutoVerificationServiceInterface interface {
+	GetAutoVerificationRules(filter structures.AutoVerificationRulesFilter) (
+		[]structures.AutoVerificationRule, *commonStructures.CommonError)
+	CreateAutoVerificationRule(ctx context.Context, ruleRequest structures.AutoVerificationRuleRequest,
+		userId uint) (structures.AutoVerificationRule, *commonStructures.CommonError)
+	UpdateAutoVerificationRule(ctx context.Context, ruleId uint, ruleRequest structures.AutoVerificationRuleRequest,
+		userId uint) (structures.AutoVerificationRule, *commonStructures.CommonError)
+	DeleteAutoVerificationRule(ctx context.Context, ruleId, userId uint) *commonStructures.CommonError
+
+	GetActiveAutoVerificationRules(ctx context.Context) []commonModels.AutoVerificationRule
+	EvaluateAutoVerification(ctx context.Context, request structures.AutoVerificationRequest) structures.AutoVerificationResult
+	DryRunAutoVerification(ctx context.Context, dryRunRequest structures.DryRunRequest) (
+		[]structures.DryRunInvestigationResult, *commonStructures.CommonError)
+	EvaluateAutoVerificationForTask(ctx context.Context, taskId uint,
+		investigationResults []commonModels.InvestigationResult) (map[uint]structures.AutoVerificationResult,
+		map[uint]deltaCheckStructures.DeltaCheckResult, *commonStructures.CommonError)
+}
+
+func (autoVerificationService *AutoVerificationService) GetAutoVerificationRules(
+	filter structures.AutoVerificationRulesFilter) ([]structures.AutoVerificationRule, *commonStructures.CommonError) {
+
+	rules, cErr := autoVerificationService.AutoVerificationDao.GetAutoVerificationRules(filter)
+	if cErr != nil {
+		return []structures.AutoVerificationRule{}, cErr
+	}
+
+	return mapper.MapAutoVerificationRules(rules), nil
+}
+
+func (autoVerificationService *AutoVerificationService) CreateAutoVerificationRule(ctx context.Context,
+	ruleRequest structures.AutoVerificationRuleRequest, userId uint) (
+	structures.AutoVerificationRule, *commonStructures.CommonError) {
+
+	if cErr := validateAutoVerificationRuleRequest(ruleRequest); cErr != nil {
+		return structures.AutoVerificationRule{}, cErr
+	}
+
+	rule := mapper.MapAutoVerificationRuleRequest(commonModels.AutoVerificationRule{}, ruleRequest, userId)
+	rule, cErr := autoVerificationService.AutoVerificationDao.CreateAutoVerificationRule(rule)
+	if cErr != nil {
+		return structures.AutoVerificationRule{}, cErr
+	}
+
+	autoVerificationService.invalidateAutoVerificationRulesCache(ctx)
+	return mapper.MapAutoVerificationRule(rule), nil
+}
+
+func (autoVerificationService *AutoVerificationService) UpdateAutoVerificationRule(ctx context.Context, ruleId uint,
+	ruleRequest structures.AutoVerificationRuleRequest, userId uint) (
+	structures.AutoVerificationRule, *commonStructures.CommonError) {
+
+	if cErr := validateAutoVerificationRuleRequest(ruleRequest); cErr != nil {
+		return structures.AutoVerificationRule{}, cErr
+	}
+
+	rule, cErr := autoVerificationService.getAutoVerificationRuleById(ruleId)
+	if cErr != nil {
+		return structures.AutoVerificationRule{}, cErr
+	}
+
+	rule = mapper.MapAutoVerificationRuleRequest(rule, ruleRequest, userId)
+	rule, cErr = autoVerificationService.AutoVerificationDao.UpdateAutoVerificationRule(rule)
+	if cErr != nil {
+		return structures.AutoVerificationRule{}, cErr
+	}
+
+	autoVerificationService.invalidateAutoVerificationRulesCache(ctx)
+	return mapper.MapAutoVerificationRule(rule), nil
+}
+
+func (autoVerificationService *AutoVerificationService) DeleteAutoVerificationRule(ctx context.Context,
+	ruleId, userId uint) *commonStructures.CommonError {
+
+	if _, cErr := autoVerificationService.getAutoVerificationRuleById(ruleId); cErr != nil {
+		return cErr
+	}
+
+	if cErr := autoVerificationService.AutoVerificationDao.DeleteAutoVerificationRule(ruleId, userId); cErr != nil {
+		return cErr
+	}
+
+	autoVerificationService.invalidateAutoVerificationRulesCache(ctx)
+	return nil
+}
+
+// GetActiveAutoVerificationRules returns every active rule. Any error is logged and no rules are returned,
+// so that callers fall back to the default rules.
+func (autoVerificationService *AutoVerificationService) GetActiveAutoVerificationRules(
+	ctx context.Context) []commonModels.AutoVerificationRule {
+
+	rules := []commonModels.AutoVerificationRule{}
+	err := autoVerificationService.Cache.Get(ctx, commonConstants.CacheKeyAutoVerificationRules, &rules)
+	if err == nil {
+		return rules
+	}
+
+	rules, cErr := autoVerificationService.AutoVerificationDao.GetActiveAutoVerificationRules()
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_FETCHING_AUTO_VERIFICATION_RULES,
+			nil, errors.New(cErr.Message))
+		return []commonModels.AutoVerificationRule{}
+	}
+	_ = autoVerificationService.Cache.Set(ctx, commonConstants.CacheKeyAutoVerificationRules, rules,
+		commonConstants.CacheExpiry5MinutesInt)
+
+	return rules
+}
+
+// EvaluateAutoVerification decides if the investigation can be auto approved. QC failures and invalid values
+// are never auto approved and the default auto approval codes always are. Otherwise every applicable rule is
+// evaluated and the failure reasons of all failed rules are listed in the result.
+func (autoVerificationService *AutoVerificationService) EvaluateAutoVerification(ctx context.Context,
+	request structures.AutoVerificationRequest) structures.AutoVerificationResult {
+
+	if request.IsQcFailed {
+		return getAutoVerificationResult(false, commonConstants.APPROVAL_SOURCE_NA,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_QC_FAILED, nil)
+	}
+	investigationValue := request.InvestigationValue
+	if investigationValue == "" || commonUtils.ConvertStringToFloat32ForAbnormality(investigationValue) < 0 ||
+		investigationValue[0] == '-' {
+		return getAutoVerificationResult(false, commonConstants.APPROVAL_SOURCE_NA,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_INVALID_INVESTIGATION_VALUE, nil)
+	}
+	if commonUtils.SliceContainsString(commonConstants.DefaultAutoApprovalCodes, request.Investigation.LisCode) {
+		return getAutoVerificationResult(true, commonConstants.APPROVAL_SOURCE_OH,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_NA, nil)
+	}
+
+	evaluations, failureReasons := []structures.AutoVerificationRuleEvaluation{}, []string{}
+	for _, rule := range getApplicableRules(request) {
+		evaluation := autoVerificationService.evaluateRule(ctx, rule, request)
+		evaluations = append(evaluations, evaluation)
+		if evaluation.Status == commonConstants.AutoVerificationStatusFailed {
+			failureReasons = append(failureReasons, evaluation.FailureReason)
+		}
+	}
+
+	if len(failureReasons) > 0 {
+		return getAutoVerificationResult(false, commonConstants.APPROVAL_SOURCE_NA,
+			commonUtils.JoinAutoApprovalFailureReasons(failureReasons), evaluations)
+	}
+
+	approvalSource := commonConstants.APPROVAL_SOURCE_OH
+	if request.ImDevice && request.ImDeviceFlag {
+		approvalSource = commonConstants.APPROVAL_SOURCE_IM
+	}
+	return getAutoVerificationResult(true, approvalSource, commonConstants.AUTO_APPROVAL_FAIL_REASON_NA, evaluations)
+}
+
+// DryRunAutoVerification evaluates the auto verification rules against the saved investigations of a task
+// without updating them. The rules of the request are evaluated instead of the configured rules if given.
+func (autoVerificationService *AutoVerificationService) DryRunAutoVerification(ctx context.Context,
+	dryRunRequest structures.DryRunRequest) ([]structures.DryRunInvestigationResult, *commonStructures.CommonError) {
+
+	dryRunResults := []structures.DryRunInvestigationResult{}
+
+	rules := []commonModels.AutoVerificationRule{}
+	if len(dryRunRequest.Rules) > 0 {
+		for _, ruleRequest := range dryRunRequest.Rules {
+			if cErr := validateAutoVerificationRuleRequest(ruleRequest); cErr != nil {
+				return dryRunResults, cErr
+			}
+			rules = append(rules, mapper.MapAutoVerificationRuleRequest(commonModels.AutoVerificationRule{},
+				ruleRequest, 0))
+		}
+	} else {
+		rules = autoVerificationService.GetActiveAutoVerificationRules(ctx)
+	}
+
+	taskInvestigations, autoVerificationResults, _, cErr := autoVerificationService.evaluateTaskInvestigations(ctx,
+		dryRunRequest.TaskId, rules, []commonModels.InvestigationResult{})
+	if cErr != nil {
+		return dryRunResults, cErr
+	}
+	if len(taskInvestigations) == 0 {
+		return dryRunResults, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_NO_INVESTIGATIONS_FOR_DRY_RUN,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	for _, taskInvestigation := range taskInvestigations {
+		dryRunResults = append(dryRunResults, structures.DryRunInvestigationResult{
+			InvestigationResultId:            taskInvestigation.Id,
+			MasterInvestigationId:            taskInvestigation.MasterInvestigationId,
+			InvestigationName:                taskInvestigation.InvestigationName,
+			LisCode:                          taskInvestigation.LisCode,
+			InvestigationValue:               taskInvestigation.InvestigationValue,
+			CurrentIsAutoApproved:            taskInvestigation.IsAutoApproved,
+			CurrentAutoApprovalFailureReason: taskInvestigation.AutoApprovalFailureReason,
+			Result:                           autoVerificationResults[taskInvestigation.Id],
+		})
+	}
+
+	return dryRunResults, nil
+}
+
+// EvaluateAutoVerificationForTask evaluates the configured auto verification rules and the delta checks for the
+// investigations of a task as they are about to be saved. Both results are keyed by investigation result id.
+func (autoVerificationService *AutoVerificationService) EvaluateAutoVerificationForTask(ctx context.Context,
+	taskId uint, investigationResults []commonModels.InvestigationResult) (
+	map[uint]structures.AutoVerificationResult, map[uint]deltaCheckStructures.DeltaCheckResult,
+	*commonStructures.CommonError) {
+
+	_, autoVerificationResults, deltaCheckResults, cErr := autoVerificationService.evaluateTaskInvestigations(ctx,
+		taskId, autoVerificationService.GetActiveAutoVerificationRules(ctx), investigationResults)
+	return autoVerificationResults, deltaCheckResults, cErr
+}
+
+// evaluateTaskInvestigations evaluates the rules against the investigations of a task that have a value, taking
+// the value, method type, abnormality and entry time of investigationResults over the saved ones.
+// IM device flags are not stored, they are inferred from the approval source and failure reason on record.
+func (autoVerificationService *AutoVerificationService) evaluateTaskInvestigations(ctx context.Context,
+	taskId uint, rules []commonModels.AutoVerificationRule, investigationResults []commonModels.InvestigationResult) (
+	[]structures.TaskInvestigationDetails, map[uint]structures.AutoVerificationResult,
+	map[uint]deltaCheckStructures.DeltaCheckResult, *commonStructures.CommonError) {
+
+	autoVerificationResults := map[uint]structures.AutoVerificationResult{}
+	deltaCheckResults := map[uint]deltaCheckStructures.DeltaCheckResult{}
+
+	taskPatientDetails, cErr := autoVerificationService.AutoVerificationDao.GetTaskPatientDetails(taskId)
+	if cErr != nil {
+		return nil, autoVerificationResults, deltaCheckResults, cErr
+	}
+
+	savedTaskInvestigations, cErr := autoVerificationService.AutoVerificationDao.GetTaskInvestigationDetails(taskId)
+	if cErr != nil {
+		return nil, autoVerificationResults, deltaCheckResults, cErr
+	}
+
+	investigationResultMap := map[uint]commonModels.InvestigationResult{}
+	for _, investigationResult := range investigationResults {
+		investigationResultMap[investigationResult.Id] = investigationResult
+	}
+
+	taskInvestigations, deltaCheckInvestigations := []structures.TaskInvestigationDetails{},
+		[]commonModels.InvestigationResult{}
+	investigationCodes, lisCodeValueMap := []string{}, map[string]string{}
+	for _, taskInvestigation := range savedTaskInvestigations {
+		if investigationResult, ok := investigationResultMap[taskInvestigation.Id]; ok {
+			taskInvestigation.InvestigationValue = investigationResult.InvestigationValue
+			taskInvestigation.MethodType = investigationResult.MethodType
+			taskInvestigation.Abnormality = investigationResult.Abnormality
+			taskInvestigation.EnteredAt = investigationResult.EnteredAt
+		}
+		if taskInvestigation.InvestigationValue == "" {
+			continue
+		}
+		deltaCheckInvestigation := commonModels.InvestigationResult{
+			MasterInvestigationId: taskInvestigation.MasterInvestigationId,
+			InvestigationValue:    taskInvestigation.InvestigationValue,
+			LisCode:               taskInvestigation.LisCode,
+			EnteredAt:             taskInvestigation.EnteredAt,
+		}
+		deltaCheckInvestigation.Id = taskInvestigation.Id
+		taskInvestigations = append(taskInvestigations, taskInvestigation)
+		deltaCheckInvestigations = append(deltaCheckInvestigations, deltaCheckInvestigation)
+		investigationCodes = append(investigationCodes, taskInvestigation.LisCode)
+		lisCodeValueMap[taskInvestigation.LisCode] = taskInvestigation.InvestigationValue
+	}
+	if len(taskInvestigations) == 0 {
+		return taskInvestigations, autoVerificationResults, deltaCheckResults, nil
+	}
+
+	var patientDob *time.Time
+	if taskPatientDetails.PatientExpectedDob != nil {
+		patientDob = taskPatientDetails.PatientExpectedDob
+	}
+	if taskPatientDetails.PatientDob != nil {
+		patientDob = taskPatientDetails.PatientDob
+	}
+	patientDobString, patientAgeDays := "", (*uint)(nil)
+	if patientDob != nil {
+		patientDobString = patientDob.Format(commonConstants.DateLayout)
+		ageDays := commonUtils.GetAgeDaysFromDob(*patientDob)
+		patientAgeDays = &ageDays
+	}
+	patientGender := commonUtils.GetGenderConstant(taskPatientDetails.PatientGender)
+
+	masterInvestigations, err := autoVerificationService.CdsClient.GetInvestigationDetails(ctx,
+		commonUtils.CreateUniqueSliceString(investigationCodes), taskPatientDetails.CityCode, taskPatientDetails.LabId,
+		patientDobString, patientGender)
+	if err != nil {
+		return taskInvestigations, autoVerificationResults, deltaCheckResults, &commonStructures.CommonError{
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	masterInvestigations = commonUtils.ResolveInvestigationReferenceRanges(masterInvestigations,
+		commonUtils.GetReferenceRangePatientDetails(taskPatientDetails.PatientDob, taskPatientDetails.PatientExpectedDob,
+			taskPatientDetails.PatientGender, taskPatientDetails.PatientIsPregnant, taskPatientDetails.PatientTrimester))
+	masterInvestigationMap := map[uint]commonStructures.Investigation{}
+	for _, masterInvestigation := range masterInvestigations {
+		masterInvestigationMap[masterInvestigation.InvestigationId] = masterInvestigation
+	}
+
+	deltaCheckResults, cErr = autoVerificationService.DeltaCheckService.EvaluateDeltaChecksForTask(ctx, taskId,
+		deltaCheckInvestigations)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_EVALUATING_DELTA_CHECK, nil,
+			errors.New(cErr.Message))
+		deltaCheckResults = map[uint]deltaCheckStructures.DeltaCheckResult{}
+	}
+
+	for _, taskInvestigation := range taskInvestigations {
+		investigation, ok := masterInvestigationMap[taskInvestigation.MasterInvestigationId]
+		if !ok {
+			investigation = commonStructures.Investigation{
+				InvestigationId: taskInvestigation.MasterInvestigationId,
+				LisCode:         taskInvestigation.LisCode,
+			}
+		}
+
+		autoVerificationResults[taskInvestigation.Id] = autoVerificationService.EvaluateAutoVerification(ctx,
+			structures.AutoVerificationRequest{
+				InvestigationValue: taskInvestigation.InvestigationValue,
+				MethodType:         taskInvestigation.MethodType,
+				ImDevice: taskInvestigation.ApprovalSource == commonConstants.APPROVAL_SOURCE_IM ||
+					commonUtils.HasAutoApprovalFailureReasonCode(taskInvestigation.AutoApprovalFailureReason,
+						commonConstants.AUTO_APPROVAL_FAIL_REASON_IM_DEVICE),
+				ImDeviceFlag: taskInvestigation.ApprovalSource == commonConstants.APPROVAL_SOURCE_IM,
+				IsQcFailed: taskInvestigation.QcStatus == commonConstants.QCStatusFail ||
+					taskInvestigation.WestgardStatus == commonConstants.QCStatusFail,
+				Abnormality:      taskInvestigation.Abnormality,
+				Investigation:    investigation,
+				DeltaCheckResult: deltaCheckResults[taskInvestigation.Id],
+				Context: structures.AutoVerificationContext{
+					LabId:           taskPatientDetails.LabId,
+					MasterTestId:    taskInvestigation.MasterTestId,
+					PatientAgeDays:  patientAgeDays,
+					PatientGender:   patientGender,
+					LisCodeValueMap: lisCodeValueMap,
+					Rules:           rules,
+				},
+			})
+	}
+
+	return taskInvestigations, autoVerificationResults, deltaCheckResults, nil
+}
+
+func (autoVerificationService *AutoVerificationService) getAutoVerificationRuleById(ruleId uint) (
+	commonModels.AutoVerificationRule, *commonStructures.CommonError) {
+
+	rule, cErr := autoVerificationService.AutoVerificationDao.GetAutoVerificationRuleById(ruleId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_AUTO_VERIFICATION_RULE_NOT_FOUND
+		}
+		return rule, cErr
+	}
+
+	return rule, nil
+}
+
+func (autoVerificationService *AutoVerificationService) invalidateAutoVerificationRulesCache(ctx context.Context) {
+	if err := autoVerificationService.Cache.Delete(ctx, commonConstants.CacheKeyAutoVerificationRules); err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL,
+			commonConstants.ERROR_WHILE_INVALIDATING_AUTO_VERIFICATION_KEY, nil, err)
+	}
+}
+
+func validateAutoVerificationRuleRequest(ruleRequest structures.AutoVerificationRuleRequest) *commonStructures.CommonError {
+	if !commonUtils.SliceContainsString(commonConstants.AutoVerificationRuleTypes, ruleRequest.RuleType) {
+		return getBadRequestError(commonConstants.ERROR_INVALID_AUTO_VERIFICATION_RULE_TYPE)
+	}
+
+	parameters := ruleRequest.Parameters
+	switch ruleRequest.RuleType {
+	case commonConstants.AutoVerificationRuleTypeRange:
+		if parameters.Min != nil && parameters.Max != nil && *parameters.Min > *parameters.Max {
+			return getBadRequestError(commonConstants.ERROR_INVALID_AUTO_VERIFICATION_RANGE)
+		}
+	case commonConstants.AutoVerificationRuleTypeDependentSum:
+		if len(commonUtils.CreateUniqueSliceString(parameters.LisCodes)) < 2 || parameters.ExpectedSum == nil ||
+			parameters.Tolerance < 0 {
+			return getBadRequestError(commonConstants.ERROR_INVALID_AUTO_VERIFICATION_DEPENDENT_SUM)
+		}
+	case commonConstants.AutoVerificationRuleTypePatientCondition:
+		if parameters.MinAgeDays == nil && parameters.MaxAgeDays == nil && len(parameters.Genders) == 0 {
+			return getBadRequestError(commonConstants.ERROR_INVALID_AUTO_VERIFICATION_PATIENT_RULE)
+		}
+		if parameters.MinAgeDays != nil && parameters.MaxAgeDays != nil && *parameters.MinAgeDays > *parameters.MaxAgeDays {
+			return getBadRequestError(commonConstants.ERROR_INVALID_AUTO_VERIFICATION_PATIENT_RULE)
+		}
+		for _, gender := range parameters.Genders {
+			if !commonUtils.SliceContainsString(commonConstants.AutoVerificationGenders, gender) {
+				return getBadRequestError(commonConstants.ERROR_INVALID_AUTO_VERIFICATION_PATIENT_RULE)
+			}
+		}
+	}
+
+	return nil
+}
+
+func getBadRequestError(message string) *commonStructures.CommonError {
+	return &commonStructures.CommonError{
+		Message:    message,
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+func getAutoVerificationResult(isAutoApproved bool, approvalSource, failureReason string,
+	evaluations []structures.AutoVerificationRuleEvaluation) structures.AutoVerificationResult {
+	if evaluations == nil {
+		evaluations = []structures.AutoVerificationRuleEvaluation{}
+	}
+	return structures.AutoVerificationResult{
+		IsAutoApproved:            isAutoApproved,
+		ApprovalSource:            approvalSource,
+		AutoApprovalFailureReason: failureReason,
+		Evaluations:               evaluations,
+	}
+}
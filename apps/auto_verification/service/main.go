@@ -0,0 +1,27 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/cache"
+	abnormalityService "github.com/Orange-Health/citadel/apps/abnormality/service"
+	"github.com/Orange-Health/citadel/apps/auto_verification/dao"
+	deltaCheckService "github.com/Orange-Health/citadel/apps/delta_check/service"
+	cdsClient "github.com/Orange-Health/citadel/clients/cds"
+)
+
+type AutoVerificationService struct {
+	AutoVerificationDao dao.DataLayer
+	Cache               cache.CacheLayer
+	AbnormalityService  abnormalityService.AbnormalityServiceInterface
+	DeltaCheckService   deltaCheckService.DeltaCheckServiceInterface
+	CdsClient           cdsClient.CdsClientInterface
+}
+
+func InitializeAutoVerificationService() AutoVerificationServiceInterface {
+	return &AutoVerificationService{
+		AutoVerificationDao: dao.InitializeAutoVerificationDao(),
+		Cache:               cache.InitializeCache(),
+		AbnormalityService:  abnormalityService.InitializeAbnormalityService(),
+		DeltaCheckService:   deltaCheckService.InitializeDeltaCheckService(),
+		CdsClient:           cdsClient.InitializeCdsClient(),
+	}
+}
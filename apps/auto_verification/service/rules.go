@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Orange-Health/citadel/apps/auto_verification/mapper"
+	"github.com/Orange-Health/citadel/apps/auto_verification/structures"
+	deltaCheckMapper "github.com/Orange-Health/citadel/apps/delta_check/mapper"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// defaultAutoVerificationRules reproduce the checks done before the rules were configurable. Each of them
+// applies unless a configured rule of the same type applies to the investigation.
+var defaultAutoVerificationRules = []commonModels.AutoVerificationRule{
+	{Name: commonConstants.AutoVerificationRemarkDefaultRule, RuleType: commonConstants.AutoVerificationRuleTypeDeltaCheck},
+	{Name: commonConstants.AutoVerificationRemarkDefaultRule, RuleType: commonConstants.AutoVerificationRuleTypeInstrumentFlag},
+	{Name: commonConstants.AutoVerificationRemarkDefaultRule, RuleType: commonConstants.AutoVerificationRuleTypeRange},
+}
+
+// getApplicableRules returns the rules to evaluate for the investigation, ordered by rule type.
+func getApplicableRules(request structures.AutoVerificationRequest) []commonModels.AutoVerificationRule {
+	applicableRules, configuredRuleTypes := []commonModels.AutoVerificationRule{}, []string{}
+	for _, rule := range request.Context.Rules {
+		if !isRuleApplicable(rule, request) {
+			continue
+		}
+		applicableRules = append(applicableRules, rule)
+		configuredRuleTypes = append(configuredRuleTypes, rule.RuleType)
+	}
+
+	for _, rule := range defaultAutoVerificationRules {
+		if !commonUtils.SliceContainsString(configuredRuleTypes, rule.RuleType) {
+			applicableRules = append(applicableRules, rule)
+		}
+	}
+
+	sort.SliceStable(applicableRules, func(i, j int) bool {
+		return getRuleTypeOrder(applicableRules[i].RuleType) < getRuleTypeOrder(applicableRules[j].RuleType)
+	})
+	return applicableRules
+}
+
+func isRuleApplicable(rule commonModels.AutoVerificationRule, request structures.AutoVerificationRequest) bool {
+	if !rule.IsActive {
+		return false
+	}
+	if !matchesScope(rule.LabId, request.Context.LabId) ||
+		!matchesScope(rule.MasterTestId, request.Context.MasterTestId) ||
+		!matchesScope(rule.MasterInvestigationId, request.Investigation.InvestigationId) {
+		return false
+	}
+	// A dependent sum rule is only evaluated for the investigations it adds up.
+	if rule.RuleType == commonConstants.AutoVerificationRuleTypeDependentSum {
+		return commonUtils.SliceContainsString(mapper.GetAutoVerificationRuleParameters(rule).LisCodes,
+			request.Investigation.LisCode)
+	}
+	return true
+}
+
+func matchesScope(ruleScope, value uint) bool {
+	return ruleScope == commonConstants.AutoVerificationRuleScopeAny || ruleScope == value
+}
+
+func getRuleTypeOrder(ruleType string) int {
+	for index, autoVerificationRuleType := range commonConstants.AutoVerificationRuleTypes {
+		if autoVerificationRuleType == ruleType {
+			return index
+		}
+	}
+	return len(commonConstants.AutoVerificationRuleTypes)
+}
+
+func (autoVerificationService *AutoVerificationService) evaluateRule(ctx context.Context,
+	rule commonModels.AutoVerificationRule,
+	request structures.AutoVerificationRequest) structures.AutoVerificationRuleEvaluation {
+
+	parameters := mapper.GetAutoVerificationRuleParameters(rule)
+	evaluation := structures.AutoVerificationRuleEvaluation{}
+	switch rule.RuleType {
+	case commonConstants.AutoVerificationRuleTypeRange:
+		evaluation = autoVerificationService.evaluateRangeRule(ctx, parameters, request)
+	case commonConstants.AutoVerificationRuleTypeDeltaCheck:
+		evaluation = evaluateDeltaCheckRule(request)
+	case commonConstants.AutoVerificationRuleTypeCritical:
+		evaluation = evaluateCriticalRule(request)
+	case commonConstants.AutoVerificationRuleTypeInstrumentFlag:
+		evaluation = evaluateInstrumentFlagRule(request)
+	case commonConstants.AutoVerificationRuleTypeDependentSum:
+		evaluation = evaluateDependentSumRule(parameters, request)
+	case commonConstants.AutoVerificationRuleTypePatientCondition:
+		evaluation = evaluatePatientConditionRule(parameters, request)
+	}
+
+	evaluation.RuleId = rule.Id
+	evaluation.RuleName = rule.Name
+	evaluation.RuleType = rule.RuleType
+	return evaluation
+}
+
+// evaluateRangeRule checks the value against the configured range, or against the auto approval range of the
+// investigation if none is configured. A value verified by the IM device is trusted over the auto approval range.
+func (autoVerificationService *AutoVerificationService) evaluateRangeRule(ctx context.Context,
+	parameters structures.AutoVerificationRuleParameters,
+	request structures.AutoVerificationRequest) structures.AutoVerificationRuleEvaluation {
+
+	if parameters.Min == nil && parameters.Max == nil {
+		if request.ImDevice && request.ImDeviceFlag {
+			return getEvaluation(commonConstants.AutoVerificationStatusSkipped, "",
+				commonConstants.AutoVerificationRemarkImDeviceVerified)
+		}
+		if autoVerificationService.AbnormalityService.GetInvestigationAutoApprovalStatus(ctx,
+			request.InvestigationValue, request.Investigation) {
+			return getEvaluation(commonConstants.AutoVerificationStatusPassed, "",
+				commonConstants.AutoVerificationRemarkWithinRange)
+		}
+		return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_REF_RANGE, commonConstants.AutoVerificationRemarkOutsideRange)
+	}
+
+	value, ok := parseValue(request.InvestigationValue)
+	if !ok {
+		return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_REF_RANGE, commonConstants.AutoVerificationRemarkNonNumericValue)
+	}
+	if (parameters.Min != nil && value < *parameters.Min) || (parameters.Max != nil && value > *parameters.Max) {
+		return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_REF_RANGE,
+			fmt.Sprintf(commonConstants.AutoVerificationRemarkOutsideConfiguredRange, value,
+				formatFloatPointer(parameters.Min), formatFloatPointer(parameters.Max)))
+	}
+	return getEvaluation(commonConstants.AutoVerificationStatusPassed, "",
+		commonConstants.AutoVerificationRemarkWithinRange)
+}
+
+func evaluateDeltaCheckRule(request structures.AutoVerificationRequest) structures.AutoVerificationRuleEvaluation {
+	switch request.DeltaCheckResult.Status {
+	case commonConstants.DeltaCheckStatusFailed:
+		return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+			deltaCheckMapper.GetAutoApprovalFailureReason(request.DeltaCheckResult), request.DeltaCheckResult.Remarks)
+	case commonConstants.DeltaCheckStatusPassed:
+		return getEvaluation(commonConstants.AutoVerificationStatusPassed, "", request.DeltaCheckResult.Remarks)
+	}
+	return getEvaluation(commonConstants.AutoVerificationStatusSkipped, "", request.DeltaCheckResult.Remarks)
+}
+
+func evaluateCriticalRule(request structures.AutoVerificationRequest) structures.AutoVerificationRuleEvaluation {
+	if commonUtils.SliceContainsString(commonConstants.OhCriticalityStringSlice, request.Abnormality) {
+		return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_CRITICAL, commonConstants.AutoVerificationRemarkCritical)
+	}
+	return getEvaluation(commonConstants.AutoVerificationStatusPassed, "",
+		commonConstants.AutoVerificationRemarkNotCritical)
+}
+
+func evaluateInstrumentFlagRule(request structures.AutoVerificationRequest) structures.AutoVerificationRuleEvaluation {
+	if request.ImDevice {
+		if request.ImDeviceFlag {
+			return getEvaluation(commonConstants.AutoVerificationStatusPassed, "",
+				commonConstants.AutoVerificationRemarkImDeviceVerified)
+		}
+		return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_IM_DEVICE, commonConstants.AutoVerificationRemarkImDeviceNotVerified)
+	}
+	if request.MethodType == commonConstants.METHOD_TYPE_MANUAL {
+		return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_MANUAL_INPUT, commonConstants.AutoVerificationRemarkManualInput)
+	}
+	return getEvaluation(commonConstants.AutoVerificationStatusPassed, "",
+		commonConstants.AutoVerificationRemarkInstrumentFlagsClear)
+}
+
+// evaluateDependentSumRule checks that the values of the dependent analytes, e.g. the differential counts,
+// add up to the expected sum. A missing dependent value fails the rule as the consistency cannot be verified.
+func evaluateDependentSumRule(parameters structures.AutoVerificationRuleParameters,
+	request structures.AutoVerificationRequest) structures.AutoVerificationRuleEvaluation {
+
+	lisCodes := strings.Join(parameters.LisCodes, ",")
+	if parameters.ExpectedSum == nil {
+		return getEvaluation(commonConstants.AutoVerificationStatusSkipped, "",
+			commonConstants.ERROR_INVALID_AUTO_VERIFICATION_DEPENDENT_SUM)
+	}
+
+	sum := 0.0
+	for _, lisCode := range parameters.LisCodes {
+		value, ok := parseValue(request.Context.LisCodeValueMap[lisCode])
+		if !ok {
+			return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+				commonConstants.AUTO_APPROVAL_FAIL_REASON_DEPENDENT_ANALYTE,
+				fmt.Sprintf(commonConstants.AutoVerificationRemarkDependentValueMissing, lisCode))
+		}
+		sum += value
+	}
+
+	if math.Abs(sum-*parameters.ExpectedSum) > parameters.Tolerance {
+		return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_DEPENDENT_ANALYTE,
+			fmt.Sprintf(commonConstants.AutoVerificationRemarkDependentSumMismatch, sum, lisCodes,
+				*parameters.ExpectedSum, parameters.Tolerance))
+	}
+	return getEvaluation(commonConstants.AutoVerificationStatusPassed, "",
+		fmt.Sprintf(commonConstants.AutoVerificationRemarkDependentSumMatch, sum, lisCodes, *parameters.ExpectedSum))
+}
+
+// evaluatePatientConditionRule restricts auto verification to the patients matching the age and gender conditions.
+func evaluatePatientConditionRule(parameters structures.AutoVerificationRuleParameters,
+	request structures.AutoVerificationRequest) structures.AutoVerificationRuleEvaluation {
+
+	if parameters.MinAgeDays != nil || parameters.MaxAgeDays != nil {
+		ageDays := request.Context.PatientAgeDays
+		if ageDays == nil {
+			return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+				commonConstants.AUTO_APPROVAL_FAIL_REASON_PATIENT_CONDITION,
+				commonConstants.AutoVerificationRemarkPatientAgeUnknown)
+		}
+		if (parameters.MinAgeDays != nil && *ageDays < *parameters.MinAgeDays) ||
+			(parameters.MaxAgeDays != nil && *ageDays > *parameters.MaxAgeDays) {
+			return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+				commonConstants.AUTO_APPROVAL_FAIL_REASON_PATIENT_CONDITION,
+				fmt.Sprintf(commonConstants.AutoVerificationRemarkPatientAgeOutside, *ageDays,
+					formatUintPointer(parameters.MinAgeDays), formatUintPointer(parameters.MaxAgeDays)))
+		}
+	}
+
+	if len(parameters.Genders) > 0 && !commonUtils.SliceContainsString(parameters.Genders, request.Context.PatientGender) {
+		return getEvaluation(commonConstants.AutoVerificationStatusFailed,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_PATIENT_CONDITION,
+			fmt.Sprintf(commonConstants.AutoVerificationRemarkPatientGenderOutside, request.Context.PatientGender,
+				strings.Join(parameters.Genders, ",")))
+	}
+
+	return getEvaluation(commonConstants.AutoVerificationStatusPassed, "",
+		commonConstants.AutoVerificationRemarkPatientMatches)
+}
+
+func getEvaluation(status, failureReason, remarks string) structures.AutoVerificationRuleEvaluation {
+	return structures.AutoVerificationRuleEvaluation{
+		Status:        status,
+		FailureReason: failureReason,
+		Remarks:       remarks,
+	}
+}
+
+func parseValue(value string) (float64, bool) {
+	parsedValue, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(parsedValue) || math.IsInf(parsedValue, 0) {
+		return 0, false
+	}
+	return parsedValue, true
+}
+
+func formatFloatPointer(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+func formatUintPointer(value *uint) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*value), 10)
+}
@@ -0,0 +1,149 @@
+package structures
+
+import (
+	"time"
+
+	deltaCheckStructures "github.com/Orange-Health/citadel/apps/delta_check/structures"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// @swagger:model AutoVerificationRule
+type AutoVerificationRule struct {
+	// The auto verification rule ID.
+	// example: 1
+	Id uint `json:"id"`
+	// example: "CBC differential sums to 100"
+	Name string `json:"name"`
+	// One of range, delta_check, critical, instrument_flag, dependent_sum and patient_condition.
+	// example: "dependent_sum"
+	RuleType string `json:"rule_type"`
+	// The lab the rule applies to, 0 for every lab.
+	// example: 0
+	LabId uint `json:"lab_id"`
+	// The master test the rule applies to, 0 for every test.
+	// example: 0
+	MasterTestId uint `json:"master_test_id"`
+	// The master investigation the rule applies to, 0 for every investigation.
+	// example: 0
+	MasterInvestigationId uint `json:"master_investigation_id"`
+	// The parameters of the rule type.
+	Parameters AutoVerificationRuleParameters `json:"parameters"`
+	// If the rule is active.
+	// example: true
+	IsActive bool `json:"is_active"`
+}
+
+// AutoVerificationRuleParameters holds the parameters of every rule type, only those of the rule type are used.
+type AutoVerificationRuleParameters struct {
+	// range: the allowed range of the value. The auto approval range of the investigation is used if both are empty.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// dependent_sum: the LIS codes whose values must add up to the expected sum within the tolerance.
+	LisCodes    []string `json:"lis_codes,omitempty"`
+	ExpectedSum *float64 `json:"expected_sum,omitempty"`
+	Tolerance   float64  `json:"tolerance,omitempty"`
+	// patient_condition: the patients the investigation may be auto verified for.
+	MinAgeDays *uint    `json:"min_age_days,omitempty"`
+	MaxAgeDays *uint    `json:"max_age_days,omitempty"`
+	Genders    []string `json:"genders,omitempty"`
+}
+
+type AutoVerificationRuleRequest struct {
+	Name                  string                         `json:"name" binding:"required"`
+	RuleType              string                         `json:"rule_type" binding:"required"`
+	LabId                 uint                           `json:"lab_id"`
+	MasterTestId          uint                           `json:"master_test_id"`
+	MasterInvestigationId uint                           `json:"master_investigation_id"`
+	Parameters            AutoVerificationRuleParameters `json:"parameters"`
+	IsActive              *bool                          `json:"is_active"`
+}
+
+type AutoVerificationRulesFilter struct {
+	LabId                 uint
+	MasterTestId          uint
+	MasterInvestigationId uint
+}
+
+// AutoVerificationContext carries what is shared by the investigations of an order.
+type AutoVerificationContext struct {
+	LabId          uint
+	MasterTestId   uint
+	PatientAgeDays *uint
+	PatientGender  string
+	// LisCodeValueMap holds the values of every investigation of the order, for the dependent analyte rules.
+	LisCodeValueMap map[string]string
+	Rules           []commonModels.AutoVerificationRule
+}
+
+type AutoVerificationRequest struct {
+	InvestigationValue string
+	MethodType         string
+	ImDevice           bool
+	ImDeviceFlag       bool
+	IsQcFailed         bool
+	Abnormality        string
+	Investigation      commonStructures.Investigation
+	DeltaCheckResult   deltaCheckStructures.DeltaCheckResult
+	Context            AutoVerificationContext
+}
+
+type AutoVerificationResult struct {
+	IsAutoApproved            bool                             `json:"is_auto_approved"`
+	ApprovalSource            string                           `json:"approval_source"`
+	AutoApprovalFailureReason string                           `json:"auto_approval_failure_reason"`
+	Evaluations               []AutoVerificationRuleEvaluation `json:"evaluations"`
+}
+
+type AutoVerificationRuleEvaluation struct {
+	RuleId        uint   `json:"rule_id,omitempty"`
+	RuleName      string `json:"rule_name"`
+	RuleType      string `json:"rule_type"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+	Remarks       string `json:"remarks,omitempty"`
+}
+
+type DryRunRequest struct {
+	TaskId uint `json:"task_id" binding:"required"`
+	// Rules to evaluate instead of the configured rules, to try out a rule set before saving it.
+	Rules []AutoVerificationRuleRequest `json:"rules"`
+}
+
+type DryRunInvestigationResult struct {
+	InvestigationResultId            uint                   `json:"investigation_result_id"`
+	MasterInvestigationId            uint                   `json:"master_investigation_id"`
+	InvestigationName                string                 `json:"investigation_name"`
+	LisCode                          string                 `json:"lis_code"`
+	InvestigationValue               string                 `json:"investigation_value"`
+	CurrentIsAutoApproved            bool                   `json:"current_is_auto_approved"`
+	CurrentAutoApprovalFailureReason string                 `json:"current_auto_approval_failure_reason"`
+	Result                           AutoVerificationResult `json:"result"`
+}
+
+type TaskInvestigationDetails struct {
+	Id                        uint       `json:"id"`
+	MasterInvestigationId     uint       `json:"master_investigation_id"`
+	InvestigationName         string     `json:"investigation_name"`
+	InvestigationValue        string     `json:"investigation_value"`
+	LisCode                   string     `json:"lis_code"`
+	MethodType                string     `json:"method_type"`
+	Abnormality               string     `json:"abnormality"`
+	EnteredAt                 *time.Time `json:"entered_at"`
+	IsAutoApproved            bool       `json:"is_auto_approved"`
+	ApprovalSource            string     `json:"approval_source"`
+	AutoApprovalFailureReason string     `json:"auto_approval_failure_reason"`
+	MasterTestId              uint       `json:"master_test_id"`
+	QcStatus                  string     `json:"qc_status"`
+	WestgardStatus            string     `json:"westgard_status"`
+}
+
+type TaskPatientDetails struct {
+	LabId              uint       `json:"lab_id"`
+	CityCode           string     `json:"city_code"`
+	PatientDob         *time.Time `json:"patient_dob"`
+	PatientExpectedDob *time.Time `json:"patient_expected_dob"`
+	PatientGender      string     `json:"patient_gender"`
+	PatientIsPregnant  bool       `json:"patient_is_pregnant"`
+	PatientTrimester   uint       `json:"patient_trimester"`
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/calculations/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Validate Formula
+// @Description	Validate the syntax, variables, units and dependency cycles of a calculation formula before it is saved in CDS
+// @Tags			calculations
+// @Accept			json
+// @Produce		json
+// @Param			request	body		structures.ValidateFormulaRequest	true	"Validate Formula Request"
+// @Success		200		{object}	structures.ValidateFormulaResponse	"Validation Result"
+// @Failure		400,500	{object}	structures.CommonAPIResponse		"Common API Response"
+// @Router			/api/v1/calculations/validate [post]
+func (calculationsController *Calculations) ValidateFormula(c *gin.Context) {
+	validateFormulaRequest := structures.ValidateFormulaRequest{}
+	if err := c.ShouldBindJSON(&validateFormulaRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if validateFormulaRequest.Formula == "" {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_NO_FORMULA_FOUND)
+		return
+	}
+	if validateFormulaRequest.MasterInvestigationId != 0 &&
+		(validateFormulaRequest.LabId == 0 || validateFormulaRequest.CityCode == "") {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_FORMULA_DEPENDENCY_LOOKUP)
+		return
+	}
+
+	validateFormulaResponse, cErr := calculationsController.CalculationsService.ValidateFormula(c.Request.Context(),
+		validateFormulaRequest)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, validateFormulaResponse)
+}
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	calculationsService "github.com/Orange-Health/citadel/apps/calculations/service"
+)
+
+type Calculations struct {
+	CalculationsService calculationsService.CalculationsServiceInterface
+}
+
+func InitCalculationsController() *Calculations {
+	return &Calculations{
+		CalculationsService: calculationsService.InitializeCalculationsService(),
+	}
+}
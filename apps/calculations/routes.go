@@ -0,0 +1,13 @@
+package calculations
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/calculations/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	calculationsController := controller.InitCalculationsController()
+
+	router.POST("/validate", calculationsController.ValidateFormula)
+}
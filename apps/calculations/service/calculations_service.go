@@ -7,10 +7,8 @@ import (
 	"math"
 	"net/http"
 	"strconv"
-	"strings"
-
-	"github.com/Knetic/govaluate"
 
+	"github.com/Orange-Health/citadel/apps/calculations/structures"
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
 	commonStructs "github.com/Orange-Health/citadel/common/structures"
 	commonUtils "github.com/Orange-Health/citadel/common/utils"
@@ -19,14 +17,18 @@ import (
 
 type CalculationsServiceInterface interface {
 	GetModifiedValue(ctx context.Context, formula string, decimals uint, dependentMasterInvestigationIds []uint,
-		masterInvestigationIdToInvestigationResultMap map[uint]commonModels.InvestigationResult) (
-		string, *commonStructs.CommonError)
+		masterInvestigationIdToInvestigationResultMap map[uint]commonModels.InvestigationResult,
+		formulaVariables structures.FormulaVariables) (string, *commonStructs.CommonError)
+	ValidateFormula(ctx context.Context, validateFormulaRequest structures.ValidateFormulaRequest) (
+		structures.ValidateFormulaResponse, *commonStructs.CommonError)
 }
 
+// GetModifiedValue calculates the value of a calculated investigation. An empty value is returned without an
+// error when the formula declines to calculate it with na().
 func (calculationsService *CalculationsService) GetModifiedValue(ctx context.Context, formula string, decimals uint,
 	dependentMasterInvestigationIds []uint,
-	masterInvestigationIdToInvestigationResultMap map[uint]commonModels.InvestigationResult) (
-	string, *commonStructs.CommonError) {
+	masterInvestigationIdToInvestigationResultMap map[uint]commonModels.InvestigationResult,
+	formulaVariables structures.FormulaVariables) (string, *commonStructs.CommonError) {
 
 	if formula == "" {
 		return "", &commonStructs.CommonError{
@@ -36,19 +38,15 @@ func (calculationsService *CalculationsService) GetModifiedValue(ctx context.Con
 	}
 
 	for _, masterInvestigationId := range dependentMasterInvestigationIds {
-		investigationResult, ok := masterInvestigationIdToInvestigationResultMap[masterInvestigationId]
-		if !ok {
+		if _, ok := masterInvestigationIdToInvestigationResultMap[masterInvestigationId]; !ok {
 			return "", &commonStructs.CommonError{
 				StatusCode: http.StatusInternalServerError,
 				Message:    commonConstants.ERROR_NO_INVESTIGATION_RESULTS_FOUND,
 			}
 		}
-
-		formula = strings.ReplaceAll(formula, fmt.Sprintf("{{%d}}", masterInvestigationId),
-			investigationResult.InvestigationValue)
 	}
 
-	expression, err := govaluate.NewEvaluableExpression(formula)
+	compiledFormula, err := compileFormula(formula)
 	if err != nil {
 		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), map[string]interface{}{
 			"formula": formula,
@@ -59,7 +57,13 @@ func (calculationsService *CalculationsService) GetModifiedValue(ctx context.Con
 		}
 	}
 
-	result, err := expression.Evaluate(map[string]interface{}{})
+	parameters, cErr := getFormulaParameters(compiledFormula, masterInvestigationIdToInvestigationResultMap,
+		formulaVariables)
+	if cErr != nil {
+		return "", cErr
+	}
+
+	result, err := compiledFormula.Expression.Evaluate(parameters)
 	if err != nil {
 		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), map[string]interface{}{
 			"formula": formula,
@@ -70,11 +74,12 @@ func (calculationsService *CalculationsService) GetModifiedValue(ctx context.Con
 		}
 	}
 
-	resultString := ""
+	if _, ok := result.(formulaNotApplicable); ok {
+		return "", nil
+	}
+
 	resultFloat, ok := result.(float64)
-	if ok {
-		resultString = roundFloat(resultFloat, decimals)
-	} else {
+	if !ok || math.IsNaN(resultFloat) || math.IsInf(resultFloat, 0) {
 		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), map[string]interface{}{
 			"formula": formula,
 		}, errors.New(commonConstants.ERROR_INVALID_RESULT))
@@ -84,7 +89,96 @@ func (calculationsService *CalculationsService) GetModifiedValue(ctx context.Con
 		}
 	}
 
-	return resultString, nil
+	return roundFloat(resultFloat, decimals), nil
+}
+
+// ValidateFormula checks the syntax, variables and units of a formula before it is saved in CDS. Dependency
+// cycles are looked for through the investigations calculated from the investigation of the formula.
+func (calculationsService *CalculationsService) ValidateFormula(ctx context.Context,
+	validateFormulaRequest structures.ValidateFormulaRequest) (
+	structures.ValidateFormulaResponse, *commonStructs.CommonError) {
+
+	validateFormulaResponse := structures.ValidateFormulaResponse{
+		DependentInvestigationIds: []uint{},
+		Variables:                 []string{},
+		Errors:                    []string{},
+	}
+
+	compiledFormula, err := compileFormula(validateFormulaRequest.Formula)
+	if err != nil {
+		validateFormulaResponse.Errors = append(validateFormulaResponse.Errors,
+			fmt.Sprintf("%s: %s", commonConstants.ERROR_INVALID_FORMULA_SYNTAX, err.Error()))
+		return validateFormulaResponse, nil
+	}
+
+	for _, placeholder := range compiledFormula.Placeholders {
+		validateFormulaResponse.DependentInvestigationIds = append(validateFormulaResponse.DependentInvestigationIds,
+			placeholder.MasterInvestigationId)
+	}
+	validateFormulaResponse.DependentInvestigationIds =
+		commonUtils.CreateUniqueSliceUint(validateFormulaResponse.DependentInvestigationIds)
+	validateFormulaResponse.Variables = compiledFormula.Variables
+
+	if _, err := compiledFormula.Expression.Evaluate(getSampleFormulaParameters(compiledFormula)); err != nil {
+		validateFormulaResponse.Errors = append(validateFormulaResponse.Errors, err.Error())
+	}
+
+	if validateFormulaRequest.MasterInvestigationId != 0 {
+		if commonUtils.SliceContainsUint(validateFormulaResponse.DependentInvestigationIds,
+			validateFormulaRequest.MasterInvestigationId) {
+			validateFormulaResponse.Errors = append(validateFormulaResponse.Errors,
+				commonConstants.ERROR_FORMULA_SELF_DEPENDENCY)
+		} else {
+			cycleInvestigationId, cErr := calculationsService.findFormulaDependencyCycle(ctx, validateFormulaRequest,
+				validateFormulaResponse.DependentInvestigationIds)
+			if cErr != nil {
+				return validateFormulaResponse, cErr
+			}
+			if cycleInvestigationId != 0 {
+				validateFormulaResponse.Errors = append(validateFormulaResponse.Errors,
+					fmt.Sprintf(commonConstants.ERROR_FORMULA_DEPENDENCY_CYCLE, cycleInvestigationId))
+			}
+		}
+	}
+
+	validateFormulaResponse.IsValid = len(validateFormulaResponse.Errors) == 0
+	return validateFormulaResponse, nil
+}
+
+// findFormulaDependencyCycle walks the investigations calculated, directly or not, from the investigation of the
+// formula. Any of them being a dependency of the formula closes a cycle, its id is returned.
+func (calculationsService *CalculationsService) findFormulaDependencyCycle(ctx context.Context,
+	validateFormulaRequest structures.ValidateFormulaRequest, dependentInvestigationIds []uint) (
+	uint, *commonStructs.CommonError) {
+
+	if len(dependentInvestigationIds) == 0 {
+		return 0, nil
+	}
+
+	visited := map[uint]bool{validateFormulaRequest.MasterInvestigationId: true}
+	queue := []uint{validateFormulaRequest.MasterInvestigationId}
+	for index := 0; index < len(queue) && index < commonConstants.FormulaMaxDependencyLookups; index++ {
+		dependentInvestigations, err := calculationsService.CdsClient.GetDependentInvestigations(ctx, queue[index],
+			validateFormulaRequest.LabId, validateFormulaRequest.CityCode, "", "")
+		if err != nil {
+			return 0, &commonStructs.CommonError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    commonConstants.ERROR_IN_GETTING_FORMULA_DEPENDENCIES,
+			}
+		}
+
+		for _, derivedInvestigation := range dependentInvestigations.DerivedInvestigations {
+			if commonUtils.SliceContainsUint(dependentInvestigationIds, derivedInvestigation.InvestigationId) {
+				return derivedInvestigation.InvestigationId, nil
+			}
+			if !visited[derivedInvestigation.InvestigationId] {
+				visited[derivedInvestigation.InvestigationId] = true
+				queue = append(queue, derivedInvestigation.InvestigationId)
+			}
+		}
+	}
+
+	return 0, nil
 }
 
 func roundFloat(val float64, precision uint) string {
@@ -0,0 +1,302 @@
+package calculationsService
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Knetic/govaluate"
+
+	"github.com/Orange-Health/citadel/apps/calculations/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructs "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// formulaNotApplicable is returned by na(), it lets a formula decline to calculate a result, e.g. LDL by
+// Friedewald when triglycerides are too high.
+type formulaNotApplicable struct{}
+
+type formulaPlaceholder struct {
+	MasterInvestigationId uint
+	Unit                  string
+	ParameterName         string
+}
+
+type compiledFormula struct {
+	Expression   *govaluate.EvaluableExpression
+	Placeholders []formulaPlaceholder
+	Variables    []string
+}
+
+// compileFormula replaces the {{id}} and {{id|unit}} placeholders of the formula with parameters and parses it
+// with the formula functions. Variables other than the placeholders and the patient variables are rejected.
+func compileFormula(formula string) (compiledFormula, error) {
+	placeholders, parameterNames := []formulaPlaceholder{}, map[string]string{}
+	expression := commonUtils.FormulaPlaceholderRegex.ReplaceAllStringFunc(formula, func(match string) string {
+		submatches := commonUtils.FormulaPlaceholderRegex.FindStringSubmatch(match)
+		key := submatches[1] + "|" + normalizeFormulaUnit(submatches[2])
+		if parameterName, ok := parameterNames[key]; ok {
+			return parameterName
+		}
+
+		parameterName := fmt.Sprintf("%s%d", commonConstants.FormulaDependentParameterPrefix, len(placeholders))
+		parameterNames[key] = parameterName
+		placeholders = append(placeholders, formulaPlaceholder{
+			MasterInvestigationId: commonUtils.ConvertStringToUint(submatches[1]),
+			Unit:                  normalizeFormulaUnit(submatches[2]),
+			ParameterName:         parameterName,
+		})
+		return parameterName
+	})
+
+	evaluableExpression, err := govaluate.NewEvaluableExpressionWithFunctions(expression, getFormulaFunctions())
+	if err != nil {
+		return compiledFormula{}, err
+	}
+
+	variables := []string{}
+	for _, variable := range evaluableExpression.Vars() {
+		if strings.HasPrefix(variable, commonConstants.FormulaDependentParameterPrefix) {
+			continue
+		}
+		if !commonUtils.SliceContainsString(commonConstants.FormulaVariables, variable) {
+			return compiledFormula{}, fmt.Errorf(commonConstants.ERROR_UNKNOWN_FORMULA_VARIABLE, variable)
+		}
+		variables = append(variables, variable)
+	}
+
+	for _, placeholder := range placeholders {
+		if placeholder.Unit != "" && getFormulaUnitDimension(placeholder.Unit) == "" {
+			return compiledFormula{}, fmt.Errorf(commonConstants.ERROR_UNKNOWN_FORMULA_UNIT, placeholder.Unit)
+		}
+	}
+
+	return compiledFormula{
+		Expression:   evaluableExpression,
+		Placeholders: placeholders,
+		Variables:    commonUtils.CreateUniqueSliceString(variables),
+	}, nil
+}
+
+func getFormulaParameters(formula compiledFormula,
+	masterInvestigationIdToInvestigationResultMap map[uint]commonModels.InvestigationResult,
+	formulaVariables structures.FormulaVariables) (map[string]interface{}, *commonStructs.CommonError) {
+
+	parameters := map[string]interface{}{}
+	for _, placeholder := range formula.Placeholders {
+		investigationResult, ok := masterInvestigationIdToInvestigationResultMap[placeholder.MasterInvestigationId]
+		if !ok {
+			return nil, getCalculationError(fmt.Sprintf(commonConstants.ERROR_MISSING_DEPENDENT_VALUE,
+				placeholder.MasterInvestigationId))
+		}
+		value, cErr := getDependentValue(placeholder, investigationResult)
+		if cErr != nil {
+			return nil, cErr
+		}
+		parameters[placeholder.ParameterName] = value
+	}
+
+	patientVariables := getPatientVariables(formulaVariables)
+	for _, variable := range formula.Variables {
+		value, ok := patientVariables[variable]
+		if !ok {
+			return nil, getCalculationError(fmt.Sprintf(commonConstants.ERROR_MISSING_FORMULA_VARIABLE, variable))
+		}
+		parameters[variable] = value
+	}
+
+	return parameters, nil
+}
+
+func getDependentValue(placeholder formulaPlaceholder, investigationResult commonModels.InvestigationResult) (
+	float64, *commonStructs.CommonError) {
+
+	investigationValue := strings.TrimSpace(investigationResult.InvestigationValue)
+	if investigationValue == "" {
+		return 0, getCalculationError(fmt.Sprintf(commonConstants.ERROR_MISSING_DEPENDENT_VALUE,
+			placeholder.MasterInvestigationId))
+	}
+	if submatches := commonUtils.QualifiedNumericValueRegex.FindStringSubmatch(investigationValue); submatches != nil {
+		return 0, getCalculationError(fmt.Sprintf(commonConstants.ERROR_QUALIFIED_DEPENDENT_VALUE,
+			placeholder.MasterInvestigationId, submatches[1]))
+	}
+
+	value, err := strconv.ParseFloat(investigationValue, 64)
+	if err != nil {
+		return 0, getCalculationError(fmt.Sprintf(commonConstants.ERROR_NON_NUMERIC_DEPENDENT_VALUE,
+			placeholder.MasterInvestigationId))
+	}
+
+	investigationUnit := normalizeFormulaUnit(investigationResult.Uom)
+	if placeholder.Unit == "" || placeholder.Unit == investigationUnit {
+		return value, nil
+	}
+	dimension := getFormulaUnitDimension(placeholder.Unit)
+	if dimension == "" || dimension != getFormulaUnitDimension(investigationUnit) {
+		return 0, getCalculationError(fmt.Sprintf(commonConstants.ERROR_INCOMPATIBLE_FORMULA_UNIT,
+			investigationResult.Uom, placeholder.MasterInvestigationId, placeholder.Unit))
+	}
+
+	units := commonConstants.FormulaConcentrationUnits[dimension]
+	return value * units[investigationUnit] / units[placeholder.Unit], nil
+}
+
+func getPatientVariables(formulaVariables structures.FormulaVariables) map[string]interface{} {
+	patientVariables := map[string]interface{}{}
+	if formulaVariables.PatientDob != nil {
+		patientVariables[commonConstants.FormulaVariableAgeYears] =
+			float64(commonUtils.GetAgeYearsFromDob(*formulaVariables.PatientDob))
+		patientVariables[commonConstants.FormulaVariableAgeDays] =
+			float64(commonUtils.GetAgeDaysFromDob(*formulaVariables.PatientDob))
+	}
+	if formulaVariables.PatientGender != "" {
+		patientVariables[commonConstants.FormulaVariableGender] =
+			commonUtils.GetGenderConstant(formulaVariables.PatientGender)
+	}
+	if formulaVariables.WeightKg != nil {
+		patientVariables[commonConstants.FormulaVariableWeightKg] = *formulaVariables.WeightKg
+	}
+	if formulaVariables.HeightCm != nil {
+		patientVariables[commonConstants.FormulaVariableHeightCm] = *formulaVariables.HeightCm
+	}
+	return patientVariables
+}
+
+// getSampleFormulaParameters gives every parameter of the formula a plausible value, so that the formula can be
+// evaluated once while validating it.
+func getSampleFormulaParameters(formula compiledFormula) map[string]interface{} {
+	parameters := map[string]interface{}{}
+	for _, placeholder := range formula.Placeholders {
+		parameters[placeholder.ParameterName] = float64(1)
+	}
+	for _, variable := range formula.Variables {
+		parameters[variable] = float64(1)
+	}
+	if commonUtils.SliceContainsString(formula.Variables, commonConstants.FormulaVariableGender) {
+		parameters[commonConstants.FormulaVariableGender] = commonUtils.GetGenderConstant("")
+	}
+	return parameters
+}
+
+func normalizeFormulaUnit(unit string) string {
+	unit = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(unit), " ", ""))
+	unit = strings.ReplaceAll(unit, "µ", "u")
+	return strings.ReplaceAll(unit, "μ", "u")
+}
+
+func getFormulaUnitDimension(unit string) string {
+	for dimension, units := range commonConstants.FormulaConcentrationUnits {
+		if _, ok := units[unit]; ok {
+			return dimension
+		}
+	}
+	return ""
+}
+
+func getFormulaFunctions() map[string]govaluate.ExpressionFunction {
+	return map[string]govaluate.ExpressionFunction{
+		commonConstants.FormulaFunctionLog:   getUnaryFormulaFunction(commonConstants.FormulaFunctionLog, math.Log),
+		commonConstants.FormulaFunctionLog10: getUnaryFormulaFunction(commonConstants.FormulaFunctionLog10, math.Log10),
+		commonConstants.FormulaFunctionExp:   getUnaryFormulaFunction(commonConstants.FormulaFunctionExp, math.Exp),
+		commonConstants.FormulaFunctionSqrt:  getUnaryFormulaFunction(commonConstants.FormulaFunctionSqrt, math.Sqrt),
+		commonConstants.FormulaFunctionAbs:   getUnaryFormulaFunction(commonConstants.FormulaFunctionAbs, math.Abs),
+		commonConstants.FormulaFunctionPow: func(args ...interface{}) (interface{}, error) {
+			values, err := getFormulaFunctionArguments(commonConstants.FormulaFunctionPow, "2", args, 2, 2)
+			if err != nil {
+				return nil, err
+			}
+			return math.Pow(values[0], values[1]), nil
+		},
+		commonConstants.FormulaFunctionMin: func(args ...interface{}) (interface{}, error) {
+			values, err := getFormulaFunctionArguments(commonConstants.FormulaFunctionMin, "at least 1", args, 1, 0)
+			if err != nil {
+				return nil, err
+			}
+			result := values[0]
+			for _, value := range values[1:] {
+				result = math.Min(result, value)
+			}
+			return result, nil
+		},
+		commonConstants.FormulaFunctionMax: func(args ...interface{}) (interface{}, error) {
+			values, err := getFormulaFunctionArguments(commonConstants.FormulaFunctionMax, "at least 1", args, 1, 0)
+			if err != nil {
+				return nil, err
+			}
+			result := values[0]
+			for _, value := range values[1:] {
+				result = math.Max(result, value)
+			}
+			return result, nil
+		},
+		commonConstants.FormulaFunctionRound: func(args ...interface{}) (interface{}, error) {
+			values, err := getFormulaFunctionArguments(commonConstants.FormulaFunctionRound, "2", args, 2, 2)
+			if err != nil {
+				return nil, err
+			}
+			ratio := math.Pow(10, values[1])
+			return math.Round(values[0]*ratio) / ratio, nil
+		},
+		commonConstants.FormulaFunctionIf: func(args ...interface{}) (interface{}, error) {
+			if len(args) != 3 {
+				return nil, fmt.Errorf(commonConstants.ERROR_FORMULA_FUNCTION_ARGUMENTS,
+					commonConstants.FormulaFunctionIf, "3")
+			}
+			condition, ok := args[0].(bool)
+			if !ok {
+				return nil, errors.New(commonConstants.ERROR_FORMULA_NON_BOOLEAN_CONDITION)
+			}
+			if condition {
+				return args[1], nil
+			}
+			return args[2], nil
+		},
+		commonConstants.FormulaFunctionNa: func(args ...interface{}) (interface{}, error) {
+			if len(args) != 0 {
+				return nil, fmt.Errorf(commonConstants.ERROR_FORMULA_FUNCTION_ARGUMENTS,
+					commonConstants.FormulaFunctionNa, "0")
+			}
+			return formulaNotApplicable{}, nil
+		},
+	}
+}
+
+func getUnaryFormulaFunction(name string, function func(float64) float64) govaluate.ExpressionFunction {
+	return func(args ...interface{}) (interface{}, error) {
+		values, err := getFormulaFunctionArguments(name, "1", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return function(values[0]), nil
+	}
+}
+
+// getFormulaFunctionArguments checks the argument count, a maxArguments of 0 leaves it unbounded.
+func getFormulaFunctionArguments(name, expectedArguments string, args []interface{}, minArguments,
+	maxArguments int) ([]float64, error) {
+
+	if len(args) < minArguments || (maxArguments != 0 && len(args) > maxArguments) {
+		return nil, fmt.Errorf(commonConstants.ERROR_FORMULA_FUNCTION_ARGUMENTS, name, expectedArguments)
+	}
+	values := []float64{}
+	for _, arg := range args {
+		value, ok := arg.(float64)
+		if !ok {
+			return nil, fmt.Errorf(commonConstants.ERROR_FORMULA_NON_NUMERIC_ARGUMENT, name)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func getCalculationError(message string) *commonStructs.CommonError {
+	return &commonStructs.CommonError{
+		StatusCode: http.StatusBadRequest,
+		Message:    message,
+	}
+}
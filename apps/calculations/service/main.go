@@ -3,16 +3,19 @@ package calculationsService
 import (
 	"github.com/Orange-Health/citadel/adapters/cache"
 	"github.com/Orange-Health/citadel/adapters/sentry"
+	cdsClient "github.com/Orange-Health/citadel/clients/cds"
 )
 
 type CalculationsService struct {
-	Cache  cache.CacheLayer
-	Sentry sentry.SentryLayer
+	Cache     cache.CacheLayer
+	Sentry    sentry.SentryLayer
+	CdsClient cdsClient.CdsClientInterface
 }
 
 func InitializeCalculationsService() CalculationsServiceInterface {
 	return &CalculationsService{
-		Cache:  cache.InitializeCache(),
-		Sentry: sentry.InitializeSentry(),
+		Cache:     cache.InitializeCache(),
+		Sentry:    sentry.InitializeSentry(),
+		CdsClient: cdsClient.InitializeCdsClient(),
 	}
 }
@@ -0,0 +1,25 @@
+package structures
+
+import "time"
+
+// FormulaVariables are the patient details a formula can use, unset details cannot be used.
+type FormulaVariables struct {
+	PatientDob    *time.Time
+	PatientGender string
+	WeightKg      *float64
+	HeightCm      *float64
+}
+
+type ValidateFormulaRequest struct {
+	MasterInvestigationId uint   `json:"master_investigation_id"`
+	Formula               string `json:"formula"`
+	LabId                 uint   `json:"lab_id"`
+	CityCode              string `json:"city_code"`
+}
+
+type ValidateFormulaResponse struct {
+	IsValid                   bool     `json:"is_valid"`
+	DependentInvestigationIds []uint   `json:"dependent_investigation_ids"`
+	Variables                 []string `json:"variables"`
+	Errors                    []string `json:"errors"`
+}
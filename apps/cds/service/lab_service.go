@@ -35,7 +35,9 @@ func (cdsService *CdsService) GetLabIdLabMap(ctx context.Context) map[uint]commo
 	for _, lab := range labs {
 		labIdLabMap[lab.Id] = lab
 	}
-	_ = cdsService.Cache.Set(ctx, cacheKey, labIdLabMap, commonConstants.CacheExpiry15MinutesInt)
+	if len(labIdLabMap) > 0 {
+		_ = cdsService.Cache.Set(ctx, cacheKey, labIdLabMap, commonConstants.CacheExpiry15MinutesInt)
+	}
 	return labIdLabMap
 }
 
@@ -44,19 +46,19 @@ func (cdsService *CdsService) GetLabById(ctx context.Context, labId uint) (commo
 	lab := commonStructures.Lab{}
 	cacheKey := fmt.Sprintf(commonConstants.CacheKeyLabsId, labId)
 	err := cdsService.Cache.Get(ctx, cacheKey, &lab)
-	if err == nil {
+	if err == nil && lab.Id != 0 {
 		return lab, nil
 	}
 
 	labIdLabMap := cdsService.GetLabIdLabMap(ctx)
 	lab = labIdLabMap[labId]
-	_ = cdsService.Cache.Set(ctx, cacheKey, lab, commonConstants.CacheExpiry15MinutesInt)
 	if lab.Id == 0 {
 		return lab, &commonStructures.CommonError{
 			Message:    commonConstants.ERROR_LAB_NOT_FOUND,
 			StatusCode: http.StatusInternalServerError,
 		}
 	}
+	_ = cdsService.Cache.Set(ctx, cacheKey, lab, commonConstants.CacheExpiry15MinutesInt)
 	return lab, nil
 }
 
@@ -1,24 +1,29 @@
 package service
 
 import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
 	"github.com/Orange-Health/citadel/adapters/sentry"
 	externalInvestigationResultsService "github.com/Orange-Health/citadel/apps/external_investigation_results/service"
+	outboxService "github.com/Orange-Health/citadel/apps/outbox/service"
 	pubsubService "github.com/Orange-Health/citadel/apps/pubsub/service"
-	snsClient "github.com/Orange-Health/citadel/clients/sns"
 )
 
 type ContactService struct {
+	Db                                 *gorm.DB
 	Sentry                             sentry.SentryLayer
-	SnsClient                          snsClient.SnsClientInterface
 	PubsubService                      pubsubService.PubsubInterface
+	OutboxService                      outboxService.OutboxServiceInterface
 	ExternalInvestigationResultService externalInvestigationResultsService.ExternalInvestigationResultServiceInterface
 }
 
 func InitializeContactService() ContactServiceInterface {
 	return &ContactService{
+		Db:                                 psql.GetDbInstance(),
 		Sentry:                             sentry.InitializeSentry(),
-		SnsClient:                          snsClient.InitializeSnsClient(),
 		PubsubService:                      pubsubService.InitializePubsubService(),
+		OutboxService:                      outboxService.InitializeOutboxService(),
 		ExternalInvestigationResultService: externalInvestigationResultsService.InitializeExternalInvestigationResultService(),
 	}
 }
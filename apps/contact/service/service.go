@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"gorm.io/gorm"
+
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
 	commonUtils "github.com/Orange-Health/citadel/common/utils"
@@ -16,13 +18,17 @@ type ContactServiceInterface interface {
 	) *commonStructures.CommonError
 }
 
-func (s *ContactService) publishMergeConfirmEvent(ctx context.Context, eventPayload commonStructures.MergeContactEvent) *commonStructures.CommonError {
+func (s *ContactService) writeMergeConfirmEventWithTx(ctx context.Context, tx *gorm.DB,
+	eventPayload commonStructures.MergeContactEvent) *commonStructures.CommonError {
 	messageBody, messageAttributes := s.PubsubService.GetContactMergeConfirmEvent(ctx, eventPayload)
-	return s.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", commonConstants.ContactMergeConfirmTopicArn, "")
+	return s.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes, "",
+		commonConstants.ContactMergeConfirmTopicArn, "")
 }
 
-func (s *ContactService) mergeExternalInvestigations(masterContactId, mergeContactId uint) (bool, *commonStructures.CommonError) {
-	err := s.ExternalInvestigationResultService.UpdateContact(
+func (s *ContactService) mergeExternalInvestigationsWithTx(tx *gorm.DB, masterContactId,
+	mergeContactId uint) (bool, *commonStructures.CommonError) {
+	err := s.ExternalInvestigationResultService.UpdateContactWithTx(
+		tx,
 		mergeContactId,
 		masterContactId,
 	)
@@ -32,17 +38,30 @@ func (s *ContactService) mergeExternalInvestigations(masterContactId, mergeConta
 	return false, nil
 }
 
+// Merge moves the external investigations of the merged contact to the master contact and writes the merge
+// confirmation on the outbox in the same transaction, so it is confirmed if and only if the merge commits.
 func (s *ContactService) Merge(
 	ctx context.Context,
 	eventPayload commonStructures.MergeContactEvent,
 ) *commonStructures.CommonError {
 	wasMergeContactFound := false
 	mergeContactId := commonUtils.ConvertStringToUint(eventPayload.MergeContact)
-	_, err := s.mergeExternalInvestigations(eventPayload.MasterContact.Id, mergeContactId)
+	tx := s.Db.Begin()
+	defer tx.Rollback()
+
+	_, err := s.mergeExternalInvestigationsWithTx(tx, eventPayload.MasterContact.Id,
+		mergeContactId)
 	if err != nil {
 		s.Sentry.LogError(ctx, commonConstants.ERROR_FAILED_TO_MERGE_INVESTIGATIONS, errors.New(err.Message), nil)
 		return err
 	}
 	eventPayload.WasMergeContactFound = wasMergeContactFound
-	return s.publishMergeConfirmEvent(ctx, eventPayload)
+	if err := s.writeMergeConfirmEventWithTx(ctx, tx, eventPayload); err != nil {
+		return err
+	}
+
+	if txErr := tx.Commit().Error; txErr != nil {
+		return commonUtils.HandleORMError(txErr)
+	}
+	return nil
 }
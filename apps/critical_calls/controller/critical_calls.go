@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/critical_calls/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Critical Calls
+// @Description	Get the critical value calls of a task, optionally only the ones not acknowledged yet
+// @Tags			critical-calls
+// @Produce		json
+// @Param			task_id		query		int								true	"Task ID"
+// @Param			open_only	query		bool							false	"Only unacknowledged calls"
+// @Success		200			{object}	[]structures.CriticalCall		"Critical Calls"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/critical-calls [get]
+func (criticalCallController *CriticalCall) GetCriticalCalls(c *gin.Context) {
+	taskId := commonUtils.ConvertStringToUint(c.Query("task_id"))
+	if taskId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_TASK_ID)
+		return
+	}
+
+	criticalCalls, cErr := criticalCallController.CriticalCallService.GetCriticalCallsByTaskId(taskId,
+		c.Query("open_only") == "true")
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, criticalCalls)
+}
+
+// @Summary		Acknowledge Critical Call
+// @Description	Log the call made for a critical value along with the read back by the recipient, and release
+// @Description	the report of the test once none of its critical values are pending acknowledgement
+// @Tags			critical-calls
+// @Accept			json
+// @Produce		json
+// @Param			criticalCallId	path		int											true	"Critical Call ID"
+// @Param			acknowledgement	body		structures.AcknowledgeCriticalCallRequest	true	"Acknowledgement"
+// @Success		200				{object}	structures.CriticalCall						"Critical Call"
+// @Failure		400,404,409,500	{object}	structures.CommonAPIResponse				"Common API Response"
+// @Router			/api/v1/critical-calls/{criticalCallId}/acknowledge [post]
+func (criticalCallController *CriticalCall) AcknowledgeCriticalCall(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	criticalCallId := commonUtils.ConvertStringToUint(c.Param("criticalCallId"))
+	if criticalCallId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_CRITICAL_CALL_ID)
+		return
+	}
+
+	acknowledgeRequest := structures.AcknowledgeCriticalCallRequest{}
+	if err := c.ShouldBindJSON(&acknowledgeRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	criticalCall, cErr := criticalCallController.CriticalCallService.AcknowledgeCriticalCall(c.Request.Context(),
+		criticalCallId, acknowledgeRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	blockedTestDetailsIds, cErr := criticalCallController.CriticalCallService.GetTestDetailsIdsWithOpenCriticalCalls(
+		[]uint{criticalCall.TestDetailsId})
+	if cErr == nil && len(blockedTestDetailsIds) == 0 {
+		_ = criticalCallController.TaskWorkerService.ReleaseReportTask(c.Request.Context(), criticalCall.TaskId, false)
+	}
+
+	c.JSON(http.StatusOK, criticalCall)
+}
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/critical_calls/service"
+	workerService "github.com/Orange-Health/citadel/apps/task/worker_service"
+)
+
+type CriticalCall struct {
+	CriticalCallService service.CriticalCallServiceInterface
+	TaskWorkerService   workerService.TaskWorkerServiceInterface
+}
+
+func InitCriticalCallController() *CriticalCall {
+	return &CriticalCall{
+		CriticalCallService: service.InitializeCriticalCallService(),
+		TaskWorkerService:   workerService.InitializeWorkerService(),
+	}
+}
@@ -0,0 +1,143 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/apps/critical_calls/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	BeginTransaction() *gorm.DB
+
+	GetCriticalCallById(criticalCallId uint) (commonModels.CriticalCall, *commonStructures.CommonError)
+	GetCriticalCallsByTaskId(taskId uint, statuses []string) ([]commonModels.CriticalCall, *commonStructures.CommonError)
+	GetCriticalCallsByInvestigationResultIdsWithTx(tx *gorm.DB, investigationResultIds []uint) (
+		[]commonModels.CriticalCall, *commonStructures.CommonError)
+	GetOpenCriticalCallsByTestDetailsIds(testDetailsIds []uint) ([]commonModels.CriticalCall, *commonStructures.CommonError)
+	GetCriticalCallsDueForEscalation(dueBefore time.Time) (
+		[]structures.CriticalCallEscalationDbStruct, *commonStructures.CommonError)
+
+	CreateCriticalCallsWithTx(tx *gorm.DB, criticalCalls []commonModels.CriticalCall) *commonStructures.CommonError
+	UpdateCriticalCall(criticalCall commonModels.CriticalCall) (commonModels.CriticalCall, *commonStructures.CommonError)
+	MarkCriticalCallsEscalatedWithTx(tx *gorm.DB, criticalCallIds []uint,
+		escalatedAt *time.Time) *commonStructures.CommonError
+}
+
+func (criticalCallDao *CriticalCallDao) BeginTransaction() *gorm.DB {
+	return criticalCallDao.Db.Begin()
+}
+
+func (criticalCallDao *CriticalCallDao) GetCriticalCallById(criticalCallId uint) (
+	commonModels.CriticalCall, *commonStructures.CommonError) {
+
+	criticalCall := commonModels.CriticalCall{}
+	if err := criticalCallDao.Db.Where("id = ?", criticalCallId).First(&criticalCall).Error; err != nil {
+		return criticalCall, commonUtils.HandleORMError(err)
+	}
+
+	return criticalCall, nil
+}
+
+func (criticalCallDao *CriticalCallDao) GetCriticalCallsByTaskId(taskId uint, statuses []string) (
+	[]commonModels.CriticalCall, *commonStructures.CommonError) {
+
+	criticalCalls := []commonModels.CriticalCall{}
+	query := criticalCallDao.Db.Where("task_id = ?", taskId)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if err := query.Order("id").Find(&criticalCalls).Error; err != nil {
+		return criticalCalls, commonUtils.HandleORMError(err)
+	}
+
+	return criticalCalls, nil
+}
+
+func (criticalCallDao *CriticalCallDao) GetCriticalCallsByInvestigationResultIdsWithTx(tx *gorm.DB,
+	investigationResultIds []uint) ([]commonModels.CriticalCall, *commonStructures.CommonError) {
+
+	criticalCalls := []commonModels.CriticalCall{}
+	if err := tx.Where("investigation_result_id IN ?", investigationResultIds).
+		Find(&criticalCalls).Error; err != nil {
+		return criticalCalls, commonUtils.HandleORMError(err)
+	}
+
+	return criticalCalls, nil
+}
+
+func (criticalCallDao *CriticalCallDao) GetOpenCriticalCallsByTestDetailsIds(testDetailsIds []uint) (
+	[]commonModels.CriticalCall, *commonStructures.CommonError) {
+
+	criticalCalls := []commonModels.CriticalCall{}
+	if err := criticalCallDao.Db.Where("test_details_id IN ?", testDetailsIds).
+		Where("status IN ?", commonConstants.CRITICAL_CALL_OPEN_STATUSES).Find(&criticalCalls).Error; err != nil {
+		return criticalCalls, commonUtils.HandleORMError(err)
+	}
+
+	return criticalCalls, nil
+}
+
+// GetCriticalCallsDueForEscalation returns the unacknowledged critical calls that were created, or last
+// escalated, before dueBefore, along with the order details needed to alert on them.
+func (criticalCallDao *CriticalCallDao) GetCriticalCallsDueForEscalation(dueBefore time.Time) (
+	[]structures.CriticalCallEscalationDbStruct, *commonStructures.CommonError) {
+
+	criticalCalls := []structures.CriticalCallEscalationDbStruct{}
+	if err := criticalCallDao.Db.Table(commonConstants.TableCriticalCalls).
+		Select("critical_calls.id, critical_calls.task_id, critical_calls.investigation_name, "+
+			"critical_calls.investigation_value, critical_calls.uom, critical_calls.escalation_count, "+
+			"critical_calls.created_at, tasks.oms_order_id, tasks.oms_request_id, tasks.city_code").
+		Joins("INNER JOIN tasks ON tasks.id = critical_calls.task_id AND tasks.deleted_at IS NULL").
+		Where("critical_calls.deleted_at IS NULL").
+		Where("critical_calls.status IN ?", commonConstants.CRITICAL_CALL_OPEN_STATUSES).
+		Where("COALESCE(critical_calls.last_escalated_at, critical_calls.created_at) <= ?", dueBefore).
+		Order("critical_calls.created_at").Scan(&criticalCalls).Error; err != nil {
+		return criticalCalls, commonUtils.HandleORMError(err)
+	}
+
+	return criticalCalls, nil
+}
+
+func (criticalCallDao *CriticalCallDao) CreateCriticalCallsWithTx(tx *gorm.DB,
+	criticalCalls []commonModels.CriticalCall) *commonStructures.CommonError {
+
+	if err := tx.Create(&criticalCalls).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+func (criticalCallDao *CriticalCallDao) UpdateCriticalCall(criticalCall commonModels.CriticalCall) (
+	commonModels.CriticalCall, *commonStructures.CommonError) {
+
+	if err := criticalCallDao.Db.Save(&criticalCall).Error; err != nil {
+		return criticalCall, commonUtils.HandleORMError(err)
+	}
+
+	return criticalCall, nil
+}
+
+func (criticalCallDao *CriticalCallDao) MarkCriticalCallsEscalatedWithTx(tx *gorm.DB, criticalCallIds []uint,
+	escalatedAt *time.Time) *commonStructures.CommonError {
+
+	criticalCallUpdates := map[string]interface{}{
+		"status":            commonConstants.CRITICAL_CALL_STATUS_ESCALATED,
+		"escalation_count":  gorm.Expr("escalation_count + 1"),
+		"last_escalated_at": escalatedAt,
+		"updated_by":        commonConstants.CitadelSystemId,
+		"updated_at":        escalatedAt,
+	}
+	if err := tx.Model(&commonModels.CriticalCall{}).Where("id IN ?", criticalCallIds).
+		Updates(criticalCallUpdates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type CriticalCallDao struct {
+	Db *gorm.DB
+}
+
+func InitializeCriticalCallDao() DataLayer {
+	return &CriticalCallDao{
+		Db: psql.GetDbInstance(),
+	}
+}
@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"github.com/Orange-Health/citadel/apps/critical_calls/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapCriticalCall(criticalCall commonModels.CriticalCall) structures.CriticalCall {
+	return structures.CriticalCall{
+		Id:                    criticalCall.Id,
+		TaskId:                criticalCall.TaskId,
+		TestDetailsId:         criticalCall.TestDetailsId,
+		InvestigationResultId: criticalCall.InvestigationResultId,
+		MasterInvestigationId: criticalCall.MasterInvestigationId,
+		InvestigationName:     criticalCall.InvestigationName,
+		InvestigationValue:    criticalCall.InvestigationValue,
+		Uom:                   criticalCall.Uom,
+		Status:                criticalCall.Status,
+		CalledBy:              criticalCall.CalledBy,
+		CalledToType:          criticalCall.CalledToType,
+		CalledToName:          criticalCall.CalledToName,
+		CalledToNumber:        criticalCall.CalledToNumber,
+		ReadBackValue:         criticalCall.ReadBackValue,
+		ReadBackConfirmed:     criticalCall.ReadBackConfirmed,
+		Remarks:               criticalCall.Remarks,
+		AcknowledgedAt:        criticalCall.AcknowledgedAt,
+		EscalationCount:       criticalCall.EscalationCount,
+		LastEscalatedAt:       criticalCall.LastEscalatedAt,
+		CreatedAt:             criticalCall.CreatedAt,
+	}
+}
+
+func MapCriticalCalls(criticalCalls []commonModels.CriticalCall) []structures.CriticalCall {
+	criticalCallsResponse := []structures.CriticalCall{}
+	for _, criticalCall := range criticalCalls {
+		criticalCallsResponse = append(criticalCallsResponse, MapCriticalCall(criticalCall))
+	}
+	return criticalCallsResponse
+}
+
+func MapCriticalCallFromInvestigation(taskId uint,
+	investigation commonModels.InvestigationResult) commonModels.CriticalCall {
+	criticalCall := commonModels.CriticalCall{
+		TaskId:                taskId,
+		TestDetailsId:         investigation.TestDetailsId,
+		InvestigationResultId: investigation.Id,
+		MasterInvestigationId: investigation.MasterInvestigationId,
+		InvestigationName:     investigation.InvestigationName,
+		InvestigationValue:    investigation.InvestigationValue,
+		Uom:                   investigation.Uom,
+		Status:                commonConstants.CRITICAL_CALL_STATUS_OPEN,
+	}
+	criticalCall.CreatedBy = commonConstants.CitadelSystemId
+	criticalCall.UpdatedBy = commonConstants.CitadelSystemId
+	return criticalCall
+}
+
+func MapAcknowledgeCriticalCallRequest(criticalCall commonModels.CriticalCall,
+	acknowledgeRequest structures.AcknowledgeCriticalCallRequest, userId uint) commonModels.CriticalCall {
+	criticalCall.Status = commonConstants.CRITICAL_CALL_STATUS_ACKNOWLEDGED
+	criticalCall.CalledBy = userId
+	criticalCall.CalledToType = acknowledgeRequest.CalledToType
+	criticalCall.CalledToName = acknowledgeRequest.CalledToName
+	criticalCall.CalledToNumber = acknowledgeRequest.CalledToNumber
+	criticalCall.ReadBackValue = acknowledgeRequest.ReadBackValue
+	criticalCall.ReadBackConfirmed = acknowledgeRequest.ReadBackConfirmed
+	criticalCall.Remarks = acknowledgeRequest.Remarks
+	criticalCall.UpdatedBy = userId
+	return criticalCall
+}
@@ -0,0 +1,14 @@
+package criticalCalls
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/critical_calls/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	criticalCallController := controller.InitCriticalCallController()
+
+	router.GET("", criticalCallController.GetCriticalCalls)
+	router.POST("/:criticalCallId/acknowledge", criticalCallController.AcknowledgeCriticalCall)
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/apps/critical_calls/mapper"
+	"github.com/Orange-Health/citadel/apps/critical_calls/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type CriticalCallServiceInterface interface {
+	GetCriticalCallsByTaskId(taskId uint, openOnly bool) ([]structures.CriticalCall, *commonStructures.CommonError)
+	GetTestDetailsIdsWithOpenCriticalCalls(testDetailsIds []uint) ([]uint, *commonStructures.CommonError)
+	CreateCriticalCallsWithTx(ctx context.Context, tx *gorm.DB, testDetails []commonModels.TestDetail,
+		investigations []commonModels.InvestigationResult) *commonStructures.CommonError
+	AcknowledgeCriticalCall(ctx context.Context, criticalCallId uint,
+		acknowledgeRequest structures.AcknowledgeCriticalCallRequest, userId uint) (
+		structures.CriticalCall, *commonStructures.CommonError)
+	EscalateOverdueCriticalCalls(ctx context.Context)
+}
+
+func (criticalCallService *CriticalCallService) GetCriticalCallsByTaskId(taskId uint, openOnly bool) (
+	[]structures.CriticalCall, *commonStructures.CommonError) {
+
+	statuses := []string{}
+	if openOnly {
+		statuses = commonConstants.CRITICAL_CALL_OPEN_STATUSES
+	}
+
+	criticalCalls, cErr := criticalCallService.CriticalCallDao.GetCriticalCallsByTaskId(taskId, statuses)
+	if cErr != nil {
+		return []structures.CriticalCall{}, cErr
+	}
+
+	return mapper.MapCriticalCalls(criticalCalls), nil
+}
+
+// GetTestDetailsIdsWithOpenCriticalCalls returns the tests whose report cannot be released yet because a
+// critical value in them has not been acknowledged.
+func (criticalCallService *CriticalCallService) GetTestDetailsIdsWithOpenCriticalCalls(testDetailsIds []uint) (
+	[]uint, *commonStructures.CommonError) {
+
+	if len(testDetailsIds) == 0 {
+		return []uint{}, nil
+	}
+
+	criticalCalls, cErr := criticalCallService.CriticalCallDao.GetOpenCriticalCallsByTestDetailsIds(testDetailsIds)
+	if cErr != nil {
+		return []uint{}, cErr
+	}
+
+	blockedTestDetailsIds := []uint{}
+	for _, criticalCall := range criticalCalls {
+		blockedTestDetailsIds = append(blockedTestDetailsIds, criticalCall.TestDetailsId)
+	}
+
+	return commonUtils.CreateUniqueSliceUint(blockedTestDetailsIds), nil
+}
+
+// CreateCriticalCallsWithTx opens a critical call for every critical investigation that does not have one
+// yet, as the results are saved, so the escalation window starts when the critical value is entered. It is
+// safe to call repeatedly for the same investigations.
+func (criticalCallService *CriticalCallService) CreateCriticalCallsWithTx(ctx context.Context, tx *gorm.DB,
+	testDetails []commonModels.TestDetail,
+	investigations []commonModels.InvestigationResult) *commonStructures.CommonError {
+
+	criticalInvestigations, investigationResultIds := []commonModels.InvestigationResult{}, []uint{}
+	for _, investigation := range investigations {
+		if investigation.IsCritical {
+			criticalInvestigations = append(criticalInvestigations, investigation)
+			investigationResultIds = append(investigationResultIds, investigation.Id)
+		}
+	}
+
+	if len(criticalInvestigations) == 0 {
+		return nil
+	}
+
+	criticalCallDao := criticalCallService.CriticalCallDao
+	existingCriticalCalls, cErr := criticalCallDao.GetCriticalCallsByInvestigationResultIdsWithTx(tx,
+		investigationResultIds)
+	if cErr != nil {
+		return cErr
+	}
+
+	existingInvestigationResultIds := map[uint]bool{}
+	for _, criticalCall := range existingCriticalCalls {
+		existingInvestigationResultIds[criticalCall.InvestigationResultId] = true
+	}
+
+	testDetailsIdToTaskIdMap := map[uint]uint{}
+	for _, testDetail := range testDetails {
+		testDetailsIdToTaskIdMap[testDetail.Id] = testDetail.TaskId
+	}
+
+	criticalCalls := []commonModels.CriticalCall{}
+	for _, investigation := range criticalInvestigations {
+		if existingInvestigationResultIds[investigation.Id] {
+			continue
+		}
+		criticalCalls = append(criticalCalls, mapper.MapCriticalCallFromInvestigation(
+			testDetailsIdToTaskIdMap[investigation.TestDetailsId], investigation))
+	}
+
+	if len(criticalCalls) == 0 {
+		return nil
+	}
+
+	if cErr := criticalCallService.CriticalCallDao.CreateCriticalCallsWithTx(tx, criticalCalls); cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_CREATING_CRITICAL_CALLS,
+			map[string]interface{}{
+				"investigation_result_ids": investigationResultIds,
+			}, errors.New(cErr.Message))
+		return cErr
+	}
+
+	return nil
+}
+
+// AcknowledgeCriticalCall logs who called whom about the critical value and the value the recipient read
+// back. The call is only acknowledged once the read back is confirmed.
+func (criticalCallService *CriticalCallService) AcknowledgeCriticalCall(ctx context.Context, criticalCallId uint,
+	acknowledgeRequest structures.AcknowledgeCriticalCallRequest, userId uint) (
+	structures.CriticalCall, *commonStructures.CommonError) {
+
+	if !commonUtils.SliceContainsString(commonConstants.CALLING_TYPES, acknowledgeRequest.CalledToType) {
+		return structures.CriticalCall{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_CALLING_TYPE,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if !acknowledgeRequest.ReadBackConfirmed {
+		return structures.CriticalCall{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_CRITICAL_CALL_READ_BACK_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	criticalCall, cErr := criticalCallService.CriticalCallDao.GetCriticalCallById(criticalCallId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_CRITICAL_CALL_NOT_FOUND
+		}
+		return structures.CriticalCall{}, cErr
+	}
+
+	if criticalCall.Status == commonConstants.CRITICAL_CALL_STATUS_ACKNOWLEDGED {
+		return structures.CriticalCall{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_CRITICAL_CALL_ALREADY_ACKNOWLEDGED,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	criticalCall = mapper.MapAcknowledgeCriticalCallRequest(criticalCall, acknowledgeRequest, userId)
+	criticalCall.AcknowledgedAt = commonUtils.GetCurrentTime()
+	criticalCall, cErr = criticalCallService.CriticalCallDao.UpdateCriticalCall(criticalCall)
+	if cErr != nil {
+		return structures.CriticalCall{}, cErr
+	}
+
+	commonUtils.AddLog(ctx, commonConstants.INFO_LEVEL, commonUtils.GetCurrentFunctionName(), map[string]interface{}{
+		"critical_call_id": criticalCall.Id,
+		"task_id":          criticalCall.TaskId,
+		"called_by":        criticalCall.CalledBy,
+		"called_to_type":   criticalCall.CalledToType,
+	}, nil)
+
+	return mapper.MapCriticalCall(criticalCall), nil
+}
+
+// EscalateOverdueCriticalCalls alerts on the critical calls that were not acknowledged within the escalation
+// window. A call keeps getting escalated every window until it is acknowledged.
+func (criticalCallService *CriticalCallService) EscalateOverdueCriticalCalls(ctx context.Context) {
+	escalationWindowMinutes := commonConstants.CriticalCallEscalationWindowMinutes
+	if escalationWindowMinutes <= 0 {
+		escalationWindowMinutes = commonConstants.CriticalCallDefaultEscalationWindowMinutes
+	}
+
+	currentTime := commonUtils.GetCurrentTime()
+	dueBefore := currentTime.Add(-time.Duration(escalationWindowMinutes) * time.Minute)
+	criticalCalls, cErr := criticalCallService.CriticalCallDao.GetCriticalCallsDueForEscalation(dueBefore)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_ESCALATING_CRITICAL_CALLS,
+			nil, errors.New(cErr.Message))
+		return
+	}
+
+	if len(criticalCalls) == 0 {
+		return
+	}
+
+	criticalCallIds := []uint{}
+	for index := range criticalCalls {
+		criticalCallIds = append(criticalCallIds, criticalCalls[index].Id)
+		criticalCalls[index].EscalationCount++
+	}
+
+	if cErr := criticalCallService.markCriticalCallsEscalated(ctx, criticalCalls, criticalCallIds,
+		currentTime); cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_ESCALATING_CRITICAL_CALLS,
+			map[string]interface{}{
+				"critical_call_ids": criticalCallIds,
+			}, errors.New(cErr.Message))
+		return
+	}
+
+	criticalCallService.sendCriticalCallEscalationSlackAlert(ctx, criticalCalls, *currentTime)
+}
+
+// markCriticalCallsEscalated writes the escalated events on the outbox in the transaction that escalates the
+// critical calls, so an escalation is announced if and only if it is recorded.
+func (criticalCallService *CriticalCallService) markCriticalCallsEscalated(ctx context.Context,
+	criticalCalls []structures.CriticalCallEscalationDbStruct, criticalCallIds []uint,
+	escalatedAt *time.Time) *commonStructures.CommonError {
+
+	tx := criticalCallService.CriticalCallDao.BeginTransaction()
+	defer tx.Rollback()
+
+	cErr := criticalCallService.CriticalCallDao.MarkCriticalCallsEscalatedWithTx(tx, criticalCallIds, escalatedAt)
+	if cErr != nil {
+		return cErr
+	}
+
+	for _, criticalCall := range criticalCalls {
+		cErr = criticalCallService.writeCriticalCallEscalatedEventWithTx(ctx, tx, criticalCall)
+		if cErr != nil {
+			return cErr
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+func (criticalCallService *CriticalCallService) writeCriticalCallEscalatedEventWithTx(ctx context.Context,
+	tx *gorm.DB, criticalCall structures.CriticalCallEscalationDbStruct) *commonStructures.CommonError {
+
+	messageBody, messageAttributes := criticalCallService.PubsubService.GetCriticalCallEscalatedEvent(
+		commonStructures.CriticalCallEscalatedEvent{
+			CriticalCallId:     criticalCall.Id,
+			OmsOrderId:         criticalCall.OmsOrderId,
+			InvestigationName:  criticalCall.InvestigationName,
+			InvestigationValue: criticalCall.InvestigationValue,
+			Uom:                criticalCall.Uom,
+			EscalationCount:    criticalCall.EscalationCount,
+			OpenSince:          criticalCall.CreatedAt,
+			CityCode:           criticalCall.CityCode,
+		})
+	return criticalCallService.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes, "",
+		commonConstants.CitadelTopicArn, "")
+}
+
+func (criticalCallService *CriticalCallService) sendCriticalCallEscalationSlackAlert(ctx context.Context,
+	criticalCalls []structures.CriticalCallEscalationDbStruct, currentTime time.Time) {
+
+	// Send critical calls in batches to avoid hitting Slack's 50 block limit
+	maxCallsPerMessage := commonConstants.MaxBlocksForSlackMessage
+	for i := 0; i < len(criticalCalls); i += maxCallsPerMessage {
+		end := i + maxCallsPerMessage
+		if end > len(criticalCalls) {
+			end = len(criticalCalls)
+		}
+
+		blocks := []map[string]interface{}{
+			{
+				"type": "header",
+				"text": map[string]interface{}{
+					"type":  "plain_text",
+					"text":  ":rotating_light: Unacknowledged Critical Values",
+					"emoji": true,
+				},
+			},
+			{
+				"type": "section",
+				"fields": []map[string]interface{}{
+					{"type": "mrkdwn", "text": "*Order ID / Investigation / Value*"},
+					{"type": "mrkdwn", "text": "*Open For (min) / Escalation*"},
+				},
+			},
+		}
+
+		for _, criticalCall := range criticalCalls[i:end] {
+			omsOrderIdString := commonUtils.GetStringOrderIdWithoutStringPart(criticalCall.OmsOrderId)
+			omsOrderUrl := fmt.Sprintf("%s/request/%s/order/%s",
+				commonUtils.GetOmsBaseDomain(criticalCall.CityCode), criticalCall.OmsRequestId, omsOrderIdString)
+			criticalCallDetail := fmt.Sprintf("%s / %s / %s %s", omsOrderIdString, criticalCall.InvestigationName,
+				criticalCall.InvestigationValue, criticalCall.Uom)
+			openForMinutes := 0
+			if criticalCall.CreatedAt != nil {
+				openForMinutes = int(currentTime.Sub(*criticalCall.CreatedAt).Minutes())
+			}
+			blocks = append(blocks, map[string]interface{}{
+				"type": "section",
+				"fields": []map[string]interface{}{
+					{"type": "mrkdwn", "text": fmt.Sprintf("<%s|%s>", omsOrderUrl, criticalCallDetail)},
+					{"type": "mrkdwn", "text": fmt.Sprintf("%d / #%d", openForMinutes, criticalCall.EscalationCount)},
+				},
+			})
+		}
+
+		err := criticalCallService.SlackClient.SendToSlackDirectly(ctx,
+			commonConstants.SlackCriticalCallEscalationChannel, blocks)
+		if err != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_FAILED_TO_SEND_SLACK_MESSAGE,
+				nil, err)
+		}
+	}
+}
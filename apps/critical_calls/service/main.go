@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/cache"
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/apps/critical_calls/dao"
+	outboxService "github.com/Orange-Health/citadel/apps/outbox/service"
+	pubsubService "github.com/Orange-Health/citadel/apps/pubsub/service"
+	slackClient "github.com/Orange-Health/citadel/clients/slack"
+)
+
+type CriticalCallService struct {
+	CriticalCallDao dao.DataLayer
+	Cache           cache.CacheLayer
+	Sentry          sentry.SentryLayer
+	PubsubService   pubsubService.PubsubInterface
+	OutboxService   outboxService.OutboxServiceInterface
+	SlackClient     slackClient.SlackClientInterface
+}
+
+func InitializeCriticalCallService() CriticalCallServiceInterface {
+	return &CriticalCallService{
+		CriticalCallDao: dao.InitializeCriticalCallDao(),
+		Cache:           cache.InitializeCache(),
+		Sentry:          sentry.InitializeSentry(),
+		PubsubService:   pubsubService.InitializePubsubService(),
+		OutboxService:   outboxService.InitializeOutboxService(),
+		SlackClient:     slackClient.InitializeSlackClient(),
+	}
+}
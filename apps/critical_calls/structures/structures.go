@@ -0,0 +1,77 @@
+package structures
+
+import (
+	"time"
+)
+
+// @swagger:model CriticalCall
+type CriticalCall struct {
+	// The critical call ID.
+	// example: 1
+	Id uint `json:"id"`
+	// example: 1
+	TaskId uint `json:"task_id"`
+	// example: 1
+	TestDetailsId uint `json:"test_details_id"`
+	// example: 1
+	InvestigationResultId uint `json:"investigation_result_id"`
+	// example: 1
+	MasterInvestigationId uint `json:"master_investigation_id"`
+	// example: "Potassium"
+	InvestigationName string `json:"investigation_name"`
+	// example: "6.8"
+	InvestigationValue string `json:"investigation_value"`
+	// example: "mmol/L"
+	Uom string `json:"uom"`
+	// One of open, escalated and acknowledged.
+	// example: "open"
+	Status string `json:"status"`
+	// The user who made the call.
+	// example: 1
+	CalledBy uint `json:"called_by"`
+	// One of doctor and customer.
+	// example: "doctor"
+	CalledToType string `json:"called_to_type"`
+	// example: "Dr. A Sharma"
+	CalledToName string `json:"called_to_name"`
+	// example: "9999999999"
+	CalledToNumber string `json:"called_to_number"`
+	// The value as read back by the recipient.
+	// example: "6.8"
+	ReadBackValue string `json:"read_back_value"`
+	// example: true
+	ReadBackConfirmed bool `json:"read_back_confirmed"`
+	// example: "Advised to visit the emergency"
+	Remarks string `json:"remarks"`
+	// example: "2026-10-18T09:30:00Z"
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	// The number of times the call was escalated for not being acknowledged in time.
+	// example: 0
+	EscalationCount uint `json:"escalation_count"`
+	// example: "2026-10-18T09:30:00Z"
+	LastEscalatedAt *time.Time `json:"last_escalated_at"`
+	// example: "2026-10-18T09:00:00Z"
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type AcknowledgeCriticalCallRequest struct {
+	CalledToType      string `json:"called_to_type" binding:"required"`
+	CalledToName      string `json:"called_to_name" binding:"required"`
+	CalledToNumber    string `json:"called_to_number"`
+	ReadBackValue     string `json:"read_back_value" binding:"required"`
+	ReadBackConfirmed bool   `json:"read_back_confirmed"`
+	Remarks           string `json:"remarks"`
+}
+
+type CriticalCallEscalationDbStruct struct {
+	Id                 uint       `json:"id"`
+	TaskId             uint       `json:"task_id"`
+	InvestigationName  string     `json:"investigation_name"`
+	InvestigationValue string     `json:"investigation_value"`
+	Uom                string     `json:"uom"`
+	EscalationCount    uint       `json:"escalation_count"`
+	CreatedAt          *time.Time `json:"created_at"`
+	OmsOrderId         string     `json:"oms_order_id"`
+	OmsRequestId       string     `json:"oms_request_id"`
+	CityCode           string     `json:"city_code"`
+}
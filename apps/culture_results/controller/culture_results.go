@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/culture_results/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Culture Result
+// @Description	Get the organisms and antibiotic susceptibilities of a culture investigation
+// @Tags			culture-results
+// @Produce		json
+// @Param			investigationResultId	path		int								true	"Investigation Result ID"
+// @Success		200						{object}	structures.CultureResult		"Culture Result"
+// @Failure		400,404,500				{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/culture-results/{investigationResultId} [get]
+func (cultureResultsController *CultureResults) GetCultureResult(c *gin.Context) {
+	investigationResultId := commonUtils.ConvertStringToUint(c.Param("investigationResultId"))
+	if investigationResultId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_INVESTIGATION_RESULT_ID)
+		return
+	}
+
+	cultureResult, cErr := cultureResultsController.CultureResultsService.GetCultureResult(investigationResultId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, cultureResult)
+}
+
+// @Summary		Update Culture Result
+// @Description	Replace the organisms and antibiotic susceptibilities of a culture investigation. Pathologists only.
+// @Description	Empty interpretations are derived from the MIC value, others are validated against the breakpoints.
+// @Tags			culture-results
+// @Accept			json
+// @Produce		json
+// @Param			investigationResultId	path		int								true	"Investigation Result ID"
+// @Param			cultureResult			body		structures.CultureResultRequest	true	"Culture Result"
+// @Success		200						{object}	structures.CultureResult		"Culture Result"
+// @Failure		400,403,404,500			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/culture-results/{investigationResultId} [put]
+func (cultureResultsController *CultureResults) UpdateCultureResult(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	investigationResultId := commonUtils.ConvertStringToUint(c.Param("investigationResultId"))
+	if investigationResultId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_INVESTIGATION_RESULT_ID)
+		return
+	}
+
+	cultureResultRequest := structures.CultureResultRequest{}
+	if err := c.ShouldBindJSON(&cultureResultRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cultureResult, cErr := cultureResultsController.CultureResultsService.UpdateCultureResult(c.Request.Context(),
+		investigationResultId, cultureResultRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, cultureResult)
+}
+
+// @Summary		Get Antimicrobial Breakpoints
+// @Description	Get the MIC breakpoints, optionally filtered by standard, organism and antimicrobial
+// @Tags			culture-results
+// @Produce		json
+// @Param			standard		query		string								false	"CLSI or EUCAST"
+// @Param			organism		query		string								false	"Organism or family"
+// @Param			antimicrobial	query		string								false	"Antimicrobial"
+// @Success		200				{object}	[]structures.AntimicrobialBreakpoint	"Antimicrobial Breakpoints"
+// @Failure		400,500			{object}	structures.CommonAPIResponse		"Common API Response"
+// @Router			/api/v1/culture-results/breakpoints [get]
+func (cultureResultsController *CultureResults) GetAntimicrobialBreakpoints(c *gin.Context) {
+	breakpoints, cErr := cultureResultsController.CultureResultsService.GetAntimicrobialBreakpoints(
+		c.Query("standard"), c.Query("organism"), c.Query("antimicrobial"))
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, breakpoints)
+}
+
+// @Summary		Create Antimicrobial Breakpoint
+// @Description	Create the MIC breakpoints of an antimicrobial for an organism or family under a standard
+// @Tags			culture-results
+// @Accept			json
+// @Produce		json
+// @Param			breakpoint	body		structures.AntimicrobialBreakpointRequest	true	"Antimicrobial Breakpoint"
+// @Success		200			{object}	structures.AntimicrobialBreakpoint			"Antimicrobial Breakpoint"
+// @Failure		400,409,500	{object}	structures.CommonAPIResponse				"Common API Response"
+// @Router			/api/v1/culture-results/breakpoints [post]
+func (cultureResultsController *CultureResults) CreateAntimicrobialBreakpoint(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	breakpointRequest := structures.AntimicrobialBreakpointRequest{}
+	if err := c.ShouldBindJSON(&breakpointRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	breakpoint, cErr := cultureResultsController.CultureResultsService.CreateAntimicrobialBreakpoint(
+		breakpointRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, breakpoint)
+}
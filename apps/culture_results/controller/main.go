@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/culture_results/service"
+)
+
+type CultureResults struct {
+	CultureResultsService service.CultureResultsServiceInterface
+}
+
+func InitCultureResultsController() *CultureResults {
+	return &CultureResults{
+		CultureResultsService: service.InitializeCultureResultsService(),
+	}
+}
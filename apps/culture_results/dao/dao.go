@@ -0,0 +1,208 @@
+package dao
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetCultureResultsByInvestigationResultIds(investigationResultIds []uint) (
+		[]commonModels.CultureResult, *commonStructures.CommonError)
+	GetAntimicrobialBreakpoints(standard string, organisms, antimicrobials []string) (
+		[]commonModels.AntimicrobialBreakpoint, *commonStructures.CommonError)
+	GetAntimicrobialBreakpoint(standard, organism, antimicrobial string) (
+		commonModels.AntimicrobialBreakpoint, *commonStructures.CommonError)
+
+	UpdateCultureResult(cultureResult commonModels.CultureResult, investigationData string) (
+		commonModels.CultureResult, *commonStructures.CommonError)
+	CreateAntimicrobialBreakpoint(breakpoint commonModels.AntimicrobialBreakpoint) (
+		commonModels.AntimicrobialBreakpoint, *commonStructures.CommonError)
+
+	ReplaceCultureResultsWithTx(tx *gorm.DB, cultureResults []commonModels.CultureResult) (
+		[]commonModels.CultureResult, *commonStructures.CommonError)
+}
+
+func orderByDisplayOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("display_order, id")
+}
+
+func (cultureResultsDao *CultureResultsDao) GetCultureResultsByInvestigationResultIds(investigationResultIds []uint) (
+	[]commonModels.CultureResult, *commonStructures.CommonError) {
+
+	cultureResults := []commonModels.CultureResult{}
+	if err := cultureResultsDao.Db.
+		Preload(commonConstants.CultureOrganisms, orderByDisplayOrder).
+		Preload(commonConstants.CultureSusceptibilities, orderByDisplayOrder).
+		Where("investigation_result_id IN (?)", investigationResultIds).
+		Find(&cultureResults).Error; err != nil {
+		return cultureResults, commonUtils.HandleORMError(err)
+	}
+
+	return cultureResults, nil
+}
+
+// GetAntimicrobialBreakpoints returns the breakpoints of the standard defined for any of the organisms (names or
+// families) and antimicrobials, matched case-insensitively. An empty filter matches everything.
+func (cultureResultsDao *CultureResultsDao) GetAntimicrobialBreakpoints(standard string,
+	organisms, antimicrobials []string) ([]commonModels.AntimicrobialBreakpoint, *commonStructures.CommonError) {
+
+	breakpoints := []commonModels.AntimicrobialBreakpoint{}
+	query := cultureResultsDao.Db
+	if standard != "" {
+		query = query.Where("standard = ?", standard)
+	}
+	if len(organisms) > 0 {
+		query = query.Where("LOWER(organism) IN (?)", lowerStrings(organisms))
+	}
+	if len(antimicrobials) > 0 {
+		query = query.Where("LOWER(antimicrobial) IN (?)", lowerStrings(antimicrobials))
+	}
+	if err := query.Order("standard, organism, antimicrobial").Find(&breakpoints).Error; err != nil {
+		return breakpoints, commonUtils.HandleORMError(err)
+	}
+
+	return breakpoints, nil
+}
+
+func (cultureResultsDao *CultureResultsDao) GetAntimicrobialBreakpoint(standard, organism, antimicrobial string) (
+	commonModels.AntimicrobialBreakpoint, *commonStructures.CommonError) {
+
+	breakpoint := commonModels.AntimicrobialBreakpoint{}
+	if err := cultureResultsDao.Db.Where("standard = ?", standard).
+		Where("LOWER(organism) = ?", strings.ToLower(organism)).
+		Where("LOWER(antimicrobial) = ?", strings.ToLower(antimicrobial)).
+		First(&breakpoint).Error; err != nil {
+		return breakpoint, commonUtils.HandleORMError(err)
+	}
+
+	return breakpoint, nil
+}
+
+// UpdateCultureResult saves the header of the culture result, replaces its organisms and susceptibilities and
+// rewrites the investigation data it was ingested from, so that both stay in sync.
+func (cultureResultsDao *CultureResultsDao) UpdateCultureResult(cultureResult commonModels.CultureResult,
+	investigationData string) (commonModels.CultureResult, *commonStructures.CommonError) {
+
+	organisms := cultureResult.Organisms
+	cultureResult.Organisms = nil
+	err := cultureResultsDao.Db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteCultureOrganismsWithTx(tx, []uint{cultureResult.Id}, cultureResult.UpdatedBy); err != nil {
+			return err
+		}
+		if err := tx.Omit(commonConstants.CultureOrganisms).Save(&cultureResult).Error; err != nil {
+			return err
+		}
+		for index := range organisms {
+			organisms[index].CultureResultId = cultureResult.Id
+		}
+		if len(organisms) > 0 {
+			if err := tx.Create(&organisms).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&commonModels.InvestigationData{}).
+			Where("investigation_result_id = ? AND data_type = ?", cultureResult.InvestigationResultId,
+				commonConstants.CultureInvestigationValue).
+			Updates(map[string]interface{}{
+				"data":       investigationData,
+				"updated_by": cultureResult.UpdatedBy,
+			}).Error
+	})
+	if err != nil {
+		return cultureResult, commonUtils.HandleORMError(err)
+	}
+
+	cultureResult.Organisms = organisms
+	return cultureResult, nil
+}
+
+func (cultureResultsDao *CultureResultsDao) CreateAntimicrobialBreakpoint(
+	breakpoint commonModels.AntimicrobialBreakpoint) (
+	commonModels.AntimicrobialBreakpoint, *commonStructures.CommonError) {
+
+	if err := cultureResultsDao.Db.Create(&breakpoint).Error; err != nil {
+		return breakpoint, commonUtils.HandleORMError(err)
+	}
+
+	return breakpoint, nil
+}
+
+// ReplaceCultureResultsWithTx soft deletes the culture results already stored for the investigations, along with
+// their organisms and susceptibilities, and creates the given ones in their place.
+func (cultureResultsDao *CultureResultsDao) ReplaceCultureResultsWithTx(tx *gorm.DB,
+	cultureResults []commonModels.CultureResult) ([]commonModels.CultureResult, *commonStructures.CommonError) {
+
+	if len(cultureResults) == 0 {
+		return cultureResults, nil
+	}
+
+	investigationResultIds := []uint{}
+	for _, cultureResult := range cultureResults {
+		investigationResultIds = append(investigationResultIds, cultureResult.InvestigationResultId)
+	}
+
+	cultureResultIds := []uint{}
+	if err := tx.Model(&commonModels.CultureResult{}).
+		Where("investigation_result_id IN (?)", investigationResultIds).
+		Pluck("id", &cultureResultIds).Error; err != nil {
+		return nil, commonUtils.HandleORMError(err)
+	}
+
+	if len(cultureResultIds) > 0 {
+		if err := deleteCultureOrganismsWithTx(tx, cultureResultIds, commonConstants.CitadelSystemId); err != nil {
+			return nil, commonUtils.HandleORMError(err)
+		}
+		if err := tx.Model(&commonModels.CultureResult{}).Where("id IN (?)", cultureResultIds).
+			Updates(getSoftDeleteUpdates(commonConstants.CitadelSystemId)).Error; err != nil {
+			return nil, commonUtils.HandleORMError(err)
+		}
+	}
+
+	if err := tx.Create(&cultureResults).Error; err != nil {
+		return nil, commonUtils.HandleORMError(err)
+	}
+
+	return cultureResults, nil
+}
+
+func deleteCultureOrganismsWithTx(tx *gorm.DB, cultureResultIds []uint, userId uint) error {
+	organismIds := []uint{}
+	if err := tx.Model(&commonModels.CultureOrganism{}).Where("culture_result_id IN (?)", cultureResultIds).
+		Pluck("id", &organismIds).Error; err != nil {
+		return err
+	}
+	if len(organismIds) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(&commonModels.CultureSusceptibility{}).Where("culture_organism_id IN (?)", organismIds).
+		Updates(getSoftDeleteUpdates(userId)).Error; err != nil {
+		return err
+	}
+	return tx.Model(&commonModels.CultureOrganism{}).Where("id IN (?)", organismIds).
+		Updates(getSoftDeleteUpdates(userId)).Error
+}
+
+func getSoftDeleteUpdates(userId uint) map[string]interface{} {
+	currentTime := commonUtils.GetCurrentTime()
+	return map[string]interface{}{
+		"deleted_by": userId,
+		"updated_by": userId,
+		"deleted_at": currentTime,
+		"updated_at": currentTime,
+	}
+}
+
+func lowerStrings(values []string) []string {
+	lowerValues := []string{}
+	for _, value := range values {
+		lowerValues = append(lowerValues, strings.ToLower(value))
+	}
+	return lowerValues
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type CultureResultsDao struct {
+	Db *gorm.DB
+}
+
+func InitializeCultureResultsDao() DataLayer {
+	return &CultureResultsDao{
+		Db: psql.GetDbInstance(),
+	}
+}
@@ -0,0 +1,252 @@
+package mapper
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/Orange-Health/citadel/apps/culture_results/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapCultureResult(cultureResult commonModels.CultureResult) structures.CultureResult {
+	stainDetails := []commonStructures.StainResultDetail{}
+	_ = json.Unmarshal([]byte(cultureResult.StainDetails), &stainDetails)
+
+	organisms := []structures.CultureOrganism{}
+	for _, organism := range cultureResult.Organisms {
+		susceptibilities := []structures.CultureSusceptibility{}
+		for _, susceptibility := range organism.Susceptibilities {
+			susceptibilities = append(susceptibilities, structures.CultureSusceptibility{
+				Id:                 susceptibility.Id,
+				Antimicrobial:      susceptibility.Antimicrobial,
+				MicValue:           susceptibility.MicValue,
+				Interpretation:     susceptibility.Interpretation,
+				BreakpointStandard: susceptibility.BreakpointStandard,
+				DisplayOrder:       susceptibility.DisplayOrder,
+			})
+		}
+		organisms = append(organisms, structures.CultureOrganism{
+			Id:               organism.Id,
+			Name:             organism.Name,
+			Family:           organism.Family,
+			ColonyCount:      organism.ColonyCount,
+			DisplayOrder:     organism.DisplayOrder,
+			Susceptibilities: susceptibilities,
+		})
+	}
+
+	return structures.CultureResult{
+		Id:                    cultureResult.Id,
+		InvestigationResultId: cultureResult.InvestigationResultId,
+		ReportStatus:          cultureResult.ReportStatus,
+		SampleType:            cultureResult.SampleType,
+		IncubationPeriod:      cultureResult.IncubationPeriod,
+		StainResult:           cultureResult.StainResult,
+		StainDetails:          stainDetails,
+		CultureReport:         cultureResult.CultureReport,
+		ColonyCount:           cultureResult.ColonyCount,
+		ResistanceDetected:    cultureResult.ResistanceDetected,
+		ClinicalHistory:       cultureResult.ClinicalHistory,
+		Gross:                 cultureResult.Gross,
+		BreakpointStandard:    cultureResult.BreakpointStandard,
+		Organisms:             organisms,
+		UpdatedBy:             cultureResult.UpdatedBy,
+		UpdatedAt:             cultureResult.UpdatedAt,
+	}
+}
+
+// MapTransformedResultsToCultureResult maps a culture result ingested from the LIS. Sensitivities Attune reports
+// as free text are normalised to S, I or R and left empty when not recognised.
+func MapTransformedResultsToCultureResult(investigationResultId uint,
+	cultureResults commonStructures.TransformedInvestigationResults) commonModels.CultureResult {
+
+	stainDetails, _ := json.Marshal(cultureResults.StainResultDetails)
+	cultureResult := commonModels.CultureResult{
+		InvestigationResultId: investigationResultId,
+		ReportStatus:          cultureResults.ReportStatus,
+		SampleType:            cultureResults.SampleType,
+		IncubationPeriod:      cultureResults.IncubationPeriod,
+		StainResult:           cultureResults.CultureStainResult,
+		StainDetails:          getStainDetails(stainDetails),
+		CultureReport:         cultureResults.CultureReport,
+		ColonyCount:           cultureResults.ColonyCount,
+		ResistanceDetected:    cultureResults.ResistanceDetected,
+		ClinicalHistory:       cultureResults.ClinicalHistory,
+		Gross:                 cultureResults.Gross,
+		BreakpointStandard:    commonConstants.DefaultBreakpointStandard,
+		Organisms:             []commonModels.CultureOrganism{},
+	}
+	cultureResult.CreatedBy = commonConstants.CitadelSystemId
+	cultureResult.UpdatedBy = commonConstants.CitadelSystemId
+
+	for organismIndex, growthData := range cultureResults.GrowthData {
+		organism := commonModels.CultureOrganism{
+			Name:             growthData.Microorganism,
+			Family:           growthData.Family,
+			ColonyCount:      growthData.ColonyCount,
+			DisplayOrder:     organismIndex + 1,
+			Susceptibilities: []commonModels.CultureSusceptibility{},
+		}
+		organism.CreatedBy = commonConstants.CitadelSystemId
+		organism.UpdatedBy = commonConstants.CitadelSystemId
+
+		for _, sensitivity := range growthData.Sensitivity {
+			micValue := sensitivity.MicValueText
+			if micValue == "" && sensitivity.MicValue != 0 {
+				micValue = strconv.Itoa(sensitivity.MicValue)
+			}
+			susceptibility := commonModels.CultureSusceptibility{
+				Antimicrobial: sensitivity.Antimicrobial,
+				MicValue:      micValue,
+				Interpretation: commonConstants.AttuneSensitivityToInterpretation[strings.ToLower(
+					strings.TrimSpace(sensitivity.Sensitivity))],
+				BreakpointStandard: cultureResult.BreakpointStandard,
+				DisplayOrder:       sensitivity.DisplayOrder,
+			}
+			susceptibility.CreatedBy = commonConstants.CitadelSystemId
+			susceptibility.UpdatedBy = commonConstants.CitadelSystemId
+			organism.Susceptibilities = append(organism.Susceptibilities, susceptibility)
+		}
+		cultureResult.Organisms = append(cultureResult.Organisms, organism)
+	}
+
+	return cultureResult
+}
+
+// MapCultureResultRequest replaces the editable fields and the organisms of the culture result. Organisms and
+// susceptibilities are displayed in the order they were sent in.
+func MapCultureResultRequest(cultureResult commonModels.CultureResult, cultureResultRequest structures.CultureResultRequest,
+	userId uint) commonModels.CultureResult {
+
+	if cultureResultRequest.BreakpointStandard != "" {
+		cultureResult.BreakpointStandard = strings.ToUpper(cultureResultRequest.BreakpointStandard)
+	}
+	cultureResult.CultureReport = cultureResultRequest.CultureReport
+	cultureResult.ColonyCount = cultureResultRequest.ColonyCount
+	cultureResult.ResistanceDetected = cultureResultRequest.ResistanceDetected
+	cultureResult.UpdatedBy = userId
+	cultureResult.Organisms = []commonModels.CultureOrganism{}
+
+	for organismIndex, organismRequest := range cultureResultRequest.Organisms {
+		organism := commonModels.CultureOrganism{
+			Name:             strings.TrimSpace(organismRequest.Name),
+			Family:           strings.TrimSpace(organismRequest.Family),
+			ColonyCount:      organismRequest.ColonyCount,
+			DisplayOrder:     organismIndex + 1,
+			Susceptibilities: []commonModels.CultureSusceptibility{},
+		}
+		organism.CreatedBy = userId
+		organism.UpdatedBy = userId
+
+		for susceptibilityIndex, susceptibilityRequest := range organismRequest.Susceptibilities {
+			breakpointStandard := cultureResult.BreakpointStandard
+			if susceptibilityRequest.BreakpointStandard != "" {
+				breakpointStandard = strings.ToUpper(susceptibilityRequest.BreakpointStandard)
+			}
+			susceptibility := commonModels.CultureSusceptibility{
+				Antimicrobial:      strings.TrimSpace(susceptibilityRequest.Antimicrobial),
+				MicValue:           strings.TrimSpace(susceptibilityRequest.MicValue),
+				Interpretation:     strings.ToUpper(strings.TrimSpace(susceptibilityRequest.Interpretation)),
+				BreakpointStandard: breakpointStandard,
+				DisplayOrder:       susceptibilityIndex + 1,
+			}
+			susceptibility.CreatedBy = userId
+			susceptibility.UpdatedBy = userId
+			organism.Susceptibilities = append(organism.Susceptibilities, susceptibility)
+		}
+		cultureResult.Organisms = append(cultureResult.Organisms, organism)
+	}
+
+	return cultureResult
+}
+
+// MapCultureResultToTransformedResults maps the culture result back into the shape stored in investigation data.
+func MapCultureResultToTransformedResults(
+	cultureResult commonModels.CultureResult) commonStructures.TransformedInvestigationResults {
+
+	stainDetails := []commonStructures.StainResultDetail{}
+	_ = json.Unmarshal([]byte(cultureResult.StainDetails), &stainDetails)
+
+	growthData := []commonStructures.MicrorganismGrowthData{}
+	for _, organism := range cultureResult.Organisms {
+		sensitivities := []commonStructures.Sensitivity{}
+		for _, susceptibility := range organism.Susceptibilities {
+			micValue, _ := strconv.ParseFloat(strings.TrimLeft(susceptibility.MicValue, "<>= "), 64)
+			sensitivities = append(sensitivities, commonStructures.Sensitivity{
+				Antimicrobial: susceptibility.Antimicrobial,
+				Sensitivity:   susceptibility.Interpretation,
+				MicValue:      int(micValue),
+				MicValueText:  susceptibility.MicValue,
+				DisplayOrder:  susceptibility.DisplayOrder,
+			})
+		}
+		growthData = append(growthData, commonStructures.MicrorganismGrowthData{
+			Microorganism: organism.Name,
+			ColonyCount:   organism.ColonyCount,
+			Family:        organism.Family,
+			Sensitivity:   sensitivities,
+		})
+	}
+
+	return commonStructures.TransformedInvestigationResults{
+		ReportStatus:       cultureResult.ReportStatus,
+		SampleType:         cultureResult.SampleType,
+		IncubationPeriod:   cultureResult.IncubationPeriod,
+		CultureStainResult: cultureResult.StainResult,
+		CultureReport:      cultureResult.CultureReport,
+		ColonyCount:        cultureResult.ColonyCount,
+		ResistanceDetected: cultureResult.ResistanceDetected,
+		StainResultDetails: stainDetails,
+		GrowthData:         growthData,
+		ClinicalHistory:    cultureResult.ClinicalHistory,
+		Gross:              cultureResult.Gross,
+	}
+}
+
+func MapAntimicrobialBreakpoint(breakpoint commonModels.AntimicrobialBreakpoint) structures.AntimicrobialBreakpoint {
+	return structures.AntimicrobialBreakpoint{
+		Id:                breakpoint.Id,
+		Standard:          breakpoint.Standard,
+		Version:           breakpoint.Version,
+		Organism:          breakpoint.Organism,
+		Antimicrobial:     breakpoint.Antimicrobial,
+		SusceptibleMaxMic: breakpoint.SusceptibleMaxMic,
+		ResistantMinMic:   breakpoint.ResistantMinMic,
+	}
+}
+
+func MapAntimicrobialBreakpoints(
+	breakpoints []commonModels.AntimicrobialBreakpoint) []structures.AntimicrobialBreakpoint {
+
+	antimicrobialBreakpoints := []structures.AntimicrobialBreakpoint{}
+	for _, breakpoint := range breakpoints {
+		antimicrobialBreakpoints = append(antimicrobialBreakpoints, MapAntimicrobialBreakpoint(breakpoint))
+	}
+	return antimicrobialBreakpoints
+}
+
+func MapAntimicrobialBreakpointRequest(breakpointRequest structures.AntimicrobialBreakpointRequest,
+	userId uint) commonModels.AntimicrobialBreakpoint {
+
+	breakpoint := commonModels.AntimicrobialBreakpoint{
+		Standard:          strings.ToUpper(breakpointRequest.Standard),
+		Version:           breakpointRequest.Version,
+		Organism:          strings.TrimSpace(breakpointRequest.Organism),
+		Antimicrobial:     strings.TrimSpace(breakpointRequest.Antimicrobial),
+		SusceptibleMaxMic: breakpointRequest.SusceptibleMaxMic,
+		ResistantMinMic:   breakpointRequest.ResistantMinMic,
+	}
+	breakpoint.CreatedBy = userId
+	breakpoint.UpdatedBy = userId
+	return breakpoint
+}
+
+func getStainDetails(stainDetails []byte) string {
+	if len(stainDetails) == 0 || string(stainDetails) == "null" {
+		return "[]"
+	}
+	return string(stainDetails)
+}
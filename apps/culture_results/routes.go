@@ -0,0 +1,16 @@
+package cultureResults
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/culture_results/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	cultureResultsController := controller.InitCultureResultsController()
+
+	router.GET("/breakpoints", cultureResultsController.GetAntimicrobialBreakpoints)
+	router.POST("/breakpoints", cultureResultsController.CreateAntimicrobialBreakpoint)
+	router.GET("/:investigationResultId", cultureResultsController.GetCultureResult)
+	router.PUT("/:investigationResultId", cultureResultsController.UpdateCultureResult)
+}
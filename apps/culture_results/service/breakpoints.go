@@ -0,0 +1,154 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// validateSusceptibilities checks every susceptibility of the culture result against the breakpoint of its
+// standard for the organism, falling back to the breakpoint of the organism's family. The interpretation is
+// derived from the MIC value when left empty and must match it otherwise. Without a MIC value or a breakpoint the
+// interpretation is taken as sent.
+func (cultureResultsService *CultureResultsService) validateSusceptibilities(
+	cultureResult *commonModels.CultureResult) *commonStructures.CommonError {
+
+	if !commonUtils.SliceContainsString(commonConstants.BreakpointStandards, cultureResult.BreakpointStandard) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_BREAKPOINT_STANDARD,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	organismNames, antimicrobials := []string{}, []string{}
+	for _, organism := range cultureResult.Organisms {
+		organismNames = append(organismNames, organism.Name)
+		if organism.Family != "" {
+			organismNames = append(organismNames, organism.Family)
+		}
+		for _, susceptibility := range organism.Susceptibilities {
+			antimicrobials = append(antimicrobials, susceptibility.Antimicrobial)
+		}
+	}
+	if len(antimicrobials) == 0 {
+		return nil
+	}
+
+	breakpoints, cErr := cultureResultsService.CultureResultsDao.GetAntimicrobialBreakpoints("",
+		commonUtils.CreateUniqueSliceString(organismNames), commonUtils.CreateUniqueSliceString(antimicrobials))
+	if cErr != nil {
+		return cErr
+	}
+	breakpointsMap := map[string]commonModels.AntimicrobialBreakpoint{}
+	for _, breakpoint := range breakpoints {
+		breakpointsMap[getBreakpointKey(breakpoint.Standard, breakpoint.Organism, breakpoint.Antimicrobial)] = breakpoint
+	}
+
+	for organismIndex, organism := range cultureResult.Organisms {
+		for susceptibilityIndex, susceptibility := range organism.Susceptibilities {
+			if !commonUtils.SliceContainsString(commonConstants.BreakpointStandards, susceptibility.BreakpointStandard) {
+				return &commonStructures.CommonError{
+					Message:    commonConstants.ERROR_INVALID_BREAKPOINT_STANDARD,
+					StatusCode: http.StatusBadRequest,
+				}
+			}
+			if susceptibility.Interpretation != "" &&
+				!commonUtils.SliceContainsString(commonConstants.SusceptibilityInterpretations, susceptibility.Interpretation) {
+				return &commonStructures.CommonError{
+					Message:    fmt.Sprintf("%s: %s", commonConstants.ERROR_INVALID_SUSCEPTIBILITY_INTERPRETATION, susceptibility.Antimicrobial),
+					StatusCode: http.StatusBadRequest,
+				}
+			}
+
+			micValue, hasMicValue, err := parseMicValue(susceptibility.MicValue)
+			if err != nil {
+				return &commonStructures.CommonError{
+					Message:    fmt.Sprintf("%s: %s", commonConstants.ERROR_INVALID_MIC_VALUE, susceptibility.MicValue),
+					StatusCode: http.StatusBadRequest,
+				}
+			}
+
+			breakpoint, ok := breakpointsMap[getBreakpointKey(susceptibility.BreakpointStandard, organism.Name,
+				susceptibility.Antimicrobial)]
+			if !ok && organism.Family != "" {
+				breakpoint, ok = breakpointsMap[getBreakpointKey(susceptibility.BreakpointStandard, organism.Family,
+					susceptibility.Antimicrobial)]
+			}
+
+			if !hasMicValue || !ok {
+				if susceptibility.Interpretation == "" {
+					return &commonStructures.CommonError{
+						Message:    fmt.Sprintf("%s: %s", commonConstants.ERROR_INVALID_SUSCEPTIBILITY_INTERPRETATION, susceptibility.Antimicrobial),
+						StatusCode: http.StatusBadRequest,
+					}
+				}
+				continue
+			}
+
+			interpretation := interpretMicValue(micValue, breakpoint)
+			if susceptibility.Interpretation != "" && susceptibility.Interpretation != interpretation {
+				return &commonStructures.CommonError{
+					Message: fmt.Sprintf("%s: %s %s is %s under %s %s", commonConstants.ERROR_INTERPRETATION_DOES_NOT_MATCH_BREAKPOINT,
+						susceptibility.Antimicrobial, susceptibility.MicValue, interpretation, breakpoint.Standard,
+						breakpoint.Organism),
+					StatusCode: http.StatusBadRequest,
+				}
+			}
+			cultureResult.Organisms[organismIndex].Susceptibilities[susceptibilityIndex].Interpretation = interpretation
+		}
+	}
+
+	return nil
+}
+
+func validateAntimicrobialBreakpoint(breakpoint commonModels.AntimicrobialBreakpoint) *commonStructures.CommonError {
+	if !commonUtils.SliceContainsString(commonConstants.BreakpointStandards, breakpoint.Standard) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_BREAKPOINT_STANDARD,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if breakpoint.SusceptibleMaxMic < 0 || breakpoint.SusceptibleMaxMic > breakpoint.ResistantMinMic {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_ANTIMICROBIAL_BREAKPOINT,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+// parseMicValue reads MIC values as analyzers report them, e.g. "0.5", "<=0.25" or ">32". The comparator is
+// dropped, the bound itself is interpreted.
+func parseMicValue(micValue string) (float64, bool, error) {
+	micValue = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(micValue), "<>="))
+	if micValue == "" {
+		return 0, false, nil
+	}
+
+	value, err := strconv.ParseFloat(micValue, 64)
+	if err != nil || value < 0 {
+		return 0, false, fmt.Errorf("invalid mic value %q", micValue)
+	}
+	return value, true, nil
+}
+
+func interpretMicValue(micValue float64, breakpoint commonModels.AntimicrobialBreakpoint) string {
+	switch {
+	case micValue <= breakpoint.SusceptibleMaxMic:
+		return commonConstants.SUSCEPTIBILITY_SUSCEPTIBLE
+	case micValue >= breakpoint.ResistantMinMic:
+		return commonConstants.SUSCEPTIBILITY_RESISTANT
+	default:
+		return commonConstants.SUSCEPTIBILITY_INTERMEDIATE
+	}
+}
+
+func getBreakpointKey(standard, organism, antimicrobial string) string {
+	return strings.ToUpper(standard) + "|" + strings.ToLower(organism) + "|" + strings.ToLower(antimicrobial)
+}
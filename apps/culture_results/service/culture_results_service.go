@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/apps/culture_results/mapper"
+	"github.com/Orange-Health/citadel/apps/culture_results/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type CultureResultsServiceInterface interface {
+	GetCultureResult(investigationResultId uint) (structures.CultureResult, *commonStructures.CommonError)
+	GetCultureResultsByInvestigationResultIds(investigationResultIds []uint) (
+		map[uint]structures.CultureResult, *commonStructures.CommonError)
+	UpdateCultureResult(ctx context.Context, investigationResultId uint,
+		cultureResultRequest structures.CultureResultRequest, userId uint) (
+		structures.CultureResult, *commonStructures.CommonError)
+
+	GetAntimicrobialBreakpoints(standard, organism, antimicrobial string) (
+		[]structures.AntimicrobialBreakpoint, *commonStructures.CommonError)
+	CreateAntimicrobialBreakpoint(breakpointRequest structures.AntimicrobialBreakpointRequest, userId uint) (
+		structures.AntimicrobialBreakpoint, *commonStructures.CommonError)
+
+	SyncCultureResultsWithTx(ctx context.Context, tx *gorm.DB,
+		investigationData []commonModels.InvestigationData) *commonStructures.CommonError
+}
+
+func (cultureResultsService *CultureResultsService) GetCultureResult(investigationResultId uint) (
+	structures.CultureResult, *commonStructures.CommonError) {
+
+	cultureResult, cErr := cultureResultsService.getCultureResultModel(investigationResultId)
+	if cErr != nil {
+		return structures.CultureResult{}, cErr
+	}
+
+	return mapper.MapCultureResult(cultureResult), nil
+}
+
+func (cultureResultsService *CultureResultsService) GetCultureResultsByInvestigationResultIds(
+	investigationResultIds []uint) (map[uint]structures.CultureResult, *commonStructures.CommonError) {
+
+	investigationResultIdToCultureResultMap := map[uint]structures.CultureResult{}
+	if len(investigationResultIds) == 0 {
+		return investigationResultIdToCultureResultMap, nil
+	}
+
+	cultureResults, cErr := cultureResultsService.CultureResultsDao.GetCultureResultsByInvestigationResultIds(
+		investigationResultIds)
+	if cErr != nil {
+		return investigationResultIdToCultureResultMap, cErr
+	}
+
+	for _, cultureResult := range cultureResults {
+		investigationResultIdToCultureResultMap[cultureResult.InvestigationResultId] = mapper.MapCultureResult(cultureResult)
+	}
+	return investigationResultIdToCultureResultMap, nil
+}
+
+// UpdateCultureResult lets a pathologist correct the organisms and susceptibilities of a culture result until the
+// investigation is approved. Interpretations left empty are derived from the MIC value and those sent are checked
+// against the breakpoints.
+func (cultureResultsService *CultureResultsService) UpdateCultureResult(ctx context.Context,
+	investigationResultId uint, cultureResultRequest structures.CultureResultRequest, userId uint) (
+	structures.CultureResult, *commonStructures.CommonError) {
+
+	if cErr := cultureResultsService.validatePathologist(userId); cErr != nil {
+		return structures.CultureResult{}, cErr
+	}
+
+	investigationResults, cErr := cultureResultsService.InvestigationResultService.GetInvestigationsByInvestigationIds(
+		[]uint{investigationResultId})
+	if cErr != nil {
+		return structures.CultureResult{}, cErr
+	}
+	if len(investigationResults) == 0 {
+		return structures.CultureResult{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_CULTURE_RESULT_NOT_FOUND,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	if commonUtils.SliceContainsString(commonConstants.INVESTIGATION_STATUSES_APPROVE,
+		investigationResults[0].InvestigationStatus) {
+		return structures.CultureResult{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_CULTURE_RESULT_ALREADY_APPROVED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	cultureResult, cErr := cultureResultsService.getCultureResultModel(investigationResultId)
+	if cErr != nil {
+		return structures.CultureResult{}, cErr
+	}
+
+	cultureResult = mapper.MapCultureResultRequest(cultureResult, cultureResultRequest, userId)
+	if cErr := cultureResultsService.validateSusceptibilities(&cultureResult); cErr != nil {
+		return structures.CultureResult{}, cErr
+	}
+
+	investigationData, err := json.Marshal(mapper.MapCultureResultToTransformedResults(cultureResult))
+	if err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
+		return structures.CultureResult{}, &commonStructures.CommonError{
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	cultureResult, cErr = cultureResultsService.CultureResultsDao.UpdateCultureResult(cultureResult,
+		string(investigationData))
+	if cErr != nil {
+		return structures.CultureResult{}, cErr
+	}
+
+	return mapper.MapCultureResult(cultureResult), nil
+}
+
+func (cultureResultsService *CultureResultsService) GetAntimicrobialBreakpoints(standard, organism,
+	antimicrobial string) ([]structures.AntimicrobialBreakpoint, *commonStructures.CommonError) {
+
+	organisms, antimicrobials := []string{}, []string{}
+	if organism != "" {
+		organisms = append(organisms, organism)
+	}
+	if antimicrobial != "" {
+		antimicrobials = append(antimicrobials, antimicrobial)
+	}
+
+	breakpoints, cErr := cultureResultsService.CultureResultsDao.GetAntimicrobialBreakpoints(standard, organisms,
+		antimicrobials)
+	if cErr != nil {
+		return []structures.AntimicrobialBreakpoint{}, cErr
+	}
+
+	return mapper.MapAntimicrobialBreakpoints(breakpoints), nil
+}
+
+func (cultureResultsService *CultureResultsService) CreateAntimicrobialBreakpoint(
+	breakpointRequest structures.AntimicrobialBreakpointRequest, userId uint) (
+	structures.AntimicrobialBreakpoint, *commonStructures.CommonError) {
+
+	breakpoint := mapper.MapAntimicrobialBreakpointRequest(breakpointRequest, userId)
+	if cErr := validateAntimicrobialBreakpoint(breakpoint); cErr != nil {
+		return structures.AntimicrobialBreakpoint{}, cErr
+	}
+
+	existingBreakpoint, cErr := cultureResultsService.CultureResultsDao.GetAntimicrobialBreakpoint(
+		breakpoint.Standard, breakpoint.Organism, breakpoint.Antimicrobial)
+	if cErr != nil && cErr.StatusCode != http.StatusNotFound {
+		return structures.AntimicrobialBreakpoint{}, cErr
+	}
+	if existingBreakpoint.Id != 0 {
+		return structures.AntimicrobialBreakpoint{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_ANTIMICROBIAL_BREAKPOINT_ALREADY_EXISTS,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	breakpoint, cErr = cultureResultsService.CultureResultsDao.CreateAntimicrobialBreakpoint(breakpoint)
+	if cErr != nil {
+		return structures.AntimicrobialBreakpoint{}, cErr
+	}
+
+	return mapper.MapAntimicrobialBreakpoint(breakpoint), nil
+}
+
+// SyncCultureResultsWithTx rebuilds the culture results of the investigations whose data was ingested as a culture
+// result. LIS values are stored as reported, without breakpoint validation.
+func (cultureResultsService *CultureResultsService) SyncCultureResultsWithTx(ctx context.Context, tx *gorm.DB,
+	investigationData []commonModels.InvestigationData) *commonStructures.CommonError {
+
+	cultureResults := []commonModels.CultureResult{}
+	for _, data := range investigationData {
+		if data.DataType != commonConstants.CultureInvestigationValue || data.InvestigationResultId == 0 {
+			continue
+		}
+
+		transformedResults := commonStructures.TransformedInvestigationResults{}
+		if err := json.Unmarshal([]byte(data.Data), &transformedResults); err != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_SYNCING_CULTURE_RESULTS,
+				map[string]interface{}{
+					"investigation_result_id": data.InvestigationResultId,
+				}, err)
+			continue
+		}
+		cultureResults = append(cultureResults,
+			mapper.MapTransformedResultsToCultureResult(data.InvestigationResultId, transformedResults))
+	}
+
+	_, cErr := cultureResultsService.CultureResultsDao.ReplaceCultureResultsWithTx(tx, cultureResults)
+	return cErr
+}
+
+func (cultureResultsService *CultureResultsService) getCultureResultModel(investigationResultId uint) (
+	commonModels.CultureResult, *commonStructures.CommonError) {
+
+	cultureResults, cErr := cultureResultsService.CultureResultsDao.GetCultureResultsByInvestigationResultIds(
+		[]uint{investigationResultId})
+	if cErr != nil {
+		return commonModels.CultureResult{}, cErr
+	}
+	if len(cultureResults) == 0 {
+		return commonModels.CultureResult{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_CULTURE_RESULT_NOT_FOUND,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	return cultureResults[0], nil
+}
+
+func (cultureResultsService *CultureResultsService) validatePathologist(userId uint) *commonStructures.CommonError {
+	user, cErr := cultureResultsService.UserService.GetUserModel(userId)
+	if cErr != nil {
+		return cErr
+	}
+
+	if !commonUtils.SliceContainsString(
+		[]string{commonConstants.USER_TYPE_PATHOLOGIST, commonConstants.USER_TYPE_SUPER_ADMIN}, user.UserType) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_ONLY_PATHOLOGISTS_CAN_EDIT_CULTURE,
+			StatusCode: http.StatusForbidden,
+		}
+	}
+
+	return nil
+}
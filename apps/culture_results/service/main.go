@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/apps/culture_results/dao"
+	investigationResultsService "github.com/Orange-Health/citadel/apps/investigation_results/service"
+	userService "github.com/Orange-Health/citadel/apps/users/service"
+)
+
+type CultureResultsService struct {
+	CultureResultsDao          dao.DataLayer
+	InvestigationResultService investigationResultsService.InvestigationResultServiceInterface
+	UserService                userService.UserServiceInterface
+}
+
+func InitializeCultureResultsService() CultureResultsServiceInterface {
+	return &CultureResultsService{
+		CultureResultsDao:          dao.InitializeCultureResultsDao(),
+		InvestigationResultService: investigationResultsService.InitializeInvestigationResultService(),
+		UserService:                userService.InitializeUserService(),
+	}
+}
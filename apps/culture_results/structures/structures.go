@@ -0,0 +1,129 @@
+package structures
+
+import (
+	"time"
+
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+)
+
+// @swagger:model CultureResult
+type CultureResult struct {
+	// The culture result ID.
+	// example: 1
+	Id uint `json:"id"`
+	// The culture investigation the result belongs to.
+	// example: 101
+	InvestigationResultId uint `json:"investigation_result_id"`
+	// example: "Final"
+	ReportStatus string `json:"report_status"`
+	// example: "Urine"
+	SampleType string `json:"sample_type"`
+	// example: "48 hours"
+	IncubationPeriod string `json:"incubation_period"`
+	// example: "Gram negative bacilli seen"
+	StainResult  string                               `json:"stain_result"`
+	StainDetails []commonStructures.StainResultDetail `json:"stain_details"`
+	// example: "Growth of Escherichia coli"
+	CultureReport string `json:"culture_report"`
+	// example: ">10^5 CFU/ml"
+	ColonyCount string `json:"colony_count"`
+	// example: "ESBL"
+	ResistanceDetected string `json:"resistance_detected"`
+	ClinicalHistory    string `json:"clinical_history"`
+	Gross              string `json:"gross"`
+	// The standard the susceptibilities are interpreted against, CLSI or EUCAST.
+	// example: "CLSI"
+	BreakpointStandard string            `json:"breakpoint_standard"`
+	Organisms          []CultureOrganism `json:"organisms"`
+	// example: 12
+	UpdatedBy uint `json:"updated_by"`
+	// example: "2026-10-18T09:00:00Z"
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// @swagger:model CultureOrganism
+type CultureOrganism struct {
+	// example: 1
+	Id uint `json:"id"`
+	// example: "Escherichia coli"
+	Name string `json:"name"`
+	// example: "Enterobacterales"
+	Family string `json:"family"`
+	// example: ">10^5 CFU/ml"
+	ColonyCount string `json:"colony_count"`
+	// example: 1
+	DisplayOrder     int                     `json:"display_order"`
+	Susceptibilities []CultureSusceptibility `json:"susceptibilities"`
+}
+
+// @swagger:model CultureSusceptibility
+type CultureSusceptibility struct {
+	// example: 1
+	Id uint `json:"id"`
+	// example: "Ciprofloxacin"
+	Antimicrobial string `json:"antimicrobial"`
+	// The minimum inhibitory concentration as reported, optionally prefixed with a comparator.
+	// example: "<=0.25"
+	MicValue string `json:"mic_value"`
+	// S, I or R.
+	// example: "S"
+	Interpretation string `json:"interpretation"`
+	// example: "CLSI"
+	BreakpointStandard string `json:"breakpoint_standard"`
+	// example: 1
+	DisplayOrder int `json:"display_order"`
+}
+
+type CultureResultRequest struct {
+	BreakpointStandard string                   `json:"breakpoint_standard"`
+	CultureReport      string                   `json:"culture_report"`
+	ColonyCount        string                   `json:"colony_count"`
+	ResistanceDetected string                   `json:"resistance_detected"`
+	Organisms          []CultureOrganismRequest `json:"organisms" binding:"dive"`
+}
+
+type CultureOrganismRequest struct {
+	Name             string                         `json:"name" binding:"required"`
+	Family           string                         `json:"family"`
+	ColonyCount      string                         `json:"colony_count"`
+	Susceptibilities []CultureSusceptibilityRequest `json:"susceptibilities" binding:"dive"`
+}
+
+// CultureSusceptibilityRequest leaves out the interpretation to have it derived from the MIC value and the
+// breakpoint, and the breakpoint standard to use the one of the culture result.
+type CultureSusceptibilityRequest struct {
+	Antimicrobial      string `json:"antimicrobial" binding:"required"`
+	MicValue           string `json:"mic_value"`
+	Interpretation     string `json:"interpretation"`
+	BreakpointStandard string `json:"breakpoint_standard"`
+}
+
+// @swagger:model AntimicrobialBreakpoint
+type AntimicrobialBreakpoint struct {
+	// example: 1
+	Id uint `json:"id"`
+	// example: "CLSI"
+	Standard string `json:"standard"`
+	// example: "M100-Ed34"
+	Version string `json:"version"`
+	// The organism name, or the family for breakpoints shared by all its organisms.
+	// example: "Enterobacterales"
+	Organism string `json:"organism"`
+	// example: "Ciprofloxacin"
+	Antimicrobial string `json:"antimicrobial"`
+	// MIC values at or below this are susceptible.
+	// example: 0.25
+	SusceptibleMaxMic float64 `json:"susceptible_max_mic"`
+	// MIC values at or above this are resistant.
+	// example: 1
+	ResistantMinMic float64 `json:"resistant_min_mic"`
+}
+
+type AntimicrobialBreakpointRequest struct {
+	Standard          string  `json:"standard" binding:"required"`
+	Version           string  `json:"version"`
+	Organism          string  `json:"organism" binding:"required"`
+	Antimicrobial     string  `json:"antimicrobial" binding:"required"`
+	SusceptibleMaxMic float64 `json:"susceptible_max_mic"`
+	ResistantMinMic   float64 `json:"resistant_min_mic"`
+}
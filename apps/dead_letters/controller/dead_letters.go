@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/dead_letters/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Dead Letter Events
+// @Description	Get the consumer events that could not be processed, most recent first
+// @Tags			dead-letters
+// @Produce		json
+// @Param			status		query		string							false	"Status"
+// @Param			event_type	query		string							false	"Event Type"
+// @Param			trace_id	query		string							false	"Trace ID"
+// @Param			limit		query		int								false	"Limit"
+// @Param			offset		query		int								false	"Offset"
+// @Success		200			{object}	[]structures.DeadLetterEvent	"Dead Letter Events"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/dead-letters/events [get]
+func (deadLetterController *DeadLetter) GetDeadLetterEvents(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	filter := structures.DeadLetterEventsFilter{
+		Status:    c.Query("status"),
+		EventType: c.Query("event_type"),
+		TraceId:   c.Query("trace_id"),
+		Limit:     limit,
+		Offset:    offset,
+	}
+
+	deadLetterEvents, cErr := deadLetterController.DeadLetterService.GetDeadLetterEvents(filter)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, deadLetterEvents)
+}
+
+// @Summary		Get Dead Letter Event
+// @Description	Get a dead letter event along with its payload and the error of its last attempt
+// @Tags			dead-letters
+// @Produce		json
+// @Param			eventId		path		int								true	"Dead Letter Event ID"
+// @Success		200			{object}	structures.DeadLetterEvent		"Dead Letter Event"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/dead-letters/events/{eventId} [get]
+func (deadLetterController *DeadLetter) GetDeadLetterEvent(c *gin.Context) {
+	deadLetterEventId := commonUtils.ConvertStringToUint(c.Param("eventId"))
+	if deadLetterEventId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_DEAD_LETTER_EVENT_ID)
+		return
+	}
+
+	deadLetterEvent, cErr := deadLetterController.DeadLetterService.GetDeadLetterEvent(deadLetterEventId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, deadLetterEvent)
+}
+
+// @Summary		Update Dead Letter Event
+// @Description	Fix the payload of a failed event before replaying it, only allowed for super admins
+// @Tags			dead-letters
+// @Accept			json
+// @Produce		json
+// @Param			eventId				path		int										true	"Dead Letter Event ID"
+// @Param			event				body		structures.UpdateDeadLetterEventRequest	true	"Event"
+// @Success		200					{object}	structures.DeadLetterEvent				"Dead Letter Event"
+// @Failure		400,403,404,409,500	{object}	structures.CommonAPIResponse			"Common API Response"
+// @Router			/api/v1/dead-letters/events/{eventId} [patch]
+func (deadLetterController *DeadLetter) UpdateDeadLetterEvent(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	deadLetterEventId := commonUtils.ConvertStringToUint(c.Param("eventId"))
+	if deadLetterEventId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_DEAD_LETTER_EVENT_ID)
+		return
+	}
+
+	updateRequest := structures.UpdateDeadLetterEventRequest{}
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deadLetterEvent, cErr := deadLetterController.DeadLetterService.UpdateDeadLetterEvent(deadLetterEventId,
+		updateRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, deadLetterEvent)
+}
+
+// @Summary		Replay Dead Letter Event
+// @Description	Queue a failed event on the worker to be processed again by its event handler, only allowed for
+// @Description	super admins. The outcome of the replay is recorded on the event.
+// @Tags			dead-letters
+// @Produce		json
+// @Param			eventId				path		int								true	"Dead Letter Event ID"
+// @Success		202					{object}	structures.DeadLetterEvent		"Dead Letter Event"
+// @Failure		400,403,404,409,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/dead-letters/events/{eventId}/replay [post]
+func (deadLetterController *DeadLetter) ReplayDeadLetterEvent(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	deadLetterEventId := commonUtils.ConvertStringToUint(c.Param("eventId"))
+	if deadLetterEventId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_DEAD_LETTER_EVENT_ID)
+		return
+	}
+
+	deadLetterEvent, cErr := deadLetterController.DeadLetterWorkerService.ReplayDeadLetterEvent(
+		c.Request.Context(), deadLetterEventId, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, deadLetterEvent)
+}
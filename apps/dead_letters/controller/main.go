@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/dead_letters/service"
+	workerService "github.com/Orange-Health/citadel/apps/dead_letters/worker_service"
+)
+
+type DeadLetter struct {
+	DeadLetterService       service.DeadLetterServiceInterface
+	DeadLetterWorkerService workerService.DeadLetterWorkerServiceInterface
+}
+
+func InitDeadLetterController() *DeadLetter {
+	return &DeadLetter{
+		DeadLetterService:       service.InitializeDeadLetterService(),
+		DeadLetterWorkerService: workerService.InitializeWorkerService(),
+	}
+}
@@ -0,0 +1,107 @@
+package dao
+
+import (
+	"github.com/Orange-Health/citadel/apps/dead_letters/structures"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetDeadLetterEvents(filter structures.DeadLetterEventsFilter) (
+		[]commonModels.DeadLetterEvent, *commonStructures.CommonError)
+	GetDeadLetterEventById(deadLetterEventId uint) (commonModels.DeadLetterEvent, *commonStructures.CommonError)
+
+	CreateDeadLetterEvent(deadLetterEvent commonModels.DeadLetterEvent) (
+		commonModels.DeadLetterEvent, *commonStructures.CommonError)
+	UpdateDeadLetterEvent(deadLetterEvent commonModels.DeadLetterEvent) *commonStructures.CommonError
+	UpdateDeadLetterEventStatus(deadLetterEventId uint, fromStatus, toStatus string, userId uint) (
+		bool, *commonStructures.CommonError)
+}
+
+func (deadLetterDao *DeadLetterDao) GetDeadLetterEvents(filter structures.DeadLetterEventsFilter) (
+	[]commonModels.DeadLetterEvent, *commonStructures.CommonError) {
+
+	deadLetterEvents := []commonModels.DeadLetterEvent{}
+	query := deadLetterDao.Db.Model(&commonModels.DeadLetterEvent{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.TraceId != "" {
+		query = query.Where("trace_id = ?", filter.TraceId)
+	}
+
+	if err := query.Order("id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&deadLetterEvents).
+		Error; err != nil {
+		return deadLetterEvents, commonUtils.HandleORMError(err)
+	}
+
+	return deadLetterEvents, nil
+}
+
+func (deadLetterDao *DeadLetterDao) GetDeadLetterEventById(deadLetterEventId uint) (
+	commonModels.DeadLetterEvent, *commonStructures.CommonError) {
+
+	deadLetterEvent := commonModels.DeadLetterEvent{}
+	if err := deadLetterDao.Db.Where("id = ?", deadLetterEventId).First(&deadLetterEvent).Error; err != nil {
+		return deadLetterEvent, commonUtils.HandleORMError(err)
+	}
+
+	return deadLetterEvent, nil
+}
+
+func (deadLetterDao *DeadLetterDao) CreateDeadLetterEvent(deadLetterEvent commonModels.DeadLetterEvent) (
+	commonModels.DeadLetterEvent, *commonStructures.CommonError) {
+
+	if err := deadLetterDao.Db.Create(&deadLetterEvent).Error; err != nil {
+		return deadLetterEvent, commonUtils.HandleORMError(err)
+	}
+
+	return deadLetterEvent, nil
+}
+
+func (deadLetterDao *DeadLetterDao) UpdateDeadLetterEvent(
+	deadLetterEvent commonModels.DeadLetterEvent) *commonStructures.CommonError {
+
+	updates := map[string]interface{}{
+		"event_payload": deadLetterEvent.EventPayload,
+		"error":         deadLetterEvent.Error,
+		"attempts":      deadLetterEvent.Attempts,
+		"status":        deadLetterEvent.Status,
+		"replayed_at":   deadLetterEvent.ReplayedAt,
+		"replayed_by":   deadLetterEvent.ReplayedBy,
+		"updated_by":    deadLetterEvent.UpdatedBy,
+		"updated_at":    commonUtils.GetCurrentTime(),
+	}
+
+	if err := deadLetterDao.Db.Model(&commonModels.DeadLetterEvent{}).Where("id = ?", deadLetterEvent.Id).
+		Updates(updates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+// UpdateDeadLetterEventStatus moves an event from one status to another, reporting whether it was still in the
+// status it is moved from. This keeps two replays of the same event from being queued at once.
+func (deadLetterDao *DeadLetterDao) UpdateDeadLetterEventStatus(deadLetterEventId uint, fromStatus,
+	toStatus string, userId uint) (bool, *commonStructures.CommonError) {
+
+	updates := map[string]interface{}{
+		"status":     toStatus,
+		"updated_by": userId,
+		"updated_at": commonUtils.GetCurrentTime(),
+	}
+
+	result := deadLetterDao.Db.Model(&commonModels.DeadLetterEvent{}).
+		Where("id = ? AND status = ?", deadLetterEventId, fromStatus).
+		Updates(updates)
+	if result.Error != nil {
+		return false, commonUtils.HandleORMError(result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type DeadLetterDao struct {
+	Db *gorm.DB
+}
+
+func InitializeDeadLetterDao() DataLayer {
+	return &DeadLetterDao{
+		Db: psql.GetDbInstance(),
+	}
+}
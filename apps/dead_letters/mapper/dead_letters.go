@@ -0,0 +1,51 @@
+package mapper
+
+import (
+	"github.com/Orange-Health/citadel/apps/dead_letters/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapDeadLetterEvent(deadLetterEvent commonModels.DeadLetterEvent) structures.DeadLetterEvent {
+	return structures.DeadLetterEvent{
+		Id:           deadLetterEvent.Id,
+		EventType:    deadLetterEvent.EventType,
+		TraceId:      deadLetterEvent.TraceId,
+		Contains:     deadLetterEvent.Contains,
+		EventKey:     deadLetterEvent.EventKey,
+		EventPayload: deadLetterEvent.EventPayload,
+		Error:        deadLetterEvent.Error,
+		Attempts:     deadLetterEvent.Attempts,
+		Status:       deadLetterEvent.Status,
+		ReplayedAt:   deadLetterEvent.ReplayedAt,
+		ReplayedBy:   deadLetterEvent.ReplayedBy,
+		CreatedAt:    deadLetterEvent.CreatedAt,
+		UpdatedAt:    deadLetterEvent.UpdatedAt,
+	}
+}
+
+func MapDeadLetterEvents(deadLetterEvents []commonModels.DeadLetterEvent) []structures.DeadLetterEvent {
+	deadLetterEventsResponse := []structures.DeadLetterEvent{}
+	for _, deadLetterEvent := range deadLetterEvents {
+		deadLetterEventsResponse = append(deadLetterEventsResponse, MapDeadLetterEvent(deadLetterEvent))
+	}
+	return deadLetterEventsResponse
+}
+
+func MapNewDeadLetterEvent(eventType, traceId, contains, eventKey, eventPayload, eventError string,
+	attempts uint) commonModels.DeadLetterEvent {
+
+	deadLetterEvent := commonModels.DeadLetterEvent{
+		EventType:    eventType,
+		TraceId:      traceId,
+		Contains:     contains,
+		EventKey:     eventKey,
+		EventPayload: eventPayload,
+		Error:        eventError,
+		Attempts:     attempts,
+		Status:       commonConstants.DEAD_LETTER_STATUS_FAILED,
+	}
+	deadLetterEvent.CreatedBy = commonConstants.CitadelSystemId
+	deadLetterEvent.UpdatedBy = commonConstants.CitadelSystemId
+	return deadLetterEvent
+}
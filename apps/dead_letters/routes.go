@@ -0,0 +1,16 @@
+package deadLetters
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/dead_letters/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	deadLetterController := controller.InitDeadLetterController()
+
+	router.GET("/events", deadLetterController.GetDeadLetterEvents)
+	router.GET("/events/:eventId", deadLetterController.GetDeadLetterEvent)
+	router.PATCH("/events/:eventId", deadLetterController.UpdateDeadLetterEvent)
+	router.POST("/events/:eventId/replay", deadLetterController.ReplayDeadLetterEvent)
+}
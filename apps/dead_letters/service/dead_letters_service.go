@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Orange-Health/citadel/apps/dead_letters/mapper"
+	"github.com/Orange-Health/citadel/apps/dead_letters/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+type DeadLetterServiceInterface interface {
+	RecordDeadLetterEvent(ctx context.Context, eventType, traceId, contains, eventKey, eventPayload string,
+		attempts uint, eventErr error)
+	GetDeadLetterEvents(filter structures.DeadLetterEventsFilter) (
+		[]structures.DeadLetterEvent, *commonStructures.CommonError)
+	GetDeadLetterEvent(deadLetterEventId uint) (structures.DeadLetterEvent, *commonStructures.CommonError)
+	UpdateDeadLetterEvent(deadLetterEventId uint, updateRequest structures.UpdateDeadLetterEventRequest,
+		userId uint) (structures.DeadLetterEvent, *commonStructures.CommonError)
+	StartDeadLetterEventReplay(deadLetterEventId, userId uint) (
+		structures.DeadLetterEvent, *commonStructures.CommonError)
+	FinishDeadLetterEventReplay(ctx context.Context, deadLetterEventId uint,
+		replayErr error) *commonStructures.CommonError
+}
+
+// RecordDeadLetterEvent stores an event that could not be processed, along with the error of its last attempt,
+// so that it can be inspected and replayed later. It never fails the caller, a failure to store the event is
+// only reported.
+func (deadLetterService *DeadLetterService) RecordDeadLetterEvent(ctx context.Context, eventType, traceId,
+	contains, eventKey, eventPayload string, attempts uint, eventErr error) {
+
+	eventError := ""
+	if eventErr != nil {
+		eventError = eventErr.Error()
+	}
+
+	loggingAttributes := map[string]interface{}{
+		"event_type": eventType,
+		"trace_id":   traceId,
+		"contains":   contains,
+		"event_key":  eventKey,
+		"attempts":   attempts,
+	}
+
+	deadLetterEvent, cErr := deadLetterService.DeadLetterDao.CreateDeadLetterEvent(
+		mapper.MapNewDeadLetterEvent(eventType, traceId, contains, eventKey, eventPayload, eventError, attempts))
+	if cErr != nil {
+		loggingAttributes["event_payload"] = eventPayload
+		deadLetterService.Sentry.LogError(ctx, commonConstants.ERROR_WHILE_WRITING_DEAD_LETTER_EVENT,
+			errors.New(cErr.Message), loggingAttributes)
+		return
+	}
+
+	loggingAttributes["dead_letter_event_id"] = deadLetterEvent.Id
+	commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), loggingAttributes,
+		eventErr)
+}
+
+func (deadLetterService *DeadLetterService) GetDeadLetterEvents(filter structures.DeadLetterEventsFilter) (
+	[]structures.DeadLetterEvent, *commonStructures.CommonError) {
+
+	if filter.Status != "" && !commonUtils.SliceContainsString(commonConstants.DEAD_LETTER_STATUSES,
+		filter.Status) {
+		return []structures.DeadLetterEvent{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_DEAD_LETTER_STATUS,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = commonConstants.DeadLetterDefaultListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	deadLetterEvents, cErr := deadLetterService.DeadLetterDao.GetDeadLetterEvents(filter)
+	if cErr != nil {
+		return []structures.DeadLetterEvent{}, cErr
+	}
+
+	return mapper.MapDeadLetterEvents(deadLetterEvents), nil
+}
+
+func (deadLetterService *DeadLetterService) GetDeadLetterEvent(deadLetterEventId uint) (
+	structures.DeadLetterEvent, *commonStructures.CommonError) {
+
+	deadLetterEvent, cErr := deadLetterService.DeadLetterDao.GetDeadLetterEventById(deadLetterEventId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_DEAD_LETTER_EVENT_NOT_FOUND
+		}
+		return structures.DeadLetterEvent{}, cErr
+	}
+
+	return mapper.MapDeadLetterEvent(deadLetterEvent), nil
+}
+
+// UpdateDeadLetterEvent replaces the payload of a failed event, so that an event that failed on bad data can be
+// fixed before it is replayed. Only super admins can edit events.
+func (deadLetterService *DeadLetterService) UpdateDeadLetterEvent(deadLetterEventId uint,
+	updateRequest structures.UpdateDeadLetterEventRequest, userId uint) (
+	structures.DeadLetterEvent, *commonStructures.CommonError) {
+
+	if cErr := deadLetterService.validateSuperAdmin(userId); cErr != nil {
+		return structures.DeadLetterEvent{}, cErr
+	}
+
+	if strings.TrimSpace(updateRequest.EventPayload) == "" {
+		return structures.DeadLetterEvent{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_DEAD_LETTER_EVENT_PAYLOAD_EMPTY,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	deadLetterEvent, cErr := deadLetterService.DeadLetterDao.GetDeadLetterEventById(deadLetterEventId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_DEAD_LETTER_EVENT_NOT_FOUND
+		}
+		return structures.DeadLetterEvent{}, cErr
+	}
+	if deadLetterEvent.Status != commonConstants.DEAD_LETTER_STATUS_FAILED {
+		return structures.DeadLetterEvent{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_DEAD_LETTER_EVENT_NOT_FAILED,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	deadLetterEvent.EventPayload = updateRequest.EventPayload
+	deadLetterEvent.UpdatedBy = userId
+	if cErr := deadLetterService.DeadLetterDao.UpdateDeadLetterEvent(deadLetterEvent); cErr != nil {
+		return structures.DeadLetterEvent{}, cErr
+	}
+
+	return deadLetterService.GetDeadLetterEvent(deadLetterEventId)
+}
+
+// StartDeadLetterEventReplay marks a failed event as replaying, before it is queued on the worker. Only super
+// admins can replay events.
+func (deadLetterService *DeadLetterService) StartDeadLetterEventReplay(deadLetterEventId, userId uint) (
+	structures.DeadLetterEvent, *commonStructures.CommonError) {
+
+	if cErr := deadLetterService.validateSuperAdmin(userId); cErr != nil {
+		return structures.DeadLetterEvent{}, cErr
+	}
+
+	deadLetterEvent, cErr := deadLetterService.DeadLetterDao.GetDeadLetterEventById(deadLetterEventId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_DEAD_LETTER_EVENT_NOT_FOUND
+		}
+		return structures.DeadLetterEvent{}, cErr
+	}
+	if strings.TrimSpace(deadLetterEvent.EventPayload) == "" {
+		return structures.DeadLetterEvent{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_DEAD_LETTER_EVENT_PAYLOAD_EMPTY,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	isUpdated, cErr := deadLetterService.DeadLetterDao.UpdateDeadLetterEventStatus(deadLetterEventId,
+		commonConstants.DEAD_LETTER_STATUS_FAILED, commonConstants.DEAD_LETTER_STATUS_REPLAYING, userId)
+	if cErr != nil {
+		return structures.DeadLetterEvent{}, cErr
+	}
+	if !isUpdated {
+		return structures.DeadLetterEvent{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_DEAD_LETTER_EVENT_NOT_FAILED,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	return deadLetterService.GetDeadLetterEvent(deadLetterEventId)
+}
+
+// FinishDeadLetterEventReplay records the outcome of a replay. An event that fails again goes back to failed
+// with the new error, so that it can be fixed and replayed once more.
+func (deadLetterService *DeadLetterService) FinishDeadLetterEventReplay(ctx context.Context,
+	deadLetterEventId uint, replayErr error) *commonStructures.CommonError {
+
+	deadLetterEvent, cErr := deadLetterService.DeadLetterDao.GetDeadLetterEventById(deadLetterEventId)
+	if cErr != nil {
+		return cErr
+	}
+
+	// The user who queued the replay is the last one to have updated the event.
+	replayedBy := deadLetterEvent.UpdatedBy
+	deadLetterEvent.Attempts++
+	deadLetterEvent.UpdatedBy = commonConstants.CitadelSystemId
+	if replayErr != nil {
+		deadLetterEvent.Status = commonConstants.DEAD_LETTER_STATUS_FAILED
+		deadLetterEvent.Error = replayErr.Error()
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_REPLAYING_DEAD_LETTER_EVENT,
+			map[string]interface{}{
+				"dead_letter_event_id": deadLetterEvent.Id,
+				"event_type":           deadLetterEvent.EventType,
+				"attempts":             deadLetterEvent.Attempts,
+			}, replayErr)
+	} else {
+		deadLetterEvent.Status = commonConstants.DEAD_LETTER_STATUS_REPLAYED
+		deadLetterEvent.ReplayedAt = commonUtils.GetCurrentTime()
+		deadLetterEvent.ReplayedBy = replayedBy
+	}
+
+	return deadLetterService.DeadLetterDao.UpdateDeadLetterEvent(deadLetterEvent)
+}
+
+func (deadLetterService *DeadLetterService) validateSuperAdmin(userId uint) *commonStructures.CommonError {
+	user, cErr := deadLetterService.UserService.GetUserModel(userId)
+	if cErr != nil {
+		return cErr
+	}
+	if user.UserType != commonConstants.USER_TYPE_SUPER_ADMIN {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_DEAD_LETTER_NOT_ALLOWED,
+			StatusCode: http.StatusForbidden,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/apps/dead_letters/dao"
+	userService "github.com/Orange-Health/citadel/apps/users/service"
+)
+
+type DeadLetterService struct {
+	DeadLetterDao dao.DataLayer
+	Sentry        sentry.SentryLayer
+	UserService   userService.UserServiceInterface
+}
+
+func InitializeDeadLetterService() DeadLetterServiceInterface {
+	return &DeadLetterService{
+		DeadLetterDao: dao.InitializeDeadLetterDao(),
+		Sentry:        sentry.InitializeSentry(),
+		UserService:   userService.InitializeUserService(),
+	}
+}
@@ -0,0 +1,52 @@
+package structures
+
+import (
+	"time"
+)
+
+// @swagger:model DeadLetterEvent
+type DeadLetterEvent struct {
+	// The dead letter event ID.
+	// example: 1
+	Id uint `json:"id"`
+	// example: "order.updated"
+	EventType string `json:"event_type"`
+	// example: "5f0e4c1a-8a9b-4c57-9a43-2a3c6d1b7e10"
+	TraceId string `json:"trace_id"`
+	// example: "order"
+	Contains string `json:"contains"`
+	// The key the payload was cached under when the event was handed to the worker.
+	// example: "order.updated_1760781600000000000"
+	EventKey string `json:"event_key"`
+	// The payload as it is passed to the event handler.
+	// example: "{\"order\": {\"id\": 1234567}}"
+	EventPayload string `json:"event_payload"`
+	// The error of the last attempt.
+	// example: "record not found"
+	Error string `json:"error"`
+	// example: 4
+	Attempts uint `json:"attempts"`
+	// One of failed, replaying and replayed.
+	// example: "failed"
+	Status string `json:"status"`
+	// example: null
+	ReplayedAt *time.Time `json:"replayed_at"`
+	// example: 0
+	ReplayedBy uint `json:"replayed_by"`
+	// example: "2026-10-18T09:58:00Z"
+	CreatedAt *time.Time `json:"created_at"`
+	// example: "2026-10-18T09:58:00Z"
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type UpdateDeadLetterEventRequest struct {
+	EventPayload string `json:"event_payload" binding:"required"`
+}
+
+type DeadLetterEventsFilter struct {
+	Status    string
+	EventType string
+	TraceId   string
+	Limit     int
+	Offset    int
+}
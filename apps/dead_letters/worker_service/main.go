@@ -0,0 +1,18 @@
+package workerService
+
+import (
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/apps/dead_letters/service"
+)
+
+type DeadLetterWorkerService struct {
+	Sentry            sentry.SentryLayer
+	DeadLetterService service.DeadLetterServiceInterface
+}
+
+func InitializeWorkerService() DeadLetterWorkerServiceInterface {
+	return &DeadLetterWorkerService{
+		Sentry:            sentry.InitializeSentry(),
+		DeadLetterService: service.InitializeDeadLetterService(),
+	}
+}
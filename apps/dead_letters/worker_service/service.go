@@ -0,0 +1,53 @@
+package workerService
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Orange-Health/citadel/apps/dead_letters/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	"github.com/Orange-Health/citadel/worker"
+	workerTasks "github.com/Orange-Health/citadel/worker_tasks"
+)
+
+type DeadLetterWorkerServiceInterface interface {
+	ReplayDeadLetterEvent(ctx context.Context, deadLetterEventId, userId uint) (
+		structures.DeadLetterEvent, *commonStructures.CommonError)
+}
+
+// ReplayDeadLetterEvent queues a failed event on the worker, where it goes through the same handlers as the
+// events coming from the queue. An event that cannot be queued is put back to failed.
+func (ws *DeadLetterWorkerService) ReplayDeadLetterEvent(ctx context.Context, deadLetterEventId, userId uint) (
+	structures.DeadLetterEvent, *commonStructures.CommonError) {
+
+	deadLetterEvent, cErr := ws.DeadLetterService.StartDeadLetterEventReplay(deadLetterEventId, userId)
+	if cErr != nil {
+		return structures.DeadLetterEvent{}, cErr
+	}
+
+	server, err := worker.StartServer(true)
+	if err == nil {
+		_, err = server.SendTask(workerTasks.ReplayDeadLetterEventTaskSignature(deadLetterEventId))
+	}
+	if err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(),
+			map[string]interface{}{
+				"error_message":        commonConstants.ERROR_WHILE_QUEUEING_DEAD_LETTER_REPLAY,
+				"dead_letter_event_id": deadLetterEventId,
+			}, err)
+		if cErr := ws.DeadLetterService.FinishDeadLetterEventReplay(ctx, deadLetterEventId,
+			errors.New(commonConstants.ERROR_WHILE_QUEUEING_DEAD_LETTER_REPLAY)); cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
+				errors.New(cErr.Message))
+		}
+		return structures.DeadLetterEvent{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_WHILE_QUEUEING_DEAD_LETTER_REPLAY,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	return deadLetterEvent, nil
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/delta_check/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructs "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Delta Check Rules
+// @Description	Get Delta Check Rules, optionally filtered by master investigation id
+// @Tags			delta-check
+// @Produce		json
+// @Param			master_investigation_id	query		int							false	"Master Investigation ID"
+// @Success		200						{object}	[]structures.DeltaCheckRule	"Delta Check Rules"
+// @Failure		400,404,500				{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/delta-check/rules [get]
+func (deltaCheckController *DeltaCheck) GetDeltaCheckRules(c *gin.Context) {
+	masterInvestigationId := commonUtils.ConvertStringToUint(c.Query("master_investigation_id"))
+
+	rules, cErr := deltaCheckController.DeltaCheckService.GetDeltaCheckRules(masterInvestigationId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Summary		Create Delta Check Rule
+// @Description	Create a Delta Check Rule for a master investigation
+// @Tags			delta-check
+// @Accept			json
+// @Produce		json
+// @Param			rule		body		structures.DeltaCheckRuleRequest	true	"Delta Check Rule"
+// @Success		200			{object}	structures.DeltaCheckRule			"Delta Check Rule"
+// @Failure		400,409,500	{object}	structures.CommonAPIResponse		"Common API Response"
+// @Router			/api/v1/delta-check/rules [post]
+func (deltaCheckController *DeltaCheck) CreateDeltaCheckRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleRequest := structures.DeltaCheckRuleRequest{}
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, cErr := deltaCheckController.DeltaCheckService.CreateDeltaCheckRule(c.Request.Context(), ruleRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary		Update Delta Check Rule
+// @Description	Update a Delta Check Rule
+// @Tags			delta-check
+// @Accept			json
+// @Produce		json
+// @Param			ruleId			path		int									true	"Delta Check Rule ID"
+// @Param			rule			body		structures.DeltaCheckRuleRequest	true	"Delta Check Rule"
+// @Success		200				{object}	structures.DeltaCheckRule			"Delta Check Rule"
+// @Failure		400,404,409,500	{object}	structures.CommonAPIResponse		"Common API Response"
+// @Router			/api/v1/delta-check/rules/{ruleId} [put]
+func (deltaCheckController *DeltaCheck) UpdateDeltaCheckRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleId := commonUtils.ConvertStringToUint(c.Param("ruleId"))
+	if ruleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_DELTA_CHECK_RULE_ID)
+		return
+	}
+
+	ruleRequest := structures.DeltaCheckRuleRequest{}
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, cErr := deltaCheckController.DeltaCheckService.UpdateDeltaCheckRule(c.Request.Context(), ruleId,
+		ruleRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary		Delete Delta Check Rule
+// @Description	Delete a Delta Check Rule
+// @Tags			delta-check
+// @Produce		json
+// @Param			ruleId		path		int								true	"Delta Check Rule ID"
+// @Success		200			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/delta-check/rules/{ruleId} [delete]
+func (deltaCheckController *DeltaCheck) DeleteDeltaCheckRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleId := commonUtils.ConvertStringToUint(c.Param("ruleId"))
+	if ruleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_DELTA_CHECK_RULE_ID)
+		return
+	}
+
+	cErr = deltaCheckController.DeltaCheckService.DeleteDeltaCheckRule(c.Request.Context(), ruleId, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructs.CommonAPIResponse{
+		Message: commonConstants.DONE_RESPONSE,
+	})
+}
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/delta_check/service"
+)
+
+type DeltaCheck struct {
+	DeltaCheckService service.DeltaCheckServiceInterface
+}
+
+func InitDeltaCheckController() *DeltaCheck {
+	return &DeltaCheck{
+		DeltaCheckService: service.InitializeDeltaCheckService(),
+	}
+}
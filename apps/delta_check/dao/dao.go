@@ -0,0 +1,134 @@
+package dao
+
+import (
+	"github.com/Orange-Health/citadel/apps/delta_check/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetDeltaCheckRuleById(ruleId uint) (commonModels.DeltaCheckRule, *commonStructures.CommonError)
+	GetDeltaCheckRules(masterInvestigationId uint) ([]commonModels.DeltaCheckRule, *commonStructures.CommonError)
+	GetActiveDeltaCheckRules() ([]commonModels.DeltaCheckRule, *commonStructures.CommonError)
+	GetDeltaCheckRuleByInvestigationAndType(masterInvestigationId uint, ruleType string) (
+		commonModels.DeltaCheckRule, *commonStructures.CommonError)
+	GetTaskPatientDetails(taskId uint) (structures.TaskPatientDetails, *commonStructures.CommonError)
+
+	CreateDeltaCheckRule(rule commonModels.DeltaCheckRule) (commonModels.DeltaCheckRule, *commonStructures.CommonError)
+	UpdateDeltaCheckRule(rule commonModels.DeltaCheckRule) (commonModels.DeltaCheckRule, *commonStructures.CommonError)
+	DeleteDeltaCheckRule(ruleId, userId uint) *commonStructures.CommonError
+}
+
+func (deltaCheckDao *DeltaCheckDao) GetDeltaCheckRuleById(ruleId uint) (
+	commonModels.DeltaCheckRule, *commonStructures.CommonError) {
+
+	rule := commonModels.DeltaCheckRule{}
+	if err := deltaCheckDao.Db.Where("id = ?", ruleId).First(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (deltaCheckDao *DeltaCheckDao) GetDeltaCheckRules(masterInvestigationId uint) (
+	[]commonModels.DeltaCheckRule, *commonStructures.CommonError) {
+
+	rules := []commonModels.DeltaCheckRule{}
+	query := deltaCheckDao.Db
+	if masterInvestigationId != 0 {
+		query = query.Where("master_investigation_id = ?", masterInvestigationId)
+	}
+	if err := query.Order("master_investigation_id, id").Find(&rules).Error; err != nil {
+		return rules, commonUtils.HandleORMError(err)
+	}
+
+	return rules, nil
+}
+
+func (deltaCheckDao *DeltaCheckDao) GetActiveDeltaCheckRules() (
+	[]commonModels.DeltaCheckRule, *commonStructures.CommonError) {
+
+	rules := []commonModels.DeltaCheckRule{}
+	if err := deltaCheckDao.Db.Where("is_active = ?", true).Find(&rules).Error; err != nil {
+		return rules, commonUtils.HandleORMError(err)
+	}
+
+	return rules, nil
+}
+
+func (deltaCheckDao *DeltaCheckDao) GetDeltaCheckRuleByInvestigationAndType(masterInvestigationId uint,
+	ruleType string) (commonModels.DeltaCheckRule, *commonStructures.CommonError) {
+
+	rule := commonModels.DeltaCheckRule{}
+	if err := deltaCheckDao.Db.Where("master_investigation_id = ?", masterInvestigationId).
+		Where("rule_type = ?", ruleType).First(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (deltaCheckDao *DeltaCheckDao) GetTaskPatientDetails(taskId uint) (
+	structures.TaskPatientDetails, *commonStructures.CommonError) {
+
+	taskPatientDetails := structures.TaskPatientDetails{}
+	selectStrings := []string{
+		"tasks.lab_id as lab_id",
+		"tasks.city_code as city_code",
+		"patient_details.system_patient_id as system_patient_id",
+		"patient_details.dob as patient_dob",
+		"patient_details.expected_dob as patient_expected_dob",
+		"patient_details.gender as patient_gender",
+		"patient_details.is_pregnant as patient_is_pregnant",
+		"patient_details.trimester as patient_trimester",
+	}
+
+	if err := deltaCheckDao.Db.Table(commonConstants.TableTasks).
+		Joins("INNER JOIN patient_details ON patient_details.id = tasks.patient_details_id").
+		Select(selectStrings).
+		Where("tasks.id = ?", taskId).
+		Scan(&taskPatientDetails).Error; err != nil {
+		return taskPatientDetails, commonUtils.HandleORMError(err)
+	}
+
+	return taskPatientDetails, nil
+}
+
+func (deltaCheckDao *DeltaCheckDao) CreateDeltaCheckRule(rule commonModels.DeltaCheckRule) (
+	commonModels.DeltaCheckRule, *commonStructures.CommonError) {
+
+	if err := deltaCheckDao.Db.Create(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (deltaCheckDao *DeltaCheckDao) UpdateDeltaCheckRule(rule commonModels.DeltaCheckRule) (
+	commonModels.DeltaCheckRule, *commonStructures.CommonError) {
+
+	if err := deltaCheckDao.Db.Save(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (deltaCheckDao *DeltaCheckDao) DeleteDeltaCheckRule(ruleId, userId uint) *commonStructures.CommonError {
+
+	currentTime := commonUtils.GetCurrentTime()
+	ruleUpdates := map[string]interface{}{
+		"deleted_by": userId,
+		"updated_by": userId,
+		"deleted_at": currentTime,
+		"updated_at": currentTime,
+	}
+	if err := deltaCheckDao.Db.Model(&commonModels.DeltaCheckRule{}).Where("id = ?", ruleId).
+		Updates(ruleUpdates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
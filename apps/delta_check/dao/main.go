@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type DeltaCheckDao struct {
+	Db *gorm.DB
+}
+
+func InitializeDeltaCheckDao() DataLayer {
+	return &DeltaCheckDao{
+		Db: psql.GetDbInstance(),
+	}
+}
@@ -0,0 +1,73 @@
+package mapper
+
+import (
+	"github.com/Orange-Health/citadel/apps/delta_check/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapDeltaCheckRule(rule commonModels.DeltaCheckRule) structures.DeltaCheckRule {
+	return structures.DeltaCheckRule{
+		Id:                    rule.Id,
+		MasterInvestigationId: rule.MasterInvestigationId,
+		RuleType:              rule.RuleType,
+		PositiveLimit:         rule.PositiveLimit,
+		NegativeLimit:         rule.NegativeLimit,
+		ThresholdDays:         rule.ThresholdDays,
+		IsActive:              rule.IsActive,
+	}
+}
+
+func MapDeltaCheckRules(rules []commonModels.DeltaCheckRule) []structures.DeltaCheckRule {
+	deltaCheckRules := []structures.DeltaCheckRule{}
+	for _, rule := range rules {
+		deltaCheckRules = append(deltaCheckRules, MapDeltaCheckRule(rule))
+	}
+	return deltaCheckRules
+}
+
+func MapDeltaCheckRuleRequest(rule commonModels.DeltaCheckRule, ruleRequest structures.DeltaCheckRuleRequest,
+	userId uint) commonModels.DeltaCheckRule {
+	if rule.Id == 0 {
+		rule.CreatedBy = userId
+		rule.IsActive = true
+	}
+	rule.MasterInvestigationId = ruleRequest.MasterInvestigationId
+	rule.RuleType = ruleRequest.RuleType
+	rule.PositiveLimit = ruleRequest.PositiveLimit
+	rule.NegativeLimit = ruleRequest.NegativeLimit
+	rule.ThresholdDays = ruleRequest.ThresholdDays
+	if ruleRequest.IsActive != nil {
+		rule.IsActive = *ruleRequest.IsActive
+	}
+	rule.UpdatedBy = userId
+	return rule
+}
+
+// MapDeltaCheckResultToMetadata copies the delta check outcome onto the investigation result metadata,
+// leaving the QC fields untouched.
+func MapDeltaCheckResultToMetadata(result structures.DeltaCheckResult,
+	metadata commonModels.InvestigationResultMetadata) commonModels.InvestigationResultMetadata {
+	metadata.DeltaCheckStatus = result.Status
+	metadata.DeltaPreviousValue = result.PreviousValue
+	metadata.DeltaPreviousApprovedAt = result.PreviousApprovedAt
+	metadata.DeltaAbsolute = result.AbsoluteDelta
+	metadata.DeltaPercentage = result.PercentageDelta
+	metadata.DeltaRatePerDay = result.RatePerDay
+	metadata.DeltaViolatedRule = result.ViolatedRule
+	metadata.DeltaCheckRemarks = result.Remarks
+	return metadata
+}
+
+// GetAutoApprovalFailureReason returns the failure reason to be recorded on the investigation result,
+// e.g. past_record|percentage. An empty string is returned if the delta check did not fail.
+func GetAutoApprovalFailureReason(result structures.DeltaCheckResult) string {
+	if result.Status != commonConstants.DeltaCheckStatusFailed {
+		return ""
+	}
+	if result.ViolatedRule == "" {
+		return commonConstants.AUTO_APPROVAL_FAIL_REASON_PAST_RECORD
+	}
+	return commonConstants.AUTO_APPROVAL_FAIL_REASON_PAST_RECORD +
+		commonConstants.AUTO_APPROVAL_FAIL_REASON_SEPARATOR + result.ViolatedRule
+}
@@ -0,0 +1,16 @@
+package deltaCheck
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/delta_check/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	deltaCheckController := controller.InitDeltaCheckController()
+
+	router.GET("/rules", deltaCheckController.GetDeltaCheckRules)
+	router.POST("/rules", deltaCheckController.CreateDeltaCheckRule)
+	router.PUT("/rules/:ruleId", deltaCheckController.UpdateDeltaCheckRule)
+	router.DELETE("/rules/:ruleId", deltaCheckController.DeleteDeltaCheckRule)
+}
@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/delta_check/mapper"
+	"github.com/Orange-Health/citadel/apps/delta_check/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DeltaCheckServiceInterface interface {
+	GetDeltaCheckRules(masterInvestigationId uint) ([]structures.DeltaCheckRule, *commonStructures.CommonError)
+	CreateDeltaCheckRule(ctx context.Context, ruleRequest structures.DeltaCheckRuleRequest, userId uint) (
+		structures.DeltaCheckRule, *commonStructures.CommonError)
+	UpdateDeltaCheckRule(ctx context.Context, ruleId uint, ruleRequest structures.DeltaCheckRuleRequest,
+		userId uint) (structures.DeltaCheckRule, *commonStructures.CommonError)
+	DeleteDeltaCheckRule(ctx context.Context, ruleId, userId uint) *commonStructures.CommonError
+
+	GetActiveDeltaCheckRulesMap(ctx context.Context) map[uint][]commonModels.DeltaCheckRule
+	EvaluateDeltaCheck(ctx context.Context, deltaCheckRequest structures.DeltaCheckRequest) structures.DeltaCheckResult
+	EvaluateDeltaChecksForTask(ctx context.Context, taskId uint, investigations []commonModels.InvestigationResult) (
+		map[uint]structures.DeltaCheckResult, *commonStructures.CommonError)
+}
+
+func (deltaCheckService *DeltaCheckService) GetDeltaCheckRules(masterInvestigationId uint) (
+	[]structures.DeltaCheckRule, *commonStructures.CommonError) {
+
+	rules, cErr := deltaCheckService.DeltaCheckDao.GetDeltaCheckRules(masterInvestigationId)
+	if cErr != nil {
+		return []structures.DeltaCheckRule{}, cErr
+	}
+
+	return mapper.MapDeltaCheckRules(rules), nil
+}
+
+func (deltaCheckService *DeltaCheckService) CreateDeltaCheckRule(ctx context.Context,
+	ruleRequest structures.DeltaCheckRuleRequest, userId uint) (
+	structures.DeltaCheckRule, *commonStructures.CommonError) {
+
+	if cErr := validateDeltaCheckRuleRequest(ruleRequest); cErr != nil {
+		return structures.DeltaCheckRule{}, cErr
+	}
+
+	if cErr := deltaCheckService.validateDuplicateDeltaCheckRule(ruleRequest, 0); cErr != nil {
+		return structures.DeltaCheckRule{}, cErr
+	}
+
+	rule := mapper.MapDeltaCheckRuleRequest(commonModels.DeltaCheckRule{}, ruleRequest, userId)
+	rule, cErr := deltaCheckService.DeltaCheckDao.CreateDeltaCheckRule(rule)
+	if cErr != nil {
+		return structures.DeltaCheckRule{}, cErr
+	}
+
+	deltaCheckService.invalidateDeltaCheckRulesCache(ctx)
+	return mapper.MapDeltaCheckRule(rule), nil
+}
+
+func (deltaCheckService *DeltaCheckService) UpdateDeltaCheckRule(ctx context.Context, ruleId uint,
+	ruleRequest structures.DeltaCheckRuleRequest, userId uint) (
+	structures.DeltaCheckRule, *commonStructures.CommonError) {
+
+	if cErr := validateDeltaCheckRuleRequest(ruleRequest); cErr != nil {
+		return structures.DeltaCheckRule{}, cErr
+	}
+
+	rule, cErr := deltaCheckService.getDeltaCheckRuleById(ruleId)
+	if cErr != nil {
+		return structures.DeltaCheckRule{}, cErr
+	}
+
+	if cErr := deltaCheckService.validateDuplicateDeltaCheckRule(ruleRequest, ruleId); cErr != nil {
+		return structures.DeltaCheckRule{}, cErr
+	}
+
+	rule = mapper.MapDeltaCheckRuleRequest(rule, ruleRequest, userId)
+	rule, cErr = deltaCheckService.DeltaCheckDao.UpdateDeltaCheckRule(rule)
+	if cErr != nil {
+		return structures.DeltaCheckRule{}, cErr
+	}
+
+	deltaCheckService.invalidateDeltaCheckRulesCache(ctx)
+	return mapper.MapDeltaCheckRule(rule), nil
+}
+
+func (deltaCheckService *DeltaCheckService) DeleteDeltaCheckRule(ctx context.Context,
+	ruleId, userId uint) *commonStructures.CommonError {
+
+	if _, cErr := deltaCheckService.getDeltaCheckRuleById(ruleId); cErr != nil {
+		return cErr
+	}
+
+	if cErr := deltaCheckService.DeltaCheckDao.DeleteDeltaCheckRule(ruleId, userId); cErr != nil {
+		return cErr
+	}
+
+	deltaCheckService.invalidateDeltaCheckRulesCache(ctx)
+	return nil
+}
+
+// GetActiveDeltaCheckRulesMap returns the active delta check rules keyed by master investigation id.
+// Any error is logged and an empty map is returned so that callers fall back to the RCV limits.
+func (deltaCheckService *DeltaCheckService) GetActiveDeltaCheckRulesMap(ctx context.Context) map[uint][]commonModels.DeltaCheckRule {
+	rulesMap := map[uint][]commonModels.DeltaCheckRule{}
+	rules := []commonModels.DeltaCheckRule{}
+
+	err := deltaCheckService.Cache.Get(ctx, commonConstants.CacheKeyDeltaCheckRulesAll, &rules)
+	if err != nil {
+		var cErr *commonStructures.CommonError
+		rules, cErr = deltaCheckService.DeltaCheckDao.GetActiveDeltaCheckRules()
+		if cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_FETCHING_DELTA_CHECK_RULES,
+				nil, errors.New(cErr.Message))
+			return rulesMap
+		}
+		_ = deltaCheckService.Cache.Set(ctx, commonConstants.CacheKeyDeltaCheckRulesAll, rules,
+			commonConstants.CacheExpiry5MinutesInt)
+	}
+
+	for _, rule := range rules {
+		rulesMap[rule.MasterInvestigationId] = append(rulesMap[rule.MasterInvestigationId], rule)
+	}
+	return rulesMap
+}
+
+// EvaluateDeltaCheck compares the investigation value against the patient's last approved value.
+// The configured rules of the investigation are used if present, otherwise its RCV limits are used
+// as a percentage rule.
+func (deltaCheckService *DeltaCheckService) EvaluateDeltaCheck(ctx context.Context,
+	deltaCheckRequest structures.DeltaCheckRequest) structures.DeltaCheckResult {
+
+	investigation := deltaCheckRequest.Investigation
+	investigationValue := strings.TrimSpace(deltaCheckRequest.InvestigationValue)
+
+	if investigationValue == "" {
+		return getDeltaCheckResult(commonConstants.DeltaCheckStatusFailed, commonConstants.DeltaCheckRemarkEmptyValue)
+	}
+
+	if commonUtils.SliceContainsInt(commonConstants.DeltaCheckWhitelistedMasterInvIds, int(investigation.InvestigationId)) {
+		commonUtils.AddLog(ctx, commonConstants.DEBUG_LEVEL, fmt.Sprintf(commonConstants.SKIP_DELTA_CHECK_WHITELISTED,
+			investigation.InvestigationId), nil, nil)
+		return getDeltaCheckResult(commonConstants.DeltaCheckStatusSkipped, commonConstants.DeltaCheckRemarkWhitelisted)
+	}
+
+	enteredAt := deltaCheckRequest.EnteredAt
+	if enteredAt == nil || enteredAt.IsZero() {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.INVALID_INVESTIGATION_ENTERED_TIME, nil,
+			fmt.Errorf(commonConstants.EMPTY_ENTERED_TIME, investigation.LisCode))
+		return getDeltaCheckResult(commonConstants.DeltaCheckStatusFailed,
+			commonConstants.DeltaCheckRemarkEnteredTimeMissing)
+	}
+
+	pastValue := deltaCheckRequest.PastValue
+	if pastValue == nil {
+		return getDeltaCheckResult(commonConstants.DeltaCheckStatusSkipped, commonConstants.DeltaCheckRemarkNoPastValue)
+	}
+	commonUtils.AddLog(ctx, commonConstants.DEBUG_LEVEL, fmt.Sprintf(commonConstants.LAST_INVESTIGATION_RESULT,
+		investigation.InvestigationName, *pastValue), nil, nil)
+
+	deltaCheckResult := structures.DeltaCheckResult{
+		PreviousValue:      pastValue.InvestigationValue,
+		PreviousApprovedAt: pastValue.ApprovedAt,
+	}
+
+	daysSincePastValue := 0
+	if pastValue.ApprovedAt != nil {
+		daysSincePastValue = commonUtils.GetDaysBetweenTimes(*pastValue.ApprovedAt, *enteredAt)
+	}
+	commonUtils.AddLog(ctx, commonConstants.DEBUG_LEVEL, fmt.Sprintf(commonConstants.DAYS_DIFF_LAST_INV_CURRENT_INV,
+		daysSincePastValue), nil, nil)
+
+	rules := getApplicableDeltaCheckRules(deltaCheckRequest.Rules, investigation, daysSincePastValue)
+	if len(rules) == 0 {
+		deltaCheckResult.Status = commonConstants.DeltaCheckStatusSkipped
+		deltaCheckResult.Remarks = commonConstants.DeltaCheckRemarkNoApplicableRules
+		return deltaCheckResult
+	}
+
+	currentFloatValue, err := strconv.ParseFloat(investigationValue, 64)
+	if err != nil {
+		deltaCheckResult.Status = commonConstants.DeltaCheckStatusSkipped
+		deltaCheckResult.Remarks = commonConstants.DeltaCheckRemarkNonNumericValue
+		return deltaCheckResult
+	}
+
+	pastFloatValue, err := strconv.ParseFloat(strings.TrimSpace(pastValue.InvestigationValue), 64)
+	if err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_PARSING_FLOAT_VALUE, nil, err)
+		deltaCheckResult.Status = commonConstants.DeltaCheckStatusFailed
+		deltaCheckResult.Remarks = commonConstants.DeltaCheckRemarkInvalidPastValue
+		return deltaCheckResult
+	}
+
+	deltaCheckResult.AbsoluteDelta, deltaCheckResult.PercentageDelta, deltaCheckResult.RatePerDay =
+		getDeltaValues(currentFloatValue, pastFloatValue, pastValue.ApprovedAt, *enteredAt)
+
+	for _, rule := range rules {
+		delta := getDeltaForRuleType(deltaCheckResult, rule.RuleType)
+		if delta == nil {
+			// A configured rule that cannot be evaluated, like a percentage change from 0, must hold the result.
+			deltaCheckResult.Status = commonConstants.DeltaCheckStatusFailed
+			deltaCheckResult.ViolatedRule = rule.RuleType
+			deltaCheckResult.Remarks = fmt.Sprintf(commonConstants.DeltaCheckRemarkDeltaNotComputable, rule.RuleType)
+			return deltaCheckResult
+		}
+		if *delta > rule.PositiveLimit || *delta < rule.NegativeLimit {
+			deltaCheckResult.Status = commonConstants.DeltaCheckStatusFailed
+			deltaCheckResult.ViolatedRule = rule.RuleType
+			deltaCheckResult.Remarks = fmt.Sprintf(commonConstants.DeltaCheckRemarkRuleViolated, rule.RuleType,
+				*delta, rule.NegativeLimit, rule.PositiveLimit)
+			return deltaCheckResult
+		}
+	}
+
+	deltaCheckResult.Status = commonConstants.DeltaCheckStatusPassed
+	deltaCheckResult.Remarks = commonConstants.DeltaCheckRemarkWithinLimits
+	return deltaCheckResult
+}
+
+// EvaluateDeltaChecksForTask evaluates the delta check of the given investigations of a task, keyed by
+// investigation result id. Used when the values are modified by the pathologist.
+func (deltaCheckService *DeltaCheckService) EvaluateDeltaChecksForTask(ctx context.Context, taskId uint,
+	investigations []commonModels.InvestigationResult) (
+	map[uint]structures.DeltaCheckResult, *commonStructures.CommonError) {
+
+	deltaCheckResults := map[uint]structures.DeltaCheckResult{}
+	if len(investigations) == 0 {
+		return deltaCheckResults, nil
+	}
+
+	taskPatientDetails, cErr := deltaCheckService.DeltaCheckDao.GetTaskPatientDetails(taskId)
+	if cErr != nil {
+		return deltaCheckResults, cErr
+	}
+
+	patientDob := ""
+	if taskPatientDetails.PatientExpectedDob != nil {
+		patientDob = taskPatientDetails.PatientExpectedDob.Format(commonConstants.DateLayout)
+	}
+	if taskPatientDetails.PatientDob != nil {
+		patientDob = taskPatientDetails.PatientDob.Format(commonConstants.DateLayout)
+	}
+	patientGender := commonUtils.GetGenderConstant(taskPatientDetails.PatientGender)
+
+	investigationCodes, masterInvestigationIds := []string{}, []uint{}
+	for _, investigation := range investigations {
+		investigationCodes = append(investigationCodes, investigation.LisCode)
+		masterInvestigationIds = append(masterInvestigationIds, investigation.MasterInvestigationId)
+	}
+	investigationCodes = commonUtils.CreateUniqueSliceString(investigationCodes)
+	masterInvestigationIds = commonUtils.CreateUniqueSliceUint(masterInvestigationIds)
+
+	masterInvestigations, err := deltaCheckService.CdsClient.GetInvestigationDetails(ctx, investigationCodes,
+		taskPatientDetails.CityCode, taskPatientDetails.LabId, patientDob, patientGender)
+	if err != nil {
+		return deltaCheckResults, &commonStructures.CommonError{
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	masterInvestigations = commonUtils.ResolveInvestigationReferenceRanges(masterInvestigations,
+		commonUtils.GetReferenceRangePatientDetails(taskPatientDetails.PatientDob, taskPatientDetails.PatientExpectedDob,
+			taskPatientDetails.PatientGender, taskPatientDetails.PatientIsPregnant, taskPatientDetails.PatientTrimester))
+	masterInvestigationMap := map[uint]commonStructures.Investigation{}
+	for _, masterInvestigation := range masterInvestigations {
+		masterInvestigationMap[masterInvestigation.InvestigationId] = masterInvestigation
+	}
+
+	pastValuesMap := map[uint]commonStructures.DeltaValuesStructResponse{}
+	if taskPatientDetails.SystemPatientId != "" {
+		pastValuesMap, cErr = deltaCheckService.InvestigationResultsService.GetLastInvValueByPatientId(ctx,
+			taskPatientDetails.SystemPatientId, masterInvestigationIds)
+		if cErr != nil {
+			return deltaCheckResults, cErr
+		}
+	}
+
+	rulesMap := deltaCheckService.GetActiveDeltaCheckRulesMap(ctx)
+	for _, investigation := range investigations {
+		masterInvestigation, ok := masterInvestigationMap[investigation.MasterInvestigationId]
+		if !ok {
+			continue
+		}
+
+		enteredAt := investigation.EnteredAt
+		if enteredAt == nil || enteredAt.IsZero() {
+			enteredAt = commonUtils.GetCurrentTime()
+		}
+
+		var pastValue *commonStructures.DeltaValuesStructResponse
+		if lastValue, ok := pastValuesMap[investigation.MasterInvestigationId]; ok {
+			pastValue = &lastValue
+		}
+
+		deltaCheckResults[investigation.Id] = deltaCheckService.EvaluateDeltaCheck(ctx, structures.DeltaCheckRequest{
+			InvestigationValue: investigation.InvestigationValue,
+			EnteredAt:          enteredAt,
+			Investigation:      masterInvestigation,
+			PastValue:          pastValue,
+			Rules:              rulesMap[investigation.MasterInvestigationId],
+		})
+	}
+
+	return deltaCheckResults, nil
+}
+
+func (deltaCheckService *DeltaCheckService) getDeltaCheckRuleById(ruleId uint) (
+	commonModels.DeltaCheckRule, *commonStructures.CommonError) {
+
+	rule, cErr := deltaCheckService.DeltaCheckDao.GetDeltaCheckRuleById(ruleId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_DELTA_CHECK_RULE_NOT_FOUND
+		}
+		return rule, cErr
+	}
+
+	return rule, nil
+}
+
+func (deltaCheckService *DeltaCheckService) validateDuplicateDeltaCheckRule(
+	ruleRequest structures.DeltaCheckRuleRequest, ruleId uint) *commonStructures.CommonError {
+
+	existingRule, cErr := deltaCheckService.DeltaCheckDao.GetDeltaCheckRuleByInvestigationAndType(
+		ruleRequest.MasterInvestigationId, ruleRequest.RuleType)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return cErr
+	}
+
+	if existingRule.Id != ruleId {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_DELTA_CHECK_RULE_ALREADY_EXISTS,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	return nil
+}
+
+func (deltaCheckService *DeltaCheckService) invalidateDeltaCheckRulesCache(ctx context.Context) {
+	if err := deltaCheckService.Cache.Delete(ctx, commonConstants.CacheKeyDeltaCheckRulesAll); err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_INVALIDATING_DELTA_CHECK_KEY,
+			nil, err)
+	}
+}
+
+func validateDeltaCheckRuleRequest(ruleRequest structures.DeltaCheckRuleRequest) *commonStructures.CommonError {
+	if !commonUtils.SliceContainsString(commonConstants.DeltaCheckRuleTypes, ruleRequest.RuleType) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_DELTA_CHECK_RULE_TYPE,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if ruleRequest.PositiveLimit < 0 || ruleRequest.NegativeLimit > 0 ||
+		(ruleRequest.PositiveLimit == 0 && ruleRequest.NegativeLimit == 0) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_DELTA_CHECK_RULE_LIMITS,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+func getDeltaCheckResult(status, remarks string) structures.DeltaCheckResult {
+	return structures.DeltaCheckResult{
+		Status:  status,
+		Remarks: remarks,
+	}
+}
+
+// getApplicableDeltaCheckRules falls back to the RCV limits of the investigation when no rules are
+// configured, and drops rules without limits or whose threshold days the past value is older than.
+func getApplicableDeltaCheckRules(rules []commonModels.DeltaCheckRule, investigation commonStructures.Investigation,
+	daysSincePastValue int) []commonModels.DeltaCheckRule {
+
+	if len(rules) == 0 {
+		rules = []commonModels.DeltaCheckRule{{
+			RuleType:      commonConstants.DeltaCheckRuleTypePercentage,
+			PositiveLimit: investigation.RcvPositive,
+			NegativeLimit: investigation.RcvNegative,
+		}}
+	}
+
+	applicableRules := []commonModels.DeltaCheckRule{}
+	for _, rule := range rules {
+		if rule.PositiveLimit == 0 && rule.NegativeLimit == 0 {
+			continue
+		}
+		thresholdDays := rule.ThresholdDays
+		if thresholdDays == 0 {
+			thresholdDays = investigation.PastValueThresholdDays
+		}
+		if thresholdDays != 0 && daysSincePastValue > int(thresholdDays) {
+			continue
+		}
+		applicableRules = append(applicableRules, rule)
+	}
+
+	return applicableRules
+}
+
+// getDeltaValues leaves the percentage delta nil when the past value is 0, as the change cannot be expressed as a
+// percentage of it, and the rate per day nil when the approval time of the past value is not known.
+func getDeltaValues(currentValue, pastValue float64, pastApprovedAt *time.Time, enteredAt time.Time) (
+	*float64, *float64, *float64) {
+
+	absoluteDelta := roundDelta(currentValue - pastValue)
+
+	var percentageDelta *float64
+	if absoluteDelta == 0 {
+		percentage := 0.0
+		percentageDelta = &percentage
+	} else if pastValue != 0 {
+		percentage := roundDelta((currentValue - pastValue) / pastValue * 100)
+		percentageDelta = &percentage
+	}
+
+	var ratePerDay *float64
+	if pastApprovedAt != nil {
+		elapsedDays := enteredAt.Sub(*pastApprovedAt).Hours() / 24
+		if elapsedDays > 0 {
+			rate := roundDelta(absoluteDelta / elapsedDays)
+			ratePerDay = &rate
+		}
+	}
+
+	return &absoluteDelta, percentageDelta, ratePerDay
+}
+
+func getDeltaForRuleType(deltaCheckResult structures.DeltaCheckResult, ruleType string) *float64 {
+	switch ruleType {
+	case commonConstants.DeltaCheckRuleTypeAbsolute:
+		return deltaCheckResult.AbsoluteDelta
+	case commonConstants.DeltaCheckRuleTypePercentage:
+		return deltaCheckResult.PercentageDelta
+	case commonConstants.DeltaCheckRuleTypeRatePerDay:
+		return deltaCheckResult.RatePerDay
+	}
+	return nil
+}
+
+// Rounding off to 5 decimal places to avoid floating number precision issues.
+func roundDelta(delta float64) float64 {
+	return math.Round(delta*1e5) / 1e5
+}
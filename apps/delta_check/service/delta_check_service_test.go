@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/apps/delta_check/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func float64Pointer(value float64) *float64 {
+	return &value
+}
+
+func TestGetDeltaValues(t *testing.T) {
+	enteredAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	approvedAt := enteredAt.Add(-48 * time.Hour)
+
+	testCases := []struct {
+		name                    string
+		currentValue            float64
+		pastValue               float64
+		pastApprovedAt          *time.Time
+		expectedAbsoluteDelta   *float64
+		expectedPercentageDelta *float64
+		expectedRatePerDay      *float64
+	}{
+		{
+			name:                    "increase",
+			currentValue:            120,
+			pastValue:               100,
+			pastApprovedAt:          &approvedAt,
+			expectedAbsoluteDelta:   float64Pointer(20),
+			expectedPercentageDelta: float64Pointer(20),
+			expectedRatePerDay:      float64Pointer(10),
+		},
+		{
+			name:                    "decrease",
+			currentValue:            0.9,
+			pastValue:               1.2,
+			pastApprovedAt:          &approvedAt,
+			expectedAbsoluteDelta:   float64Pointer(-0.3),
+			expectedPercentageDelta: float64Pointer(-25),
+			expectedRatePerDay:      float64Pointer(-0.15),
+		},
+		{
+			name:                    "unchanged from 0",
+			currentValue:            0,
+			pastValue:               0,
+			pastApprovedAt:          &approvedAt,
+			expectedAbsoluteDelta:   float64Pointer(0),
+			expectedPercentageDelta: float64Pointer(0),
+			expectedRatePerDay:      float64Pointer(0),
+		},
+		{
+			name:                  "changed from 0",
+			currentValue:          5,
+			pastValue:             0,
+			pastApprovedAt:        &approvedAt,
+			expectedAbsoluteDelta: float64Pointer(5),
+			expectedRatePerDay:    float64Pointer(2.5),
+		},
+		{
+			name:                    "past approval time unknown",
+			currentValue:            110,
+			pastValue:               100,
+			expectedAbsoluteDelta:   float64Pointer(10),
+			expectedPercentageDelta: float64Pointer(10),
+		},
+		{
+			name:                    "past approved after entry",
+			currentValue:            110,
+			pastValue:               100,
+			pastApprovedAt:          &enteredAt,
+			expectedAbsoluteDelta:   float64Pointer(10),
+			expectedPercentageDelta: float64Pointer(10),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			absoluteDelta, percentageDelta, ratePerDay := getDeltaValues(testCase.currentValue, testCase.pastValue,
+				testCase.pastApprovedAt, enteredAt)
+			assert.Equal(t, testCase.expectedAbsoluteDelta, absoluteDelta)
+			assert.Equal(t, testCase.expectedPercentageDelta, percentageDelta)
+			assert.Equal(t, testCase.expectedRatePerDay, ratePerDay)
+		})
+	}
+}
+
+func TestEvaluateDeltaCheck(t *testing.T) {
+	enteredAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	approvedAt := enteredAt.Add(-48 * time.Hour)
+	oldApprovedAt := enteredAt.Add(-40 * 24 * time.Hour)
+	investigation := commonStructures.Investigation{
+		InvestigationId: 1,
+		RcvPositive:     20,
+		RcvNegative:     -20,
+	}
+	percentageRule := commonModels.DeltaCheckRule{
+		RuleType:      commonConstants.DeltaCheckRuleTypePercentage,
+		PositiveLimit: 20,
+		NegativeLimit: -20,
+	}
+	ratePerDayRule := commonModels.DeltaCheckRule{
+		RuleType:      commonConstants.DeltaCheckRuleTypeRatePerDay,
+		PositiveLimit: 5,
+		NegativeLimit: -5,
+	}
+	absoluteRule := commonModels.DeltaCheckRule{
+		RuleType:      commonConstants.DeltaCheckRuleTypeAbsolute,
+		PositiveLimit: 50,
+		NegativeLimit: -50,
+		ThresholdDays: 30,
+	}
+
+	testCases := []struct {
+		name                 string
+		investigationValue   string
+		enteredAt            *time.Time
+		pastValue            *commonStructures.DeltaValuesStructResponse
+		rules                []commonModels.DeltaCheckRule
+		expectedStatus       string
+		expectedViolatedRule string
+		expectedRemarks      string
+	}{
+		{
+			name:               "empty value",
+			investigationValue: " ",
+			enteredAt:          &enteredAt,
+			expectedStatus:     commonConstants.DeltaCheckStatusFailed,
+			expectedRemarks:    commonConstants.DeltaCheckRemarkEmptyValue,
+		},
+		{
+			name:               "entered time missing",
+			investigationValue: "100",
+			expectedStatus:     commonConstants.DeltaCheckStatusFailed,
+			expectedRemarks:    commonConstants.DeltaCheckRemarkEnteredTimeMissing,
+		},
+		{
+			name:               "no past value",
+			investigationValue: "100",
+			enteredAt:          &enteredAt,
+			expectedStatus:     commonConstants.DeltaCheckStatusSkipped,
+			expectedRemarks:    commonConstants.DeltaCheckRemarkNoPastValue,
+		},
+		{
+			name:               "non numeric value",
+			investigationValue: "Reactive",
+			enteredAt:          &enteredAt,
+			pastValue:          &commonStructures.DeltaValuesStructResponse{InvestigationValue: "100"},
+			expectedStatus:     commonConstants.DeltaCheckStatusSkipped,
+			expectedRemarks:    commonConstants.DeltaCheckRemarkNonNumericValue,
+		},
+		{
+			name:               "non numeric past value",
+			investigationValue: "100",
+			enteredAt:          &enteredAt,
+			pastValue:          &commonStructures.DeltaValuesStructResponse{InvestigationValue: "Reactive"},
+			expectedStatus:     commonConstants.DeltaCheckStatusFailed,
+			expectedRemarks:    commonConstants.DeltaCheckRemarkInvalidPastValue,
+		},
+		{
+			name:               "within rcv limits",
+			investigationValue: "110",
+			enteredAt:          &enteredAt,
+			pastValue:          &commonStructures.DeltaValuesStructResponse{InvestigationValue: "100"},
+			expectedStatus:     commonConstants.DeltaCheckStatusPassed,
+			expectedRemarks:    commonConstants.DeltaCheckRemarkWithinLimits,
+		},
+		{
+			name:                 "outside rcv limits",
+			investigationValue:   "130",
+			enteredAt:            &enteredAt,
+			pastValue:            &commonStructures.DeltaValuesStructResponse{InvestigationValue: "100"},
+			expectedStatus:       commonConstants.DeltaCheckStatusFailed,
+			expectedViolatedRule: commonConstants.DeltaCheckRuleTypePercentage,
+			expectedRemarks:      "percentage delta 30 outside limits [-20, 20]",
+		},
+		{
+			name:                 "percentage rule with past value 0",
+			investigationValue:   "5",
+			enteredAt:            &enteredAt,
+			pastValue:            &commonStructures.DeltaValuesStructResponse{InvestigationValue: "0"},
+			rules:                []commonModels.DeltaCheckRule{percentageRule},
+			expectedStatus:       commonConstants.DeltaCheckStatusFailed,
+			expectedViolatedRule: commonConstants.DeltaCheckRuleTypePercentage,
+			expectedRemarks:      "percentage delta cannot be computed against the past value",
+		},
+		{
+			name:               "percentage rule with past value 0 unchanged",
+			investigationValue: "0",
+			enteredAt:          &enteredAt,
+			pastValue:          &commonStructures.DeltaValuesStructResponse{InvestigationValue: "0"},
+			rules:              []commonModels.DeltaCheckRule{percentageRule},
+			expectedStatus:     commonConstants.DeltaCheckStatusPassed,
+			expectedRemarks:    commonConstants.DeltaCheckRemarkWithinLimits,
+		},
+		{
+			name:                 "rate per day rule without past approval time",
+			investigationValue:   "101",
+			enteredAt:            &enteredAt,
+			pastValue:            &commonStructures.DeltaValuesStructResponse{InvestigationValue: "100"},
+			rules:                []commonModels.DeltaCheckRule{ratePerDayRule},
+			expectedStatus:       commonConstants.DeltaCheckStatusFailed,
+			expectedViolatedRule: commonConstants.DeltaCheckRuleTypeRatePerDay,
+			expectedRemarks:      "rate_per_day delta cannot be computed against the past value",
+		},
+		{
+			name:               "rate per day rule within limits",
+			investigationValue: "108",
+			enteredAt:          &enteredAt,
+			pastValue: &commonStructures.DeltaValuesStructResponse{InvestigationValue: "100",
+				ApprovedAt: &approvedAt},
+			rules:           []commonModels.DeltaCheckRule{ratePerDayRule},
+			expectedStatus:  commonConstants.DeltaCheckStatusPassed,
+			expectedRemarks: commonConstants.DeltaCheckRemarkWithinLimits,
+		},
+		{
+			name:               "rate per day rule outside limits",
+			investigationValue: "112",
+			enteredAt:          &enteredAt,
+			pastValue: &commonStructures.DeltaValuesStructResponse{InvestigationValue: "100",
+				ApprovedAt: &approvedAt},
+			rules:                []commonModels.DeltaCheckRule{absoluteRule, ratePerDayRule},
+			expectedStatus:       commonConstants.DeltaCheckStatusFailed,
+			expectedViolatedRule: commonConstants.DeltaCheckRuleTypeRatePerDay,
+			expectedRemarks:      "rate_per_day delta 6 outside limits [-5, 5]",
+		},
+		{
+			name:               "past value older than threshold days",
+			investigationValue: "200",
+			enteredAt:          &enteredAt,
+			pastValue: &commonStructures.DeltaValuesStructResponse{InvestigationValue: "100",
+				ApprovedAt: &oldApprovedAt},
+			rules:           []commonModels.DeltaCheckRule{absoluteRule},
+			expectedStatus:  commonConstants.DeltaCheckStatusSkipped,
+			expectedRemarks: commonConstants.DeltaCheckRemarkNoApplicableRules,
+		},
+	}
+
+	deltaCheckService := &DeltaCheckService{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			deltaCheckResult := deltaCheckService.EvaluateDeltaCheck(context.Background(),
+				structures.DeltaCheckRequest{
+					InvestigationValue: testCase.investigationValue,
+					EnteredAt:          testCase.enteredAt,
+					Investigation:      investigation,
+					PastValue:          testCase.pastValue,
+					Rules:              testCase.rules,
+				})
+			assert.Equal(t, testCase.expectedStatus, deltaCheckResult.Status)
+			assert.Equal(t, testCase.expectedViolatedRule, deltaCheckResult.ViolatedRule)
+			assert.Equal(t, testCase.expectedRemarks, deltaCheckResult.Remarks)
+		})
+	}
+}
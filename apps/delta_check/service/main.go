@@ -0,0 +1,27 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/cache"
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/apps/delta_check/dao"
+	investigationResultsService "github.com/Orange-Health/citadel/apps/investigation_results/service"
+	cdsClient "github.com/Orange-Health/citadel/clients/cds"
+)
+
+type DeltaCheckService struct {
+	DeltaCheckDao               dao.DataLayer
+	Cache                       cache.CacheLayer
+	Sentry                      sentry.SentryLayer
+	InvestigationResultsService investigationResultsService.InvestigationResultServiceInterface
+	CdsClient                   cdsClient.CdsClientInterface
+}
+
+func InitializeDeltaCheckService() DeltaCheckServiceInterface {
+	return &DeltaCheckService{
+		DeltaCheckDao:               dao.InitializeDeltaCheckDao(),
+		Cache:                       cache.InitializeCache(),
+		Sentry:                      sentry.InitializeSentry(),
+		InvestigationResultsService: investigationResultsService.InitializeInvestigationResultService(),
+		CdsClient:                   cdsClient.InitializeCdsClient(),
+	}
+}
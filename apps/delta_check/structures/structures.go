@@ -0,0 +1,72 @@
+package structures
+
+import (
+	"time"
+
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// @swagger:model DeltaCheckRule
+type DeltaCheckRule struct {
+	// The delta check rule ID.
+	// example: 1
+	Id uint `json:"id"`
+	// The master investigation ID the rule applies to.
+	// example: 1
+	MasterInvestigationId uint `json:"master_investigation_id"`
+	// The rule type, one of absolute, percentage and rate_per_day.
+	// example: "percentage"
+	RuleType string `json:"rule_type"`
+	// The maximum allowed increase, in the unit of the rule type.
+	// example: 25
+	PositiveLimit float64 `json:"positive_limit"`
+	// The maximum allowed decrease, stored as a negative number.
+	// example: -20
+	NegativeLimit float64 `json:"negative_limit"`
+	// Past values older than these many days are ignored. 0 falls back to the investigation's threshold.
+	// example: 30
+	ThresholdDays uint `json:"threshold_days"`
+	// If the rule is active.
+	// example: true
+	IsActive bool `json:"is_active"`
+}
+
+type DeltaCheckRuleRequest struct {
+	MasterInvestigationId uint    `json:"master_investigation_id" binding:"required"`
+	RuleType              string  `json:"rule_type" binding:"required"`
+	PositiveLimit         float64 `json:"positive_limit"`
+	NegativeLimit         float64 `json:"negative_limit"`
+	ThresholdDays         uint    `json:"threshold_days"`
+	IsActive              *bool   `json:"is_active"`
+}
+
+type DeltaCheckRequest struct {
+	InvestigationValue string
+	EnteredAt          *time.Time
+	Investigation      commonStructures.Investigation
+	PastValue          *commonStructures.DeltaValuesStructResponse
+	Rules              []commonModels.DeltaCheckRule
+}
+
+type DeltaCheckResult struct {
+	Status             string     `json:"status"`
+	PreviousValue      string     `json:"previous_value,omitempty"`
+	PreviousApprovedAt *time.Time `json:"previous_approved_at,omitempty"`
+	AbsoluteDelta      *float64   `json:"absolute_delta,omitempty"`
+	PercentageDelta    *float64   `json:"percentage_delta,omitempty"`
+	RatePerDay         *float64   `json:"rate_per_day,omitempty"`
+	ViolatedRule       string     `json:"violated_rule,omitempty"`
+	Remarks            string     `json:"remarks,omitempty"`
+}
+
+type TaskPatientDetails struct {
+	LabId              uint       `json:"lab_id"`
+	CityCode           string     `json:"city_code"`
+	SystemPatientId    string     `json:"system_patient_id"`
+	PatientDob         *time.Time `json:"patient_dob"`
+	PatientExpectedDob *time.Time `json:"patient_expected_dob"`
+	PatientGender      string     `json:"patient_gender"`
+	PatientIsPregnant  bool       `json:"patient_is_pregnant"`
+	PatientTrimester   uint       `json:"patient_trimester"`
+}
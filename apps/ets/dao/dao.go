@@ -44,25 +44,29 @@ func (etsDao *EtsDao) GetEtsCommonQuery(inhouseLabIds []uint) *gorm.DB {
 	return query
 }
 
-func (etsDao *EtsDao) CreateEtsEvents(etsEvents []commonModels.EtsEvent) error {
+func (etsDao *EtsDao) BeginTransaction() *gorm.DB {
+	return etsDao.Db.Begin()
+}
+
+func (etsDao *EtsDao) CreateEtsEventsWithTx(tx *gorm.DB, etsEvents []commonModels.EtsEvent) error {
 	if len(etsEvents) == 0 {
 		return nil
 	}
 
-	if err := etsDao.Db.Model(&commonModels.EtsEvent{}).Create(&etsEvents).Error; err != nil {
+	if err := tx.Model(&commonModels.EtsEvent{}).Create(&etsEvents).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (etsDao *EtsDao) MarkEventAsInactive(testIds []string) error {
+func (etsDao *EtsDao) MarkEventAsInactiveWithTx(tx *gorm.DB, testIds []string) error {
 	if len(testIds) == 0 {
 		return nil
 	}
 
 	currentTime := time.Now()
-	err := etsDao.Db.Model(&commonModels.EtsEvent{}).
+	err := tx.Model(&commonModels.EtsEvent{}).
 		Where("test_id IN (?)", testIds).
 		Updates(map[string]interface{}{
 			"updated_by": commonConstants.CitadelSystemId,
@@ -14,8 +14,9 @@ type EtsDao struct {
 }
 
 type DataLayer interface {
-	CreateEtsEvents(etsEvents []commonModels.EtsEvent) error
-	MarkEventAsInactive(testIds []string) error
+	BeginTransaction() *gorm.DB
+	CreateEtsEventsWithTx(tx *gorm.DB, etsEvents []commonModels.EtsEvent) error
+	MarkEventAsInactiveWithTx(tx *gorm.DB, testIds []string) error
 	GetEtsEventByTestId(testId string) commonModels.EtsEvent
 
 	FetchTatBreachDetails(inhouseLabIds []uint) []commonStructures.EtsTestEvent
@@ -2,16 +2,31 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"gorm.io/gorm"
+
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
 	commonUtils "github.com/Orange-Health/citadel/common/utils"
 	commonModels "github.com/Orange-Health/citadel/models"
 )
 
+func (etsService *EtsService) writeEtsTestEventWithTx(ctx context.Context, tx *gorm.DB,
+	etsEventDetail commonStructures.EtsTestEvent) *commonStructures.CommonError {
+
+	body, messageAttributes := etsService.PubsubService.GetEtsTestEvent(etsEventDetail)
+	return etsService.OutboxService.WriteMessageWithTx(ctx, tx, body, messageAttributes,
+		commonConstants.EtsTestEventGroupId, commonConstants.EtsTestEventTopicArn,
+		commonUtils.GetDeduplicationIdForTestEvent(etsEventDetail.TestID))
+}
+
 func (etsService *EtsService) GetPublishAndCreateEtsTestEventForCron(ctx context.Context,
 	etsEventDetails []commonStructures.EtsTestEvent) {
+	tx := etsService.EtsDao.BeginTransaction()
+	defer tx.Rollback()
+
 	testIdsToBeCreated := []string{}
 	for _, etsEventDetail := range etsEventDetails {
 		if commonUtils.SliceContainsString(testIdsToBeCreated, etsEventDetail.TestID) || etsEventDetail.Barcode == "" {
@@ -22,37 +37,43 @@ func (etsService *EtsService) GetPublishAndCreateEtsTestEventForCron(ctx context
 			continue
 		}
 		etsEventDetail.LabEta = labEta.Format(commonConstants.DateTimeUTCLayoutWithoutTZOffset)
-		body, messageAttributes := etsService.PubsubService.GetEtsTestEvent(etsEventDetail)
-		cErr := etsService.SnsClient.PublishTo(ctx, body, messageAttributes, commonConstants.EtsTestEventGroupId,
-			commonConstants.EtsTestEventTopicArn, commonUtils.GetDeduplicationIdForTestEvent(etsEventDetail.TestID))
-		if cErr == nil {
-			testIdsToBeCreated = append(testIdsToBeCreated, etsEventDetail.TestID)
+		if cErr := etsService.writeEtsTestEventWithTx(ctx, tx, etsEventDetail); cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
+				errors.New(cErr.Message))
+			return
 		}
+		testIdsToBeCreated = append(testIdsToBeCreated, etsEventDetail.TestID)
 	}
 
-	if len(testIdsToBeCreated) > 0 {
-		etsEvents := []commonModels.EtsEvent{}
-		for _, testId := range testIdsToBeCreated {
-			etsEvent := commonModels.EtsEvent{
-				TestID:   testId,
-				IsActive: true,
-			}
-			etsEvent.CreatedBy = commonConstants.CitadelSystemId
-			etsEvent.UpdatedBy = commonConstants.CitadelSystemId
-			etsEvents = append(etsEvents, etsEvent)
-		}
-		if len(etsEvents) > 0 {
-			err := etsService.EtsDao.CreateEtsEvents(etsEvents)
-			if err != nil {
-				commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
-				return
-			}
+	if len(testIdsToBeCreated) == 0 {
+		return
+	}
+
+	etsEvents := []commonModels.EtsEvent{}
+	for _, testId := range testIdsToBeCreated {
+		etsEvent := commonModels.EtsEvent{
+			TestID:   testId,
+			IsActive: true,
 		}
+		etsEvent.CreatedBy = commonConstants.CitadelSystemId
+		etsEvent.UpdatedBy = commonConstants.CitadelSystemId
+		etsEvents = append(etsEvents, etsEvent)
+	}
+	if err := etsService.EtsDao.CreateEtsEventsWithTx(tx, etsEvents); err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
 	}
 }
 
 func (etsService *EtsService) GetPublishAndUpdateEtsTestEvent(ctx context.Context,
 	etsEventDetails []commonStructures.EtsTestEvent, updateDb bool) {
+	tx := etsService.EtsDao.BeginTransaction()
+	defer tx.Rollback()
+
 	testIdsToBeUpdated := []string{}
 	for _, etsEventDetail := range etsEventDetails {
 		if commonUtils.SliceContainsString(testIdsToBeUpdated, etsEventDetail.TestID) || etsEventDetail.Barcode == "" {
@@ -63,48 +84,70 @@ func (etsService *EtsService) GetPublishAndUpdateEtsTestEvent(ctx context.Contex
 			continue
 		}
 		etsEventDetail.LabEta = labEta.Format(commonConstants.DateTimeUTCLayoutWithoutTZOffset)
-		body, messageAttributes := etsService.PubsubService.GetEtsTestEvent(etsEventDetail)
-		cErr := etsService.SnsClient.PublishTo(ctx, body, messageAttributes, commonConstants.EtsTestEventGroupId,
-			commonConstants.EtsTestEventTopicArn, commonUtils.GetDeduplicationIdForTestEvent(etsEventDetail.TestID))
-		if cErr == nil {
-			testIdsToBeUpdated = append(testIdsToBeUpdated, etsEventDetail.TestID)
+		if cErr := etsService.writeEtsTestEventWithTx(ctx, tx, etsEventDetail); cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
+				errors.New(cErr.Message))
+			return
 		}
+		testIdsToBeUpdated = append(testIdsToBeUpdated, etsEventDetail.TestID)
 	}
 
-	if updateDb && len(testIdsToBeUpdated) > 0 {
-		err := etsService.EtsDao.MarkEventAsInactive(testIdsToBeUpdated)
-		if err != nil {
+	if len(testIdsToBeUpdated) == 0 {
+		return
+	}
+
+	if updateDb {
+		if err := etsService.EtsDao.MarkEventAsInactiveWithTx(tx, testIdsToBeUpdated); err != nil {
 			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
 			return
 		}
 	}
+
+	if err := tx.Commit().Error; err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
+	}
 }
 
 func (etsService *EtsService) GetPublishAndCreateEtsTestEventForRerunWebhook(ctx context.Context,
 	etsEventDetails []commonStructures.EtsTestEvent) {
+	tx := etsService.EtsDao.BeginTransaction()
+	defer tx.Rollback()
+
+	testIdsToBeCreated, etsEvents := []string{}, []commonModels.EtsEvent{}
 	for _, etsEventDetail := range etsEventDetails {
 		labEta, err := time.Parse(commonConstants.DateTimeUTCLayoutWithoutTZOffset, etsEventDetail.LabEta)
 		if err != nil {
 			continue
 		}
 		etsEventDetail.LabEta = labEta.Format(commonConstants.DateTimeUTCLayoutWithoutTZOffset)
-		body, messageAttributes := etsService.PubsubService.GetEtsTestEvent(etsEventDetail)
-		cErr := etsService.SnsClient.PublishTo(ctx, body, messageAttributes, commonConstants.EtsTestEventGroupId,
-			commonConstants.EtsTestEventTopicArn, commonUtils.GetDeduplicationIdForTestEvent(etsEventDetail.TestID))
-		if cErr == nil {
-			etsEvent := etsService.EtsDao.GetEtsEventByTestId(etsEventDetail.TestID)
-			if etsEvent.TestID == "" {
-				etsEvent = commonModels.EtsEvent{
-					TestID:   etsEventDetail.TestID,
-					IsActive: true,
-				}
-				etsEvent.CreatedBy = commonConstants.CitadelSystemId
-				etsEvent.UpdatedBy = commonConstants.CitadelSystemId
-				if err := etsService.EtsDao.CreateEtsEvents([]commonModels.EtsEvent{etsEvent}); err != nil {
-					commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
-					return
-				}
+		if cErr := etsService.writeEtsTestEventWithTx(ctx, tx, etsEventDetail); cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
+				errors.New(cErr.Message))
+			return
+		}
+
+		if commonUtils.SliceContainsString(testIdsToBeCreated, etsEventDetail.TestID) {
+			continue
+		}
+		etsEvent := etsService.EtsDao.GetEtsEventByTestId(etsEventDetail.TestID)
+		if etsEvent.TestID == "" {
+			etsEvent = commonModels.EtsEvent{
+				TestID:   etsEventDetail.TestID,
+				IsActive: true,
 			}
+			etsEvent.CreatedBy = commonConstants.CitadelSystemId
+			etsEvent.UpdatedBy = commonConstants.CitadelSystemId
+			testIdsToBeCreated = append(testIdsToBeCreated, etsEvent.TestID)
+			etsEvents = append(etsEvents, etsEvent)
 		}
 	}
+
+	if err := etsService.EtsDao.CreateEtsEventsWithTx(tx, etsEvents); err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
+	}
 }
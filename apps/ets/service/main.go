@@ -7,8 +7,8 @@ import (
 	"github.com/Orange-Health/citadel/adapters/sentry"
 	cdsService "github.com/Orange-Health/citadel/apps/cds/service"
 	"github.com/Orange-Health/citadel/apps/ets/dao"
+	outboxService "github.com/Orange-Health/citadel/apps/outbox/service"
 	pubsubService "github.com/Orange-Health/citadel/apps/pubsub/service"
-	snsClient "github.com/Orange-Health/citadel/clients/sns"
 )
 
 type EtsService struct {
@@ -19,14 +19,14 @@ type EtsService struct {
 	CdsService cdsService.CdsServiceInterface
 
 	PubsubService pubsubService.PubsubInterface
-	SnsClient     snsClient.SnsClientInterface
+	OutboxService outboxService.OutboxServiceInterface
 }
 
 type EtsServiceInterface interface {
 	HandleTatBreachedTestsCronByEvents(ctx context.Context)
 	GetAndPublishEtsTestEventForSampleRejection(ctx context.Context, omsOrderId string, sampleNumber uint)
 	GetAndPublishEtsTestEventForPartialRejection(ctx context.Context, omsOrderId string, sampleNumber uint, testId string)
-	GetAndPublishEtsTestEventForLisWebhook(ctx context.Context, testIds []string, attuneStatus string)
+	GetAndPublishEtsTestEventForLisWebhook(ctx context.Context, testIds []string, lisStatus string)
 	GetAndPublishEtsTestBasicEvent(ctx context.Context, testIds []string)
 }
 
@@ -37,6 +37,6 @@ func InitializeEtsService() EtsServiceInterface {
 		Sentry:        sentry.InitializeSentry(),
 		CdsService:    cdsService.InitializeCdsService(),
 		PubsubService: pubsubService.InitializePubsubService(),
-		SnsClient:     snsClient.InitializeSnsClient(),
+		OutboxService: outboxService.InitializeOutboxService(),
 	}
 }
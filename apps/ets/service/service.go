@@ -45,16 +45,16 @@ func (etsService *EtsService) HandleTatBreachedTestsCronByEvents(ctx context.Con
 }
 
 func (etsService *EtsService) CreateLisAlertPayloadForEts(ctx context.Context, testIds []string,
-	attuneStatus string) []commonStructures.EtsTestEvent {
+	lisStatus string) []commonStructures.EtsTestEvent {
 	inhouseLabIds := etsService.CdsService.GetInhouseLabIds(ctx)
 	etsEvents := etsService.EtsDao.FetchLisWebhookTests(testIds, inhouseLabIds)
 
-	if attuneStatus != commonConstants.ATTUNE_TEST_STATUS_RERUN {
+	if lisStatus != commonConstants.LIS_TEST_STATUS_RERUN {
 		etsEvents = etsService.EtsDao.KeepTestsWhichAreAlreadySent(etsEvents)
 	}
 
 	for index := range etsEvents {
-		etsEvents[index].LisStatus = attuneStatus
+		etsEvents[index].LisStatus = lisStatus
 	}
 
 	return etsEvents
@@ -95,9 +95,9 @@ func (etsService *EtsService) GetAndPublishEtsTestEventForPartialRejection(ctx c
 }
 
 func (etsService *EtsService) GetAndPublishEtsTestEventForLisWebhook(ctx context.Context, testIds []string,
-	attuneStatus string) {
-	etsTestEvents := etsService.CreateLisAlertPayloadForEts(ctx, testIds, attuneStatus)
-	if attuneStatus == commonConstants.ATTUNE_TEST_STATUS_RERUN {
+	lisStatus string) {
+	etsTestEvents := etsService.CreateLisAlertPayloadForEts(ctx, testIds, lisStatus)
+	if lisStatus == commonConstants.LIS_TEST_STATUS_RERUN {
 		etsService.GetPublishAndCreateEtsTestEventForRerunWebhook(ctx, etsTestEvents)
 		return
 	}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/event_ledger/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Processed Events
+// @Description	Get the ledger of an entity, most recent first, showing which of its events were processed and
+// @Description	which were ignored as duplicate or stale
+// @Tags			event-ledger
+// @Produce		json
+// @Param			business_id	query		string							true	"Business ID"
+// @Param			event_type	query		string							false	"Event Type"
+// @Param			decision	query		string							false	"Decision"
+// @Param			limit		query		int								false	"Limit"
+// @Param			offset		query		int								false	"Offset"
+// @Success		200			{object}	[]structures.ProcessedEvent		"Processed Events"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/event-ledger/events [get]
+func (eventLedgerController *EventLedger) GetProcessedEvents(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	filter := structures.ProcessedEventsFilter{
+		BusinessId: c.Query("business_id"),
+		EventType:  c.Query("event_type"),
+		Decision:   c.Query("decision"),
+		Limit:      limit,
+		Offset:     offset,
+	}
+
+	processedEvents, cErr := eventLedgerController.EventLedgerService.GetProcessedEvents(filter)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, processedEvents)
+}
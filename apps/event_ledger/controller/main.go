@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/event_ledger/service"
+)
+
+type EventLedger struct {
+	EventLedgerService service.EventLedgerServiceInterface
+}
+
+func InitEventLedgerController() *EventLedger {
+	return &EventLedger{
+		EventLedgerService: service.InitializeEventLedgerService(),
+	}
+}
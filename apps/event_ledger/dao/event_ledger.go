@@ -0,0 +1,172 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/Orange-Health/citadel/apps/event_ledger/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetProcessedEvents(filter structures.ProcessedEventsFilter) (
+		[]commonModels.ProcessedEvent, *commonStructures.CommonError)
+	GetLatestProcessedEvent(eventTypes []string, businessId string) (
+		commonModels.ProcessedEvent, *commonStructures.CommonError)
+	GetProcessedEventByPayloadHash(eventTypes []string, businessId, payloadHash string, eventSentAt time.Time) (
+		commonModels.ProcessedEvent, *commonStructures.CommonError)
+
+	CreateProcessedEvent(processedEvent commonModels.ProcessedEvent) (
+		commonModels.ProcessedEvent, *commonStructures.CommonError)
+	ClaimProcessedEvent(processedEvent commonModels.ProcessedEvent) (
+		commonModels.ProcessedEvent, bool, *commonStructures.CommonError)
+	CompleteProcessedEvent(processedEventId uint) *commonStructures.CommonError
+	DeleteProcessedEvent(processedEventId uint) *commonStructures.CommonError
+}
+
+func (eventLedgerDao *EventLedgerDao) GetProcessedEvents(filter structures.ProcessedEventsFilter) (
+	[]commonModels.ProcessedEvent, *commonStructures.CommonError) {
+
+	processedEvents := []commonModels.ProcessedEvent{}
+	query := eventLedgerDao.Db.Model(&commonModels.ProcessedEvent{}).Where("business_id = ?", filter.BusinessId)
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Decision != "" {
+		query = query.Where("decision = ?", filter.Decision)
+	}
+
+	if err := query.Order("id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&processedEvents).
+		Error; err != nil {
+		return processedEvents, commonUtils.HandleORMError(err)
+	}
+
+	return processedEvents, nil
+}
+
+// GetLatestProcessedEvent gets the processed event of an entity that was sent last, falling back on the one
+// processed last for the events that were recorded without a sent time.
+func (eventLedgerDao *EventLedgerDao) GetLatestProcessedEvent(eventTypes []string, businessId string) (
+	commonModels.ProcessedEvent, *commonStructures.CommonError) {
+
+	processedEvent := commonModels.ProcessedEvent{}
+	err := eventLedgerDao.Db.
+		Where("business_id = ? AND event_type IN ? AND decision = ?", businessId, eventTypes,
+			commonConstants.EVENT_LEDGER_DECISION_PROCESSED).
+		Order("event_sent_at DESC NULLS LAST").
+		Order("id DESC").
+		Limit(1).
+		Find(&processedEvent).Error
+	if err != nil {
+		return processedEvent, commonUtils.HandleORMError(err)
+	}
+
+	return processedEvent, nil
+}
+
+func (eventLedgerDao *EventLedgerDao) GetProcessedEventByPayloadHash(eventTypes []string, businessId,
+	payloadHash string, eventSentAt time.Time) (commonModels.ProcessedEvent, *commonStructures.CommonError) {
+
+	processedEvent := commonModels.ProcessedEvent{}
+	err := eventLedgerDao.Db.
+		Where("business_id = ? AND event_type IN ? AND decision = ?", businessId, eventTypes,
+			commonConstants.EVENT_LEDGER_DECISION_PROCESSED).
+		Where("payload_hash = ? AND event_sent_at = ?", payloadHash, eventSentAt).
+		Order("id DESC").
+		Limit(1).
+		Find(&processedEvent).Error
+	if err != nil {
+		return processedEvent, commonUtils.HandleORMError(err)
+	}
+
+	return processedEvent, nil
+}
+
+func (eventLedgerDao *EventLedgerDao) CreateProcessedEvent(processedEvent commonModels.ProcessedEvent) (
+	commonModels.ProcessedEvent, *commonStructures.CommonError) {
+
+	if err := eventLedgerDao.Db.Create(&processedEvent).Error; err != nil {
+		return processedEvent, commonUtils.HandleORMError(err)
+	}
+
+	return processedEvent, nil
+}
+
+// ClaimProcessedEvent records an event as in progress unless the same payload of its entity sent at the same time
+// is already claimed or processed, relying on the unique index over the claimed payloads. A claim whose lease
+// has expired is taken over, as the processing that held it never finished. It reports whether the event was
+// claimed, and returns the processed event that holds the claim otherwise. Events without a sent time never
+// conflict, since the index treats their sent times as distinct.
+func (eventLedgerDao *EventLedgerDao) ClaimProcessedEvent(processedEvent commonModels.ProcessedEvent) (
+	commonModels.ProcessedEvent, bool, *commonStructures.CommonError) {
+
+	result := eventLedgerDao.Db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "event_type"}, {Name: "business_id"}, {Name: "payload_hash"},
+			{Name: "event_sent_at"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "decision IN ('in_progress', 'processed')"}}},
+		DoNothing: true,
+	}).Create(&processedEvent)
+	if result.Error != nil {
+		return processedEvent, false, commonUtils.HandleORMError(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return processedEvent, true, nil
+	}
+
+	claimedEvent := commonModels.ProcessedEvent{}
+	err := eventLedgerDao.Db.
+		Where("event_type = ? AND business_id = ? AND payload_hash = ? AND decision IN ?", processedEvent.EventType,
+			processedEvent.BusinessId, processedEvent.PayloadHash, []string{
+				commonConstants.EVENT_LEDGER_DECISION_IN_PROGRESS, commonConstants.EVENT_LEDGER_DECISION_PROCESSED}).
+		Where("event_sent_at = ?", processedEvent.EventSentAt).
+		First(&claimedEvent).Error
+	if err != nil {
+		return claimedEvent, false, commonUtils.HandleORMError(err)
+	}
+	if claimedEvent.Decision != commonConstants.EVENT_LEDGER_DECISION_IN_PROGRESS {
+		return claimedEvent, false, nil
+	}
+
+	result = eventLedgerDao.Db.Model(&commonModels.ProcessedEvent{}).
+		Where("id = ? AND decision = ? AND lease_expires_at < ?", claimedEvent.Id,
+			commonConstants.EVENT_LEDGER_DECISION_IN_PROGRESS, commonUtils.GetCurrentTime()).
+		Updates(map[string]interface{}{
+			"lease_expires_at": processedEvent.LeaseExpiresAt,
+			"updated_at":       commonUtils.GetCurrentTime(),
+		})
+	if result.Error != nil {
+		return claimedEvent, false, commonUtils.HandleORMError(result.Error)
+	}
+
+	return claimedEvent, result.RowsAffected > 0, nil
+}
+
+// CompleteProcessedEvent records an event claimed for processing as processed once its handler succeeds.
+func (eventLedgerDao *EventLedgerDao) CompleteProcessedEvent(processedEventId uint) *commonStructures.CommonError {
+	err := eventLedgerDao.Db.Model(&commonModels.ProcessedEvent{}).
+		Where("id = ? AND decision = ?", processedEventId, commonConstants.EVENT_LEDGER_DECISION_IN_PROGRESS).
+		Updates(map[string]interface{}{
+			"decision":         commonConstants.EVENT_LEDGER_DECISION_PROCESSED,
+			"lease_expires_at": nil,
+			"updated_at":       commonUtils.GetCurrentTime(),
+		}).Error
+	if err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+func (eventLedgerDao *EventLedgerDao) DeleteProcessedEvent(processedEventId uint) *commonStructures.CommonError {
+	if err := eventLedgerDao.Db.Unscoped().Where("id = ?", processedEventId).
+		Delete(&commonModels.ProcessedEvent{}).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type EventLedgerDao struct {
+	Db *gorm.DB
+}
+
+func InitializeEventLedgerDao() DataLayer {
+	return &EventLedgerDao{
+		Db: psql.GetDbInstance(),
+	}
+}
@@ -0,0 +1,47 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/event_ledger/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapProcessedEvent(processedEvent commonModels.ProcessedEvent) structures.ProcessedEvent {
+	return structures.ProcessedEvent{
+		Id:               processedEvent.Id,
+		EventType:        processedEvent.EventType,
+		BusinessId:       processedEvent.BusinessId,
+		PayloadHash:      processedEvent.PayloadHash,
+		EventSentAt:      processedEvent.EventSentAt,
+		Decision:         processedEvent.Decision,
+		ProcessedEventId: processedEvent.ProcessedEventId,
+		LeaseExpiresAt:   processedEvent.LeaseExpiresAt,
+		CreatedAt:        processedEvent.CreatedAt,
+	}
+}
+
+func MapProcessedEvents(processedEvents []commonModels.ProcessedEvent) []structures.ProcessedEvent {
+	processedEventsResponse := []structures.ProcessedEvent{}
+	for _, processedEvent := range processedEvents {
+		processedEventsResponse = append(processedEventsResponse, MapProcessedEvent(processedEvent))
+	}
+	return processedEventsResponse
+}
+
+func MapNewProcessedEvent(eventType, businessId, payloadHash, decision string, eventSentAt *time.Time,
+	processedEventId uint) commonModels.ProcessedEvent {
+
+	processedEvent := commonModels.ProcessedEvent{
+		EventType:        eventType,
+		BusinessId:       businessId,
+		PayloadHash:      payloadHash,
+		EventSentAt:      eventSentAt,
+		Decision:         decision,
+		ProcessedEventId: processedEventId,
+	}
+	processedEvent.CreatedBy = commonConstants.CitadelSystemId
+	processedEvent.UpdatedBy = commonConstants.CitadelSystemId
+	return processedEvent
+}
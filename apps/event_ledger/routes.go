@@ -0,0 +1,13 @@
+package eventLedger
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/event_ledger/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	eventLedgerController := controller.InitEventLedgerController()
+
+	router.GET("/events", eventLedgerController.GetProcessedEvents)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/event_ledger/mapper"
+	"github.com/Orange-Health/citadel/apps/event_ledger/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+type EventLedgerServiceInterface interface {
+	CheckEvent(eventType, businessId, eventPayload string, eventSentAt *time.Time) (
+		string, uint, *commonStructures.CommonError)
+	CompleteEvent(processedEventId uint) *commonStructures.CommonError
+	ReleaseEvent(processedEventId uint) *commonStructures.CommonError
+	GetProcessedEvents(filter structures.ProcessedEventsFilter) (
+		[]structures.ProcessedEvent, *commonStructures.CommonError)
+}
+
+// CheckEvent decides whether an event is to be processed, by comparing it with the processed events of its
+// entity. An event with the payload of the last processed event, or a redelivery of an earlier processed event,
+// is a duplicate. An event carrying a snapshot that was sent before the last processed one is stale. Duplicate
+// and stale events are recorded in the ledger along with the event they were compared with.
+// An event to be processed is claimed in the ledger as in progress right away, and the id of its claim is
+// returned, so that the same event delivered twice at once is processed only once. An event that loses the claim
+// is a duplicate. The claim is held for a lease, so that it lapses if the processing never finishes.
+func (eventLedgerService *EventLedgerService) CheckEvent(eventType, businessId, eventPayload string,
+	eventSentAt *time.Time) (string, uint, *commonStructures.CommonError) {
+
+	eventTypes, ok := commonConstants.EventLedgerStreams[eventType]
+	if !ok || businessId == "" {
+		return commonConstants.EVENT_LEDGER_DECISION_IN_PROGRESS, 0, nil
+	}
+
+	latestProcessedEvent, cErr := eventLedgerService.EventLedgerDao.GetLatestProcessedEvent(eventTypes,
+		businessId)
+	if cErr != nil {
+		return "", 0, cErr
+	}
+
+	payloadHash := getPayloadHash(eventPayload)
+	decision, comparedEventId := commonConstants.EVENT_LEDGER_DECISION_PROCESSED, latestProcessedEvent.Id
+	if latestProcessedEvent.Id != 0 && latestProcessedEvent.PayloadHash == payloadHash {
+		decision = commonConstants.EVENT_LEDGER_DECISION_DUPLICATE
+	} else if eventSentAt != nil {
+		isOrdered := commonUtils.SliceContainsString(commonConstants.EventLedgerOrderedEventTypes, eventType)
+		if isOrdered && latestProcessedEvent.EventSentAt != nil &&
+			eventSentAt.Before(*latestProcessedEvent.EventSentAt) {
+			decision = commonConstants.EVENT_LEDGER_DECISION_STALE
+		} else {
+			redeliveredEvent, cErr := eventLedgerService.EventLedgerDao.GetProcessedEventByPayloadHash(eventTypes,
+				businessId, payloadHash, *eventSentAt)
+			if cErr != nil {
+				return "", 0, cErr
+			}
+			if redeliveredEvent.Id != 0 {
+				decision, comparedEventId = commonConstants.EVENT_LEDGER_DECISION_DUPLICATE, redeliveredEvent.Id
+			}
+		}
+	}
+
+	if decision == commonConstants.EVENT_LEDGER_DECISION_PROCESSED {
+		processedEvent := mapper.MapNewProcessedEvent(eventType, businessId, payloadHash,
+			commonConstants.EVENT_LEDGER_DECISION_IN_PROGRESS, eventSentAt, 0)
+		leaseExpiresAt := commonUtils.GetCurrentTime().Add(commonConstants.EventLedgerClaimLease)
+		processedEvent.LeaseExpiresAt = &leaseExpiresAt
+		claimedEvent, isClaimed, cErr := eventLedgerService.EventLedgerDao.ClaimProcessedEvent(processedEvent)
+		if cErr != nil {
+			return "", 0, cErr
+		}
+		if isClaimed {
+			return commonConstants.EVENT_LEDGER_DECISION_IN_PROGRESS, claimedEvent.Id, nil
+		}
+		decision, comparedEventId = commonConstants.EVENT_LEDGER_DECISION_DUPLICATE, claimedEvent.Id
+	}
+
+	_, cErr = eventLedgerService.EventLedgerDao.CreateProcessedEvent(mapper.MapNewProcessedEvent(eventType,
+		businessId, payloadHash, decision, eventSentAt, comparedEventId))
+	if cErr != nil {
+		return "", 0, cErr
+	}
+
+	return decision, 0, nil
+}
+
+// CompleteEvent records the claimed event as processed once its handler succeeds.
+func (eventLedgerService *EventLedgerService) CompleteEvent(processedEventId uint) *commonStructures.CommonError {
+	if processedEventId == 0 {
+		return nil
+	}
+
+	return eventLedgerService.EventLedgerDao.CompleteProcessedEvent(processedEventId)
+}
+
+// ReleaseEvent drops the claim of an event whose processing failed, so that its redelivery is processed.
+func (eventLedgerService *EventLedgerService) ReleaseEvent(processedEventId uint) *commonStructures.CommonError {
+	if processedEventId == 0 {
+		return nil
+	}
+
+	return eventLedgerService.EventLedgerDao.DeleteProcessedEvent(processedEventId)
+}
+
+func (eventLedgerService *EventLedgerService) GetProcessedEvents(filter structures.ProcessedEventsFilter) (
+	[]structures.ProcessedEvent, *commonStructures.CommonError) {
+
+	if strings.TrimSpace(filter.BusinessId) == "" {
+		return []structures.ProcessedEvent{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_EVENT_LEDGER_BUSINESS_ID_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if filter.Decision != "" && !commonUtils.SliceContainsString(commonConstants.EVENT_LEDGER_DECISIONS,
+		filter.Decision) {
+		return []structures.ProcessedEvent{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_EVENT_LEDGER_DECISION,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = commonConstants.EventLedgerDefaultListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	processedEvents, cErr := eventLedgerService.EventLedgerDao.GetProcessedEvents(filter)
+	if cErr != nil {
+		return []structures.ProcessedEvent{}, cErr
+	}
+
+	return mapper.MapProcessedEvents(processedEvents), nil
+}
+
+func getPayloadHash(eventPayload string) string {
+	hash := sha256.Sum256([]byte(eventPayload))
+	return hex.EncodeToString(hash[:])
+}
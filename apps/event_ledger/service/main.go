@@ -0,0 +1,15 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/apps/event_ledger/dao"
+)
+
+type EventLedgerService struct {
+	EventLedgerDao dao.DataLayer
+}
+
+func InitializeEventLedgerService() EventLedgerServiceInterface {
+	return &EventLedgerService{
+		EventLedgerDao: dao.InitializeEventLedgerDao(),
+	}
+}
@@ -0,0 +1,42 @@
+package structures
+
+import (
+	"time"
+)
+
+// @swagger:model ProcessedEvent
+type ProcessedEvent struct {
+	// The ledger entry ID.
+	// example: 1
+	Id uint `json:"id"`
+	// example: "order.updated"
+	EventType string `json:"event_type"`
+	// The entity the event is about, like the order of an order event or the visit of a LIS event.
+	// example: "ORD1234567"
+	BusinessId string `json:"business_id"`
+	// The SHA-256 of the event payload.
+	// example: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	PayloadHash string `json:"payload_hash"`
+	// The time the event was sent to the queue.
+	// example: "2026-10-18T09:58:00Z"
+	EventSentAt *time.Time `json:"event_sent_at"`
+	// One of in_progress, processed, duplicate and stale.
+	// example: "processed"
+	Decision string `json:"decision"`
+	// The processed event a duplicate or stale event was compared with.
+	// example: 0
+	ProcessedEventId uint `json:"processed_event_id"`
+	// The time the claim of an in progress event lapses at.
+	// example: "2026-10-18T10:28:01Z"
+	LeaseExpiresAt *time.Time `json:"lease_expires_at"`
+	// example: "2026-10-18T09:58:01Z"
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type ProcessedEventsFilter struct {
+	BusinessId string
+	EventType  string
+	Decision   string
+	Limit      int
+	Offset     int
+}
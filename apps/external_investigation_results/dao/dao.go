@@ -17,10 +17,13 @@ import (
 type DataLayer interface {
 	UpsertInvestigations(investigations *[]commonModels.ExternalInvestigationResult) *commonStructures.CommonError
 	BulkDelete(systemExternalInvestigationIds []uint, deletedBy uint) *commonStructures.CommonError
-	UpdateContact(sourceContactId, newContactId uint) *commonStructures.CommonError
+	UpdateContactWithTx(tx *gorm.DB, sourceContactId, newContactId uint) *commonStructures.CommonError
 	GetInvestigations(
 		filters structures.ExternalInvestigationResultsDbFilters,
 	) (*[]commonModels.ExternalInvestigationResult, *commonStructures.CommonError)
+	GetLoincCodeMappings(
+		masterInvestigationIds []uint,
+	) ([]commonModels.ExternalInvestigationResult, *commonStructures.CommonError)
 }
 
 func (dao *ExternalInvestigationResultDao) UpsertInvestigations(
@@ -76,14 +79,15 @@ func (dao *ExternalInvestigationResultDao) BulkDelete(systemExternalInvestigatio
 	return nil
 }
 
-func (dao *ExternalInvestigationResultDao) UpdateContact(
+func (dao *ExternalInvestigationResultDao) UpdateContactWithTx(
+	tx *gorm.DB,
 	sourceContactId,
 	newContactId uint,
 ) *commonStructures.CommonError {
 	updates := map[string]interface{}{
 		"contact_id": newContactId,
 	}
-	err := dao.Db.Model(commonModels.ExternalInvestigationResult{}).
+	err := tx.Model(commonModels.ExternalInvestigationResult{}).
 		Where("contact_id = ?", sourceContactId).
 		Updates(updates).Error
 	if err != nil {
@@ -117,3 +121,17 @@ func (dao *ExternalInvestigationResultDao) GetInvestigations(
 	}
 	return &investigations, nil
 }
+
+func (dao *ExternalInvestigationResultDao) GetLoincCodeMappings(
+	masterInvestigationIds []uint,
+) ([]commonModels.ExternalInvestigationResult, *commonStructures.CommonError) {
+	var loincCodeMappings []commonModels.ExternalInvestigationResult
+	err := dao.Db.Model(commonModels.ExternalInvestigationResult{}).
+		Distinct("master_investigation_id", "master_investigation_method_mapping_id", "loinc_code").
+		Where("master_investigation_id IN ? AND loinc_code <> ''", masterInvestigationIds).
+		Find(&loincCodeMappings).Error
+	if err != nil {
+		return nil, commonUtils.HandleORMError(err)
+	}
+	return loincCodeMappings, nil
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"gorm.io/gorm"
+
 	"github.com/Orange-Health/citadel/apps/external_investigation_results/mapper"
 	"github.com/Orange-Health/citadel/apps/external_investigation_results/structures"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
@@ -10,10 +12,13 @@ import (
 type ExternalInvestigationResultServiceInterface interface {
 	BulkUpsertInvestigations(externalInvestigationResults *[]structures.ExternalInvestigateResultUpsertItem) (*[]commonModels.ExternalInvestigationResult, *commonStructures.CommonError)
 	BulkDeleteInvestigations(systemExternalInvestigationResultIds *[]uint, deletedBy uint) *commonStructures.CommonError
-	UpdateContact(sourceContactId, newContactId uint) *commonStructures.CommonError
+	UpdateContactWithTx(tx *gorm.DB, sourceContactId, newContactId uint) *commonStructures.CommonError
 	FetchInvestigations(
 		filters structures.ExternalInvestigationResultsDbFilters,
 	) (*[]commonModels.ExternalInvestigationResult, *commonStructures.CommonError)
+	GetLoincCodeMappings(
+		masterInvestigationIds []uint,
+	) ([]commonModels.ExternalInvestigationResult, *commonStructures.CommonError)
 }
 
 func (s *ExternalInvestigationResultService) BulkUpsertInvestigations(externalInvestigationResultsData *[]structures.ExternalInvestigateResultUpsertItem) (*[]commonModels.ExternalInvestigationResult, *commonStructures.CommonError) {
@@ -38,8 +43,9 @@ func (s *ExternalInvestigationResultService) BulkDeleteInvestigations(
 	return nil
 }
 
-func (s *ExternalInvestigationResultService) UpdateContact(sourceContactId, newContactId uint) *commonStructures.CommonError {
-	return s.ExtInvResDao.UpdateContact(sourceContactId, newContactId)
+func (s *ExternalInvestigationResultService) UpdateContactWithTx(tx *gorm.DB, sourceContactId,
+	newContactId uint) *commonStructures.CommonError {
+	return s.ExtInvResDao.UpdateContactWithTx(tx, sourceContactId, newContactId)
 }
 
 func (s *ExternalInvestigationResultService) FetchInvestigations(
@@ -47,3 +53,14 @@ func (s *ExternalInvestigationResultService) FetchInvestigations(
 ) (*[]commonModels.ExternalInvestigationResult, *commonStructures.CommonError) {
 	return s.ExtInvResDao.GetInvestigations(filters)
 }
+
+// GetLoincCodeMappings returns the distinct (investigation, method, LOINC) combinations seen on external
+// results, which is the only place we hold LOINC codes for our master investigations.
+func (s *ExternalInvestigationResultService) GetLoincCodeMappings(
+	masterInvestigationIds []uint,
+) ([]commonModels.ExternalInvestigationResult, *commonStructures.CommonError) {
+	if len(masterInvestigationIds) == 0 {
+		return []commonModels.ExternalInvestigationResult{}, nil
+	}
+	return s.ExtInvResDao.GetLoincCodeMappings(masterInvestigationIds)
+}
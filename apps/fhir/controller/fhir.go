@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Search Diagnostic Reports
+// @Description	Get the approved tests of an order as a FHIR R4 searchset bundle of DiagnosticReport resources with
+// @Description	their Observation, Patient and Practitioner resources included
+// @Tags			fhir
+// @Produce		json
+// @Param			order_id	query		string							true	"OMS Order ID"
+// @Success		200			{object}	structures.FhirBundle			"FHIR Bundle"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/fhir/DiagnosticReport [get]
+func (fhirController *Fhir) SearchDiagnosticReports(c *gin.Context) {
+	bundle, cErr := fhirController.FhirService.GetDiagnosticReportBundle(c.Request.Context(), c.Query("order_id"))
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.Header("Content-Type", commonConstants.FHIR_CONTENT_TYPE)
+	c.JSON(http.StatusOK, bundle)
+}
+
+// @Summary		Get Diagnostic Report
+// @Description	Get an approved test as a FHIR R4 DiagnosticReport resource
+// @Tags			fhir
+// @Produce		json
+// @Param			id			path		int								true	"Test Details ID"
+// @Success		200			{object}	structures.FhirDiagnosticReport	"FHIR DiagnosticReport"
+// @Failure		404,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/fhir/DiagnosticReport/{id} [get]
+func (fhirController *Fhir) GetDiagnosticReport(c *gin.Context) {
+	diagnosticReport, cErr := fhirController.FhirService.GetDiagnosticReport(c.Request.Context(),
+		commonUtils.ConvertStringToUint(c.Param("id")))
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.Header("Content-Type", commonConstants.FHIR_CONTENT_TYPE)
+	c.JSON(http.StatusOK, diagnosticReport)
+}
+
+// @Summary		Get Observation
+// @Description	Get an approved investigation as a FHIR R4 Observation resource
+// @Tags			fhir
+// @Produce		json
+// @Param			id			path		int								true	"Investigation Result ID"
+// @Success		200			{object}	structures.FhirObservation		"FHIR Observation"
+// @Failure		404,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/fhir/Observation/{id} [get]
+func (fhirController *Fhir) GetObservation(c *gin.Context) {
+	observation, cErr := fhirController.FhirService.GetObservation(c.Request.Context(),
+		commonUtils.ConvertStringToUint(c.Param("id")))
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.Header("Content-Type", commonConstants.FHIR_CONTENT_TYPE)
+	c.JSON(http.StatusOK, observation)
+}
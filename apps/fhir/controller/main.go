@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/fhir/service"
+)
+
+type Fhir struct {
+	FhirService service.FhirServiceInterface
+}
+
+func InitFhirController() *Fhir {
+	return &Fhir{
+		FhirService: service.InitializeFhirService(),
+	}
+}
@@ -0,0 +1,345 @@
+package mapper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/fhir/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+var referenceRangeRegex = regexp.MustCompile(`^\s*(-?\d+(?:\.\d+)?)\s*-\s*(-?\d+(?:\.\d+)?)\s*$`)
+
+func GetResourceReference(resourceType string, id uint) string {
+	return fmt.Sprintf("%s/%d", resourceType, id)
+}
+
+func formatDateTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func getLaboratoryCategory() []commonStructures.FhirCodeableConcept {
+	return []commonStructures.FhirCodeableConcept{
+		{
+			Coding: []commonStructures.FhirCoding{
+				{
+					System:  commonConstants.FHIR_SYSTEM_OBSERVATION_CATEGORY,
+					Code:    commonConstants.FHIR_OBSERVATION_CATEGORY_LAB,
+					Display: commonConstants.FHIR_OBSERVATION_CATEGORY_LAB_DISPLAY,
+				},
+			},
+		},
+	}
+}
+
+func getFhirGender(gender string) string {
+	switch strings.ToLower(gender) {
+	case "male", "female":
+		return strings.ToLower(gender)
+	case "":
+		return commonConstants.FHIR_GENDER_UNKNOWN
+	}
+	return commonConstants.FHIR_GENDER_OTHER
+}
+
+func getUcumCode(uom string) string {
+	if ucumCode, ok := commonConstants.UomToUcum[strings.ToLower(strings.TrimSpace(uom))]; ok {
+		return ucumCode
+	}
+	return ""
+}
+
+func getQuantity(value float64, uom string) *commonStructures.FhirQuantity {
+	quantity := commonStructures.FhirQuantity{
+		Value: &value,
+	}
+	if uom != "" {
+		quantity.Unit = uom
+		// Units without a known UCUM code are sent as display text only.
+		if ucumCode := getUcumCode(uom); ucumCode != "" {
+			quantity.System = commonConstants.FHIR_SYSTEM_UCUM
+			quantity.Code = ucumCode
+		}
+	}
+	return &quantity
+}
+
+func getInterpretation(abnormality string, isAbnormal bool) []commonStructures.FhirCodeableConcept {
+	interpretationCode, ok := commonConstants.AbnormalityToFhirInterpretation[abnormality]
+	if !ok {
+		if !isAbnormal {
+			return nil
+		}
+		interpretationCode = commonConstants.FHIR_INTERPRETATION_ABNORMAL
+	}
+	return []commonStructures.FhirCodeableConcept{
+		{
+			Coding: []commonStructures.FhirCoding{
+				{
+					System:  commonConstants.FHIR_SYSTEM_INTERPRETATION,
+					Code:    interpretationCode,
+					Display: commonConstants.FhirInterpretationDisplay[interpretationCode],
+				},
+			},
+		},
+	}
+}
+
+// getReferenceRange always carries the printed text and additionally fills low/high when the text is a
+// plain "low - high" numeric range.
+func getReferenceRange(referenceRangeText, uom string) []commonStructures.FhirObservationReferenceRange {
+	referenceRangeText = strings.TrimSpace(referenceRangeText)
+	if referenceRangeText == "" {
+		return nil
+	}
+
+	referenceRange := commonStructures.FhirObservationReferenceRange{
+		Text: referenceRangeText,
+	}
+	if matches := referenceRangeRegex.FindStringSubmatch(referenceRangeText); matches != nil {
+		low, lowErr := strconv.ParseFloat(matches[1], 64)
+		high, highErr := strconv.ParseFloat(matches[2], 64)
+		if lowErr == nil && highErr == nil {
+			referenceRange.Low = getQuantity(low, uom)
+			referenceRange.High = getQuantity(high, uom)
+		}
+	}
+	return []commonStructures.FhirObservationReferenceRange{referenceRange}
+}
+
+func MapPatient(patientDetails commonModels.PatientDetail) commonStructures.FhirPatient {
+	patient := commonStructures.FhirPatient{
+		ResourceType: commonConstants.FHIR_RESOURCE_TYPE_PATIENT,
+		Id:           fmt.Sprint(patientDetails.Id),
+		Name:         []commonStructures.FhirHumanName{{Text: patientDetails.Name}},
+		Gender:       getFhirGender(patientDetails.Gender),
+	}
+	if patientDetails.SystemPatientId != "" {
+		patient.Identifier = []commonStructures.FhirIdentifier{
+			{
+				System: commonConstants.FHIR_SYSTEM_SYSTEM_PATIENT_ID,
+				Value:  patientDetails.SystemPatientId,
+			},
+		}
+	}
+	if patientDetails.Number != "" {
+		patient.Telecom = []commonStructures.FhirContactPoint{{System: "phone", Value: patientDetails.Number}}
+	}
+	if patientDetails.Dob != nil {
+		patient.BirthDate = patientDetails.Dob.Format(commonConstants.FHIR_DATE_LAYOUT)
+	}
+	return patient
+}
+
+func MapPractitioner(user commonModels.User) commonStructures.FhirPractitioner {
+	practitioner := commonStructures.FhirPractitioner{
+		ResourceType: commonConstants.FHIR_RESOURCE_TYPE_PRACTITIONER,
+		Id:           fmt.Sprint(user.Id),
+		Name:         []commonStructures.FhirHumanName{{Text: user.UserName}},
+	}
+	if user.SystemUserId != "" {
+		practitioner.Identifier = []commonStructures.FhirIdentifier{
+			{
+				System: commonConstants.FHIR_SYSTEM_SYSTEM_USER_ID,
+				Value:  user.SystemUserId,
+			},
+		}
+	}
+	if user.Email != "" {
+		practitioner.Telecom = []commonStructures.FhirContactPoint{{System: "email", Value: user.Email}}
+	}
+	return practitioner
+}
+
+func MapObservation(investigationResult commonModels.InvestigationResult, loincCode string,
+	subject commonStructures.FhirReference, performers []commonStructures.FhirReference,
+) commonStructures.FhirObservation {
+	code := commonStructures.FhirCodeableConcept{
+		Text: investigationResult.InvestigationName,
+	}
+	if loincCode != "" {
+		code.Coding = append(code.Coding, commonStructures.FhirCoding{
+			System:  commonConstants.FHIR_SYSTEM_LOINC,
+			Code:    loincCode,
+			Display: investigationResult.InvestigationName,
+		})
+	}
+	if investigationResult.LisCode != "" {
+		code.Coding = append(code.Coding, commonStructures.FhirCoding{
+			System: commonConstants.FHIR_SYSTEM_LIS_CODE,
+			Code:   investigationResult.LisCode,
+		})
+	}
+
+	observation := commonStructures.FhirObservation{
+		ResourceType:   commonConstants.FHIR_RESOURCE_TYPE_OBSERVATION,
+		Id:             fmt.Sprint(investigationResult.Id),
+		Status:         commonConstants.FHIR_STATUS_FINAL,
+		Category:       getLaboratoryCategory(),
+		Code:           code,
+		Subject:        &subject,
+		Issued:         formatDateTime(investigationResult.ApprovedAt),
+		Performer:      performers,
+		Interpretation: getInterpretation(investigationResult.Abnormality, investigationResult.IsAbnormal),
+		ReferenceRange: getReferenceRange(investigationResult.ReferenceRangeText, investigationResult.Uom),
+	}
+	if value, err := strconv.ParseFloat(strings.TrimSpace(investigationResult.InvestigationValue), 64); err == nil {
+		observation.ValueQuantity = getQuantity(value, investigationResult.Uom)
+	} else {
+		observation.ValueString = investigationResult.InvestigationValue
+	}
+	if investigationResult.Method != "" {
+		observation.Method = &commonStructures.FhirCodeableConcept{Text: investigationResult.Method}
+	}
+	return observation
+}
+
+func MapDiagnosticReport(testDetail commonModels.TestDetail, orderDetails commonModels.OrderDetails,
+	subject commonStructures.FhirReference, performers []commonStructures.FhirReference,
+	observations []commonStructures.FhirObservation, issuedAt *time.Time,
+) commonStructures.FhirDiagnosticReport {
+	code := commonStructures.FhirCodeableConcept{
+		Text: testDetail.TestName,
+	}
+	if testDetail.LisCode != "" {
+		code.Coding = []commonStructures.FhirCoding{
+			{
+				System:  commonConstants.FHIR_SYSTEM_LIS_CODE,
+				Code:    testDetail.LisCode,
+				Display: testDetail.TestName,
+			},
+		}
+	}
+
+	results := []commonStructures.FhirReference{}
+	for _, observation := range observations {
+		results = append(results, commonStructures.FhirReference{
+			Reference: fmt.Sprintf("%s/%s", commonConstants.FHIR_RESOURCE_TYPE_OBSERVATION, observation.Id),
+			Display:   observation.Code.Text,
+		})
+	}
+
+	return commonStructures.FhirDiagnosticReport{
+		ResourceType: commonConstants.FHIR_RESOURCE_TYPE_DIAGNOSTIC_REPORT,
+		Id:           fmt.Sprint(testDetail.Id),
+		Identifier: []commonStructures.FhirIdentifier{
+			{
+				System: commonConstants.FHIR_SYSTEM_OMS_ORDER_ID,
+				Value:  testDetail.OmsOrderId,
+			},
+			{
+				System: commonConstants.FHIR_SYSTEM_OMS_TEST_ID,
+				Value:  testDetail.CentralOmsTestId,
+			},
+		},
+		Status: commonConstants.FHIR_STATUS_FINAL,
+		Category: []commonStructures.FhirCodeableConcept{
+			{
+				Coding: []commonStructures.FhirCoding{
+					{
+						System: commonConstants.FHIR_SYSTEM_DIAGNOSTIC_SERVICE,
+						Code:   commonConstants.FHIR_DIAGNOSTIC_SERVICE_LAB,
+					},
+				},
+				Text: testDetail.Department,
+			},
+		},
+		Code:               code,
+		Subject:            &subject,
+		EffectiveDateTime:  formatDateTime(orderDetails.CollectedOn),
+		Issued:             formatDateTime(issuedAt),
+		Performer:          performers,
+		ResultsInterpreter: performers,
+		Result:             results,
+	}
+}
+
+func getEntryFullUrl(resourceType, id string) string {
+	return fmt.Sprintf("%s/%s/%s", commonConstants.FhirBaseUrl, resourceType, id)
+}
+
+func getBundleResources(resources structures.FhirOrderResources) []interface{} {
+	bundleResources := []interface{}{}
+	for _, diagnosticReport := range resources.DiagnosticReports {
+		bundleResources = append(bundleResources, diagnosticReport)
+	}
+	for _, observation := range resources.Observations {
+		bundleResources = append(bundleResources, observation)
+	}
+	bundleResources = append(bundleResources, resources.Patient)
+	for _, practitioner := range resources.Practitioners {
+		bundleResources = append(bundleResources, practitioner)
+	}
+	return bundleResources
+}
+
+func getResourceTypeAndId(resource interface{}) (string, string) {
+	switch resource := resource.(type) {
+	case commonStructures.FhirDiagnosticReport:
+		return resource.ResourceType, resource.Id
+	case commonStructures.FhirObservation:
+		return resource.ResourceType, resource.Id
+	case commonStructures.FhirPatient:
+		return resource.ResourceType, resource.Id
+	case commonStructures.FhirPractitioner:
+		return resource.ResourceType, resource.Id
+	}
+	return "", ""
+}
+
+// MapSearchsetBundle returns the diagnostic reports as search matches and everything they reference as
+// included resources.
+func MapSearchsetBundle(resources structures.FhirOrderResources) commonStructures.FhirBundle {
+	total := len(resources.DiagnosticReports)
+	bundle := commonStructures.FhirBundle{
+		ResourceType: commonConstants.FHIR_RESOURCE_TYPE_BUNDLE,
+		Type:         commonConstants.FHIR_BUNDLE_TYPE_SEARCHSET,
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		Total:        &total,
+		Entry:        []commonStructures.FhirBundleEntry{},
+	}
+	for _, resource := range getBundleResources(resources) {
+		resourceType, id := getResourceTypeAndId(resource)
+		searchMode := commonConstants.FHIR_SEARCH_MODE_INCLUDE
+		if resourceType == commonConstants.FHIR_RESOURCE_TYPE_DIAGNOSTIC_REPORT {
+			searchMode = commonConstants.FHIR_SEARCH_MODE_MATCH
+		}
+		bundle.Entry = append(bundle.Entry, commonStructures.FhirBundleEntry{
+			FullUrl:  getEntryFullUrl(resourceType, id),
+			Resource: resource,
+			Search:   &commonStructures.FhirBundleEntrySearch{Mode: searchMode},
+		})
+	}
+	return bundle
+}
+
+// MapTransactionBundle uses PUT with our own ids so that re-releasing a report updates the partner's copy
+// instead of creating a duplicate.
+func MapTransactionBundle(resources structures.FhirOrderResources) commonStructures.FhirBundle {
+	bundle := commonStructures.FhirBundle{
+		ResourceType: commonConstants.FHIR_RESOURCE_TYPE_BUNDLE,
+		Type:         commonConstants.FHIR_BUNDLE_TYPE_TRANSACTION,
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		Entry:        []commonStructures.FhirBundleEntry{},
+	}
+	for _, resource := range getBundleResources(resources) {
+		resourceType, id := getResourceTypeAndId(resource)
+		bundle.Entry = append(bundle.Entry, commonStructures.FhirBundleEntry{
+			FullUrl:  getEntryFullUrl(resourceType, id),
+			Resource: resource,
+			Request: &commonStructures.FhirBundleEntryRequest{
+				Method: commonConstants.FHIR_HTTP_METHOD_PUT,
+				Url:    fmt.Sprintf("%s/%s", resourceType, id),
+			},
+		})
+	}
+	return bundle
+}
@@ -0,0 +1,17 @@
+package fhir
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/fhir/controller"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	tokenAuthMiddleware "github.com/Orange-Health/citadel/middlewares/token_auth"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	fhirController := controller.InitFhirController()
+
+	router.GET("/DiagnosticReport", tokenAuthMiddleware.Authenticate(commonConstants.HealthServiceName), fhirController.SearchDiagnosticReports)
+	router.GET("/DiagnosticReport/:id", tokenAuthMiddleware.Authenticate(commonConstants.HealthServiceName), fhirController.GetDiagnosticReport)
+	router.GET("/Observation/:id", tokenAuthMiddleware.Authenticate(commonConstants.HealthServiceName), fhirController.GetObservation)
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/fhir/mapper"
+	"github.com/Orange-Health/citadel/apps/fhir/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type FhirServiceInterface interface {
+	GetDiagnosticReportBundle(ctx context.Context, omsOrderId string) (
+		commonStructures.FhirBundle, *commonStructures.CommonError)
+	GetDiagnosticReport(ctx context.Context, testDetailsId uint) (
+		commonStructures.FhirDiagnosticReport, *commonStructures.CommonError)
+	GetObservation(ctx context.Context, investigationResultId uint) (
+		commonStructures.FhirObservation, *commonStructures.CommonError)
+	PushApprovedResults(ctx context.Context, omsOrderId string, testDetailsIds []uint) *commonStructures.CommonError
+}
+
+func (fhirService *FhirService) GetDiagnosticReportBundle(ctx context.Context, omsOrderId string) (
+	commonStructures.FhirBundle, *commonStructures.CommonError) {
+
+	if omsOrderId == "" {
+		return commonStructures.FhirBundle{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_FHIR_ORDER_ID_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	resources, cErr := fhirService.getOrderResources(ctx, omsOrderId, []uint{})
+	if cErr != nil {
+		return commonStructures.FhirBundle{}, cErr
+	}
+
+	return mapper.MapSearchsetBundle(resources), nil
+}
+
+func (fhirService *FhirService) GetDiagnosticReport(ctx context.Context, testDetailsId uint) (
+	commonStructures.FhirDiagnosticReport, *commonStructures.CommonError) {
+
+	testDetail, cErr := fhirService.TestDetailService.GetTestDetailModelById(testDetailsId)
+	if cErr != nil {
+		return commonStructures.FhirDiagnosticReport{}, cErr
+	}
+
+	resources, cErr := fhirService.getOrderResources(ctx, testDetail.OmsOrderId, []uint{testDetail.Id})
+	if cErr != nil {
+		return commonStructures.FhirDiagnosticReport{}, cErr
+	}
+
+	return resources.DiagnosticReports[0], nil
+}
+
+func (fhirService *FhirService) GetObservation(ctx context.Context, investigationResultId uint) (
+	commonStructures.FhirObservation, *commonStructures.CommonError) {
+
+	investigationResults, cErr := fhirService.InvestigationResultsService.GetInvestigationsByInvestigationIds(
+		[]uint{investigationResultId})
+	if cErr != nil {
+		return commonStructures.FhirObservation{}, cErr
+	}
+	if len(investigationResults) == 0 {
+		return commonStructures.FhirObservation{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_FHIR_RESOURCE_NOT_FOUND,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	testDetail, cErr := fhirService.TestDetailService.GetTestDetailModelById(investigationResults[0].TestDetailsId)
+	if cErr != nil {
+		return commonStructures.FhirObservation{}, cErr
+	}
+
+	resources, cErr := fhirService.getOrderResources(ctx, testDetail.OmsOrderId, []uint{testDetail.Id})
+	if cErr != nil {
+		return commonStructures.FhirObservation{}, cErr
+	}
+
+	for _, observation := range resources.Observations {
+		if observation.Id == fmt.Sprint(investigationResultId) {
+			return observation, nil
+		}
+	}
+
+	return commonStructures.FhirObservation{}, &commonStructures.CommonError{
+		Message:    commonConstants.ERROR_FHIR_RESOURCE_NOT_FOUND,
+		StatusCode: http.StatusNotFound,
+	}
+}
+
+// PushApprovedResults sends the released tests of the order as a transaction bundle to the order's partner.
+// Orders of partners without a configured FHIR endpoint are skipped.
+func (fhirService *FhirService) PushApprovedResults(ctx context.Context, omsOrderId string,
+	testDetailsIds []uint) *commonStructures.CommonError {
+
+	if len(testDetailsIds) == 0 {
+		return nil
+	}
+
+	orderDetails, cErr := fhirService.OrderDetailsService.GetOrderDetailsByOmsOrderId(omsOrderId)
+	if cErr != nil {
+		return cErr
+	}
+	if !fhirService.FhirClient.IsPartnerConfigured(orderDetails.PartnerId) {
+		return nil
+	}
+
+	resources, cErr := fhirService.getOrderResources(ctx, omsOrderId, testDetailsIds)
+	if cErr != nil {
+		return cErr
+	}
+
+	return fhirService.FhirClient.PushBundle(ctx, resources.PartnerId, mapper.MapTransactionBundle(resources))
+}
+
+// getOrderResources builds the FHIR resources of the approved tests of an order, restricted to
+// testDetailsIds when any are passed.
+func (fhirService *FhirService) getOrderResources(ctx context.Context, omsOrderId string,
+	testDetailsIds []uint) (structures.FhirOrderResources, *commonStructures.CommonError) {
+
+	orderDetails, cErr := fhirService.OrderDetailsService.GetOrderDetailsByOmsOrderId(omsOrderId)
+	if cErr != nil {
+		return structures.FhirOrderResources{}, cErr
+	}
+
+	testDetails, cErr := fhirService.TestDetailService.GetTestDetailsByOmsOrderId(omsOrderId)
+	if cErr != nil {
+		return structures.FhirOrderResources{}, cErr
+	}
+
+	approvedTestDetails, approvedTestDetailsIds := []commonModels.TestDetail{}, []uint{}
+	for _, testDetail := range testDetails {
+		if testDetail.Status != commonConstants.TEST_STATUS_APPROVE {
+			continue
+		}
+		if len(testDetailsIds) > 0 && !commonUtils.SliceContainsUint(testDetailsIds, testDetail.Id) {
+			continue
+		}
+		approvedTestDetails = append(approvedTestDetails, testDetail)
+		approvedTestDetailsIds = append(approvedTestDetailsIds, testDetail.Id)
+	}
+
+	if len(approvedTestDetails) == 0 {
+		return structures.FhirOrderResources{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_FHIR_NO_APPROVED_RESULTS,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+
+	patientDetails, cErr := fhirService.PatientDetailsService.GetPatientDetailsById(orderDetails.PatientDetailsId)
+	if cErr != nil {
+		return structures.FhirOrderResources{}, cErr
+	}
+
+	investigationResults, cErr := fhirService.getReportableInvestigationResults(approvedTestDetailsIds)
+	if cErr != nil {
+		return structures.FhirOrderResources{}, cErr
+	}
+
+	approvedByIds, masterInvestigationIds := []uint{}, []uint{}
+	for _, investigationResult := range investigationResults {
+		approvedByIds = append(approvedByIds, investigationResult.ApprovedBy)
+		masterInvestigationIds = append(masterInvestigationIds, investigationResult.MasterInvestigationId)
+	}
+
+	approvedByUsers, cErr := fhirService.UserService.GetUsersByIds(commonUtils.CreateUniqueSliceUint(approvedByIds))
+	if cErr != nil {
+		return structures.FhirOrderResources{}, cErr
+	}
+
+	loincCodeMappings, cErr := fhirService.ExternalInvestigationResultService.GetLoincCodeMappings(
+		commonUtils.CreateUniqueSliceUint(masterInvestigationIds))
+	if cErr != nil {
+		return structures.FhirOrderResources{}, cErr
+	}
+
+	resources := structures.FhirOrderResources{
+		PartnerId:     orderDetails.PartnerId,
+		Patient:       mapper.MapPatient(patientDetails),
+		Practitioners: []commonStructures.FhirPractitioner{},
+	}
+	subject := commonStructures.FhirReference{
+		Reference: mapper.GetResourceReference(commonConstants.FHIR_RESOURCE_TYPE_PATIENT, patientDetails.Id),
+		Display:   patientDetails.Name,
+	}
+
+	practitionerReferenceMap := map[uint]commonStructures.FhirReference{}
+	for _, user := range approvedByUsers {
+		resources.Practitioners = append(resources.Practitioners, mapper.MapPractitioner(user))
+		practitionerReferenceMap[user.Id] = commonStructures.FhirReference{
+			Reference: mapper.GetResourceReference(commonConstants.FHIR_RESOURCE_TYPE_PRACTITIONER, user.Id),
+			Display:   user.UserName,
+		}
+	}
+
+	testDetailsIdToInvestigationResultsMap := map[uint][]commonModels.InvestigationResult{}
+	for _, investigationResult := range investigationResults {
+		testDetailsIdToInvestigationResultsMap[investigationResult.TestDetailsId] = append(
+			testDetailsIdToInvestigationResultsMap[investigationResult.TestDetailsId], investigationResult)
+	}
+
+	for _, testDetail := range approvedTestDetails {
+		observations, performers := []commonStructures.FhirObservation{}, []commonStructures.FhirReference{}
+		performerIds := []uint{}
+		var issuedAt *time.Time
+		for _, investigationResult := range testDetailsIdToInvestigationResultsMap[testDetail.Id] {
+			observationPerformers := []commonStructures.FhirReference{}
+			if practitionerReference, ok := practitionerReferenceMap[investigationResult.ApprovedBy]; ok {
+				observationPerformers = append(observationPerformers, practitionerReference)
+				if !commonUtils.SliceContainsUint(performerIds, investigationResult.ApprovedBy) {
+					performerIds = append(performerIds, investigationResult.ApprovedBy)
+					performers = append(performers, practitionerReference)
+				}
+			}
+			if investigationResult.ApprovedAt != nil && (issuedAt == nil || investigationResult.ApprovedAt.After(*issuedAt)) {
+				issuedAt = investigationResult.ApprovedAt
+			}
+			observations = append(observations, mapper.MapObservation(investigationResult,
+				getLoincCode(loincCodeMappings, investigationResult), subject, observationPerformers))
+		}
+		resources.Observations = append(resources.Observations, observations...)
+		resources.DiagnosticReports = append(resources.DiagnosticReports, mapper.MapDiagnosticReport(testDetail,
+			orderDetails, subject, performers, observations, issuedAt))
+	}
+
+	return resources, nil
+}
+
+// getReportableInvestigationResults returns the approved, reportable investigations of the tests with the
+// long-form values from investigation_data applied.
+func (fhirService *FhirService) getReportableInvestigationResults(testDetailsIds []uint) (
+	[]commonModels.InvestigationResult, *commonStructures.CommonError) {
+
+	investigationResults, cErr := fhirService.InvestigationResultsService.GetInvestigationResultsByTestDetailsIds(
+		testDetailsIds)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	reportableInvestigationResults, investigationResultIds := []commonModels.InvestigationResult{}, []uint{}
+	for _, investigationResult := range investigationResults {
+		if investigationResult.InvestigationStatus != commonConstants.INVESTIGATION_STATUS_APPROVE ||
+			investigationResult.IsNonReportable {
+			continue
+		}
+		reportableInvestigationResults = append(reportableInvestigationResults, investigationResult)
+		investigationResultIds = append(investigationResultIds, investigationResult.Id)
+	}
+
+	if len(investigationResultIds) == 0 {
+		return reportableInvestigationResults, nil
+	}
+
+	investigationsData, cErr := fhirService.InvestigationResultsService.GetInvestigationDataByInvestigationResultsIds(
+		investigationResultIds)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	investigationIdToInvestigationDataMap := map[uint]commonModels.InvestigationData{}
+	for _, investigationData := range investigationsData {
+		investigationIdToInvestigationDataMap[investigationData.InvestigationResultId] = investigationData
+	}
+
+	for index := range reportableInvestigationResults {
+		if investigationData, ok := investigationIdToInvestigationDataMap[reportableInvestigationResults[index].Id]; ok {
+			reportableInvestigationResults[index].InvestigationValue = investigationData.Data
+		}
+	}
+
+	return reportableInvestigationResults, nil
+}
+
+// getLoincCode prefers a LOINC code recorded against the exact method of the investigation and falls back to
+// one recorded against the investigation itself.
+func getLoincCode(loincCodeMappings []commonModels.ExternalInvestigationResult,
+	investigationResult commonModels.InvestigationResult) string {
+
+	loincCode := ""
+	for _, loincCodeMapping := range loincCodeMappings {
+		if loincCodeMapping.MasterInvestigationId != investigationResult.MasterInvestigationId {
+			continue
+		}
+		if investigationResult.MasterInvestigationMethodMappingId != 0 &&
+			loincCodeMapping.MasterInvestigationMethodMappingId == investigationResult.MasterInvestigationMethodMappingId {
+			return loincCodeMapping.LoincCode
+		}
+		if loincCode == "" {
+			loincCode = loincCodeMapping.LoincCode
+		}
+	}
+	return loincCode
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	externalInvestigationResultService "github.com/Orange-Health/citadel/apps/external_investigation_results/service"
+	investigationResultsService "github.com/Orange-Health/citadel/apps/investigation_results/service"
+	orderDetailsService "github.com/Orange-Health/citadel/apps/order_details/service"
+	patientDetailsService "github.com/Orange-Health/citadel/apps/patient_details/service"
+	testDetailService "github.com/Orange-Health/citadel/apps/test_detail/service"
+	userService "github.com/Orange-Health/citadel/apps/users/service"
+	fhirClient "github.com/Orange-Health/citadel/clients/fhir"
+)
+
+type FhirService struct {
+	Sentry                             sentry.SentryLayer
+	OrderDetailsService                orderDetailsService.OrderDetailsServiceInterface
+	PatientDetailsService              patientDetailsService.PatientDetailServiceInterface
+	TestDetailService                  testDetailService.TestDetailServiceInterface
+	InvestigationResultsService        investigationResultsService.InvestigationResultServiceInterface
+	UserService                        userService.UserServiceInterface
+	ExternalInvestigationResultService externalInvestigationResultService.ExternalInvestigationResultServiceInterface
+	FhirClient                         fhirClient.FhirClientInterface
+}
+
+func InitializeFhirService() FhirServiceInterface {
+	return &FhirService{
+		Sentry:                             sentry.InitializeSentry(),
+		OrderDetailsService:                orderDetailsService.InitializeOrderDetailsService(),
+		PatientDetailsService:              patientDetailsService.InitializePatientDetailService(),
+		TestDetailService:                  testDetailService.InitializeTestDetailService(),
+		InvestigationResultsService:        investigationResultsService.InitializeInvestigationResultService(),
+		UserService:                        userService.InitializeUserService(),
+		ExternalInvestigationResultService: externalInvestigationResultService.InitializeExternalInvestigationResultService(),
+		FhirClient:                         fhirClient.InitializeFhirClient(),
+	}
+}
@@ -0,0 +1,13 @@
+package structures
+
+import (
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+)
+
+type FhirOrderResources struct {
+	PartnerId         uint
+	Patient           commonStructures.FhirPatient
+	Practitioners     []commonStructures.FhirPractitioner
+	DiagnosticReports []commonStructures.FhirDiagnosticReport
+	Observations      []commonStructures.FhirObservation
+}
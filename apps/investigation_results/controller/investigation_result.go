@@ -170,7 +170,8 @@ func (investigationResultController *InvestigationResult) GetDerivedInvestigatio
 	}
 
 	modifyValueResponse, cErr := investigationResultController.InvResService.GetDerivedInvestigationsAndAbnormality(
-		c.Request.Context(), taskId, masterInvestigationId, investigationValue, modifyValueApiRequest.PastInvestigations)
+		c.Request.Context(), taskId, masterInvestigationId, investigationValue, modifyValueApiRequest.PastInvestigations,
+		modifyValueApiRequest.PatientWeightKg, modifyValueApiRequest.PatientHeightCm)
 	if cErr != nil {
 		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
 		return
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/Orange-Health/citadel/apps/investigation_results/structures"
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
@@ -42,6 +43,8 @@ type DataLayer interface {
 	UpdateInvestigationResultsWithTx(tx *gorm.DB,
 		investigationResults []commonModels.InvestigationResult) (
 		[]commonModels.InvestigationResult, *commonStructures.CommonError)
+	GetInvestigationsByIdsForUpdateWithTx(tx *gorm.DB, investigationIds []uint) (
+		[]commonModels.InvestigationResult, *commonStructures.CommonError)
 	CreateInvestigationDataWithTx(tx *gorm.DB,
 		invData []commonModels.InvestigationData) (
 		[]commonModels.InvestigationData, *commonStructures.CommonError)
@@ -91,8 +94,21 @@ func getSelectStringsForInvestigationResults() []string {
 		"investigation_results_metadata.qc_value as qc_value",
 		"investigation_results_metadata.qc_west_gard_warning as qc_west_gard_warning",
 		"investigation_results_metadata.qc_status as qc_status",
+		"investigation_results_metadata.delta_check_status as delta_check_status",
+		"investigation_results_metadata.delta_previous_value as delta_previous_value",
+		"investigation_results_metadata.delta_previous_approved_at as delta_previous_approved_at",
+		"investigation_results_metadata.delta_absolute as delta_absolute",
+		"investigation_results_metadata.delta_percentage as delta_percentage",
+		"investigation_results_metadata.delta_rate_per_day as delta_rate_per_day",
+		"investigation_results_metadata.delta_violated_rule as delta_violated_rule",
+		"investigation_results_metadata.delta_check_remarks as delta_check_remarks",
+		"investigation_results_metadata.westgard_status as westgard_status",
+		"investigation_results_metadata.westgard_violations as westgard_violations",
+		"investigation_results_metadata.auto_verification_evaluations as auto_verification_evaluations",
 		"test_details.master_test_id as master_test_id",
+		"investigation_results.row_version as row_version",
 		"test_details.status as test_details_status",
+		"test_details.row_version as test_details_row_version",
 		"test_details.lab_id as processing_lab_id",
 		"test_details_metadata.barcodes as barcodes",
 	}
@@ -104,7 +120,7 @@ func getSelectStringsForInvestigationResultsV1() []string {
 		"investigation_value", "device_value", "result_representation_type", "department", "uom", "method",
 		"method_type", "investigation_status", "reference_range_text", "lis_code", "abnormality", "is_abnormal",
 		"approved_by", "approved_at", "entered_by", "entered_at", "is_auto_approved", "is_critical",
-		"auto_approval_failure_reason",
+		"auto_approval_failure_reason", "row_version",
 	}
 }
 
@@ -208,6 +224,18 @@ func (ird *InvestigationResultDao) CreateInvestigationResultsWithTx(tx *gorm.DB,
 	return investigationResults, nil
 }
 
+func (ird *InvestigationResultDao) GetInvestigationsByIdsForUpdateWithTx(tx *gorm.DB, investigationIds []uint) (
+	[]commonModels.InvestigationResult, *commonStructures.CommonError) {
+
+	var invResults []commonModels.InvestigationResult
+	err := tx.Clauses(clause.Locking{Strength: commonConstants.CLAUSE_UPDATE}).
+		Where("id IN (?)", investigationIds).Order("id").Find(&invResults).Error
+	if err != nil {
+		return nil, commonUtils.HandleORMError(err)
+	}
+	return invResults, nil
+}
+
 func (ird *InvestigationResultDao) UpdateInvestigationResultsWithTx(tx *gorm.DB,
 	investigationResults []commonModels.InvestigationResult) (
 	[]commonModels.InvestigationResult, *commonStructures.CommonError) {
@@ -292,6 +320,7 @@ func (ird *InvestigationResultDao) GetTestDetailsByTaskId(taskId uint) (
 		"test_details.status as status",
 		"test_details.lab_id as lab_id",
 		"test_details.cp_enabled as cp_enabled",
+		"test_details.row_version as row_version",
 		"test_details_metadata.barcodes as barcodes",
 	}
 
@@ -331,6 +360,8 @@ func (ird *InvestigationResultDao) GetDetailsForAbnormality(taskId uint) (
 		"patient_details.dob as patient_dob",
 		"patient_details.expected_dob as patient_expected_dob",
 		"patient_details.gender as patient_gender",
+		"patient_details.is_pregnant as patient_is_pregnant",
+		"patient_details.trimester as patient_trimester",
 	}
 
 	if err := ird.Db.Table(commonConstants.TableTasks).
@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"encoding/json"
 	"sort"
 	"strings"
 
@@ -51,6 +52,21 @@ func MapInvResultFromDbStruct(invResult structures.InvestigationResultDbResponse
 		QcValue:                   invResult.QcValue,
 		QcWestGardWarning:         invResult.QcWestGardWarning,
 		QcStatus:                  invResult.QcStatus,
+		DeltaCheckStatus:          invResult.DeltaCheckStatus,
+		DeltaPreviousValue:        invResult.DeltaPreviousValue,
+		DeltaPreviousApprovedAt:   commonUtils.GetTimeInString(invResult.DeltaPreviousApprovedAt),
+		DeltaAbsolute:             invResult.DeltaAbsolute,
+		DeltaPercentage:           invResult.DeltaPercentage,
+		DeltaRatePerDay:           invResult.DeltaRatePerDay,
+		DeltaViolatedRule:         invResult.DeltaViolatedRule,
+		DeltaCheckRemarks:         invResult.DeltaCheckRemarks,
+		WestgardStatus:            invResult.WestgardStatus,
+		WestgardViolations:        invResult.WestgardViolations,
+		RowVersion:                invResult.RowVersion,
+		TestDetailsRowVersion:     invResult.TestDetailsRowVersion,
+	}
+	if invResult.AutoVerificationEvaluations != "" {
+		invRes.AutoVerificationEvaluations = json.RawMessage(invResult.AutoVerificationEvaluations)
 	}
 
 	invRes.Id = invResult.Id
@@ -254,6 +270,7 @@ func MapInvestigationResultsDbStructsWithMasterTests(invResults []structures.Inv
 			invResult.TestDetailsStatus = testDetailsMap[invResult.TestDetailsId].Status
 			invResult.MasterTestId = testDetailsMap[invResult.TestDetailsId].MasterTestId
 			invResult.Barcodes = testDetailsMap[invResult.TestDetailsId].Barcodes
+			invResult.TestDetailsRowVersion = testDetailsMap[invResult.TestDetailsId].RowVersion
 
 			finalInvestigationResults = append(finalInvestigationResults, invResult)
 		}
@@ -9,6 +9,7 @@ import (
 
 	"gorm.io/gorm"
 
+	calculationsStructures "github.com/Orange-Health/citadel/apps/calculations/structures"
 	mapper "github.com/Orange-Health/citadel/apps/investigation_results/mapper"
 	"github.com/Orange-Health/citadel/apps/investigation_results/structures"
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
@@ -38,8 +39,8 @@ type InvestigationResultServiceInterface interface {
 	GetInvestigationAbnormality(ctx context.Context, taskId uint, investigationCode string,
 		investigationValue string) (string, *commonStructures.CommonError)
 	GetDerivedInvestigationsAndAbnormality(ctx context.Context, taskId uint, masterInvestigationId uint,
-		investigationValue string, modifyValueRequest []structures.ModifyValueRequest) (
-		map[uint]structures.ModifyValueResponse, *commonStructures.CommonError)
+		investigationValue string, modifyValueRequest []structures.ModifyValueRequest,
+		patientWeightKg, patientHeightCm *float64) (map[uint]structures.ModifyValueResponse, *commonStructures.CommonError)
 	GetInvestigationResultsByTaskIdAndOmsTestId(taskId uint, omsTestId string) (
 		[]commonModels.InvestigationResult, *commonStructures.CommonError)
 	GetInvestigationResultsMetadataByInvestigationResultIds(ctx context.Context,
@@ -51,6 +52,8 @@ type InvestigationResultServiceInterface interface {
 	UpdateInvestigationResultsWithTx(tx *gorm.DB,
 		investigationResults []commonModels.InvestigationResult) (
 		[]commonModels.InvestigationResult, *commonStructures.CommonError)
+	GetInvestigationsByIdsForUpdateWithTx(tx *gorm.DB, investigationIds []uint) (
+		[]commonModels.InvestigationResult, *commonStructures.CommonError)
 	DeleteInvestigationResultsByIdsWithTx(tx *gorm.DB,
 		investigationIds []uint) *commonStructures.CommonError
 	UpdateInvestigationsDataWithTx(tx *gorm.DB,
@@ -432,6 +435,16 @@ func (invResService *InvestigationResultService) CreateInvestigationResultsWithT
 	return invResService.InvResDao.CreateInvestigationResultsWithTx(tx, investigationResults)
 }
 
+func (invResService *InvestigationResultService) GetInvestigationsByIdsForUpdateWithTx(tx *gorm.DB,
+	investigationIds []uint) ([]commonModels.InvestigationResult, *commonStructures.CommonError) {
+
+	if len(investigationIds) == 0 {
+		return []commonModels.InvestigationResult{}, nil
+	}
+
+	return invResService.InvResDao.GetInvestigationsByIdsForUpdateWithTx(tx, investigationIds)
+}
+
 func (invResService *InvestigationResultService) UpdateInvestigationResultsWithTx(tx *gorm.DB,
 	investigationResults []commonModels.InvestigationResult) (
 	[]commonModels.InvestigationResult, *commonStructures.CommonError) {
@@ -490,14 +503,16 @@ func (invResService *InvestigationResultService) GetInvestigationAbnormality(ctx
 			StatusCode: http.StatusInternalServerError,
 		}
 	}
+	investigations = commonUtils.ResolveInvestigationReferenceRanges(investigations,
+		getReferenceRangePatientDetails(abnormalityDetails))
 	investigation := investigations[0]
 
 	return invResService.AbnormalityService.GetInvestigationAbnormality(ctx, investigationValue, investigation), nil
 }
 
 func (invResService *InvestigationResultService) GetDerivedInvestigationsAndAbnormality(ctx context.Context, taskId uint,
-	masterInvestigationId uint, investigationValue string, modifyValueRequest []structures.ModifyValueRequest) (
-	map[uint]structures.ModifyValueResponse, *commonStructures.CommonError) {
+	masterInvestigationId uint, investigationValue string, modifyValueRequest []structures.ModifyValueRequest,
+	patientWeightKg, patientHeightCm *float64) (map[uint]structures.ModifyValueResponse, *commonStructures.CommonError) {
 
 	abnormalityDetails, cErr := invResService.InvResDao.GetDetailsForAbnormality(taskId)
 	if cErr != nil {
@@ -522,12 +537,24 @@ func (invResService *InvestigationResultService) GetDerivedInvestigationsAndAbno
 			StatusCode: http.StatusInternalServerError,
 		}
 	}
+	dependentInvestigationsApiResponse = commonUtils.ResolveDependentInvestigationReferenceRanges(
+		dependentInvestigationsApiResponse, getReferenceRangePatientDetails(abnormalityDetails))
 
 	investigationResults, cErr := invResService.InvResDao.GetInvestigationResultModelsByTaskId(taskId)
 	if cErr != nil {
 		return nil, cErr
 	}
 
+	formulaVariables := calculationsStructures.FormulaVariables{
+		PatientDob:    abnormalityDetails.PatientExpectedDob,
+		PatientGender: abnormalityDetails.PatientGender,
+		WeightKg:      patientWeightKg,
+		HeightCm:      patientHeightCm,
+	}
+	if abnormalityDetails.PatientDob != nil {
+		formulaVariables.PatientDob = abnormalityDetails.PatientDob
+	}
+
 	masterInvestigationIdToNewInvestigationValueMap := make(map[uint]string)
 	for _, modifyValueRequest := range modifyValueRequest {
 		masterInvestigationIdToNewInvestigationValueMap[modifyValueRequest.MasterInvestigationId] =
@@ -561,7 +588,7 @@ func (invResService *InvestigationResultService) GetDerivedInvestigationsAndAbno
 
 			modifiedValue, cErr := invResService.CalculationsService.GetModifiedValue(ctx, derivedInvestigation.Formula,
 				derivedInvestigation.Decimals, derivedInvestigation.DependentInvestigationIds,
-				masterInvestigationIdToInvestigationResultMap)
+				masterInvestigationIdToInvestigationResultMap, formulaVariables)
 			if cErr != nil {
 				return nil, cErr
 			}
@@ -649,3 +676,10 @@ func (invResService *InvestigationResultService) DeleteInvestigationResultsMetad
 
 	return invResService.InvResDao.DeleteInvestigationResultsMetadataByIdsWithTx(tx, investigationResultsMetadataIds)
 }
+
+func getReferenceRangePatientDetails(
+	abnormalityDetails structures.BasicAbnormalityStruct) commonStructures.ReferenceRangePatientDetails {
+	return commonUtils.GetReferenceRangePatientDetails(abnormalityDetails.PatientDob,
+		abnormalityDetails.PatientExpectedDob, abnormalityDetails.PatientGender, abnormalityDetails.PatientIsPregnant,
+		abnormalityDetails.PatientTrimester)
+}
@@ -14,6 +14,8 @@ type BasicAbnormalityStruct struct {
 	PatientDob         *time.Time `json:"patient_dob"`
 	PatientExpectedDob *time.Time `json:"patient_expected_dob"`
 	PatientGender      string     `json:"patient_gender"`
+	PatientIsPregnant  bool       `json:"patient_is_pregnant"`
+	PatientTrimester   uint       `json:"patient_trimester"`
 }
 
 type ModifyValueRequest struct {
@@ -24,4 +26,6 @@ type ModifyValueRequest struct {
 type ModifyValueApiRequest struct {
 	CurrentInvestigation ModifyValueRequest   `json:"current_investigation"`
 	PastInvestigations   []ModifyValueRequest `json:"past_investigations"`
+	PatientWeightKg      *float64             `json:"patient_weight_kg,omitempty"`
+	PatientHeightCm      *float64             `json:"patient_height_cm,omitempty"`
 }
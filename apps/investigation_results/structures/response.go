@@ -1,6 +1,7 @@
 package structures
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -107,6 +108,24 @@ type InvestigationResult struct {
 	QcValue           string `json:"qc_value,omitempty"`
 	QcWestGardWarning string `json:"qc_west_gard_warning,omitempty"`
 	QcStatus          string `json:"qc_status,omitempty"`
+	// The Delta Check fields explain the comparison against the patient's previous value.
+	DeltaCheckStatus        string   `json:"delta_check_status,omitempty"`
+	DeltaPreviousValue      string   `json:"delta_previous_value,omitempty"`
+	DeltaPreviousApprovedAt string   `json:"delta_previous_approved_at,omitempty"`
+	DeltaAbsolute           *float64 `json:"delta_absolute,omitempty"`
+	DeltaPercentage         *float64 `json:"delta_percentage,omitempty"`
+	DeltaRatePerDay         *float64 `json:"delta_rate_per_day,omitempty"`
+	DeltaViolatedRule       string   `json:"delta_violated_rule,omitempty"`
+	DeltaCheckRemarks       string   `json:"delta_check_remarks,omitempty"`
+	// The Westgard fields carry the QC evaluation done in Citadel over the QC history of the lot.
+	WestgardStatus     string `json:"westgard_status,omitempty"`
+	WestgardViolations string `json:"westgard_violations,omitempty"`
+	// AutoVerificationEvaluations lists the outcome of every auto verification rule evaluated.
+	AutoVerificationEvaluations json.RawMessage `json:"auto_verification_evaluations,omitempty"`
+	// The row versions of the investigation result and its test, sent back while updating the task details.
+	// example: 3
+	RowVersion            uint64 `json:"row_version"`
+	TestDetailsRowVersion uint64 `json:"test_details_row_version"`
 }
 
 // @swagger:response Remark
@@ -239,7 +258,20 @@ type InvestigationResultDbResponse struct {
 	QcValue                            string     `json:"qc_value,omitempty"`
 	QcWestGardWarning                  string     `json:"qc_west_gard_warning,omitempty"`
 	QcStatus                           string     `json:"qc_status,omitempty"`
+	DeltaCheckStatus                   string     `json:"delta_check_status,omitempty"`
+	DeltaPreviousValue                 string     `json:"delta_previous_value,omitempty"`
+	DeltaPreviousApprovedAt            *time.Time `json:"delta_previous_approved_at,omitempty"`
+	DeltaAbsolute                      *float64   `json:"delta_absolute,omitempty"`
+	DeltaPercentage                    *float64   `json:"delta_percentage,omitempty"`
+	DeltaRatePerDay                    *float64   `json:"delta_rate_per_day,omitempty"`
+	DeltaViolatedRule                  string     `json:"delta_violated_rule,omitempty"`
+	DeltaCheckRemarks                  string     `json:"delta_check_remarks,omitempty"`
+	WestgardStatus                     string     `json:"westgard_status,omitempty"`
+	WestgardViolations                 string     `json:"westgard_violations,omitempty"`
+	AutoVerificationEvaluations        string     `json:"auto_verification_evaluations,omitempty"`
 	CpEnabled                          bool       `json:"cp_enabled,omitempty"`
+	RowVersion                         uint64     `json:"row_version"`
+	TestDetailsRowVersion              uint64     `json:"test_details_row_version"`
 }
 
 type TestDetailsDbResponse struct {
@@ -249,4 +281,5 @@ type TestDetailsDbResponse struct {
 	LabId        uint   `json:"lab_id"`
 	CpEnabled    bool   `json:"cp_enabled"`
 	Barcodes     string `json:"barcodes"`
+	RowVersion   uint64 `json:"row_version"`
 }
@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetHl7LisResultByVisitId(visitId string) (commonModels.Hl7LisResult, *commonStructures.CommonError)
+	GetHl7LisResultForUpdateWithTx(tx *gorm.DB, visitId string) (commonModels.Hl7LisResult,
+		*commonStructures.CommonError)
+	UpdateHl7LisResultWithTx(tx *gorm.DB, hl7LisResult commonModels.Hl7LisResult) (commonModels.Hl7LisResult,
+		*commonStructures.CommonError)
+}
+
+func (lisDao *LisDao) GetHl7LisResultByVisitId(visitId string) (commonModels.Hl7LisResult,
+	*commonStructures.CommonError) {
+
+	hl7LisResult := commonModels.Hl7LisResult{}
+	err := lisDao.Db.Where("visit_id = ?", visitId).First(&hl7LisResult).Error
+	if err != nil {
+		return hl7LisResult, commonUtils.HandleORMError(err)
+	}
+	return hl7LisResult, nil
+}
+
+// GetHl7LisResultForUpdateWithTx creates the row of the visit when it is not there yet and locks it, so that
+// messages for the same visit received on different connections are merged one after the other.
+func (lisDao *LisDao) GetHl7LisResultForUpdateWithTx(tx *gorm.DB, visitId string) (commonModels.Hl7LisResult,
+	*commonStructures.CommonError) {
+
+	hl7LisResult := commonModels.Hl7LisResult{
+		BaseModel: commonModels.BaseModel{
+			CreatedBy: commonConstants.CitadelSystemId,
+			UpdatedBy: commonConstants.CitadelSystemId,
+		},
+		VisitId:  visitId,
+		LisOrder: "{}",
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "visit_id"}},
+		DoNothing: true,
+	}).Create(&hl7LisResult).Error
+	if err != nil {
+		return hl7LisResult, commonUtils.HandleORMError(err)
+	}
+
+	hl7LisResult = commonModels.Hl7LisResult{}
+	err = tx.Clauses(clause.Locking{Strength: commonConstants.CLAUSE_UPDATE}).
+		Where("visit_id = ?", visitId).First(&hl7LisResult).Error
+	if err != nil {
+		return hl7LisResult, commonUtils.HandleORMError(err)
+	}
+	return hl7LisResult, nil
+}
+
+func (lisDao *LisDao) UpdateHl7LisResultWithTx(tx *gorm.DB, hl7LisResult commonModels.Hl7LisResult) (
+	commonModels.Hl7LisResult, *commonStructures.CommonError) {
+
+	hl7LisResult.UpdatedBy = commonConstants.CitadelSystemId
+	err := tx.Save(&hl7LisResult).Error
+	if err != nil {
+		return hl7LisResult, commonUtils.HandleORMError(err)
+	}
+	return hl7LisResult, nil
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type LisDao struct {
+	Db *gorm.DB
+}
+
+func InitializeLisDao() DataLayer {
+	return &LisDao{
+		Db: psql.GetDbInstance(),
+	}
+}
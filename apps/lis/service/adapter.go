@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// LisAdapter is implemented once per LIS vendor. Orders are exchanged as commonStructures.LisOrder with
+// LIS test statuses and report formats, so vendor specific payloads, message types and statuses stay inside the
+// adapter.
+type LisAdapter interface {
+	// SyncOrder registers the samples of an order with the LIS and returns the LIS visit id.
+	SyncOrder(ctx context.Context, omsOrderId string, labId uint, samples []commonModels.Sample,
+		samplesMetadata []commonModels.SampleMetadata, sampleIdBarcodeMap map[uint]string) (
+		string, *commonStructures.CommonError)
+	// ModifyOrder pushes a test added to an already synced visit.
+	ModifyOrder(ctx context.Context, omsOrderId string, labId uint, testDetail commonModels.TestDetail,
+		sample commonStructures.SampleInfo) *commonStructures.CommonError
+	CancelOrder(ctx context.Context, testDetails []commonModels.TestDetail, visitId string,
+		sample commonStructures.SampleInfo) *commonStructures.CommonError
+	FetchResults(ctx context.Context, visitId string, labId uint) (commonStructures.LisOrder,
+		*commonStructures.CommonError)
+	FetchReportPdf(ctx context.Context, visitId, reportPdfFormat string, labId uint) (
+		commonStructures.LisOrder, *commonStructures.CommonError)
+	// PushApprovals writes approved, rerun and retest results back to the LIS.
+	PushApprovals(ctx context.Context, labId uint, lisOrder commonStructures.LisOrder) *commonStructures.CommonError
+	GetLisSyncData(ctx context.Context, visitId, reportPdfFormat string) (commonStructures.LisSyncDetails,
+		commonStructures.LisOrder, *commonStructures.CommonError)
+	UpdateSrfId(ctx context.Context, sample commonStructures.SampleInfo, srfId string) *commonStructures.CommonError
+	// DecodeResultsEvent reads the webhook data of a LIS event raised for the vendor.
+	DecodeResultsEvent(payload []byte) (commonStructures.LisOrder, *commonStructures.CommonError)
+	// EncodeResultsEvent writes an order as the webhook data of a LIS event for the vendor.
+	EncodeResultsEvent(lisOrder commonStructures.LisOrder) ([]byte, *commonStructures.CommonError)
+}
+
+// LisOrderRequestBuilder builds the order to register with a LIS for the given samples of an order.
+type LisOrderRequestBuilder interface {
+	GetLisOrderRequest(ctx context.Context, omsOrderId string, labId uint, sampleIds []uint,
+		sampleIdBarcodeMap map[uint]string) (commonStructures.LisOrderRequest, *commonStructures.CommonError)
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	attuneService "github.com/Orange-Health/citadel/apps/attune/service"
+	attuneClient "github.com/Orange-Health/citadel/clients/attune"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type AttuneAdapter struct {
+	AttuneService attuneService.AttuneServiceInterface
+	AttuneClient  attuneClient.AttuneClientInterface
+}
+
+func InitializeAttuneAdapter(attuneServiceLayer attuneService.AttuneServiceInterface,
+	attuneClientLayer attuneClient.AttuneClientInterface) LisAdapter {
+	return &AttuneAdapter{
+		AttuneService: attuneServiceLayer,
+		AttuneClient:  attuneClientLayer,
+	}
+}
+
+func (attuneAdapter *AttuneAdapter) SyncOrder(ctx context.Context, omsOrderId string, labId uint,
+	samples []commonModels.Sample, samplesMetadata []commonModels.SampleMetadata,
+	sampleIdBarcodeMap map[uint]string) (string, *commonStructures.CommonError) {
+	return attuneAdapter.AttuneService.SyncDataToLisByOmsOrderId(ctx, omsOrderId, labId, samples, samplesMetadata,
+		sampleIdBarcodeMap)
+}
+
+func (attuneAdapter *AttuneAdapter) ModifyOrder(ctx context.Context, omsOrderId string, labId uint,
+	testDetail commonModels.TestDetail, sample commonStructures.SampleInfo) *commonStructures.CommonError {
+	return attuneAdapter.AttuneService.ModifyLisDataPostSyncByOrderId(ctx, omsOrderId, labId, testDetail, sample)
+}
+
+func (attuneAdapter *AttuneAdapter) CancelOrder(ctx context.Context, testDetails []commonModels.TestDetail,
+	visitId string, sample commonStructures.SampleInfo) *commonStructures.CommonError {
+	return attuneAdapter.AttuneService.CancelLisSyncData(ctx, testDetails, visitId, sample)
+}
+
+func (attuneAdapter *AttuneAdapter) FetchResults(ctx context.Context, visitId string, labId uint) (
+	commonStructures.LisOrder, *commonStructures.CommonError) {
+	return attuneAdapter.FetchReportPdf(ctx, visitId, commonConstants.LIS_REPORT_FORMAT_BRANDED, labId)
+}
+
+func (attuneAdapter *AttuneAdapter) FetchReportPdf(ctx context.Context, visitId, reportPdfFormat string,
+	labId uint) (commonStructures.LisOrder, *commonStructures.CommonError) {
+	attuneOrderResponse, cErr := attuneAdapter.AttuneClient.GetPatientVisitDetailsbyVisitNo(ctx, visitId,
+		commonConstants.LisReportFormatToAttuneReportFormat[reportPdfFormat], labId)
+	if cErr != nil {
+		return commonStructures.LisOrder{}, cErr
+	}
+	lisOrder := getLisOrderFromAttuneOrderResponse(attuneOrderResponse)
+	lisOrder.ReportPdfFormat = reportPdfFormat
+	return lisOrder, nil
+}
+
+// PushApprovals overlays the approved, rerun and retest results on the visit as Attune holds it, so that the
+// Attune fields Citadel does not track are sent back unchanged.
+func (attuneAdapter *AttuneAdapter) PushApprovals(ctx context.Context, labId uint,
+	lisOrder commonStructures.LisOrder) *commonStructures.CommonError {
+	attuneOrderResponse, cErr := attuneAdapter.AttuneClient.GetPatientVisitDetailsbyVisitNo(ctx, lisOrder.VisitId,
+		commonConstants.AttuneReportWithStationery, labId)
+	if cErr != nil {
+		return cErr
+	}
+
+	testCodeOrderInfoMap := map[string]commonStructures.AttuneOrderInfo{}
+	for _, orderInfo := range attuneOrderResponse.OrderInfo {
+		testCodeOrderInfoMap[orderInfo.TestCode] = orderInfo
+	}
+
+	attuneOrder := commonStructures.AttuneOrderResponse{
+		OrderId: lisOrder.VisitId,
+		OrgCode: attuneOrderResponse.OrgCode,
+	}
+	if attuneOrder.OrgCode == "" {
+		attuneOrder.OrgCode = commonUtils.GetAttuneOrgCodeByLabId(labId)
+	}
+	for _, test := range lisOrder.Tests {
+		orderInfo, ok := testCodeOrderInfoMap[test.TestCode]
+		if !ok {
+			continue
+		}
+		attuneOrder.OrderInfo = append(attuneOrder.OrderInfo, overlayLisTestResultOnAttuneOrderInfo(orderInfo, test))
+	}
+	if len(attuneOrder.OrderInfo) == 0 {
+		return nil
+	}
+	return attuneAdapter.AttuneClient.InsertTestDataToAttune(ctx, attuneOrder)
+}
+
+func (attuneAdapter *AttuneAdapter) GetLisSyncData(ctx context.Context, visitId, reportPdfFormat string) (
+	commonStructures.LisSyncDetails, commonStructures.LisOrder, *commonStructures.CommonError) {
+	lisSyncDetails, attuneOrderResponse, cErr := attuneAdapter.AttuneService.GetLisSyncData(ctx, visitId,
+		commonConstants.LisReportFormatToAttuneReportFormat[reportPdfFormat])
+	if cErr != nil {
+		return lisSyncDetails, commonStructures.LisOrder{}, cErr
+	}
+	lisOrder := getLisOrderFromAttuneOrderResponse(attuneOrderResponse)
+	lisOrder.ReportPdfFormat = reportPdfFormat
+	return lisSyncDetails, lisOrder, nil
+}
+
+func (attuneAdapter *AttuneAdapter) UpdateSrfId(ctx context.Context, sample commonStructures.SampleInfo,
+	srfId string) *commonStructures.CommonError {
+	return attuneAdapter.AttuneService.UpdateSrfIdToAttune(ctx, sample, srfId)
+}
+
+// DecodeResultsEvent reads the order pushed by the Attune webhook.
+func (attuneAdapter *AttuneAdapter) DecodeResultsEvent(payload []byte) (commonStructures.LisOrder,
+	*commonStructures.CommonError) {
+	lisOrderInfo := commonStructures.LisOrderInfo{}
+	err := json.Unmarshal(payload, &lisOrderInfo)
+	if err != nil {
+		return commonStructures.LisOrder{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_FAILED_TO_UNMARSHAL_JSON,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	lisOrder := commonStructures.LisOrder{
+		VisitId:         lisOrderInfo.OrderId,
+		OverallStatus:   lisOrderInfo.OverAllStatus,
+		VisitDocuments:  lisOrderInfo.VisitDocumentinfo,
+		ReportPdf:       lisOrderInfo.ResultAsPdf,
+		ReportPdfFormat: commonConstants.AttuneReportFormatToLisReportFormat[lisOrderInfo.ReportPdfFormat],
+	}
+	for _, orderInfo := range lisOrderInfo.OrderInfo {
+		lisOrder.Tests = append(lisOrder.Tests, getLisTestResultFromAttuneOrderInfo(orderInfo))
+	}
+	return lisOrder, nil
+}
+
+// EncodeResultsEvent writes the order in the shape of the Attune webhook, which is what resyncs republish.
+func (attuneAdapter *AttuneAdapter) EncodeResultsEvent(lisOrder commonStructures.LisOrder) ([]byte,
+	*commonStructures.CommonError) {
+	lisOrderInfo := commonStructures.LisOrderInfo{
+		OrderId:           lisOrder.VisitId,
+		OverAllStatus:     lisOrder.OverallStatus,
+		VisitDocumentinfo: lisOrder.VisitDocuments,
+		ResultAsPdf:       lisOrder.ReportPdf,
+		ReportPdfFormat:   commonConstants.LisReportFormatToAttuneReportFormat[lisOrder.ReportPdfFormat],
+	}
+	for _, test := range lisOrder.Tests {
+		lisOrderInfo.OrderInfo = append(lisOrderInfo.OrderInfo, getAttuneOrderInfoFromLisTestResult(test))
+	}
+
+	payload, err := json.Marshal(lisOrderInfo)
+	if err != nil {
+		return nil, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_FAILED_TO_MARSHAL_PAYLOAD,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	return payload, nil
+}
+
+func getLisOrderFromAttuneOrderResponse(attuneOrderResponse commonStructures.AttuneOrderResponse) commonStructures.LisOrder {
+	lisOrder := commonStructures.LisOrder{
+		VisitId:            attuneOrderResponse.OrderId,
+		OverallStatus:      attuneOrderResponse.OverAllStatus,
+		VisitDocuments:     attuneOrderResponse.VisitDocumentinfo,
+		ReportPdf:          attuneOrderResponse.ResultAsPdf,
+		OutsourceReportPdf: attuneOrderResponse.OutsourceAsPdf,
+		ReportPdfFormat:    commonConstants.AttuneReportFormatToLisReportFormat[attuneOrderResponse.ReportPdfFormat],
+	}
+	for _, orderInfo := range attuneOrderResponse.OrderInfo {
+		lisOrder.Tests = append(lisOrder.Tests, getLisTestResultFromAttuneOrderInfo(orderInfo))
+	}
+	return lisOrder
+}
+
+func getLisTestStatusFromAttuneTestStatus(attuneTestStatus string) string {
+	if lisTestStatus, ok := commonConstants.AttuneTestStatusToLisTestStatus[attuneTestStatus]; ok {
+		return lisTestStatus
+	}
+	return attuneTestStatus
+}
+
+func getAttuneTestStatusFromLisTestStatus(lisTestStatus string) string {
+	if attuneTestStatus, ok := commonConstants.LisTestStatusToAttuneTestStatus[lisTestStatus]; ok {
+		return attuneTestStatus
+	}
+	return lisTestStatus
+}
+
+func getTestDocuments(testDocumentInfo []commonStructures.AttuneTestDocumentInfo) []string {
+	testDocuments := []string{}
+	for _, testDocument := range testDocumentInfo {
+		testDocuments = append(testDocuments, testDocument.TestDocument)
+	}
+	return testDocuments
+}
+
+func getAttuneTestDocumentInfo(testDocuments []string) []commonStructures.AttuneTestDocumentInfo {
+	testDocumentInfo := []commonStructures.AttuneTestDocumentInfo{}
+	for _, testDocument := range testDocuments {
+		testDocumentInfo = append(testDocumentInfo, commonStructures.AttuneTestDocumentInfo{TestDocument: testDocument})
+	}
+	return testDocumentInfo
+}
+
+func getLisTestResultFromAttuneOrderInfo(orderInfo commonStructures.AttuneOrderInfo) commonStructures.LisTestResult {
+	lisTestResult := commonStructures.LisTestResult{
+		TestId:            orderInfo.TestID,
+		TestCode:          orderInfo.TestCode,
+		TestName:          orderInfo.TestName,
+		TestType:          orderInfo.TestType,
+		TestStatus:        getLisTestStatusFromAttuneTestStatus(orderInfo.TestStatus),
+		TestValue:         orderInfo.TestValue,
+		DeviceActualValue: orderInfo.DeviceActualValue,
+		Unit:              orderInfo.UOMCode,
+		MethodName:        orderInfo.MethodName,
+		DepartmentName:    orderInfo.DepartmentName,
+		ReferenceRange:    orderInfo.ReferenceRange,
+		LisAbnormality:    orderInfo.LisAbnormality,
+		DeviceId:          orderInfo.DeviceID,
+		IMDevice:          orderInfo.IMDevice,
+		IMDeviceFlag:      orderInfo.IMDeviceFlag,
+		MedicalRemarks:    orderInfo.MedicalRemarks,
+		TechnicalRemarks:  orderInfo.TechnicalRemarks,
+		RerunReason:       orderInfo.RerunReason,
+		RerunRemarks:      orderInfo.RerunRemarks,
+		RerunTime:         orderInfo.RerunTime,
+		ResultCapturedAt:  orderInfo.ResultCapturedAt,
+		ResultCapturedBy:  orderInfo.ResultCapturedBy,
+		ResultApprovedAt:  orderInfo.ResultApprovedAt,
+		ResultApprovedBy:  orderInfo.ResultApprovedBy,
+		LisUserId:         orderInfo.UserID,
+		SampleName:        orderInfo.SampleName,
+		Barcode:           orderInfo.BarcodeNumber,
+		QcFlag:            orderInfo.QcFlag,
+		QcLotNumber:       orderInfo.QcLotNumber,
+		QcValue:           orderInfo.QcValue,
+		QcWestGardWarning: orderInfo.QcWestGardWarning,
+		QcStatus:          orderInfo.QcStatus,
+		TestDocuments:     getTestDocuments(orderInfo.TestDocumentInfo),
+	}
+	for _, orderContentListInfo := range orderInfo.OrderContentListInfo {
+		lisTestResult.Parameters = append(lisTestResult.Parameters,
+			getLisTestResultFromAttuneOrderContentListInfo(orderContentListInfo))
+	}
+	return lisTestResult
+}
+
+func getLisTestResultFromAttuneOrderContentListInfo(
+	orderContentListInfo commonStructures.AttuneOrderContentListInfo) commonStructures.LisTestResult {
+	resultCapturedAt := ""
+	if !orderContentListInfo.ResultCapturedAt.IsZero() {
+		resultCapturedAt = orderContentListInfo.ResultCapturedAt.Format(commonConstants.DateTimeUTCLayoutWithoutTZOffset)
+	}
+
+	lisTestResult := commonStructures.LisTestResult{
+		TestId:            orderContentListInfo.TestID,
+		TestCode:          orderContentListInfo.TestCode,
+		TestName:          orderContentListInfo.TestName,
+		TestType:          orderContentListInfo.TestType,
+		TestStatus:        getLisTestStatusFromAttuneTestStatus(orderContentListInfo.TestStatus),
+		TestValue:         orderContentListInfo.TestValue,
+		DeviceActualValue: orderContentListInfo.DeviceActualValue,
+		Unit:              orderContentListInfo.UOMCode,
+		MethodName:        orderContentListInfo.MethodName,
+		DepartmentName:    orderContentListInfo.DepartmentName,
+		ReferenceRange:    orderContentListInfo.ReferenceRange,
+		LisAbnormality:    orderContentListInfo.LisAbnormality,
+		DeviceId:          orderContentListInfo.DeviceID,
+		IMDevice:          orderContentListInfo.IMDevice,
+		IMDeviceFlag:      orderContentListInfo.IMDeviceFlag,
+		MedicalRemarks:    orderContentListInfo.MedicalRemarks,
+		TechnicalRemarks:  orderContentListInfo.TechnicalRemarks,
+		RerunReason:       orderContentListInfo.RerunReason,
+		RerunRemarks:      orderContentListInfo.RerunRemarks,
+		RerunTime:         orderContentListInfo.RerunTime,
+		ResultCapturedAt:  resultCapturedAt,
+		ResultCapturedBy:  orderContentListInfo.ResultCapturedBy,
+		ResultApprovedAt:  orderContentListInfo.ResultApprovedAt,
+		ResultApprovedBy:  orderContentListInfo.ResultApprovedBy,
+		LisUserId:         orderContentListInfo.UserID,
+		SampleName:        orderContentListInfo.SampleName,
+		Barcode:           orderContentListInfo.BarcodeNumber,
+		QcFlag:            orderContentListInfo.QcFlag,
+		QcLotNumber:       orderContentListInfo.QcLotNumber,
+		QcValue:           orderContentListInfo.QcValue,
+		QcWestGardWarning: orderContentListInfo.QcWestGardWarning,
+		QcStatus:          orderContentListInfo.QcStatus,
+		TestDocuments:     getTestDocuments(orderContentListInfo.TestDocumentInfo),
+	}
+	for _, parameterListInfo := range orderContentListInfo.ParameterListInfo {
+		lisTestResult.Parameters = append(lisTestResult.Parameters,
+			getLisTestResultFromAttuneOrderContentListInfo(parameterListInfo))
+	}
+	return lisTestResult
+}
+
+func getAttuneOrderInfoFromLisTestResult(lisTestResult commonStructures.LisTestResult) commonStructures.AttuneOrderInfo {
+	orderInfo := commonStructures.AttuneOrderInfo{
+		TestID:            lisTestResult.TestId,
+		TestCode:          lisTestResult.TestCode,
+		TestName:          lisTestResult.TestName,
+		TestType:          lisTestResult.TestType,
+		TestStatus:        getAttuneTestStatusFromLisTestStatus(lisTestResult.TestStatus),
+		TestValue:         lisTestResult.TestValue,
+		DeviceActualValue: lisTestResult.DeviceActualValue,
+		UOMCode:           lisTestResult.Unit,
+		MethodName:        lisTestResult.MethodName,
+		DepartmentName:    lisTestResult.DepartmentName,
+		ReferenceRange:    lisTestResult.ReferenceRange,
+		LisAbnormality:    lisTestResult.LisAbnormality,
+		DeviceID:          lisTestResult.DeviceId,
+		IMDevice:          lisTestResult.IMDevice,
+		IMDeviceFlag:      lisTestResult.IMDeviceFlag,
+		MedicalRemarks:    lisTestResult.MedicalRemarks,
+		TechnicalRemarks:  lisTestResult.TechnicalRemarks,
+		RerunReason:       lisTestResult.RerunReason,
+		RerunRemarks:      lisTestResult.RerunRemarks,
+		RerunTime:         lisTestResult.RerunTime,
+		ResultCapturedAt:  lisTestResult.ResultCapturedAt,
+		ResultCapturedBy:  lisTestResult.ResultCapturedBy,
+		ResultApprovedAt:  lisTestResult.ResultApprovedAt,
+		ResultApprovedBy:  lisTestResult.ResultApprovedBy,
+		UserID:            lisTestResult.LisUserId,
+		SampleName:        lisTestResult.SampleName,
+		BarcodeNumber:     lisTestResult.Barcode,
+		QcFlag:            lisTestResult.QcFlag,
+		QcLotNumber:       lisTestResult.QcLotNumber,
+		QcValue:           lisTestResult.QcValue,
+		QcWestGardWarning: lisTestResult.QcWestGardWarning,
+		QcStatus:          lisTestResult.QcStatus,
+		TestDocumentInfo:  getAttuneTestDocumentInfo(lisTestResult.TestDocuments),
+	}
+	for _, parameter := range lisTestResult.Parameters {
+		orderInfo.OrderContentListInfo = append(orderInfo.OrderContentListInfo,
+			getAttuneOrderContentListInfoFromLisTestResult(parameter))
+	}
+	return orderInfo
+}
+
+func getAttuneOrderContentListInfoFromLisTestResult(
+	lisTestResult commonStructures.LisTestResult) commonStructures.AttuneOrderContentListInfo {
+	orderContentListInfo := commonStructures.AttuneOrderContentListInfo{
+		TestID:            lisTestResult.TestId,
+		TestCode:          lisTestResult.TestCode,
+		TestName:          lisTestResult.TestName,
+		TestType:          lisTestResult.TestType,
+		TestStatus:        getAttuneTestStatusFromLisTestStatus(lisTestResult.TestStatus),
+		TestValue:         lisTestResult.TestValue,
+		DeviceActualValue: lisTestResult.DeviceActualValue,
+		UOMCode:           lisTestResult.Unit,
+		MethodName:        lisTestResult.MethodName,
+		DepartmentName:    lisTestResult.DepartmentName,
+		ReferenceRange:    lisTestResult.ReferenceRange,
+		LisAbnormality:    lisTestResult.LisAbnormality,
+		DeviceID:          lisTestResult.DeviceId,
+		IMDevice:          lisTestResult.IMDevice,
+		IMDeviceFlag:      lisTestResult.IMDeviceFlag,
+		MedicalRemarks:    lisTestResult.MedicalRemarks,
+		TechnicalRemarks:  lisTestResult.TechnicalRemarks,
+		RerunReason:       lisTestResult.RerunReason,
+		RerunRemarks:      lisTestResult.RerunRemarks,
+		RerunTime:         lisTestResult.RerunTime,
+		ResultCapturedAt:  *commonUtils.GetEnteredAtTime(lisTestResult.ResultCapturedAt),
+		ResultCapturedBy:  lisTestResult.ResultCapturedBy,
+		ResultApprovedAt:  lisTestResult.ResultApprovedAt,
+		ResultApprovedBy:  lisTestResult.ResultApprovedBy,
+		UserID:            lisTestResult.LisUserId,
+		SampleName:        lisTestResult.SampleName,
+		BarcodeNumber:     lisTestResult.Barcode,
+		QcFlag:            lisTestResult.QcFlag,
+		QcLotNumber:       lisTestResult.QcLotNumber,
+		QcValue:           lisTestResult.QcValue,
+		QcWestGardWarning: lisTestResult.QcWestGardWarning,
+		QcStatus:          lisTestResult.QcStatus,
+		TestDocumentInfo:  getAttuneTestDocumentInfo(lisTestResult.TestDocuments),
+	}
+	for _, parameter := range lisTestResult.Parameters {
+		orderContentListInfo.ParameterListInfo = append(orderContentListInfo.ParameterListInfo,
+			getAttuneOrderContentListInfoFromLisTestResult(parameter))
+	}
+	return orderContentListInfo
+}
+
+// overlayLisTestResultOnAttuneOrderInfo writes the fields Citadel changes on approval, rerun and retest onto
+// the order info fetched from Attune, matching panel parameters by test code.
+func overlayLisTestResultOnAttuneOrderInfo(orderInfo commonStructures.AttuneOrderInfo,
+	lisTestResult commonStructures.LisTestResult) commonStructures.AttuneOrderInfo {
+	orderInfo.TestStatus = getAttuneTestStatusFromLisTestStatus(lisTestResult.TestStatus)
+	orderInfo.TestValue = lisTestResult.TestValue
+	orderInfo.MedicalRemarks = lisTestResult.MedicalRemarks
+	orderInfo.RerunReason = lisTestResult.RerunReason
+	orderInfo.RerunRemarks = lisTestResult.RerunRemarks
+	orderInfo.RerunTime = lisTestResult.RerunTime
+	orderInfo.UserID = lisTestResult.LisUserId
+	orderInfo.ResultApprovedAt = lisTestResult.ResultApprovedAt
+	orderInfo.OrderContentListInfo = overlayLisTestResultsOnAttuneOrderContentListInfo(
+		orderInfo.OrderContentListInfo, lisTestResult.Parameters)
+	return orderInfo
+}
+
+func overlayLisTestResultsOnAttuneOrderContentListInfo(
+	orderContentListInfo []commonStructures.AttuneOrderContentListInfo,
+	lisTestResults []commonStructures.LisTestResult) []commonStructures.AttuneOrderContentListInfo {
+	testCodeLisTestResultMap := map[string]commonStructures.LisTestResult{}
+	for _, lisTestResult := range lisTestResults {
+		testCodeLisTestResultMap[lisTestResult.TestCode] = lisTestResult
+	}
+
+	for index := range orderContentListInfo {
+		lisTestResult, ok := testCodeLisTestResultMap[orderContentListInfo[index].TestCode]
+		if !ok {
+			continue
+		}
+		orderContentListInfo[index].TestStatus = getAttuneTestStatusFromLisTestStatus(lisTestResult.TestStatus)
+		orderContentListInfo[index].TestValue = lisTestResult.TestValue
+		orderContentListInfo[index].MedicalRemarks = lisTestResult.MedicalRemarks
+		orderContentListInfo[index].RerunReason = lisTestResult.RerunReason
+		orderContentListInfo[index].RerunRemarks = lisTestResult.RerunRemarks
+		orderContentListInfo[index].RerunTime = lisTestResult.RerunTime
+		orderContentListInfo[index].UserID = lisTestResult.LisUserId
+		orderContentListInfo[index].ResultApprovedAt = lisTestResult.ResultApprovedAt
+		orderContentListInfo[index].ParameterListInfo = overlayLisTestResultsOnAttuneOrderContentListInfo(
+			orderContentListInfo[index].ParameterListInfo, lisTestResult.Parameters)
+	}
+	return orderContentListInfo
+}
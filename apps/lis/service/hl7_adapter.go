@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	lisDao "github.com/Orange-Health/citadel/apps/lis/dao"
+	hl7Client "github.com/Orange-Health/citadel/clients/hl7"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// Hl7Adapter talks HL7 v2 over MLLP. Orders are pushed as ORM^O01; results arrive as ORU^R01 on the hl7
+// listener, which stores them per visit and raises a LIS event, so fetches are served from the stored results.
+type Hl7Adapter struct {
+	LisDao                 lisDao.DataLayer
+	LisOrderRequestBuilder LisOrderRequestBuilder
+	Hl7Client              hl7Client.Hl7ClientInterface
+}
+
+func InitializeHl7Adapter(lisOrderRequestBuilder LisOrderRequestBuilder,
+	hl7ClientLayer hl7Client.Hl7ClientInterface) LisAdapter {
+	return &Hl7Adapter{
+		LisDao:                 lisDao.InitializeLisDao(),
+		LisOrderRequestBuilder: lisOrderRequestBuilder,
+		Hl7Client:              hl7ClientLayer,
+	}
+}
+
+func (hl7Adapter *Hl7Adapter) SyncOrder(ctx context.Context, omsOrderId string, labId uint,
+	samples []commonModels.Sample, samplesMetadata []commonModels.SampleMetadata,
+	sampleIdBarcodeMap map[uint]string) (string, *commonStructures.CommonError) {
+	sampleIds := []uint{}
+	for _, sample := range samples {
+		sampleIds = append(sampleIds, sample.Id)
+	}
+
+	lisOrderRequest, cErr := hl7Adapter.LisOrderRequestBuilder.GetLisOrderRequest(ctx, omsOrderId, labId, sampleIds,
+		sampleIdBarcodeMap)
+	if cErr != nil {
+		return "", cErr
+	}
+
+	cErr = hl7Adapter.Hl7Client.SendMessage(ctx, labId,
+		hl7Client.BuildOrmO01(commonConstants.HL7_ORDER_CONTROL_NEW, lisOrderRequest))
+	if cErr != nil {
+		return "", cErr
+	}
+	return lisOrderRequest.VisitId, nil
+}
+
+func (hl7Adapter *Hl7Adapter) ModifyOrder(ctx context.Context, omsOrderId string, labId uint,
+	testDetail commonModels.TestDetail, sample commonStructures.SampleInfo) *commonStructures.CommonError {
+	lisOrderRequest := getHl7LisOrderRequest(sample, []commonModels.TestDetail{testDetail})
+	return hl7Adapter.Hl7Client.SendMessage(ctx, labId,
+		hl7Client.BuildOrmO01(commonConstants.HL7_ORDER_CONTROL_NEW, lisOrderRequest))
+}
+
+func (hl7Adapter *Hl7Adapter) CancelOrder(ctx context.Context, testDetails []commonModels.TestDetail,
+	visitId string, sample commonStructures.SampleInfo) *commonStructures.CommonError {
+	sample.VisitId = visitId
+	lisOrderRequest := getHl7LisOrderRequest(sample, testDetails)
+	return hl7Adapter.Hl7Client.SendMessage(ctx, sample.LabId,
+		hl7Client.BuildOrmO01(commonConstants.HL7_ORDER_CONTROL_CANCEL, lisOrderRequest))
+}
+
+func (hl7Adapter *Hl7Adapter) FetchResults(ctx context.Context, visitId string, labId uint) (
+	commonStructures.LisOrder, *commonStructures.CommonError) {
+	return hl7Adapter.getStoredResults(visitId)
+}
+
+func (hl7Adapter *Hl7Adapter) FetchReportPdf(ctx context.Context, visitId, reportPdfFormat string, labId uint) (
+	commonStructures.LisOrder, *commonStructures.CommonError) {
+	lisOrder, cErr := hl7Adapter.getStoredResults(visitId)
+	if cErr != nil {
+		return lisOrder, cErr
+	}
+	// ORU^R01 carries a single report, so it is served for every requested format.
+	lisOrder.ReportPdfFormat = reportPdfFormat
+	return lisOrder, nil
+}
+
+// PushApprovals is a no-op: approvals happen in Citadel and HL7 receivers do not take them back.
+func (hl7Adapter *Hl7Adapter) PushApprovals(ctx context.Context, labId uint,
+	lisOrder commonStructures.LisOrder) *commonStructures.CommonError {
+	return nil
+}
+
+func (hl7Adapter *Hl7Adapter) GetLisSyncData(ctx context.Context, visitId, reportPdfFormat string) (
+	commonStructures.LisSyncDetails, commonStructures.LisOrder, *commonStructures.CommonError) {
+	lisOrder, cErr := hl7Adapter.getStoredResults(visitId)
+	if cErr != nil {
+		return commonStructures.LisSyncDetails{VisitID: visitId}, lisOrder, cErr
+	}
+	lisOrder.ReportPdfFormat = reportPdfFormat
+	return getLisSyncDetailsFromLisOrder(lisOrder), lisOrder, nil
+}
+
+// UpdateSrfId is a no-op: SRF ids are only required by Attune.
+func (hl7Adapter *Hl7Adapter) UpdateSrfId(ctx context.Context, sample commonStructures.SampleInfo,
+	srfId string) *commonStructures.CommonError {
+	return nil
+}
+
+// DecodeResultsEvent reads the order the hl7 listener raises LIS events with.
+func (hl7Adapter *Hl7Adapter) DecodeResultsEvent(payload []byte) (commonStructures.LisOrder,
+	*commonStructures.CommonError) {
+	lisOrder := commonStructures.LisOrder{}
+	err := json.Unmarshal(payload, &lisOrder)
+	if err != nil {
+		return lisOrder, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_FAILED_TO_UNMARSHAL_JSON,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	return lisOrder, nil
+}
+
+func (hl7Adapter *Hl7Adapter) EncodeResultsEvent(lisOrder commonStructures.LisOrder) ([]byte,
+	*commonStructures.CommonError) {
+	payload, err := json.Marshal(lisOrder)
+	if err != nil {
+		return nil, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_FAILED_TO_MARSHAL_PAYLOAD,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	return payload, nil
+}
+
+func (hl7Adapter *Hl7Adapter) getStoredResults(visitId string) (commonStructures.LisOrder,
+	*commonStructures.CommonError) {
+	lisOrder := commonStructures.LisOrder{}
+	hl7LisResult, cErr := hl7Adapter.LisDao.GetHl7LisResultByVisitId(visitId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_HL7_RESULTS_NOT_FOUND
+		}
+		return lisOrder, cErr
+	}
+
+	err := json.Unmarshal([]byte(hl7LisResult.LisOrder), &lisOrder)
+	if err != nil || lisOrder.VisitId == "" {
+		return lisOrder, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_HL7_RESULTS_NOT_FOUND,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return lisOrder, nil
+}
+
+func getHl7LisOrderRequest(sample commonStructures.SampleInfo,
+	testDetails []commonModels.TestDetail) commonStructures.LisOrderRequest {
+	lisOrderRequest := commonStructures.LisOrderRequest{VisitId: sample.VisitId}
+	for _, testDetail := range testDetails {
+		lisOrderRequest.Tests = append(lisOrderRequest.Tests, commonStructures.LisOrderTest{
+			TestId:   testDetail.Id,
+			TestCode: testDetail.LisCode,
+			TestName: testDetail.TestName,
+			TestType: testDetail.TestType,
+			Barcodes: []string{sample.Barcode},
+		})
+	}
+	return lisOrderRequest
+}
+
+// getLisSyncDetailsFromLisOrder lists the tests and samples received for the visit, leaving out cancelled and
+// retest ones, with the first result capture time as the sync time.
+func getLisSyncDetailsFromLisOrder(lisOrder commonStructures.LisOrder) commonStructures.LisSyncDetails {
+	lisSyncDetails := commonStructures.LisSyncDetails{
+		VisitID:       lisOrder.VisitId,
+		SyncedTests:   []commonStructures.LisTestInfo{},
+		SyncedSamples: []commonStructures.LisSampleInfo{},
+	}
+
+	barcodeMap := map[string]bool{}
+	for _, test := range lisOrder.Tests {
+		if test.TestStatus == commonConstants.LIS_TEST_STATUS_CANCELLED ||
+			test.TestStatus == commonConstants.LIS_TEST_STATUS_RETEST {
+			continue
+		}
+		lisSyncDetails.SyncedTests = append(lisSyncDetails.SyncedTests, commonStructures.LisTestInfo{
+			TestCode: test.TestCode,
+			TestName: test.TestName,
+			TestType: test.TestType,
+		})
+		if test.Barcode != "" && !barcodeMap[test.Barcode] {
+			barcodeMap[test.Barcode] = true
+			lisSyncDetails.SyncedSamples = append(lisSyncDetails.SyncedSamples, commonStructures.LisSampleInfo{
+				SampleName: test.SampleName,
+				Barcode:    test.Barcode,
+			})
+		}
+		if lisSyncDetails.LisSyncTime == "" {
+			lisSyncDetails.LisSyncTime = test.ResultCapturedAt
+		}
+	}
+	return lisSyncDetails
+}
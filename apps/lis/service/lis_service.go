@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func (lisService *LisService) GetLisAdapter(ctx context.Context, labId uint) (LisAdapter,
+	*commonStructures.CommonError) {
+	lab, cErr := lisService.CdsService.GetLabById(ctx, labId)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(),
+			map[string]interface{}{"lab_id": labId}, errors.New(cErr.Message))
+		return nil, cErr
+	}
+
+	// Labs without a vendor in CDS were all on Attune before adapters existed, so keep them there.
+	lisVendor := commonConstants.DefaultLisVendor
+	if lab.LisVendor != "" {
+		lisVendor = strings.ToLower(lab.LisVendor)
+	}
+
+	lisAdapter, ok := lisService.Adapters[lisVendor]
+	if !ok {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_UNSUPPORTED_LIS_VENDOR,
+			map[string]interface{}{"lab_id": labId, "lis_vendor": lisVendor}, nil)
+		return nil, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_UNSUPPORTED_LIS_VENDOR,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	return lisAdapter, nil
+}
+
+func (lisService *LisService) GetSampleByVisitId(visitId string) (commonModels.Sample,
+	*commonStructures.CommonError) {
+	return lisService.AttuneService.GetSampleByVisitId(visitId)
+}
+
+func (lisService *LisService) SyncOrder(ctx context.Context, omsOrderId string, labId uint,
+	samples []commonModels.Sample, samplesMetadata []commonModels.SampleMetadata,
+	sampleIdBarcodeMap map[uint]string) (string, *commonStructures.CommonError) {
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, labId)
+	if cErr != nil {
+		return "", cErr
+	}
+	return lisAdapter.SyncOrder(ctx, omsOrderId, labId, samples, samplesMetadata, sampleIdBarcodeMap)
+}
+
+func (lisService *LisService) ModifyOrder(ctx context.Context, omsOrderId string, labId uint,
+	testDetail commonModels.TestDetail, sample commonStructures.SampleInfo) *commonStructures.CommonError {
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, labId)
+	if cErr != nil {
+		return cErr
+	}
+	return lisAdapter.ModifyOrder(ctx, omsOrderId, labId, testDetail, sample)
+}
+
+func (lisService *LisService) CancelOrder(ctx context.Context, testDetails []commonModels.TestDetail,
+	visitId string, sample commonStructures.SampleInfo) *commonStructures.CommonError {
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, sample.LabId)
+	if cErr != nil {
+		return cErr
+	}
+	return lisAdapter.CancelOrder(ctx, testDetails, visitId, sample)
+}
+
+func (lisService *LisService) FetchResults(ctx context.Context, visitId string, labId uint) (
+	commonStructures.LisOrder, *commonStructures.CommonError) {
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, labId)
+	if cErr != nil {
+		return commonStructures.LisOrder{}, cErr
+	}
+	return lisAdapter.FetchResults(ctx, visitId, labId)
+}
+
+func (lisService *LisService) FetchReportPdf(ctx context.Context, visitId, reportPdfFormat string, labId uint) (
+	commonStructures.LisOrder, *commonStructures.CommonError) {
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, labId)
+	if cErr != nil {
+		return commonStructures.LisOrder{}, cErr
+	}
+	return lisAdapter.FetchReportPdf(ctx, visitId, reportPdfFormat, labId)
+}
+
+func (lisService *LisService) PushApprovals(ctx context.Context, visitId string,
+	lisOrder commonStructures.LisOrder) *commonStructures.CommonError {
+	sample, cErr := lisService.AttuneService.GetSampleByVisitId(visitId)
+	if cErr != nil {
+		return cErr
+	}
+
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, sample.LabId)
+	if cErr != nil {
+		return cErr
+	}
+	return lisAdapter.PushApprovals(ctx, sample.LabId, lisOrder)
+}
+
+func (lisService *LisService) GetLisSyncData(ctx context.Context, visitId, reportPdfFormat string) (
+	commonStructures.LisSyncDetails, commonStructures.LisOrder, *commonStructures.CommonError) {
+	sample, cErr := lisService.AttuneService.GetSampleByVisitId(visitId)
+	if cErr != nil {
+		return commonStructures.LisSyncDetails{VisitID: visitId}, commonStructures.LisOrder{}, cErr
+	}
+
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, sample.LabId)
+	if cErr != nil {
+		return commonStructures.LisSyncDetails{VisitID: visitId}, commonStructures.LisOrder{}, cErr
+	}
+	return lisAdapter.GetLisSyncData(ctx, visitId, reportPdfFormat)
+}
+
+func (lisService *LisService) UpdateSrfId(ctx context.Context, sample commonStructures.SampleInfo,
+	srfId string) *commonStructures.CommonError {
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, sample.LabId)
+	if cErr != nil {
+		return cErr
+	}
+	return lisAdapter.UpdateSrfId(ctx, sample, srfId)
+}
+
+// DecodeResultsEvent reads the base64 webhook data of a LIS event with the adapter of the lab.
+func (lisService *LisService) DecodeResultsEvent(ctx context.Context, labId uint, webhookData string) (
+	commonStructures.LisOrder, *commonStructures.CommonError) {
+	payload, err := base64.StdEncoding.DecodeString(webhookData)
+	if err != nil {
+		return commonStructures.LisOrder{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_FAILED_TO_DECODE_BASE64_STRING,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, labId)
+	if cErr != nil {
+		return commonStructures.LisOrder{}, cErr
+	}
+	return lisAdapter.DecodeResultsEvent(payload)
+}
+
+// EncodeResultsEvent writes an order as the base64 webhook data of a LIS event with the adapter of the lab.
+func (lisService *LisService) EncodeResultsEvent(ctx context.Context, labId uint,
+	lisOrder commonStructures.LisOrder) (string, *commonStructures.CommonError) {
+	lisAdapter, cErr := lisService.GetLisAdapter(ctx, labId)
+	if cErr != nil {
+		return "", cErr
+	}
+
+	payload, cErr := lisAdapter.EncodeResultsEvent(lisOrder)
+	if cErr != nil {
+		return "", cErr
+	}
+	return base64.StdEncoding.EncodeToString(payload), nil
+}
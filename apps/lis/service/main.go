@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	attuneService "github.com/Orange-Health/citadel/apps/attune/service"
+	cdsService "github.com/Orange-Health/citadel/apps/cds/service"
+	attuneClient "github.com/Orange-Health/citadel/clients/attune"
+	hl7Client "github.com/Orange-Health/citadel/clients/hl7"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// LisService routes LIS operations to the adapter configured for the lab in CDS lab master data.
+type LisService struct {
+	Sentry        sentry.SentryLayer
+	CdsService    cdsService.CdsServiceInterface
+	AttuneService attuneService.AttuneServiceInterface
+	Adapters      map[string]LisAdapter
+}
+
+type LisServiceInterface interface {
+	GetLisAdapter(ctx context.Context, labId uint) (LisAdapter, *commonStructures.CommonError)
+	GetSampleByVisitId(visitId string) (commonModels.Sample, *commonStructures.CommonError)
+	SyncOrder(ctx context.Context, omsOrderId string, labId uint, samples []commonModels.Sample,
+		samplesMetadata []commonModels.SampleMetadata, sampleIdBarcodeMap map[uint]string) (
+		string, *commonStructures.CommonError)
+	ModifyOrder(ctx context.Context, omsOrderId string, labId uint, testDetail commonModels.TestDetail,
+		sample commonStructures.SampleInfo) *commonStructures.CommonError
+	CancelOrder(ctx context.Context, testDetails []commonModels.TestDetail, visitId string,
+		sample commonStructures.SampleInfo) *commonStructures.CommonError
+	FetchResults(ctx context.Context, visitId string, labId uint) (commonStructures.LisOrder,
+		*commonStructures.CommonError)
+	FetchReportPdf(ctx context.Context, visitId, reportPdfFormat string, labId uint) (
+		commonStructures.LisOrder, *commonStructures.CommonError)
+	PushApprovals(ctx context.Context, visitId string,
+		lisOrder commonStructures.LisOrder) *commonStructures.CommonError
+	GetLisSyncData(ctx context.Context, visitId, reportPdfFormat string) (commonStructures.LisSyncDetails,
+		commonStructures.LisOrder, *commonStructures.CommonError)
+	UpdateSrfId(ctx context.Context, sample commonStructures.SampleInfo, srfId string) *commonStructures.CommonError
+	DecodeResultsEvent(ctx context.Context, labId uint, webhookData string) (commonStructures.LisOrder,
+		*commonStructures.CommonError)
+	EncodeResultsEvent(ctx context.Context, labId uint, lisOrder commonStructures.LisOrder) (string,
+		*commonStructures.CommonError)
+}
+
+func InitializeLisService() LisServiceInterface {
+	attuneServiceLayer := attuneService.InitializeAttuneService()
+	return &LisService{
+		Sentry:        sentry.InitializeSentry(),
+		CdsService:    cdsService.InitializeCdsService(),
+		AttuneService: attuneServiceLayer,
+		Adapters: map[string]LisAdapter{
+			commonConstants.LIS_VENDOR_ATTUNE: InitializeAttuneAdapter(attuneServiceLayer,
+				attuneClient.InitializeAttuneClient()),
+			commonConstants.LIS_VENDOR_HL7: InitializeHl7Adapter(attuneServiceLayer, hl7Client.InitializeHl7Client()),
+		},
+	}
+}
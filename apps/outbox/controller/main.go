@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/outbox/service"
+)
+
+type Outbox struct {
+	OutboxService service.OutboxServiceInterface
+}
+
+func InitOutboxController() *Outbox {
+	return &Outbox{
+		OutboxService: service.InitializeOutboxService(),
+	}
+}
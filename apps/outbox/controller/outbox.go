@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/outbox/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Outbox Messages
+// @Description	Get the messages written to the outbox, most recent first. Stuck messages are the ones still not
+// @Description	published long after they were written.
+// @Tags			outbox
+// @Produce		json
+// @Param			status				query		string							false	"Status"
+// @Param			topic				query		string							false	"Topic"
+// @Param			message_group_id	query		string							false	"Message Group ID"
+// @Param			event_type			query		string							false	"Event Type"
+// @Param			stuck_only			query		bool							false	"Stuck Only"
+// @Param			limit				query		int								false	"Limit"
+// @Param			offset				query		int								false	"Offset"
+// @Success		200					{object}	[]structures.OutboxMessage		"Outbox Messages"
+// @Failure		400,500				{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/outbox/messages [get]
+func (outboxController *Outbox) GetOutboxMessages(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	filter := structures.OutboxMessagesFilter{
+		Status:         c.Query("status"),
+		Topic:          c.Query("topic"),
+		MessageGroupId: c.Query("message_group_id"),
+		EventType:      c.Query("event_type"),
+		StuckOnly:      c.Query("stuck_only") == "true",
+		Limit:          limit,
+		Offset:         offset,
+	}
+
+	messages, cErr := outboxController.OutboxService.GetOutboxMessages(filter)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// @Summary		Get Outbox Message
+// @Description	Get an outbox message along with its body, attributes and the outcome of its last attempt
+// @Tags			outbox
+// @Produce		json
+// @Param			messageId	path		int								true	"Outbox Message ID"
+// @Success		200			{object}	structures.OutboxMessage		"Outbox Message"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/outbox/messages/{messageId} [get]
+func (outboxController *Outbox) GetOutboxMessage(c *gin.Context) {
+	messageId := commonUtils.ConvertStringToUint(c.Param("messageId"))
+	if messageId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_OUTBOX_MESSAGE_ID)
+		return
+	}
+
+	message, cErr := outboxController.OutboxService.GetOutboxMessage(messageId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// @Summary		Replay Outbox Messages
+// @Description	Put failed or stuck outbox messages back in line for the relay, only allowed for super admins
+// @Tags			outbox
+// @Accept			json
+// @Produce		json
+// @Param			replay					body		structures.ReplayOutboxMessagesRequest	true	"Replay"
+// @Success		200						{object}	[]structures.OutboxMessage				"Outbox Messages"
+// @Failure		400,403,404,409,500		{object}	structures.CommonAPIResponse			"Common API Response"
+// @Router			/api/v1/outbox/messages/replay [post]
+func (outboxController *Outbox) ReplayOutboxMessages(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	replayRequest := structures.ReplayOutboxMessagesRequest{}
+	if err := c.ShouldBindJSON(&replayRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	messages, cErr := outboxController.OutboxService.ReplayOutboxMessages(replayRequest.MessageIds, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
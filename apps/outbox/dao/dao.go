@@ -0,0 +1,185 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/apps/outbox/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetOutboxMessages(filter structures.OutboxMessagesFilter, stuckBefore time.Time) (
+		[]commonModels.OutboxMessage, *commonStructures.CommonError)
+	GetOutboxMessageById(messageId uint) (commonModels.OutboxMessage, *commonStructures.CommonError)
+	GetOutboxMessagesByIds(messageIds []uint) ([]commonModels.OutboxMessage, *commonStructures.CommonError)
+	ClaimRelayableOutboxMessages(currentTime, claimedUntil time.Time, limit int) (
+		[]commonModels.OutboxMessage, bool, *commonStructures.CommonError)
+
+	CreateOutboxMessage(message commonModels.OutboxMessage) (
+		commonModels.OutboxMessage, *commonStructures.CommonError)
+	CreateOutboxMessageWithTx(tx *gorm.DB, message commonModels.OutboxMessage) (
+		commonModels.OutboxMessage, *commonStructures.CommonError)
+	UpdateOutboxMessage(message commonModels.OutboxMessage) *commonStructures.CommonError
+	ResetOutboxMessages(messageIds []uint, userId uint) *commonStructures.CommonError
+}
+
+func (outboxDao *OutboxDao) GetOutboxMessages(filter structures.OutboxMessagesFilter, stuckBefore time.Time) (
+	[]commonModels.OutboxMessage, *commonStructures.CommonError) {
+
+	messages := []commonModels.OutboxMessage{}
+	query := outboxDao.Db.Model(&commonModels.OutboxMessage{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Topic != "" {
+		query = query.Where("topic = ?", filter.Topic)
+	}
+	if filter.MessageGroupId != "" {
+		query = query.Where("message_group_id = ?", filter.MessageGroupId)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.StuckOnly {
+		query = query.Where("status <> ? AND created_at < ?", commonConstants.OUTBOX_STATUS_PUBLISHED, stuckBefore)
+	}
+
+	if err := query.Order("id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&messages).Error; err != nil {
+		return messages, commonUtils.HandleORMError(err)
+	}
+
+	return messages, nil
+}
+
+func (outboxDao *OutboxDao) GetOutboxMessageById(messageId uint) (
+	commonModels.OutboxMessage, *commonStructures.CommonError) {
+
+	message := commonModels.OutboxMessage{}
+	if err := outboxDao.Db.Where("id = ?", messageId).First(&message).Error; err != nil {
+		return message, commonUtils.HandleORMError(err)
+	}
+
+	return message, nil
+}
+
+func (outboxDao *OutboxDao) GetOutboxMessagesByIds(messageIds []uint) (
+	[]commonModels.OutboxMessage, *commonStructures.CommonError) {
+
+	messages := []commonModels.OutboxMessage{}
+	if err := outboxDao.Db.Where("id IN ?", messageIds).Order("id").Find(&messages).Error; err != nil {
+		return messages, commonUtils.HandleORMError(err)
+	}
+
+	return messages, nil
+}
+
+// ClaimRelayableOutboxMessages claims the pending messages that are due, oldest first, by pushing their next
+// attempt to claimedUntil and committing. A message is held back while an earlier message of its group is failed,
+// waiting on a retry or claimed, so that groups are published in order. Claims are taken under a transaction
+// scoped advisory lock and it reports whether the lock was acquired; the messages keep their own next attempt.
+func (outboxDao *OutboxDao) ClaimRelayableOutboxMessages(currentTime, claimedUntil time.Time, limit int) (
+	[]commonModels.OutboxMessage, bool, *commonStructures.CommonError) {
+
+	messages, isLocked := []commonModels.OutboxMessage{}, false
+	err := outboxDao.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", commonConstants.OutboxRelayLockKey).
+			Scan(&isLocked).Error; err != nil {
+			return err
+		}
+		if !isLocked {
+			return nil
+		}
+
+		err := tx.
+			Where("status = ? AND next_attempt_at <= ?", commonConstants.OUTBOX_STATUS_PENDING, currentTime).
+			Where("message_group_id = '' OR NOT EXISTS (?)",
+				tx.Table(commonConstants.TableOutboxMessages+" AS earlier_messages").
+					Select("1").
+					Where("earlier_messages.message_group_id = outbox_messages.message_group_id").
+					Where("earlier_messages.id < outbox_messages.id").
+					Where("earlier_messages.deleted_at IS NULL").
+					Where("earlier_messages.status = ? OR (earlier_messages.status = ? AND "+
+						"earlier_messages.next_attempt_at > ?)", commonConstants.OUTBOX_STATUS_FAILED,
+						commonConstants.OUTBOX_STATUS_PENDING, currentTime)).
+			Order("id").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		messageIds := []uint{}
+		for _, message := range messages {
+			messageIds = append(messageIds, message.Id)
+		}
+		return tx.Model(&commonModels.OutboxMessage{}).Where("id IN ?", messageIds).
+			Updates(map[string]interface{}{
+				"next_attempt_at": claimedUntil,
+				"updated_at":      commonUtils.GetCurrentTime(),
+			}).Error
+	})
+	if err != nil {
+		return []commonModels.OutboxMessage{}, false, commonUtils.HandleORMError(err)
+	}
+
+	return messages, isLocked, nil
+}
+
+func (outboxDao *OutboxDao) CreateOutboxMessage(message commonModels.OutboxMessage) (
+	commonModels.OutboxMessage, *commonStructures.CommonError) {
+
+	return outboxDao.CreateOutboxMessageWithTx(outboxDao.Db, message)
+}
+
+func (outboxDao *OutboxDao) CreateOutboxMessageWithTx(tx *gorm.DB, message commonModels.OutboxMessage) (
+	commonModels.OutboxMessage, *commonStructures.CommonError) {
+
+	if err := tx.Create(&message).Error; err != nil {
+		return message, commonUtils.HandleORMError(err)
+	}
+
+	return message, nil
+}
+
+func (outboxDao *OutboxDao) UpdateOutboxMessage(message commonModels.OutboxMessage) *commonStructures.CommonError {
+	updates := map[string]interface{}{
+		"status":          message.Status,
+		"attempts":        message.Attempts,
+		"next_attempt_at": message.NextAttemptAt,
+		"last_error":      message.LastError,
+		"published_at":    message.PublishedAt,
+		"updated_by":      message.UpdatedBy,
+		"updated_at":      commonUtils.GetCurrentTime(),
+	}
+
+	if err := outboxDao.Db.Model(&commonModels.OutboxMessage{}).Where("id = ?", message.Id).
+		Updates(updates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+func (outboxDao *OutboxDao) ResetOutboxMessages(messageIds []uint, userId uint) *commonStructures.CommonError {
+	updates := map[string]interface{}{
+		"status":          commonConstants.OUTBOX_STATUS_PENDING,
+		"attempts":        0,
+		"next_attempt_at": commonUtils.GetCurrentTime(),
+		"last_error":      "",
+		"updated_by":      userId,
+		"updated_at":      commonUtils.GetCurrentTime(),
+	}
+
+	if err := outboxDao.Db.Model(&commonModels.OutboxMessage{}).
+		Where("id IN ? AND status <> ?", messageIds, commonConstants.OUTBOX_STATUS_PUBLISHED).
+		Updates(updates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
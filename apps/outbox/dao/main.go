@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type OutboxDao struct {
+	Db *gorm.DB
+}
+
+func InitializeOutboxDao() DataLayer {
+	return &OutboxDao{
+		Db: psql.GetDbInstance(),
+	}
+}
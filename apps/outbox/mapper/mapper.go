@@ -0,0 +1,69 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Orange-Health/citadel/apps/outbox/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapOutboxMessage(message commonModels.OutboxMessage) structures.OutboxMessage {
+	return structures.OutboxMessage{
+		Id:                     message.Id,
+		Topic:                  message.Topic,
+		MessageGroupId:         message.MessageGroupId,
+		MessageDeduplicationId: message.MessageDeduplicationId,
+		EventType:              message.EventType,
+		Status:                 message.Status,
+		Attempts:               message.Attempts,
+		NextAttemptAt:          message.NextAttemptAt,
+		LastError:              message.LastError,
+		PublishedAt:            message.PublishedAt,
+		CreatedAt:              message.CreatedAt,
+		MessageBody:            json.RawMessage(message.MessageBody),
+		MessageAttributes:      json.RawMessage(message.MessageAttributes),
+	}
+}
+
+func MapOutboxMessages(messages []commonModels.OutboxMessage) []structures.OutboxMessage {
+	messagesResponse := []structures.OutboxMessage{}
+	for _, message := range messages {
+		messagesResponse = append(messagesResponse, MapOutboxMessage(message))
+	}
+	return messagesResponse
+}
+
+func MapNewOutboxMessage(messageBody, messageAttributes map[string]interface{}, messageGroupId, topic,
+	messageDeduplicationId string) (commonModels.OutboxMessage, error) {
+
+	messageBodyBytes, err := json.Marshal(messageBody)
+	if err != nil {
+		return commonModels.OutboxMessage{}, err
+	}
+	messageAttributesBytes, err := json.Marshal(messageAttributes)
+	if err != nil {
+		return commonModels.OutboxMessage{}, err
+	}
+
+	eventType := ""
+	if messageAttributes["event_type"] != nil {
+		eventType = fmt.Sprint(messageAttributes["event_type"])
+	}
+
+	message := commonModels.OutboxMessage{
+		Topic:                  topic,
+		MessageGroupId:         messageGroupId,
+		MessageDeduplicationId: messageDeduplicationId,
+		MessageBody:            string(messageBodyBytes),
+		MessageAttributes:      string(messageAttributesBytes),
+		EventType:              eventType,
+		Status:                 commonConstants.OUTBOX_STATUS_PENDING,
+		NextAttemptAt:          commonUtils.GetCurrentTime(),
+	}
+	message.CreatedBy = commonConstants.CitadelSystemId
+	message.UpdatedBy = commonConstants.CitadelSystemId
+	return message, nil
+}
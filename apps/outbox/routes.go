@@ -0,0 +1,15 @@
+package outbox
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/outbox/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	outboxController := controller.InitOutboxController()
+
+	router.GET("/messages", outboxController.GetOutboxMessages)
+	router.GET("/messages/:messageId", outboxController.GetOutboxMessage)
+	router.POST("/messages/replay", outboxController.ReplayOutboxMessages)
+}
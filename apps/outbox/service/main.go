@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/apps/outbox/dao"
+	userService "github.com/Orange-Health/citadel/apps/users/service"
+	snsClient "github.com/Orange-Health/citadel/clients/sns"
+)
+
+type OutboxService struct {
+	OutboxDao   dao.DataLayer
+	Sentry      sentry.SentryLayer
+	SnsClient   snsClient.SnsClientInterface
+	UserService userService.UserServiceInterface
+}
+
+func InitializeOutboxService() OutboxServiceInterface {
+	return &OutboxService{
+		OutboxDao:   dao.InitializeOutboxDao(),
+		Sentry:      sentry.InitializeSentry(),
+		SnsClient:   snsClient.InitializeSnsClient(),
+		UserService: userService.InitializeUserService(),
+	}
+}
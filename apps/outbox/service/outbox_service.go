@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/apps/outbox/mapper"
+	"github.com/Orange-Health/citadel/apps/outbox/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type OutboxServiceInterface interface {
+	WriteMessage(ctx context.Context, messageBody, messageAttributes map[string]interface{}, messageGroupId,
+		topic, messageDeduplicationId string) *commonStructures.CommonError
+	WriteMessageWithTx(ctx context.Context, tx *gorm.DB, messageBody, messageAttributes map[string]interface{},
+		messageGroupId, topic, messageDeduplicationId string) *commonStructures.CommonError
+	RelayPendingMessages(ctx context.Context)
+	GetOutboxMessages(filter structures.OutboxMessagesFilter) (
+		[]structures.OutboxMessage, *commonStructures.CommonError)
+	GetOutboxMessage(messageId uint) (structures.OutboxMessage, *commonStructures.CommonError)
+	ReplayOutboxMessages(messageIds []uint, userId uint) ([]structures.OutboxMessage, *commonStructures.CommonError)
+}
+
+// WriteMessage puts a message on the outbox for the relay to publish, for the callers that have no transaction
+// to write it in but still want it retried.
+func (outboxService *OutboxService) WriteMessage(ctx context.Context, messageBody,
+	messageAttributes map[string]interface{}, messageGroupId, topic,
+	messageDeduplicationId string) *commonStructures.CommonError {
+
+	return outboxService.writeMessage(ctx, nil, messageBody, messageAttributes, messageGroupId, topic,
+		messageDeduplicationId)
+}
+
+// WriteMessageWithTx puts a message on the outbox in the transaction of the change it announces, so that the
+// message is published if and only if the change is committed.
+func (outboxService *OutboxService) WriteMessageWithTx(ctx context.Context, tx *gorm.DB, messageBody,
+	messageAttributes map[string]interface{}, messageGroupId, topic,
+	messageDeduplicationId string) *commonStructures.CommonError {
+
+	return outboxService.writeMessage(ctx, tx, messageBody, messageAttributes, messageGroupId, topic,
+		messageDeduplicationId)
+}
+
+func (outboxService *OutboxService) writeMessage(ctx context.Context, tx *gorm.DB, messageBody,
+	messageAttributes map[string]interface{}, messageGroupId, topic,
+	messageDeduplicationId string) *commonStructures.CommonError {
+
+	message, err := mapper.MapNewOutboxMessage(messageBody, messageAttributes, messageGroupId, topic,
+		messageDeduplicationId)
+	if err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_WRITING_OUTBOX_MESSAGE,
+			map[string]interface{}{
+				"topic":            topic,
+				"message_group_id": messageGroupId,
+			}, err)
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_WHILE_WRITING_OUTBOX_MESSAGE,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	var cErr *commonStructures.CommonError
+	if tx != nil {
+		_, cErr = outboxService.OutboxDao.CreateOutboxMessageWithTx(tx, message)
+	} else {
+		_, cErr = outboxService.OutboxDao.CreateOutboxMessage(message)
+	}
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_WRITING_OUTBOX_MESSAGE,
+			map[string]interface{}{
+				"topic":            topic,
+				"message_group_id": messageGroupId,
+				"event_type":       message.EventType,
+			}, errors.New(cErr.Message))
+		return cErr
+	}
+
+	return nil
+}
+
+// RelayPendingMessages publishes the due outbox messages, oldest first. The messages are claimed in a short
+// transaction and published outside of it, each one then being marked with the outcome of its attempt. Once a
+// message of a group fails, the rest of its group waits for it, so that a group is never published out of order.
+func (outboxService *OutboxService) RelayPendingMessages(ctx context.Context) {
+	currentTime := time.Now()
+	claimedUntil := currentTime.Add(commonConstants.OutboxRelayClaimSeconds * time.Second)
+	messages, isLocked, cErr := outboxService.OutboxDao.ClaimRelayableOutboxMessages(currentTime, claimedUntil,
+		commonConstants.OutboxRelayBatchSize)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_RELAYING_OUTBOX_MESSAGES,
+			nil, errors.New(cErr.Message))
+		return
+	}
+
+	if !isLocked {
+		commonUtils.AddLog(ctx, commonConstants.INFO_LEVEL, commonUtils.GetCurrentFunctionName(),
+			map[string]interface{}{
+				"message": "another relay is claiming messages",
+			}, nil)
+		return
+	}
+
+	blockedMessageGroupIds := map[string]bool{}
+	for _, message := range messages {
+		// A message behind a failed one of its group is released with its own next attempt unchanged.
+		if message.MessageGroupId == "" || !blockedMessageGroupIds[message.MessageGroupId] {
+			message = outboxService.publishMessage(ctx, message)
+		}
+		if cErr := outboxService.OutboxDao.UpdateOutboxMessage(message); cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_RELAYING_OUTBOX_MESSAGES,
+				map[string]interface{}{
+					"outbox_message_id": message.Id,
+				}, errors.New(cErr.Message))
+		}
+
+		if message.Status != commonConstants.OUTBOX_STATUS_PUBLISHED {
+			blockedMessageGroupIds[message.MessageGroupId] = true
+		}
+	}
+}
+
+// publishMessage publishes a message and returns it with the outcome of the attempt. A failed attempt is retried
+// with an exponential backoff until the attempts run out, after which the message is marked failed.
+func (outboxService *OutboxService) publishMessage(ctx context.Context,
+	message commonModels.OutboxMessage) commonModels.OutboxMessage {
+
+	message.Attempts++
+	message.UpdatedBy = commonConstants.CitadelSystemId
+
+	messageBody, messageAttributes := map[string]interface{}{}, map[string]interface{}{}
+	err := json.Unmarshal([]byte(message.MessageBody), &messageBody)
+	if err == nil {
+		err = json.Unmarshal([]byte(message.MessageAttributes), &messageAttributes)
+	}
+	if err == nil {
+		if cErr := outboxService.SnsClient.PublishTo(ctx, messageBody, messageAttributes, message.MessageGroupId,
+			message.Topic, message.MessageDeduplicationId); cErr != nil {
+			err = errors.New(cErr.Message)
+		}
+	}
+
+	if err == nil {
+		message.Status = commonConstants.OUTBOX_STATUS_PUBLISHED
+		message.PublishedAt = commonUtils.GetCurrentTime()
+		message.LastError = ""
+		return message
+	}
+
+	message.LastError = err.Error()
+	maxAttempts := commonConstants.OutboxMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = commonConstants.OutboxDefaultMaxAttempts
+	}
+	if message.Attempts >= uint(maxAttempts) {
+		message.Status = commonConstants.OUTBOX_STATUS_FAILED
+		outboxService.Sentry.LogError(ctx, commonConstants.ERROR_WHILE_PUBLISHING_OUTBOX_MESSAGE, err,
+			map[string]interface{}{
+				"outbox_message_id": message.Id,
+				"event_type":        message.EventType,
+				"attempts":          message.Attempts,
+			})
+	} else {
+		nextAttemptAt := time.Now().Add(time.Duration(getRetryDelaySeconds(message.Attempts)) * time.Second)
+		message.NextAttemptAt = &nextAttemptAt
+	}
+
+	commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_PUBLISHING_OUTBOX_MESSAGE,
+		map[string]interface{}{
+			"outbox_message_id": message.Id,
+			"event_type":        message.EventType,
+			"attempts":          message.Attempts,
+			"status":            message.Status,
+		}, err)
+
+	return message
+}
+
+func (outboxService *OutboxService) GetOutboxMessages(filter structures.OutboxMessagesFilter) (
+	[]structures.OutboxMessage, *commonStructures.CommonError) {
+
+	if filter.Status != "" && !commonUtils.SliceContainsString(commonConstants.OUTBOX_STATUSES, filter.Status) {
+		return []structures.OutboxMessage{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_OUTBOX_STATUS,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = commonConstants.OutboxDefaultListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	stuckAfterMinutes := commonConstants.OutboxStuckAfterMinutes
+	if stuckAfterMinutes <= 0 {
+		stuckAfterMinutes = commonConstants.OutboxDefaultStuckAfterMinutes
+	}
+	stuckBefore := time.Now().Add(-time.Duration(stuckAfterMinutes) * time.Minute)
+
+	messages, cErr := outboxService.OutboxDao.GetOutboxMessages(filter, stuckBefore)
+	if cErr != nil {
+		return []structures.OutboxMessage{}, cErr
+	}
+
+	return mapper.MapOutboxMessages(messages), nil
+}
+
+func (outboxService *OutboxService) GetOutboxMessage(messageId uint) (
+	structures.OutboxMessage, *commonStructures.CommonError) {
+
+	message, cErr := outboxService.OutboxDao.GetOutboxMessageById(messageId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_OUTBOX_MESSAGE_NOT_FOUND
+		}
+		return structures.OutboxMessage{}, cErr
+	}
+
+	return mapper.MapOutboxMessage(message), nil
+}
+
+// ReplayOutboxMessages puts failed or stuck messages back in line for the relay with a fresh set of attempts.
+// Published messages are not replayed. Only super admins can replay messages.
+func (outboxService *OutboxService) ReplayOutboxMessages(messageIds []uint, userId uint) (
+	[]structures.OutboxMessage, *commonStructures.CommonError) {
+
+	user, cErr := outboxService.UserService.GetUserModel(userId)
+	if cErr != nil {
+		return []structures.OutboxMessage{}, cErr
+	}
+	if user.UserType != commonConstants.USER_TYPE_SUPER_ADMIN {
+		return []structures.OutboxMessage{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_OUTBOX_REPLAY_NOT_ALLOWED,
+			StatusCode: http.StatusForbidden,
+		}
+	}
+
+	messageIds = commonUtils.CreateUniqueSliceUint(messageIds)
+	messages, cErr := outboxService.OutboxDao.GetOutboxMessagesByIds(messageIds)
+	if cErr != nil {
+		return []structures.OutboxMessage{}, cErr
+	}
+	if len(messages) != len(messageIds) {
+		return []structures.OutboxMessage{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_OUTBOX_MESSAGE_NOT_FOUND,
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	for _, message := range messages {
+		if message.Status == commonConstants.OUTBOX_STATUS_PUBLISHED {
+			return []structures.OutboxMessage{}, &commonStructures.CommonError{
+				Message:    commonConstants.ERROR_OUTBOX_MESSAGE_ALREADY_PUBLISHED,
+				StatusCode: http.StatusConflict,
+			}
+		}
+	}
+
+	if cErr := outboxService.OutboxDao.ResetOutboxMessages(messageIds, userId); cErr != nil {
+		return []structures.OutboxMessage{}, cErr
+	}
+
+	messages, cErr = outboxService.OutboxDao.GetOutboxMessagesByIds(messageIds)
+	if cErr != nil {
+		return []structures.OutboxMessage{}, cErr
+	}
+
+	return mapper.MapOutboxMessages(messages), nil
+}
+
+func getRetryDelaySeconds(attempts uint) int {
+	baseDelaySeconds := commonConstants.OutboxRetryBaseDelaySeconds
+	if baseDelaySeconds <= 0 {
+		baseDelaySeconds = commonConstants.OutboxDefaultRetryBaseDelaySeconds
+	}
+
+	delaySeconds := float64(baseDelaySeconds) * math.Pow(2, float64(attempts-1))
+	return int(math.Min(delaySeconds, commonConstants.OutboxMaxRetryDelaySeconds))
+}
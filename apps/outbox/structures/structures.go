@@ -0,0 +1,54 @@
+package structures
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// @swagger:model OutboxMessage
+type OutboxMessage struct {
+	// The outbox message ID.
+	// example: 1
+	Id uint `json:"id"`
+	// example: "arn:aws:sns:ap-south-1:000000000000:oms-updates.fifo"
+	Topic string `json:"topic"`
+	// Messages of a group are published in the order they were written.
+	// example: "1234567"
+	MessageGroupId string `json:"message_group_id"`
+	// example: ""
+	MessageDeduplicationId string `json:"message_deduplication_id"`
+	// example: "update_test_status"
+	EventType string `json:"event_type"`
+	// One of pending, published and failed.
+	// example: "pending"
+	Status string `json:"status"`
+	// example: 2
+	Attempts uint `json:"attempts"`
+	// example: "2026-10-18T10:00:00Z"
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	// example: "RequestError: send request failed"
+	LastError string `json:"last_error"`
+	// example: null
+	PublishedAt *time.Time `json:"published_at"`
+	// example: "2026-10-18T09:58:00Z"
+	CreatedAt *time.Time `json:"created_at"`
+	// The message as it is published.
+	MessageBody json.RawMessage `json:"message_body"`
+	// The SNS message attributes.
+	MessageAttributes json.RawMessage `json:"message_attributes"`
+}
+
+type ReplayOutboxMessagesRequest struct {
+	MessageIds []uint `json:"message_ids" binding:"required,min=1"`
+}
+
+type OutboxMessagesFilter struct {
+	Status         string
+	Topic          string
+	MessageGroupId string
+	EventType      string
+	// StuckOnly keeps the messages that are still not published long after they were written.
+	StuckOnly bool
+	Limit     int
+	Offset    int
+}
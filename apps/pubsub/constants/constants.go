@@ -12,6 +12,8 @@ var PubSubEventContainsMap = map[string][]string{
 	LabEtaUpdateEvent:            labEtaUpdateEventContains,
 	CheckOrderCompletionEvent:    checkOrderCompletionEventContains,
 	CitadelLisEvent:              citadelLisEventContains,
+	CriticalCallEscalatedEvent:   criticalCallEscalatedEventContains,
+	ReflexTestAddedEvent:         reflexTestAddedEventContains,
 }
 
 const (
@@ -27,6 +29,8 @@ const (
 	CheckOrderCompletionEvent    = "order.check_order_completion"
 	CitadelLisEvent              = "lisvisit.update"
 	EtsTestEvent                 = "ets.test"
+	CriticalCallEscalatedEvent   = "order.critical_call_escalated"
+	ReflexTestAddedEvent         = "order.reflex_test_added"
 )
 
 var (
@@ -41,4 +45,6 @@ var (
 	labEtaUpdateEventContains            = []string{"test_ids", "lis_sync_at"}
 	checkOrderCompletionEventContains    = []string{"order_id"}
 	citadelLisEventContains              = []string{"lis_data", "visit_id"}
+	criticalCallEscalatedEventContains   = []string{"critical_call_id", "order_id", "escalation_count"}
+	reflexTestAddedEventContains         = []string{"order_id", "tests"}
 )
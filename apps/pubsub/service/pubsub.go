@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"strings"
 	"time"
@@ -34,10 +33,14 @@ type PubsubInterface interface {
 		map[string]interface{}, map[string]interface{})
 	GetCheckOrderCompletionEvent(orderId, cityCode string) (
 		map[string]interface{}, map[string]interface{})
-	GetLisDataEvent(ctx context.Context, attuneOrderResponse commonStructures.AttuneOrderResponse) (
+	GetLisDataEvent(visitId, webhookData string) (
 		map[string]interface{}, map[string]interface{})
 	GetEtsTestEvent(eventPayload commonStructures.EtsTestEvent) (
 		map[string]interface{}, map[string]interface{})
+	GetCriticalCallEscalatedEvent(escalatedEvent commonStructures.CriticalCallEscalatedEvent) (
+		map[string]interface{}, map[string]interface{})
+	GetReflexTestAddedEvent(reflexTestAddedEvent commonStructures.ReflexTestAddedEvent) (
+		map[string]interface{}, map[string]interface{})
 }
 
 func (s *PubsubService) GetReportGenerationEvent(ctx context.Context, eventPayload interface{}) (
@@ -244,8 +247,7 @@ func (s *PubsubService) GetCheckOrderCompletionEvent(orderId, cityCode string) (
 	return eventPayload, messageAttributes
 }
 
-func (s *PubsubService) GetLisDataEvent(ctx context.Context, attuneOrderResponse commonStructures.AttuneOrderResponse) (
-	map[string]interface{},
+func (s *PubsubService) GetLisDataEvent(visitId, webhookData string) (map[string]interface{},
 	map[string]interface{}) {
 	eventType := constants.CitadelLisEvent
 	messageAttributes := map[string]interface{}{
@@ -254,17 +256,10 @@ func (s *PubsubService) GetLisDataEvent(ctx context.Context, attuneOrderResponse
 		"event_type": eventType,
 	}
 
-	jsonBytes, err := json.Marshal(attuneOrderResponse)
-	if err != nil {
-		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_FAILED_TO_MARSHAL_PAYLOAD, nil, err)
-		return nil, nil
-	}
-
-	base64BodyString := base64.StdEncoding.EncodeToString(jsonBytes)
 	messageBody := map[string]interface{}{
-		"entity_id":    attuneOrderResponse.OrderId,
+		"entity_id":    visitId,
 		"entity_name":  eventType,
-		"webhook_data": base64BodyString,
+		"webhook_data": webhookData,
 	}
 
 	return messageBody, messageAttributes
@@ -322,3 +317,44 @@ func (s *PubsubService) GetEtsTestEvent(eventPayload commonStructures.EtsTestEve
 
 	return messageBody, messageAttributes
 }
+
+func (s *PubsubService) GetCriticalCallEscalatedEvent(escalatedEvent commonStructures.CriticalCallEscalatedEvent) (
+	map[string]interface{}, map[string]interface{}) {
+	eventType := constants.CriticalCallEscalatedEvent
+	messageAttributes := map[string]interface{}{
+		"source":     strings.ToLower(commonConstants.CitadelServiceName),
+		"contains":   constants.PubSubEventContainsMap[eventType],
+		"event_type": eventType,
+	}
+
+	eventPayload := map[string]interface{}{
+		"critical_call_id":    escalatedEvent.CriticalCallId,
+		"order_id":            escalatedEvent.OmsOrderId,
+		"investigation_name":  escalatedEvent.InvestigationName,
+		"investigation_value": escalatedEvent.InvestigationValue,
+		"uom":                 escalatedEvent.Uom,
+		"escalation_count":    escalatedEvent.EscalationCount,
+		"open_since":          escalatedEvent.OpenSince,
+		"servicing_city_code": escalatedEvent.CityCode,
+	}
+
+	return eventPayload, messageAttributes
+}
+
+func (s *PubsubService) GetReflexTestAddedEvent(reflexTestAddedEvent commonStructures.ReflexTestAddedEvent) (
+	map[string]interface{}, map[string]interface{}) {
+	eventType := constants.ReflexTestAddedEvent
+	messageAttributes := map[string]interface{}{
+		"source":     strings.ToLower(commonConstants.CitadelServiceName),
+		"contains":   constants.PubSubEventContainsMap[eventType],
+		"event_type": eventType,
+	}
+
+	eventPayload := map[string]interface{}{
+		"order_id":            reflexTestAddedEvent.OmsOrderId,
+		"tests":               reflexTestAddedEvent.Tests,
+		"servicing_city_code": reflexTestAddedEvent.CityCode,
+	}
+
+	return eventPayload, messageAttributes
+}
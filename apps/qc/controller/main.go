@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/qc/service"
+)
+
+type Qc struct {
+	QcService service.QcServiceInterface
+}
+
+func InitQcController() *Qc {
+	return &Qc{
+		QcService: service.InitializeQcService(),
+	}
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/qc/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructs "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get QC Targets
+// @Description	Get QC Targets, optionally filtered by lab, lot number and lis code
+// @Tags			qc
+// @Produce		json
+// @Param			lab_id		query		int								false	"Lab ID"
+// @Param			lot_number	query		string							false	"Lot Number"
+// @Param			lis_code	query		string							false	"LIS Code"
+// @Success		200			{object}	[]structures.QcTarget			"QC Targets"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/qc/targets [get]
+func (qcController *Qc) GetQcTargets(c *gin.Context) {
+	labId := commonUtils.ConvertStringToUint(c.Query("lab_id"))
+
+	targets, cErr := qcController.QcService.GetQcTargets(labId, c.Query("lot_number"), c.Query("lis_code"))
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, targets)
+}
+
+// @Summary		Create QC Target
+// @Description	Create the target mean and SD of a QC lot for an analyte
+// @Tags			qc
+// @Accept			json
+// @Produce		json
+// @Param			target		body		structures.QcTargetRequest		true	"QC Target"
+// @Success		200			{object}	structures.QcTarget				"QC Target"
+// @Failure		400,409,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/qc/targets [post]
+func (qcController *Qc) CreateQcTarget(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	targetRequest := structures.QcTargetRequest{}
+	if err := c.ShouldBindJSON(&targetRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	target, cErr := qcController.QcService.CreateQcTarget(targetRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+// @Summary		Update QC Target
+// @Description	Update a QC Target
+// @Tags			qc
+// @Accept			json
+// @Produce		json
+// @Param			targetId		path		int								true	"QC Target ID"
+// @Param			target			body		structures.QcTargetRequest		true	"QC Target"
+// @Success		200				{object}	structures.QcTarget				"QC Target"
+// @Failure		400,404,409,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/qc/targets/{targetId} [put]
+func (qcController *Qc) UpdateQcTarget(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	targetId := commonUtils.ConvertStringToUint(c.Param("targetId"))
+	if targetId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_QC_TARGET_ID)
+		return
+	}
+
+	targetRequest := structures.QcTargetRequest{}
+	if err := c.ShouldBindJSON(&targetRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	target, cErr := qcController.QcService.UpdateQcTarget(targetId, targetRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+// @Summary		Delete QC Target
+// @Description	Delete a QC Target
+// @Tags			qc
+// @Produce		json
+// @Param			targetId	path		int								true	"QC Target ID"
+// @Success		200			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/qc/targets/{targetId} [delete]
+func (qcController *Qc) DeleteQcTarget(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	targetId := commonUtils.ConvertStringToUint(c.Param("targetId"))
+	if targetId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_QC_TARGET_ID)
+		return
+	}
+
+	cErr = qcController.QcService.DeleteQcTarget(targetId, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructs.CommonAPIResponse{
+		Message: commonConstants.DONE_RESPONSE,
+	})
+}
+
+// @Summary		Get QC Results
+// @Description	Get the QC history of a lab with the Westgard evaluation of every run, most recent first
+// @Tags			qc
+// @Produce		json
+// @Param			lab_id		query		int								true	"Lab ID"
+// @Param			lot_number	query		string							false	"Lot Number"
+// @Param			lis_code	query		string							false	"LIS Code"
+// @Param			limit		query		int								false	"Limit"
+// @Success		200			{object}	[]structures.QcResult			"QC Results"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/qc/results [get]
+func (qcController *Qc) GetQcResults(c *gin.Context) {
+	filter := structures.QcResultsFilter{
+		LabId:     commonUtils.ConvertStringToUint(c.Query("lab_id")),
+		LotNumber: c.Query("lot_number"),
+		LisCode:   c.Query("lis_code"),
+		Limit:     commonUtils.ConvertStringToInt(c.Query("limit")),
+	}
+
+	qcResults, cErr := qcController.QcService.GetQcResults(filter)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, qcResults)
+}
+
+// @Summary		Record QC Result
+// @Description	Record a QC run and evaluate the Westgard rules over the history of its lot and analyte
+// @Tags			qc
+// @Accept			json
+// @Produce		json
+// @Param			qc_result	body		structures.QcResultRequest		true	"QC Result"
+// @Success		200			{object}	structures.QcResult				"QC Result"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/qc/results [post]
+func (qcController *Qc) RecordQcResult(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	qcResultRequest := structures.QcResultRequest{}
+	if err := c.ShouldBindJSON(&qcResultRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	qcResult, cErr := qcController.QcService.RecordQcResult(c.Request.Context(), qcResultRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, qcResult)
+}
+
+// @Summary		Get QC Rerun Candidates
+// @Description	Get the unapproved patient results of the analyte of a QC run entered since the last accepted run of its lot, to be rerun if the run failed
+// @Tags			qc
+// @Produce		json
+// @Param			qcResultId	path		int								true	"QC Result ID"
+// @Success		200			{object}	[]structures.QcRerunCandidate	"QC Rerun Candidates"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/qc/results/{qcResultId}/rerun-candidates [get]
+func (qcController *Qc) GetQcRerunCandidates(c *gin.Context) {
+	qcResultId := commonUtils.ConvertStringToUint(c.Param("qcResultId"))
+	if qcResultId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_QC_RESULT_ID)
+		return
+	}
+
+	rerunCandidates, cErr := qcController.QcService.GetQcRerunCandidates(qcResultId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rerunCandidates)
+}
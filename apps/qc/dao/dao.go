@@ -0,0 +1,208 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/qc/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetQcTargetById(targetId uint) (commonModels.QcTarget, *commonStructures.CommonError)
+	GetQcTargets(labId uint, lotNumber, lisCode string) ([]commonModels.QcTarget, *commonStructures.CommonError)
+	GetQcTarget(labId uint, lotNumber, lisCode string) (commonModels.QcTarget, *commonStructures.CommonError)
+	GetQcResults(filter structures.QcResultsFilter) ([]commonModels.QcResult, *commonStructures.CommonError)
+	GetQcResultById(qcResultId uint) (commonModels.QcResult, *commonStructures.CommonError)
+	GetQcResultByRun(labId uint, lotNumber, lisCode string, runAt *time.Time) (
+		commonModels.QcResult, *commonStructures.CommonError)
+	GetLastAcceptedQcResult(qcResult commonModels.QcResult) (commonModels.QcResult, *commonStructures.CommonError)
+	GetQcRerunCandidates(filter structures.QcRerunCandidatesFilter) (
+		[]structures.QcRerunCandidate, *commonStructures.CommonError)
+
+	CreateQcTarget(target commonModels.QcTarget) (commonModels.QcTarget, *commonStructures.CommonError)
+	UpdateQcTarget(target commonModels.QcTarget) (commonModels.QcTarget, *commonStructures.CommonError)
+	DeleteQcTarget(targetId, userId uint) *commonStructures.CommonError
+	CreateQcResult(qcResult commonModels.QcResult) (commonModels.QcResult, *commonStructures.CommonError)
+}
+
+func (qcDao *QcDao) GetQcTargetById(targetId uint) (commonModels.QcTarget, *commonStructures.CommonError) {
+
+	target := commonModels.QcTarget{}
+	if err := qcDao.Db.Where("id = ?", targetId).First(&target).Error; err != nil {
+		return target, commonUtils.HandleORMError(err)
+	}
+
+	return target, nil
+}
+
+func (qcDao *QcDao) GetQcTargets(labId uint, lotNumber, lisCode string) (
+	[]commonModels.QcTarget, *commonStructures.CommonError) {
+
+	targets := []commonModels.QcTarget{}
+	query := qcDao.Db
+	if labId != 0 {
+		query = query.Where("lab_id = ?", labId)
+	}
+	if lotNumber != "" {
+		query = query.Where("lot_number = ?", lotNumber)
+	}
+	if lisCode != "" {
+		query = query.Where("lis_code = ?", lisCode)
+	}
+	if err := query.Order("lab_id, lot_number, lis_code").Find(&targets).Error; err != nil {
+		return targets, commonUtils.HandleORMError(err)
+	}
+
+	return targets, nil
+}
+
+func (qcDao *QcDao) GetQcTarget(labId uint, lotNumber, lisCode string) (
+	commonModels.QcTarget, *commonStructures.CommonError) {
+
+	target := commonModels.QcTarget{}
+	if err := qcDao.Db.Where("lab_id = ?", labId).Where("lot_number = ?", lotNumber).
+		Where("lis_code = ?", lisCode).First(&target).Error; err != nil {
+		return target, commonUtils.HandleORMError(err)
+	}
+
+	return target, nil
+}
+
+// GetQcResults returns the QC results matching the filter, most recent run first.
+func (qcDao *QcDao) GetQcResults(filter structures.QcResultsFilter) (
+	[]commonModels.QcResult, *commonStructures.CommonError) {
+
+	qcResults := []commonModels.QcResult{}
+	query := qcDao.Db.Where("lab_id = ?", filter.LabId)
+	if filter.LotNumber != "" {
+		query = query.Where("lot_number = ?", filter.LotNumber)
+	}
+	if filter.LisCode != "" {
+		query = query.Where("lis_code = ?", filter.LisCode)
+	}
+	if err := query.Order("run_at DESC, id DESC").Limit(filter.Limit).Find(&qcResults).Error; err != nil {
+		return qcResults, commonUtils.HandleORMError(err)
+	}
+
+	return qcResults, nil
+}
+
+func (qcDao *QcDao) GetQcResultById(qcResultId uint) (commonModels.QcResult, *commonStructures.CommonError) {
+
+	qcResult := commonModels.QcResult{}
+	if err := qcDao.Db.Where("id = ?", qcResultId).First(&qcResult).Error; err != nil {
+		return qcResult, commonUtils.HandleORMError(err)
+	}
+
+	return qcResult, nil
+}
+
+// GetQcResultByRun returns the stored QC result of a run, identified by its lab, lot, analyte and run time.
+// An empty result is returned if the run is not stored yet.
+func (qcDao *QcDao) GetQcResultByRun(labId uint, lotNumber, lisCode string, runAt *time.Time) (
+	commonModels.QcResult, *commonStructures.CommonError) {
+
+	qcResult := commonModels.QcResult{}
+	if err := qcDao.Db.Where("lab_id = ? AND lot_number = ? AND lis_code = ?", labId, lotNumber, lisCode).
+		Where("run_at = ?", runAt).Order("id DESC").Limit(1).Find(&qcResult).Error; err != nil {
+		return qcResult, commonUtils.HandleORMError(err)
+	}
+
+	return qcResult, nil
+}
+
+// GetLastAcceptedQcResult returns the latest run of the lot and analyte before the given run that did not fail.
+// An empty result is returned if there is none.
+func (qcDao *QcDao) GetLastAcceptedQcResult(qcResult commonModels.QcResult) (
+	commonModels.QcResult, *commonStructures.CommonError) {
+
+	lastAcceptedQcResult := commonModels.QcResult{}
+	if err := qcDao.Db.Where("lab_id = ? AND lot_number = ? AND lis_code = ?", qcResult.LabId, qcResult.LotNumber,
+		qcResult.LisCode).
+		Where("run_at < ? AND qc_status IN ?", qcResult.RunAt,
+			[]string{commonConstants.QCStatusPass, commonConstants.QCStatusPassWithWarnings}).
+		Order("run_at DESC, id DESC").Limit(1).Find(&lastAcceptedQcResult).Error; err != nil {
+		return lastAcceptedQcResult, commonUtils.HandleORMError(err)
+	}
+
+	return lastAcceptedQcResult, nil
+}
+
+// GetQcRerunCandidates returns the unapproved patient results of the analyte processed in the lab and entered
+// within the window of the filter, oldest first.
+func (qcDao *QcDao) GetQcRerunCandidates(filter structures.QcRerunCandidatesFilter) (
+	[]structures.QcRerunCandidate, *commonStructures.CommonError) {
+
+	rerunCandidates := []structures.QcRerunCandidate{}
+	query := qcDao.Db.Table(commonConstants.TableInvestigationResults).
+		Joins("INNER JOIN test_details ON test_details.id = investigation_results.test_details_id").
+		Where("test_details.processing_lab_id = ? AND investigation_results.lis_code = ?", filter.LabId,
+			filter.LisCode).
+		Where("investigation_results.investigation_status IN ?",
+			commonConstants.QcRerunCandidateInvestigationStatuses).
+		Where("investigation_results.entered_at <= ?", filter.EnteredUntil).
+		Where("investigation_results.deleted_at IS NULL AND test_details.deleted_at IS NULL")
+	if filter.EnteredAfter != nil {
+		query = query.Where("investigation_results.entered_at > ?", filter.EnteredAfter)
+	}
+	if err := query.Select("test_details.task_id, investigation_results.test_details_id, " +
+		"investigation_results.id AS investigation_result_id, investigation_results.investigation_name, " +
+		"investigation_results.investigation_value, investigation_results.investigation_status, " +
+		"investigation_results.entered_at").
+		Order("investigation_results.entered_at, investigation_results.id").
+		Scan(&rerunCandidates).Error; err != nil {
+		return rerunCandidates, commonUtils.HandleORMError(err)
+	}
+
+	return rerunCandidates, nil
+}
+
+func (qcDao *QcDao) CreateQcTarget(target commonModels.QcTarget) (
+	commonModels.QcTarget, *commonStructures.CommonError) {
+
+	if err := qcDao.Db.Create(&target).Error; err != nil {
+		return target, commonUtils.HandleORMError(err)
+	}
+
+	return target, nil
+}
+
+func (qcDao *QcDao) UpdateQcTarget(target commonModels.QcTarget) (
+	commonModels.QcTarget, *commonStructures.CommonError) {
+
+	if err := qcDao.Db.Save(&target).Error; err != nil {
+		return target, commonUtils.HandleORMError(err)
+	}
+
+	return target, nil
+}
+
+func (qcDao *QcDao) DeleteQcTarget(targetId, userId uint) *commonStructures.CommonError {
+
+	currentTime := commonUtils.GetCurrentTime()
+	targetUpdates := map[string]interface{}{
+		"deleted_by": userId,
+		"updated_by": userId,
+		"deleted_at": currentTime,
+		"updated_at": currentTime,
+	}
+	if err := qcDao.Db.Model(&commonModels.QcTarget{}).Where("id = ?", targetId).
+		Updates(targetUpdates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+func (qcDao *QcDao) CreateQcResult(qcResult commonModels.QcResult) (
+	commonModels.QcResult, *commonStructures.CommonError) {
+
+	if err := qcDao.Db.Create(&qcResult).Error; err != nil {
+		return qcResult, commonUtils.HandleORMError(err)
+	}
+
+	return qcResult, nil
+}
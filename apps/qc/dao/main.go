@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type QcDao struct {
+	Db *gorm.DB
+}
+
+func InitializeQcDao() DataLayer {
+	return &QcDao{
+		Db: psql.GetDbInstance(),
+	}
+}
@@ -0,0 +1,93 @@
+package mapper
+
+import (
+	"strings"
+
+	"github.com/Orange-Health/citadel/apps/qc/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapQcTarget(target commonModels.QcTarget) structures.QcTarget {
+	return structures.QcTarget{
+		Id:        target.Id,
+		LabId:     target.LabId,
+		LotNumber: target.LotNumber,
+		LisCode:   target.LisCode,
+		Mean:      target.Mean,
+		Sd:        target.Sd,
+		IsActive:  target.IsActive,
+	}
+}
+
+func MapQcTargets(targets []commonModels.QcTarget) []structures.QcTarget {
+	qcTargets := []structures.QcTarget{}
+	for _, target := range targets {
+		qcTargets = append(qcTargets, MapQcTarget(target))
+	}
+	return qcTargets
+}
+
+func MapQcTargetRequest(target commonModels.QcTarget, targetRequest structures.QcTargetRequest,
+	userId uint) commonModels.QcTarget {
+	if target.Id == 0 {
+		target.CreatedBy = userId
+		target.IsActive = true
+	}
+	target.LabId = targetRequest.LabId
+	target.LotNumber = targetRequest.LotNumber
+	target.LisCode = targetRequest.LisCode
+	target.Mean = targetRequest.Mean
+	target.Sd = targetRequest.Sd
+	if targetRequest.IsActive != nil {
+		target.IsActive = *targetRequest.IsActive
+	}
+	target.UpdatedBy = userId
+	return target
+}
+
+func MapQcResult(qcResult commonModels.QcResult) structures.QcResult {
+	westgardViolations := []string{}
+	if qcResult.WestgardViolations != "" {
+		westgardViolations = strings.Split(qcResult.WestgardViolations, commonConstants.WESTGARD_VIOLATIONS_SEPARATOR)
+	}
+	return structures.QcResult{
+		Id:                 qcResult.Id,
+		LabId:              qcResult.LabId,
+		LotNumber:          qcResult.LotNumber,
+		LisCode:            qcResult.LisCode,
+		QcValue:            qcResult.QcValue,
+		RunAt:              qcResult.RunAt,
+		ZScore:             qcResult.ZScore,
+		WestgardViolations: westgardViolations,
+		QcStatus:           qcResult.QcStatus,
+		Source:             qcResult.Source,
+	}
+}
+
+func MapQcResults(qcResults []commonModels.QcResult) []structures.QcResult {
+	results := []structures.QcResult{}
+	for _, qcResult := range qcResults {
+		results = append(results, MapQcResult(qcResult))
+	}
+	return results
+}
+
+// MapQcResultToMetadata copies the Westgard evaluation onto the investigation result metadata,
+// leaving the QC fields passed through from Attune untouched.
+func MapQcResultToMetadata(qcResult structures.QcResult,
+	metadata commonModels.InvestigationResultMetadata) commonModels.InvestigationResultMetadata {
+	metadata.WestgardStatus = qcResult.QcStatus
+	metadata.WestgardViolations = strings.Join(qcResult.WestgardViolations, commonConstants.WESTGARD_VIOLATIONS_SEPARATOR)
+	return metadata
+}
+
+// GetAutoApprovalFailureReason returns the failure reason to be recorded on the investigation result,
+// e.g. qc_failed|1-3s,2-2s. An empty string is returned if the QC run did not fail.
+func GetAutoApprovalFailureReason(qcResult structures.QcResult) string {
+	if qcResult.QcStatus != commonConstants.QCStatusFail {
+		return ""
+	}
+	return commonConstants.AUTO_APPROVAL_FAIL_REASON_QC_FAILED + commonConstants.AUTO_APPROVAL_FAIL_REASON_SEPARATOR +
+		strings.Join(qcResult.WestgardViolations, commonConstants.WESTGARD_VIOLATIONS_SEPARATOR)
+}
@@ -0,0 +1,19 @@
+package qc
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/qc/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	qcController := controller.InitQcController()
+
+	router.GET("/targets", qcController.GetQcTargets)
+	router.POST("/targets", qcController.CreateQcTarget)
+	router.PUT("/targets/:targetId", qcController.UpdateQcTarget)
+	router.DELETE("/targets/:targetId", qcController.DeleteQcTarget)
+	router.GET("/results", qcController.GetQcResults)
+	router.POST("/results", qcController.RecordQcResult)
+	router.GET("/results/:qcResultId/rerun-candidates", qcController.GetQcRerunCandidates)
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/apps/qc/dao"
+)
+
+type QcService struct {
+	QcDao dao.DataLayer
+}
+
+func InitializeQcService() QcServiceInterface {
+	return &QcService{
+		QcDao: dao.InitializeQcDao(),
+	}
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/qc/mapper"
+	"github.com/Orange-Health/citadel/apps/qc/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type QcServiceInterface interface {
+	GetQcTargets(labId uint, lotNumber, lisCode string) ([]structures.QcTarget, *commonStructures.CommonError)
+	CreateQcTarget(targetRequest structures.QcTargetRequest, userId uint) (
+		structures.QcTarget, *commonStructures.CommonError)
+	UpdateQcTarget(targetId uint, targetRequest structures.QcTargetRequest, userId uint) (
+		structures.QcTarget, *commonStructures.CommonError)
+	DeleteQcTarget(targetId, userId uint) *commonStructures.CommonError
+
+	GetQcResults(filter structures.QcResultsFilter) ([]structures.QcResult, *commonStructures.CommonError)
+	RecordQcResult(ctx context.Context, qcResultRequest structures.QcResultRequest, userId uint) (
+		structures.QcResult, *commonStructures.CommonError)
+	GetQcRerunCandidates(qcResultId uint) ([]structures.QcRerunCandidate, *commonStructures.CommonError)
+	EvaluateLisQcResults(ctx context.Context, labId uint,
+		testUpdates map[string]commonStructures.LisTestUpdateInfo) map[string]structures.QcResult
+}
+
+func (qcService *QcService) GetQcTargets(labId uint, lotNumber, lisCode string) (
+	[]structures.QcTarget, *commonStructures.CommonError) {
+
+	targets, cErr := qcService.QcDao.GetQcTargets(labId, lotNumber, lisCode)
+	if cErr != nil {
+		return []structures.QcTarget{}, cErr
+	}
+
+	return mapper.MapQcTargets(targets), nil
+}
+
+func (qcService *QcService) CreateQcTarget(targetRequest structures.QcTargetRequest, userId uint) (
+	structures.QcTarget, *commonStructures.CommonError) {
+
+	if cErr := validateQcTargetRequest(targetRequest); cErr != nil {
+		return structures.QcTarget{}, cErr
+	}
+
+	if cErr := qcService.validateDuplicateQcTarget(targetRequest, 0); cErr != nil {
+		return structures.QcTarget{}, cErr
+	}
+
+	target := mapper.MapQcTargetRequest(commonModels.QcTarget{}, targetRequest, userId)
+	target, cErr := qcService.QcDao.CreateQcTarget(target)
+	if cErr != nil {
+		return structures.QcTarget{}, cErr
+	}
+
+	return mapper.MapQcTarget(target), nil
+}
+
+func (qcService *QcService) UpdateQcTarget(targetId uint, targetRequest structures.QcTargetRequest,
+	userId uint) (structures.QcTarget, *commonStructures.CommonError) {
+
+	if cErr := validateQcTargetRequest(targetRequest); cErr != nil {
+		return structures.QcTarget{}, cErr
+	}
+
+	target, cErr := qcService.getQcTargetById(targetId)
+	if cErr != nil {
+		return structures.QcTarget{}, cErr
+	}
+
+	if cErr := qcService.validateDuplicateQcTarget(targetRequest, targetId); cErr != nil {
+		return structures.QcTarget{}, cErr
+	}
+
+	target = mapper.MapQcTargetRequest(target, targetRequest, userId)
+	target, cErr = qcService.QcDao.UpdateQcTarget(target)
+	if cErr != nil {
+		return structures.QcTarget{}, cErr
+	}
+
+	return mapper.MapQcTarget(target), nil
+}
+
+func (qcService *QcService) DeleteQcTarget(targetId, userId uint) *commonStructures.CommonError {
+	if _, cErr := qcService.getQcTargetById(targetId); cErr != nil {
+		return cErr
+	}
+
+	return qcService.QcDao.DeleteQcTarget(targetId, userId)
+}
+
+func (qcService *QcService) GetQcResults(filter structures.QcResultsFilter) (
+	[]structures.QcResult, *commonStructures.CommonError) {
+
+	if filter.LabId == 0 {
+		return []structures.QcResult{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_QC_LAB_ID,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = commonConstants.QcResultsDefaultLimit
+	}
+	if filter.Limit > commonConstants.QcResultsMaxLimit {
+		filter.Limit = commonConstants.QcResultsMaxLimit
+	}
+
+	qcResults, cErr := qcService.QcDao.GetQcResults(filter)
+	if cErr != nil {
+		return []structures.QcResult{}, cErr
+	}
+
+	return mapper.MapQcResults(qcResults), nil
+}
+
+// RecordQcResult stores a QC run submitted outside of the LIS events, e.g. from instrument middleware,
+// and returns its Westgard evaluation. The patient results affected by a failed run are proposed for rerun.
+func (qcService *QcService) RecordQcResult(ctx context.Context, qcResultRequest structures.QcResultRequest,
+	userId uint) (structures.QcResult, *commonStructures.CommonError) {
+
+	qcValue, err := strconv.ParseFloat(strings.TrimSpace(qcResultRequest.QcValue), 64)
+	if err != nil {
+		return structures.QcResult{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_QC_VALUE,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	runAt := qcResultRequest.RunAt
+	if runAt == nil || runAt.IsZero() {
+		runAt = commonUtils.GetCurrentTime()
+	}
+
+	qcResult := commonModels.QcResult{
+		LabId:     qcResultRequest.LabId,
+		LotNumber: qcResultRequest.LotNumber,
+		LisCode:   qcResultRequest.LisCode,
+		QcValue:   qcValue,
+		RunAt:     runAt,
+		Source:    commonConstants.QcResultSourceApi,
+	}
+	qcResult.CreatedBy = userId
+	qcResult.UpdatedBy = userId
+
+	evaluatedQcResult, cErr := qcService.evaluateAndSaveQcResult(ctx, qcResult, false)
+	if cErr != nil {
+		return structures.QcResult{}, cErr
+	}
+	if evaluatedQcResult.QcStatus == commonConstants.QCStatusFail {
+		evaluatedQcResult.RerunCandidates, cErr = qcService.GetQcRerunCandidates(evaluatedQcResult.Id)
+		if cErr != nil {
+			return structures.QcResult{}, cErr
+		}
+	}
+
+	return evaluatedQcResult, nil
+}
+
+// GetQcRerunCandidates returns the unapproved patient results of the analyte of a QC run, processed in its lab
+// and entered after the last accepted run of the lot up to the run, as the results to be rerun if it failed.
+func (qcService *QcService) GetQcRerunCandidates(qcResultId uint) (
+	[]structures.QcRerunCandidate, *commonStructures.CommonError) {
+
+	qcResult, cErr := qcService.QcDao.GetQcResultById(qcResultId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_QC_RESULT_NOT_FOUND
+		}
+		return []structures.QcRerunCandidate{}, cErr
+	}
+
+	lastAcceptedQcResult, cErr := qcService.QcDao.GetLastAcceptedQcResult(qcResult)
+	if cErr != nil {
+		return []structures.QcRerunCandidate{}, cErr
+	}
+
+	return qcService.QcDao.GetQcRerunCandidates(structures.QcRerunCandidatesFilter{
+		LabId:        qcResult.LabId,
+		LisCode:      qcResult.LisCode,
+		EnteredAfter: lastAcceptedQcResult.RunAt,
+		EnteredUntil: qcResult.RunAt,
+	})
+}
+
+// EvaluateLisQcResults records the QC runs reported alongside the patient results of a LIS event and
+// evaluates the Westgard rules over them. The evaluations are returned keyed by LIS code. Errors are
+// logged and the affected analyte is left out, so that upstream QC flags still apply.
+func (qcService *QcService) EvaluateLisQcResults(ctx context.Context, labId uint,
+	testUpdates map[string]commonStructures.LisTestUpdateInfo) map[string]structures.QcResult {
+
+	lisCodeQcResultMap := map[string]structures.QcResult{}
+	if !commonUtils.IsQcEnabledForLab(labId) {
+		return lisCodeQcResultMap
+	}
+
+	qcResults := []commonModels.QcResult{}
+	for _, testDetails := range testUpdates {
+		orderInfo := testDetails.MetaData
+		if orderInfo.TestType == commonConstants.InvestigationShortHand {
+			qcResults = appendLisQcResult(qcResults, labId, orderInfo.TestCode, orderInfo.QcLotNumber,
+				orderInfo.QcValue, commonUtils.GetEnteredAtTime(orderInfo.ResultCapturedAt))
+		}
+		for _, parameter := range orderInfo.Parameters {
+			qcResults = appendLisQcResult(qcResults, labId, parameter.TestCode, parameter.QcLotNumber,
+				parameter.QcValue, commonUtils.GetEnteredAtTime(parameter.ResultCapturedAt))
+		}
+	}
+
+	for _, qcResult := range qcResults {
+		if _, exists := lisCodeQcResultMap[qcResult.LisCode]; exists {
+			continue
+		}
+		evaluatedQcResult, cErr := qcService.evaluateAndSaveQcResult(ctx, qcResult, true)
+		if cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_EVALUATING_WESTGARD_QC,
+				map[string]interface{}{
+					"lab_id":     qcResult.LabId,
+					"lot_number": qcResult.LotNumber,
+					"lis_code":   qcResult.LisCode,
+				}, errors.New(cErr.Message))
+			continue
+		}
+		lisCodeQcResultMap[qcResult.LisCode] = evaluatedQcResult
+	}
+
+	return lisCodeQcResultMap
+}
+
+// evaluateAndSaveQcResult evaluates the Westgard rules for the QC run against the history of its lot and
+// analyte and stores it. The same QC run is reported with every patient result it covers, so when
+// deduplicate is set a run already stored for the lot and analyte at the same run time is not stored
+// again and the stored evaluation is returned instead.
+func (qcService *QcService) evaluateAndSaveQcResult(ctx context.Context, qcResult commonModels.QcResult,
+	deduplicate bool) (structures.QcResult, *commonStructures.CommonError) {
+
+	if deduplicate {
+		storedQcResult, cErr := qcService.QcDao.GetQcResultByRun(qcResult.LabId, qcResult.LotNumber,
+			qcResult.LisCode, qcResult.RunAt)
+		if cErr != nil {
+			return structures.QcResult{}, &commonStructures.CommonError{
+				Message:    commonConstants.ERROR_WHILE_FETCHING_QC_HISTORY,
+				StatusCode: cErr.StatusCode,
+			}
+		}
+		if storedQcResult.Id != 0 {
+			return mapper.MapQcResult(storedQcResult), nil
+		}
+	}
+
+	history, cErr := qcService.QcDao.GetQcResults(structures.QcResultsFilter{
+		LabId:     qcResult.LabId,
+		LotNumber: qcResult.LotNumber,
+		LisCode:   qcResult.LisCode,
+		Limit:     commonConstants.QcHistoryLimit,
+	})
+	if cErr != nil {
+		return structures.QcResult{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_WHILE_FETCHING_QC_HISTORY,
+			StatusCode: cErr.StatusCode,
+		}
+	}
+
+	statistics, evaluable, cErr := qcService.getQcStatistics(qcResult, history)
+	if cErr != nil {
+		return structures.QcResult{}, cErr
+	}
+
+	if evaluable {
+		// History is most recent first, the rules expect the oldest run first and the current run last.
+		zScores := []float64{}
+		for i := len(history) - 1; i >= 0; i-- {
+			zScores = append(zScores, getZScore(history[i].QcValue, statistics))
+		}
+		zScore := getZScore(qcResult.QcValue, statistics)
+		zScores = append(zScores, zScore)
+
+		violations := getWestgardViolations(zScores)
+		qcResult.ZScore = &zScore
+		qcResult.WestgardViolations = strings.Join(violations, commonConstants.WESTGARD_VIOLATIONS_SEPARATOR)
+		qcResult.QcStatus = getWestgardStatus(violations)
+	} else {
+		qcResult.QcStatus = commonConstants.QCStatusNotEvaluated
+	}
+
+	qcResult, cErr = qcService.QcDao.CreateQcResult(qcResult)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_SAVING_QC_RESULT, nil,
+			errors.New(cErr.Message))
+		return structures.QcResult{}, cErr
+	}
+
+	return mapper.MapQcResult(qcResult), nil
+}
+
+// getQcStatistics resolves the mean and SD to evaluate the QC run against. A configured active target is
+// preferred, otherwise they are derived from the stored history of the lot and analyte. The run is not
+// evaluable if neither is available.
+func (qcService *QcService) getQcStatistics(qcResult commonModels.QcResult, history []commonModels.QcResult) (
+	structures.QcStatistics, bool, *commonStructures.CommonError) {
+
+	target, cErr := qcService.QcDao.GetQcTarget(qcResult.LabId, qcResult.LotNumber, qcResult.LisCode)
+	if cErr != nil && cErr.StatusCode != http.StatusNotFound {
+		return structures.QcStatistics{}, false, cErr
+	}
+	if cErr == nil && target.IsActive && target.Sd > 0 {
+		return structures.QcStatistics{Mean: target.Mean, Sd: target.Sd}, true, nil
+	}
+
+	if len(history) < commonConstants.QcMinimumPointsForStatistics {
+		return structures.QcStatistics{}, false, nil
+	}
+	values := []float64{}
+	for _, pastQcResult := range history {
+		values = append(values, pastQcResult.QcValue)
+	}
+	mean, sd := getMeanAndSd(values)
+	if sd == 0 {
+		return structures.QcStatistics{}, false, nil
+	}
+
+	return structures.QcStatistics{Mean: mean, Sd: sd}, true, nil
+}
+
+func (qcService *QcService) getQcTargetById(targetId uint) (commonModels.QcTarget, *commonStructures.CommonError) {
+	target, cErr := qcService.QcDao.GetQcTargetById(targetId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_QC_TARGET_NOT_FOUND
+		}
+		return target, cErr
+	}
+
+	return target, nil
+}
+
+func (qcService *QcService) validateDuplicateQcTarget(targetRequest structures.QcTargetRequest,
+	targetId uint) *commonStructures.CommonError {
+
+	existingTarget, cErr := qcService.QcDao.GetQcTarget(targetRequest.LabId, targetRequest.LotNumber,
+		targetRequest.LisCode)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return cErr
+	}
+
+	if existingTarget.Id != targetId {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_QC_TARGET_ALREADY_EXISTS,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	return nil
+}
+
+func validateQcTargetRequest(targetRequest structures.QcTargetRequest) *commonStructures.CommonError {
+	if targetRequest.Sd <= 0 {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_QC_TARGET_SD,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+func appendLisQcResult(qcResults []commonModels.QcResult, labId uint, lisCode, lotNumber, value string,
+	runAt *time.Time) []commonModels.QcResult {
+
+	if lisCode == "" || lotNumber == "" || strings.TrimSpace(value) == "" {
+		return qcResults
+	}
+	qcValue, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return qcResults
+	}
+	if runAt == nil || runAt.IsZero() {
+		runAt = commonUtils.GetCurrentTime()
+	}
+
+	qcResult := commonModels.QcResult{
+		LabId:     labId,
+		LotNumber: lotNumber,
+		LisCode:   lisCode,
+		QcValue:   qcValue,
+		RunAt:     runAt,
+		Source:    commonConstants.QcResultSourceLis,
+	}
+	qcResult.CreatedBy = commonConstants.CitadelSystemId
+	qcResult.UpdatedBy = commonConstants.CitadelSystemId
+	return append(qcResults, qcResult)
+}
+
+func getZScore(value float64, statistics structures.QcStatistics) float64 {
+	return (value - statistics.Mean) / statistics.Sd
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"math"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// getWestgardViolations evaluates the Westgard multi-rules over the z-scores of a lot and analyte, ordered
+// oldest first with the run being evaluated last. Only rule windows ending at the latest run are checked,
+// earlier windows were evaluated when their own latest run was recorded.
+func getWestgardViolations(zScores []float64) []string {
+	violations := []string{}
+	count := len(zScores)
+	if count == 0 {
+		return violations
+	}
+	latest := zScores[count-1]
+
+	if math.Abs(latest) > 2 {
+		violations = append(violations, commonConstants.Westgard12s)
+	}
+	if math.Abs(latest) > 3 {
+		violations = append(violations, commonConstants.Westgard13s)
+	}
+	if count >= 2 {
+		previous := zScores[count-2]
+		if (latest > 2 && previous > 2) || (latest < -2 && previous < -2) {
+			violations = append(violations, commonConstants.Westgard22s)
+		}
+		if (latest > 2 && previous < -2) || (latest < -2 && previous > 2) {
+			violations = append(violations, commonConstants.WestgardR4s)
+		}
+	}
+	if areOnSameSideBeyond(zScores, 4, 1) {
+		violations = append(violations, commonConstants.Westgard41s)
+	}
+	if areOnSameSideBeyond(zScores, 10, 0) {
+		violations = append(violations, commonConstants.Westgard10x)
+	}
+
+	return violations
+}
+
+// areOnSameSideBeyond checks if the last n z-scores all exceed the limit on the same side of the mean.
+func areOnSameSideBeyond(zScores []float64, n int, limit float64) bool {
+	if len(zScores) < n {
+		return false
+	}
+	above, below := true, true
+	for _, zScore := range zScores[len(zScores)-n:] {
+		above = above && zScore > limit
+		below = below && zScore < -limit
+	}
+	return above || below
+}
+
+func getWestgardStatus(violations []string) string {
+	for _, violation := range violations {
+		if commonUtils.SliceContainsString(commonConstants.WestgardRejectionRules, violation) {
+			return commonConstants.QCStatusFail
+		}
+	}
+	if len(violations) > 0 {
+		return commonConstants.QCStatusPassWithWarnings
+	}
+	return commonConstants.QCStatusPass
+}
+
+// getMeanAndSd returns the mean and the sample standard deviation of the values.
+func getMeanAndSd(values []float64) (float64, float64) {
+	if len(values) < 2 {
+		return 0, 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+
+	squaredDeviations := 0.0
+	for _, value := range values {
+		squaredDeviations += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(squaredDeviations / float64(len(values)-1))
+}
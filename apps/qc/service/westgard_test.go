@@ -0,0 +1,148 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+)
+
+func TestGetWestgardViolations(t *testing.T) {
+	testCases := []struct {
+		name               string
+		zScores            []float64
+		expectedViolations []string
+	}{
+		{
+			name:               "no runs",
+			zScores:            []float64{},
+			expectedViolations: []string{},
+		},
+		{
+			name:               "within limits",
+			zScores:            []float64{0.5, -0.8, 1.9},
+			expectedViolations: []string{},
+		},
+		{
+			name:               "1-2s",
+			zScores:            []float64{0.5, -2.5},
+			expectedViolations: []string{commonConstants.Westgard12s},
+		},
+		{
+			name:               "1-3s",
+			zScores:            []float64{0.5, 3.2},
+			expectedViolations: []string{commonConstants.Westgard12s, commonConstants.Westgard13s},
+		},
+		{
+			name:               "2-2s",
+			zScores:            []float64{2.1, 2.4},
+			expectedViolations: []string{commonConstants.Westgard12s, commonConstants.Westgard22s},
+		},
+		{
+			name:               "2-2s not on the same side",
+			zScores:            []float64{-2.1, 2.4},
+			expectedViolations: []string{commonConstants.Westgard12s, commonConstants.WestgardR4s},
+		},
+		{
+			name:               "2-2s only for the window ending at the latest run",
+			zScores:            []float64{2.1, 2.4, 0.3},
+			expectedViolations: []string{},
+		},
+		{
+			name:               "4-1s",
+			zScores:            []float64{1.2, 1.5, 1.1, 1.3},
+			expectedViolations: []string{commonConstants.Westgard41s},
+		},
+		{
+			name:               "4-1s below the mean",
+			zScores:            []float64{-1.2, -1.5, -1.1, -1.3},
+			expectedViolations: []string{commonConstants.Westgard41s},
+		},
+		{
+			name:               "4-1s broken by a run within 1 sd",
+			zScores:            []float64{1.2, 0.5, 1.1, 1.3},
+			expectedViolations: []string{},
+		},
+		{
+			name:               "10x",
+			zScores:            []float64{0.2, 0.4, 0.1, 0.6, 0.3, 0.5, 0.2, 0.7, 0.1, 0.4},
+			expectedViolations: []string{commonConstants.Westgard10x},
+		},
+		{
+			name:               "10x with fewer runs",
+			zScores:            []float64{0.2, 0.4, 0.1, 0.6, 0.3, 0.5, 0.2, 0.7, 0.1},
+			expectedViolations: []string{},
+		},
+		{
+			name:    "every rule",
+			zScores: []float64{1.2, 1.5, 1.1, 1.3, 1.4, 1.2, 1.6, 1.1, 2.2, 3.5},
+			expectedViolations: []string{commonConstants.Westgard12s, commonConstants.Westgard13s,
+				commonConstants.Westgard22s, commonConstants.Westgard41s, commonConstants.Westgard10x},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedViolations, getWestgardViolations(testCase.zScores))
+		})
+	}
+}
+
+func TestGetWestgardStatus(t *testing.T) {
+	testCases := []struct {
+		name           string
+		violations     []string
+		expectedStatus string
+	}{
+		{
+			name:           "no violations",
+			violations:     []string{},
+			expectedStatus: commonConstants.QCStatusPass,
+		},
+		{
+			name:           "warning only",
+			violations:     []string{commonConstants.Westgard12s},
+			expectedStatus: commonConstants.QCStatusPassWithWarnings,
+		},
+		{
+			name:           "rejection rule",
+			violations:     []string{commonConstants.Westgard12s, commonConstants.Westgard22s},
+			expectedStatus: commonConstants.QCStatusFail,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedStatus, getWestgardStatus(testCase.violations))
+		})
+	}
+}
+
+func TestGetMeanAndSd(t *testing.T) {
+	testCases := []struct {
+		name         string
+		values       []float64
+		expectedMean float64
+		expectedSd   float64
+	}{
+		{
+			name:   "single value",
+			values: []float64{5},
+		},
+		{
+			name:         "sample standard deviation",
+			values:       []float64{2, 4, 4, 4, 5, 5, 7, 9},
+			expectedMean: 5,
+			expectedSd:   2.138089935299395,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mean, sd := getMeanAndSd(testCase.values)
+			assert.InDelta(t, testCase.expectedMean, mean, 1e-9)
+			assert.InDelta(t, testCase.expectedSd, sd, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package structures
+
+import (
+	"time"
+)
+
+// @swagger:model QcTarget
+type QcTarget struct {
+	// The QC target ID.
+	// example: 1
+	Id uint `json:"id"`
+	// The lab the QC material is run in.
+	// example: 1
+	LabId uint `json:"lab_id"`
+	// The QC material lot number.
+	// example: "LOT-2026-A"
+	LotNumber string `json:"lot_number"`
+	// The LIS code of the analyte.
+	// example: "GLU"
+	LisCode string `json:"lis_code"`
+	// The target mean of the lot for the analyte.
+	// example: 100
+	Mean float64 `json:"mean"`
+	// The target standard deviation of the lot for the analyte.
+	// example: 2.5
+	Sd float64 `json:"sd"`
+	// If the target is active.
+	// example: true
+	IsActive bool `json:"is_active"`
+}
+
+type QcTargetRequest struct {
+	LabId     uint    `json:"lab_id" binding:"required"`
+	LotNumber string  `json:"lot_number" binding:"required"`
+	LisCode   string  `json:"lis_code" binding:"required"`
+	Mean      float64 `json:"mean"`
+	Sd        float64 `json:"sd"`
+	IsActive  *bool   `json:"is_active"`
+}
+
+// @swagger:model QcResult
+type QcResult struct {
+	// The QC result ID.
+	// example: 1
+	Id uint `json:"id"`
+	// example: 1
+	LabId uint `json:"lab_id"`
+	// example: "LOT-2026-A"
+	LotNumber string `json:"lot_number"`
+	// example: "GLU"
+	LisCode string `json:"lis_code"`
+	// example: 104.2
+	QcValue float64 `json:"qc_value"`
+	// example: "2026-10-18T09:00:00Z"
+	RunAt *time.Time `json:"run_at"`
+	// The deviation of the value from the target mean, in SDs. Absent if no target could be resolved.
+	// example: 1.68
+	ZScore *float64 `json:"z_score"`
+	// The Westgard rules violated by this run.
+	// example: ["1-2s"]
+	WestgardViolations []string `json:"westgard_violations"`
+	// One of Pass, PassWithWarnings, Fail and NotEvaluated.
+	// example: "PassWithWarnings"
+	QcStatus string `json:"qc_status"`
+	// example: "lis"
+	Source string `json:"source"`
+	// The unapproved patient results of the analyte entered since the last accepted run of the lot, proposed
+	// for rerun. Only set on failed runs recorded through the API.
+	RerunCandidates []QcRerunCandidate `json:"rerun_candidates,omitempty"`
+}
+
+// @swagger:model QcRerunCandidate
+type QcRerunCandidate struct {
+	// example: 1
+	TaskId uint `json:"task_id"`
+	// example: 1
+	TestDetailsId uint `json:"test_details_id"`
+	// example: 1
+	InvestigationResultId uint `json:"investigation_result_id"`
+	// example: "Glucose Fasting"
+	InvestigationName string `json:"investigation_name"`
+	// example: "98"
+	InvestigationValue string `json:"investigation_value"`
+	// example: "pending"
+	InvestigationStatus string `json:"investigation_status"`
+	// example: "2026-10-18T08:45:00Z"
+	EnteredAt *time.Time `json:"entered_at"`
+}
+
+type QcResultRequest struct {
+	LabId     uint       `json:"lab_id" binding:"required"`
+	LotNumber string     `json:"lot_number" binding:"required"`
+	LisCode   string     `json:"lis_code" binding:"required"`
+	QcValue   string     `json:"qc_value" binding:"required"`
+	RunAt     *time.Time `json:"run_at"`
+}
+
+// QcRerunCandidatesFilter selects the unapproved patient results of an analyte in a lab entered within a window.
+type QcRerunCandidatesFilter struct {
+	LabId        uint
+	LisCode      string
+	EnteredAfter *time.Time
+	EnteredUntil *time.Time
+}
+
+type QcResultsFilter struct {
+	LabId     uint
+	LotNumber string
+	LisCode   string
+	Limit     int
+}
+
+// QcStatistics is the mean and SD a QC run is evaluated against.
+type QcStatistics struct {
+	Mean float64
+	Sd   float64
+}
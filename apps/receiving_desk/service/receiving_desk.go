@@ -81,6 +81,14 @@ func validateReceiveAndSyncSamplesRequest(
 				StatusCode: http.StatusBadRequest,
 			}
 		}
+
+		if sample.StorageCondition != "" &&
+			!commonUtils.SliceContainsString(commonConstants.StorageConditions, sample.StorageCondition) {
+			return &commonStructures.CommonError{
+				Message:    commonConstants.ERROR_INVALID_STORAGE_CONDITION,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
 	}
 
 	return nil
@@ -384,9 +392,20 @@ func (rdService *ReceivingDeskService) updateLabEtaAndLabTat(omsOrderId string,
 
 	ctx := context.Background()
 	if len(omsTestIds) == 0 || lisSyncAt == nil {
-		messageBody, messageAttributes := rdService.PubsubService.GetLabEtaUpdateEvent(omsOrderId, omsTestIds, lisSyncAt,
+		tx := rdService.ReceivingDeskDao.BeginTransaction()
+		defer tx.Rollback()
+
+		cErr := rdService.SampleService.WriteLabEtaUpdateEventWithTx(ctx, tx, omsOrderId, omsTestIds, lisSyncAt,
 			cityCode)
-		rdService.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", commonConstants.OmsUpdatesTopicArn, "")
+		if cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
+				errors.New(cErr.Message))
+			return
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
+		}
 		return
 	}
 
@@ -433,15 +452,26 @@ func (rdService *ReceivingDeskService) updateLabEtaAndLabTat(omsOrderId string,
 		finalTestDetails = append(finalTestDetails, testDetails...)
 	}
 
-	_, cErr = rdService.TestDetailsService.UpdateTestDetails(finalTestDetails)
+	tx := rdService.ReceivingDeskDao.BeginTransaction()
+	defer tx.Rollback()
+
+	_, cErr = rdService.TestDetailsService.UpdateTestDetailsWithTx(tx, finalTestDetails)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
+			errors.New(cErr.Message))
+		return
+	}
+
+	cErr = rdService.SampleService.WriteLabEtaUpdateEventWithTx(ctx, tx, omsOrderId, omsTestIds, lisSyncAt, cityCode)
 	if cErr != nil {
 		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
 			errors.New(cErr.Message))
+		return
 	}
 
-	messageBody, messageAttributes := rdService.PubsubService.GetLabEtaUpdateEvent(omsOrderId, omsTestIds, lisSyncAt,
-		cityCode)
-	rdService.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", commonConstants.OmsUpdatesTopicArn, "")
+	if err := tx.Commit().Error; err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil, err)
+	}
 }
 
 func (rdService *ReceivingDeskService) ReceiveAndSyncSamplesByOmsOrderId(ctx context.Context,
@@ -511,6 +541,15 @@ func (rdService *ReceivingDeskService) ReceiveAndSyncSamplesByOmsOrderId(ctx con
 		omsTestIdsToTestDetailsMap[testDetail.CentralOmsTestId] = testDetail
 	}
 
+	samples, cErr = rdService.rejectSamplesBeyondStability(ctx, sessionLabId, userId, samples,
+		sampleIdToSampleMetadataMap, omsTestIdsToTestDetailsMap, testSampleMappings)
+	if cErr != nil {
+		return cErr
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+
 	masterTests := rdService.CdsService.GetMasterTestsByIds(ctx, masterTestIds)
 	for _, masterTest := range masterTests {
 		masterTestIdToMasterTestMap[masterTest.Id] = masterTest
@@ -618,7 +657,7 @@ func (rdService *ReceivingDeskService) ReceiveAndSyncSamplesByOmsOrderId(ctx con
 	// Step 2: Update the samples statuses to final statuses
 	currentTime = commonUtils.GetCurrentTime()
 	if len(inhouseSampleIds) > 0 {
-		visitId, cErr := rdService.AttuneService.SyncDataToLisByOmsOrderId(ctx, omsOrderId, sessionLabId, inhouseSamples,
+		visitId, cErr := rdService.LisService.SyncOrder(ctx, omsOrderId, sessionLabId, inhouseSamples,
 			inhouseSamplesMetadata, sampleIdBarcodeMap)
 		if cErr != nil {
 			return cErr
@@ -765,13 +804,16 @@ func (rdService *ReceivingDeskService) ReceiveAndSyncSamples(ctx context.Context
 	}
 
 	sessionLabId, userId := receiveSamplesRequest.ReceivingLabId, receiveSamplesRequest.UserId
-	newBarcodes, sampleIdBarcodeMap := []string{}, map[uint]string{}
+	newBarcodes, sampleIdBarcodeMap, sampleIdStorageConditionMap := []string{}, map[uint]string{}, map[uint]string{}
 	errorStrings := []string{}
 
 	sampleIds := []uint{}
 	for _, sample := range receiveSamplesRequest.Samples {
 		sampleIds = append(sampleIds, sample.Id)
 		sampleIdBarcodeMap[sample.Id] = sample.Barcode
+		if sample.StorageCondition != "" {
+			sampleIdStorageConditionMap[sample.Id] = sample.StorageCondition
+		}
 	}
 
 	samples, samplesMetadata, cErr := rdService.SampleService.GetSamplesDataBySampleIds(sampleIds)
@@ -779,6 +821,12 @@ func (rdService *ReceivingDeskService) ReceiveAndSyncSamples(ctx context.Context
 		return nil, cErr
 	}
 
+	for index := range samplesMetadata {
+		if storageCondition, ok := sampleIdStorageConditionMap[samplesMetadata[index].SampleId]; ok {
+			samplesMetadata[index].StorageCondition = storageCondition
+		}
+	}
+
 	sampleIdToSampleMetadataMap := map[uint]commonModels.SampleMetadata{}
 	for _, sampleMetadata := range samplesMetadata {
 		sampleIdToSampleMetadataMap[sampleMetadata.SampleId] = sampleMetadata
@@ -957,12 +1005,19 @@ func (rdService *ReceivingDeskService) MarkAsNotReceived(ctx context.Context,
 		return cErr
 	}
 
-	tx.Commit()
-
 	if len(omsTestIds) > 0 && sendEvents {
-		go rdService.SampleService.PublishResetTatsEvent(omsTestIds, sample.OmsCityCode)
-		go rdService.SampleService.PublishUpdateTestStatusEvent(omsTestStatusMap, sample.OmsOrderId, true, sample.OmsCityCode)
+		cErr = rdService.SampleService.WriteResetTatsEventWithTx(ctx, tx, omsTestIds, sample.OmsCityCode)
+		if cErr != nil {
+			return cErr
+		}
+		cErr = rdService.SampleService.WriteUpdateTestStatusEventWithTx(ctx, tx, omsTestStatusMap,
+			sample.OmsOrderId, true, sample.OmsCityCode)
+		if cErr != nil {
+			return cErr
+		}
 	}
 
+	tx.Commit()
+
 	return nil
 }
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// rejectSamplesBeyondStability applies the sample stability rules of the receiving lab before the samples are
+// received and synced to the LIS. Samples beyond a reject rule are rejected through the sample rejection flow and
+// dropped from the returned samples, samples beyond a flag rule are flagged on their metadata and received as usual.
+func (rdService *ReceivingDeskService) rejectSamplesBeyondStability(ctx context.Context, labId, userId uint,
+	samples []commonModels.Sample, sampleIdToSampleMetadataMap map[uint]commonModels.SampleMetadata,
+	omsTestIdsToTestDetailsMap map[string]commonModels.TestDetail,
+	testSampleMappings []commonModels.TestSampleMapping) ([]commonModels.Sample, *commonStructures.CommonError) {
+
+	sampleNumberToMasterTestIdsMap := map[uint][]uint{}
+	for _, testSampleMapping := range testSampleMappings {
+		if testSampleMapping.IsRejected {
+			continue
+		}
+		masterTestId := omsTestIdsToTestDetailsMap[testSampleMapping.OmsTestId].MasterTestId
+		sampleNumberToMasterTestIdsMap[testSampleMapping.SampleNumber] = append(
+			sampleNumberToMasterTestIdsMap[testSampleMapping.SampleNumber], masterTestId)
+	}
+
+	samplesMetadata, sampleIdToMasterTestIdsMap := []commonModels.SampleMetadata{}, map[uint][]uint{}
+	for _, sample := range samples {
+		samplesMetadata = append(samplesMetadata, sampleIdToSampleMetadataMap[sample.Id])
+		sampleIdToMasterTestIdsMap[sample.Id] = sampleNumberToMasterTestIdsMap[sample.SampleNumber]
+	}
+
+	currentTime := commonUtils.GetCurrentTime()
+	stabilityChecks := rdService.SampleStabilityService.CheckSamplesStability(ctx, labId, samples, samplesMetadata,
+		sampleIdToMasterTestIdsMap, *currentTime)
+
+	stableSamples := []commonModels.Sample{}
+	for _, sample := range samples {
+		stabilityCheck, ok := stabilityChecks[sample.Id]
+		if !ok || !stabilityCheck.IsExpired {
+			stableSamples = append(stableSamples, sample)
+			continue
+		}
+
+		sampleMetadata := sampleIdToSampleMetadataMap[sample.Id]
+		loggingAttributes := map[string]interface{}{
+			"oms_order_id":             sample.OmsOrderId,
+			"sample_id":                sample.Id,
+			"sample_stability_rule_id": stabilityCheck.SampleStabilityRuleId,
+			"reason":                   stabilityCheck.Reason,
+		}
+
+		if stabilityCheck.Action == commonConstants.SampleStabilityActionFlag {
+			sampleMetadata.StabilityFlaggedAt = currentTime
+			sampleMetadata.StabilityFlagReason = stabilityCheck.Reason
+			sampleIdToSampleMetadataMap[sample.Id] = sampleMetadata
+			stableSamples = append(stableSamples, sample)
+			commonUtils.AddLog(ctx, commonConstants.INFO_LEVEL, commonUtils.GetCurrentFunctionName(),
+				loggingAttributes, nil)
+			continue
+		}
+
+		if sample.Status != commonConstants.SampleReceived {
+			sampleMetadata.ReceivedAt = currentTime
+		}
+		sampleMetadata.UpdatedBy = userId
+		_, cErr := rdService.SampleService.RejectSample(ctx, userId, labId, stabilityCheck.Reason,
+			[]commonModels.Sample{sample}, []commonModels.SampleMetadata{sampleMetadata})
+		if cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_REJECTING_UNSTABLE_SAMPLE,
+				loggingAttributes, nil)
+			return nil, cErr
+		}
+		commonUtils.AddLog(ctx, commonConstants.INFO_LEVEL, commonUtils.GetCurrentFunctionName(),
+			loggingAttributes, nil)
+	}
+
+	return stableSamples, nil
+}
@@ -59,6 +59,7 @@ type ReceiveSamplesRequest struct {
 }
 
 type SampleDetails struct {
-	Id      uint   `json:"id"`
-	Barcode string `json:"barcode"`
+	Id               uint   `json:"id"`
+	Barcode          string `json:"barcode"`
+	StorageCondition string `json:"storage_condition"`
 }
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/reflex_rules/service"
+)
+
+type ReflexRules struct {
+	ReflexRulesService service.ReflexRulesServiceInterface
+}
+
+func InitReflexRulesController() *ReflexRules {
+	return &ReflexRules{
+		ReflexRulesService: service.InitializeReflexRulesService(),
+	}
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/reflex_rules/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructs "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Reflex Rules
+// @Description	Get Reflex Rules, optionally filtered by the trigger LIS code
+// @Tags			reflex-rules
+// @Produce		json
+// @Param			trigger_lis_code	query		string							false	"Trigger LIS Code"
+// @Success		200					{object}	[]structures.ReflexRule			"Reflex Rules"
+// @Failure		400,404,500			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/reflex-rules/rules [get]
+func (reflexRulesController *ReflexRules) GetReflexRules(c *gin.Context) {
+	rules, cErr := reflexRulesController.ReflexRulesService.GetReflexRules(c.Query("trigger_lis_code"))
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Summary		Create Reflex Rule
+// @Description	Create a Reflex Rule ordering a follow-up test when a result meets its condition
+// @Tags			reflex-rules
+// @Accept			json
+// @Produce		json
+// @Param			rule		body		structures.ReflexRuleRequest	true	"Reflex Rule"
+// @Success		200			{object}	structures.ReflexRule			"Reflex Rule"
+// @Failure		400,409,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/reflex-rules/rules [post]
+func (reflexRulesController *ReflexRules) CreateReflexRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleRequest := structures.ReflexRuleRequest{}
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, cErr := reflexRulesController.ReflexRulesService.CreateReflexRule(c.Request.Context(), ruleRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary		Update Reflex Rule
+// @Description	Update a Reflex Rule
+// @Tags			reflex-rules
+// @Accept			json
+// @Produce		json
+// @Param			ruleId			path		int								true	"Reflex Rule ID"
+// @Param			rule			body		structures.ReflexRuleRequest	true	"Reflex Rule"
+// @Success		200				{object}	structures.ReflexRule			"Reflex Rule"
+// @Failure		400,404,409,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/reflex-rules/rules/{ruleId} [put]
+func (reflexRulesController *ReflexRules) UpdateReflexRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleId := commonUtils.ConvertStringToUint(c.Param("ruleId"))
+	if ruleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_REFLEX_RULE_ID)
+		return
+	}
+
+	ruleRequest := structures.ReflexRuleRequest{}
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, cErr := reflexRulesController.ReflexRulesService.UpdateReflexRule(c.Request.Context(), ruleId,
+		ruleRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary		Delete Reflex Rule
+// @Description	Delete a Reflex Rule
+// @Tags			reflex-rules
+// @Produce		json
+// @Param			ruleId		path		int								true	"Reflex Rule ID"
+// @Success		200			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/reflex-rules/rules/{ruleId} [delete]
+func (reflexRulesController *ReflexRules) DeleteReflexRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleId := commonUtils.ConvertStringToUint(c.Param("ruleId"))
+	if ruleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_REFLEX_RULE_ID)
+		return
+	}
+
+	cErr = reflexRulesController.ReflexRulesService.DeleteReflexRule(c.Request.Context(), ruleId, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructs.CommonAPIResponse{
+		Message: commonConstants.DONE_RESPONSE,
+	})
+}
+
+// @Summary		Get Reflex Test Triggers
+// @Description	Get the reflex rules that fired on a task, with the reason each reflex test was ordered or skipped
+// @Tags			reflex-rules
+// @Produce		json
+// @Param			taskId		path		int								true	"Task ID"
+// @Success		200			{object}	[]structures.ReflexTestTrigger	"Reflex Test Triggers"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/reflex-rules/triggers/{taskId} [get]
+func (reflexRulesController *ReflexRules) GetReflexTestTriggers(c *gin.Context) {
+	taskId := commonUtils.ConvertStringToUint(c.Param("taskId"))
+	if taskId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_TASK_ID)
+		return
+	}
+
+	triggers, cErr := reflexRulesController.ReflexRulesService.GetReflexTestTriggersByTaskId(taskId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, triggers)
+}
@@ -0,0 +1,197 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetReflexRuleById(ruleId uint) (commonModels.ReflexRule, *commonStructures.CommonError)
+	GetReflexRules(triggerLisCode string) ([]commonModels.ReflexRule, *commonStructures.CommonError)
+	GetActiveReflexRules() ([]commonModels.ReflexRule, *commonStructures.CommonError)
+	GetReflexRuleByTriggerAndReflexTest(rule commonModels.ReflexRule) (
+		commonModels.ReflexRule, *commonStructures.CommonError)
+	GetReflexRulesByIds(ruleIds []uint) ([]commonModels.ReflexRule, *commonStructures.CommonError)
+	GetReflexTestTriggersByTaskId(taskId uint) ([]commonModels.ReflexTestTrigger, *commonStructures.CommonError)
+	GetReflexTestTriggersByInvestigationResultIdsWithTx(tx *gorm.DB, investigationResultIds []uint) (
+		[]commonModels.ReflexTestTrigger, *commonStructures.CommonError)
+	GetOrderedReflexTestTriggersBySampleIdsWithTx(tx *gorm.DB, sampleIds []uint) (
+		[]commonModels.ReflexTestTrigger, *commonStructures.CommonError)
+
+	CreateReflexRule(rule commonModels.ReflexRule) (commonModels.ReflexRule, *commonStructures.CommonError)
+	UpdateReflexRule(rule commonModels.ReflexRule) (commonModels.ReflexRule, *commonStructures.CommonError)
+	DeleteReflexRule(ruleId, userId uint) *commonStructures.CommonError
+	CreateReflexTestTriggerWithTx(tx *gorm.DB, trigger commonModels.ReflexTestTrigger) (
+		commonModels.ReflexTestTrigger, *commonStructures.CommonError)
+	UpdateReflexTestTriggerWithTx(tx *gorm.DB, trigger commonModels.ReflexTestTrigger) (
+		commonModels.ReflexTestTrigger, *commonStructures.CommonError)
+}
+
+func (reflexRulesDao *ReflexRulesDao) GetReflexRuleById(ruleId uint) (
+	commonModels.ReflexRule, *commonStructures.CommonError) {
+
+	rule := commonModels.ReflexRule{}
+	if err := reflexRulesDao.Db.Where("id = ?", ruleId).First(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) GetReflexRules(triggerLisCode string) (
+	[]commonModels.ReflexRule, *commonStructures.CommonError) {
+
+	rules := []commonModels.ReflexRule{}
+	query := reflexRulesDao.Db
+	if triggerLisCode != "" {
+		query = query.Where("UPPER(trigger_lis_code) = UPPER(?)", triggerLisCode)
+	}
+	if err := query.Order("trigger_lis_code, id").Find(&rules).Error; err != nil {
+		return rules, commonUtils.HandleORMError(err)
+	}
+
+	return rules, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) GetActiveReflexRules() ([]commonModels.ReflexRule, *commonStructures.CommonError) {
+	rules := []commonModels.ReflexRule{}
+	if err := reflexRulesDao.Db.Where("is_active = ?", true).Order("id").Find(&rules).Error; err != nil {
+		return rules, commonUtils.HandleORMError(err)
+	}
+
+	return rules, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) GetReflexRuleByTriggerAndReflexTest(rule commonModels.ReflexRule) (
+	commonModels.ReflexRule, *commonStructures.CommonError) {
+
+	existingRule := commonModels.ReflexRule{}
+	if err := reflexRulesDao.Db.Where("lab_id = ?", rule.LabId).
+		Where("UPPER(trigger_lis_code) = UPPER(?)", rule.TriggerLisCode).
+		Where("operator = ?", rule.Operator).
+		Where("value = ?", rule.Value).
+		Where("reflex_master_test_id = ?", rule.ReflexMasterTestId).
+		First(&existingRule).Error; err != nil {
+		return existingRule, commonUtils.HandleORMError(err)
+	}
+
+	return existingRule, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) GetReflexRulesByIds(ruleIds []uint) (
+	[]commonModels.ReflexRule, *commonStructures.CommonError) {
+
+	rules := []commonModels.ReflexRule{}
+	if len(ruleIds) == 0 {
+		return rules, nil
+	}
+	if err := reflexRulesDao.Db.Unscoped().Where("id IN (?)", ruleIds).Find(&rules).Error; err != nil {
+		return rules, commonUtils.HandleORMError(err)
+	}
+
+	return rules, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) GetReflexTestTriggersByTaskId(taskId uint) (
+	[]commonModels.ReflexTestTrigger, *commonStructures.CommonError) {
+
+	triggers := []commonModels.ReflexTestTrigger{}
+	if err := reflexRulesDao.Db.Where("task_id = ?", taskId).Order("triggered_at, id").
+		Find(&triggers).Error; err != nil {
+		return triggers, commonUtils.HandleORMError(err)
+	}
+
+	return triggers, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) GetReflexTestTriggersByInvestigationResultIdsWithTx(tx *gorm.DB,
+	investigationResultIds []uint) ([]commonModels.ReflexTestTrigger, *commonStructures.CommonError) {
+
+	triggers := []commonModels.ReflexTestTrigger{}
+	if len(investigationResultIds) == 0 {
+		return triggers, nil
+	}
+	if err := tx.Where("trigger_investigation_result_id IN (?)", investigationResultIds).
+		Find(&triggers).Error; err != nil {
+		return triggers, commonUtils.HandleORMError(err)
+	}
+
+	return triggers, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) GetOrderedReflexTestTriggersBySampleIdsWithTx(tx *gorm.DB, sampleIds []uint) (
+	[]commonModels.ReflexTestTrigger, *commonStructures.CommonError) {
+
+	triggers := []commonModels.ReflexTestTrigger{}
+	if len(sampleIds) == 0 {
+		return triggers, nil
+	}
+	if err := tx.Where("sample_id IN (?)", sampleIds).
+		Where("status = ?", commonConstants.ReflexTriggerStatusOrdered).
+		Find(&triggers).Error; err != nil {
+		return triggers, commonUtils.HandleORMError(err)
+	}
+
+	return triggers, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) CreateReflexRule(rule commonModels.ReflexRule) (
+	commonModels.ReflexRule, *commonStructures.CommonError) {
+
+	if err := reflexRulesDao.Db.Create(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) UpdateReflexRule(rule commonModels.ReflexRule) (
+	commonModels.ReflexRule, *commonStructures.CommonError) {
+
+	if err := reflexRulesDao.Db.Save(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) DeleteReflexRule(ruleId, userId uint) *commonStructures.CommonError {
+
+	currentTime := commonUtils.GetCurrentTime()
+	ruleUpdates := map[string]interface{}{
+		"deleted_by": userId,
+		"updated_by": userId,
+		"deleted_at": currentTime,
+		"updated_at": currentTime,
+	}
+	if err := reflexRulesDao.Db.Model(&commonModels.ReflexRule{}).Where("id = ?", ruleId).
+		Updates(ruleUpdates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) CreateReflexTestTriggerWithTx(tx *gorm.DB,
+	trigger commonModels.ReflexTestTrigger) (commonModels.ReflexTestTrigger, *commonStructures.CommonError) {
+
+	if err := tx.Create(&trigger).Error; err != nil {
+		return trigger, commonUtils.HandleORMError(err)
+	}
+
+	return trigger, nil
+}
+
+func (reflexRulesDao *ReflexRulesDao) UpdateReflexTestTriggerWithTx(tx *gorm.DB,
+	trigger commonModels.ReflexTestTrigger) (commonModels.ReflexTestTrigger, *commonStructures.CommonError) {
+
+	if err := tx.Save(&trigger).Error; err != nil {
+		return trigger, commonUtils.HandleORMError(err)
+	}
+
+	return trigger, nil
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type ReflexRulesDao struct {
+	Db *gorm.DB
+}
+
+func InitializeReflexRulesDao() DataLayer {
+	return &ReflexRulesDao{
+		Db: psql.GetDbInstance(),
+	}
+}
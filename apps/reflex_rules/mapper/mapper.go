@@ -0,0 +1,166 @@
+package mapper
+
+import (
+	"strings"
+
+	"github.com/Orange-Health/citadel/apps/reflex_rules/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapReflexRule(rule commonModels.ReflexRule) structures.ReflexRule {
+	return structures.ReflexRule{
+		Id:                 rule.Id,
+		Name:               rule.Name,
+		LabId:              rule.LabId,
+		TriggerLisCode:     rule.TriggerLisCode,
+		Operator:           rule.Operator,
+		Value:              rule.Value,
+		ReflexMasterTestId: rule.ReflexMasterTestId,
+		ReflexLisCode:      rule.ReflexLisCode,
+		ReflexTestName:     rule.ReflexTestName,
+		ReflexTestType:     rule.ReflexTestType,
+		ReflexDepartment:   rule.ReflexDepartment,
+		VialTypeId:         rule.VialTypeId,
+		RequiredVolume:     rule.RequiredVolume,
+		StabilityHours:     rule.StabilityHours,
+		IsActive:           rule.IsActive,
+	}
+}
+
+func MapReflexRules(rules []commonModels.ReflexRule) []structures.ReflexRule {
+	reflexRules := []structures.ReflexRule{}
+	for _, rule := range rules {
+		reflexRules = append(reflexRules, MapReflexRule(rule))
+	}
+	return reflexRules
+}
+
+func MapReflexRuleRequest(rule commonModels.ReflexRule, ruleRequest structures.ReflexRuleRequest,
+	userId uint) commonModels.ReflexRule {
+	if rule.Id == 0 {
+		rule.CreatedBy = userId
+		rule.IsActive = true
+	}
+	rule.Name = strings.TrimSpace(ruleRequest.Name)
+	rule.LabId = ruleRequest.LabId
+	rule.TriggerLisCode = strings.TrimSpace(ruleRequest.TriggerLisCode)
+	rule.Operator = ruleRequest.Operator
+	rule.Value = strings.TrimSpace(ruleRequest.Value)
+	rule.ReflexMasterTestId = ruleRequest.ReflexMasterTestId
+	rule.ReflexLisCode = strings.TrimSpace(ruleRequest.ReflexLisCode)
+	rule.ReflexTestName = strings.TrimSpace(ruleRequest.ReflexTestName)
+	rule.ReflexTestType = ruleRequest.ReflexTestType
+	rule.ReflexDepartment = ruleRequest.ReflexDepartment
+	rule.VialTypeId = ruleRequest.VialTypeId
+	rule.RequiredVolume = ruleRequest.RequiredVolume
+	rule.StabilityHours = ruleRequest.StabilityHours
+	if ruleRequest.IsActive != nil {
+		rule.IsActive = *ruleRequest.IsActive
+	}
+	rule.UpdatedBy = userId
+	return rule
+}
+
+func MapReflexTestTriggers(triggers []commonModels.ReflexTestTrigger,
+	ruleIdToRuleMap map[uint]commonModels.ReflexRule) []structures.ReflexTestTrigger {
+	reflexTestTriggers := []structures.ReflexTestTrigger{}
+	for _, trigger := range triggers {
+		reflexTestTriggers = append(reflexTestTriggers, structures.ReflexTestTrigger{
+			Id:                           trigger.Id,
+			ReflexRuleId:                 trigger.ReflexRuleId,
+			ReflexRuleName:               ruleIdToRuleMap[trigger.ReflexRuleId].Name,
+			TaskId:                       trigger.TaskId,
+			OmsOrderId:                   trigger.OmsOrderId,
+			TriggerTestDetailsId:         trigger.TriggerTestDetailsId,
+			TriggerInvestigationResultId: trigger.TriggerInvestigationResultId,
+			TriggerValue:                 trigger.TriggerValue,
+			TriggerAbnormality:           trigger.TriggerAbnormality,
+			SampleId:                     trigger.SampleId,
+			ReflexTestDetailsId:          trigger.ReflexTestDetailsId,
+			ReflexCentralOmsTestId:       trigger.ReflexCentralOmsTestId,
+			RequiredVolume:               trigger.RequiredVolume,
+			Status:                       trigger.Status,
+			Reason:                       trigger.Reason,
+			TriggeredAt:                  trigger.TriggeredAt,
+		})
+	}
+	return reflexTestTriggers
+}
+
+func MapReflexTestTrigger(candidate structures.ReflexCandidate, reason string,
+	userId uint) commonModels.ReflexTestTrigger {
+	trigger := commonModels.ReflexTestTrigger{
+		ReflexRuleId:                 candidate.Rule.Id,
+		TaskId:                       candidate.TestDetail.TaskId,
+		OmsOrderId:                   candidate.TestDetail.OmsOrderId,
+		TriggerTestDetailsId:         candidate.TestDetail.Id,
+		TriggerInvestigationResultId: candidate.Investigation.Id,
+		TriggerValue:                 candidate.Investigation.InvestigationValue,
+		TriggerAbnormality:           candidate.Investigation.Abnormality,
+		Status:                       commonConstants.ReflexTriggerStatusSkipped,
+		Reason:                       reason,
+		TriggeredAt:                  commonUtils.GetCurrentTime(),
+	}
+	trigger.CreatedBy = userId
+	trigger.UpdatedBy = userId
+	return trigger
+}
+
+// MapReflexTestDetail builds the reflex test on the order and processing lab of the triggering test.
+func MapReflexTestDetail(candidate structures.ReflexCandidate, centralOmsTestId string,
+	userId uint) commonModels.TestDetail {
+	testDetail := commonModels.TestDetail{
+		OmsOrderId:       candidate.TestDetail.OmsOrderId,
+		TaskId:           candidate.TestDetail.TaskId,
+		OmsTestId:        commonUtils.GetUintTestIdWithoutStringPart(centralOmsTestId),
+		CentralOmsTestId: centralOmsTestId,
+		CityCode:         candidate.TestDetail.CityCode,
+		TestName:         candidate.Rule.ReflexTestName,
+		LabId:            candidate.TestDetail.LabId,
+		ProcessingLabId:  candidate.TestDetail.ProcessingLabId,
+		LisCode:          candidate.Rule.ReflexLisCode,
+		MasterTestId:     candidate.Rule.ReflexMasterTestId,
+		TestType:         candidate.Rule.ReflexTestType,
+		Department:       candidate.Rule.ReflexDepartment,
+		Status:           commonConstants.TEST_STATUS_RESULT_PENDING,
+		ReportStatus:     commonConstants.TEST_REPORT_STATUS_NOT_READY,
+		CpEnabled:        candidate.TestDetail.CpEnabled,
+	}
+	testDetail.CreatedBy = userId
+	testDetail.UpdatedBy = userId
+	return testDetail
+}
+
+func MapReflexTestSampleMapping(testDetail commonModels.TestDetail, sample commonModels.Sample,
+	userId uint) commonModels.TestSampleMapping {
+	testSampleMapping := commonModels.TestSampleMapping{
+		OmsCityCode:  sample.OmsCityCode,
+		OmsTestId:    testDetail.CentralOmsTestId,
+		VialTypeId:   sample.VialTypeId,
+		SampleId:     sample.Id,
+		SampleNumber: sample.SampleNumber,
+		OmsOrderId:   sample.OmsOrderId,
+	}
+	testSampleMapping.CreatedBy = userId
+	testSampleMapping.UpdatedBy = userId
+	return testSampleMapping
+}
+
+func MapReflexTestAddedEventTest(candidate structures.ReflexCandidate, testDetail commonModels.TestDetail,
+	sample commonModels.Sample, reason string) commonStructures.ReflexTestAddedEventTest {
+	return commonStructures.ReflexTestAddedEventTest{
+		TestId:            testDetail.CentralOmsTestId,
+		MasterTestId:      testDetail.MasterTestId,
+		TestCode:          testDetail.LisCode,
+		TestName:          testDetail.TestName,
+		LabId:             testDetail.LabId,
+		SampleNumber:      sample.SampleNumber,
+		VialTypeId:        sample.VialTypeId,
+		TriggeredByTestId: candidate.TestDetail.CentralOmsTestId,
+		ReflexRuleId:      candidate.Rule.Id,
+		Reason:            reason,
+	}
+}
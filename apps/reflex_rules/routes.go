@@ -0,0 +1,17 @@
+package reflexRules
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/reflex_rules/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	reflexRulesController := controller.InitReflexRulesController()
+
+	router.GET("/rules", reflexRulesController.GetReflexRules)
+	router.POST("/rules", reflexRulesController.CreateReflexRule)
+	router.PUT("/rules/:ruleId", reflexRulesController.UpdateReflexRule)
+	router.DELETE("/rules/:ruleId", reflexRulesController.DeleteReflexRule)
+	router.GET("/triggers/:taskId", reflexRulesController.GetReflexTestTriggers)
+}
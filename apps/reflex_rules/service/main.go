@@ -0,0 +1,36 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/cache"
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	outboxService "github.com/Orange-Health/citadel/apps/outbox/service"
+	pubsubService "github.com/Orange-Health/citadel/apps/pubsub/service"
+	"github.com/Orange-Health/citadel/apps/reflex_rules/dao"
+	sampleService "github.com/Orange-Health/citadel/apps/samples/service"
+	testDetailService "github.com/Orange-Health/citadel/apps/test_detail/service"
+	tsmService "github.com/Orange-Health/citadel/apps/test_sample_mapping/service"
+)
+
+type ReflexRulesService struct {
+	ReflexRulesDao           dao.DataLayer
+	Cache                    cache.CacheLayer
+	Sentry                   sentry.SentryLayer
+	SampleService            sampleService.SampleServiceInterface
+	TestDetailService        testDetailService.TestDetailServiceInterface
+	TestSampleMappingService tsmService.TestSampleMappingServiceInterface
+	PubsubService            pubsubService.PubsubInterface
+	OutboxService            outboxService.OutboxServiceInterface
+}
+
+func InitializeReflexRulesService() ReflexRulesServiceInterface {
+	return &ReflexRulesService{
+		ReflexRulesDao:           dao.InitializeReflexRulesDao(),
+		Cache:                    cache.InitializeCache(),
+		Sentry:                   sentry.InitializeSentry(),
+		SampleService:            sampleService.InitializeSampleService(),
+		TestDetailService:        testDetailService.InitializeTestDetailService(),
+		TestSampleMappingService: tsmService.InitializeTestSampleMappingService(),
+		PubsubService:            pubsubService.InitializePubsubService(),
+		OutboxService:            outboxService.InitializeOutboxService(),
+	}
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/apps/reflex_rules/mapper"
+	"github.com/Orange-Health/citadel/apps/reflex_rules/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// EvaluateReflexRulesWithTx runs the active reflex rules against the saved investigation results. A rule fires
+// once per investigation result and is recorded with the reason, whether the reflex test could be ordered or not.
+// The reflex test is added on a received sample of the triggering test if the sample is still within the stability
+// of the rule and has the required volume left, and OMS is notified of the added tests through the outbox.
+func (reflexRulesService *ReflexRulesService) EvaluateReflexRulesWithTx(ctx context.Context, tx *gorm.DB,
+	testDetails []commonModels.TestDetail, investigations []commonModels.InvestigationResult,
+	userId uint) *commonStructures.CommonError {
+
+	candidates := reflexRulesService.getReflexCandidates(ctx, testDetails, investigations)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	investigationResultIds := []uint{}
+	for _, candidate := range candidates {
+		investigationResultIds = append(investigationResultIds, candidate.Investigation.Id)
+	}
+	existingTriggers, cErr := reflexRulesService.ReflexRulesDao.GetReflexTestTriggersByInvestigationResultIdsWithTx(tx,
+		commonUtils.CreateUniqueSliceUint(investigationResultIds))
+	if cErr != nil {
+		return cErr
+	}
+
+	firedRules := map[string]bool{}
+	for _, trigger := range existingTriggers {
+		firedRules[getReflexTriggerKey(trigger.ReflexRuleId, trigger.TriggerInvestigationResultId)] = true
+	}
+
+	orderTestsMap := map[string][]commonModels.TestDetail{}
+	consumedVolumeMap := map[uint]uint{}
+	reflexTestAddedEvents := map[string]*commonStructures.ReflexTestAddedEvent{}
+	for _, candidate := range candidates {
+		triggerKey := getReflexTriggerKey(candidate.Rule.Id, candidate.Investigation.Id)
+		if firedRules[triggerKey] {
+			continue
+		}
+		firedRules[triggerKey] = true
+
+		omsOrderId := candidate.TestDetail.OmsOrderId
+		orderTests, ok := orderTestsMap[omsOrderId]
+		if !ok {
+			orderTests, cErr = reflexRulesService.TestDetailService.GetTestDetailsByOmsOrderIdWithTx(tx, omsOrderId)
+			if cErr != nil {
+				return cErr
+			}
+			orderTestsMap[omsOrderId] = orderTests
+		}
+
+		reason := getReflexMatchedReason(candidate)
+		trigger := mapper.MapReflexTestTrigger(candidate, reason, userId)
+
+		sample, skipReason, cErr := reflexRulesService.getReflexSampleWithTx(tx, candidate, orderTests,
+			consumedVolumeMap)
+		if cErr != nil {
+			return cErr
+		}
+		if skipReason != "" {
+			trigger.SampleId = sample.Id
+			trigger.Reason = fmt.Sprintf("%s, %s", reason, skipReason)
+			if _, cErr = reflexRulesService.ReflexRulesDao.CreateReflexTestTriggerWithTx(tx, trigger); cErr != nil {
+				return cErr
+			}
+			continue
+		}
+
+		trigger.Status = commonConstants.ReflexTriggerStatusOrdered
+		trigger.SampleId = sample.Id
+		trigger.RequiredVolume = candidate.Rule.RequiredVolume
+		trigger, cErr = reflexRulesService.ReflexRulesDao.CreateReflexTestTriggerWithTx(tx, trigger)
+		if cErr != nil {
+			return cErr
+		}
+
+		reflexTestDetail, cErr := reflexRulesService.createReflexTestWithTx(tx, candidate, trigger, sample, userId)
+		if cErr != nil {
+			return cErr
+		}
+
+		trigger.ReflexTestDetailsId = reflexTestDetail.Id
+		trigger.ReflexCentralOmsTestId = reflexTestDetail.CentralOmsTestId
+		if _, cErr = reflexRulesService.ReflexRulesDao.UpdateReflexTestTriggerWithTx(tx, trigger); cErr != nil {
+			return cErr
+		}
+
+		orderTestsMap[omsOrderId] = append(orderTests, reflexTestDetail)
+		consumedVolumeMap[sample.Id] += candidate.Rule.RequiredVolume
+
+		if _, ok := reflexTestAddedEvents[omsOrderId]; !ok {
+			reflexTestAddedEvents[omsOrderId] = &commonStructures.ReflexTestAddedEvent{
+				OmsOrderId: omsOrderId,
+				CityCode:   candidate.TestDetail.CityCode,
+			}
+		}
+		reflexTestAddedEvents[omsOrderId].Tests = append(reflexTestAddedEvents[omsOrderId].Tests,
+			mapper.MapReflexTestAddedEventTest(candidate, reflexTestDetail, sample, reason))
+	}
+
+	for _, reflexTestAddedEvent := range reflexTestAddedEvents {
+		messageBody, messageAttributes := reflexRulesService.PubsubService.GetReflexTestAddedEvent(*reflexTestAddedEvent)
+		cErr = reflexRulesService.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes, "",
+			commonConstants.OmsUpdatesTopicArn, "")
+		if cErr != nil {
+			return cErr
+		}
+	}
+
+	return nil
+}
+
+// getReflexCandidates pairs the investigation results having a value with the active rules of their LIS code
+// whose condition they meet.
+func (reflexRulesService *ReflexRulesService) getReflexCandidates(ctx context.Context,
+	testDetails []commonModels.TestDetail,
+	investigations []commonModels.InvestigationResult) []structures.ReflexCandidate {
+
+	candidates := []structures.ReflexCandidate{}
+	if len(investigations) == 0 {
+		return candidates
+	}
+
+	rulesMap := reflexRulesService.GetActiveReflexRulesMap(ctx)
+	if len(rulesMap) == 0 {
+		return candidates
+	}
+
+	testDetailsMap := map[uint]commonModels.TestDetail{}
+	for _, testDetail := range testDetails {
+		testDetailsMap[testDetail.Id] = testDetail
+	}
+
+	for _, investigation := range investigations {
+		if strings.TrimSpace(investigation.InvestigationValue) == "" {
+			continue
+		}
+		testDetail, ok := testDetailsMap[investigation.TestDetailsId]
+		if !ok {
+			continue
+		}
+		for _, rule := range rulesMap[strings.ToUpper(investigation.LisCode)] {
+			if rule.LabId != 0 && rule.LabId != testDetail.ProcessingLabId {
+				continue
+			}
+			if !isReflexConditionMatched(rule, investigation) {
+				continue
+			}
+			candidates = append(candidates, structures.ReflexCandidate{
+				Rule:          rule,
+				TestDetail:    testDetail,
+				Investigation: investigation,
+			})
+		}
+	}
+
+	return candidates
+}
+
+// getReflexSampleWithTx returns the sample the reflex test can be added on, or the reason it cannot be ordered.
+// The first received sample of the triggering test that is within the stability of the rule and has the required
+// volume left after the reflex tests already added on it is used.
+func (reflexRulesService *ReflexRulesService) getReflexSampleWithTx(tx *gorm.DB,
+	candidate structures.ReflexCandidate, orderTests []commonModels.TestDetail,
+	consumedVolumeMap map[uint]uint) (commonModels.Sample, string, *commonStructures.CommonError) {
+
+	rule := candidate.Rule
+	for _, orderTest := range orderTests {
+		if orderTest.MasterTestId == rule.ReflexMasterTestId {
+			return commonModels.Sample{}, commonConstants.ReflexReasonTestAlreadyOrdered, nil
+		}
+	}
+
+	samples, samplesMetadata, cErr := reflexRulesService.SampleService.GetSamplesForTests(
+		[]string{candidate.TestDetail.CentralOmsTestId})
+	if cErr != nil && cErr.StatusCode != http.StatusNotFound {
+		return commonModels.Sample{}, "", cErr
+	}
+
+	sampleIdToMetadataMap := map[uint]commonModels.SampleMetadata{}
+	for _, sampleMetadata := range samplesMetadata {
+		sampleIdToMetadataMap[sampleMetadata.SampleId] = sampleMetadata
+	}
+
+	eligibleSamples, sampleIds := []commonModels.Sample{}, []uint{}
+	for _, sample := range samples {
+		if !commonUtils.SliceContainsString(commonConstants.ReflexEligibleSampleStatuses, sample.Status) {
+			continue
+		}
+		if rule.VialTypeId != 0 && sample.VialTypeId != rule.VialTypeId {
+			continue
+		}
+		eligibleSamples = append(eligibleSamples, sample)
+		if _, ok := consumedVolumeMap[sample.Id]; !ok {
+			sampleIds = append(sampleIds, sample.Id)
+		}
+	}
+	if len(eligibleSamples) == 0 {
+		return commonModels.Sample{}, commonConstants.ReflexReasonNoEligibleSample, nil
+	}
+
+	orderedTriggers, cErr := reflexRulesService.ReflexRulesDao.GetOrderedReflexTestTriggersBySampleIdsWithTx(tx,
+		sampleIds)
+	if cErr != nil {
+		return commonModels.Sample{}, "", cErr
+	}
+	for _, sampleId := range sampleIds {
+		consumedVolumeMap[sampleId] = 0
+	}
+	for _, trigger := range orderedTriggers {
+		consumedVolumeMap[trigger.SampleId] += trigger.RequiredVolume
+	}
+
+	skipReason := ""
+	for _, sample := range eligibleSamples {
+		reason := getReflexSampleSkipReason(rule, sampleIdToMetadataMap[sample.Id], consumedVolumeMap[sample.Id])
+		if reason == "" {
+			return sample, "", nil
+		}
+		if skipReason == "" {
+			skipReason = reason
+		}
+	}
+
+	return eligibleSamples[0], skipReason, nil
+}
+
+// createReflexTestWithTx adds the reflex test to the order of the triggering test and maps it to the sample.
+// The central test id is generated from the trigger, OMS is expected to add the test to the order with this id.
+func (reflexRulesService *ReflexRulesService) createReflexTestWithTx(tx *gorm.DB,
+	candidate structures.ReflexCandidate, trigger commonModels.ReflexTestTrigger, sample commonModels.Sample,
+	userId uint) (commonModels.TestDetail, *commonStructures.CommonError) {
+
+	centralOmsTestId := fmt.Sprintf("%s%d", commonConstants.ReflexTestIdPrefix, trigger.Id)
+	reflexTestDetails, cErr := reflexRulesService.TestDetailService.CreateTestDetailsWithTx(tx,
+		[]commonModels.TestDetail{mapper.MapReflexTestDetail(candidate, centralOmsTestId, userId)})
+	if cErr != nil {
+		return commonModels.TestDetail{}, cErr
+	}
+	reflexTestDetail := reflexTestDetails[0]
+
+	_, cErr = reflexRulesService.TestSampleMappingService.CreateTestSampleMappingWithTx(tx,
+		mapper.MapReflexTestSampleMapping(reflexTestDetail, sample, userId))
+	if cErr != nil {
+		return commonModels.TestDetail{}, cErr
+	}
+
+	return reflexTestDetail, nil
+}
+
+func getReflexSampleSkipReason(rule commonModels.ReflexRule, sampleMetadata commonModels.SampleMetadata,
+	consumedVolume uint) string {
+
+	if rule.StabilityHours > 0 {
+		if sampleMetadata.CollectedAt == nil || sampleMetadata.CollectedAt.IsZero() {
+			return commonConstants.ReflexReasonCollectionTimeMissing
+		}
+		sampleAge := time.Since(*sampleMetadata.CollectedAt)
+		if sampleAge > time.Duration(rule.StabilityHours)*time.Hour {
+			return fmt.Sprintf(commonConstants.ReflexReasonSampleNotStable, sampleAge.Round(time.Minute),
+				rule.StabilityHours)
+		}
+	}
+
+	if rule.RequiredVolume > 0 {
+		remainingVolume := uint(0)
+		if sampleMetadata.CollectedVolume > consumedVolume {
+			remainingVolume = sampleMetadata.CollectedVolume - consumedVolume
+		}
+		if remainingVolume < rule.RequiredVolume {
+			return fmt.Sprintf(commonConstants.ReflexReasonInsufficientVolume, remainingVolume, rule.RequiredVolume)
+		}
+	}
+
+	return ""
+}
+
+func isReflexConditionMatched(rule commonModels.ReflexRule, investigation commonModels.InvestigationResult) bool {
+	investigationValue := strings.TrimSpace(investigation.InvestigationValue)
+	switch rule.Operator {
+	case commonConstants.ReflexOperatorAbnormal:
+		if rule.Value != "" {
+			return strings.EqualFold(investigation.Abnormality, rule.Value)
+		}
+		return investigation.IsAbnormal ||
+			commonUtils.SliceContainsString(commonConstants.OhAbnormalityStringSlice, investigation.Abnormality)
+	case commonConstants.ReflexOperatorCritical:
+		return investigation.IsCritical ||
+			commonUtils.SliceContainsString(commonConstants.OhCriticalityStringSlice, investigation.Abnormality)
+	case commonConstants.ReflexOperatorEqual:
+		return strings.EqualFold(investigationValue, rule.Value)
+	}
+
+	value, err := strconv.ParseFloat(investigationValue, 64)
+	if err != nil {
+		return false
+	}
+	ruleValue, err := strconv.ParseFloat(rule.Value, 64)
+	if err != nil {
+		return false
+	}
+
+	switch rule.Operator {
+	case commonConstants.ReflexOperatorGt:
+		return value > ruleValue
+	case commonConstants.ReflexOperatorGte:
+		return value >= ruleValue
+	case commonConstants.ReflexOperatorLt:
+		return value < ruleValue
+	case commonConstants.ReflexOperatorLte:
+		return value <= ruleValue
+	}
+	return false
+}
+
+func getReflexMatchedReason(candidate structures.ReflexCandidate) string {
+	condition := strings.TrimSpace(fmt.Sprintf("%s %s", candidate.Rule.Operator, candidate.Rule.Value))
+	return fmt.Sprintf(commonConstants.ReflexReasonMatched,
+		commonUtils.GetNonEmptyString(candidate.Investigation.InvestigationName, candidate.Investigation.LisCode),
+		strings.TrimSpace(candidate.Investigation.InvestigationValue), condition)
+}
+
+func getReflexTriggerKey(ruleId, investigationResultId uint) string {
+	return fmt.Sprintf("%d:%d", ruleId, investigationResultId)
+}
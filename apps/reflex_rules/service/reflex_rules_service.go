@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/apps/reflex_rules/mapper"
+	"github.com/Orange-Health/citadel/apps/reflex_rules/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type ReflexRulesServiceInterface interface {
+	GetReflexRules(triggerLisCode string) ([]structures.ReflexRule, *commonStructures.CommonError)
+	CreateReflexRule(ctx context.Context, ruleRequest structures.ReflexRuleRequest, userId uint) (
+		structures.ReflexRule, *commonStructures.CommonError)
+	UpdateReflexRule(ctx context.Context, ruleId uint, ruleRequest structures.ReflexRuleRequest, userId uint) (
+		structures.ReflexRule, *commonStructures.CommonError)
+	DeleteReflexRule(ctx context.Context, ruleId, userId uint) *commonStructures.CommonError
+	GetReflexTestTriggersByTaskId(taskId uint) ([]structures.ReflexTestTrigger, *commonStructures.CommonError)
+
+	GetActiveReflexRulesMap(ctx context.Context) map[string][]commonModels.ReflexRule
+	EvaluateReflexRulesWithTx(ctx context.Context, tx *gorm.DB, testDetails []commonModels.TestDetail,
+		investigations []commonModels.InvestigationResult, userId uint) *commonStructures.CommonError
+}
+
+func (reflexRulesService *ReflexRulesService) GetReflexRules(triggerLisCode string) (
+	[]structures.ReflexRule, *commonStructures.CommonError) {
+
+	rules, cErr := reflexRulesService.ReflexRulesDao.GetReflexRules(triggerLisCode)
+	if cErr != nil {
+		return []structures.ReflexRule{}, cErr
+	}
+
+	return mapper.MapReflexRules(rules), nil
+}
+
+func (reflexRulesService *ReflexRulesService) CreateReflexRule(ctx context.Context,
+	ruleRequest structures.ReflexRuleRequest, userId uint) (structures.ReflexRule, *commonStructures.CommonError) {
+
+	if cErr := validateReflexRuleRequest(ruleRequest); cErr != nil {
+		return structures.ReflexRule{}, cErr
+	}
+
+	rule := mapper.MapReflexRuleRequest(commonModels.ReflexRule{}, ruleRequest, userId)
+	if cErr := reflexRulesService.validateDuplicateReflexRule(rule); cErr != nil {
+		return structures.ReflexRule{}, cErr
+	}
+
+	rule, cErr := reflexRulesService.ReflexRulesDao.CreateReflexRule(rule)
+	if cErr != nil {
+		return structures.ReflexRule{}, cErr
+	}
+
+	reflexRulesService.invalidateReflexRulesCache(ctx)
+	return mapper.MapReflexRule(rule), nil
+}
+
+func (reflexRulesService *ReflexRulesService) UpdateReflexRule(ctx context.Context, ruleId uint,
+	ruleRequest structures.ReflexRuleRequest, userId uint) (structures.ReflexRule, *commonStructures.CommonError) {
+
+	if cErr := validateReflexRuleRequest(ruleRequest); cErr != nil {
+		return structures.ReflexRule{}, cErr
+	}
+
+	rule, cErr := reflexRulesService.getReflexRuleById(ruleId)
+	if cErr != nil {
+		return structures.ReflexRule{}, cErr
+	}
+
+	rule = mapper.MapReflexRuleRequest(rule, ruleRequest, userId)
+	if cErr := reflexRulesService.validateDuplicateReflexRule(rule); cErr != nil {
+		return structures.ReflexRule{}, cErr
+	}
+
+	rule, cErr = reflexRulesService.ReflexRulesDao.UpdateReflexRule(rule)
+	if cErr != nil {
+		return structures.ReflexRule{}, cErr
+	}
+
+	reflexRulesService.invalidateReflexRulesCache(ctx)
+	return mapper.MapReflexRule(rule), nil
+}
+
+func (reflexRulesService *ReflexRulesService) DeleteReflexRule(ctx context.Context,
+	ruleId, userId uint) *commonStructures.CommonError {
+
+	if _, cErr := reflexRulesService.getReflexRuleById(ruleId); cErr != nil {
+		return cErr
+	}
+
+	if cErr := reflexRulesService.ReflexRulesDao.DeleteReflexRule(ruleId, userId); cErr != nil {
+		return cErr
+	}
+
+	reflexRulesService.invalidateReflexRulesCache(ctx)
+	return nil
+}
+
+// GetReflexTestTriggersByTaskId returns every reflex rule that fired on the task, ordered or skipped, with the
+// reason it was recorded.
+func (reflexRulesService *ReflexRulesService) GetReflexTestTriggersByTaskId(taskId uint) (
+	[]structures.ReflexTestTrigger, *commonStructures.CommonError) {
+
+	triggers, cErr := reflexRulesService.ReflexRulesDao.GetReflexTestTriggersByTaskId(taskId)
+	if cErr != nil {
+		return []structures.ReflexTestTrigger{}, cErr
+	}
+
+	ruleIds := []uint{}
+	for _, trigger := range triggers {
+		ruleIds = append(ruleIds, trigger.ReflexRuleId)
+	}
+	rules, cErr := reflexRulesService.ReflexRulesDao.GetReflexRulesByIds(commonUtils.CreateUniqueSliceUint(ruleIds))
+	if cErr != nil {
+		return []structures.ReflexTestTrigger{}, cErr
+	}
+
+	ruleIdToRuleMap := map[uint]commonModels.ReflexRule{}
+	for _, rule := range rules {
+		ruleIdToRuleMap[rule.Id] = rule
+	}
+
+	return mapper.MapReflexTestTriggers(triggers, ruleIdToRuleMap), nil
+}
+
+// GetActiveReflexRulesMap returns the active reflex rules keyed by the upper cased trigger LIS code.
+// Any error is logged and an empty map is returned so that saving results is never blocked on the rules.
+func (reflexRulesService *ReflexRulesService) GetActiveReflexRulesMap(
+	ctx context.Context) map[string][]commonModels.ReflexRule {
+
+	rulesMap := map[string][]commonModels.ReflexRule{}
+	rules := []commonModels.ReflexRule{}
+
+	err := reflexRulesService.Cache.Get(ctx, commonConstants.CacheKeyReflexRulesAll, &rules)
+	if err != nil {
+		var cErr *commonStructures.CommonError
+		rules, cErr = reflexRulesService.ReflexRulesDao.GetActiveReflexRules()
+		if cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_FETCHING_REFLEX_RULES,
+				nil, errors.New(cErr.Message))
+			return rulesMap
+		}
+		_ = reflexRulesService.Cache.Set(ctx, commonConstants.CacheKeyReflexRulesAll, rules,
+			commonConstants.CacheExpiry5MinutesInt)
+	}
+
+	for _, rule := range rules {
+		triggerLisCode := strings.ToUpper(rule.TriggerLisCode)
+		rulesMap[triggerLisCode] = append(rulesMap[triggerLisCode], rule)
+	}
+	return rulesMap
+}
+
+func (reflexRulesService *ReflexRulesService) getReflexRuleById(ruleId uint) (
+	commonModels.ReflexRule, *commonStructures.CommonError) {
+
+	rule, cErr := reflexRulesService.ReflexRulesDao.GetReflexRuleById(ruleId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_REFLEX_RULE_NOT_FOUND
+		}
+		return rule, cErr
+	}
+
+	return rule, nil
+}
+
+func (reflexRulesService *ReflexRulesService) validateDuplicateReflexRule(
+	rule commonModels.ReflexRule) *commonStructures.CommonError {
+
+	existingRule, cErr := reflexRulesService.ReflexRulesDao.GetReflexRuleByTriggerAndReflexTest(rule)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return cErr
+	}
+
+	if existingRule.Id != rule.Id {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_REFLEX_RULE_ALREADY_EXISTS,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	return nil
+}
+
+func (reflexRulesService *ReflexRulesService) invalidateReflexRulesCache(ctx context.Context) {
+	if err := reflexRulesService.Cache.Delete(ctx, commonConstants.CacheKeyReflexRulesAll); err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_INVALIDATING_REFLEX_RULES_KEY,
+			nil, err)
+	}
+}
+
+func validateReflexRuleRequest(ruleRequest structures.ReflexRuleRequest) *commonStructures.CommonError {
+	if !commonUtils.SliceContainsString(commonConstants.ReflexOperators, ruleRequest.Operator) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_REFLEX_RULE_OPERATOR,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if ruleRequest.Operator == commonConstants.ReflexOperatorEqual && strings.TrimSpace(ruleRequest.Value) == "" {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_REFLEX_RULE_VALUE_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if commonUtils.SliceContainsString(commonConstants.ReflexNumericOperators, ruleRequest.Operator) {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(ruleRequest.Value), 64); err != nil {
+			return &commonStructures.CommonError{
+				Message:    commonConstants.ERROR_REFLEX_RULE_VALUE_NOT_NUMERIC,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+	}
+
+	return nil
+}
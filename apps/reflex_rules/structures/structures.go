@@ -0,0 +1,132 @@
+package structures
+
+import (
+	"time"
+
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// @swagger:model ReflexRule
+type ReflexRule struct {
+	// The reflex rule ID.
+	// example: 1
+	Id uint `json:"id"`
+	// The name of the rule.
+	// example: "TSH abnormal - FT4"
+	Name string `json:"name"`
+	// The lab the rule applies to. 0 applies the rule to all labs.
+	// example: 0
+	LabId uint `json:"lab_id"`
+	// The LIS code of the investigation whose result triggers the rule.
+	// example: "TSH"
+	TriggerLisCode string `json:"trigger_lis_code"`
+	// The condition on the result, one of abnormal, critical, eq, gt, gte, lt and lte.
+	// example: "gt"
+	Operator string `json:"operator"`
+	// The value the result is compared against. Optional for abnormal and critical.
+	// example: "4.5"
+	Value string `json:"value"`
+	// The master test ID of the reflex test.
+	// example: 120
+	ReflexMasterTestId uint `json:"reflex_master_test_id"`
+	// The LIS code of the reflex test.
+	// example: "FT4"
+	ReflexLisCode string `json:"reflex_lis_code"`
+	// The name of the reflex test.
+	// example: "Free T4"
+	ReflexTestName string `json:"reflex_test_name"`
+	// The test type of the reflex test.
+	// example: "test"
+	ReflexTestType string `json:"reflex_test_type"`
+	// The department of the reflex test.
+	// example: "Biochemistry"
+	ReflexDepartment string `json:"reflex_department"`
+	// The vial type the reflex test can be run on. 0 allows any vial of the triggering test.
+	// example: 0
+	VialTypeId uint `json:"vial_type_id"`
+	// The sample volume the reflex test needs, in the unit of the collected volume. 0 skips the volume check.
+	// example: 500
+	RequiredVolume uint `json:"required_volume"`
+	// The hours after collection the sample remains stable for the reflex test. 0 skips the stability check.
+	// example: 48
+	StabilityHours uint `json:"stability_hours"`
+	// If the rule is active.
+	// example: true
+	IsActive bool `json:"is_active"`
+}
+
+type ReflexRuleRequest struct {
+	Name               string `json:"name" binding:"required"`
+	LabId              uint   `json:"lab_id"`
+	TriggerLisCode     string `json:"trigger_lis_code" binding:"required"`
+	Operator           string `json:"operator" binding:"required"`
+	Value              string `json:"value"`
+	ReflexMasterTestId uint   `json:"reflex_master_test_id" binding:"required"`
+	ReflexLisCode      string `json:"reflex_lis_code" binding:"required"`
+	ReflexTestName     string `json:"reflex_test_name" binding:"required"`
+	ReflexTestType     string `json:"reflex_test_type" binding:"required"`
+	ReflexDepartment   string `json:"reflex_department"`
+	VialTypeId         uint   `json:"vial_type_id"`
+	RequiredVolume     uint   `json:"required_volume"`
+	StabilityHours     uint   `json:"stability_hours"`
+	IsActive           *bool  `json:"is_active"`
+}
+
+// @swagger:model ReflexTestTrigger
+type ReflexTestTrigger struct {
+	// The reflex test trigger ID.
+	// example: 1
+	Id uint `json:"id"`
+	// The reflex rule that fired.
+	// example: 1
+	ReflexRuleId uint `json:"reflex_rule_id"`
+	// The name of the reflex rule.
+	// example: "TSH abnormal - FT4"
+	ReflexRuleName string `json:"reflex_rule_name"`
+	// The task ID.
+	// example: 1
+	TaskId uint `json:"task_id"`
+	// The OMS order ID.
+	// example: "OR1234"
+	OmsOrderId string `json:"oms_order_id"`
+	// The test whose result triggered the rule.
+	// example: 10
+	TriggerTestDetailsId uint `json:"trigger_test_details_id"`
+	// The investigation result that triggered the rule.
+	// example: 100
+	TriggerInvestigationResultId uint `json:"trigger_investigation_result_id"`
+	// The value of the investigation when the rule fired.
+	// example: "12.5"
+	TriggerValue string `json:"trigger_value"`
+	// The abnormality of the investigation when the rule fired.
+	// example: "H"
+	TriggerAbnormality string `json:"trigger_abnormality"`
+	// The sample the reflex test was added on.
+	// example: 5
+	SampleId uint `json:"sample_id"`
+	// The test details ID of the reflex test.
+	// example: 11
+	ReflexTestDetailsId uint `json:"reflex_test_details_id"`
+	// The central test ID of the reflex test.
+	// example: "RFX1"
+	ReflexCentralOmsTestId string `json:"reflex_central_oms_test_id"`
+	// The sample volume reserved for the reflex test.
+	// example: 500
+	RequiredVolume uint `json:"required_volume"`
+	// ordered or skipped.
+	// example: "ordered"
+	Status string `json:"status"`
+	// Why the reflex test was ordered or skipped.
+	// example: "TSH 12.5 matched gt 4.5"
+	Reason string `json:"reason"`
+	// When the rule fired.
+	// example: "2024-01-01T00:00:00Z"
+	TriggeredAt *time.Time `json:"triggered_at"`
+}
+
+// ReflexCandidate is an investigation result matching an active reflex rule.
+type ReflexCandidate struct {
+	Rule          commonModels.ReflexRule
+	TestDetail    commonModels.TestDetail
+	Investigation commonModels.InvestigationResult
+}
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/report_amendments/service"
+	workerService "github.com/Orange-Health/citadel/apps/task/worker_service"
+)
+
+type ReportAmendments struct {
+	ReportAmendmentService service.ReportAmendmentServiceInterface
+	TaskWorkerService      workerService.TaskWorkerServiceInterface
+}
+
+func InitReportAmendmentsController() *ReportAmendments {
+	return &ReportAmendments{
+		ReportAmendmentService: service.InitializeReportAmendmentService(),
+		TaskWorkerService:      workerService.InitializeWorkerService(),
+	}
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/report_amendments/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructs "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Report Amendments
+// @Description	Get the amendments of a task report with the changed values and the report version of each
+// @Tags			report-amendments
+// @Produce		json
+// @Param			taskId		path		int								true	"Task ID"
+// @Success		200			{object}	[]structures.ReportAmendment	"Report Amendments"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/report-amendments/tasks/{taskId} [get]
+func (reportAmendmentsController *ReportAmendments) GetReportAmendments(c *gin.Context) {
+	taskId := commonUtils.ConvertStringToUint(c.Param("taskId"))
+	if taskId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_TASK_ID)
+		return
+	}
+
+	amendments, cErr := reportAmendmentsController.ReportAmendmentService.GetReportAmendmentsByTaskId(taskId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, amendments)
+}
+
+// @Summary		Create Report Amendment
+// @Description	Open an amendment on a released report with a reason code and justification
+// @Tags			report-amendments
+// @Accept			json
+// @Produce		json
+// @Param			taskId			path		int									true	"Task ID"
+// @Param			amendment		body		structures.ReportAmendmentRequest	true	"Report Amendment"
+// @Success		200				{object}	structures.ReportAmendment			"Report Amendment"
+// @Failure		400,404,409,500	{object}	structures.CommonAPIResponse		"Common API Response"
+// @Router			/api/v1/report-amendments/tasks/{taskId} [post]
+func (reportAmendmentsController *ReportAmendments) CreateReportAmendment(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	taskId := commonUtils.ConvertStringToUint(c.Param("taskId"))
+	if taskId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_TASK_ID)
+		return
+	}
+
+	amendmentRequest := structures.ReportAmendmentRequest{}
+	if err := c.ShouldBindJSON(&amendmentRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	amendment, cErr := reportAmendmentsController.ReportAmendmentService.CreateReportAmendment(taskId,
+		amendmentRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, amendment)
+}
+
+// @Summary		Approve Report Amendment
+// @Description	Approve an open amendment, retain the released report and release the amended report
+// @Tags			report-amendments
+// @Produce		json
+// @Param			amendmentId			path		int								true	"Report Amendment ID"
+// @Success		200					{object}	structures.ReportAmendment		"Report Amendment"
+// @Failure		400,403,404,409,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/report-amendments/{amendmentId}/approve [post]
+func (reportAmendmentsController *ReportAmendments) ApproveReportAmendment(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	amendmentId := commonUtils.ConvertStringToUint(c.Param("amendmentId"))
+	if amendmentId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_REPORT_AMENDMENT_ID)
+		return
+	}
+
+	amendment, cErr := reportAmendmentsController.ReportAmendmentService.ApproveReportAmendment(amendmentId, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	_ = reportAmendmentsController.TaskWorkerService.ReleaseReportTask(c.Request.Context(), amendment.TaskId, true)
+
+	c.JSON(http.StatusOK, amendment)
+}
+
+// @Summary		Cancel Report Amendment
+// @Description	Cancel an open amendment
+// @Tags			report-amendments
+// @Produce		json
+// @Param			amendmentId		path		int								true	"Report Amendment ID"
+// @Success		200				{object}	structures.CommonAPIResponse	"Common API Response"
+// @Failure		400,404,409,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/report-amendments/{amendmentId}/cancel [post]
+func (reportAmendmentsController *ReportAmendments) CancelReportAmendment(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	amendmentId := commonUtils.ConvertStringToUint(c.Param("amendmentId"))
+	if amendmentId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_REPORT_AMENDMENT_ID)
+		return
+	}
+
+	cErr = reportAmendmentsController.ReportAmendmentService.CancelReportAmendment(amendmentId, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructs.CommonAPIResponse{
+		Message: commonConstants.DONE_RESPONSE,
+	})
+}
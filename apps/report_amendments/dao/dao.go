@@ -0,0 +1,167 @@
+package dao
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetReportAmendmentById(amendmentId uint) (commonModels.ReportAmendment, *commonStructures.CommonError)
+	GetReportAmendmentsByTaskId(taskId uint) ([]commonModels.ReportAmendment, *commonStructures.CommonError)
+	GetOpenReportAmendmentByTaskId(taskId uint) (commonModels.ReportAmendment, *commonStructures.CommonError)
+	GetLatestApprovedReportAmendmentByTaskId(taskId uint) (
+		commonModels.ReportAmendment, *commonStructures.CommonError)
+	GetLatestApprovedReportAmendmentByOmsOrderId(omsOrderId string) (
+		commonModels.ReportAmendment, *commonStructures.CommonError)
+
+	CreateReportAmendment(amendment commonModels.ReportAmendment) (
+		commonModels.ReportAmendment, *commonStructures.CommonError)
+	ApproveReportAmendment(amendment commonModels.ReportAmendment,
+		retainedAttachments []commonModels.Attachment) *commonStructures.CommonError
+	CancelReportAmendment(amendmentId, userId uint) *commonStructures.CommonError
+}
+
+func (reportAmendmentDao *ReportAmendmentDao) GetReportAmendmentById(amendmentId uint) (
+	commonModels.ReportAmendment, *commonStructures.CommonError) {
+
+	amendment := commonModels.ReportAmendment{}
+	if err := reportAmendmentDao.Db.Where("id = ?", amendmentId).First(&amendment).Error; err != nil {
+		return amendment, commonUtils.HandleORMError(err)
+	}
+
+	return amendment, nil
+}
+
+func (reportAmendmentDao *ReportAmendmentDao) GetReportAmendmentsByTaskId(taskId uint) (
+	[]commonModels.ReportAmendment, *commonStructures.CommonError) {
+
+	amendments := []commonModels.ReportAmendment{}
+	if err := reportAmendmentDao.Db.Where("task_id = ?", taskId).Order("id DESC").
+		Find(&amendments).Error; err != nil {
+		return amendments, commonUtils.HandleORMError(err)
+	}
+
+	return amendments, nil
+}
+
+func (reportAmendmentDao *ReportAmendmentDao) GetOpenReportAmendmentByTaskId(taskId uint) (
+	commonModels.ReportAmendment, *commonStructures.CommonError) {
+
+	amendment := commonModels.ReportAmendment{}
+	if err := reportAmendmentDao.Db.Where("task_id = ?", taskId).
+		Where("status = ?", commonConstants.ReportAmendmentStatusOpen).
+		First(&amendment).Error; err != nil {
+		return amendment, commonUtils.HandleORMError(err)
+	}
+
+	return amendment, nil
+}
+
+func (reportAmendmentDao *ReportAmendmentDao) GetLatestApprovedReportAmendmentByTaskId(taskId uint) (
+	commonModels.ReportAmendment, *commonStructures.CommonError) {
+
+	amendment := commonModels.ReportAmendment{}
+	if err := reportAmendmentDao.Db.Where("task_id = ?", taskId).
+		Where("status = ?", commonConstants.ReportAmendmentStatusApproved).
+		Order("report_version DESC").First(&amendment).Error; err != nil {
+		return amendment, commonUtils.HandleORMError(err)
+	}
+
+	return amendment, nil
+}
+
+func (reportAmendmentDao *ReportAmendmentDao) GetLatestApprovedReportAmendmentByOmsOrderId(omsOrderId string) (
+	commonModels.ReportAmendment, *commonStructures.CommonError) {
+
+	amendment := commonModels.ReportAmendment{}
+	if err := reportAmendmentDao.Db.Where("oms_order_id = ?", omsOrderId).
+		Where("status = ?", commonConstants.ReportAmendmentStatusApproved).
+		Order("report_version DESC").First(&amendment).Error; err != nil {
+		return amendment, commonUtils.HandleORMError(err)
+	}
+
+	return amendment, nil
+}
+
+func (reportAmendmentDao *ReportAmendmentDao) CreateReportAmendment(amendment commonModels.ReportAmendment) (
+	commonModels.ReportAmendment, *commonStructures.CommonError) {
+
+	if err := reportAmendmentDao.Db.Create(&amendment).Error; err != nil {
+		return amendment, commonUtils.HandleORMError(err)
+	}
+
+	return amendment, nil
+}
+
+// ApproveReportAmendment approves the amendment only if it is still open and retains the attachments of the
+// released report in the same transaction, so a concurrent approval or cancellation cannot copy them twice.
+func (reportAmendmentDao *ReportAmendmentDao) ApproveReportAmendment(amendment commonModels.ReportAmendment,
+	retainedAttachments []commonModels.Attachment) *commonStructures.CommonError {
+
+	var cErr *commonStructures.CommonError
+	err := reportAmendmentDao.Db.Transaction(func(tx *gorm.DB) error {
+		amendmentUpdates := map[string]interface{}{
+			"status":             commonConstants.ReportAmendmentStatusApproved,
+			"report_version":     amendment.ReportVersion,
+			"approved_by":        amendment.ApprovedBy,
+			"approved_at":        amendment.ApprovedAt,
+			"investigation_diff": amendment.InvestigationDiff,
+			"updated_by":         amendment.UpdatedBy,
+			"updated_at":         commonUtils.GetCurrentTime(),
+		}
+		result := tx.Model(&commonModels.ReportAmendment{}).Where("id = ?", amendment.Id).
+			Where("status = ?", commonConstants.ReportAmendmentStatusOpen).Updates(amendmentUpdates)
+		if result.Error != nil {
+			cErr = commonUtils.HandleORMError(result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			cErr = &commonStructures.CommonError{
+				Message:    commonConstants.ERROR_REPORT_AMENDMENT_NOT_OPEN,
+				StatusCode: http.StatusConflict,
+			}
+			return gorm.ErrRecordNotFound
+		}
+
+		if len(retainedAttachments) == 0 {
+			return nil
+		}
+		if err := tx.Create(&retainedAttachments).Error; err != nil {
+			cErr = commonUtils.HandleORMError(err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return cErr
+	}
+
+	return nil
+}
+
+func (reportAmendmentDao *ReportAmendmentDao) CancelReportAmendment(amendmentId, userId uint) *commonStructures.CommonError {
+	amendmentUpdates := map[string]interface{}{
+		"status":     commonConstants.ReportAmendmentStatusCancelled,
+		"updated_by": userId,
+		"updated_at": commonUtils.GetCurrentTime(),
+	}
+	result := reportAmendmentDao.Db.Model(&commonModels.ReportAmendment{}).Where("id = ?", amendmentId).
+		Where("status = ?", commonConstants.ReportAmendmentStatusOpen).Updates(amendmentUpdates)
+	if result.Error != nil {
+		return commonUtils.HandleORMError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_REPORT_AMENDMENT_NOT_OPEN,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	return nil
+}
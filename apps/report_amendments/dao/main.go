@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type ReportAmendmentDao struct {
+	Db *gorm.DB
+}
+
+func InitializeReportAmendmentDao() DataLayer {
+	return &ReportAmendmentDao{
+		Db: psql.GetDbInstance(),
+	}
+}
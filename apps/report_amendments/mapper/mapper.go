@@ -0,0 +1,132 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"github.com/Orange-Health/citadel/apps/report_amendments/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapReportAmendment(amendment commonModels.ReportAmendment) structures.ReportAmendment {
+	investigationDiff := []structures.ReportAmendmentDiff{}
+	if amendment.InvestigationDiff != "" {
+		_ = json.Unmarshal([]byte(amendment.InvestigationDiff), &investigationDiff)
+	}
+
+	return structures.ReportAmendment{
+		Id:                amendment.Id,
+		TaskId:            amendment.TaskId,
+		OmsOrderId:        amendment.OmsOrderId,
+		Status:            amendment.Status,
+		ReasonCode:        amendment.ReasonCode,
+		Justification:     amendment.Justification,
+		ReportVersion:     amendment.ReportVersion,
+		RequestedBy:       amendment.RequestedBy,
+		ApprovedBy:        amendment.ApprovedBy,
+		ApprovedAt:        amendment.ApprovedAt,
+		InvestigationDiff: investigationDiff,
+		CreatedAt:         amendment.CreatedAt,
+	}
+}
+
+func MapReportAmendments(amendments []commonModels.ReportAmendment) []structures.ReportAmendment {
+	reportAmendments := []structures.ReportAmendment{}
+	for _, amendment := range amendments {
+		reportAmendments = append(reportAmendments, MapReportAmendment(amendment))
+	}
+	return reportAmendments
+}
+
+// MapReportAmendmentValues captures the values of the investigations as they appear on the report, where the
+// investigation data takes the place of the investigation value when present.
+func MapReportAmendmentValues(investigations []commonModels.InvestigationResult,
+	investigationsData []commonModels.InvestigationData) []structures.ReportAmendmentValue {
+
+	investigationIdToDataMap := make(map[uint]string)
+	for _, investigationData := range investigationsData {
+		investigationIdToDataMap[investigationData.InvestigationResultId] = investigationData.Data
+	}
+
+	values := []structures.ReportAmendmentValue{}
+	for _, investigation := range investigations {
+		value := investigation.InvestigationValue
+		if data, ok := investigationIdToDataMap[investigation.Id]; ok && data != "" {
+			value = data
+		}
+		values = append(values, structures.ReportAmendmentValue{
+			InvestigationResultId: investigation.Id,
+			InvestigationName:     investigation.InvestigationName,
+			LisCode:               investigation.LisCode,
+			Value:                 value,
+			Abnormality:           investigation.Abnormality,
+		})
+	}
+	return values
+}
+
+// MapReportAmendmentDiff returns the investigations whose value or abnormality differs between the released and
+// the amended report, including the investigations added or removed after the amendment was opened.
+func MapReportAmendmentDiff(previousValues, amendedValues []structures.ReportAmendmentValue) []structures.ReportAmendmentDiff {
+	investigationIdToPreviousValueMap := make(map[uint]structures.ReportAmendmentValue)
+	for _, previousValue := range previousValues {
+		investigationIdToPreviousValueMap[previousValue.InvestigationResultId] = previousValue
+	}
+
+	diff := []structures.ReportAmendmentDiff{}
+	for _, amendedValue := range amendedValues {
+		previousValue, ok := investigationIdToPreviousValueMap[amendedValue.InvestigationResultId]
+		delete(investigationIdToPreviousValueMap, amendedValue.InvestigationResultId)
+		if ok && previousValue.Value == amendedValue.Value && previousValue.Abnormality == amendedValue.Abnormality {
+			continue
+		}
+		diff = append(diff, structures.ReportAmendmentDiff{
+			InvestigationResultId: amendedValue.InvestigationResultId,
+			InvestigationName:     amendedValue.InvestigationName,
+			LisCode:               amendedValue.LisCode,
+			PreviousValue:         previousValue.Value,
+			AmendedValue:          amendedValue.Value,
+			PreviousAbnormality:   previousValue.Abnormality,
+			AmendedAbnormality:    amendedValue.Abnormality,
+		})
+	}
+
+	for _, previousValue := range previousValues {
+		if _, ok := investigationIdToPreviousValueMap[previousValue.InvestigationResultId]; !ok {
+			continue
+		}
+		diff = append(diff, structures.ReportAmendmentDiff{
+			InvestigationResultId: previousValue.InvestigationResultId,
+			InvestigationName:     previousValue.InvestigationName,
+			LisCode:               previousValue.LisCode,
+			PreviousValue:         previousValue.Value,
+			PreviousAbnormality:   previousValue.Abnormality,
+		})
+	}
+
+	return diff
+}
+
+// MapRetainedReportAttachments copies the attachments of the released report as amended reports so they remain
+// with the task after the amended report replaces them.
+func MapRetainedReportAttachments(attachments []commonModels.Attachment, userId uint) []commonModels.Attachment {
+	retainedAttachments := []commonModels.Attachment{}
+	for _, attachment := range attachments {
+		retainedAttachments = append(retainedAttachments, commonModels.Attachment{
+			BaseModel: commonModels.BaseModel{
+				CreatedBy: userId,
+				UpdatedBy: userId,
+			},
+			TaskId:             attachment.TaskId,
+			Reference:          attachment.Reference,
+			AttachmentUrl:      attachment.AttachmentUrl,
+			ThumbnailUrl:       attachment.ThumbnailUrl,
+			ThumbnailReference: attachment.ThumbnailReference,
+			AttachmentType:     commonConstants.AttachmentTypeAmendedReport,
+			AttachmentLabel:    commonConstants.AttachmentLabelLabDocs,
+			IsReportable:       false,
+			Extension:          attachment.Extension,
+		})
+	}
+	return retainedAttachments
+}
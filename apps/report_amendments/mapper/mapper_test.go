@@ -0,0 +1,107 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/apps/report_amendments/structures"
+)
+
+func TestMapReportAmendmentDiff(t *testing.T) {
+	hemoglobin := structures.ReportAmendmentValue{
+		InvestigationResultId: 1,
+		InvestigationName:     "Hemoglobin",
+		LisCode:               "HB",
+		Value:                 "13.5",
+	}
+	potassium := structures.ReportAmendmentValue{
+		InvestigationResultId: 2,
+		InvestigationName:     "Potassium",
+		LisCode:               "K",
+		Value:                 "4.1",
+	}
+
+	amendedHemoglobin := hemoglobin
+	amendedHemoglobin.Value = "7.2"
+	amendedHemoglobin.Abnormality = "critical_low"
+	flaggedPotassium := potassium
+	flaggedPotassium.Abnormality = "high"
+
+	testCases := []struct {
+		name           string
+		previousValues []structures.ReportAmendmentValue
+		amendedValues  []structures.ReportAmendmentValue
+		expectedDiff   []structures.ReportAmendmentDiff
+	}{
+		{
+			name:           "unchanged",
+			previousValues: []structures.ReportAmendmentValue{hemoglobin, potassium},
+			amendedValues:  []structures.ReportAmendmentValue{hemoglobin, potassium},
+			expectedDiff:   []structures.ReportAmendmentDiff{},
+		},
+		{
+			name:           "value and abnormality changed",
+			previousValues: []structures.ReportAmendmentValue{hemoglobin, potassium},
+			amendedValues:  []structures.ReportAmendmentValue{amendedHemoglobin, potassium},
+			expectedDiff: []structures.ReportAmendmentDiff{
+				{
+					InvestigationResultId: 1,
+					InvestigationName:     "Hemoglobin",
+					LisCode:               "HB",
+					PreviousValue:         "13.5",
+					AmendedValue:          "7.2",
+					AmendedAbnormality:    "critical_low",
+				},
+			},
+		},
+		{
+			name:           "only abnormality changed",
+			previousValues: []structures.ReportAmendmentValue{potassium},
+			amendedValues:  []structures.ReportAmendmentValue{flaggedPotassium},
+			expectedDiff: []structures.ReportAmendmentDiff{
+				{
+					InvestigationResultId: 2,
+					InvestigationName:     "Potassium",
+					LisCode:               "K",
+					PreviousValue:         "4.1",
+					AmendedValue:          "4.1",
+					AmendedAbnormality:    "high",
+				},
+			},
+		},
+		{
+			name:           "investigation added",
+			previousValues: []structures.ReportAmendmentValue{hemoglobin},
+			amendedValues:  []structures.ReportAmendmentValue{hemoglobin, potassium},
+			expectedDiff: []structures.ReportAmendmentDiff{
+				{
+					InvestigationResultId: 2,
+					InvestigationName:     "Potassium",
+					LisCode:               "K",
+					AmendedValue:          "4.1",
+				},
+			},
+		},
+		{
+			name:           "investigation removed",
+			previousValues: []structures.ReportAmendmentValue{hemoglobin, potassium},
+			amendedValues:  []structures.ReportAmendmentValue{potassium},
+			expectedDiff: []structures.ReportAmendmentDiff{
+				{
+					InvestigationResultId: 1,
+					InvestigationName:     "Hemoglobin",
+					LisCode:               "HB",
+					PreviousValue:         "13.5",
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedDiff, MapReportAmendmentDiff(testCase.previousValues,
+				testCase.amendedValues))
+		})
+	}
+}
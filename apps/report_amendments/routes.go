@@ -0,0 +1,16 @@
+package reportAmendments
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/report_amendments/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	reportAmendmentsController := controller.InitReportAmendmentsController()
+
+	router.GET("/tasks/:taskId", reportAmendmentsController.GetReportAmendments)
+	router.POST("/tasks/:taskId", reportAmendmentsController.CreateReportAmendment)
+	router.POST("/:amendmentId/approve", reportAmendmentsController.ApproveReportAmendment)
+	router.POST("/:amendmentId/cancel", reportAmendmentsController.CancelReportAmendment)
+}
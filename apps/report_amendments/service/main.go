@@ -0,0 +1,27 @@
+package service
+
+import (
+	attachmentService "github.com/Orange-Health/citadel/apps/attachments/service"
+	invResService "github.com/Orange-Health/citadel/apps/investigation_results/service"
+	"github.com/Orange-Health/citadel/apps/report_amendments/dao"
+	taskService "github.com/Orange-Health/citadel/apps/task/service"
+	userService "github.com/Orange-Health/citadel/apps/users/service"
+)
+
+type ReportAmendmentService struct {
+	ReportAmendmentDao          dao.DataLayer
+	TaskService                 taskService.TaskServiceInterface
+	InvestigationResultsService invResService.InvestigationResultServiceInterface
+	AttachmentService           attachmentService.AttachmentServiceInterface
+	UserService                 userService.UserServiceInterface
+}
+
+func InitializeReportAmendmentService() ReportAmendmentServiceInterface {
+	return &ReportAmendmentService{
+		ReportAmendmentDao:          dao.InitializeReportAmendmentDao(),
+		TaskService:                 taskService.InitializeTaskService(),
+		InvestigationResultsService: invResService.InitializeInvestigationResultService(),
+		AttachmentService:           attachmentService.InitializeAttachmentService(),
+		UserService:                 userService.InitializeUserService(),
+	}
+}
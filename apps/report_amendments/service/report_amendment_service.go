@@ -0,0 +1,275 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Orange-Health/citadel/apps/report_amendments/mapper"
+	"github.com/Orange-Health/citadel/apps/report_amendments/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type ReportAmendmentServiceInterface interface {
+	GetReportAmendmentsByTaskId(taskId uint) ([]structures.ReportAmendment, *commonStructures.CommonError)
+	CreateReportAmendment(taskId uint, amendmentRequest structures.ReportAmendmentRequest, userId uint) (
+		structures.ReportAmendment, *commonStructures.CommonError)
+	ApproveReportAmendment(amendmentId, userId uint) (structures.ReportAmendment, *commonStructures.CommonError)
+	CancelReportAmendment(amendmentId, userId uint) *commonStructures.CommonError
+
+	GetLatestApprovedReportAmendmentByOmsOrderId(omsOrderId string) (
+		commonModels.ReportAmendment, *commonStructures.CommonError)
+}
+
+func (reportAmendmentService *ReportAmendmentService) GetReportAmendmentsByTaskId(taskId uint) (
+	[]structures.ReportAmendment, *commonStructures.CommonError) {
+
+	amendments, cErr := reportAmendmentService.ReportAmendmentDao.GetReportAmendmentsByTaskId(taskId)
+	if cErr != nil {
+		return []structures.ReportAmendment{}, cErr
+	}
+
+	return mapper.MapReportAmendments(amendments), nil
+}
+
+// CreateReportAmendment opens an amendment on a released report and captures the values on the report, which
+// the values at approval are compared against.
+func (reportAmendmentService *ReportAmendmentService) CreateReportAmendment(taskId uint,
+	amendmentRequest structures.ReportAmendmentRequest, userId uint) (
+	structures.ReportAmendment, *commonStructures.CommonError) {
+
+	if cErr := validateReportAmendmentRequest(amendmentRequest); cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+
+	task, cErr := reportAmendmentService.TaskService.GetTaskModelById(taskId)
+	if cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+	if task.Status != commonConstants.TASK_STATUS_COMPLETED {
+		return structures.ReportAmendment{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_REPORT_AMENDMENT_TASK_NOT_COMPLETED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	_, cErr = reportAmendmentService.ReportAmendmentDao.GetOpenReportAmendmentByTaskId(taskId)
+	if cErr == nil {
+		return structures.ReportAmendment{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_REPORT_AMENDMENT_ALREADY_OPEN,
+			StatusCode: http.StatusConflict,
+		}
+	}
+	if cErr.StatusCode != http.StatusNotFound {
+		return structures.ReportAmendment{}, cErr
+	}
+
+	values, cErr := reportAmendmentService.getReportAmendmentValues(taskId)
+	if cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+	valuesBytes, _ := json.Marshal(values)
+
+	amendment := commonModels.ReportAmendment{
+		BaseModel: commonModels.BaseModel{
+			CreatedBy: userId,
+			UpdatedBy: userId,
+		},
+		TaskId:            task.Id,
+		OmsOrderId:        task.OmsOrderId,
+		Status:            commonConstants.ReportAmendmentStatusOpen,
+		ReasonCode:        amendmentRequest.ReasonCode,
+		Justification:     strings.TrimSpace(amendmentRequest.Justification),
+		RequestedBy:       userId,
+		PreviousValues:    string(valuesBytes),
+		InvestigationDiff: "[]",
+	}
+	amendment, cErr = reportAmendmentService.ReportAmendmentDao.CreateReportAmendment(amendment)
+	if cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+
+	return mapper.MapReportAmendment(amendment), nil
+}
+
+// ApproveReportAmendment records the changes made since the amendment was opened, bumps the report version and
+// retains the released report in the attachments. The caller is expected to release the report again so that the
+// amended report is generated.
+func (reportAmendmentService *ReportAmendmentService) ApproveReportAmendment(amendmentId, userId uint) (
+	structures.ReportAmendment, *commonStructures.CommonError) {
+
+	if cErr := reportAmendmentService.validateApprover(userId); cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+
+	amendment, cErr := reportAmendmentService.getOpenReportAmendment(amendmentId)
+	if cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+
+	previousValues := []structures.ReportAmendmentValue{}
+	if err := json.Unmarshal([]byte(amendment.PreviousValues), &previousValues); err != nil {
+		return structures.ReportAmendment{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_WHILE_DECODING_REPORT_AMENDMENT,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	amendedValues, cErr := reportAmendmentService.getReportAmendmentValues(amendment.TaskId)
+	if cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+
+	diff := mapper.MapReportAmendmentDiff(previousValues, amendedValues)
+	if len(diff) == 0 {
+		return structures.ReportAmendment{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_REPORT_AMENDMENT_NO_CHANGES,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	diffBytes, _ := json.Marshal(diff)
+
+	reportVersion, cErr := reportAmendmentService.getCurrentReportVersion(amendment.TaskId)
+	if cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+
+	attachments, cErr := reportAmendmentService.AttachmentService.GetAttachmentDtosByTaskId(amendment.TaskId,
+		commonConstants.ReportAmendmentRetainedAttachmentTypes)
+	if cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+
+	amendment.Status = commonConstants.ReportAmendmentStatusApproved
+	amendment.ReportVersion = reportVersion + 1
+	amendment.ApprovedBy = userId
+	amendment.ApprovedAt = commonUtils.GetCurrentTime()
+	amendment.InvestigationDiff = string(diffBytes)
+	amendment.UpdatedBy = userId
+
+	cErr = reportAmendmentService.ReportAmendmentDao.ApproveReportAmendment(amendment,
+		mapper.MapRetainedReportAttachments(attachments, userId))
+	if cErr != nil {
+		return structures.ReportAmendment{}, cErr
+	}
+
+	return mapper.MapReportAmendment(amendment), nil
+}
+
+func (reportAmendmentService *ReportAmendmentService) CancelReportAmendment(amendmentId,
+	userId uint) *commonStructures.CommonError {
+
+	if _, cErr := reportAmendmentService.getOpenReportAmendment(amendmentId); cErr != nil {
+		return cErr
+	}
+
+	return reportAmendmentService.ReportAmendmentDao.CancelReportAmendment(amendmentId, userId)
+}
+
+// GetLatestApprovedReportAmendmentByOmsOrderId returns an empty amendment when the report of the order was never
+// amended.
+func (reportAmendmentService *ReportAmendmentService) GetLatestApprovedReportAmendmentByOmsOrderId(
+	omsOrderId string) (commonModels.ReportAmendment, *commonStructures.CommonError) {
+
+	amendment, cErr := reportAmendmentService.ReportAmendmentDao.GetLatestApprovedReportAmendmentByOmsOrderId(
+		omsOrderId)
+	if cErr != nil && cErr.StatusCode != http.StatusNotFound {
+		return commonModels.ReportAmendment{}, cErr
+	}
+
+	return amendment, nil
+}
+
+func (reportAmendmentService *ReportAmendmentService) getOpenReportAmendment(amendmentId uint) (
+	commonModels.ReportAmendment, *commonStructures.CommonError) {
+
+	amendment, cErr := reportAmendmentService.ReportAmendmentDao.GetReportAmendmentById(amendmentId)
+	if cErr != nil {
+		return amendment, cErr
+	}
+	if amendment.Status != commonConstants.ReportAmendmentStatusOpen {
+		return amendment, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_REPORT_AMENDMENT_NOT_OPEN,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	return amendment, nil
+}
+
+func (reportAmendmentService *ReportAmendmentService) getReportAmendmentValues(taskId uint) (
+	[]structures.ReportAmendmentValue, *commonStructures.CommonError) {
+
+	investigations, cErr := reportAmendmentService.InvestigationResultsService.GetInvestigationResultModelsByTaskId(
+		taskId)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	investigationIds := []uint{}
+	for _, investigation := range investigations {
+		investigationIds = append(investigationIds, investigation.Id)
+	}
+
+	investigationsData, cErr := reportAmendmentService.InvestigationResultsService.
+		GetInvestigationDataByInvestigationResultsIds(investigationIds)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	return mapper.MapReportAmendmentValues(investigations, investigationsData), nil
+}
+
+func (reportAmendmentService *ReportAmendmentService) getCurrentReportVersion(taskId uint) (
+	uint, *commonStructures.CommonError) {
+
+	amendment, cErr := reportAmendmentService.ReportAmendmentDao.GetLatestApprovedReportAmendmentByTaskId(taskId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			return commonConstants.ReportAmendmentInitialReportVersion, nil
+		}
+		return 0, cErr
+	}
+
+	return amendment.ReportVersion, nil
+}
+
+func (reportAmendmentService *ReportAmendmentService) validateApprover(userId uint) *commonStructures.CommonError {
+	user, cErr := reportAmendmentService.UserService.GetUserModel(userId)
+	if cErr != nil {
+		return cErr
+	}
+
+	if !commonUtils.SliceContainsString(
+		[]string{commonConstants.USER_TYPE_PATHOLOGIST, commonConstants.USER_TYPE_SUPER_ADMIN}, user.UserType) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_REPORT_AMENDMENT_APPROVER,
+			StatusCode: http.StatusForbidden,
+		}
+	}
+
+	return nil
+}
+
+func validateReportAmendmentRequest(amendmentRequest structures.ReportAmendmentRequest) *commonStructures.CommonError {
+	if !commonUtils.SliceContainsString(commonConstants.ReportAmendmentReasonCodes, amendmentRequest.ReasonCode) {
+		return &commonStructures.CommonError{
+			Message: fmt.Sprintf(commonConstants.ERROR_INVALID_REPORT_AMENDMENT_REASON_CODE,
+				strings.Join(commonConstants.ReportAmendmentReasonCodes, ", ")),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if strings.TrimSpace(amendmentRequest.Justification) == "" {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_REPORT_AMENDMENT_JUSTIFICATION,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
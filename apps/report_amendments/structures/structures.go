@@ -0,0 +1,81 @@
+package structures
+
+import "time"
+
+// @swagger:model ReportAmendment
+type ReportAmendment struct {
+	// The report amendment ID.
+	// example: 1
+	Id uint `json:"id"`
+	// The task whose report is amended.
+	// example: 101
+	TaskId uint `json:"task_id"`
+	// The OMS order ID of the task.
+	// example: "OH1234"
+	OmsOrderId string `json:"oms_order_id"`
+	// The status of the amendment, one of open, approved and cancelled.
+	// example: "approved"
+	Status string `json:"status"`
+	// The reason the report is amended.
+	// example: "transcription_error"
+	ReasonCode string `json:"reason_code"`
+	// The free text justification for the amendment.
+	// example: "Hemoglobin was entered as 1.3 instead of 13"
+	Justification string `json:"justification"`
+	// The version of the report released with the amendment. 0 till the amendment is approved.
+	// example: 2
+	ReportVersion uint `json:"report_version"`
+	// The user who opened the amendment.
+	// example: 12
+	RequestedBy uint `json:"requested_by"`
+	// The pathologist who approved the amendment.
+	// example: 7
+	ApprovedBy uint `json:"approved_by"`
+	// The time the amendment was approved.
+	// example: "2026-10-18T10:00:00Z"
+	ApprovedAt *time.Time `json:"approved_at"`
+	// The changes made to the investigations between the released report and the amended report.
+	InvestigationDiff []ReportAmendmentDiff `json:"investigation_diff"`
+	// The time the amendment was opened.
+	// example: "2026-10-18T09:00:00Z"
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// @swagger:model ReportAmendmentDiff
+type ReportAmendmentDiff struct {
+	// The investigation result ID.
+	// example: 1001
+	InvestigationResultId uint `json:"investigation_result_id"`
+	// The name of the investigation.
+	// example: "Hemoglobin"
+	InvestigationName string `json:"investigation_name"`
+	// The LIS code of the investigation.
+	// example: "HB"
+	LisCode string `json:"lis_code"`
+	// The value on the released report.
+	// example: "1.3"
+	PreviousValue string `json:"previous_value"`
+	// The value on the amended report.
+	// example: "13"
+	AmendedValue string `json:"amended_value"`
+	// The abnormality on the released report.
+	// example: "L"
+	PreviousAbnormality string `json:"previous_abnormality"`
+	// The abnormality on the amended report.
+	// example: ""
+	AmendedAbnormality string `json:"amended_abnormality"`
+}
+
+// ReportAmendmentValue is the value of an investigation captured when the amendment is opened.
+type ReportAmendmentValue struct {
+	InvestigationResultId uint   `json:"investigation_result_id"`
+	InvestigationName     string `json:"investigation_name"`
+	LisCode               string `json:"lis_code"`
+	Value                 string `json:"value"`
+	Abnormality           string `json:"abnormality"`
+}
+
+type ReportAmendmentRequest struct {
+	ReasonCode    string `json:"reason_code" binding:"required"`
+	Justification string `json:"justification"`
+}
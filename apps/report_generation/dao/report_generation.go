@@ -3,6 +3,8 @@ package dao
 import (
 	"context"
 
+	"gorm.io/gorm"
+
 	"github.com/Orange-Health/citadel/apps/report_generation/structures"
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
@@ -11,6 +13,7 @@ import (
 )
 
 type DataLayer interface {
+	BeginTransaction() *gorm.DB
 	GetInvestigationDataForReportGeneration(ctx context.Context, omsOrderId string,
 		omsTestIds []string) ([]structures.InvestigationEvent, *commonStructures.CommonError)
 	GetUserDetails(ctx context.Context, userIds []string) ([]commonModels.User, *commonStructures.CommonError)
@@ -51,6 +54,10 @@ func getSelectQueryForReportGenerationData() []string {
 	}
 }
 
+func (dao *ReportGenerationDao) BeginTransaction() *gorm.DB {
+	return dao.Db.Begin()
+}
+
 func (dao *ReportGenerationDao) GetInvestigationDataForReportGeneration(ctx context.Context, orderId string,
 	omsTestIds []string) ([]structures.InvestigationEvent, *commonStructures.CommonError) {
 
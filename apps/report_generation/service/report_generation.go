@@ -2,13 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/Orange-Health/citadel/apps/report_generation/constants"
 	"github.com/Orange-Health/citadel/apps/report_generation/structures"
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
@@ -19,12 +21,35 @@ import (
 
 type ReportGenerationInterface interface {
 	TriggerReportGenerationEvent(ctx context.Context, omsOrderId string, omsTestIds []string) *commonStructures.CommonError
+	TriggerReportGenerationEventWithTx(ctx context.Context, tx *gorm.DB, omsOrderId string,
+		omsTestIds []string) *commonStructures.CommonError
 	TriggerOrderApprovedEvent(ctx context.Context, omsOrderId string, isDummyReport bool) (interface{}, *commonStructures.CommonError)
 }
 
 func (s *ReportGenerationService) TriggerReportGenerationEvent(ctx context.Context, omsOrderId string,
 	omsTestIds []string) *commonStructures.CommonError {
 
+	tx := s.Dao.BeginTransaction()
+	defer tx.Rollback()
+
+	cErr := s.TriggerReportGenerationEventWithTx(ctx, tx, omsOrderId, omsTestIds)
+	if cErr != nil && cErr.Message != commonConstants.ERROR_NO_INHOUSE_VISIT_TESTS_FOUND {
+		return cErr
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return cErr
+}
+
+// TriggerReportGenerationEventWithTx writes the report generation events of the order on the outbox in tx.
+// When none of the tests are in in-house visits, only the event for all the visits is written and
+// ERROR_NO_INHOUSE_VISIT_TESTS_FOUND is returned, so the callers still commit tx for that error.
+func (s *ReportGenerationService) TriggerReportGenerationEventWithTx(ctx context.Context, tx *gorm.DB,
+	omsOrderId string, omsTestIds []string) *commonStructures.CommonError {
+
 	orderDetails, cErr := s.OrderDetailsService.GetOrderDetailsByOmsOrderId(omsOrderId)
 	if cErr != nil {
 		return cErr
@@ -46,7 +71,12 @@ func (s *ReportGenerationService) TriggerReportGenerationEvent(ctx context.Conte
 		return err
 	}
 	reportGenerationEventAllVisits.ServicingCityCode = orderDetails.CityCode
-	go s.GetAndPublishReportGenerationEventForAllVisits(context.Background(), orderDetails, reportGenerationEventAllVisits, attachments, false)
+	_, cErr = s.GetAndPublishReportGenerationEventForAllVisitsWithTx(ctx, tx, orderDetails,
+		reportGenerationEventAllVisits, attachments, false)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
+			errors.New(cErr.Message))
+	}
 
 	if len(reportGenerationEvent.Visits) == 0 {
 		return &commonStructures.CommonError{
@@ -65,6 +95,10 @@ func (s *ReportGenerationService) TriggerReportGenerationEvent(ctx context.Conte
 	if cErr != nil {
 		return cErr
 	}
+	cErr = s.attachCultureResults(investigations)
+	if cErr != nil {
+		return cErr
+	}
 	attachmentsMap := make(map[uint][]string)
 	for _, attachment := range attachments {
 		if attachment.AttachmentType == commonConstants.AttachmentTypeTestDocument && attachment.InvestigationResultId > 0 {
@@ -120,7 +154,7 @@ func (s *ReportGenerationService) TriggerReportGenerationEvent(ctx context.Conte
 			Message:    commonConstants.ERROR_NO_REPORT_GENERATION_EVENT_FOUND,
 		}
 	}
-	cErr = s.SnsClient.PublishTo(ctx, messageBody, messageAttributes, fmt.Sprint(omsOrderId),
+	cErr = s.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes, fmt.Sprint(omsOrderId),
 		commonConstants.OrderReportUpdateTopicArn, "")
 	if cErr != nil {
 		return cErr
@@ -172,19 +206,66 @@ func (s *ReportGenerationService) fetchReportGenerationEventDetails(ctx context.
 		return reportGenerationEvent, reportGenerationEventAllVisits, cErrList[0]
 	}
 
+	reportVersion, amendmentEvent, cErr := s.fetchReportAmendmentEventDetails(ctx, orderDetails.OmsOrderId)
+	if cErr != nil {
+		return reportGenerationEvent, reportGenerationEventAllVisits, cErr
+	}
+
 	reportGenerationEvent = structures.ReportGenerationEvent{
-		Order:  orderEvent,
-		Visits: visits,
+		Order:         orderEvent,
+		Visits:        visits,
+		ReportVersion: reportVersion,
+		IsAmended:     amendmentEvent != nil,
+		Amendment:     amendmentEvent,
 	}
 
 	reportGenerationEventAllVisits = structures.ReportGenerationEvent{
-		Order:  orderEvent,
-		Visits: allVisits,
+		Order:         orderEvent,
+		Visits:        allVisits,
+		ReportVersion: reportVersion,
+		IsAmended:     amendmentEvent != nil,
+		Amendment:     amendmentEvent,
 	}
 
 	return reportGenerationEvent, reportGenerationEventAllVisits, nil
 }
 
+// fetchReportAmendmentEventDetails returns the version of the report along with the amendment note when the report
+// of the order has been amended.
+func (s *ReportGenerationService) fetchReportAmendmentEventDetails(ctx context.Context, omsOrderId string) (
+	uint, *structures.ReportAmendmentEvent, *commonStructures.CommonError) {
+
+	amendment, cErr := s.ReportAmendmentService.GetLatestApprovedReportAmendmentByOmsOrderId(omsOrderId)
+	if cErr != nil {
+		return 0, nil, cErr
+	}
+	if amendment.Id == 0 {
+		return commonConstants.ReportAmendmentInitialReportVersion, nil, nil
+	}
+
+	approvedBy := constants.DEFAULT_SYSTEM_USER_ID
+	users, cErr := s.Dao.GetUserDetails(ctx, []string{fmt.Sprint(amendment.ApprovedBy)})
+	if cErr != nil {
+		return 0, nil, cErr
+	}
+	if len(users) > 0 && users[0].SystemUserId != "" {
+		approvedBy = users[0].SystemUserId
+	}
+
+	approvedAt := ""
+	if amendment.ApprovedAt != nil {
+		approvedAt = amendment.ApprovedAt.Format(commonConstants.DateTimeUTCLayoutWithoutTZOffset)
+	}
+
+	return amendment.ReportVersion, &structures.ReportAmendmentEvent{
+		ReasonCode:            amendment.ReasonCode,
+		Justification:         amendment.Justification,
+		ApprovedBy:            approvedBy,
+		ApprovedAt:            approvedAt,
+		PreviousReportVersion: amendment.ReportVersion - 1,
+	}, nil
+}
+
 func (s *ReportGenerationService) fetchAttachmentDetails(omsOrderId string) ([]commonModels.Attachment, *commonStructures.CommonError) {
 	task, cErr := s.TaskService.GetTaskByOmsOrderId(omsOrderId)
 	if cErr != nil {
@@ -404,8 +485,8 @@ func (s *ReportGenerationService) mapInvestigationsAndTests(omsOrderId string, c
 		investigation.OrderId = omsOrderId
 		if investigation.Data != "" {
 			investigation.Value = investigation.Data
-			investigation.Data = ""
 		}
+		investigation.Data = ""
 		investigationsMap[investigation.TestId] = append(investigationsMap[investigation.TestId], investigation)
 	}
 
@@ -416,7 +497,7 @@ func (s *ReportGenerationService) mapInvestigationsAndTests(omsOrderId string, c
 				reportGenerationEvent.Visits[visitIndex].Tests[testIndex].Investigations = investigations
 				testApprovedAt := s.GetTestApprovedAt(test.ApprovedAt, investigations[0].ApprovedAt)
 				testApprovedBy := investigations[0].ApprovedBy
-				reportReleasedBy := getDutyDoctorUserId(investigations[0].ApprovedAt, cityCode)
+				reportReleasedBy := s.getDutyDoctorUserId(investigations[0].ApprovedAt, cityCode, test.LabId)
 				reportGenerationEvent.Visits[visitIndex].Tests[testIndex].ApprovedAt = testApprovedAt
 				reportGenerationEvent.Visits[visitIndex].Tests[testIndex].ReportReleasedAt = testApprovedAt
 				reportGenerationEvent.Visits[visitIndex].Tests[testIndex].ApprovedBy = testApprovedBy
@@ -426,72 +507,54 @@ func (s *ReportGenerationService) mapInvestigationsAndTests(omsOrderId string, c
 	}
 }
 
-func getDutyDoctorUserId(approvedAt *time.Time, cityCode string) int {
-	dutyDoctorMap := commonConstants.DutyDoctorMap
+// attachCultureResults adds the structured culture result to culture investigations. Their investigation data is
+// still sent as the value, for the renderers that do not read the structured result.
+func (s *ReportGenerationService) attachCultureResults(
+	investigations []structures.InvestigationEvent) *commonStructures.CommonError {
 
-	if approvedAt == nil {
-		return 0
+	investigationResultIds := []uint{}
+	for _, investigation := range investigations {
+		investigationResultIds = append(investigationResultIds, investigation.Id)
 	}
 
-	istLocation, _ := time.LoadLocation(commonConstants.LocalTimeZoneLocation)
-	approvedTime := approvedAt.In(istLocation)
-	approvedDayStr := strings.ToLower(approvedTime.Weekday().String())
-
-	dutyDoctor := dutyDoctorMap[approvedDayStr].(map[string]interface{})
-	dutyDoctorPreviousDay :=
-		dutyDoctorMap[strings.ToLower(approvedTime.AddDate(0, 0, -1).Weekday().String())].(map[string]interface{})
-
-	approvedDayStartTime := dutyDoctor["shift_1"].(map[string]interface{})["start_time"].(string)
-	startTime, _ := time.Parse(commonConstants.TimeLayout, approvedDayStartTime)
-
-	if approvedTime.Hour() > 00 && approvedTime.Hour() < startTime.Hour() {
-		dutyDoctor = dutyDoctorPreviousDay
+	investigationResultIdToCultureResultMap, cErr := s.CultureResultsService.GetCultureResultsByInvestigationResultIds(
+		investigationResultIds)
+	if cErr != nil {
+		return cErr
 	}
 
-	for _, shift := range dutyDoctor {
-		doctorUserId := getDoctorUserId(shift, approvedTime, cityCode)
-		if doctorUserId != "" {
-			intDoctorUserId, _ := strconv.Atoi(doctorUserId)
-			return intDoctorUserId
+	for index := range investigations {
+		if cultureResult, ok := investigationResultIdToCultureResultMap[investigations[index].Id]; ok {
+			investigations[index].CultureResult = &cultureResult
 		}
 	}
-
-	return 0
+	return nil
 }
 
-func getDoctorUserId(shift interface{}, approvedTime time.Time, cityCode string) string {
-	shiftMap := shift.(map[string]interface{})
-	startTimeStr := shiftMap["start_time"].(string)
-	endTimeStr := shiftMap["end_time"].(string)
-	doctorNameMap := shiftMap["doctor"].(map[string]interface{})
-	startTime, _ := time.Parse(commonConstants.TimeLayout, startTimeStr)
-	endTime, _ := time.Parse(commonConstants.TimeLayout, endTimeStr)
-
-	startTime = time.Date(approvedTime.Year(), approvedTime.Month(), approvedTime.Day(), startTime.Hour(),
-		startTime.Minute(), startTime.Second(), startTime.Nanosecond(), approvedTime.Location())
-	endTime = time.Date(approvedTime.Year(), approvedTime.Month(), approvedTime.Day(), endTime.Hour(), endTime.Minute(),
-		endTime.Second(), endTime.Nanosecond(), approvedTime.Location())
-	approvedTime = time.Date(approvedTime.Year(), approvedTime.Month(), approvedTime.Day(), approvedTime.Hour(),
-		approvedTime.Minute(), approvedTime.Second(), 0, approvedTime.Location())
-
-	if endTime.Before(startTime) {
-		endTime = endTime.AddDate(0, 0, +1)
+// getDutyDoctorUserId finds the system user id of the doctor on the roster when a test was approved, so that
+// the report is released under their name. A roster without a doctor on duty at that time is a gap in its
+// coverage, it is logged as an error and the report is released without a duty doctor.
+func (s *ReportGenerationService) getDutyDoctorUserId(approvedAt *time.Time, cityCode string, labId uint) int {
+	if approvedAt == nil {
+		return 0
 	}
 
-	commonUtils.AddLog(context.Background(), commonConstants.DEBUG_LEVEL, commonUtils.GetCurrentFunctionName(),
-		map[string]interface{}{
-			"start_time":    startTime.String(),
-			"end_time":      endTime.String(),
-			"approved_time": approvedTime.String(),
-		}, nil)
-
-	if (approvedTime.After(startTime) || approvedTime.Equal(startTime)) && approvedTime.Before(endTime) {
-		doctorUserId := doctorNameMap[strings.ToLower(cityCode)]
-		if doctorUserId != nil {
-			return fmt.Sprint(doctorUserId)
-		}
+	onDutyDoctor, cErr := s.RosterService.GetOnDutyDoctor(cityCode, labId, *approvedAt)
+	if cErr != nil {
+		message := commonUtils.GetCurrentFunctionName()
+		if cErr.StatusCode == http.StatusNotFound {
+			message = commonConstants.ERROR_ROSTER_COVERAGE_GAP
+		}
+		commonUtils.AddLog(context.Background(), commonConstants.ERROR_LEVEL, message, map[string]interface{}{
+			"city_code":   cityCode,
+			"lab_id":      labId,
+			"approved_at": approvedAt.String(),
+		}, errors.New(cErr.Message))
+		return 0
 	}
-	return ""
+
+	dutyDoctorUserId, _ := strconv.Atoi(onDutyDoctor.SystemUserId)
+	return dutyDoctorUserId
 }
 
 func rearrangeTestDetailsForIndexPage(testDetails []commonModels.TestDetail) []commonModels.TestDetail {
@@ -669,15 +732,24 @@ func (s *ReportGenerationService) TriggerOrderApprovedEvent(ctx context.Context,
 	if cErr != nil {
 		return nil, cErr
 	}
-	payload, cErr = s.GetAndPublishReportGenerationEventForAllVisits(ctx, orderDetails, reportGenerationEventAllVisits, attachments, isDummyReport)
+
+	tx := s.Dao.BeginTransaction()
+	defer tx.Rollback()
+
+	payload, cErr = s.GetAndPublishReportGenerationEventForAllVisitsWithTx(ctx, tx, orderDetails,
+		reportGenerationEventAllVisits, attachments, isDummyReport)
 	if cErr != nil {
 		return nil, cErr
 	}
 
+	if err := tx.Commit().Error; err != nil {
+		return nil, commonUtils.HandleORMError(err)
+	}
+
 	return payload, nil
 }
 
-func (s *ReportGenerationService) GetAndPublishReportGenerationEventForAllVisits(ctx context.Context,
+func (s *ReportGenerationService) GetAndPublishReportGenerationEventForAllVisitsWithTx(ctx context.Context, tx *gorm.DB,
 	orderDetails commonModels.OrderDetails, reportGenerationEvent structures.ReportGenerationEvent, attachments []commonModels.Attachment, isDummyReport bool) (interface{}, *commonStructures.CommonError) {
 	omsTestIds := s.fetchTestIdsFromReportGenerationEvent(reportGenerationEvent)
 
@@ -685,6 +757,10 @@ func (s *ReportGenerationService) GetAndPublishReportGenerationEventForAllVisits
 	if cErr != nil {
 		return nil, cErr
 	}
+	cErr = s.attachCultureResults(investigations)
+	if cErr != nil {
+		return nil, cErr
+	}
 
 	attachmentsMap := make(map[uint][]string)
 	for _, attachment := range attachments {
@@ -738,15 +814,15 @@ func (s *ReportGenerationService) GetAndPublishReportGenerationEventForAllVisits
 	}
 
 	if isDummyReport {
-		cErr = s.SnsClient.PublishTo(ctx, messageBody, messageAttributes, fmt.Sprint(orderDetails.OmsOrderId),
-			commonConstants.OrderReportUpdateTopicArn, "")
+		cErr = s.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes,
+			fmt.Sprint(orderDetails.OmsOrderId), commonConstants.OrderReportUpdateTopicArn, "")
 		if cErr != nil {
 			return nil, cErr
 		}
 		return messageBody, nil
 	}
-	cErr = s.SnsClient.PublishTo(ctx, messageBody, messageAttributes, fmt.Sprint(orderDetails.OmsOrderId),
-		commonConstants.OrderResultsApprovedTopicArn, "")
+	cErr = s.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes,
+		fmt.Sprint(orderDetails.OmsOrderId), commonConstants.OrderResultsApprovedTopicArn, "")
 	if cErr != nil {
 		return nil, cErr
 	}
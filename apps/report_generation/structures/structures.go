@@ -4,14 +4,28 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	cultureResultsStructures "github.com/Orange-Health/citadel/apps/culture_results/structures"
 )
 
 type ReportGenerationEvent struct {
-	Order             OrderEvent         `json:"order"`
-	Visits            []VisitEvent       `json:"visits"`
-	IndexDetails      ReportIndexDetails `json:"index_details"`
-	ServicingCityCode string             `json:"servicing_city_code"`
-	IsDummyReport     bool               `json:"is_dummy_report"`
+	Order             OrderEvent            `json:"order"`
+	Visits            []VisitEvent          `json:"visits"`
+	IndexDetails      ReportIndexDetails    `json:"index_details"`
+	ServicingCityCode string                `json:"servicing_city_code"`
+	IsDummyReport     bool                  `json:"is_dummy_report"`
+	ReportVersion     uint                  `json:"report_version"`
+	IsAmended         bool                  `json:"is_amended"`
+	Amendment         *ReportAmendmentEvent `json:"amendment,omitempty"`
+}
+
+// ReportAmendmentEvent carries the amendment note printed on an amended report.
+type ReportAmendmentEvent struct {
+	ReasonCode            string `json:"reason_code"`
+	Justification         string `json:"justification"`
+	ApprovedBy            string `json:"approved_by"`
+	ApprovedAt            string `json:"approved_at"`
+	PreviousReportVersion uint   `json:"previous_report_version"`
 }
 
 type OrderEvent struct {
@@ -83,6 +97,8 @@ type InvestigationEvent struct {
 	CreatedAt                string     `json:"created_at"`
 	UpdatedAt                string     `json:"updated_at"`
 	TestDocument             []string   `json:"test_document,omitempty" gorm:"-"`
+
+	CultureResult *cultureResultsStructures.CultureResult `json:"culture_result,omitempty" gorm:"-"`
 }
 
 type VisitTestsStruct struct {
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/roster/service"
+)
+
+type Roster struct {
+	RosterService service.RosterServiceInterface
+}
+
+func InitRosterController() *Roster {
+	return &Roster{
+		RosterService: service.InitializeRosterService(),
+	}
+}
@@ -0,0 +1,279 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/roster/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructs "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Roster Shifts
+// @Description	Get the weekly shifts of a city, along with the ones of a lab when a lab is passed
+// @Tags			rosters
+// @Produce		json
+// @Param			city_code	query		string							true	"City Code"
+// @Param			lab_id		query		int								false	"Lab ID"
+// @Param			weekday		query		string							false	"Weekday"
+// @Success		200			{object}	[]structures.RosterShift		"Roster Shifts"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/rosters/shifts [get]
+func (rosterController *Roster) GetRosterShifts(c *gin.Context) {
+	shifts, cErr := rosterController.RosterService.GetRosterShifts(c.Query("city_code"),
+		commonUtils.ConvertStringToUint(c.Query("lab_id")), c.Query("weekday"))
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// @Summary		Create Roster Shift
+// @Description	Put a doctor on duty for a window of a weekday. A shift whose end time is not after its start
+// @Description	time runs into the next day.
+// @Tags			rosters
+// @Accept			json
+// @Produce		json
+// @Param			shift			body		structures.RosterShiftRequest	true	"Shift"
+// @Success		201				{object}	structures.RosterShift			"Roster Shift"
+// @Failure		400,409,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/rosters/shifts [post]
+func (rosterController *Roster) CreateRosterShift(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	shiftRequest := structures.RosterShiftRequest{}
+	if err := c.ShouldBindJSON(&shiftRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shift, cErr := rosterController.RosterService.CreateRosterShift(shiftRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusCreated, shift)
+}
+
+// @Summary		Update Roster Shift
+// @Description	Update the window or the doctor of a shift
+// @Tags			rosters
+// @Accept			json
+// @Produce		json
+// @Param			shiftId			path		int								true	"Shift ID"
+// @Param			shift			body		structures.RosterShiftRequest	true	"Shift"
+// @Success		200				{object}	structures.RosterShift			"Roster Shift"
+// @Failure		400,404,409,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/rosters/shifts/{shiftId} [put]
+func (rosterController *Roster) UpdateRosterShift(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	shiftId := commonUtils.ConvertStringToUint(c.Param("shiftId"))
+	if shiftId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_ROSTER_SHIFT_ID)
+		return
+	}
+
+	shiftRequest := structures.RosterShiftRequest{}
+	if err := c.ShouldBindJSON(&shiftRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shift, cErr := rosterController.RosterService.UpdateRosterShift(shiftId, shiftRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, shift)
+}
+
+// @Summary		Delete Roster Shift
+// @Description	Delete a shift from the roster
+// @Tags			rosters
+// @Produce		json
+// @Param			shiftId		path		int								true	"Shift ID"
+// @Success		200			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/rosters/shifts/{shiftId} [delete]
+func (rosterController *Roster) DeleteRosterShift(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	shiftId := commonUtils.ConvertStringToUint(c.Param("shiftId"))
+	if shiftId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_ROSTER_SHIFT_ID)
+		return
+	}
+
+	cErr = rosterController.RosterService.DeleteRosterShift(shiftId, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructs.CommonAPIResponse{
+		Message: commonConstants.DONE_RESPONSE,
+	})
+}
+
+// @Summary		Get Roster Overrides
+// @Description	Get the date specific overrides of a city, along with the ones of a lab when a lab is passed
+// @Tags			rosters
+// @Produce		json
+// @Param			city_code	query		string							true	"City Code"
+// @Param			lab_id		query		int								false	"Lab ID"
+// @Param			from_date	query		string							false	"From Date (YYYY-MM-DD)"
+// @Param			to_date		query		string							false	"To Date (YYYY-MM-DD)"
+// @Success		200			{object}	[]structures.RosterOverride		"Roster Overrides"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/rosters/overrides [get]
+func (rosterController *Roster) GetRosterOverrides(c *gin.Context) {
+	filter := structures.RosterOverridesFilter{
+		CityCode: c.Query("city_code"),
+		LabId:    commonUtils.ConvertStringToUint(c.Query("lab_id")),
+		FromDate: c.Query("from_date"),
+		ToDate:   c.Query("to_date"),
+	}
+
+	overrides, cErr := rosterController.RosterService.GetRosterOverrides(filter)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, overrides)
+}
+
+// @Summary		Create Roster Override
+// @Description	Hand a window of a date to a doctor for a leave, a holiday or a swap. Overrides take precedence
+// @Description	over the weekly shifts.
+// @Tags			rosters
+// @Accept			json
+// @Produce		json
+// @Param			override		body		structures.RosterOverrideRequest	true	"Override"
+// @Success		201				{object}	structures.RosterOverride			"Roster Override"
+// @Failure		400,409,500		{object}	structures.CommonAPIResponse		"Common API Response"
+// @Router			/api/v1/rosters/overrides [post]
+func (rosterController *Roster) CreateRosterOverride(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	overrideRequest := structures.RosterOverrideRequest{}
+	if err := c.ShouldBindJSON(&overrideRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	override, cErr := rosterController.RosterService.CreateRosterOverride(overrideRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusCreated, override)
+}
+
+// @Summary		Delete Roster Override
+// @Description	Delete an override, putting the weekly shifts back in effect for its window
+// @Tags			rosters
+// @Produce		json
+// @Param			overrideId	path		int								true	"Override ID"
+// @Success		200			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/rosters/overrides/{overrideId} [delete]
+func (rosterController *Roster) DeleteRosterOverride(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	overrideId := commonUtils.ConvertStringToUint(c.Param("overrideId"))
+	if overrideId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_ROSTER_OVERRIDE_ID)
+		return
+	}
+
+	cErr = rosterController.RosterService.DeleteRosterOverride(overrideId, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructs.CommonAPIResponse{
+		Message: commonConstants.DONE_RESPONSE,
+	})
+}
+
+// @Summary		Get Roster Coverage
+// @Description	Get the windows of the week in which nobody is on duty for a city or a lab
+// @Tags			rosters
+// @Produce		json
+// @Param			city_code	query		string							true	"City Code"
+// @Param			lab_id		query		int								false	"Lab ID"
+// @Success		200			{object}	structures.RosterCoverage		"Roster Coverage"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/rosters/coverage [get]
+func (rosterController *Roster) GetRosterCoverage(c *gin.Context) {
+	coverage, cErr := rosterController.RosterService.GetRosterCoverage(c.Query("city_code"),
+		commonUtils.ConvertStringToUint(c.Query("lab_id")))
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, coverage)
+}
+
+// @Summary		Get On Duty Doctor
+// @Description	Get the doctor on duty for a city or a lab, right now or at the time passed
+// @Tags			rosters
+// @Produce		json
+// @Param			city_code	query		string							true	"City Code"
+// @Param			lab_id		query		int								false	"Lab ID"
+// @Param			at			query		string							false	"Time (RFC 3339)"
+// @Success		200			{object}	structures.OnDutyDoctor			"On Duty Doctor"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/rosters/on-duty [get]
+func (rosterController *Roster) GetOnDutyDoctor(c *gin.Context) {
+	at := time.Now()
+	if c.Query("at") != "" {
+		parsedAt, err := time.Parse(commonConstants.DateTimeUTCLayout, c.Query("at"))
+		if err != nil {
+			commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_ON_DUTY_AT)
+			return
+		}
+		at = parsedAt
+	}
+
+	onDutyDoctor, cErr := rosterController.RosterService.GetOnDutyDoctor(c.Query("city_code"),
+		commonUtils.ConvertStringToUint(c.Query("lab_id")), at)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, onDutyDoctor)
+}
@@ -0,0 +1,163 @@
+package dao
+
+import (
+	"github.com/Orange-Health/citadel/apps/roster/structures"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetRosterShifts(cityCode string, labId uint, weekdays []string) (
+		[]commonModels.RosterShift, *commonStructures.CommonError)
+	GetRosterShiftById(shiftId uint) (commonModels.RosterShift, *commonStructures.CommonError)
+	GetRosterOverrides(filter structures.RosterOverridesFilter) (
+		[]commonModels.RosterOverride, *commonStructures.CommonError)
+	GetRosterOverrideById(overrideId uint) (commonModels.RosterOverride, *commonStructures.CommonError)
+	GetRosterLocations() ([]structures.RosterLocationDbStruct, *commonStructures.CommonError)
+
+	CreateRosterShift(shift commonModels.RosterShift) (commonModels.RosterShift, *commonStructures.CommonError)
+	UpdateRosterShift(shift commonModels.RosterShift) (commonModels.RosterShift, *commonStructures.CommonError)
+	DeleteRosterShift(shiftId, userId uint) *commonStructures.CommonError
+	CreateRosterOverride(override commonModels.RosterOverride) (
+		commonModels.RosterOverride, *commonStructures.CommonError)
+	DeleteRosterOverride(overrideId, userId uint) *commonStructures.CommonError
+}
+
+// getLabIds returns the labs whose roster applies to a lab, the city wide roster being lab 0.
+func getLabIds(labId uint) []uint {
+	if labId == 0 {
+		return []uint{0}
+	}
+	return []uint{0, labId}
+}
+
+func (rosterDao *RosterDao) GetRosterShifts(cityCode string, labId uint, weekdays []string) (
+	[]commonModels.RosterShift, *commonStructures.CommonError) {
+
+	shifts := []commonModels.RosterShift{}
+	query := rosterDao.Db.Where("city_code = ? AND lab_id IN ?", cityCode, getLabIds(labId))
+	if len(weekdays) > 0 {
+		query = query.Where("weekday IN ?", weekdays)
+	}
+	if err := query.Order("lab_id DESC, start_time, id").Find(&shifts).Error; err != nil {
+		return shifts, commonUtils.HandleORMError(err)
+	}
+
+	return shifts, nil
+}
+
+// GetRosterLocations gets the cities and labs that have shifts or overrides on their roster.
+func (rosterDao *RosterDao) GetRosterLocations() ([]structures.RosterLocationDbStruct,
+	*commonStructures.CommonError) {
+
+	rosterLocations := []structures.RosterLocationDbStruct{}
+	err := rosterDao.Db.Raw("SELECT city_code, lab_id FROM roster_shifts WHERE deleted_at IS NULL " +
+		"UNION SELECT city_code, lab_id FROM roster_overrides WHERE deleted_at IS NULL").
+		Scan(&rosterLocations).Error
+	if err != nil {
+		return rosterLocations, commonUtils.HandleORMError(err)
+	}
+
+	return rosterLocations, nil
+}
+
+func (rosterDao *RosterDao) GetRosterShiftById(shiftId uint) (commonModels.RosterShift, *commonStructures.CommonError) {
+	shift := commonModels.RosterShift{}
+	if err := rosterDao.Db.Where("id = ?", shiftId).First(&shift).Error; err != nil {
+		return shift, commonUtils.HandleORMError(err)
+	}
+
+	return shift, nil
+}
+
+func (rosterDao *RosterDao) GetRosterOverrides(filter structures.RosterOverridesFilter) (
+	[]commonModels.RosterOverride, *commonStructures.CommonError) {
+
+	overrides := []commonModels.RosterOverride{}
+	query := rosterDao.Db.Where("city_code = ? AND lab_id IN ?", filter.CityCode, getLabIds(filter.LabId))
+	if filter.FromDate != "" {
+		query = query.Where("override_date >= ?", filter.FromDate)
+	}
+	if filter.ToDate != "" {
+		query = query.Where("override_date <= ?", filter.ToDate)
+	}
+	if err := query.Order("override_date, lab_id DESC, start_time, id").Find(&overrides).Error; err != nil {
+		return overrides, commonUtils.HandleORMError(err)
+	}
+
+	return overrides, nil
+}
+
+func (rosterDao *RosterDao) GetRosterOverrideById(overrideId uint) (
+	commonModels.RosterOverride, *commonStructures.CommonError) {
+
+	override := commonModels.RosterOverride{}
+	if err := rosterDao.Db.Where("id = ?", overrideId).First(&override).Error; err != nil {
+		return override, commonUtils.HandleORMError(err)
+	}
+
+	return override, nil
+}
+
+func (rosterDao *RosterDao) CreateRosterShift(shift commonModels.RosterShift) (
+	commonModels.RosterShift, *commonStructures.CommonError) {
+
+	if err := rosterDao.Db.Create(&shift).Error; err != nil {
+		return shift, commonUtils.HandleORMError(err)
+	}
+
+	return shift, nil
+}
+
+func (rosterDao *RosterDao) UpdateRosterShift(shift commonModels.RosterShift) (
+	commonModels.RosterShift, *commonStructures.CommonError) {
+
+	if err := rosterDao.Db.Save(&shift).Error; err != nil {
+		return shift, commonUtils.HandleORMError(err)
+	}
+
+	return shift, nil
+}
+
+func (rosterDao *RosterDao) DeleteRosterShift(shiftId, userId uint) *commonStructures.CommonError {
+	updates := map[string]interface{}{
+		"updated_by": userId,
+		"updated_at": commonUtils.GetCurrentTime(),
+		"deleted_by": userId,
+		"deleted_at": commonUtils.GetCurrentTime(),
+	}
+
+	if err := rosterDao.Db.Model(&commonModels.RosterShift{}).Where("id = ?", shiftId).
+		Updates(updates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+func (rosterDao *RosterDao) CreateRosterOverride(override commonModels.RosterOverride) (
+	commonModels.RosterOverride, *commonStructures.CommonError) {
+
+	if err := rosterDao.Db.Create(&override).Error; err != nil {
+		return override, commonUtils.HandleORMError(err)
+	}
+
+	return override, nil
+}
+
+func (rosterDao *RosterDao) DeleteRosterOverride(overrideId, userId uint) *commonStructures.CommonError {
+	updates := map[string]interface{}{
+		"updated_by": userId,
+		"updated_at": commonUtils.GetCurrentTime(),
+		"deleted_by": userId,
+		"deleted_at": commonUtils.GetCurrentTime(),
+	}
+
+	if err := rosterDao.Db.Model(&commonModels.RosterOverride{}).Where("id = ?", overrideId).
+		Updates(updates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
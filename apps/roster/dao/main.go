@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type RosterDao struct {
+	Db *gorm.DB
+}
+
+func InitializeRosterDao() DataLayer {
+	return &RosterDao{
+		Db: psql.GetDbInstance(),
+	}
+}
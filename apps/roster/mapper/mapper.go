@@ -0,0 +1,93 @@
+package mapper
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/roster/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapRosterShift(shift commonModels.RosterShift) structures.RosterShift {
+	return structures.RosterShift{
+		Id:        shift.Id,
+		CityCode:  shift.CityCode,
+		LabId:     shift.LabId,
+		Weekday:   shift.Weekday,
+		StartTime: shift.StartTime,
+		EndTime:   shift.EndTime,
+		UserId:    shift.UserId,
+	}
+}
+
+func MapRosterShifts(shifts []commonModels.RosterShift) []structures.RosterShift {
+	shiftsResponse := []structures.RosterShift{}
+	for _, shift := range shifts {
+		shiftsResponse = append(shiftsResponse, MapRosterShift(shift))
+	}
+	return shiftsResponse
+}
+
+func MapRosterShiftRequest(shift commonModels.RosterShift, shiftRequest structures.RosterShiftRequest,
+	userId uint) commonModels.RosterShift {
+
+	if shift.Id == 0 {
+		shift.CreatedBy = userId
+	}
+	shift.CityCode = strings.ToUpper(shiftRequest.CityCode)
+	shift.LabId = shiftRequest.LabId
+	shift.Weekday = strings.ToLower(shiftRequest.Weekday)
+	shift.StartTime = shiftRequest.StartTime
+	shift.EndTime = shiftRequest.EndTime
+	shift.UserId = shiftRequest.UserId
+	shift.UpdatedBy = userId
+	return shift
+}
+
+func MapRosterOverride(override commonModels.RosterOverride) structures.RosterOverride {
+	overrideDate := ""
+	if override.OverrideDate != nil {
+		overrideDate = override.OverrideDate.Format(commonConstants.DateLayout)
+	}
+
+	return structures.RosterOverride{
+		Id:             override.Id,
+		CityCode:       override.CityCode,
+		LabId:          override.LabId,
+		OverrideDate:   overrideDate,
+		StartTime:      override.StartTime,
+		EndTime:        override.EndTime,
+		UserId:         override.UserId,
+		ReplacedUserId: override.ReplacedUserId,
+		OverrideType:   override.OverrideType,
+		Reason:         override.Reason,
+	}
+}
+
+func MapRosterOverrides(overrides []commonModels.RosterOverride) []structures.RosterOverride {
+	overridesResponse := []structures.RosterOverride{}
+	for _, override := range overrides {
+		overridesResponse = append(overridesResponse, MapRosterOverride(override))
+	}
+	return overridesResponse
+}
+
+func MapRosterOverrideRequest(overrideRequest structures.RosterOverrideRequest, overrideDate time.Time,
+	userId uint) commonModels.RosterOverride {
+
+	override := commonModels.RosterOverride{
+		CityCode:       strings.ToUpper(overrideRequest.CityCode),
+		LabId:          overrideRequest.LabId,
+		OverrideDate:   &overrideDate,
+		StartTime:      overrideRequest.StartTime,
+		EndTime:        overrideRequest.EndTime,
+		UserId:         overrideRequest.UserId,
+		ReplacedUserId: overrideRequest.ReplacedUserId,
+		OverrideType:   overrideRequest.OverrideType,
+		Reason:         overrideRequest.Reason,
+	}
+	override.CreatedBy = userId
+	override.UpdatedBy = userId
+	return override
+}
@@ -0,0 +1,21 @@
+package roster
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/roster/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	rosterController := controller.InitRosterController()
+
+	router.GET("/shifts", rosterController.GetRosterShifts)
+	router.POST("/shifts", rosterController.CreateRosterShift)
+	router.PUT("/shifts/:shiftId", rosterController.UpdateRosterShift)
+	router.DELETE("/shifts/:shiftId", rosterController.DeleteRosterShift)
+	router.GET("/overrides", rosterController.GetRosterOverrides)
+	router.POST("/overrides", rosterController.CreateRosterOverride)
+	router.DELETE("/overrides/:overrideId", rosterController.DeleteRosterOverride)
+	router.GET("/coverage", rosterController.GetRosterCoverage)
+	router.GET("/on-duty", rosterController.GetOnDutyDoctor)
+}
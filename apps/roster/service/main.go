@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/apps/roster/dao"
+	userService "github.com/Orange-Health/citadel/apps/users/service"
+)
+
+type RosterService struct {
+	RosterDao   dao.DataLayer
+	Sentry      sentry.SentryLayer
+	UserService userService.UserServiceInterface
+}
+
+func InitializeRosterService() RosterServiceInterface {
+	return &RosterService{
+		RosterDao:   dao.InitializeRosterDao(),
+		Sentry:      sentry.InitializeSentry(),
+		UserService: userService.InitializeUserService(),
+	}
+}
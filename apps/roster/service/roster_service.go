@@ -0,0 +1,562 @@
+package service
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/roster/mapper"
+	"github.com/Orange-Health/citadel/apps/roster/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type RosterServiceInterface interface {
+	GetRosterShifts(cityCode string, labId uint, weekday string) (
+		[]structures.RosterShift, *commonStructures.CommonError)
+	CreateRosterShift(shiftRequest structures.RosterShiftRequest, userId uint) (
+		structures.RosterShift, *commonStructures.CommonError)
+	UpdateRosterShift(shiftId uint, shiftRequest structures.RosterShiftRequest, userId uint) (
+		structures.RosterShift, *commonStructures.CommonError)
+	DeleteRosterShift(shiftId, userId uint) *commonStructures.CommonError
+	GetRosterOverrides(filter structures.RosterOverridesFilter) (
+		[]structures.RosterOverride, *commonStructures.CommonError)
+	CreateRosterOverride(overrideRequest structures.RosterOverrideRequest, userId uint) (
+		structures.RosterOverride, *commonStructures.CommonError)
+	DeleteRosterOverride(overrideId, userId uint) *commonStructures.CommonError
+	GetRosterCoverage(cityCode string, labId uint) (structures.RosterCoverage, *commonStructures.CommonError)
+	GetOnDutyDoctor(cityCode string, labId uint, at time.Time) (
+		structures.OnDutyDoctor, *commonStructures.CommonError)
+	GetOnDutyUserIds(at time.Time) (map[uint]bool, *commonStructures.CommonError)
+}
+
+func (rosterService *RosterService) GetRosterShifts(cityCode string, labId uint, weekday string) (
+	[]structures.RosterShift, *commonStructures.CommonError) {
+
+	if cityCode == "" {
+		return []structures.RosterShift{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_CITY_CODE_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	weekdays := []string{}
+	if weekday != "" {
+		weekday = strings.ToLower(weekday)
+		if !commonUtils.SliceContainsString(commonConstants.ROSTER_WEEKDAYS, weekday) {
+			return []structures.RosterShift{}, &commonStructures.CommonError{
+				Message:    commonConstants.ERROR_INVALID_WEEKDAY,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+		weekdays = append(weekdays, weekday)
+	}
+
+	shifts, cErr := rosterService.RosterDao.GetRosterShifts(strings.ToUpper(cityCode), labId, weekdays)
+	if cErr != nil {
+		return []structures.RosterShift{}, cErr
+	}
+
+	return mapper.MapRosterShifts(shifts), nil
+}
+
+func (rosterService *RosterService) CreateRosterShift(shiftRequest structures.RosterShiftRequest, userId uint) (
+	structures.RosterShift, *commonStructures.CommonError) {
+
+	shift := mapper.MapRosterShiftRequest(commonModels.RosterShift{}, shiftRequest, userId)
+	if cErr := rosterService.validateRosterShift(shift); cErr != nil {
+		return structures.RosterShift{}, cErr
+	}
+
+	shift, cErr := rosterService.RosterDao.CreateRosterShift(shift)
+	if cErr != nil {
+		return structures.RosterShift{}, cErr
+	}
+
+	return mapper.MapRosterShift(shift), nil
+}
+
+func (rosterService *RosterService) UpdateRosterShift(shiftId uint, shiftRequest structures.RosterShiftRequest,
+	userId uint) (structures.RosterShift, *commonStructures.CommonError) {
+
+	shift, cErr := rosterService.RosterDao.GetRosterShiftById(shiftId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_ROSTER_SHIFT_NOT_FOUND
+		}
+		return structures.RosterShift{}, cErr
+	}
+
+	shift = mapper.MapRosterShiftRequest(shift, shiftRequest, userId)
+	if cErr := rosterService.validateRosterShift(shift); cErr != nil {
+		return structures.RosterShift{}, cErr
+	}
+
+	shift, cErr = rosterService.RosterDao.UpdateRosterShift(shift)
+	if cErr != nil {
+		return structures.RosterShift{}, cErr
+	}
+
+	return mapper.MapRosterShift(shift), nil
+}
+
+func (rosterService *RosterService) DeleteRosterShift(shiftId, userId uint) *commonStructures.CommonError {
+	if _, cErr := rosterService.RosterDao.GetRosterShiftById(shiftId); cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_ROSTER_SHIFT_NOT_FOUND
+		}
+		return cErr
+	}
+
+	return rosterService.RosterDao.DeleteRosterShift(shiftId, userId)
+}
+
+func (rosterService *RosterService) GetRosterOverrides(filter structures.RosterOverridesFilter) (
+	[]structures.RosterOverride, *commonStructures.CommonError) {
+
+	if filter.CityCode == "" {
+		return []structures.RosterOverride{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_CITY_CODE_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	for _, date := range []string{filter.FromDate, filter.ToDate} {
+		if _, ok := parseRosterDate(date); date != "" && !ok {
+			return []structures.RosterOverride{}, &commonStructures.CommonError{
+				Message:    commonConstants.ERROR_INVALID_OVERRIDE_DATE,
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+	}
+	filter.CityCode = strings.ToUpper(filter.CityCode)
+
+	overrides, cErr := rosterService.RosterDao.GetRosterOverrides(filter)
+	if cErr != nil {
+		return []structures.RosterOverride{}, cErr
+	}
+
+	return mapper.MapRosterOverrides(overrides), nil
+}
+
+// CreateRosterOverride hands a window of a date to a doctor, taking precedence over the weekly shifts for that
+// window. It is how leaves, holidays and swaps are put on the roster.
+func (rosterService *RosterService) CreateRosterOverride(overrideRequest structures.RosterOverrideRequest,
+	userId uint) (structures.RosterOverride, *commonStructures.CommonError) {
+
+	overrideDate, ok := parseRosterDate(overrideRequest.OverrideDate)
+	if !ok {
+		return structures.RosterOverride{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_OVERRIDE_DATE,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	override := mapper.MapRosterOverrideRequest(overrideRequest, overrideDate, userId)
+	if cErr := rosterService.validateRosterOverride(override); cErr != nil {
+		return structures.RosterOverride{}, cErr
+	}
+
+	override, cErr := rosterService.RosterDao.CreateRosterOverride(override)
+	if cErr != nil {
+		return structures.RosterOverride{}, cErr
+	}
+
+	return mapper.MapRosterOverride(override), nil
+}
+
+func (rosterService *RosterService) DeleteRosterOverride(overrideId, userId uint) *commonStructures.CommonError {
+	if _, cErr := rosterService.RosterDao.GetRosterOverrideById(overrideId); cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_ROSTER_OVERRIDE_NOT_FOUND
+		}
+		return cErr
+	}
+
+	return rosterService.RosterDao.DeleteRosterOverride(overrideId, userId)
+}
+
+// GetRosterCoverage lists the windows of the week in which nobody is on duty for a lab, counting the city wide
+// shifts along with the ones of the lab.
+func (rosterService *RosterService) GetRosterCoverage(cityCode string, labId uint) (
+	structures.RosterCoverage, *commonStructures.CommonError) {
+
+	if cityCode == "" {
+		return structures.RosterCoverage{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_CITY_CODE_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	cityCode = strings.ToUpper(cityCode)
+
+	shifts, cErr := rosterService.RosterDao.GetRosterShifts(cityCode, labId, []string{})
+	if cErr != nil {
+		return structures.RosterCoverage{}, cErr
+	}
+
+	gaps := getCoverageGaps(shifts)
+	return structures.RosterCoverage{
+		CityCode:       cityCode,
+		LabId:          labId,
+		IsFullyCovered: len(gaps) == 0,
+		Gaps:           gaps,
+	}, nil
+}
+
+// GetOnDutyDoctor finds the doctor on duty for a lab at a point in time. Overrides of the date win over the
+// weekly shifts and the shifts of the lab win over the city wide ones. Windows that started on the previous day
+// and run past midnight are taken into account.
+func (rosterService *RosterService) GetOnDutyDoctor(cityCode string, labId uint, at time.Time) (
+	structures.OnDutyDoctor, *commonStructures.CommonError) {
+
+	if cityCode == "" {
+		return structures.OnDutyDoctor{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_CITY_CODE_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	cityCode = strings.ToUpper(cityCode)
+
+	location, err := commonUtils.GetLocalLocation()
+	if err != nil {
+		return structures.OnDutyDoctor{}, &commonStructures.CommonError{
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+	at = at.In(location)
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, location)
+	previousDay := day.AddDate(0, 0, -1)
+
+	overrides, cErr := rosterService.RosterDao.GetRosterOverrides(structures.RosterOverridesFilter{
+		CityCode: cityCode,
+		LabId:    labId,
+		FromDate: previousDay.Format(commonConstants.DateLayout),
+		ToDate:   day.Format(commonConstants.DateLayout),
+	})
+	if cErr != nil {
+		return structures.OnDutyDoctor{}, cErr
+	}
+
+	// The lab specific overrides go ahead of the city wide ones
+	sort.SliceStable(overrides, func(i, j int) bool {
+		return overrides[i].LabId > overrides[j].LabId
+	})
+	for _, override := range overrides {
+		if override.OverrideDate == nil {
+			continue
+		}
+		overrideDay := time.Date(override.OverrideDate.Year(), override.OverrideDate.Month(),
+			override.OverrideDate.Day(), 0, 0, 0, 0, location)
+		startsAt, endsAt := getDutyWindow(overrideDay, override.StartTime, override.EndTime)
+		if isWithinWindow(at, startsAt, endsAt) {
+			return rosterService.getOnDutyDoctor(override.UserId, commonConstants.ROSTER_DUTY_SOURCE_OVERRIDE,
+				override.Id, startsAt, endsAt)
+		}
+	}
+
+	shifts, cErr := rosterService.RosterDao.GetRosterShifts(cityCode, labId, []string{
+		getRosterWeekday(previousDay), getRosterWeekday(day),
+	})
+	if cErr != nil {
+		return structures.OnDutyDoctor{}, cErr
+	}
+
+	for _, shift := range shifts {
+		for _, shiftDay := range []time.Time{previousDay, day} {
+			if shift.Weekday != getRosterWeekday(shiftDay) {
+				continue
+			}
+			startsAt, endsAt := getDutyWindow(shiftDay, shift.StartTime, shift.EndTime)
+			if isWithinWindow(at, startsAt, endsAt) {
+				return rosterService.getOnDutyDoctor(shift.UserId, commonConstants.ROSTER_DUTY_SOURCE_SHIFT,
+					shift.Id, startsAt, endsAt)
+			}
+		}
+	}
+
+	return structures.OnDutyDoctor{}, &commonStructures.CommonError{
+		Message:    commonConstants.ERROR_NO_DOCTOR_ON_DUTY,
+		StatusCode: http.StatusNotFound,
+	}
+}
+
+// GetOnDutyUserIds gets the doctors on duty at a point in time across the rosters of every city and lab. The
+// cities and labs without a doctor on duty at that time are skipped.
+func (rosterService *RosterService) GetOnDutyUserIds(at time.Time) (map[uint]bool, *commonStructures.CommonError) {
+	onDutyUserIds := map[uint]bool{}
+	rosterLocations, cErr := rosterService.RosterDao.GetRosterLocations()
+	if cErr != nil {
+		return onDutyUserIds, cErr
+	}
+
+	for _, rosterLocation := range rosterLocations {
+		onDutyDoctor, cErr := rosterService.GetOnDutyDoctor(rosterLocation.CityCode, rosterLocation.LabId, at)
+		if cErr != nil {
+			if cErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return onDutyUserIds, cErr
+		}
+		onDutyUserIds[onDutyDoctor.UserId] = true
+	}
+
+	return onDutyUserIds, nil
+}
+
+func (rosterService *RosterService) getOnDutyDoctor(userId uint, source string, sourceId uint, startsAt,
+	endsAt time.Time) (structures.OnDutyDoctor, *commonStructures.CommonError) {
+
+	user, cErr := rosterService.UserService.GetUserModel(userId)
+	if cErr != nil {
+		return structures.OnDutyDoctor{}, cErr
+	}
+
+	return structures.OnDutyDoctor{
+		UserId:       user.Id,
+		UserName:     user.UserName,
+		SystemUserId: user.SystemUserId,
+		Source:       source,
+		SourceId:     sourceId,
+		DutyStartsAt: startsAt,
+		DutyEndsAt:   endsAt,
+	}, nil
+}
+
+func (rosterService *RosterService) validateRosterShift(shift commonModels.RosterShift) *commonStructures.CommonError {
+	if !commonUtils.SliceContainsString(commonConstants.ROSTER_WEEKDAYS, shift.Weekday) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_WEEKDAY,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	startMinute, endMinute, ok := getWeekMinutes(shift.Weekday, shift.StartTime, shift.EndTime)
+	if !ok {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_SHIFT_TIME,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if cErr := rosterService.validateDoctor(shift.UserId); cErr != nil {
+		return cErr
+	}
+
+	shifts, cErr := rosterService.RosterDao.GetRosterShifts(shift.CityCode, shift.LabId, []string{})
+	if cErr != nil {
+		return cErr
+	}
+
+	for _, existingShift := range shifts {
+		if existingShift.Id == shift.Id || existingShift.LabId != shift.LabId {
+			continue
+		}
+		existingStartMinute, existingEndMinute, _ := getWeekMinutes(existingShift.Weekday,
+			existingShift.StartTime, existingShift.EndTime)
+		if windowsOverlap(startMinute, endMinute, existingStartMinute, existingEndMinute,
+			commonConstants.RosterMinutesInWeek) {
+			return &commonStructures.CommonError{
+				Message:    commonConstants.ERROR_ROSTER_SHIFT_OVERLAPS,
+				StatusCode: http.StatusConflict,
+			}
+		}
+	}
+
+	return nil
+}
+
+func (rosterService *RosterService) validateRosterOverride(
+	override commonModels.RosterOverride) *commonStructures.CommonError {
+
+	if !commonUtils.SliceContainsString(commonConstants.ROSTER_OVERRIDE_TYPES, override.OverrideType) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_OVERRIDE_TYPE,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if _, _, ok := getWeekMinutes(commonConstants.ROSTER_WEEKDAYS[0], override.StartTime,
+		override.EndTime); !ok {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_SHIFT_TIME,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if cErr := rosterService.validateDoctor(override.UserId); cErr != nil {
+		return cErr
+	}
+
+	overrideDay := *override.OverrideDate
+	startsAt, endsAt := getDutyWindow(overrideDay, override.StartTime, override.EndTime)
+	overrides, cErr := rosterService.RosterDao.GetRosterOverrides(structures.RosterOverridesFilter{
+		CityCode: override.CityCode,
+		LabId:    override.LabId,
+		FromDate: overrideDay.AddDate(0, 0, -1).Format(commonConstants.DateLayout),
+		ToDate:   overrideDay.AddDate(0, 0, 1).Format(commonConstants.DateLayout),
+	})
+	if cErr != nil {
+		return cErr
+	}
+
+	for _, existingOverride := range overrides {
+		if existingOverride.LabId != override.LabId || existingOverride.OverrideDate == nil {
+			continue
+		}
+		existingDay := time.Date(existingOverride.OverrideDate.Year(), existingOverride.OverrideDate.Month(),
+			existingOverride.OverrideDate.Day(), 0, 0, 0, 0, overrideDay.Location())
+		existingStartsAt, existingEndsAt := getDutyWindow(existingDay, existingOverride.StartTime,
+			existingOverride.EndTime)
+		if startsAt.Before(existingEndsAt) && existingStartsAt.Before(endsAt) {
+			return &commonStructures.CommonError{
+				Message:    commonConstants.ERROR_ROSTER_OVERRIDE_OVERLAPS,
+				StatusCode: http.StatusConflict,
+			}
+		}
+	}
+
+	return nil
+}
+
+func (rosterService *RosterService) validateDoctor(userId uint) *commonStructures.CommonError {
+	doctor, cErr := rosterService.UserService.GetUserModel(userId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_INVALID_PATHOLOGIST_ID
+			cErr.StatusCode = http.StatusBadRequest
+		}
+		return cErr
+	}
+
+	if !commonUtils.SliceContainsString(
+		[]string{commonConstants.USER_TYPE_PATHOLOGIST, commonConstants.USER_TYPE_SUPER_ADMIN},
+		doctor.UserType) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_USER_IS_NOT_A_PATHOLOGIST,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+// getCoverageGaps marks every minute of the week a shift covers and returns the uncovered runs, split by day.
+func getCoverageGaps(shifts []commonModels.RosterShift) []structures.RosterCoverageGap {
+	covered := make([]bool, commonConstants.RosterMinutesInWeek)
+	for _, shift := range shifts {
+		startMinute, endMinute, ok := getWeekMinutes(shift.Weekday, shift.StartTime, shift.EndTime)
+		if !ok {
+			continue
+		}
+		for minute := startMinute; minute < endMinute; minute++ {
+			covered[minute%commonConstants.RosterMinutesInWeek] = true
+		}
+	}
+
+	gaps := []structures.RosterCoverageGap{}
+	for dayIndex, weekday := range commonConstants.ROSTER_WEEKDAYS {
+		dayStartMinute := dayIndex * commonConstants.RosterMinutesInDay
+		gapStartMinute := -1
+		for minute := 0; minute <= commonConstants.RosterMinutesInDay; minute++ {
+			isCovered := minute == commonConstants.RosterMinutesInDay || covered[dayStartMinute+minute]
+			if !isCovered && gapStartMinute < 0 {
+				gapStartMinute = minute
+			}
+			if isCovered && gapStartMinute >= 0 {
+				gaps = append(gaps, structures.RosterCoverageGap{
+					Weekday:   weekday,
+					StartTime: formatDayMinute(gapStartMinute),
+					EndTime:   formatDayMinute(minute % commonConstants.RosterMinutesInDay),
+				})
+				gapStartMinute = -1
+			}
+		}
+	}
+
+	return gaps
+}
+
+// getWeekMinutes places a shift on the week, counting minutes from Sunday midnight. The end of a shift that
+// runs past midnight goes beyond the day, and of one that runs past Saturday beyond the week.
+func getWeekMinutes(weekday, startTime, endTime string) (int, int, bool) {
+	dayIndex := -1
+	for index, rosterWeekday := range commonConstants.ROSTER_WEEKDAYS {
+		if rosterWeekday == weekday {
+			dayIndex = index
+		}
+	}
+	startMinute, startOk := parseRosterTime(startTime)
+	endMinute, endOk := parseRosterTime(endTime)
+	if dayIndex < 0 || !startOk || !endOk {
+		return 0, 0, false
+	}
+
+	if endMinute <= startMinute {
+		endMinute += commonConstants.RosterMinutesInDay
+	}
+	dayStartMinute := dayIndex * commonConstants.RosterMinutesInDay
+
+	return dayStartMinute + startMinute, dayStartMinute + endMinute, true
+}
+
+// getDutyWindow turns the start and end times of a day into the window they span, ending on the next day when
+// the end time is not after the start time.
+func getDutyWindow(day time.Time, startTime, endTime string) (time.Time, time.Time) {
+	startMinute, _ := parseRosterTime(startTime)
+	endMinute, _ := parseRosterTime(endTime)
+	if endMinute <= startMinute {
+		endMinute += commonConstants.RosterMinutesInDay
+	}
+
+	startsAt := time.Date(day.Year(), day.Month(), day.Day(), 0, startMinute, 0, 0, day.Location())
+	endsAt := time.Date(day.Year(), day.Month(), day.Day(), 0, endMinute, 0, 0, day.Location())
+	return startsAt, endsAt
+}
+
+// windowsOverlap tells whether two windows on a cycle of the given length overlap.
+func windowsOverlap(firstStart, firstEnd, secondStart, secondEnd, cycle int) bool {
+	for _, offset := range []int{-cycle, 0, cycle} {
+		if firstStart < secondEnd+offset && secondStart+offset < firstEnd {
+			return true
+		}
+	}
+	return false
+}
+
+func isWithinWindow(at, startsAt, endsAt time.Time) bool {
+	return !at.Before(startsAt) && at.Before(endsAt)
+}
+
+func parseRosterTime(rosterTime string) (int, bool) {
+	if len(rosterTime) != len(commonConstants.TimeLayout) {
+		return 0, false
+	}
+	parsedTime, err := time.Parse(commonConstants.TimeLayout, rosterTime)
+	if err != nil {
+		return 0, false
+	}
+	return parsedTime.Hour()*60 + parsedTime.Minute(), true
+}
+
+func parseRosterDate(rosterDate string) (time.Time, bool) {
+	location, err := commonUtils.GetLocalLocation()
+	if err != nil {
+		return time.Time{}, false
+	}
+	parsedDate, err := time.ParseInLocation(commonConstants.DateLayout, rosterDate, location)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsedDate, true
+}
+
+func formatDayMinute(minute int) string {
+	return time.Date(0, 1, 1, 0, minute, 0, 0, time.UTC).Format(commonConstants.TimeLayout)
+}
+
+func getRosterWeekday(day time.Time) string {
+	return commonConstants.ROSTER_WEEKDAYS[day.Weekday()]
+}
@@ -0,0 +1,129 @@
+package structures
+
+import (
+	"time"
+)
+
+// @swagger:model RosterShift
+type RosterShift struct {
+	// The roster shift ID.
+	// example: 1
+	Id uint `json:"id"`
+	// example: "BLR"
+	CityCode string `json:"city_code"`
+	// The lab the shift is for, 0 for every lab of the city.
+	// example: 0
+	LabId uint `json:"lab_id"`
+	// example: "monday"
+	Weekday string `json:"weekday"`
+	// example: "09:00"
+	StartTime string `json:"start_time"`
+	// Ends on the next day when it is not after the start time.
+	// example: "21:00"
+	EndTime string `json:"end_time"`
+	// The doctor on duty.
+	// example: 1
+	UserId uint `json:"user_id"`
+}
+
+type RosterShiftRequest struct {
+	CityCode  string `json:"city_code" binding:"required"`
+	LabId     uint   `json:"lab_id"`
+	Weekday   string `json:"weekday" binding:"required"`
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+	UserId    uint   `json:"user_id" binding:"required"`
+}
+
+// @swagger:model RosterOverride
+type RosterOverride struct {
+	// The roster override ID.
+	// example: 1
+	Id uint `json:"id"`
+	// example: "BLR"
+	CityCode string `json:"city_code"`
+	// example: 0
+	LabId uint `json:"lab_id"`
+	// example: "2026-10-20"
+	OverrideDate string `json:"override_date"`
+	// example: "09:00"
+	StartTime string `json:"start_time"`
+	// example: "21:00"
+	EndTime string `json:"end_time"`
+	// The doctor covering the window.
+	// example: 2
+	UserId uint `json:"user_id"`
+	// The doctor who is off, if any.
+	// example: 1
+	ReplacedUserId *uint `json:"replaced_user_id"`
+	// One of leave, holiday and swap.
+	// example: "leave"
+	OverrideType string `json:"override_type"`
+	// example: "Annual leave"
+	Reason string `json:"reason"`
+}
+
+type RosterOverrideRequest struct {
+	CityCode       string `json:"city_code" binding:"required"`
+	LabId          uint   `json:"lab_id"`
+	OverrideDate   string `json:"override_date" binding:"required"`
+	StartTime      string `json:"start_time" binding:"required"`
+	EndTime        string `json:"end_time" binding:"required"`
+	UserId         uint   `json:"user_id" binding:"required"`
+	ReplacedUserId *uint  `json:"replaced_user_id"`
+	OverrideType   string `json:"override_type" binding:"required"`
+	Reason         string `json:"reason"`
+}
+
+// @swagger:model RosterCoverage
+type RosterCoverage struct {
+	// example: "BLR"
+	CityCode string `json:"city_code"`
+	// example: 0
+	LabId uint `json:"lab_id"`
+	// example: false
+	IsFullyCovered bool `json:"is_fully_covered"`
+	// The windows of the week nobody is on duty in.
+	Gaps []RosterCoverageGap `json:"gaps"`
+}
+
+type RosterCoverageGap struct {
+	// example: "sunday"
+	Weekday string `json:"weekday"`
+	// example: "02:00"
+	StartTime string `json:"start_time"`
+	// example: "06:00"
+	EndTime string `json:"end_time"`
+}
+
+// @swagger:model OnDutyDoctor
+type OnDutyDoctor struct {
+	// example: 1
+	UserId uint `json:"user_id"`
+	// example: "Dr. A Sharma"
+	UserName string `json:"user_name"`
+	// example: "1234"
+	SystemUserId string `json:"system_user_id"`
+	// One of shift and override.
+	// example: "shift"
+	Source string `json:"source"`
+	// The ID of the shift or the override the doctor is on duty through.
+	// example: 1
+	SourceId uint `json:"source_id"`
+	// example: "2026-10-18T09:00:00+05:30"
+	DutyStartsAt time.Time `json:"duty_starts_at"`
+	// example: "2026-10-18T21:00:00+05:30"
+	DutyEndsAt time.Time `json:"duty_ends_at"`
+}
+
+type RosterOverridesFilter struct {
+	CityCode string
+	LabId    uint
+	FromDate string
+	ToDate   string
+}
+
+type RosterLocationDbStruct struct {
+	CityCode string `json:"city_code"`
+	LabId    uint   `json:"lab_id"`
+}
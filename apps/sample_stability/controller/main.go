@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/sample_stability/service"
+)
+
+type SampleStability struct {
+	SampleStabilityService service.SampleStabilityServiceInterface
+}
+
+func InitSampleStabilityController() *SampleStability {
+	return &SampleStability{
+		SampleStabilityService: service.InitializeSampleStabilityService(),
+	}
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/sample_stability/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructs "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Sample Stability Rules
+// @Description	Get Sample Stability Rules, optionally filtered to the rules applying to a lab and vial type
+// @Tags			sample-stability
+// @Produce		json
+// @Param			lab_id			query		int								false	"Lab ID"
+// @Param			vial_type_id	query		int								false	"Vial Type ID"
+// @Success		200				{object}	[]structures.SampleStabilityRule	"Sample Stability Rules"
+// @Failure		400,404,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/sample-stability/rules [get]
+func (sampleStabilityController *SampleStability) GetSampleStabilityRules(c *gin.Context) {
+	labId := commonUtils.ConvertStringToUint(c.Query("lab_id"))
+	vialTypeId := commonUtils.ConvertStringToUint(c.Query("vial_type_id"))
+
+	rules, cErr := sampleStabilityController.SampleStabilityService.GetSampleStabilityRules(labId, vialTypeId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Summary		Create Sample Stability Rule
+// @Description	Create a Sample Stability Rule limiting the hours after collection a sample can be processed
+// @Tags			sample-stability
+// @Accept			json
+// @Produce		json
+// @Param			rule		body		structures.SampleStabilityRuleRequest	true	"Sample Stability Rule"
+// @Success		200			{object}	structures.SampleStabilityRule			"Sample Stability Rule"
+// @Failure		400,409,500	{object}	structures.CommonAPIResponse			"Common API Response"
+// @Router			/api/v1/sample-stability/rules [post]
+func (sampleStabilityController *SampleStability) CreateSampleStabilityRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleRequest := structures.SampleStabilityRuleRequest{}
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, cErr := sampleStabilityController.SampleStabilityService.CreateSampleStabilityRule(c.Request.Context(),
+		ruleRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary		Update Sample Stability Rule
+// @Description	Update a Sample Stability Rule
+// @Tags			sample-stability
+// @Accept			json
+// @Produce		json
+// @Param			ruleId			path		int										true	"Sample Stability Rule ID"
+// @Param			rule			body		structures.SampleStabilityRuleRequest	true	"Sample Stability Rule"
+// @Success		200				{object}	structures.SampleStabilityRule			"Sample Stability Rule"
+// @Failure		400,404,409,500	{object}	structures.CommonAPIResponse			"Common API Response"
+// @Router			/api/v1/sample-stability/rules/{ruleId} [put]
+func (sampleStabilityController *SampleStability) UpdateSampleStabilityRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleId := commonUtils.ConvertStringToUint(c.Param("ruleId"))
+	if ruleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_SAMPLE_STABILITY_RULE_ID)
+		return
+	}
+
+	ruleRequest := structures.SampleStabilityRuleRequest{}
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, cErr := sampleStabilityController.SampleStabilityService.UpdateSampleStabilityRule(c.Request.Context(),
+		ruleId, ruleRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary		Delete Sample Stability Rule
+// @Description	Delete a Sample Stability Rule
+// @Tags			sample-stability
+// @Produce		json
+// @Param			ruleId		path		int								true	"Sample Stability Rule ID"
+// @Success		200			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/sample-stability/rules/{ruleId} [delete]
+func (sampleStabilityController *SampleStability) DeleteSampleStabilityRule(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	ruleId := commonUtils.ConvertStringToUint(c.Param("ruleId"))
+	if ruleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_SAMPLE_STABILITY_RULE_ID)
+		return
+	}
+
+	cErr = sampleStabilityController.SampleStabilityService.DeleteSampleStabilityRule(c.Request.Context(), ruleId,
+		userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructs.CommonAPIResponse{
+		Message: commonConstants.DONE_RESPONSE,
+	})
+}
+
+// @Summary		Get Near Expiry Samples
+// @Description	Get the samples waiting in a lab which are close to or beyond their stability, earliest expiry first
+// @Tags			sample-stability
+// @Produce		json
+// @Param			lab_id			query		int								true	"Lab ID"
+// @Param			within_hours	query		int								false	"Hours to expiry, defaults to the near expiry hours of each rule"
+// @Success		200				{object}	[]structures.NearExpirySample	"Near Expiry Samples"
+// @Failure		400,500			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/sample-stability/near-expiry [get]
+func (sampleStabilityController *SampleStability) GetNearExpirySamples(c *gin.Context) {
+	labId := commonUtils.ConvertStringToUint(c.Query("lab_id"))
+	if labId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_LAB_ID)
+		return
+	}
+	withinHours := commonUtils.ConvertStringToUint(c.Query("within_hours"))
+
+	samples, cErr := sampleStabilityController.SampleStabilityService.GetNearExpirySamples(c.Request.Context(),
+		labId, withinHours)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, samples)
+}
@@ -0,0 +1,154 @@
+package dao
+
+import (
+	"github.com/Orange-Health/citadel/apps/sample_stability/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetSampleStabilityRuleById(ruleId uint) (commonModels.SampleStabilityRule, *commonStructures.CommonError)
+	GetSampleStabilityRules(labId, vialTypeId uint) ([]commonModels.SampleStabilityRule, *commonStructures.CommonError)
+	GetActiveSampleStabilityRules() ([]commonModels.SampleStabilityRule, *commonStructures.CommonError)
+	GetSampleStabilityRuleByScope(rule commonModels.SampleStabilityRule) (
+		commonModels.SampleStabilityRule, *commonStructures.CommonError)
+	GetInLabSampleTestsByLabId(labId uint) ([]structures.InLabSampleTest, *commonStructures.CommonError)
+
+	CreateSampleStabilityRule(rule commonModels.SampleStabilityRule) (
+		commonModels.SampleStabilityRule, *commonStructures.CommonError)
+	UpdateSampleStabilityRule(rule commonModels.SampleStabilityRule) (
+		commonModels.SampleStabilityRule, *commonStructures.CommonError)
+	DeleteSampleStabilityRule(ruleId, userId uint) *commonStructures.CommonError
+}
+
+func (sampleStabilityDao *SampleStabilityDao) GetSampleStabilityRuleById(ruleId uint) (
+	commonModels.SampleStabilityRule, *commonStructures.CommonError) {
+
+	rule := commonModels.SampleStabilityRule{}
+	if err := sampleStabilityDao.Db.Where("id = ?", ruleId).First(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (sampleStabilityDao *SampleStabilityDao) GetSampleStabilityRules(labId, vialTypeId uint) (
+	[]commonModels.SampleStabilityRule, *commonStructures.CommonError) {
+
+	rules := []commonModels.SampleStabilityRule{}
+	query := sampleStabilityDao.Db
+	if labId != 0 {
+		query = query.Where("lab_id IN (?)", []uint{0, labId})
+	}
+	if vialTypeId != 0 {
+		query = query.Where("vial_type_id IN (?)", []uint{0, vialTypeId})
+	}
+	if err := query.Order("lab_id, vial_type_id, master_test_id, storage_condition").
+		Find(&rules).Error; err != nil {
+		return rules, commonUtils.HandleORMError(err)
+	}
+
+	return rules, nil
+}
+
+func (sampleStabilityDao *SampleStabilityDao) GetActiveSampleStabilityRules() (
+	[]commonModels.SampleStabilityRule, *commonStructures.CommonError) {
+
+	rules := []commonModels.SampleStabilityRule{}
+	if err := sampleStabilityDao.Db.Where("is_active = ?", true).Order("id").Find(&rules).Error; err != nil {
+		return rules, commonUtils.HandleORMError(err)
+	}
+
+	return rules, nil
+}
+
+func (sampleStabilityDao *SampleStabilityDao) GetSampleStabilityRuleByScope(rule commonModels.SampleStabilityRule) (
+	commonModels.SampleStabilityRule, *commonStructures.CommonError) {
+
+	existingRule := commonModels.SampleStabilityRule{}
+	if err := sampleStabilityDao.Db.Where("lab_id = ?", rule.LabId).
+		Where("vial_type_id = ?", rule.VialTypeId).
+		Where("master_test_id = ?", rule.MasterTestId).
+		Where("storage_condition = ?", rule.StorageCondition).
+		First(&existingRule).Error; err != nil {
+		return existingRule, commonUtils.HandleORMError(err)
+	}
+
+	return existingRule, nil
+}
+
+// GetInLabSampleTestsByLabId returns one row per sample and mapped test for the collected samples waiting in the
+// lab. Samples without a mapped test are returned once with a zero master test id.
+func (sampleStabilityDao *SampleStabilityDao) GetInLabSampleTestsByLabId(labId uint) (
+	[]structures.InLabSampleTest, *commonStructures.CommonError) {
+
+	inLabSampleTests := []structures.InLabSampleTest{}
+	selectStrings := []string{
+		"samples.id as sample_id",
+		"samples.sample_number as sample_number",
+		"samples.barcode as barcode",
+		"samples.oms_order_id as oms_order_id",
+		"samples.vial_type_id as vial_type_id",
+		"samples.status as status",
+		"sample_metadata.collected_at as collected_at",
+		"sample_metadata.storage_condition as storage_condition",
+		"test_details.master_test_id as master_test_id",
+	}
+
+	if err := sampleStabilityDao.Db.Table(commonConstants.TableSamples).
+		Joins("INNER JOIN sample_metadata ON sample_metadata.sample_id = samples.id AND "+
+			"sample_metadata.deleted_at IS NULL").
+		Joins("LEFT JOIN test_sample_mapping ON test_sample_mapping.sample_id = samples.id AND "+
+			"test_sample_mapping.is_rejected = false AND test_sample_mapping.deleted_at IS NULL").
+		Joins("LEFT JOIN test_details ON test_details.central_oms_test_id = test_sample_mapping.oms_test_id AND "+
+			"test_details.deleted_at IS NULL").
+		Select(selectStrings).
+		Where("samples.lab_id = ?", labId).
+		Where("samples.status IN (?)", commonConstants.SampleStabilityReportStatuses).
+		Where("sample_metadata.collected_at IS NOT NULL").
+		Where("samples.deleted_at IS NULL").
+		Scan(&inLabSampleTests).Error; err != nil {
+		return inLabSampleTests, commonUtils.HandleORMError(err)
+	}
+
+	return inLabSampleTests, nil
+}
+
+func (sampleStabilityDao *SampleStabilityDao) CreateSampleStabilityRule(rule commonModels.SampleStabilityRule) (
+	commonModels.SampleStabilityRule, *commonStructures.CommonError) {
+
+	if err := sampleStabilityDao.Db.Create(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (sampleStabilityDao *SampleStabilityDao) UpdateSampleStabilityRule(rule commonModels.SampleStabilityRule) (
+	commonModels.SampleStabilityRule, *commonStructures.CommonError) {
+
+	if err := sampleStabilityDao.Db.Save(&rule).Error; err != nil {
+		return rule, commonUtils.HandleORMError(err)
+	}
+
+	return rule, nil
+}
+
+func (sampleStabilityDao *SampleStabilityDao) DeleteSampleStabilityRule(ruleId, userId uint) *commonStructures.CommonError {
+
+	currentTime := commonUtils.GetCurrentTime()
+	ruleUpdates := map[string]interface{}{
+		"deleted_by": userId,
+		"updated_by": userId,
+		"deleted_at": currentTime,
+		"updated_at": currentTime,
+	}
+	if err := sampleStabilityDao.Db.Model(&commonModels.SampleStabilityRule{}).Where("id = ?", ruleId).
+		Updates(ruleUpdates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
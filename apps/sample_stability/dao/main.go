@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type SampleStabilityDao struct {
+	Db *gorm.DB
+}
+
+func InitializeSampleStabilityDao() DataLayer {
+	return &SampleStabilityDao{
+		Db: psql.GetDbInstance(),
+	}
+}
@@ -0,0 +1,68 @@
+package mapper
+
+import (
+	"github.com/Orange-Health/citadel/apps/sample_stability/structures"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapSampleStabilityRule(rule commonModels.SampleStabilityRule) structures.SampleStabilityRule {
+	return structures.SampleStabilityRule{
+		Id:               rule.Id,
+		LabId:            rule.LabId,
+		VialTypeId:       rule.VialTypeId,
+		MasterTestId:     rule.MasterTestId,
+		StorageCondition: rule.StorageCondition,
+		StabilityHours:   rule.StabilityHours,
+		NearExpiryHours:  rule.NearExpiryHours,
+		Action:           rule.Action,
+		IsActive:         rule.IsActive,
+	}
+}
+
+func MapSampleStabilityRules(rules []commonModels.SampleStabilityRule) []structures.SampleStabilityRule {
+	sampleStabilityRules := []structures.SampleStabilityRule{}
+	for _, rule := range rules {
+		sampleStabilityRules = append(sampleStabilityRules, MapSampleStabilityRule(rule))
+	}
+	return sampleStabilityRules
+}
+
+func MapSampleStabilityRuleRequest(rule commonModels.SampleStabilityRule,
+	ruleRequest structures.SampleStabilityRuleRequest, userId uint) commonModels.SampleStabilityRule {
+	if rule.Id == 0 {
+		rule.CreatedBy = userId
+		rule.IsActive = true
+	}
+	rule.LabId = ruleRequest.LabId
+	rule.VialTypeId = ruleRequest.VialTypeId
+	rule.MasterTestId = ruleRequest.MasterTestId
+	rule.StorageCondition = ruleRequest.StorageCondition
+	rule.StabilityHours = ruleRequest.StabilityHours
+	rule.NearExpiryHours = ruleRequest.NearExpiryHours
+	rule.Action = ruleRequest.Action
+	if ruleRequest.IsActive != nil {
+		rule.IsActive = *ruleRequest.IsActive
+	}
+	rule.UpdatedBy = userId
+	return rule
+}
+
+func MapNearExpirySample(sampleTest structures.InLabSampleTest, stabilityCheck commonStructures.SampleStabilityCheck,
+	minutesToExpiry int) structures.NearExpirySample {
+	return structures.NearExpirySample{
+		SampleId:              sampleTest.SampleId,
+		SampleNumber:          sampleTest.SampleNumber,
+		Barcode:               sampleTest.Barcode,
+		OmsOrderId:            sampleTest.OmsOrderId,
+		VialTypeId:            sampleTest.VialTypeId,
+		Status:                sampleTest.Status,
+		StorageCondition:      stabilityCheck.StorageCondition,
+		CollectedAt:           sampleTest.CollectedAt,
+		SampleStabilityRuleId: stabilityCheck.SampleStabilityRuleId,
+		StabilityHours:        stabilityCheck.StabilityHours,
+		ExpiresAt:             stabilityCheck.ExpiresAt,
+		MinutesToExpiry:       minutesToExpiry,
+		IsExpired:             stabilityCheck.IsExpired,
+	}
+}
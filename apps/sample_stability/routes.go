@@ -0,0 +1,17 @@
+package sampleStability
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/sample_stability/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	sampleStabilityController := controller.InitSampleStabilityController()
+
+	router.GET("/rules", sampleStabilityController.GetSampleStabilityRules)
+	router.POST("/rules", sampleStabilityController.CreateSampleStabilityRule)
+	router.PUT("/rules/:ruleId", sampleStabilityController.UpdateSampleStabilityRule)
+	router.DELETE("/rules/:ruleId", sampleStabilityController.DeleteSampleStabilityRule)
+	router.GET("/near-expiry", sampleStabilityController.GetNearExpirySamples)
+}
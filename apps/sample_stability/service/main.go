@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/cache"
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/apps/sample_stability/dao"
+)
+
+type SampleStabilityService struct {
+	SampleStabilityDao dao.DataLayer
+	Cache              cache.CacheLayer
+	Sentry             sentry.SentryLayer
+}
+
+func InitializeSampleStabilityService() SampleStabilityServiceInterface {
+	return &SampleStabilityService{
+		SampleStabilityDao: dao.InitializeSampleStabilityDao(),
+		Cache:              cache.InitializeCache(),
+		Sentry:             sentry.InitializeSentry(),
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/sample_stability/mapper"
+	"github.com/Orange-Health/citadel/apps/sample_stability/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// CheckSamplesStability applies the active stability rules to the samples as of currentTime. Samples with no
+// collection time or no applicable rule are left out of the returned map.
+func (sampleStabilityService *SampleStabilityService) CheckSamplesStability(ctx context.Context, labId uint,
+	samples []commonModels.Sample, samplesMetadata []commonModels.SampleMetadata,
+	sampleIdToMasterTestIdsMap map[uint][]uint, currentTime time.Time) map[uint]commonStructures.SampleStabilityCheck {
+
+	stabilityChecks := map[uint]commonStructures.SampleStabilityCheck{}
+	rules := sampleStabilityService.getActiveSampleStabilityRules(ctx)
+	if len(rules) == 0 {
+		return stabilityChecks
+	}
+
+	sampleIdToSampleMetadataMap := map[uint]commonModels.SampleMetadata{}
+	for _, sampleMetadata := range samplesMetadata {
+		sampleIdToSampleMetadataMap[sampleMetadata.SampleId] = sampleMetadata
+	}
+
+	for _, sample := range samples {
+		sampleMetadata := sampleIdToSampleMetadataMap[sample.Id]
+		stabilityCheck, ok := getSampleStabilityCheck(rules, labId, sample.VialTypeId,
+			sampleMetadata.StorageCondition, sampleMetadata.CollectedAt, sampleIdToMasterTestIdsMap[sample.Id],
+			currentTime)
+		if !ok {
+			continue
+		}
+		stabilityCheck.SampleId = sample.Id
+		stabilityChecks[sample.Id] = stabilityCheck
+	}
+
+	return stabilityChecks
+}
+
+// GetNearExpirySamples lists the samples waiting in the lab that expire within withinHours, or within the near
+// expiry hours of their rule when withinHours is 0, along with the samples already beyond stability.
+func (sampleStabilityService *SampleStabilityService) GetNearExpirySamples(ctx context.Context,
+	labId, withinHours uint) ([]structures.NearExpirySample, *commonStructures.CommonError) {
+
+	nearExpirySamples := []structures.NearExpirySample{}
+	rules := sampleStabilityService.getActiveSampleStabilityRules(ctx)
+	if len(rules) == 0 {
+		return nearExpirySamples, nil
+	}
+
+	inLabSampleTests, cErr := sampleStabilityService.SampleStabilityDao.GetInLabSampleTestsByLabId(labId)
+	if cErr != nil {
+		return nearExpirySamples, cErr
+	}
+
+	sampleIds, sampleIdToSampleTestMap, sampleIdToMasterTestIdsMap :=
+		[]uint{}, map[uint]structures.InLabSampleTest{}, map[uint][]uint{}
+	for _, sampleTest := range inLabSampleTests {
+		if _, ok := sampleIdToSampleTestMap[sampleTest.SampleId]; !ok {
+			sampleIds = append(sampleIds, sampleTest.SampleId)
+			sampleIdToSampleTestMap[sampleTest.SampleId] = sampleTest
+		}
+		if sampleTest.MasterTestId != 0 {
+			sampleIdToMasterTestIdsMap[sampleTest.SampleId] = append(sampleIdToMasterTestIdsMap[sampleTest.SampleId],
+				sampleTest.MasterTestId)
+		}
+	}
+
+	currentTime := *commonUtils.GetCurrentTime()
+	for _, sampleId := range sampleIds {
+		sampleTest := sampleIdToSampleTestMap[sampleId]
+		stabilityCheck, ok := getSampleStabilityCheck(rules, labId, sampleTest.VialTypeId, sampleTest.StorageCondition,
+			sampleTest.CollectedAt, sampleIdToMasterTestIdsMap[sampleId], currentTime)
+		if !ok {
+			continue
+		}
+
+		nearExpiryHours := withinHours
+		if nearExpiryHours == 0 {
+			nearExpiryHours = stabilityCheck.NearExpiryHours
+		}
+		if nearExpiryHours == 0 {
+			nearExpiryHours = commonConstants.SampleStabilityDefaultNearExpiryHours
+		}
+
+		timeToExpiry := stabilityCheck.ExpiresAt.Sub(currentTime)
+		if timeToExpiry > time.Duration(nearExpiryHours)*time.Hour {
+			continue
+		}
+
+		nearExpirySamples = append(nearExpirySamples, mapper.MapNearExpirySample(sampleTest, stabilityCheck,
+			int(timeToExpiry.Minutes())))
+	}
+
+	sort.SliceStable(nearExpirySamples, func(i, j int) bool {
+		return nearExpirySamples[i].ExpiresAt.Before(*nearExpirySamples[j].ExpiresAt)
+	})
+
+	return nearExpirySamples, nil
+}
+
+// getSampleStabilityCheck picks the rule governing a sample among the rules matching its lab, vial type, tests and
+// storage condition. An expired reject rule wins over everything else, otherwise the earliest expiry wins.
+func getSampleStabilityCheck(rules []commonModels.SampleStabilityRule, labId, vialTypeId uint,
+	storageCondition string, collectedAt *time.Time, masterTestIds []uint,
+	currentTime time.Time) (commonStructures.SampleStabilityCheck, bool) {
+
+	stabilityCheck, found := commonStructures.SampleStabilityCheck{}, false
+	if collectedAt == nil || collectedAt.IsZero() {
+		return stabilityCheck, found
+	}
+	if storageCondition == "" {
+		storageCondition = commonConstants.StorageConditionAmbient
+	}
+
+	for _, rule := range rules {
+		if rule.StorageCondition != storageCondition ||
+			(rule.LabId != 0 && rule.LabId != labId) ||
+			(rule.VialTypeId != 0 && rule.VialTypeId != vialTypeId) ||
+			(rule.MasterTestId != 0 && !commonUtils.SliceContainsUint(masterTestIds, rule.MasterTestId)) {
+			continue
+		}
+
+		expiresAt := collectedAt.Add(time.Duration(rule.StabilityHours) * time.Hour)
+		ruleCheck := commonStructures.SampleStabilityCheck{
+			SampleStabilityRuleId: rule.Id,
+			StorageCondition:      storageCondition,
+			StabilityHours:        rule.StabilityHours,
+			NearExpiryHours:       rule.NearExpiryHours,
+			Action:                rule.Action,
+			ExpiresAt:             &expiresAt,
+			IsExpired:             currentTime.After(expiresAt),
+		}
+		if !found || isStricterSampleStabilityCheck(ruleCheck, stabilityCheck) {
+			stabilityCheck, found = ruleCheck, true
+		}
+	}
+
+	if found && stabilityCheck.IsExpired {
+		if stabilityCheck.Action == commonConstants.SampleStabilityActionReject {
+			stabilityCheck.Reason = fmt.Sprintf(commonConstants.SampleStabilityRejectionReason,
+				stabilityCheck.StorageCondition, stabilityCheck.StabilityHours)
+		} else {
+			stabilityCheck.Reason = fmt.Sprintf(commonConstants.SampleStabilityFlagReason,
+				currentTime.Sub(*collectedAt).Round(time.Minute), stabilityCheck.StorageCondition,
+				stabilityCheck.StabilityHours)
+		}
+	}
+
+	return stabilityCheck, found
+}
+
+func isStricterSampleStabilityCheck(check, currentCheck commonStructures.SampleStabilityCheck) bool {
+	checkRejects := check.IsExpired && check.Action == commonConstants.SampleStabilityActionReject
+	currentCheckRejects := currentCheck.IsExpired && currentCheck.Action == commonConstants.SampleStabilityActionReject
+	if checkRejects != currentCheckRejects {
+		return checkRejects
+	}
+	return check.ExpiresAt.Before(*currentCheck.ExpiresAt)
+}
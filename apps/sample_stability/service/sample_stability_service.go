@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/sample_stability/mapper"
+	"github.com/Orange-Health/citadel/apps/sample_stability/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type SampleStabilityServiceInterface interface {
+	GetSampleStabilityRules(labId, vialTypeId uint) ([]structures.SampleStabilityRule, *commonStructures.CommonError)
+	CreateSampleStabilityRule(ctx context.Context, ruleRequest structures.SampleStabilityRuleRequest, userId uint) (
+		structures.SampleStabilityRule, *commonStructures.CommonError)
+	UpdateSampleStabilityRule(ctx context.Context, ruleId uint, ruleRequest structures.SampleStabilityRuleRequest,
+		userId uint) (structures.SampleStabilityRule, *commonStructures.CommonError)
+	DeleteSampleStabilityRule(ctx context.Context, ruleId, userId uint) *commonStructures.CommonError
+	GetNearExpirySamples(ctx context.Context, labId, withinHours uint) (
+		[]structures.NearExpirySample, *commonStructures.CommonError)
+
+	CheckSamplesStability(ctx context.Context, labId uint, samples []commonModels.Sample,
+		samplesMetadata []commonModels.SampleMetadata, sampleIdToMasterTestIdsMap map[uint][]uint,
+		currentTime time.Time) map[uint]commonStructures.SampleStabilityCheck
+}
+
+func (sampleStabilityService *SampleStabilityService) GetSampleStabilityRules(labId, vialTypeId uint) (
+	[]structures.SampleStabilityRule, *commonStructures.CommonError) {
+
+	rules, cErr := sampleStabilityService.SampleStabilityDao.GetSampleStabilityRules(labId, vialTypeId)
+	if cErr != nil {
+		return []structures.SampleStabilityRule{}, cErr
+	}
+
+	return mapper.MapSampleStabilityRules(rules), nil
+}
+
+func (sampleStabilityService *SampleStabilityService) CreateSampleStabilityRule(ctx context.Context,
+	ruleRequest structures.SampleStabilityRuleRequest, userId uint) (
+	structures.SampleStabilityRule, *commonStructures.CommonError) {
+
+	if cErr := validateSampleStabilityRuleRequest(ruleRequest); cErr != nil {
+		return structures.SampleStabilityRule{}, cErr
+	}
+
+	rule := mapper.MapSampleStabilityRuleRequest(commonModels.SampleStabilityRule{}, ruleRequest, userId)
+	if cErr := sampleStabilityService.validateDuplicateSampleStabilityRule(rule); cErr != nil {
+		return structures.SampleStabilityRule{}, cErr
+	}
+
+	rule, cErr := sampleStabilityService.SampleStabilityDao.CreateSampleStabilityRule(rule)
+	if cErr != nil {
+		return structures.SampleStabilityRule{}, cErr
+	}
+
+	sampleStabilityService.invalidateSampleStabilityRulesCache(ctx)
+	return mapper.MapSampleStabilityRule(rule), nil
+}
+
+func (sampleStabilityService *SampleStabilityService) UpdateSampleStabilityRule(ctx context.Context, ruleId uint,
+	ruleRequest structures.SampleStabilityRuleRequest, userId uint) (
+	structures.SampleStabilityRule, *commonStructures.CommonError) {
+
+	if cErr := validateSampleStabilityRuleRequest(ruleRequest); cErr != nil {
+		return structures.SampleStabilityRule{}, cErr
+	}
+
+	rule, cErr := sampleStabilityService.getSampleStabilityRuleById(ruleId)
+	if cErr != nil {
+		return structures.SampleStabilityRule{}, cErr
+	}
+
+	rule = mapper.MapSampleStabilityRuleRequest(rule, ruleRequest, userId)
+	if cErr := sampleStabilityService.validateDuplicateSampleStabilityRule(rule); cErr != nil {
+		return structures.SampleStabilityRule{}, cErr
+	}
+
+	rule, cErr = sampleStabilityService.SampleStabilityDao.UpdateSampleStabilityRule(rule)
+	if cErr != nil {
+		return structures.SampleStabilityRule{}, cErr
+	}
+
+	sampleStabilityService.invalidateSampleStabilityRulesCache(ctx)
+	return mapper.MapSampleStabilityRule(rule), nil
+}
+
+func (sampleStabilityService *SampleStabilityService) DeleteSampleStabilityRule(ctx context.Context,
+	ruleId, userId uint) *commonStructures.CommonError {
+
+	if _, cErr := sampleStabilityService.getSampleStabilityRuleById(ruleId); cErr != nil {
+		return cErr
+	}
+
+	if cErr := sampleStabilityService.SampleStabilityDao.DeleteSampleStabilityRule(ruleId, userId); cErr != nil {
+		return cErr
+	}
+
+	sampleStabilityService.invalidateSampleStabilityRulesCache(ctx)
+	return nil
+}
+
+// getActiveSampleStabilityRules returns the active rules from the cache, falling back to the database.
+// Any error is logged and no rules are returned so that receiving is never blocked on the rules.
+func (sampleStabilityService *SampleStabilityService) getActiveSampleStabilityRules(
+	ctx context.Context) []commonModels.SampleStabilityRule {
+
+	rules := []commonModels.SampleStabilityRule{}
+	err := sampleStabilityService.Cache.Get(ctx, commonConstants.CacheKeySampleStabilityRulesAll, &rules)
+	if err == nil {
+		return rules
+	}
+
+	rules, cErr := sampleStabilityService.SampleStabilityDao.GetActiveSampleStabilityRules()
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_FETCHING_SAMPLE_STABILITY_RULES,
+			nil, errors.New(cErr.Message))
+		return []commonModels.SampleStabilityRule{}
+	}
+	_ = sampleStabilityService.Cache.Set(ctx, commonConstants.CacheKeySampleStabilityRulesAll, rules,
+		commonConstants.CacheExpiry5MinutesInt)
+
+	return rules
+}
+
+func (sampleStabilityService *SampleStabilityService) getSampleStabilityRuleById(ruleId uint) (
+	commonModels.SampleStabilityRule, *commonStructures.CommonError) {
+
+	rule, cErr := sampleStabilityService.SampleStabilityDao.GetSampleStabilityRuleById(ruleId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_SAMPLE_STABILITY_RULE_NOT_FOUND
+		}
+		return rule, cErr
+	}
+
+	return rule, nil
+}
+
+func (sampleStabilityService *SampleStabilityService) validateDuplicateSampleStabilityRule(
+	rule commonModels.SampleStabilityRule) *commonStructures.CommonError {
+
+	existingRule, cErr := sampleStabilityService.SampleStabilityDao.GetSampleStabilityRuleByScope(rule)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return cErr
+	}
+
+	if existingRule.Id != rule.Id {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_SAMPLE_STABILITY_RULE_ALREADY_EXISTS,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	return nil
+}
+
+func (sampleStabilityService *SampleStabilityService) invalidateSampleStabilityRulesCache(ctx context.Context) {
+	if err := sampleStabilityService.Cache.Delete(ctx, commonConstants.CacheKeySampleStabilityRulesAll); err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL,
+			commonConstants.ERROR_WHILE_INVALIDATING_SAMPLE_STABILITY_RULES_KEY, nil, err)
+	}
+}
+
+func validateSampleStabilityRuleRequest(
+	ruleRequest structures.SampleStabilityRuleRequest) *commonStructures.CommonError {
+
+	if !commonUtils.SliceContainsString(commonConstants.StorageConditions, ruleRequest.StorageCondition) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_STORAGE_CONDITION,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if !commonUtils.SliceContainsString(commonConstants.SampleStabilityActions, ruleRequest.Action) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_SAMPLE_STABILITY_ACTION,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if ruleRequest.StabilityHours == 0 {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_SAMPLE_STABILITY_HOURS_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	if ruleRequest.NearExpiryHours >= ruleRequest.StabilityHours {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_SAMPLE_STABILITY_NEAR_EXPIRY_HOURS_INVALID,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
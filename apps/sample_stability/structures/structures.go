@@ -0,0 +1,103 @@
+package structures
+
+import (
+	"time"
+)
+
+// @swagger:model SampleStabilityRule
+type SampleStabilityRule struct {
+	// The sample stability rule ID.
+	// example: 1
+	Id uint `json:"id"`
+	// The lab the rule applies to. 0 applies the rule to all labs.
+	// example: 0
+	LabId uint `json:"lab_id"`
+	// The vial type the rule applies to. 0 applies the rule to all vial types.
+	// example: 3
+	VialTypeId uint `json:"vial_type_id"`
+	// The master test the rule applies to. 0 applies the rule to all tests on the vial.
+	// example: 0
+	MasterTestId uint `json:"master_test_id"`
+	// The condition the sample is stored in, one of ambient, refrigerated and frozen.
+	// example: "ambient"
+	StorageCondition string `json:"storage_condition"`
+	// The hours after collection the sample remains stable.
+	// example: 24
+	StabilityHours uint `json:"stability_hours"`
+	// The hours before expiry from which the sample is listed on the near expiry report.
+	// example: 2
+	NearExpiryHours uint `json:"near_expiry_hours"`
+	// What happens to a sample beyond stability, reject or flag.
+	// example: "reject"
+	Action string `json:"action"`
+	// If the rule is active.
+	// example: true
+	IsActive bool `json:"is_active"`
+}
+
+type SampleStabilityRuleRequest struct {
+	LabId            uint   `json:"lab_id"`
+	VialTypeId       uint   `json:"vial_type_id"`
+	MasterTestId     uint   `json:"master_test_id"`
+	StorageCondition string `json:"storage_condition" binding:"required"`
+	StabilityHours   uint   `json:"stability_hours" binding:"required"`
+	NearExpiryHours  uint   `json:"near_expiry_hours"`
+	Action           string `json:"action" binding:"required"`
+	IsActive         *bool  `json:"is_active"`
+}
+
+// @swagger:model NearExpirySample
+type NearExpirySample struct {
+	// The sample ID.
+	// example: 1
+	SampleId uint `json:"sample_id"`
+	// The sample number within the order.
+	// example: 1
+	SampleNumber uint `json:"sample_number"`
+	// The barcode of the sample.
+	// example: "AB123456"
+	Barcode string `json:"barcode"`
+	// The OMS order ID.
+	// example: "OR1234"
+	OmsOrderId string `json:"oms_order_id"`
+	// The vial type of the sample.
+	// example: 3
+	VialTypeId uint `json:"vial_type_id"`
+	// The status of the sample.
+	// example: "synced"
+	Status string `json:"status"`
+	// The condition the sample is stored in.
+	// example: "ambient"
+	StorageCondition string `json:"storage_condition"`
+	// When the sample was collected.
+	// example: "2024-01-01T00:00:00Z"
+	CollectedAt *time.Time `json:"collected_at"`
+	// The rule with the earliest expiry for the sample.
+	// example: 1
+	SampleStabilityRuleId uint `json:"sample_stability_rule_id"`
+	// The stability of the sample in hours.
+	// example: 24
+	StabilityHours uint `json:"stability_hours"`
+	// When the sample goes beyond stability.
+	// example: "2024-01-02T00:00:00Z"
+	ExpiresAt *time.Time `json:"expires_at"`
+	// Minutes left before the sample goes beyond stability. Negative once it has expired.
+	// example: 45
+	MinutesToExpiry int `json:"minutes_to_expiry"`
+	// If the sample is already beyond stability.
+	// example: false
+	IsExpired bool `json:"is_expired"`
+}
+
+// InLabSampleTest is a sample waiting in the lab along with one of the tests mapped to it.
+type InLabSampleTest struct {
+	SampleId         uint       `json:"sample_id"`
+	SampleNumber     uint       `json:"sample_number"`
+	Barcode          string     `json:"barcode"`
+	OmsOrderId       string     `json:"oms_order_id"`
+	VialTypeId       uint       `json:"vial_type_id"`
+	Status           string     `json:"status"`
+	CollectedAt      *time.Time `json:"collected_at"`
+	StorageCondition string     `json:"storage_condition"`
+	MasterTestId     uint       `json:"master_test_id"`
+}
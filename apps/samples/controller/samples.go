@@ -363,7 +363,7 @@ func (s *Sample) ForcefullyMarkSampleAsCollected(c *gin.Context) {
 	}
 	requestBody.UserId = userId
 
-	cErr = s.SampleService.ForcefullyMarkSampleAsCollected(requestBody)
+	cErr = s.SampleService.ForcefullyMarkSampleAsCollected(c.Request.Context(), requestBody)
 	if cErr != nil {
 		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
 		return
@@ -389,3 +389,52 @@ func (s *Sample) GetSrfOrderIds(c *gin.Context) {
 		Data: orderIds,
 	})
 }
+
+func (s *Sample) CreateSampleAliquots(c *gin.Context) {
+	sampleId := commonUtils.ConvertStringToUint(c.Param("sampleId"))
+	if sampleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_SAMPLE_ID_CANNOT_BE_ZERO)
+		return
+	}
+
+	requestBody := structures.CreateSampleAliquotsRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+	requestBody.UserId = userId
+
+	response, cErr := s.SampleService.CreateSampleAliquots(c.Request.Context(), sampleId, requestBody)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructures.CommonAPIResponse{
+		Data: response,
+	})
+}
+
+func (s *Sample) GetSampleAliquots(c *gin.Context) {
+	sampleId := commonUtils.ConvertStringToUint(c.Param("sampleId"))
+	if sampleId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_SAMPLE_ID_CANNOT_BE_ZERO)
+		return
+	}
+
+	response, cErr := s.SampleService.GetSampleAliquots(c.Request.Context(), sampleId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructures.CommonAPIResponse{
+		Data: response,
+	})
+}
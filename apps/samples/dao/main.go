@@ -92,6 +92,13 @@ type DataLayer interface {
 	GetInterlabSamplesByParentSampleId(ctx context.Context, parentSampleId uint) ([]commonStructures.SampleInfo,
 		*commonStructures.CommonError)
 
+	// Sample Aliquots
+	CreateSampleAliquotsWithTx(tx *gorm.DB, sampleAliquots []commonModels.SampleAliquot) *commonStructures.CommonError
+	GetSampleAliquotsByParentSampleId(ctx context.Context, parentSampleId uint) ([]commonModels.SampleAliquot,
+		*commonStructures.CommonError)
+	GetSampleAliquotsByParentSampleIdWithTx(tx *gorm.DB, parentSampleId uint) ([]commonModels.SampleAliquot,
+		*commonStructures.CommonError)
+
 	GetSamplesForDelayedReverseLogistics(normalTat, campTat, inclinicTat, days uint) (
 		[]structures.DelayedReverseLogisticsSamplesDbStruct, *commonStructures.CommonError)
 	GetSamplesForDelayedInterlabLogistics() ([]commonStructures.SampleInfo, *commonStructures.CommonError)
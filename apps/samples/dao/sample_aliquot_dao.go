@@ -0,0 +1,49 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func (sampleDao *SampleDao) CreateSampleAliquotsWithTx(tx *gorm.DB,
+	sampleAliquots []commonModels.SampleAliquot) *commonStructures.CommonError {
+	if len(sampleAliquots) == 0 {
+		return nil
+	}
+
+	if err := tx.Create(&sampleAliquots).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+func (sampleDao *SampleDao) GetSampleAliquotsByParentSampleId(ctx context.Context, parentSampleId uint) (
+	[]commonModels.SampleAliquot, *commonStructures.CommonError) {
+	sampleAliquots := []commonModels.SampleAliquot{}
+	if err := sampleDao.Db.
+		Where("parent_sample_id = ?", parentSampleId).
+		Order("id").
+		Find(&sampleAliquots).Error; err != nil {
+		return nil, commonUtils.HandleORMError(err)
+	}
+
+	return sampleAliquots, nil
+}
+
+func (sampleDao *SampleDao) GetSampleAliquotsByParentSampleIdWithTx(tx *gorm.DB, parentSampleId uint) (
+	[]commonModels.SampleAliquot, *commonStructures.CommonError) {
+	sampleAliquots := []commonModels.SampleAliquot{}
+	if err := tx.Where("parent_sample_id = ?", parentSampleId).
+		Order("id").
+		Find(&sampleAliquots).Error; err != nil {
+		return nil, commonUtils.HandleORMError(err)
+	}
+
+	return sampleAliquots, nil
+}
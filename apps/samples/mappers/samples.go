@@ -39,6 +39,9 @@ func MapSampleSampleMetaToSampleInfo(sample commonModels.Sample,
 		CollectLaterReason:       sampleMeta.CollectLaterReason,
 		RejectingLab:             sampleMeta.RejectingLab,
 		CollectedVolume:          sampleMeta.CollectedVolume,
+		StorageCondition:         sampleMeta.StorageCondition,
+		StabilityFlaggedAt:       sampleMeta.StabilityFlaggedAt,
+		StabilityFlagReason:      sampleMeta.StabilityFlagReason,
 		CreatedAt:                sample.CreatedAt,
 		CreatedBy:                sample.CreatedBy,
 		UpdatedAt:                sample.UpdatedAt,
@@ -127,6 +130,9 @@ func MapSampleInfoToSampleMetadata(sampleInfo commonStructures.SampleInfo) commo
 		CollectLaterReason:       sampleInfo.CollectLaterReason,
 		TaskSequence:             sampleInfo.TaskSequence,
 		CollectedVolume:          sampleInfo.CollectedVolume,
+		StorageCondition:         sampleInfo.StorageCondition,
+		StabilityFlaggedAt:       sampleInfo.StabilityFlaggedAt,
+		StabilityFlagReason:      sampleInfo.StabilityFlagReason,
 		BaseModel:                baseModel,
 	}
 }
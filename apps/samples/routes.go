@@ -24,6 +24,7 @@ func RouteHandler(router *gin.RouterGroup) {
 		sampleController.GetSamplesForDelayedReverseLogisticsDashboard)
 	router.GET("/srf-order-ids", tokenAuthMiddleware.Authenticate(commonConstants.OmsServiceName),
 		sampleController.GetSrfOrderIds)
+	router.GET("/sample/:sampleId/aliquots", sampleController.GetSampleAliquots)
 
 	router.POST("/remap-samples", tokenAuthMiddleware.Authenticate(commonConstants.OmsServiceName),
 		sampleController.RemapSamplesToNewTaskSequence)
@@ -35,6 +36,7 @@ func RouteHandler(router *gin.RouterGroup) {
 		sampleController.GetSampleDetailsForScheduler)
 	router.POST("/scheduler/update-sample-details", tokenAuthMiddleware.Authenticate(commonConstants.OmsServiceName),
 		sampleController.UpdateSampleDetailsPostTaskCompletion)
+	router.POST("/sample/:sampleId/aliquots", sampleController.CreateSampleAliquots)
 
 	router.PATCH("/collected", sampleController.UpdateSampleCollected)
 	router.PATCH("/forcefully-mark-collected", sampleController.ForcefullyMarkSampleAsCollected)
@@ -8,10 +8,11 @@ import (
 
 	"github.com/Orange-Health/citadel/adapters/cache"
 	"github.com/Orange-Health/citadel/adapters/sentry"
-	attuneService "github.com/Orange-Health/citadel/apps/attune/service"
 	cdsService "github.com/Orange-Health/citadel/apps/cds/service"
 	etsService "github.com/Orange-Health/citadel/apps/ets/service"
+	lisService "github.com/Orange-Health/citadel/apps/lis/service"
 	orderDetailsService "github.com/Orange-Health/citadel/apps/order_details/service"
+	outboxService "github.com/Orange-Health/citadel/apps/outbox/service"
 	patientDetailsService "github.com/Orange-Health/citadel/apps/patient_details/service"
 	pubsubService "github.com/Orange-Health/citadel/apps/pubsub/service"
 	"github.com/Orange-Health/citadel/apps/samples/dao"
@@ -20,7 +21,6 @@ import (
 	tsmService "github.com/Orange-Health/citadel/apps/test_sample_mapping/service"
 	accountsApiClient "github.com/Orange-Health/citadel/clients/accounts_api"
 	slackClient "github.com/Orange-Health/citadel/clients/slack"
-	snsClient "github.com/Orange-Health/citadel/clients/sns"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
 	commonModels "github.com/Orange-Health/citadel/models"
 )
@@ -34,10 +34,10 @@ type SampleService struct {
 	PatientDetailsService    patientDetailsService.PatientDetailServiceInterface
 	TestDetailsService       testDetailsService.TestDetailServiceInterface
 	TestSampleMappingService tsmService.TestSampleMappingServiceInterface
-	AttuneService            attuneService.AttuneServiceInterface
+	LisService               lisService.LisServiceInterface
 	EtsService               etsService.EtsServiceInterface
 	PubsubService            pubsubService.PubsubInterface
-	SnsClient                snsClient.SnsClientInterface
+	OutboxService            outboxService.OutboxServiceInterface
 	AccountsApiClient        accountsApiClient.AccountsApiClientInterface
 	SlackClient              slackClient.SlackClientInterface
 }
@@ -85,7 +85,8 @@ type SampleServiceInterface interface {
 	SynchronizeTasksWithSamplesWithTx(tx *gorm.DB, omsRequestId string, tasks []commonStructures.OmsTaskModelDetails,
 		taskTestsMapping [][]commonStructures.TestsJsonStruct) *commonStructures.CommonError
 	UpdateSampleCollected(sampleCollectionRequest commonStructures.SampleCollectedRequest) *commonStructures.CommonError
-	ForcefullyMarkSampleAsCollected(request structures.ForcefullyMarkSampleAsCollectedRequest) *commonStructures.CommonError
+	ForcefullyMarkSampleAsCollected(ctx context.Context,
+		request structures.ForcefullyMarkSampleAsCollectedRequest) *commonStructures.CommonError
 	AddBarcodeDetails(barcodeDetails structures.AddBarcodesRequest) (map[string]string, *commonStructures.CommonError)
 	AddBarcodeDetailsForOrangers(accessionBody structures.UpdateAccessionBody) (
 		map[string]string, *commonStructures.CommonError)
@@ -104,6 +105,8 @@ type SampleServiceInterface interface {
 	AddCollectedVolumeToSample(requestBody structures.AddCollectedVolumneRequest) (
 		structures.AddVolumeResponse, *commonStructures.CommonError)
 	RemoveSamplesNotLinkedToAnyTests(omsOrderId string) *commonStructures.CommonError
+	RejectSample(ctx context.Context, userId, labId uint, rejectionReason string, samples []commonModels.Sample,
+		sampleMetadatas []commonModels.SampleMetadata) ([]string, *commonStructures.CommonError)
 	RejectSampleByBarcode(ctx context.Context, barcode string, requestBody structures.RejectSampleRequest) (string, []string,
 		*commonStructures.CommonError)
 	RejectSamplePartiallyBySampleNumberAndTestId(ctx context.Context, requestBody structures.RejectSamplePartiallyRequest) (
@@ -115,17 +118,31 @@ type SampleServiceInterface interface {
 	DeleteAllSamplesDataByOmsOrderIdWithTx(tx *gorm.DB, omsOrderId string) *commonStructures.CommonError
 	UpdateSrfIdToLis(ctx context.Context, orderDetails commonModels.OrderDetails) *commonStructures.CommonError
 
-	// Publish events functions
-	PublishResetTatsEvent(omsTestIds []string, cityCode string)
-	PublishUpdateTestStatusEvent(omsTestStatusMap map[string]string, omsOrderId string, checkOrderCompletion bool,
-		cityCode string)
-	PublishRemoveSampleRejectedTagEvent(omsRequestId string, omsOrderIds []string, removeRequestTag bool, cityCode string)
-	PublishLisDataEvent(attuneOrderResponse commonStructures.AttuneOrderResponse)
+	// Outbox events functions
+	WriteSampleCollectedEventWithTx(ctx context.Context, tx *gorm.DB, omsTestIds []string, collectedAt *time.Time,
+		cityCode string) *commonStructures.CommonError
+	WriteAddSampleRejectedTagEventWithTx(ctx context.Context, tx *gorm.DB, omsOrderId,
+		servicingCityCode string) *commonStructures.CommonError
+	WriteRemoveSampleRejectedTagEvent(ctx context.Context, omsRequestId string, omsOrderIds []string,
+		removeRequestTag bool, cityCode string)
+	WriteLisDataEvent(ctx context.Context, visitId, webhookData string)
+	WriteResetTatsEventWithTx(ctx context.Context, tx *gorm.DB, omsTestIds []string,
+		cityCode string) *commonStructures.CommonError
+	WriteUpdateTestStatusEventWithTx(ctx context.Context, tx *gorm.DB, omsTestStatusMap map[string]string,
+		omsOrderId string, checkOrderCompletion bool, cityCode string) *commonStructures.CommonError
+	WriteLabEtaUpdateEventWithTx(ctx context.Context, tx *gorm.DB, omsOrderId string, omsTestIds []string,
+		lisSyncAt *time.Time, cityCode string) *commonStructures.CommonError
 
 	// Outbound Samples
 	CreateInterlabSamplesWithTx(ctx context.Context, tx *gorm.DB, samples []commonModels.Sample,
 		samplesMetadata []commonModels.SampleMetadata, sampleNumberToLabIdMap map[uint]uint) *commonStructures.CommonError
 
+	// Sample Aliquots
+	CreateSampleAliquots(ctx context.Context, sampleId uint, requestBody structures.CreateSampleAliquotsRequest) (
+		structures.SampleAliquotsResponse, *commonStructures.CommonError)
+	GetSampleAliquots(ctx context.Context, sampleId uint) (structures.SampleAliquotsResponse,
+		*commonStructures.CommonError)
+
 	GetSamplesForDelayedReverseLogisticsDashboard(ctx context.Context,
 		cityCode string) []structures.DelayedReverseLogisticsSamplesResponse
 	GetSrfOrderIds(ctx context.Context, cityCode string) []string
@@ -143,10 +160,10 @@ func InitializeSampleService() SampleServiceInterface {
 		TestDetailsService:       testDetailsService.InitializeTestDetailService(),
 		CdsService:               cdsService.InitializeCdsService(),
 		TestSampleMappingService: tsmService.InitializeTestSampleMappingService(),
-		AttuneService:            attuneService.InitializeAttuneService(),
+		LisService:               lisService.InitializeLisService(),
 		EtsService:               etsService.InitializeEtsService(),
 		PubsubService:            pubsubService.InitializePubsubService(),
-		SnsClient:                snsClient.InitializeSnsClient(),
+		OutboxService:            outboxService.InitializeOutboxService(),
 		AccountsApiClient:        accountsApiClient.InitializeAccountsApiClient(),
 		SlackClient:              slackClient.InitializeSlackClient(),
 	}
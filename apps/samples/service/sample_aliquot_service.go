@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	mappers "github.com/Orange-Health/citadel/apps/samples/mappers"
+	"github.com/Orange-Health/citadel/apps/samples/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+// CreateSampleAliquots splits a sample in the lab into aliquots with new barcodes and moves the requested tests to
+// them. Aliquots are primary samples of the order with their own sample number, the lineage to the parent tube is
+// kept in sample_aliquots. Aliquots processed in the same lab are added to the parent's LIS visit once the split is
+// committed, the rest stay received to go through the usual dispatch flow.
+func (sampleService *SampleService) CreateSampleAliquots(ctx context.Context, sampleId uint,
+	requestBody structures.CreateSampleAliquotsRequest) (structures.SampleAliquotsResponse, *commonStructures.CommonError) {
+
+	cErr := validateCreateSampleAliquotsRequest(requestBody)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+
+	sample, sampleMetadata, cErr := sampleService.SampleDao.GetSampleDataBySampleId(sampleId)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+	if sample.Id == 0 {
+		return structures.SampleAliquotsResponse{}, &commonStructures.CommonError{
+			StatusCode: http.StatusNotFound,
+			Message:    commonConstants.ERROR_SAMPLE_NOT_FOUND,
+		}
+	}
+	if !commonUtils.SliceContainsString(commonConstants.AliquotEligibleSampleStatuses, sample.Status) {
+		return structures.SampleAliquotsResponse{}, &commonStructures.CommonError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf(commonConstants.ERROR_SAMPLE_NOT_ELIGIBLE_FOR_ALIQUOTING, sample.Status),
+		}
+	}
+
+	barcodes := []string{}
+	for _, aliquot := range requestBody.Aliquots {
+		barcodes = append(barcodes, strings.TrimSpace(aliquot.Barcode))
+	}
+	barcodesExist, cErr := sampleService.SampleDao.BarcodesExistsInSystem(barcodes)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+	if barcodesExist {
+		return structures.SampleAliquotsResponse{}, &commonStructures.CommonError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf(commonConstants.ERROR_BARCODE_EXISTS_IN_SYSTEM, strings.Join(barcodes, ", ")),
+		}
+	}
+
+	testDetails, testSampleMappings, cErr := sampleService.SampleDao.GetAllTestsAndSampleMappingsBySampleNumbers(
+		[]uint{sample.SampleNumber}, sample.OmsOrderId)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+	testIdToTestDetailMap := map[string]commonModels.TestDetail{}
+	for _, testDetail := range testDetails {
+		testIdToTestDetailMap[testDetail.CentralOmsTestId] = testDetail
+	}
+	testIdToTestSampleMappingMap := map[string]commonModels.TestSampleMapping{}
+	for _, testSampleMapping := range testSampleMappings {
+		if testSampleMapping.IsRejected {
+			continue
+		}
+		testIdToTestSampleMappingMap[testSampleMapping.OmsTestId] = testSampleMapping
+	}
+
+	movedTestIds := map[string]bool{}
+	requestedVolume := uint(0)
+	for _, aliquot := range requestBody.Aliquots {
+		requestedVolume += aliquot.Volume
+		for _, testId := range aliquot.TestIds {
+			if _, ok := testIdToTestSampleMappingMap[testId]; !ok {
+				return structures.SampleAliquotsResponse{}, &commonStructures.CommonError{
+					StatusCode: http.StatusBadRequest,
+					Message:    fmt.Sprintf(commonConstants.ERROR_TEST_NOT_MAPPED_TO_SAMPLE, testId),
+				}
+			}
+			if movedTestIds[testId] {
+				return structures.SampleAliquotsResponse{}, &commonStructures.CommonError{
+					StatusCode: http.StatusBadRequest,
+					Message:    fmt.Sprintf(commonConstants.ERROR_TEST_MAPPED_TO_MULTIPLE_ALIQUOTS, testId),
+				}
+			}
+			movedTestIds[testId] = true
+		}
+	}
+
+	tx := sampleService.SampleDao.BeginTransaction()
+	defer tx.Rollback()
+
+	existingAliquots, cErr := sampleService.SampleDao.GetSampleAliquotsByParentSampleIdWithTx(tx, sample.Id)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+	if sampleMetadata.CollectedVolume > 0 {
+		remainingVolume := getRemainingSampleVolume(sampleMetadata.CollectedVolume, existingAliquots)
+		if requestedVolume > remainingVolume {
+			return structures.SampleAliquotsResponse{}, &commonStructures.CommonError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf(commonConstants.ERROR_ALIQUOT_VOLUME_EXCEEDED, requestedVolume, remainingVolume),
+			}
+		}
+	}
+
+	syncToParentVisit := sample.VisitId != "" && commonUtils.SliceContainsString([]string{
+		commonConstants.SampleSynced, commonConstants.SampleAccessioned, commonConstants.SamplePartiallyRejected,
+	}, sample.Status)
+
+	currentTime := commonUtils.GetCurrentTime()
+	sampleNumber := sampleService.SampleDao.GetMaxSampleNumberByOmsOrderIdWithTx(tx, sample.OmsOrderId)
+	updateTestSampleMappings, sampleAliquots := []commonModels.TestSampleMapping{}, []commonModels.SampleAliquot{}
+	lisSyncAliquots := []sampleAliquotLisSync{}
+	for _, aliquot := range requestBody.Aliquots {
+		sampleNumber++
+		aliquotSample := getAliquotSampleInfo(sample, sampleMetadata, aliquot, sampleNumber, requestBody.UserId)
+		sameLab := aliquotSample.DestinationLabId == sample.LabId
+		if sameLab && syncToParentVisit {
+			aliquotSample.VisitId = sample.VisitId
+			aliquotSample.Status = commonConstants.SampleSynced
+			aliquotSample.LisSyncAt = currentTime
+		}
+
+		aliquotSample, cErr = sampleService.SampleDao.CreateSampleWithTx(tx, aliquotSample)
+		if cErr != nil {
+			return structures.SampleAliquotsResponse{}, cErr
+		}
+
+		department := strings.TrimSpace(aliquot.Department)
+		aliquotTestDetails := []commonModels.TestDetail{}
+		for _, testId := range aliquot.TestIds {
+			testSampleMapping := testIdToTestSampleMappingMap[testId]
+			testSampleMapping.SampleId = aliquotSample.Id
+			testSampleMapping.SampleNumber = aliquotSample.SampleNumber
+			testSampleMapping.VialTypeId = aliquotSample.VialTypeId
+			testSampleMapping.UpdatedBy = requestBody.UserId
+			updateTestSampleMappings = append(updateTestSampleMappings, testSampleMapping)
+			if department == "" {
+				department = testIdToTestDetailMap[testId].Department
+			}
+
+			aliquotTestDetails = append(aliquotTestDetails, testIdToTestDetailMap[testId])
+		}
+		if sameLab && syncToParentVisit {
+			lisSyncAliquots = append(lisSyncAliquots, sampleAliquotLisSync{
+				AliquotSample: aliquotSample,
+				TestDetails:   aliquotTestDetails,
+			})
+		}
+
+		sampleAliquot := commonModels.SampleAliquot{
+			OmsOrderId:       sample.OmsOrderId,
+			ParentSampleId:   sample.Id,
+			AliquotSampleId:  aliquotSample.Id,
+			Volume:           aliquot.Volume,
+			Department:       department,
+			DestinationLabId: aliquotSample.DestinationLabId,
+			AliquotedAt:      currentTime,
+		}
+		sampleAliquot.CreatedBy = requestBody.UserId
+		sampleAliquot.UpdatedBy = requestBody.UserId
+		sampleAliquots = append(sampleAliquots, sampleAliquot)
+	}
+
+	_, cErr = sampleService.TestSampleMappingService.UpdateBulkTestSampleMappingWithTx(tx, updateTestSampleMappings)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+
+	cErr = sampleService.SampleDao.CreateSampleAliquotsWithTx(tx, sampleAliquots)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return structures.SampleAliquotsResponse{}, commonUtils.HandleORMError(err)
+	}
+
+	sampleService.syncSampleAliquotsToLis(ctx, mappers.MapSampleSampleMetaToSampleInfo(sample, sampleMetadata),
+		lisSyncAliquots)
+
+	return sampleService.GetSampleAliquots(ctx, sample.Id)
+}
+
+// sampleAliquotLisSync is an aliquot to be added to the LIS visit of its parent along with the tests moved to it.
+type sampleAliquotLisSync struct {
+	AliquotSample commonStructures.SampleInfo
+	TestDetails   []commonModels.TestDetail
+}
+
+// syncSampleAliquotsToLis moves the tests of the committed aliquots from the parent barcode to the aliquot barcodes
+// on the LIS visit, adding each test to its aliquot before cancelling it on the parent. An aliquot none of whose
+// tests could be added is set back to received, so that it goes through the usual sync flow instead.
+func (sampleService *SampleService) syncSampleAliquotsToLis(ctx context.Context,
+	parentSample commonStructures.SampleInfo, lisSyncAliquots []sampleAliquotLisSync) {
+
+	for _, lisSyncAliquot := range lisSyncAliquots {
+		aliquotSample, addedTestCount := lisSyncAliquot.AliquotSample, 0
+		for _, testDetail := range lisSyncAliquot.TestDetails {
+			cErr := sampleService.LisService.ModifyOrder(ctx, parentSample.OmsOrderId, parentSample.LabId,
+				testDetail, aliquotSample)
+			if cErr == nil {
+				addedTestCount++
+				cErr = sampleService.LisService.CancelOrder(ctx, []commonModels.TestDetail{testDetail},
+					parentSample.VisitId, parentSample)
+			}
+			if cErr != nil {
+				commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_SYNCING_SAMPLE_ALIQUOT,
+					map[string]interface{}{
+						"visit_id":          parentSample.VisitId,
+						"parent_sample_id":  parentSample.Id,
+						"aliquot_sample_id": aliquotSample.Id,
+						"oms_test_id":       testDetail.CentralOmsTestId,
+					}, errors.New(cErr.Message))
+				break
+			}
+		}
+
+		if addedTestCount > 0 {
+			continue
+		}
+		savedSample, savedSampleMetadata, cErr := sampleService.SampleDao.GetSampleDataBySampleId(aliquotSample.Id)
+		if cErr == nil {
+			savedSample.VisitId = ""
+			savedSample.Status = commonConstants.SampleReceived
+			savedSampleMetadata.LisSyncAt = nil
+			_, _, cErr = sampleService.SampleDao.UpdateSamplesAndSamplesMetadata(
+				[]commonModels.Sample{savedSample}, []commonModels.SampleMetadata{savedSampleMetadata})
+		}
+		if cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_SYNCING_SAMPLE_ALIQUOT,
+				map[string]interface{}{
+					"aliquot_sample_id": aliquotSample.Id,
+				}, errors.New(cErr.Message))
+		}
+	}
+}
+
+// GetSampleAliquots returns the aliquots split from a sample along with the volume consumed by them.
+func (sampleService *SampleService) GetSampleAliquots(ctx context.Context, sampleId uint) (
+	structures.SampleAliquotsResponse, *commonStructures.CommonError) {
+
+	sample, sampleMetadata, cErr := sampleService.SampleDao.GetSampleDataBySampleId(sampleId)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+	if sample.Id == 0 {
+		return structures.SampleAliquotsResponse{}, &commonStructures.CommonError{
+			StatusCode: http.StatusNotFound,
+			Message:    commonConstants.ERROR_SAMPLE_NOT_FOUND,
+		}
+	}
+
+	sampleAliquots, cErr := sampleService.SampleDao.GetSampleAliquotsByParentSampleId(ctx, sample.Id)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+
+	response := structures.SampleAliquotsResponse{
+		SampleId:        sample.Id,
+		Barcode:         sample.Barcode,
+		CollectedVolume: sampleMetadata.CollectedVolume,
+		RemainingVolume: getRemainingSampleVolume(sampleMetadata.CollectedVolume, sampleAliquots),
+		Aliquots:        []structures.SampleAliquotDetails{},
+	}
+	if len(sampleAliquots) == 0 {
+		return response, nil
+	}
+
+	aliquotSampleIds, aliquotSampleNumbers := []uint{}, []uint{}
+	for _, sampleAliquot := range sampleAliquots {
+		response.ConsumedVolume += sampleAliquot.Volume
+		aliquotSampleIds = append(aliquotSampleIds, sampleAliquot.AliquotSampleId)
+	}
+	aliquotSamples, _, cErr := sampleService.SampleDao.GetSamplesDataBySampleIds(aliquotSampleIds)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+	sampleIdToSampleMap := map[uint]commonModels.Sample{}
+	for _, aliquotSample := range aliquotSamples {
+		sampleIdToSampleMap[aliquotSample.Id] = aliquotSample
+		aliquotSampleNumbers = append(aliquotSampleNumbers, aliquotSample.SampleNumber)
+	}
+
+	_, testSampleMappings, cErr := sampleService.SampleDao.GetAllTestsAndSampleMappingsBySampleNumbers(
+		aliquotSampleNumbers, sample.OmsOrderId)
+	if cErr != nil {
+		return structures.SampleAliquotsResponse{}, cErr
+	}
+	sampleNumberToTestIdsMap := map[uint][]string{}
+	for _, testSampleMapping := range testSampleMappings {
+		sampleNumberToTestIdsMap[testSampleMapping.SampleNumber] = append(
+			sampleNumberToTestIdsMap[testSampleMapping.SampleNumber], testSampleMapping.OmsTestId)
+	}
+
+	for _, sampleAliquot := range sampleAliquots {
+		aliquotSample := sampleIdToSampleMap[sampleAliquot.AliquotSampleId]
+		testIds := sampleNumberToTestIdsMap[aliquotSample.SampleNumber]
+		if testIds == nil {
+			testIds = []string{}
+		}
+		response.Aliquots = append(response.Aliquots, structures.SampleAliquotDetails{
+			SampleId:         sampleAliquot.AliquotSampleId,
+			SampleNumber:     aliquotSample.SampleNumber,
+			Barcode:          aliquotSample.Barcode,
+			VialTypeId:       aliquotSample.VialTypeId,
+			Status:           aliquotSample.Status,
+			LabId:            aliquotSample.LabId,
+			DestinationLabId: sampleAliquot.DestinationLabId,
+			VisitId:          aliquotSample.VisitId,
+			Volume:           sampleAliquot.Volume,
+			Department:       sampleAliquot.Department,
+			AliquotedAt:      sampleAliquot.AliquotedAt,
+			TestIds:          testIds,
+		})
+	}
+
+	return response, nil
+}
+
+func validateCreateSampleAliquotsRequest(requestBody structures.CreateSampleAliquotsRequest) *commonStructures.CommonError {
+	if len(requestBody.Aliquots) == 0 {
+		return &commonStructures.CommonError{
+			StatusCode: http.StatusBadRequest,
+			Message:    commonConstants.ERROR_ALIQUOTS_REQUIRED,
+		}
+	}
+
+	barcodes := map[string]bool{}
+	for _, aliquot := range requestBody.Aliquots {
+		barcode := strings.TrimSpace(aliquot.Barcode)
+		if barcode == "" {
+			return &commonStructures.CommonError{
+				StatusCode: http.StatusBadRequest,
+				Message:    commonConstants.ERROR_ALIQUOT_BARCODE_REQUIRED,
+			}
+		}
+		if barcodes[barcode] {
+			return &commonStructures.CommonError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf(commonConstants.ERROR_ALIQUOT_BARCODE_REPEATED, barcode),
+			}
+		}
+		barcodes[barcode] = true
+		if aliquot.Volume == 0 {
+			return &commonStructures.CommonError{
+				StatusCode: http.StatusBadRequest,
+				Message:    commonConstants.ERROR_ALIQUOT_VOLUME_REQUIRED,
+			}
+		}
+		if len(aliquot.TestIds) == 0 {
+			return &commonStructures.CommonError{
+				StatusCode: http.StatusBadRequest,
+				Message:    commonConstants.ERROR_ALIQUOT_TEST_IDS_REQUIRED,
+			}
+		}
+	}
+
+	return nil
+}
+
+func getAliquotSampleInfo(sample commonModels.Sample, sampleMetadata commonModels.SampleMetadata,
+	aliquot structures.SampleAliquotRequest, sampleNumber, userId uint) commonStructures.SampleInfo {
+
+	vialTypeId := aliquot.VialTypeId
+	if vialTypeId == 0 {
+		vialTypeId = sample.VialTypeId
+	}
+	destinationLabId := aliquot.DestinationLabId
+	if destinationLabId == 0 {
+		destinationLabId = sample.LabId
+	}
+	currentTime := commonUtils.GetCurrentTime()
+
+	return commonStructures.SampleInfo{
+		OmsCityCode:              sample.OmsCityCode,
+		OmsOrderId:               sample.OmsOrderId,
+		OmsRequestId:             sample.OmsRequestId,
+		LabId:                    sample.LabId,
+		VialTypeId:               vialTypeId,
+		DestinationLabId:         destinationLabId,
+		CollectionSequenceNumber: sampleMetadata.CollectionSequenceNumber,
+		Barcode:                  strings.TrimSpace(aliquot.Barcode),
+		Status:                   commonConstants.SampleReceived,
+		SampleNumber:             sampleNumber,
+		TaskSequence:             sampleMetadata.TaskSequence,
+		LastUpdatedAt:            currentTime,
+		BarcodeScannedAt:         currentTime,
+		CollectedAt:              sampleMetadata.CollectedAt,
+		ReceivedAt:               sampleMetadata.ReceivedAt,
+		CollectedVolume:          aliquot.Volume,
+		StorageCondition:         sampleMetadata.StorageCondition,
+		CreatedBy:                userId,
+		UpdatedBy:                userId,
+	}
+}
+
+func getRemainingSampleVolume(collectedVolume uint, sampleAliquots []commonModels.SampleAliquot) uint {
+	consumedVolume := uint(0)
+	for _, sampleAliquot := range sampleAliquots {
+		consumedVolume += sampleAliquot.Volume
+	}
+	if consumedVolume >= collectedVolume {
+		return 0
+	}
+	return collectedVolume - consumedVolume
+}
@@ -5,72 +5,73 @@ import (
 	"errors"
 	"time"
 
+	"gorm.io/gorm"
+
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
 	commonUtils "github.com/Orange-Health/citadel/common/utils"
 )
 
-func (sampleService *SampleService) PublishSampleCollectedEvent(omsTestIds []string, collectedAt *time.Time, cityCode string) {
-	ctx := context.Background()
-	messageBody, messageAttributes := sampleService.PubsubService.GetSampleCollectedEvent(omsTestIds, collectedAt, cityCode)
-	cErr := sampleService.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", commonConstants.OmsUpdatesTopicArn,
-		"")
-	if cErr != nil {
-		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
-			errors.New(cErr.Message))
-	}
+// The Write*EventWithTx functions put the events on the outbox in the transaction of the change they announce,
+// so that the outbox relay publishes them once, and only if, the transaction commits. The Write*Event functions
+// are for the events that are not tied to a transaction, which still get the retries of the outbox relay.
+
+func (sampleService *SampleService) WriteSampleCollectedEventWithTx(ctx context.Context, tx *gorm.DB,
+	omsTestIds []string, collectedAt *time.Time, cityCode string) *commonStructures.CommonError {
+	messageBody, messageAttributes := sampleService.PubsubService.GetSampleCollectedEvent(omsTestIds, collectedAt,
+		cityCode)
+	return sampleService.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes, "",
+		commonConstants.OmsUpdatesTopicArn, "")
 }
 
-func (sampleService *SampleService) PublishResetTatsEvent(omsTestIds []string, cityCode string) {
-	ctx := context.Background()
+func (sampleService *SampleService) WriteResetTatsEventWithTx(ctx context.Context, tx *gorm.DB, omsTestIds []string,
+	cityCode string) *commonStructures.CommonError {
 	messageBody, messageAttributes := sampleService.PubsubService.GetResetTestTatsEvent(omsTestIds, cityCode)
-	cErr := sampleService.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", commonConstants.OmsUpdatesTopicArn,
-		"")
-	if cErr != nil {
-		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
-			errors.New(cErr.Message))
-	}
+	return sampleService.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes, "",
+		commonConstants.OmsUpdatesTopicArn, "")
 }
 
-func (sampleService *SampleService) PublishAddSampleRejectedTagEvent(omsOrderId, servicingCityCode string) {
-	ctx := context.Background()
-	messageBody, messageAttributes := sampleService.PubsubService.GetAddSampleRejectedTagEvent(omsOrderId, servicingCityCode)
-	if cErr := sampleService.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", commonConstants.OmsUpdatesTopicArn,
-		""); cErr != nil {
-		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
-			errors.New(cErr.Message))
-	}
+func (sampleService *SampleService) WriteUpdateTestStatusEventWithTx(ctx context.Context, tx *gorm.DB,
+	omsTestStatusMap map[string]string, omsOrderId string, checkOrderCompletion bool,
+	cityCode string) *commonStructures.CommonError {
+	messageBody, messageAttributes := sampleService.PubsubService.GetUpdateTestStatusEvent(omsTestStatusMap, omsOrderId,
+		checkOrderCompletion, cityCode)
+	return sampleService.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes, "",
+		commonConstants.OmsUpdatesTopicArn, "")
 }
 
-func (sampleService *SampleService) PublishRemoveSampleRejectedTagEvent(omsRequestId string, omsOrderIds []string,
-	removeRequestTag bool, cityCode string) {
-	ctx := context.Background()
-	messageBody, messageAttributes := sampleService.PubsubService.GetRemoveSampleRejectedTagEvent(omsRequestId,
-		omsOrderIds, removeRequestTag, cityCode)
-	if cErr := sampleService.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", commonConstants.OmsUpdatesTopicArn,
-		""); cErr != nil {
-		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
-			errors.New(cErr.Message))
-	}
+func (sampleService *SampleService) WriteLabEtaUpdateEventWithTx(ctx context.Context, tx *gorm.DB, omsOrderId string,
+	omsTestIds []string, lisSyncAt *time.Time, cityCode string) *commonStructures.CommonError {
+	messageBody, messageAttributes := sampleService.PubsubService.GetLabEtaUpdateEvent(omsOrderId, omsTestIds,
+		lisSyncAt, cityCode)
+	return sampleService.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes, "",
+		commonConstants.OmsUpdatesTopicArn, "")
 }
 
-func (sampleService *SampleService) PublishUpdateTestStatusEvent(omsTestStatusMap map[string]string, omsOrderId string,
-	checkOrderCompletion bool, cityCode string) {
-	ctx := context.Background()
-	messageBody, messageAttributes := sampleService.PubsubService.GetUpdateTestStatusEvent(omsTestStatusMap, omsOrderId,
-		checkOrderCompletion, cityCode)
-	cErr := sampleService.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", commonConstants.OmsUpdatesTopicArn,
-		"")
+func (sampleService *SampleService) WriteAddSampleRejectedTagEventWithTx(ctx context.Context, tx *gorm.DB,
+	omsOrderId, servicingCityCode string) *commonStructures.CommonError {
+	messageBody, messageAttributes := sampleService.PubsubService.GetAddSampleRejectedTagEvent(omsOrderId,
+		servicingCityCode)
+	return sampleService.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes, "",
+		commonConstants.OmsUpdatesTopicArn, "")
+}
+
+func (sampleService *SampleService) WriteRemoveSampleRejectedTagEvent(ctx context.Context, omsRequestId string,
+	omsOrderIds []string, removeRequestTag bool, cityCode string) {
+	messageBody, messageAttributes := sampleService.PubsubService.GetRemoveSampleRejectedTagEvent(omsRequestId,
+		omsOrderIds, removeRequestTag, cityCode)
+	cErr := sampleService.OutboxService.WriteMessage(ctx, messageBody, messageAttributes, "",
+		commonConstants.OmsUpdatesTopicArn, "")
 	if cErr != nil {
 		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
 			errors.New(cErr.Message))
 	}
 }
 
-func (sampleService *SampleService) PublishLisDataEvent(attuneOrderResponse commonStructures.AttuneOrderResponse) {
-	ctx := context.Background()
-	messageBody, messageAttributes := sampleService.PubsubService.GetLisDataEvent(ctx, attuneOrderResponse)
-	cErr := sampleService.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", commonConstants.CitadelTopicArn, "")
+func (sampleService *SampleService) WriteLisDataEvent(ctx context.Context, visitId, webhookData string) {
+	messageBody, messageAttributes := sampleService.PubsubService.GetLisDataEvent(visitId, webhookData)
+	cErr := sampleService.OutboxService.WriteMessage(ctx, messageBody, messageAttributes, "",
+		commonConstants.CitadelTopicArn, "")
 	if cErr != nil {
 		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), nil,
 			errors.New(cErr.Message))
@@ -608,7 +608,7 @@ func (sampleService *SampleService) CreateUpdateTestsWithSamplePostCollectionWit
 				if commonUtils.SliceContainsString(sampleStatusesForModifyingAttuneVisit,
 					sampleNumberToSampleMapping[currentTestSampleMapping.SampleNumber].Status) {
 					testIdToLisSyncAtTime[newTestSampleMapping.OmsTestId] = newSample.LisSyncAt
-					cErr = sampleService.AttuneService.ModifyLisDataPostSyncByOrderId(ctx, orderDetails.OmsOrderId,
+					cErr = sampleService.LisService.ModifyOrder(ctx, orderDetails.OmsOrderId,
 						orderDetails.ServicingLabId, allTestDetailsMap[omsTest.AlnumTestId], newSample)
 					if cErr != nil {
 						return nil, cErr
@@ -628,7 +628,7 @@ func (sampleService *SampleService) CreateUpdateTestsWithSamplePostCollectionWit
 				if commonUtils.SliceContainsString(sampleStatusesForModifyingAttuneVisit,
 					sampleNumberToSampleMapping[testSampleMappings[0].SampleNumber].Status) {
 					testIdToLisSyncAtTime[newTestSampleMapping.OmsTestId] = sample.LisSyncAt
-					cErr = sampleService.AttuneService.ModifyLisDataPostSyncByOrderId(ctx, orderDetails.OmsOrderId,
+					cErr = sampleService.LisService.ModifyOrder(ctx, orderDetails.OmsOrderId,
 						orderDetails.ServicingLabId, allTestDetailsMap[omsTest.AlnumTestId], sample)
 					if cErr != nil {
 						return nil, cErr
@@ -661,7 +661,7 @@ func (sampleService *SampleService) CreateUpdateTestsWithSamplePostCollectionWit
 					sampleMetadataModel := mappers.MapSampleInfoToSampleMetadata(sample)
 					sampleIdBarcodeMap := map[uint]string{}
 					sampleIdBarcodeMap[sample.Id] = sample.Barcode
-					visitId, cErr := sampleService.AttuneService.SyncDataToLisByOmsOrderId(ctx, orderDetails.OmsOrderId,
+					visitId, cErr := sampleService.LisService.SyncOrder(ctx, orderDetails.OmsOrderId,
 						orderDetails.ServicingLabId, []commonModels.Sample{sampleModel},
 						[]commonModels.SampleMetadata{sampleMetadataModel}, sampleIdBarcodeMap)
 					if cErr != nil {
@@ -872,7 +872,7 @@ func (sampleService *SampleService) UpdateSampleCollected(
 	return nil
 }
 
-func (sampleService *SampleService) ForcefullyMarkSampleAsCollected(
+func (sampleService *SampleService) ForcefullyMarkSampleAsCollected(ctx context.Context,
 	request structures.ForcefullyMarkSampleAsCollectedRequest) *commonStructures.CommonError {
 	if len(request.SampleNumbers) == 0 || request.OmsOrderId == "" {
 		return &commonStructures.CommonError{
@@ -927,9 +927,13 @@ func (sampleService *SampleService) ForcefullyMarkSampleAsCollected(
 		return cErr
 	}
 
+	cErr = sampleService.WriteSampleCollectedEventWithTx(ctx, tx, omsTestIds, collectedAt, samples[0].OmsCityCode)
+	if cErr != nil {
+		return cErr
+	}
+
 	tx.Commit()
 
-	sampleService.PublishSampleCollectedEvent(omsTestIds, collectedAt, samples[0].OmsCityCode)
 	return nil
 }
 
@@ -1314,7 +1318,7 @@ func (sampleService *SampleService) DeleteSamplesAndTestDetailsWithTx(ctx contex
 				testDetail := omsTestIdToTestDetailMap[omsTestId]
 
 				// Trigger LIS sync cancellation for just this test
-				cErr = sampleService.AttuneService.CancelLisSyncData(ctx, []commonModels.TestDetail{testDetail},
+				cErr = sampleService.LisService.CancelOrder(ctx, []commonModels.TestDetail{testDetail},
 					sample.VisitId, sample)
 				if cErr != nil {
 					return cErr
@@ -1395,7 +1399,7 @@ func (sampleService *SampleService) DeleteTestSampleMappingForDeletedTestIds(ctx
 				testDetail := omsTestIdToTestDetailMap[omsTestId]
 
 				// Trigger LIS sync cancellation for just this test
-				cErr = sampleService.AttuneService.CancelLisSyncData(ctx, []commonModels.TestDetail{testDetail},
+				cErr = sampleService.LisService.CancelOrder(ctx, []commonModels.TestDetail{testDetail},
 					sample.VisitId, sample)
 				if cErr != nil {
 					return cErr
@@ -1446,7 +1450,7 @@ func (sampleService *SampleService) RejectSample(ctx context.Context, userId, la
 
 	for index := range samples {
 		if samples[index].VisitId != "" {
-			cErr = sampleService.AttuneService.CancelLisSyncData(ctx, testDetails, samples[index].VisitId,
+			cErr = sampleService.LisService.CancelOrder(ctx, testDetails, samples[index].VisitId,
 				mappers.MapSampleSampleMetaToSampleInfo(samples[index], sampleMetadatas[index]))
 			if cErr != nil {
 				return centralOmsTestIds, cErr
@@ -1471,6 +1475,32 @@ func (sampleService *SampleService) RejectSample(ctx context.Context, userId, la
 		}
 	}
 
+	for _, sample := range samples {
+		if sample.ParentSampleId != 0 {
+			continue
+		}
+
+		omsTestStatusMap := map[string]string{}
+		for _, testId := range centralOmsTestIds {
+			omsTestStatusMap[testId] = commonConstants.TEST_STATUS_REQUESTED
+		}
+		cErr = sampleService.WriteUpdateTestStatusEventWithTx(ctx, tx, omsTestStatusMap, sample.OmsOrderId, true,
+			sample.OmsCityCode)
+		if cErr != nil {
+			return centralOmsTestIds, cErr
+		}
+
+		cErr = sampleService.WriteResetTatsEventWithTx(ctx, tx, centralOmsTestIds, sample.OmsCityCode)
+		if cErr != nil {
+			return centralOmsTestIds, cErr
+		}
+
+		cErr = sampleService.WriteAddSampleRejectedTagEventWithTx(ctx, tx, sample.OmsOrderId, sample.OmsCityCode)
+		if cErr != nil {
+			return centralOmsTestIds, cErr
+		}
+	}
+
 	tx.Commit()
 
 	for _, sample := range samples {
@@ -1480,16 +1510,6 @@ func (sampleService *SampleService) RejectSample(ctx context.Context, userId, la
 					constants.SampleRejectedFreshDeskSubject, rejectionReason, testDetails)
 			}
 
-			omsTestStatusMap := map[string]string{}
-			for _, testId := range centralOmsTestIds {
-				omsTestStatusMap[testId] = commonConstants.TEST_STATUS_REQUESTED
-			}
-			go sampleService.PublishUpdateTestStatusEvent(omsTestStatusMap, sample.OmsOrderId, true, sample.OmsCityCode)
-
-			go sampleService.PublishResetTatsEvent(centralOmsTestIds, sample.OmsCityCode)
-
-			go sampleService.PublishAddSampleRejectedTagEvent(sample.OmsOrderId, sample.OmsCityCode)
-
 			go sampleService.EtsService.GetAndPublishEtsTestEventForSampleRejection(context.Background(),
 				sample.OmsOrderId, sample.SampleNumber)
 		}
@@ -1536,7 +1556,7 @@ func (sampleService *SampleService) PartiallyRejectSample(ctx context.Context, o
 
 	for _, sample := range samples {
 		if sample.VisitId != "" {
-			cErr = sampleService.AttuneService.CancelLisSyncData(ctx, []commonModels.TestDetail{testDetails}, sample.VisitId,
+			cErr = sampleService.LisService.CancelOrder(ctx, []commonModels.TestDetail{testDetails}, sample.VisitId,
 				mappers.MapSampleSampleMetaToSampleInfo(sample, sampleIdToSampleMetadataMap[sample.Id]))
 			if cErr != nil {
 				return omsTestIds, cErr
@@ -1564,9 +1584,17 @@ func (sampleService *SampleService) PartiallyRejectSample(ctx context.Context, o
 		return omsTestIds, cErr
 	}
 
-	tx.Commit()
+	cErr = sampleService.WriteResetTatsEventWithTx(ctx, tx, omsTestIds, samples[0].OmsCityCode)
+	if cErr != nil {
+		return omsTestIds, cErr
+	}
 
-	go sampleService.PublishResetTatsEvent(omsTestIds, samples[0].OmsCityCode)
+	cErr = sampleService.WriteAddSampleRejectedTagEventWithTx(ctx, tx, samples[0].OmsOrderId, samples[0].OmsCityCode)
+	if cErr != nil {
+		return omsTestIds, cErr
+	}
+
+	tx.Commit()
 
 	go sampleService.EtsService.GetAndPublishEtsTestEventForPartialRejection(context.Background(), samples[0].OmsOrderId, samples[0].SampleNumber,
 		omsTestId)
@@ -1604,8 +1632,6 @@ func (sampleService *SampleService) PartiallyRejectSample(ctx context.Context, o
 		}
 	}
 
-	go sampleService.PublishAddSampleRejectedTagEvent(samples[0].OmsOrderId, samples[0].OmsCityCode)
-
 	return omsTestIds, nil
 }
 
@@ -1957,13 +1983,17 @@ func (sampleService *SampleService) GetLisSyncDataByVisitId(ctx context.Context,
 		}
 	}
 
-	lisSyncDetails, attuneOrderResponse, cErr := sampleService.AttuneService.GetLisSyncData(ctx, visitId,
-		commonConstants.AttuneReportWithStationery)
+	lisSyncDetails, lisOrder, cErr := sampleService.LisService.GetLisSyncData(ctx, visitId,
+		commonConstants.LIS_REPORT_FORMAT_BRANDED)
 	if cErr != nil {
 		return lisSyncDetails, cErr
 	}
 
-	sampleService.PublishLisDataEvent(attuneOrderResponse)
+	webhookData, cErr := sampleService.LisService.EncodeResultsEvent(ctx, sample.LabId, lisOrder)
+	if cErr != nil {
+		return lisSyncDetails, cErr
+	}
+	sampleService.WriteLisDataEvent(ctx, visitId, webhookData)
 
 	return lisSyncDetails, nil
 }
@@ -2160,7 +2190,7 @@ func (sampleService *SampleService) UpdateSrfIdToLis(ctx context.Context,
 		if sample.VisitId == "" {
 			continue
 		}
-		cErr := sampleService.AttuneService.UpdateSrfIdToAttune(ctx, sample, orderDetails.SrfId)
+		cErr := sampleService.LisService.UpdateSrfId(ctx, sample, orderDetails.SrfId)
 		if cErr != nil {
 			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonUtils.GetCurrentFunctionName(), map[string]interface{}{
 				"error": cErr.Message,
@@ -202,3 +202,41 @@ type SrfOrderIdsOrderDetails struct {
 	OmsOrderId string `json:"oms_order_id"`
 	CityCode   string `json:"city_code"`
 }
+
+type CreateSampleAliquotsRequest struct {
+	Aliquots []SampleAliquotRequest `json:"aliquots"`
+	UserId   uint                   `json:"user_id"`
+}
+
+type SampleAliquotRequest struct {
+	Barcode          string   `json:"barcode"`
+	VialTypeId       uint     `json:"vial_type_id"`
+	Volume           uint     `json:"volume"`
+	Department       string   `json:"department"`
+	DestinationLabId uint     `json:"destination_lab_id"`
+	TestIds          []string `json:"test_ids"`
+}
+
+type SampleAliquotsResponse struct {
+	SampleId        uint                   `json:"sample_id"`
+	Barcode         string                 `json:"barcode"`
+	CollectedVolume uint                   `json:"collected_volume"`
+	ConsumedVolume  uint                   `json:"consumed_volume"`
+	RemainingVolume uint                   `json:"remaining_volume"`
+	Aliquots        []SampleAliquotDetails `json:"aliquots"`
+}
+
+type SampleAliquotDetails struct {
+	SampleId         uint       `json:"sample_id"`
+	SampleNumber     uint       `json:"sample_number"`
+	Barcode          string     `json:"barcode"`
+	VialTypeId       uint       `json:"vial_type_id"`
+	Status           string     `json:"status"`
+	LabId            uint       `json:"lab_id"`
+	DestinationLabId uint       `json:"destination_lab_id"`
+	VisitId          string     `json:"visit_id,omitempty"`
+	Volume           uint       `json:"volume"`
+	Department       string     `json:"department"`
+	AliquotedAt      *time.Time `json:"aliquoted_at"`
+	TestIds          []string   `json:"test_ids"`
+}
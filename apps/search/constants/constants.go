@@ -53,3 +53,24 @@ const (
 	INFO_SCREEN_SEARCH_TYPE_VISIT_ID = "visit_id"
 	INFO_SCREEN_SEARCH_TYPE_BARCODE  = "barcode"
 )
+
+// Task list sorting and free text search
+const (
+	TASK_LIST_SORT_BY_DOCTOR_TAT = "doctor_tat"
+	TASK_LIST_SORT_BY_CREATED_AT = "created_at"
+
+	TASK_LIST_SORT_ORDER_ASC  = "asc"
+	TASK_LIST_SORT_ORDER_DESC = "desc"
+
+	TASK_SEARCH_QUERY_MIN_LENGTH = 3
+)
+
+var TASK_LIST_SORT_BY = []string{
+	TASK_LIST_SORT_BY_DOCTOR_TAT,
+	TASK_LIST_SORT_BY_CREATED_AT,
+}
+
+var TASK_LIST_SORT_ORDERS = []string{
+	TASK_LIST_SORT_ORDER_ASC,
+	TASK_LIST_SORT_ORDER_DESC,
+}
@@ -14,6 +14,11 @@ import (
 // @Description	Get all tasks based on filters provided
 // @Tags			searchController
 // @Produce		json
+// @Param			q			query		string							false	"Free text search on patient, phone, test, partner and doctor"
+// @Param			view_id		query		int								false	"Saved view whose filters fill the parameters not sent"
+// @Param			sort_by		query		string							false	"doctor_tat or created_at"
+// @Param			sort_order	query		string							false	"asc or desc"
+// @Param			cursor		query		string							false	"next_cursor of the previous page, for a single task type"
 // @Success		200			{object}	structures.TaskListResponse		"Tasks List"
 // @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Error Response"
 // @Router			/api/v1/searchController/tasks [get]
@@ -42,6 +47,11 @@ func (searchController *Search) GetTasksList(c *gin.Context) {
 		LabId:              c.Query("lab_id"),
 		SpecialRequirement: c.Query("special_requirement"),
 		OrderType:          c.Query("order_type"),
+		Query:              c.Query("q"),
+		ViewId:             commonUtils.ConvertStringToUint(c.Query("view_id")),
+		SortBy:             c.Query("sort_by"),
+		SortOrder:          c.Query("sort_order"),
+		Cursor:             c.Query("cursor"),
 	}
 
 	response, cErr := searchController.SearchService.GetTasksList(c.Request.Context(), taskListBasicRequest)
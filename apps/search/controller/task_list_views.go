@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/search/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructs "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Task List Views
+// @Description	Get the saved task list views of the user, default view first
+// @Tags			searchController
+// @Produce		json
+// @Success		200			{object}	[]structures.TaskListView		"Task List Views"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Error Response"
+// @Router			/api/v1/search/task-views [get]
+func (searchController *Search) GetTaskListViews(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	views, cErr := searchController.SearchService.GetTaskListViews(userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
+// @Summary		Create Task List View
+// @Description	Save a named set of task list filters and sort order for the user
+// @Tags			searchController
+// @Accept			json
+// @Produce		json
+// @Param			view		body		structures.TaskListViewRequest	true	"Task List View"
+// @Success		200			{object}	structures.TaskListView			"Task List View"
+// @Failure		400,409,500	{object}	structures.CommonAPIResponse	"Error Response"
+// @Router			/api/v1/search/task-views [post]
+func (searchController *Search) CreateTaskListView(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	viewRequest := structures.TaskListViewRequest{}
+	if err := c.ShouldBindJSON(&viewRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	view, cErr := searchController.SearchService.CreateTaskListView(viewRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// @Summary		Update Task List View
+// @Description	Update a saved task list view of the user
+// @Tags			searchController
+// @Accept			json
+// @Produce		json
+// @Param			viewId			path		int								true	"Task List View ID"
+// @Param			view			body		structures.TaskListViewRequest	true	"Task List View"
+// @Success		200				{object}	structures.TaskListView			"Task List View"
+// @Failure		400,404,409,500	{object}	structures.CommonAPIResponse	"Error Response"
+// @Router			/api/v1/search/task-views/{viewId} [put]
+func (searchController *Search) UpdateTaskListView(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	viewId := commonUtils.ConvertStringToUint(c.Param("viewId"))
+	if viewId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_TASK_LIST_VIEW_ID)
+		return
+	}
+
+	viewRequest := structures.TaskListViewRequest{}
+	if err := c.ShouldBindJSON(&viewRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	view, cErr := searchController.SearchService.UpdateTaskListView(viewId, viewRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// @Summary		Delete Task List View
+// @Description	Delete a saved task list view of the user
+// @Tags			searchController
+// @Produce		json
+// @Param			viewId		path		int								true	"Task List View ID"
+// @Success		200			{object}	structures.CommonAPIResponse	"Common API Response"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Error Response"
+// @Router			/api/v1/search/task-views/{viewId} [delete]
+func (searchController *Search) DeleteTaskListView(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	viewId := commonUtils.ConvertStringToUint(c.Param("viewId"))
+	if viewId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_TASK_LIST_VIEW_ID)
+		return
+	}
+
+	cErr = searchController.SearchService.DeleteTaskListView(viewId, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, commonStructs.CommonAPIResponse{
+		Message: commonConstants.DONE_RESPONSE,
+	})
+}
@@ -1,6 +1,9 @@
 package dao
 
 import (
+	"fmt"
+	"strings"
+
 	"gorm.io/gorm"
 
 	"github.com/Orange-Health/citadel/apps/search/constants"
@@ -20,6 +23,7 @@ func getCommonSelectStringsForTaskDetails(taskType string) []string {
 		"tasks.doctor_tat as doctor_tat",
 		"tasks.status as status",
 		"tasks.lab_id as lab_id",
+		"tasks.created_at as created_at",
 		"patient_details.name as patient_name",
 		"task_metadata.contains_morphle as contains_morphle",
 		"task_metadata.contains_package as contains_package",
@@ -118,6 +122,49 @@ func (searchDao *SearchDao) addClausesInQuery(query *gorm.DB,
 	return query
 }
 
+// addSearchTermsInQuery requires every search term to match at least one of the patient, test, partner or doctor
+// fields. The LIKE patterns are served by the trigram indexes on these columns.
+func (searchDao *SearchDao) addSearchTermsInQuery(query *gorm.DB, searchTerms []string) *gorm.DB {
+	likeEscaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	for _, searchTerm := range searchTerms {
+		pattern := "%" + likeEscaper.Replace(searchTerm) + "%"
+		query = query.Where("lower(patient_details.name) LIKE ? OR patient_details.number LIKE ? OR "+
+			"lower(test_details.test_name) LIKE ? OR lower(task_metadata.partner_name) LIKE ? OR "+
+			"lower(task_metadata.doctor_name) LIKE ?", pattern, pattern, pattern, pattern, pattern)
+	}
+
+	return query
+}
+
+// addKeysetPaginationInQuery orders the tasks by the sort column with the task id as tie breaker and, when a
+// cursor is given, only returns the tasks after it. Postgres sorts nulls last in ascending and first in
+// descending order, which the cursor conditions follow.
+func (searchDao *SearchDao) addKeysetPaginationInQuery(query *gorm.DB,
+	taskListDbRequest structures.TaskListDbRequest) *gorm.DB {
+
+	sortColumn := "tasks." + taskListDbRequest.SortBy
+	cursor := taskListDbRequest.Cursor
+	isDescending := taskListDbRequest.SortOrder == constants.TASK_LIST_SORT_ORDER_DESC
+
+	if cursor != nil {
+		switch {
+		case cursor.SortValue == nil && isDescending:
+			query = query.Where(fmt.Sprintf("(%s IS NULL AND tasks.id < ?) OR %s IS NOT NULL", sortColumn, sortColumn),
+				cursor.TaskId)
+		case cursor.SortValue == nil:
+			query = query.Where(fmt.Sprintf("%s IS NULL AND tasks.id > ?", sortColumn), cursor.TaskId)
+		case isDescending:
+			query = query.Where(fmt.Sprintf("(%s, tasks.id) < (?, ?)", sortColumn), cursor.SortValue, cursor.TaskId)
+		default:
+			query = query.Where(fmt.Sprintf("(%s, tasks.id) > (?, ?) OR %s IS NULL", sortColumn, sortColumn),
+				cursor.SortValue, cursor.TaskId)
+		}
+	}
+
+	return query.Order(fmt.Sprintf("%s %s, tasks.id %s", sortColumn, taskListDbRequest.SortOrder,
+		taskListDbRequest.SortOrder))
+}
+
 func (searchDao *SearchDao) getTaskListCommonQuery(taskListDbRequest structures.TaskListDbRequest,
 	taskStatuses []string, isCritical bool, taskType string) *gorm.DB {
 
@@ -130,12 +177,16 @@ func (searchDao *SearchDao) getTaskListCommonQuery(taskListDbRequest structures.
 
 	query = searchDao.addClausesInQuery(query, taskListDbRequest)
 
+	query = searchDao.addSearchTermsInQuery(query, taskListDbRequest.SearchTerms)
+
 	query = searchDao.applyWhereClauseBasedOnTaskType(query, taskStatuses, isCritical, taskType, taskListDbRequest.UserId)
 
-	query = query.Limit(int(taskListDbRequest.Limit + 1)).
-		Offset(int(taskListDbRequest.Offset))
+	query = query.Limit(int(taskListDbRequest.Limit + 1))
+	if taskListDbRequest.Cursor == nil {
+		query = query.Offset(int(taskListDbRequest.Offset))
+	}
 
-	query = query.Order("tasks.doctor_tat")
+	query = searchDao.addKeysetPaginationInQuery(query, taskListDbRequest)
 
 	return query
 }
@@ -253,3 +253,21 @@ func (searchDao *SearchDao) GetTestDetailsByBarcode(barcode, serviceType string,
 
 	return testDetails, nil
 }
+
+func (searchDao *SearchDao) GetSampleAliquotsByOrderId(omsOrderId string) ([]commonModels.SampleAliquot,
+	*commonStructures.CommonError) {
+	sampleAliquots := []commonModels.SampleAliquot{}
+
+	err := searchDao.Db.Table(commonConstants.TableSampleAliquots).
+		Where("oms_order_id = ?", omsOrderId).
+		Where("deleted_at is NULL").
+		Order("id").Find(&sampleAliquots).Error
+	if err != nil {
+		return sampleAliquots, &commonStructures.CommonError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    commonConstants.ERROR_WHILE_FETCHING_SAMPLE_ALIQUOTS,
+		}
+	}
+
+	return sampleAliquots, nil
+}
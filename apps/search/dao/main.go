@@ -6,6 +6,7 @@ import (
 	"github.com/Orange-Health/citadel/adapters/psql"
 	"github.com/Orange-Health/citadel/apps/search/structures"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
 )
 
 type SearchDao struct {
@@ -27,6 +28,13 @@ type DataLayer interface {
 	GetAmendmentTaskDetails(taskListDbRequest structures.TaskListDbRequest) (
 		[]structures.TaskDetailsDbStruct, *commonStructures.CommonError)
 
+	// Task List Views
+	GetTaskListViewsByUserId(userId uint) ([]commonModels.TaskListView, *commonStructures.CommonError)
+	GetTaskListViewById(viewId, userId uint) (commonModels.TaskListView, *commonStructures.CommonError)
+	GetTaskListViewByName(name string, userId uint) (commonModels.TaskListView, *commonStructures.CommonError)
+	SaveTaskListView(view commonModels.TaskListView) (commonModels.TaskListView, *commonStructures.CommonError)
+	DeleteTaskListView(viewId, userId uint) *commonStructures.CommonError
+
 	// Info Screen
 	GetOrderDetailsByBarcode(barcode, serviceType string) (
 		structures.InfoScreenOrderDetails, *commonStructures.CommonError)
@@ -42,6 +50,7 @@ type DataLayer interface {
 		[]structures.InfoScreenTestDetails, *commonStructures.CommonError)
 	GetTestDetailsByBarcode(barcode, serviceType string, labId uint) (
 		[]structures.InfoScreenTestDetails, *commonStructures.CommonError)
+	GetSampleAliquotsByOrderId(omsOrderId string) ([]commonModels.SampleAliquot, *commonStructures.CommonError)
 }
 
 func InitializeSearchDao() DataLayer {
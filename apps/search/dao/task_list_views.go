@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func (searchDao *SearchDao) GetTaskListViewsByUserId(userId uint) (
+	[]commonModels.TaskListView, *commonStructures.CommonError) {
+
+	views := []commonModels.TaskListView{}
+	if err := searchDao.Db.Where("user_id = ?", userId).Order("is_default DESC, name").
+		Find(&views).Error; err != nil {
+		return views, commonUtils.HandleORMError(err)
+	}
+
+	return views, nil
+}
+
+func (searchDao *SearchDao) GetTaskListViewById(viewId, userId uint) (
+	commonModels.TaskListView, *commonStructures.CommonError) {
+
+	view := commonModels.TaskListView{}
+	if err := searchDao.Db.Where("id = ?", viewId).Where("user_id = ?", userId).
+		First(&view).Error; err != nil {
+		return view, commonUtils.HandleORMError(err)
+	}
+
+	return view, nil
+}
+
+func (searchDao *SearchDao) GetTaskListViewByName(name string, userId uint) (
+	commonModels.TaskListView, *commonStructures.CommonError) {
+
+	view := commonModels.TaskListView{}
+	if err := searchDao.Db.Where("lower(name) = lower(?)", name).Where("user_id = ?", userId).
+		First(&view).Error; err != nil {
+		return view, commonUtils.HandleORMError(err)
+	}
+
+	return view, nil
+}
+
+// SaveTaskListView creates or updates the view. A default view replaces the previous default view of the user.
+func (searchDao *SearchDao) SaveTaskListView(view commonModels.TaskListView) (
+	commonModels.TaskListView, *commonStructures.CommonError) {
+
+	err := searchDao.Db.Transaction(func(tx *gorm.DB) error {
+		if view.IsDefault {
+			if err := tx.Model(&commonModels.TaskListView{}).
+				Where("user_id = ? AND is_default = ? AND id <> ?", view.UserId, true, view.Id).
+				Updates(map[string]interface{}{
+					"is_default": false,
+					"updated_by": view.UpdatedBy,
+					"updated_at": commonUtils.GetCurrentTime(),
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(&view).Error
+	})
+	if err != nil {
+		return view, commonUtils.HandleORMError(err)
+	}
+
+	return view, nil
+}
+
+func (searchDao *SearchDao) DeleteTaskListView(viewId, userId uint) *commonStructures.CommonError {
+
+	currentTime := commonUtils.GetCurrentTime()
+	viewUpdates := map[string]interface{}{
+		"deleted_by": userId,
+		"updated_by": userId,
+		"deleted_at": currentTime,
+		"updated_at": currentTime,
+	}
+	if err := searchDao.Db.Model(&commonModels.TaskListView{}).Where("id = ?", viewId).
+		Updates(viewUpdates).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
@@ -15,6 +15,12 @@ func RouteHandler(router *gin.RouterGroup) {
 	router.GET("/tasks", searchController.GetTasksList)
 	router.GET("/amendment-tasks", searchController.GetAmendmentTasksList)
 
+	// Superlab Doctor Task List Views
+	router.GET("/task-views", searchController.GetTaskListViews)
+	router.POST("/task-views", searchController.CreateTaskListView)
+	router.PUT("/task-views/:viewId", searchController.UpdateTaskListView)
+	router.DELETE("/task-views/:viewId", searchController.DeleteTaskListView)
+
 	// Superlab Info Screen
 	router.GET("info-screen/barcode/:barcode", tokenAuthMiddleware.Authenticate(commonConstants.EtsServiceName),
 		searchController.GetInfoScreenDataByBarcode)
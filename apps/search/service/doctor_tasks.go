@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/Orange-Health/citadel/apps/search/constants"
 	"github.com/Orange-Health/citadel/apps/search/structures"
@@ -56,6 +60,14 @@ func validateAndReturnSearchParameters(taskListBasicRequest structures.TaskListB
 		tasksSearch.RequestId = requestId
 	}
 
+	if taskListBasicRequest.Query != "" {
+		searchTerms, cErr := getSearchTerms(taskListBasicRequest.Query)
+		if cErr != nil {
+			return tasksSearch, cErr
+		}
+		tasksSearch.SearchTerms = searchTerms
+	}
+
 	return tasksSearch, nil
 }
 
@@ -131,8 +143,80 @@ func validateAndReturnFilters(taskListBasicRequest structures.TaskListBasicReque
 	return taskFilters, nil
 }
 
+func getSearchTerms(query string) ([]string, *commonStructures.CommonError) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if utf8.RuneCountInString(query) < constants.TASK_SEARCH_QUERY_MIN_LENGTH {
+		return nil, &commonStructures.CommonError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf(commonConstants.ERROR_TASK_SEARCH_QUERY_TOO_SHORT, constants.TASK_SEARCH_QUERY_MIN_LENGTH),
+		}
+	}
+
+	return strings.Fields(query), nil
+}
+
+func validateAndReturnSortParameters(sortBy, sortOrder string) (string, string, *commonStructures.CommonError) {
+	if sortBy == "" {
+		sortBy = constants.TASK_LIST_SORT_BY_DOCTOR_TAT
+	}
+	if !commonUtils.SliceContainsString(constants.TASK_LIST_SORT_BY, sortBy) {
+		return sortBy, sortOrder, &commonStructures.CommonError{
+			StatusCode: http.StatusBadRequest,
+			Message:    commonConstants.ERROR_INVALID_TASK_LIST_SORT_BY,
+		}
+	}
+
+	if sortOrder == "" {
+		sortOrder = constants.TASK_LIST_SORT_ORDER_ASC
+	}
+	if !commonUtils.SliceContainsString(constants.TASK_LIST_SORT_ORDERS, sortOrder) {
+		return sortBy, sortOrder, &commonStructures.CommonError{
+			StatusCode: http.StatusBadRequest,
+			Message:    commonConstants.ERROR_INVALID_TASK_LIST_SORT_ORDER,
+		}
+	}
+
+	return sortBy, sortOrder, nil
+}
+
+func decodeTaskListCursor(encodedCursor string) (*structures.TaskListCursor, *commonStructures.CommonError) {
+	cursor := structures.TaskListCursor{}
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(encodedCursor)
+	if err == nil {
+		err = json.Unmarshal(cursorBytes, &cursor)
+	}
+	if err != nil || cursor.TaskId == 0 {
+		return nil, &commonStructures.CommonError{
+			StatusCode: http.StatusBadRequest,
+			Message:    commonConstants.ERROR_INVALID_TASK_LIST_CURSOR,
+		}
+	}
+
+	return &cursor, nil
+}
+
+// getNextTaskListCursor returns the cursor of the last task of the page when there are more tasks to fetch.
+func getNextTaskListCursor(tasks []structures.TaskDetailsStruct, showMore bool, sortBy string) string {
+	if !showMore || len(tasks) == 0 {
+		return ""
+	}
+
+	lastTask := tasks[len(tasks)-1]
+	cursor := structures.TaskListCursor{
+		SortValue: lastTask.DoctorTat,
+		TaskId:    lastTask.TaskId,
+	}
+	if sortBy == constants.TASK_LIST_SORT_BY_CREATED_AT {
+		cursor.SortValue = lastTask.CreatedAt
+	}
+
+	cursorBytes, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(cursorBytes)
+}
+
 func validateAndReturnTaskListRequestParameters(taskListBasicRequest structures.TaskListBasicRequest) (
 	structures.TaskListRequest, *commonStructures.CommonError) {
+	var cErr *commonStructures.CommonError
 	taskListRequest := structures.TaskListRequest{}
 	if taskListBasicRequest.Limit <= 0 {
 		return taskListRequest, &commonStructures.CommonError{
@@ -164,6 +248,25 @@ func validateAndReturnTaskListRequestParameters(taskListBasicRequest structures.
 	}
 	taskListRequest.TaskTypes = taskTypes
 
+	taskListRequest.SortBy, taskListRequest.SortOrder, cErr = validateAndReturnSortParameters(
+		taskListBasicRequest.SortBy, taskListBasicRequest.SortOrder)
+	if cErr != nil {
+		return taskListRequest, cErr
+	}
+
+	if taskListBasicRequest.Cursor != "" {
+		if len(taskTypes) > 1 {
+			return taskListRequest, &commonStructures.CommonError{
+				StatusCode: http.StatusBadRequest,
+				Message:    commonConstants.ERROR_CURSOR_WITH_MULTIPLE_TASK_TYPE,
+			}
+		}
+		taskListRequest.Cursor, cErr = decodeTaskListCursor(taskListBasicRequest.Cursor)
+		if cErr != nil {
+			return taskListRequest, cErr
+		}
+	}
+
 	tasksSearch, cErr := validateAndReturnSearchParameters(taskListBasicRequest)
 	if cErr != nil {
 		return taskListRequest, cErr
@@ -202,6 +305,8 @@ func addWhereClauseParameters(taskListRequest structures.TaskListRequest,
 		taskListDbRequest.DoctorName = "dr. " + doctorName
 	}
 
+	taskListDbRequest.SearchTerms = searchCriterion.SearchTerms
+
 	if searchCriterion.ContactNumber != "" {
 		patientDetailsClause["patient_details.number"] = searchCriterion.ContactNumber
 	}
@@ -324,6 +429,7 @@ func (searchService *SearchService) getTestDetailsAndCreateTaskDetails(ctx conte
 			OmsOrderId:     task.OmsOrderId,
 			CityCode:       task.CityCode,
 			DoctorTat:      task.DoctorTat,
+			CreatedAt:      task.CreatedAt,
 			PickedBy:       task.PickedBy,
 			CoAuthorizedBy: task.CoAuthorizedBy,
 			Status:         task.Status,
@@ -494,26 +600,32 @@ func (searchService *SearchService) getTaskListAndCreateResponse(ctx context.Con
 		return structures.TaskListResponse{}, errList
 	}
 
+	sortBy := taskListRequest.SortBy
 	response := structures.TaskListResponse{
 		CriticalTasks: structures.TaskResponseStruct{
-			Tasks:    criticalTasks,
-			ShowMore: criticalShowMore,
+			Tasks:      criticalTasks,
+			ShowMore:   criticalShowMore,
+			NextCursor: getNextTaskListCursor(criticalTasks, criticalShowMore, sortBy),
 		},
 		WithheldTasks: structures.TaskResponseStruct{
-			Tasks:    withheldTasks,
-			ShowMore: withheldShowMore,
+			Tasks:      withheldTasks,
+			ShowMore:   withheldShowMore,
+			NextCursor: getNextTaskListCursor(withheldTasks, withheldShowMore, sortBy),
 		},
 		CoAuthorizeTasks: structures.TaskResponseStruct{
-			Tasks:    coAuthorizedTasks,
-			ShowMore: coAuthorizedShowMore,
+			Tasks:      coAuthorizedTasks,
+			ShowMore:   coAuthorizedShowMore,
+			NextCursor: getNextTaskListCursor(coAuthorizedTasks, coAuthorizedShowMore, sortBy),
 		},
 		NormalTasks: structures.TaskResponseStruct{
-			Tasks:    normalTasks,
-			ShowMore: normalShowMore,
+			Tasks:      normalTasks,
+			ShowMore:   normalShowMore,
+			NextCursor: getNextTaskListCursor(normalTasks, normalShowMore, sortBy),
 		},
 		InProgressTasks: structures.TaskResponseStruct{
-			Tasks:    inProgressTasks,
-			ShowMore: inProgressShowMore,
+			Tasks:      inProgressTasks,
+			ShowMore:   inProgressShowMore,
+			NextCursor: getNextTaskListCursor(inProgressTasks, inProgressShowMore, sortBy),
 		},
 	}
 
@@ -523,15 +635,26 @@ func (searchService *SearchService) getTaskListAndCreateResponse(ctx context.Con
 func (searchService *SearchService) GetTasksList(ctx context.Context, taskListBasicRequest structures.TaskListBasicRequest) (
 	structures.TaskListResponse, *commonStructures.CommonError) {
 
+	if taskListBasicRequest.ViewId != 0 {
+		view, cErr := searchService.getTaskListViewById(taskListBasicRequest.ViewId, taskListBasicRequest.UserId)
+		if cErr != nil {
+			return structures.TaskListResponse{}, cErr
+		}
+		applyTaskListView(view, &taskListBasicRequest)
+	}
+
 	taskListRequest, cErr := validateAndReturnTaskListRequestParameters(taskListBasicRequest)
 	if cErr != nil {
 		return structures.TaskListResponse{}, cErr
 	}
 
 	taskListDbRequest := structures.TaskListDbRequest{
-		UserId: taskListRequest.UserId,
-		Limit:  taskListRequest.Limit,
-		Offset: taskListRequest.Offset,
+		UserId:    taskListRequest.UserId,
+		Limit:     taskListRequest.Limit,
+		Offset:    taskListRequest.Offset,
+		SortBy:    taskListRequest.SortBy,
+		SortOrder: taskListRequest.SortOrder,
+		Cursor:    taskListRequest.Cursor,
 	}
 
 	addWhereClauseParameters(taskListRequest, &taskListDbRequest)
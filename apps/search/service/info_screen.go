@@ -14,6 +14,7 @@ import (
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
 	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
 )
 
 func getTestStatus(status, omsStatus string) string {
@@ -220,6 +221,104 @@ func createBarcodeVisitMapAndVisitSampleMap(basicVisitDetails []structures.InfoS
 	return visitIdToSampleNumbersMap, sampleNumberToSampleDetailsMap
 }
 
+// createSampleLineageResponse builds the tree of samples derived from the tube of the scanned barcode. Interlab
+// copies hang off their parent through parent_sample_id and aliquots through sample_aliquots.
+func createSampleLineageResponse(barcode string, basicVisitDetails []structures.InfoScreenBasicVisitDetails,
+	sampleAliquots []commonModels.SampleAliquot, servicingLabId uint,
+	labIdMap map[uint]commonStructures.Lab) []structures.InfoScreenSampleLineageResponse {
+
+	sampleIdToVisitDetailMap := map[uint]structures.InfoScreenBasicVisitDetails{}
+	for _, basicVisitDetail := range basicVisitDetails {
+		sampleIdToVisitDetailMap[basicVisitDetail.SampleId] = basicVisitDetail
+	}
+	aliquotSampleIdToAliquotMap := map[uint]commonModels.SampleAliquot{}
+	for _, sampleAliquot := range sampleAliquots {
+		aliquotSampleIdToAliquotMap[sampleAliquot.AliquotSampleId] = sampleAliquot
+	}
+
+	sampleIdToParentIdMap := map[uint]uint{}
+	parentIdToChildIdsMap := map[uint][]uint{}
+	for _, basicVisitDetail := range basicVisitDetails {
+		parentSampleId := basicVisitDetail.ParentSampleId
+		if sampleAliquot, ok := aliquotSampleIdToAliquotMap[basicVisitDetail.SampleId]; ok {
+			parentSampleId = sampleAliquot.ParentSampleId
+		}
+		if _, ok := sampleIdToVisitDetailMap[parentSampleId]; !ok {
+			continue
+		}
+		sampleIdToParentIdMap[basicVisitDetail.SampleId] = parentSampleId
+		parentIdToChildIdsMap[parentSampleId] = append(parentIdToChildIdsMap[parentSampleId],
+			basicVisitDetail.SampleId)
+	}
+
+	rootSampleIds, rootSampleIdUsedMap := []uint{}, map[uint]bool{}
+	for _, basicVisitDetail := range basicVisitDetails {
+		if basicVisitDetail.Barcode != barcode {
+			continue
+		}
+		rootSampleId, visitedMap := basicVisitDetail.SampleId, map[uint]bool{}
+		for {
+			parentSampleId, ok := sampleIdToParentIdMap[rootSampleId]
+			if !ok || visitedMap[parentSampleId] {
+				break
+			}
+			visitedMap[rootSampleId] = true
+			rootSampleId = parentSampleId
+		}
+		if !rootSampleIdUsedMap[rootSampleId] {
+			rootSampleIds = append(rootSampleIds, rootSampleId)
+			rootSampleIdUsedMap[rootSampleId] = true
+		}
+	}
+
+	var createLineageNode func(sampleId uint, visitedMap map[uint]bool) structures.InfoScreenSampleLineageResponse
+	createLineageNode = func(sampleId uint, visitedMap map[uint]bool) structures.InfoScreenSampleLineageResponse {
+		visitedMap[sampleId] = true
+		basicVisitDetail := sampleIdToVisitDetailMap[sampleId]
+		labId := basicVisitDetail.LabId
+		if labId == 0 {
+			labId = basicVisitDetail.DestinationLabId
+		}
+		if labId == 0 {
+			labId = servicingLabId
+		}
+		lineageNode := structures.InfoScreenSampleLineageResponse{
+			SampleId:       sampleId,
+			ParentSampleId: sampleIdToParentIdMap[sampleId],
+			LineageType:    commonConstants.SampleLineagePrimary,
+			SampleNumber:   basicVisitDetail.SampleNumber,
+			Barcode:        basicVisitDetail.Barcode,
+			VialTypeID:     basicVisitDetail.VialTypeID,
+			CurrentStatus:  basicVisitDetail.CurrentStatus,
+			LabId:          labId,
+			LabName:        labIdMap[labId].LabName,
+			Children:       []structures.InfoScreenSampleLineageResponse{},
+		}
+		if sampleAliquot, ok := aliquotSampleIdToAliquotMap[sampleId]; ok {
+			lineageNode.LineageType = commonConstants.SampleLineageAliquot
+			lineageNode.Volume = sampleAliquot.Volume
+			lineageNode.Department = sampleAliquot.Department
+			lineageNode.AliquotedAt = sampleAliquot.AliquotedAt
+		} else if lineageNode.ParentSampleId != 0 {
+			lineageNode.LineageType = commonConstants.SampleLineageTransfer
+		}
+
+		for _, childSampleId := range parentIdToChildIdsMap[sampleId] {
+			if visitedMap[childSampleId] {
+				continue
+			}
+			lineageNode.Children = append(lineageNode.Children, createLineageNode(childSampleId, visitedMap))
+		}
+		return lineageNode
+	}
+
+	sampleLineage := []structures.InfoScreenSampleLineageResponse{}
+	for _, rootSampleId := range rootSampleIds {
+		sampleLineage = append(sampleLineage, createLineageNode(rootSampleId, map[uint]bool{}))
+	}
+	return sampleLineage
+}
+
 func createSampleTestMap(testDetails []structures.InfoScreenTestDetails,
 	labIdMap map[uint]commonStructures.Lab) map[uint]map[uint]structures.InfoScreenTestDetailsResponse {
 	sampleTestMap := map[uint]map[uint]structures.InfoScreenTestDetailsResponse{}
@@ -615,12 +714,13 @@ func (searchService *SearchService) GetInfoScreenDataByBarcode(ctx context.Conte
 	var (
 		basicVisitDetails []structures.InfoScreenBasicVisitDetails
 		testDetails       []structures.InfoScreenTestDetails
+		sampleAliquots    []commonModels.SampleAliquot
 		labIdMap          map[uint]commonStructures.Lab
 	)
 
 	wg := &sync.WaitGroup{}
-	errChan := make(chan *commonStructures.CommonError, 2)
-	wg.Add(3)
+	errChan := make(chan *commonStructures.CommonError, 3)
+	wg.Add(4)
 
 	// Fetch basicVisitDetails
 	go func() {
@@ -632,6 +732,16 @@ func (searchService *SearchService) GetInfoScreenDataByBarcode(ctx context.Conte
 		}
 	}()
 
+	// Fetch sampleAliquots
+	go func() {
+		defer wg.Done()
+		var err *commonStructures.CommonError
+		sampleAliquots, err = searchService.SearchDao.GetSampleAliquotsByOrderId(orderDetails.OrderID)
+		if err != nil {
+			errChan <- err
+		}
+	}()
+
 	// Fetch testDetails
 	go func() {
 		defer wg.Done()
@@ -657,6 +767,8 @@ func (searchService *SearchService) GetInfoScreenDataByBarcode(ctx context.Conte
 	}
 
 	infoScreenSearchResponse = createSearchResponse(orderDetails, basicVisitDetails, testDetails, labIdMap)
+	infoScreenSearchResponse.SampleLineage = createSampleLineageResponse(barcode, basicVisitDetails, sampleAliquots,
+		orderDetails.ServicingLabId, labIdMap)
 	infoScreenSearchResponse = prependBarcodeInSearchResponse(infoScreenSearchResponse, barcode)
 	return prependSessionLabIdSamplesInSearchSearchResponse(infoScreenSearchResponse, labId,
 		constants.INFO_SCREEN_SEARCH_TYPE_BARCODE), nil
@@ -29,6 +29,13 @@ type SearchServiceInterface interface {
 	GetAmendmentTasksList(ctx context.Context, taskListBasicRequest structures.TaskListBasicRequest) (
 		structures.AmendmentTaskListResponse, *commonStructures.CommonError)
 
+	GetTaskListViews(userId uint) ([]structures.TaskListView, *commonStructures.CommonError)
+	CreateTaskListView(viewRequest structures.TaskListViewRequest, userId uint) (
+		structures.TaskListView, *commonStructures.CommonError)
+	UpdateTaskListView(viewId uint, viewRequest structures.TaskListViewRequest, userId uint) (
+		structures.TaskListView, *commonStructures.CommonError)
+	DeleteTaskListView(viewId, userId uint) *commonStructures.CommonError
+
 	GetBarcodeDetails(barcode, searchType string, labId uint) (structures.BarcodeDetailsResponse,
 		*commonStructures.CommonError)
 	GetInfoScreenDataByBarcode(ctx context.Context, barcode string, labId uint) (structures.InfoScreenSearchResponse,
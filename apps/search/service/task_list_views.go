@@ -0,0 +1,238 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Orange-Health/citadel/apps/search/constants"
+	"github.com/Orange-Health/citadel/apps/search/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func (searchService *SearchService) GetTaskListViews(userId uint) (
+	[]structures.TaskListView, *commonStructures.CommonError) {
+
+	views, cErr := searchService.SearchDao.GetTaskListViewsByUserId(userId)
+	if cErr != nil {
+		return []structures.TaskListView{}, cErr
+	}
+
+	taskListViews := []structures.TaskListView{}
+	for _, view := range views {
+		taskListViews = append(taskListViews, mapTaskListView(view))
+	}
+
+	return taskListViews, nil
+}
+
+func (searchService *SearchService) CreateTaskListView(viewRequest structures.TaskListViewRequest, userId uint) (
+	structures.TaskListView, *commonStructures.CommonError) {
+
+	viewRequest, cErr := validateTaskListViewRequest(viewRequest)
+	if cErr != nil {
+		return structures.TaskListView{}, cErr
+	}
+
+	view := mapTaskListViewRequest(commonModels.TaskListView{}, viewRequest, userId)
+	if cErr := searchService.validateDuplicateTaskListView(view); cErr != nil {
+		return structures.TaskListView{}, cErr
+	}
+
+	view, cErr = searchService.SearchDao.SaveTaskListView(view)
+	if cErr != nil {
+		return structures.TaskListView{}, cErr
+	}
+
+	return mapTaskListView(view), nil
+}
+
+func (searchService *SearchService) UpdateTaskListView(viewId uint, viewRequest structures.TaskListViewRequest,
+	userId uint) (structures.TaskListView, *commonStructures.CommonError) {
+
+	viewRequest, cErr := validateTaskListViewRequest(viewRequest)
+	if cErr != nil {
+		return structures.TaskListView{}, cErr
+	}
+
+	view, cErr := searchService.getTaskListViewById(viewId, userId)
+	if cErr != nil {
+		return structures.TaskListView{}, cErr
+	}
+
+	view = mapTaskListViewRequest(view, viewRequest, userId)
+	if cErr := searchService.validateDuplicateTaskListView(view); cErr != nil {
+		return structures.TaskListView{}, cErr
+	}
+
+	view, cErr = searchService.SearchDao.SaveTaskListView(view)
+	if cErr != nil {
+		return structures.TaskListView{}, cErr
+	}
+
+	return mapTaskListView(view), nil
+}
+
+func (searchService *SearchService) DeleteTaskListView(viewId, userId uint) *commonStructures.CommonError {
+	if _, cErr := searchService.getTaskListViewById(viewId, userId); cErr != nil {
+		return cErr
+	}
+
+	return searchService.SearchDao.DeleteTaskListView(viewId, userId)
+}
+
+func (searchService *SearchService) getTaskListViewById(viewId, userId uint) (
+	commonModels.TaskListView, *commonStructures.CommonError) {
+
+	view, cErr := searchService.SearchDao.GetTaskListViewById(viewId, userId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_TASK_LIST_VIEW_NOT_FOUND
+		}
+		return view, cErr
+	}
+
+	return view, nil
+}
+
+func (searchService *SearchService) validateDuplicateTaskListView(
+	view commonModels.TaskListView) *commonStructures.CommonError {
+
+	existingView, cErr := searchService.SearchDao.GetTaskListViewByName(view.Name, view.UserId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return cErr
+	}
+
+	if existingView.Id != view.Id {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_TASK_LIST_VIEW_ALREADY_EXISTS,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	return nil
+}
+
+// validateTaskListViewRequest validates the saved filters the same way as the task list query parameters and
+// returns the request with the default sort applied.
+func validateTaskListViewRequest(viewRequest structures.TaskListViewRequest) (
+	structures.TaskListViewRequest, *commonStructures.CommonError) {
+
+	viewRequest.Name = strings.TrimSpace(viewRequest.Name)
+	if viewRequest.Name == "" {
+		return viewRequest, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_TASK_LIST_VIEW_NAME_REQUIRED,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	filters := viewRequest.Filters
+	if filters.TaskTypes != "" {
+		for _, taskType := range strings.Split(filters.TaskTypes, ",") {
+			if !commonUtils.SliceContainsString(constants.TASK_TYPES, taskType) {
+				return viewRequest, &commonStructures.CommonError{
+					Message:    commonConstants.ERROR_INVALID_TASK_TYPE,
+					StatusCode: http.StatusBadRequest,
+				}
+			}
+		}
+	}
+
+	taskListBasicRequest := structures.TaskListBasicRequest{}
+	applyTaskListViewFilters(filters, &taskListBasicRequest)
+	if _, cErr := validateAndReturnFilters(taskListBasicRequest); cErr != nil {
+		return viewRequest, cErr
+	}
+
+	if filters.Query != "" {
+		if _, cErr := getSearchTerms(filters.Query); cErr != nil {
+			return viewRequest, cErr
+		}
+	}
+
+	sortBy, sortOrder, cErr := validateAndReturnSortParameters(viewRequest.SortBy, viewRequest.SortOrder)
+	if cErr != nil {
+		return viewRequest, cErr
+	}
+	viewRequest.SortBy, viewRequest.SortOrder = sortBy, sortOrder
+
+	return viewRequest, nil
+}
+
+// applyTaskListView fills the task list request parameters which were not sent with the ones saved in the view.
+func applyTaskListView(view commonModels.TaskListView, taskListBasicRequest *structures.TaskListBasicRequest) {
+	applyTaskListViewFilters(getTaskListViewFilters(view), taskListBasicRequest)
+
+	if taskListBasicRequest.SortBy == "" {
+		taskListBasicRequest.SortBy = view.SortBy
+	}
+	if taskListBasicRequest.SortOrder == "" {
+		taskListBasicRequest.SortOrder = view.SortOrder
+	}
+}
+
+func applyTaskListViewFilters(filters structures.TaskListViewFilters,
+	taskListBasicRequest *structures.TaskListBasicRequest) {
+
+	if taskListBasicRequest.TaskTypes == "" {
+		taskListBasicRequest.TaskTypes = filters.TaskTypes
+	}
+	if taskListBasicRequest.Status == "" {
+		taskListBasicRequest.Status = filters.Status
+	}
+	if taskListBasicRequest.Department == "" {
+		taskListBasicRequest.Department = filters.Department
+	}
+	if taskListBasicRequest.LabId == "" {
+		taskListBasicRequest.LabId = filters.LabId
+	}
+	if taskListBasicRequest.SpecialRequirement == "" {
+		taskListBasicRequest.SpecialRequirement = filters.SpecialRequirement
+	}
+	if taskListBasicRequest.OrderType == "" {
+		taskListBasicRequest.OrderType = filters.OrderType
+	}
+	if taskListBasicRequest.Query == "" {
+		taskListBasicRequest.Query = filters.Query
+	}
+}
+
+func getTaskListViewFilters(view commonModels.TaskListView) structures.TaskListViewFilters {
+	filters := structures.TaskListViewFilters{}
+	_ = json.Unmarshal([]byte(view.Filters), &filters)
+	return filters
+}
+
+func mapTaskListView(view commonModels.TaskListView) structures.TaskListView {
+	return structures.TaskListView{
+		Id:        view.Id,
+		Name:      view.Name,
+		Filters:   getTaskListViewFilters(view),
+		SortBy:    view.SortBy,
+		SortOrder: view.SortOrder,
+		IsDefault: view.IsDefault,
+	}
+}
+
+func mapTaskListViewRequest(view commonModels.TaskListView, viewRequest structures.TaskListViewRequest,
+	userId uint) commonModels.TaskListView {
+
+	if view.Id == 0 {
+		view.UserId = userId
+		view.CreatedBy = userId
+	}
+	filtersBytes, _ := json.Marshal(viewRequest.Filters)
+	view.Name = viewRequest.Name
+	view.Filters = string(filtersBytes)
+	view.SortBy = viewRequest.SortBy
+	view.SortOrder = viewRequest.SortOrder
+	view.IsDefault = viewRequest.IsDefault
+	view.UpdatedBy = userId
+	return view
+}
@@ -1,5 +1,7 @@
 package structures
 
+import "time"
+
 type TaskListBasicRequest struct {
 	TaskTypes          string `json:"task_types"`
 	UserId             uint   `json:"user_id"`
@@ -19,6 +21,11 @@ type TaskListBasicRequest struct {
 	LabId              string `json:"lab_id,omitempty"`
 	SpecialRequirement string `json:"special_requirement,omitempty"`
 	OrderType          string `json:"order_type,omitempty"`
+	Query              string `json:"q,omitempty"`
+	ViewId             uint   `json:"view_id,omitempty"`
+	SortBy             string `json:"sort_by,omitempty"`
+	SortOrder          string `json:"sort_order,omitempty"`
+	Cursor             string `json:"cursor,omitempty"`
 }
 
 type TaskListRequest struct {
@@ -28,6 +35,9 @@ type TaskListRequest struct {
 	Limit     uint
 	Offset    uint
 	UserId    uint
+	SortBy    string
+	SortOrder string
+	Cursor    *TaskListCursor
 }
 
 type TasksSearch struct {
@@ -40,6 +50,7 @@ type TasksSearch struct {
 	VisitId       string
 	RequestId     uint
 	CityCode      string
+	SearchTerms   []string
 }
 
 type TasksFilters struct {
@@ -57,9 +68,37 @@ type TaskListDbRequest struct {
 	PatientName          string
 	PartnerName          string
 	DoctorName           string
+	SearchTerms          []string
+	SortBy               string
+	SortOrder            string
+	Cursor               *TaskListCursor
 	PatientDetailsClause map[string]interface{}
 	TasksClause          map[string]interface{}
 	VisitClause          map[string]interface{}
 	TaskMetadataClause   map[string]interface{}
 	TestDetailsClause    map[string]interface{}
 }
+
+// TaskListCursor is the keyset position of the last task of a page, sent to clients as an opaque string.
+type TaskListCursor struct {
+	SortValue *time.Time `json:"sort_value"`
+	TaskId    uint       `json:"task_id"`
+}
+
+type TaskListViewFilters struct {
+	TaskTypes          string `json:"task_types,omitempty"`
+	Status             string `json:"status,omitempty"`
+	Department         string `json:"department,omitempty"`
+	LabId              string `json:"lab_id,omitempty"`
+	SpecialRequirement string `json:"special_requirement,omitempty"`
+	OrderType          string `json:"order_type,omitempty"`
+	Query              string `json:"q,omitempty"`
+}
+
+type TaskListViewRequest struct {
+	Name      string              `json:"name" binding:"required"`
+	Filters   TaskListViewFilters `json:"filters"`
+	SortBy    string              `json:"sort_by"`
+	SortOrder string              `json:"sort_order"`
+	IsDefault bool                `json:"is_default"`
+}
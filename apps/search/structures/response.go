@@ -16,8 +16,9 @@ type AmendmentTaskListResponse struct {
 }
 
 type TaskResponseStruct struct {
-	Tasks    []TaskDetailsStruct `json:"tasks"`
-	ShowMore bool                `json:"show_more"`
+	Tasks      []TaskDetailsStruct `json:"tasks"`
+	ShowMore   bool                `json:"show_more"`
+	NextCursor string              `json:"next_cursor,omitempty"`
 }
 
 type TaskDetailsStruct struct {
@@ -26,6 +27,7 @@ type TaskDetailsStruct struct {
 	OmsOrderId     string              `json:"oms_order_id,omitempty"`
 	CityCode       string              `json:"city_code"`
 	DoctorTat      *time.Time          `json:"doctor_tat,omitempty"`
+	CreatedAt      *time.Time          `json:"created_at,omitempty"`
 	PickedBy       uint                `json:"picked_by,omitempty"`
 	CoAuthorizedBy uint                `json:"co_authorized_by,omitempty"`
 	ApprovedBy     []uint              `json:"approved_by,omitempty"`
@@ -65,6 +67,16 @@ type TaskDetailsDbStruct struct {
 	ContainsMorphle bool       `json:"contains_morphle"`
 	ContainsPackage bool       `json:"contains_package"`
 	LabId           uint       `json:"lab_id"`
+	CreatedAt       *time.Time `json:"created_at"`
+}
+
+type TaskListView struct {
+	Id        uint                `json:"id"`
+	Name      string              `json:"name"`
+	Filters   TaskListViewFilters `json:"filters"`
+	SortBy    string              `json:"sort_by"`
+	SortOrder string              `json:"sort_order"`
+	IsDefault bool                `json:"is_default"`
 }
 
 type InfoScreenPatientDetailsResponse struct {
@@ -153,6 +165,24 @@ type InfoScreenSearchResponse struct {
 	Request InfoScreenRequestDetailsResponse `json:"request"`
 	Order   InfoScreenOrderDetailsResponse   `json:"order"`
 	Visits  []InfoScreenVisitDetailsResponse `json:"visits"`
+
+	SampleLineage []InfoScreenSampleLineageResponse `json:"sample_lineage,omitempty"`
+}
+
+type InfoScreenSampleLineageResponse struct {
+	SampleId       uint                              `json:"sample_id"`
+	ParentSampleId uint                              `json:"parent_sample_id,omitempty"`
+	LineageType    string                            `json:"lineage_type"`
+	SampleNumber   uint                              `json:"sample_number"`
+	Barcode        string                            `json:"barcode"`
+	VialTypeID     uint                              `json:"vial_type_id"`
+	CurrentStatus  string                            `json:"current_status"`
+	LabId          uint                              `json:"lab_id"`
+	LabName        string                            `json:"lab_name"`
+	Volume         uint                              `json:"volume,omitempty"`
+	Department     string                            `json:"department,omitempty"`
+	AliquotedAt    *time.Time                        `json:"aliquoted_at,omitempty"`
+	Children       []InfoScreenSampleLineageResponse `json:"children"`
 }
 
 type InfoScreenRequestDetailsResponse struct {
@@ -61,6 +61,7 @@ func (taskController *Task) GetTasksCount(c *gin.Context) {
 // @Produce		json
 // @Param			taskDetails	body		structures.UpdateAllTaskDetailsStruct	true	"Task Details"
 // @Success		200			{object}	structures.CommonAPIResponse			"Common API Response"
+// @Failure		409			{object}	structures.CommonAPIResponse			"Conflicting rows with server and client values"
 // @Failure		400,404,500	{object}	structures.CommonAPIResponse			"Common API Response"
 // @Router			/api/v1/tasks/details [patch]
 func (taskController *Task) UpdateAllTaskDetails(c *gin.Context) {
@@ -78,8 +79,15 @@ func (taskController *Task) UpdateAllTaskDetails(c *gin.Context) {
 
 	taskUpdateRequest.UserId = userId
 
-	cErr = taskController.TaskService.UpdateAllTaskDetails(c.Request.Context(), taskUpdateRequest)
+	conflicts, cErr := taskController.TaskService.UpdateAllTaskDetails(c.Request.Context(), taskUpdateRequest)
 	if cErr != nil {
+		if len(conflicts) > 0 {
+			c.JSON(cErr.StatusCode, commonStructs.CommonAPIResponse{
+				Error: cErr.Message,
+				Data:  conflicts,
+			})
+			return
+		}
 		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
 		return
 	}
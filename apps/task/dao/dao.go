@@ -2,6 +2,7 @@ package dao
 
 import (
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	commonConstants "github.com/Orange-Health/citadel/common/constants"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
@@ -22,6 +23,7 @@ type DataLayer interface {
 	UpdateTask(task commonModels.Task) (commonModels.Task, *commonStructures.CommonError)
 	CreateTaskWithTx(tx *gorm.DB, task commonModels.Task) (commonModels.Task, *commonStructures.CommonError)
 	UpdateTaskWithTx(tx *gorm.DB, task commonModels.Task) (commonModels.Task, *commonStructures.CommonError)
+	GetTaskByIdForUpdateWithTx(tx *gorm.DB, taskId uint) (commonModels.Task, *commonStructures.CommonError)
 	DeleteTaskWithTx(tx *gorm.DB, taskId uint) *commonStructures.CommonError
 
 	// Task Metadata
@@ -145,6 +147,16 @@ func (taskDao *TaskDao) UpdateTaskWithTx(tx *gorm.DB, task commonModels.Task) (c
 	return task, nil
 }
 
+func (taskDao *TaskDao) GetTaskByIdForUpdateWithTx(tx *gorm.DB, taskId uint) (commonModels.Task, *commonStructures.CommonError) {
+
+	task := commonModels.Task{}
+	err := tx.Clauses(clause.Locking{Strength: commonConstants.CLAUSE_UPDATE}).First(&task, taskId).Error
+	if err != nil {
+		return task, commonUtils.HandleORMError(err)
+	}
+	return task, nil
+}
+
 func (taskDao *TaskDao) DeleteTaskWithTx(tx *gorm.DB, taskId uint) *commonStructures.CommonError {
 
 	updates := map[string]interface{}{
@@ -44,6 +44,7 @@ func MapTaskDetail(task commonModels.Task, completedAtStr string) structures.Tas
 	taskStruct.OrderType = task.OrderType
 	taskStruct.IsActive = task.IsActive
 	taskStruct.CompletedAt = completedAtStr
+	taskStruct.RowVersion = task.RowVersion
 	taskStruct.CreatedAt = task.CreatedAt
 	taskStruct.UpdatedAt = task.UpdatedAt
 	taskStruct.DeletedAt = commonUtils.GetGoLangTimeFromGormDeletedAt(task.DeletedAt)
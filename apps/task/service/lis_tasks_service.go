@@ -11,49 +11,48 @@ import (
 	commonModels "github.com/Orange-Health/citadel/models"
 )
 
-func (taskService *TaskService) GetVisitIdToAttuneResponseMap(
+func (taskService *TaskService) GetVisitIdToLisOrderMap(
 	ctx context.Context, omsTestIds []string,
-) (map[string]commonStructures.AttuneOrderResponse, *commonStructures.CommonError) {
-	visitIdToAttuneResponseMap := map[string]commonStructures.AttuneOrderResponse{}
+) (map[string]commonStructures.LisOrder, *commonStructures.CommonError) {
+	visitIdToLisOrderMap := map[string]commonStructures.LisOrder{}
 	visitIdLabMap, cErr := taskService.SampleService.GetVisitLabMapByOmsTestIds(omsTestIds)
 	if cErr != nil {
-		return visitIdToAttuneResponseMap, cErr
+		return visitIdToLisOrderMap, cErr
 	}
 
 	for visitId, labId := range visitIdLabMap {
 		if visitId == "" || labId == 0 {
 			continue
 		}
-		attuneResponse, cErr := taskService.AttuneClient.GetPatientVisitDetailsbyVisitNo(ctx, visitId,
-			commonConstants.AttuneReportWithStationery, labId)
+		lisOrder, cErr := taskService.LisService.FetchResults(ctx, visitId, labId)
 		if cErr != nil {
-			return visitIdToAttuneResponseMap, cErr
+			return visitIdToLisOrderMap, cErr
 		}
-		visitIdToAttuneResponseMap[visitId] = attuneResponse
+		visitIdToLisOrderMap[visitId] = lisOrder
 	}
 
-	return visitIdToAttuneResponseMap, nil
+	return visitIdToLisOrderMap, nil
 }
 
-func GetUpdatedAttuneOrderDetailsForRerunTests(
-	response commonStructures.AttuneOrderResponse,
+func GetUpdatedLisOrderForRerunTests(
+	response commonStructures.LisOrder,
 	lisCodeForTestsToBeRerun []string,
 	lisCodeToValueMap map[string]string,
 	lisCodeToRerunInvestigationMap map[string]bool,
 	lisCodeToRerunDetailsMap map[string]commonModels.RerunInvestigationResult,
 	user commonModels.User,
-) commonStructures.AttuneOrderResponse {
-	testStatus := commonConstants.ATTUNE_TEST_STATUS_RECHECK
+) commonStructures.LisOrder {
+	testStatus := commonConstants.LIS_TEST_STATUS_RECHECK
 	currentTime := time.Now()
 	rerunTime := currentTime.Format(commonConstants.DateTimeUTCWithFractionSecWithoutZOffset)
-	orderInfoStruct, newOrderInfoStruct := response.OrderInfo, []commonStructures.AttuneOrderInfo{}
+	orderInfoStruct, newOrderInfoStruct := response.Tests, []commonStructures.LisTestResult{}
 
 	attuneUserId, _ := strconv.ParseUint(user.AttuneUserId, 10, 64)
 
 	for _, orderInfo := range orderInfoStruct {
 		if commonUtils.SliceContainsString(lisCodeForTestsToBeRerun, orderInfo.TestCode) {
 			orderInfo.TestStatus = testStatus
-			orderInfo.UserID = int(attuneUserId)
+			orderInfo.LisUserId = int(attuneUserId)
 			orderInfo.RerunTime = rerunTime
 			orderInfo.RerunReason = commonConstants.DEFAULT_RERUN_REASON
 			orderInfo.RerunRemarks = commonConstants.DEFAULT_RERUN_REMARK
@@ -69,21 +68,21 @@ func GetUpdatedAttuneOrderDetailsForRerunTests(
 				}
 			}
 
-			for j := range orderInfo.OrderContentListInfo {
-				if value, ok := lisCodeToValueMap[orderInfo.OrderContentListInfo[j].TestCode]; ok {
-					orderInfo.OrderContentListInfo[j].TestValue = value
+			for j := range orderInfo.Parameters {
+				if value, ok := lisCodeToValueMap[orderInfo.Parameters[j].TestCode]; ok {
+					orderInfo.Parameters[j].TestValue = value
 				}
-				orderInfo.OrderContentListInfo[j].UserID = int(attuneUserId)
-				if rerunDetails, ok := lisCodeToRerunDetailsMap[orderInfo.OrderContentListInfo[j].TestCode]; ok {
-					orderInfo.OrderContentListInfo[j].TestStatus = testStatus
-					orderInfo.OrderContentListInfo[j].RerunReason = rerunDetails.RerunReason
-					orderInfo.OrderContentListInfo[j].RerunRemarks = rerunDetails.RerunRemarks
-					orderInfo.OrderContentListInfo[j].RerunTime = rerunTime
+				orderInfo.Parameters[j].LisUserId = int(attuneUserId)
+				if rerunDetails, ok := lisCodeToRerunDetailsMap[orderInfo.Parameters[j].TestCode]; ok {
+					orderInfo.Parameters[j].TestStatus = testStatus
+					orderInfo.Parameters[j].RerunReason = rerunDetails.RerunReason
+					orderInfo.Parameters[j].RerunRemarks = rerunDetails.RerunRemarks
+					orderInfo.Parameters[j].RerunTime = rerunTime
 				}
 
-				params := orderInfo.OrderContentListInfo[j].ParameterListInfo
+				params := orderInfo.Parameters[j].Parameters
 				for k := range params {
-					params[k].UserID = int(attuneUserId)
+					params[k].LisUserId = int(attuneUserId)
 					if rerunDetails, ok := lisCodeToRerunDetailsMap[params[k].TestCode]; ok {
 						params[k].TestStatus = testStatus
 						params[k].RerunReason = rerunDetails.RerunReason
@@ -94,29 +93,28 @@ func GetUpdatedAttuneOrderDetailsForRerunTests(
 						params[k].TestValue = value
 					}
 				}
-				orderInfo.OrderContentListInfo[j].ParameterListInfo = params
+				orderInfo.Parameters[j].Parameters = params
 			}
 
 			newOrderInfoStruct = append(newOrderInfoStruct, orderInfo)
 		}
 	}
 
-	return commonStructures.AttuneOrderResponse{
-		OrderId:   response.OrderId,
-		OrgCode:   response.OrgCode,
-		OrderInfo: newOrderInfoStruct,
+	return commonStructures.LisOrder{
+		VisitId: response.VisitId,
+		Tests:   newOrderInfoStruct,
 	}
 }
 
-func (taskService *TaskService) FetchAttuneDataForReruningTests(ctx context.Context, cityCode string,
+func (taskService *TaskService) FetchLisDataForReruningTests(ctx context.Context, cityCode string,
 	testDetailsIdsToBeRerun []uint,
 	testDetailIdToTestDetailMap map[uint]commonModels.TestDetail,
 	investigations []commonModels.InvestigationResult,
 	rerunDetails []commonModels.RerunInvestigationResult,
 	user commonModels.User) (
-	map[string]commonStructures.AttuneOrderResponse, *commonStructures.CommonError) {
+	map[string]commonStructures.LisOrder, *commonStructures.CommonError) {
 
-	updatedVisitIdToAttuneResponseMap := map[string]commonStructures.AttuneOrderResponse{}
+	updatedVisitIdToLisOrderMap := map[string]commonStructures.LisOrder{}
 	lisCodeToValueMap := map[string]string{}
 	lisCodeToRerunDetailsMap := map[string]commonModels.RerunInvestigationResult{}
 	lisCodeToRerunInvestigationMap := map[string]bool{}
@@ -129,12 +127,12 @@ func (taskService *TaskService) FetchAttuneDataForReruningTests(ctx context.Cont
 	}
 
 	if len(omsTestIds) == 0 {
-		return updatedVisitIdToAttuneResponseMap, nil
+		return updatedVisitIdToLisOrderMap, nil
 	}
 
-	visitIdToAttuneResponseMap, cErr := taskService.GetVisitIdToAttuneResponseMap(ctx, omsTestIds)
+	visitIdToLisOrderMap, cErr := taskService.GetVisitIdToLisOrderMap(ctx, omsTestIds)
 	if cErr != nil {
-		return updatedVisitIdToAttuneResponseMap, cErr
+		return updatedVisitIdToLisOrderMap, cErr
 	}
 
 	for _, investigation := range investigations {
@@ -148,37 +146,37 @@ func (taskService *TaskService) FetchAttuneDataForReruningTests(ctx context.Cont
 		lisCodeToRerunDetailsMap[rerunDetail.LisCode] = rerunDetail
 	}
 
-	for visitId, attuneResponse := range visitIdToAttuneResponseMap {
-		updatedAttuneResponse := GetUpdatedAttuneOrderDetailsForRerunTests(attuneResponse,
+	for visitId, lisOrder := range visitIdToLisOrderMap {
+		updatedLisOrder := GetUpdatedLisOrderForRerunTests(lisOrder,
 			lisCodesForTestToBeRerun, lisCodeToValueMap, lisCodeToRerunInvestigationMap, lisCodeToRerunDetailsMap, user)
-		updatedVisitIdToAttuneResponseMap[visitId] = updatedAttuneResponse
+		updatedVisitIdToLisOrderMap[visitId] = updatedLisOrder
 	}
 
 	commonUtils.AddLog(ctx, commonConstants.DEBUG_LEVEL, commonUtils.GetCurrentFunctionName(), map[string]interface{}{
-		"updatedVisitIdToAttuneResponseMap": updatedVisitIdToAttuneResponseMap,
+		"updatedVisitIdToLisOrderMap": updatedVisitIdToLisOrderMap,
 	}, nil)
 
-	return updatedVisitIdToAttuneResponseMap, nil
+	return updatedVisitIdToLisOrderMap, nil
 }
 
-func GetUpdatedAttuneOrderDetailsForApprovedTests(
-	response commonStructures.AttuneOrderResponse,
+func GetUpdatedLisOrderForApprovedTests(
+	response commonStructures.LisOrder,
 	lisCodeForTestsToBeApproved []string,
 	lisCodeToValueMap map[string]string,
 	lisCodeToMedicalRemarkMap map[string]string,
 	testLisCodeToApprovedByMap map[string]uint,
 	approvedByIdToAttuneIdMap map[uint]uint,
-) commonStructures.AttuneOrderResponse {
-	testStatus := commonConstants.ATTUNE_TEST_STATUS_APPROVED
+) commonStructures.LisOrder {
+	testStatus := commonConstants.LIS_TEST_STATUS_APPROVED
 	currentTime := time.Now()
 	approvedAt := currentTime.Format(commonConstants.DateTimeUTCWithFractionSecWithoutZOffset)
-	orderInfoStruct, newOrderInfoStruct := response.OrderInfo, []commonStructures.AttuneOrderInfo{}
+	orderInfoStruct, newOrderInfoStruct := response.Tests, []commonStructures.LisTestResult{}
 
 	for _, orderInfo := range orderInfoStruct {
 		if commonUtils.SliceContainsString(lisCodeForTestsToBeApproved, orderInfo.TestCode) {
 			userId := int(approvedByIdToAttuneIdMap[testLisCodeToApprovedByMap[orderInfo.TestCode]])
 			orderInfo.TestStatus = testStatus
-			orderInfo.UserID = userId
+			orderInfo.LisUserId = userId
 			orderInfo.ResultApprovedAt = &approvedAt
 			orderInfo.RerunReason = ""
 			orderInfo.RerunRemarks = ""
@@ -195,27 +193,27 @@ func GetUpdatedAttuneOrderDetailsForApprovedTests(
 				}
 			}
 
-			for j := range orderInfo.OrderContentListInfo {
-				orderInfo.OrderContentListInfo[j].TestStatus = testStatus
-				if value, ok := lisCodeToValueMap[orderInfo.OrderContentListInfo[j].TestCode]; ok {
-					orderInfo.OrderContentListInfo[j].TestValue = value
+			for j := range orderInfo.Parameters {
+				orderInfo.Parameters[j].TestStatus = testStatus
+				if value, ok := lisCodeToValueMap[orderInfo.Parameters[j].TestCode]; ok {
+					orderInfo.Parameters[j].TestValue = value
 				}
 
-				if medicalRemark, ok := lisCodeToMedicalRemarkMap[orderInfo.OrderContentListInfo[j].TestCode]; ok {
-					orderInfo.OrderContentListInfo[j].MedicalRemarks = medicalRemark
+				if medicalRemark, ok := lisCodeToMedicalRemarkMap[orderInfo.Parameters[j].TestCode]; ok {
+					orderInfo.Parameters[j].MedicalRemarks = medicalRemark
 				} else {
-					orderInfo.OrderContentListInfo[j].MedicalRemarks = ""
+					orderInfo.Parameters[j].MedicalRemarks = ""
 				}
 
-				orderInfo.OrderContentListInfo[j].UserID = userId
-				orderInfo.OrderContentListInfo[j].ResultApprovedAt = &approvedAt
-				orderInfo.OrderContentListInfo[j].RerunReason = ""
-				orderInfo.OrderContentListInfo[j].RerunRemarks = ""
+				orderInfo.Parameters[j].LisUserId = userId
+				orderInfo.Parameters[j].ResultApprovedAt = &approvedAt
+				orderInfo.Parameters[j].RerunReason = ""
+				orderInfo.Parameters[j].RerunRemarks = ""
 
-				params := orderInfo.OrderContentListInfo[j].ParameterListInfo
+				params := orderInfo.Parameters[j].Parameters
 				for k := range params {
 					params[k].TestStatus = testStatus
-					params[k].UserID = userId
+					params[k].LisUserId = userId
 					params[k].ResultApprovedAt = &approvedAt
 					params[k].RerunReason = ""
 					params[k].RerunRemarks = ""
@@ -229,35 +227,34 @@ func GetUpdatedAttuneOrderDetailsForApprovedTests(
 						params[k].MedicalRemarks = ""
 					}
 				}
-				orderInfo.OrderContentListInfo[j].ParameterListInfo = params
+				orderInfo.Parameters[j].Parameters = params
 			}
 
 			newOrderInfoStruct = append(newOrderInfoStruct, orderInfo)
 		}
 	}
 
-	return commonStructures.AttuneOrderResponse{
-		OrderId:   response.OrderId,
-		OrgCode:   response.OrgCode,
-		OrderInfo: newOrderInfoStruct,
+	return commonStructures.LisOrder{
+		VisitId: response.VisitId,
+		Tests:   newOrderInfoStruct,
 	}
 }
 
-func (taskService *TaskService) FetchAttuneDataForApprovingTests(ctx context.Context, task commonModels.Task,
+func (taskService *TaskService) FetchLisDataForApprovingTests(ctx context.Context, task commonModels.Task,
 	testDetailsToBeAproved []commonModels.TestDetail,
 	investigations []commonModels.InvestigationResult,
 	medicalRemarks []commonModels.Remark,
 	approvedByUsers []commonModels.User,
-) (map[string]commonStructures.AttuneOrderResponse, *commonStructures.CommonError) {
+) (map[string]commonStructures.LisOrder, *commonStructures.CommonError) {
 
-	updatedVisitIdToAttuneResponseMap := map[string]commonStructures.AttuneOrderResponse{}
+	updatedVisitIdToLisOrderMap := map[string]commonStructures.LisOrder{}
 	lisCodeToValueMap, investigationIdToLisCodeMap := map[string]string{}, map[uint]string{}
 	lisCodeToMedicalRemarkMap, approvedByIdToAttuneIdMap := map[string]string{}, map[uint]uint{}
 	testDetailIdToTestLisCodeMap, testLisCodeToApprovedByMap := map[uint]string{}, map[string]uint{}
 	omsTestIds, lisCodesForTestToBeApproved := []string{}, []string{}
 
 	if len(testDetailsToBeAproved) == 0 {
-		return updatedVisitIdToAttuneResponseMap, nil
+		return updatedVisitIdToLisOrderMap, nil
 	}
 
 	for _, testDetail := range testDetailsToBeAproved {
@@ -266,9 +263,9 @@ func (taskService *TaskService) FetchAttuneDataForApprovingTests(ctx context.Con
 		testDetailIdToTestLisCodeMap[testDetail.Id] = testDetail.LisCode
 	}
 
-	visitIdToAttuneResponseMap, cErr := taskService.GetVisitIdToAttuneResponseMap(ctx, omsTestIds)
+	visitIdToLisOrderMap, cErr := taskService.GetVisitIdToLisOrderMap(ctx, omsTestIds)
 	if cErr != nil {
-		return updatedVisitIdToAttuneResponseMap, cErr
+		return updatedVisitIdToLisOrderMap, cErr
 	}
 
 	for _, investigation := range investigations {
@@ -286,17 +283,17 @@ func (taskService *TaskService) FetchAttuneDataForApprovingTests(ctx context.Con
 		approvedByIdToAttuneIdMap[user.Id] = uint(attuneUserId)
 	}
 
-	for visitId, attuneResponse := range visitIdToAttuneResponseMap {
-		updatedOrderDetails := GetUpdatedAttuneOrderDetailsForApprovedTests(attuneResponse,
+	for visitId, lisOrder := range visitIdToLisOrderMap {
+		updatedOrderDetails := GetUpdatedLisOrderForApprovedTests(lisOrder,
 			lisCodesForTestToBeApproved, lisCodeToValueMap, lisCodeToMedicalRemarkMap, testLisCodeToApprovedByMap, approvedByIdToAttuneIdMap)
-		if len(updatedOrderDetails.OrderInfo) > 0 {
-			updatedVisitIdToAttuneResponseMap[visitId] = updatedOrderDetails
+		if len(updatedOrderDetails.Tests) > 0 {
+			updatedVisitIdToLisOrderMap[visitId] = updatedOrderDetails
 		}
 	}
 
 	commonUtils.AddLog(ctx, commonConstants.DEBUG_LEVEL, commonUtils.GetCurrentFunctionName(), map[string]interface{}{
-		"updatedVisitIdToAttuneResponseMap": updatedVisitIdToAttuneResponseMap,
+		"updatedVisitIdToLisOrderMap": updatedVisitIdToLisOrderMap,
 	}, nil)
 
-	return updatedVisitIdToAttuneResponseMap, nil
+	return updatedVisitIdToLisOrderMap, nil
 }
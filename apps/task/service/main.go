@@ -9,17 +9,22 @@ import (
 	"github.com/Orange-Health/citadel/adapters/sentry"
 	cdsService "github.com/Orange-Health/citadel/apps/cds/service"
 	coAuthorizePathologistService "github.com/Orange-Health/citadel/apps/co_authorize_pathologists/service"
+	criticalCallService "github.com/Orange-Health/citadel/apps/critical_calls/service"
+	cultureResultsService "github.com/Orange-Health/citadel/apps/culture_results/service"
+	deltaCheckService "github.com/Orange-Health/citadel/apps/delta_check/service"
 	etsService "github.com/Orange-Health/citadel/apps/ets/service"
 	investigationResultsService "github.com/Orange-Health/citadel/apps/investigation_results/service"
+	lisService "github.com/Orange-Health/citadel/apps/lis/service"
+	reflexRulesService "github.com/Orange-Health/citadel/apps/reflex_rules/service"
 	remarkService "github.com/Orange-Health/citadel/apps/remarks/service"
 	rerunService "github.com/Orange-Health/citadel/apps/rerun/service"
 	sampleService "github.com/Orange-Health/citadel/apps/samples/service"
 	"github.com/Orange-Health/citadel/apps/task/dao"
 	"github.com/Orange-Health/citadel/apps/task/structures"
+	taskEventsService "github.com/Orange-Health/citadel/apps/task_events/service"
 	taskPathService "github.com/Orange-Health/citadel/apps/task_pathologist_mapping/service"
 	testDetailService "github.com/Orange-Health/citadel/apps/test_detail/service"
 	userService "github.com/Orange-Health/citadel/apps/users/service"
-	attuneClient "github.com/Orange-Health/citadel/clients/attune"
 	omsClient "github.com/Orange-Health/citadel/clients/oms"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
 	commonModels "github.com/Orange-Health/citadel/models"
@@ -38,9 +43,14 @@ type TaskService struct {
 	RemarkService                 remarkService.RemarkServiceInterface
 	EtsService                    etsService.EtsServiceInterface
 	CdsService                    cdsService.CdsServiceInterface
+	DeltaCheckService             deltaCheckService.DeltaCheckServiceInterface
+	CriticalCallService           criticalCallService.CriticalCallServiceInterface
+	CultureResultsService         cultureResultsService.CultureResultsServiceInterface
+	ReflexRulesService            reflexRulesService.ReflexRulesServiceInterface
+	TaskEventsService             taskEventsService.TaskEventsServiceInterface
 	UserService                   userService.UserServiceInterface
 	OmsClient                     omsClient.OmsClientInterface
-	AttuneClient                  attuneClient.AttuneClientInterface
+	LisService                    lisService.LisServiceInterface
 }
 
 type TaskServiceInterface interface {
@@ -55,20 +65,21 @@ type TaskServiceInterface interface {
 	UpdateTaskWithTx(tx *gorm.DB, task commonModels.Task) (commonModels.Task, *commonStructures.CommonError)
 	DeleteTaskWithTx(tx *gorm.DB, taskID uint) *commonStructures.CommonError
 	GetTaskIdByVisitId(visitId string) (uint, *commonStructures.CommonError)
-	UpdateAllTaskDetails(ctx context.Context, taskDetails structures.UpdateAllTaskDetailsStruct) *commonStructures.CommonError
+	UpdateAllTaskDetails(ctx context.Context, taskDetails structures.UpdateAllTaskDetailsStruct) (
+		[]structures.TaskEditConflict, *commonStructures.CommonError)
 	UndoReportRelease(ctx context.Context, taskID uint) *commonStructures.CommonError
 	GetTaskCallingDetails(taskId, userId uint, callingType string) (
 		structures.TaskCallingDetailsResponse, *commonStructures.CommonError)
 	GetQcFailedTestDataToRerun(ctx context.Context, userId uint, testDetailsIdsToRerun []uint, cityCode string,
 		testDetails []commonModels.TestDetail, qcFailedInvestigations []commonModels.InvestigationResult,
 		userIdToAttuneUserId map[uint]int) (
-		[]commonModels.RerunInvestigationResult, map[string]commonStructures.AttuneOrderResponse, *commonStructures.CommonError)
+		[]commonModels.RerunInvestigationResult, map[string]commonStructures.LisOrder, *commonStructures.CommonError)
 
-	// Attune Task Data
-	FetchAttuneDataForApprovingTests(ctx context.Context, task commonModels.Task,
+	// LIS Task Data
+	FetchLisDataForApprovingTests(ctx context.Context, task commonModels.Task,
 		testDetailsToBeAproved []commonModels.TestDetail, investigations []commonModels.InvestigationResult,
 		medicalRemarks []commonModels.Remark, approvedByUsers []commonModels.User,
-	) (map[string]commonStructures.AttuneOrderResponse, *commonStructures.CommonError)
+	) (map[string]commonStructures.LisOrder, *commonStructures.CommonError)
 
 	// Task Metadata
 	GetTaskMetadataByTaskId(taskID uint) (commonModels.TaskMetadata, *commonStructures.CommonError)
@@ -96,8 +107,13 @@ func InitializeTaskService() TaskServiceInterface {
 		RemarkService:                 remarkService.InitializeRemarkService(),
 		EtsService:                    etsService.InitializeEtsService(),
 		CdsService:                    cdsService.InitializeCdsService(),
+		DeltaCheckService:             deltaCheckService.InitializeDeltaCheckService(),
+		CriticalCallService:           criticalCallService.InitializeCriticalCallService(),
+		CultureResultsService:         cultureResultsService.InitializeCultureResultsService(),
+		ReflexRulesService:            reflexRulesService.InitializeReflexRulesService(),
+		TaskEventsService:             taskEventsService.InitializeTaskEventsService(),
 		UserService:                   userService.InitializeUserService(),
 		OmsClient:                     omsClient.InitializeOmsClient(),
-		AttuneClient:                  attuneClient.InitializeAttuneClient(),
+		LisService:                    lisService.InitializeLisService(),
 	}
 }
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/apps/task/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func getTaskEditConflictError() *commonStructures.CommonError {
+	return &commonStructures.CommonError{
+		Message:    commonConstants.ERROR_TASK_EDIT_CONFLICT,
+		StatusCode: http.StatusConflict,
+	}
+}
+
+func hasRowVersionsInUpdateRequest(taskDetails structures.UpdateTaskStruct) bool {
+	if taskDetails.RowVersion != 0 {
+		return true
+	}
+	for _, testDetail := range taskDetails.TestDetails {
+		if testDetail.RowVersion != 0 {
+			return true
+		}
+		for _, investigation := range testDetail.Investigations {
+			if investigation.RowVersion != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getTaskEditConflictsWithTx locks the rows carrying a row version in the request and compares them with the
+// versions held by the client. The locks are held till the transaction ends so the rows cannot be modified
+// between the check and the update. The updated task and test details are the values the client is saving.
+func (taskService *TaskService) getTaskEditConflictsWithTx(tx *gorm.DB, taskDetails structures.UpdateTaskStruct,
+	updatedTask commonModels.Task, updatedTestDetails []commonModels.TestDetail) (
+	[]structures.TaskEditConflict, *commonStructures.CommonError) {
+
+	if !hasRowVersionsInUpdateRequest(taskDetails) {
+		return nil, nil
+	}
+
+	testDetailIds, investigationIds := []uint{}, []uint{}
+	for _, testDetail := range taskDetails.TestDetails {
+		if testDetail.RowVersion != 0 {
+			testDetailIds = append(testDetailIds, testDetail.Id)
+		}
+		for _, investigation := range testDetail.Investigations {
+			if investigation.RowVersion != 0 {
+				investigationIds = append(investigationIds, investigation.Id)
+			}
+		}
+	}
+
+	task, cErr := taskService.TaskDao.GetTaskByIdForUpdateWithTx(tx, taskDetails.Id)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	testDetails, cErr := taskService.TestDetailService.GetTestDetailsByIdsForUpdateWithTx(tx, testDetailIds)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	investigations, cErr := taskService.InvestigationResultsService.GetInvestigationsByIdsForUpdateWithTx(tx,
+		investigationIds)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	conflicts := getTaskEditConflicts(taskDetails, task, testDetails, investigations, nil, updatedTask,
+		updatedTestDetails)
+	if len(conflicts) == 0 || len(investigationIds) == 0 {
+		return conflicts, nil
+	}
+
+	investigationsData, cErr := taskService.InvestigationResultsService.
+		GetInvestigationDataByInvestigationResultsIds(investigationIds)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	return getTaskEditConflicts(taskDetails, task, testDetails, investigations, investigationsData, updatedTask,
+		updatedTestDetails), nil
+}
+
+// getTaskEditConflicts returns the rows whose row version on the server differs from the one sent by the client,
+// along with the fields where the server values differ from the values the client is trying to save. The task
+// and test details sent by the client only carry their investigations, so their values are taken from the
+// updated task and test details derived from the request.
+func getTaskEditConflicts(taskDetails structures.UpdateTaskStruct, task commonModels.Task,
+	testDetails []commonModels.TestDetail, investigations []commonModels.InvestigationResult,
+	investigationsData []commonModels.InvestigationData, updatedTask commonModels.Task,
+	updatedTestDetails []commonModels.TestDetail) []structures.TaskEditConflict {
+
+	conflicts := []structures.TaskEditConflict{}
+	testDetailIdToTestDetailMap := make(map[uint]commonModels.TestDetail)
+	testDetailIdToUpdatedTestDetailMap := make(map[uint]commonModels.TestDetail)
+	investigationIdToInvestigationMap := make(map[uint]commonModels.InvestigationResult)
+	investigationIdToInvestigationDataMap := make(map[uint]commonModels.InvestigationData)
+
+	for _, testDetail := range testDetails {
+		testDetailIdToTestDetailMap[testDetail.Id] = testDetail
+	}
+	for _, testDetail := range updatedTestDetails {
+		testDetailIdToUpdatedTestDetailMap[testDetail.Id] = testDetail
+	}
+	for _, investigation := range investigations {
+		investigationIdToInvestigationMap[investigation.Id] = investigation
+	}
+	for _, investigationData := range investigationsData {
+		investigationIdToInvestigationDataMap[investigationData.InvestigationResultId] = investigationData
+	}
+
+	if taskDetails.RowVersion != 0 && taskDetails.RowVersion != task.RowVersion {
+		conflicts = append(conflicts, structures.TaskEditConflict{
+			Entity:           commonConstants.TableTasks,
+			Id:               task.Id,
+			ClientRowVersion: taskDetails.RowVersion,
+			ServerRowVersion: task.RowVersion,
+			ServerUpdatedBy:  task.UpdatedBy,
+			ServerUpdatedAt:  task.UpdatedAt,
+			Fields:           getTaskConflictFields(task, updatedTask),
+		})
+	}
+
+	for _, testDetailStruct := range taskDetails.TestDetails {
+		testDetail, ok := testDetailIdToTestDetailMap[testDetailStruct.Id]
+		if ok && testDetailStruct.RowVersion != 0 && testDetailStruct.RowVersion != testDetail.RowVersion {
+			conflicts = append(conflicts, structures.TaskEditConflict{
+				Entity:           commonConstants.TableTestDetails,
+				Id:               testDetail.Id,
+				ClientRowVersion: testDetailStruct.RowVersion,
+				ServerRowVersion: testDetail.RowVersion,
+				ServerUpdatedBy:  testDetail.UpdatedBy,
+				ServerUpdatedAt:  testDetail.UpdatedAt,
+				Fields: getTestDetailConflictFields(testDetail,
+					testDetailIdToUpdatedTestDetailMap[testDetail.Id]),
+			})
+		}
+
+		for _, investigationStruct := range testDetailStruct.Investigations {
+			investigation, ok := investigationIdToInvestigationMap[investigationStruct.Id]
+			if !ok || investigationStruct.RowVersion == 0 || investigationStruct.RowVersion == investigation.RowVersion {
+				continue
+			}
+			conflicts = append(conflicts, structures.TaskEditConflict{
+				Entity:           commonConstants.TableInvestigationResults,
+				Id:               investigation.Id,
+				ClientRowVersion: investigationStruct.RowVersion,
+				ServerRowVersion: investigation.RowVersion,
+				ServerUpdatedBy:  investigation.UpdatedBy,
+				ServerUpdatedAt:  investigation.UpdatedAt,
+				Fields: getInvestigationConflictFields(investigationStruct, investigation,
+					investigationIdToInvestigationDataMap),
+			})
+		}
+	}
+
+	return conflicts
+}
+
+func getInvestigationConflictFields(investigationStruct structures.UpdateInvestigationStruct,
+	investigation commonModels.InvestigationResult,
+	investigationIdToInvestigationDataMap map[uint]commonModels.InvestigationData,
+) []structures.TaskEditConflictField {
+
+	fields := []structures.TaskEditConflictField{}
+	fields = appendConflictField(fields, commonConstants.TaskEditConflictFieldStatus,
+		investigation.InvestigationStatus, investigationStruct.Status)
+	fields = appendConflictField(fields, commonConstants.TaskEditConflictFieldInvestigationValue,
+		investigation.InvestigationValue, investigationStruct.InvestigationValue)
+	fields = appendConflictField(fields, commonConstants.TaskEditConflictFieldAbnormality,
+		investigation.Abnormality, investigationStruct.Abnormality)
+
+	if investigationData, ok := investigationIdToInvestigationDataMap[investigation.Id]; ok &&
+		investigationStruct.InvestigationData != "" {
+		fields = appendConflictField(fields, commonConstants.TaskEditConflictFieldInvestigationData,
+			investigationData.Data, investigationStruct.InvestigationData)
+	}
+
+	return fields
+}
+
+func getTaskConflictFields(task, updatedTask commonModels.Task) []structures.TaskEditConflictField {
+	return appendConflictField([]structures.TaskEditConflictField{}, commonConstants.TaskEditConflictFieldStatus,
+		task.Status, updatedTask.Status)
+}
+
+func getTestDetailConflictFields(testDetail,
+	updatedTestDetail commonModels.TestDetail) []structures.TaskEditConflictField {
+
+	fields := []structures.TaskEditConflictField{}
+	fields = appendConflictField(fields, commonConstants.TaskEditConflictFieldStatus, testDetail.Status,
+		updatedTestDetail.Status)
+	fields = appendConflictField(fields, commonConstants.TaskEditConflictFieldApprovalSource,
+		testDetail.ApprovalSource, updatedTestDetail.ApprovalSource)
+
+	return fields
+}
+
+func appendConflictField(fields []structures.TaskEditConflictField, field, serverValue,
+	clientValue string) []structures.TaskEditConflictField {
+
+	if serverValue == clientValue {
+		return fields
+	}
+
+	return append(fields, structures.TaskEditConflictField{
+		Field:       field,
+		ServerValue: serverValue,
+		ClientValue: clientValue,
+	})
+}
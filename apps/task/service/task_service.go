@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -11,6 +12,8 @@ import (
 
 	"gorm.io/gorm"
 
+	criticalCallStructures "github.com/Orange-Health/citadel/apps/critical_calls/structures"
+	deltaCheckMapper "github.com/Orange-Health/citadel/apps/delta_check/mapper"
 	mapper "github.com/Orange-Health/citadel/apps/task/mapper"
 	"github.com/Orange-Health/citadel/apps/task/structures"
 	"github.com/Orange-Health/citadel/common/constants"
@@ -368,84 +371,130 @@ func (taskService *TaskService) updateTaskDetailsAfterValidation(ctx context.Con
 	deleteRemarkIds []uint,
 	rerunInvestigationResults []commonModels.RerunInvestigationResult,
 	testDetailsIdsToBeRerun []uint,
-	visitIdToAttuneOrderMap map[string]commonStructures.AttuneOrderResponse,
-	userId uint) *commonStructures.CommonError {
+	visitIdToLisOrderMap map[string]commonStructures.LisOrder,
+	createInvestigationsMetadata []commonModels.InvestigationResultMetadata,
+	updateInvestigationsMetadata []commonModels.InvestigationResultMetadata,
+	updateTaskMetadata *commonModels.TaskMetadata,
+	taskDetails structures.UpdateTaskStruct,
+	userId uint) ([]structures.TaskEditConflict, *commonStructures.CommonError) {
 
 	tx := taskService.TaskDao.GetDbTransactionObject()
 	defer tx.Rollback()
 
-	_, cErr := taskService.TaskDao.UpdateTaskWithTx(tx, task)
+	conflicts, cErr := taskService.getTaskEditConflictsWithTx(tx, taskDetails, task, testDetails)
 	if cErr != nil {
-		return cErr
+		return nil, cErr
+	}
+	if len(conflicts) > 0 {
+		return conflicts, getTaskEditConflictError()
+	}
+
+	_, cErr = taskService.TaskDao.UpdateTaskWithTx(tx, task)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	if updateTaskMetadata != nil {
+		_, cErr = taskService.TaskDao.UpdateTaskMetadataWithTx(tx, *updateTaskMetadata)
+		if cErr != nil {
+			return nil, cErr
+		}
 	}
 
 	_, cErr = taskService.TestDetailService.UpdateTestDetailsWithTx(tx, testDetails)
 	if cErr != nil {
-		return cErr
+		return nil, cErr
 	}
 
 	_, cErr = taskService.InvestigationResultsService.UpdateInvestigationResultsWithTx(tx, investigations)
 	if cErr != nil {
-		return cErr
+		return nil, cErr
 	}
 
 	_, cErr = taskService.InvestigationResultsService.UpdateInvestigationsDataWithTx(tx, investigationsData)
 	if cErr != nil {
-		return cErr
+		return nil, cErr
+	}
+
+	cErr = taskService.CultureResultsService.SyncCultureResultsWithTx(ctx, tx, investigationsData)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	cErr = taskService.ReflexRulesService.EvaluateReflexRulesWithTx(ctx, tx, testDetails, investigations, userId)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	cErr = taskService.CriticalCallService.CreateCriticalCallsWithTx(ctx, tx, testDetails, investigations)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	_, cErr = taskService.InvestigationResultsService.CreateInvestigationResultsMetadataWithTx(ctx, tx,
+		createInvestigationsMetadata)
+	if cErr != nil {
+		return nil, cErr
+	}
+
+	_, cErr = taskService.InvestigationResultsService.UpdateInvestigationResultsMetadataWithTx(ctx, tx,
+		updateInvestigationsMetadata, nil)
+	if cErr != nil {
+		return nil, cErr
 	}
 
 	if len(rerunInvestigationResults) > 0 {
 		_, cErr = taskService.RerunService.CreateRerunInvestigationResultsWithTx(tx, rerunInvestigationResults)
 		if cErr != nil {
-			return cErr
+			return nil, cErr
 		}
 	}
 
 	cErr = taskService.CoAuthorizePathologistService.DeleteCurrentCoAuthorizePathologistWithTx(tx, task.Id, userId)
 	if cErr != nil {
-		return cErr
+		return nil, cErr
 	}
 
 	if coAuthorizePathologist.CoAuthorizedTo != 0 {
 		_, cErr = taskService.CoAuthorizePathologistService.CreateCoAuthrizePathologistWithTx(tx, coAuthorizePathologist)
 		if cErr != nil {
-			return cErr
+			return nil, cErr
 		}
 	}
 
 	if len(createRemarks) > 0 {
 		_, cErr = taskService.RemarkService.CreateRemarksWithTx(tx, createRemarks)
 		if cErr != nil {
-			return cErr
+			return nil, cErr
 		}
 	}
 
 	if len(updateRemarks) > 0 {
 		_, cErr = taskService.RemarkService.UpdateRemarksWithTx(tx, updateRemarks)
 		if cErr != nil {
-			return cErr
+			return nil, cErr
 		}
 	}
 
 	if len(deleteRemarkIds) > 0 {
 		cErr = taskService.RemarkService.DeleteRemarksWithTx(tx, deleteRemarkIds, userId)
 		if cErr != nil {
-			return cErr
+			return nil, cErr
 		}
 	}
 
 	if len(testDetailsIdsToBeRerun) > 0 {
-		for _, attuneResponse := range visitIdToAttuneOrderMap {
-			cErr = taskService.AttuneClient.InsertTestDataToAttune(ctx, attuneResponse)
+		for visitId, lisOrder := range visitIdToLisOrderMap {
+			cErr = taskService.LisService.PushApprovals(ctx, visitId, lisOrder)
 			if cErr != nil {
-				return cErr
+				return nil, cErr
 			}
 		}
 	}
 
 	tx.Commit()
 
-	return nil
+	return nil, nil
 }
 
 func (taskService *TaskService) GetUpdatedRemarkDetails(investigationStruct structures.UpdateInvestigationStruct,
@@ -603,6 +652,8 @@ func (taskService *TaskService) updateNewTaskDetails(testDetails []commonModels.
 			investigation.InvestigationStatus = investigationStruct.Status
 			investigation.Abnormality = investigationStruct.Abnormality
 			investigation.IsAbnormal = getIsAbnormalFlagForInvestigation(investigationStruct.Abnormality)
+			investigation.IsCritical = commonUtils.SliceContainsString(constants.OhCriticalityStringSlice,
+				investigationStruct.Abnormality)
 			if investigationStruct.Status == commonConstants.INVESTIGATION_STATUS_APPROVE {
 				investigation.ApprovedBy = userId
 				investigation.ApprovedAt = currentTime
@@ -689,7 +740,7 @@ func (taskService *TaskService) validatePathologist(taskId, userId uint) bool {
 }
 
 func (taskService *TaskService) UpdateAllTaskDetails(ctx context.Context,
-	allTaskDetails structures.UpdateAllTaskDetailsStruct) *commonStructures.CommonError {
+	allTaskDetails structures.UpdateAllTaskDetailsStruct) ([]structures.TaskEditConflict, *commonStructures.CommonError) {
 
 	taskDetails := allTaskDetails.Task
 	userId := allTaskDetails.UserId
@@ -705,18 +756,18 @@ func (taskService *TaskService) UpdateAllTaskDetails(ctx context.Context,
 	var cErr *commonStructures.CommonError
 	var errList []*commonStructures.CommonError
 	var mu sync.Mutex
-	visitIdToAttuneOrderMap := make(map[string]commonStructures.AttuneOrderResponse)
+	visitIdToLisOrderMap := make(map[string]commonStructures.LisOrder)
 
 	isPathologistValid := taskService.validatePathologist(taskId, userId)
 	if !isPathologistValid {
-		return &commonStructures.CommonError{
+		return nil, &commonStructures.CommonError{
 			Message:    commonConstants.ERROR_ACTION_NOT_ALLOWED_FOR_PATHOLOGIST,
 			StatusCode: http.StatusConflict,
 		}
 	}
 
 	if !validateApprovedInvestigationsByValues(allTaskDetails.Task.TestDetails) {
-		return &commonStructures.CommonError{
+		return nil, &commonStructures.CommonError{
 			Message:    commonConstants.ERROR_NEGATIVE_INVESTIGATION_VALUE,
 			StatusCode: http.StatusBadRequest,
 		}
@@ -766,23 +817,36 @@ func (taskService *TaskService) UpdateAllTaskDetails(ctx context.Context,
 	wg.Wait()
 
 	if len(errList) > 0 {
-		return errList[0]
+		return nil, errList[0]
 	}
 
+	serverTask, serverTestDetails, serverInvestigations, serverInvestigationsData :=
+		task, newTestDetails, newInvestigations, newInvestigationsData
+
 	newTestDetails, newInvestigations, newInvestigationsData, createRemarks,
 		updateRemarks, deleteRemarkIds, rerunDetails, coAuthorizePathologist, cErr :=
 		taskService.updateNewTaskDetails(newTestDetails, newInvestigations,
 			newInvestigationsData, newRemarks, taskDetails, userId)
 	if cErr != nil {
-		return cErr
+		return nil, cErr
 	}
 
 	cErr = taskService.validateUpdateTaskDetailsAndUpdateData(&task, oldTestDetails,
 		oldInvestigations, newTestDetails, newInvestigations)
 	if cErr != nil {
-		return cErr
+		return nil, cErr
+	}
+
+	conflicts := getTaskEditConflicts(taskDetails, serverTask, serverTestDetails, serverInvestigations,
+		serverInvestigationsData, task, newTestDetails)
+	if len(conflicts) > 0 {
+		return conflicts, getTaskEditConflictError()
 	}
 
+	newInvestigations, createInvestigationsMetadata, updateInvestigationsMetadata :=
+		taskService.evaluateDeltaChecksForModifiedInvestigations(ctx, taskId, oldInvestigations, newInvestigations,
+			userId)
+
 	testDetailsIdsToBeRerun, omsTestIdsToRerun := taskService.getTestDetailsToBeRerun(newTestDetails)
 
 	for _, testDetail := range newTestDetails {
@@ -790,10 +854,10 @@ func (taskService *TaskService) UpdateAllTaskDetails(ctx context.Context,
 	}
 
 	if len(testDetailsIdsToBeRerun) != 0 {
-		visitIdToAttuneOrderMap, cErr = taskService.FetchAttuneDataForReruningTests(ctx, task.CityCode,
+		visitIdToLisOrderMap, cErr = taskService.FetchLisDataForReruningTests(ctx, task.CityCode,
 			testDetailsIdsToBeRerun, testDetailIdToTestDetailMap, newInvestigations, rerunDetails, user)
 		if cErr != nil {
-			return cErr
+			return nil, cErr
 		}
 	}
 
@@ -801,19 +865,146 @@ func (taskService *TaskService) UpdateAllTaskDetails(ctx context.Context,
 		remarkIdToRemarkMap[remark.Id] = remark
 	}
 
-	cErr = taskService.updateTaskDetailsAfterValidation(ctx, task, newTestDetails, newInvestigations,
+	taskMetadata, cErr := taskService.TaskDao.GetTaskMetadataByTaskId(taskId)
+	if cErr != nil {
+		return nil, cErr
+	}
+	wasTaskCritical := taskMetadata.IsCritical
+	var updateTaskMetadata *commonModels.TaskMetadata
+	if isTaskCritical := getTaskCriticalityAfterEdit(taskId, oldTestDetails, oldInvestigations,
+		newInvestigations); isTaskCritical != wasTaskCritical {
+		taskMetadata.IsCritical = isTaskCritical
+		taskMetadata.UpdatedBy = userId
+		updateTaskMetadata = &taskMetadata
+	}
+
+	conflicts, cErr = taskService.updateTaskDetailsAfterValidation(ctx, task, newTestDetails, newInvestigations,
 		newInvestigationsData, coAuthorizePathologist,
 		createRemarks, updateRemarks, deleteRemarkIds,
-		rerunDetails, testDetailsIdsToBeRerun, visitIdToAttuneOrderMap, userId)
+		rerunDetails, testDetailsIdsToBeRerun, visitIdToLisOrderMap, createInvestigationsMetadata,
+		updateInvestigationsMetadata, updateTaskMetadata, taskDetails, userId)
 	if cErr != nil {
-		return cErr
+		return conflicts, cErr
+	}
+	if task.Status == commonConstants.TASK_STATUS_COMPLETED && serverTask.Status != task.Status {
+		taskService.TaskEventsService.PublishTaskEvent(ctx, commonConstants.TaskEventCompleted, task.Id, userId)
+	}
+	if taskMetadata.IsCritical && !wasTaskCritical {
+		taskService.TaskEventsService.PublishTaskEvent(ctx, commonConstants.TaskEventCriticalFlagged, task.Id,
+			userId)
 	}
 	if len(omsTestIdsToRerun) != 0 {
 		go taskService.EtsService.GetAndPublishEtsTestEventForLisWebhook(context.Background(), omsTestIdsToRerun,
-			commonConstants.AttuneTestStatusRerun)
+			commonConstants.LIS_TEST_STATUS_RERUN)
 	}
 
-	return nil
+	return nil, nil
+}
+
+// getTaskCriticalityAfterEdit returns if any investigation of the task is critical once the edited investigations
+// are saved.
+func getTaskCriticalityAfterEdit(taskId uint, testDetails []commonModels.TestDetail,
+	investigations, editedInvestigations []commonModels.InvestigationResult) bool {
+
+	taskTestDetailsIds := []uint{}
+	for _, testDetail := range testDetails {
+		if testDetail.TaskId == taskId {
+			taskTestDetailsIds = append(taskTestDetailsIds, testDetail.Id)
+		}
+	}
+
+	editedInvestigationsMap := make(map[uint]commonModels.InvestigationResult)
+	for _, investigation := range editedInvestigations {
+		editedInvestigationsMap[investigation.Id] = investigation
+	}
+
+	for _, investigation := range investigations {
+		if !commonUtils.SliceContainsUint(taskTestDetailsIds, investigation.TestDetailsId) {
+			continue
+		}
+		if editedInvestigation, ok := editedInvestigationsMap[investigation.Id]; ok {
+			investigation = editedInvestigation
+		}
+		if investigation.IsCritical {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evaluateDeltaChecksForModifiedInvestigations runs the delta check for the investigations whose values were
+// modified by the pathologist, records the outcome in AutoApprovalFailureReason and returns the metadata to be
+// created and updated. Failures are only logged as the delta check must not block saving the task.
+func (taskService *TaskService) evaluateDeltaChecksForModifiedInvestigations(ctx context.Context, taskId uint,
+	oldInvestigations, newInvestigations []commonModels.InvestigationResult, userId uint) (
+	[]commonModels.InvestigationResult, []commonModels.InvestigationResultMetadata,
+	[]commonModels.InvestigationResultMetadata) {
+
+	createInvestigationsMetadata, updateInvestigationsMetadata :=
+		[]commonModels.InvestigationResultMetadata{}, []commonModels.InvestigationResultMetadata{}
+
+	oldInvestigationValueMap := map[uint]string{}
+	for _, investigation := range oldInvestigations {
+		oldInvestigationValueMap[investigation.Id] = investigation.InvestigationValue
+	}
+
+	modifiedInvestigations, modifiedInvestigationIds := []commonModels.InvestigationResult{}, []uint{}
+	for _, investigation := range newInvestigations {
+		if investigation.InvestigationValue == "" ||
+			investigation.InvestigationValue == oldInvestigationValueMap[investigation.Id] {
+			continue
+		}
+		modifiedInvestigations = append(modifiedInvestigations, investigation)
+		modifiedInvestigationIds = append(modifiedInvestigationIds, investigation.Id)
+	}
+	if len(modifiedInvestigations) == 0 {
+		return newInvestigations, createInvestigationsMetadata, updateInvestigationsMetadata
+	}
+
+	deltaCheckResults, cErr := taskService.DeltaCheckService.EvaluateDeltaChecksForTask(ctx, taskId,
+		modifiedInvestigations)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_EVALUATING_DELTA_CHECK, nil,
+			errors.New(cErr.Message))
+		return newInvestigations, createInvestigationsMetadata, updateInvestigationsMetadata
+	}
+
+	existingMetadataMap, cErr := taskService.InvestigationResultsService.
+		GetInvestigationResultsMetadataByInvestigationResultIds(ctx, modifiedInvestigationIds)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_EVALUATING_DELTA_CHECK, nil,
+			errors.New(cErr.Message))
+		return newInvestigations, createInvestigationsMetadata, updateInvestigationsMetadata
+	}
+
+	for index, investigation := range newInvestigations {
+		deltaCheckResult, ok := deltaCheckResults[investigation.Id]
+		if !ok {
+			continue
+		}
+
+		failureReason := deltaCheckMapper.GetAutoApprovalFailureReason(deltaCheckResult)
+		if failureReason != "" || commonUtils.HasAutoApprovalFailureReasonCode(investigation.AutoApprovalFailureReason,
+			commonConstants.AUTO_APPROVAL_FAIL_REASON_PAST_RECORD) {
+			newInvestigations[index].AutoApprovalFailureReason = commonUtils.ReplaceAutoApprovalFailureReason(
+				investigation.AutoApprovalFailureReason, commonConstants.AUTO_APPROVAL_FAIL_REASON_PAST_RECORD,
+				failureReason)
+		}
+
+		investigationMetadata, exists := existingMetadataMap[investigation.Id]
+		investigationMetadata = deltaCheckMapper.MapDeltaCheckResultToMetadata(deltaCheckResult, investigationMetadata)
+		investigationMetadata.UpdatedBy = userId
+		if exists {
+			updateInvestigationsMetadata = append(updateInvestigationsMetadata, investigationMetadata)
+			continue
+		}
+		investigationMetadata.InvestigationResultId = investigation.Id
+		investigationMetadata.CreatedBy = userId
+		createInvestigationsMetadata = append(createInvestigationsMetadata, investigationMetadata)
+	}
+
+	return newInvestigations, createInvestigationsMetadata, updateInvestigationsMetadata
 }
 
 func (taskService *TaskService) UndoReportRelease(ctx context.Context, taskID uint) *commonStructures.CommonError {
@@ -900,10 +1091,11 @@ func (taskService *TaskService) GetTaskCallingDetails(taskId, userId uint, calli
 
 	mobileNumber := ""
 	user := commonModels.User{}
+	criticalCalls := []criticalCallStructures.CriticalCall{}
 	var errList []*commonStructures.CommonError
 
 	wg, mu := sync.WaitGroup{}, sync.Mutex{}
-	wg.Add(2)
+	wg.Add(3)
 
 	go func() {
 		defer wg.Done()
@@ -940,6 +1132,17 @@ func (taskService *TaskService) GetTaskCallingDetails(taskId, userId uint, calli
 		}
 	}()
 
+	go func() {
+		defer wg.Done()
+		var cErr *commonStructures.CommonError
+		criticalCalls, cErr = taskService.CriticalCallService.GetCriticalCallsByTaskId(taskId, true)
+		if cErr != nil {
+			mu.Lock()
+			errList = append(errList, cErr)
+			mu.Unlock()
+		}
+	}()
+
 	wg.Wait()
 
 	if len(errList) > 0 {
@@ -947,8 +1150,9 @@ func (taskService *TaskService) GetTaskCallingDetails(taskId, userId uint, calli
 	}
 
 	return structures.TaskCallingDetailsResponse{
-		MobileNumber: mobileNumber,
-		AgentId:      user.AgentId,
+		MobileNumber:  mobileNumber,
+		AgentId:       user.AgentId,
+		CriticalCalls: criticalCalls,
 	}, nil
 }
 
@@ -964,7 +1168,7 @@ func (taskService *TaskService) GetTaskIdByVisitId(visitId string) (uint, *commo
 func (taskService *TaskService) GetQcFailedTestDataToRerun(ctx context.Context, userId uint, testDetailsIdsToRerun []uint,
 	cityCode string, testDetails []commonModels.TestDetail, qcFailedInvestigations []commonModels.InvestigationResult,
 	userIdToAttuneUserId map[uint]int) (
-	[]commonModels.RerunInvestigationResult, map[string]commonStructures.AttuneOrderResponse, *commonStructures.CommonError) {
+	[]commonModels.RerunInvestigationResult, map[string]commonStructures.LisOrder, *commonStructures.CommonError) {
 	testDetailIdToTestDetailMap := map[uint]commonModels.TestDetail{}
 	for _, testDetail := range testDetails {
 		testDetailIdToTestDetailMap[testDetail.Id] = testDetail
@@ -981,31 +1185,32 @@ func (taskService *TaskService) GetQcFailedTestDataToRerun(ctx context.Context,
 			investigationStruct := getUpdateInvestigationStruct(investigation)
 			rerunDetail := taskService.getRerunInvestigationStruct(testDetailIdToTestDetailMap[investigation.TestDetailsId],
 				investigation, investigationStruct, currentTime, userId)
+			rerunDetail.RerunRemarks = getQcFailedRerunRemarks(investigation, rerunDetail.RerunRemarks)
 			rerunDetails = append(rerunDetails, rerunDetail)
 		}
 
-		newVisitIdToAttuneOrderMap, cErr := taskService.FetchAttuneDataForReruningTests(ctx, cityCode,
+		newVisitIdToLisOrderMap, cErr := taskService.FetchLisDataForReruningTests(ctx, cityCode,
 			testDetailsIdsToRerun, testDetailIdToTestDetailMap, qcFailedInvestigations, rerunDetails, user)
 
 		// Adding userId from auto approval of the city code here.
 		userIdForRerun, _ := (strconv.ParseUint(constants.AutoApprovalIdsMap[strings.ToLower(cityCode)], 10, 64))
 		userIdForRerunUint := uint(userIdForRerun)
 		attuneUserId := userIdToAttuneUserId[userIdForRerunUint]
-		for visitId, attuneOrder := range newVisitIdToAttuneOrderMap {
-			for idx, order := range attuneOrder.OrderInfo {
-				order.UserID = attuneUserId
-				attuneOrder.OrderInfo[idx] = order
+		for visitId, lisOrder := range newVisitIdToLisOrderMap {
+			for idx, test := range lisOrder.Tests {
+				test.LisUserId = attuneUserId
+				lisOrder.Tests[idx] = test
 			}
-			newVisitIdToAttuneOrderMap[visitId] = attuneOrder
+			newVisitIdToLisOrderMap[visitId] = lisOrder
 		}
 
 		if cErr != nil {
-			return []commonModels.RerunInvestigationResult{}, map[string]commonStructures.AttuneOrderResponse{}, cErr
+			return []commonModels.RerunInvestigationResult{}, map[string]commonStructures.LisOrder{}, cErr
 		}
-		return rerunDetails, newVisitIdToAttuneOrderMap, nil
+		return rerunDetails, newVisitIdToLisOrderMap, nil
 	}
 
-	return []commonModels.RerunInvestigationResult{}, map[string]commonStructures.AttuneOrderResponse{}, nil
+	return []commonModels.RerunInvestigationResult{}, map[string]commonStructures.LisOrder{}, nil
 }
 
 func getUpdateInvestigationStruct(investigation commonModels.InvestigationResult) structures.UpdateInvestigationStruct {
@@ -1018,6 +1223,20 @@ func getUpdateInvestigationStruct(investigation commonModels.InvestigationResult
 	}
 }
 
+// getQcFailedRerunRemarks lists the Westgard rules violated by the QC run, as evaluated in Citadel,
+// on the rerun remarks. The remarks are left as is for investigations failed only by the upstream QC flags.
+func getQcFailedRerunRemarks(investigation commonModels.InvestigationResult, rerunRemarks string) string {
+	if commonUtils.GetAutoApprovalFailureReasonCode(investigation.AutoApprovalFailureReason) !=
+		commonConstants.AUTO_APPROVAL_FAIL_REASON_QC_FAILED {
+		return rerunRemarks
+	}
+	westgardViolations := commonUtils.GetAutoApprovalFailureReasonDetail(investigation.AutoApprovalFailureReason)
+	if westgardViolations == "" {
+		return rerunRemarks
+	}
+	return fmt.Sprintf(commonConstants.RERUN_REMARKS_WESTGARD_QC_FAIL, westgardViolations)
+}
+
 func validateApprovedInvestigationsByValues(testDetails []structures.UpdateTestDetailsStruct) bool {
 	for _, testDetail := range testDetails {
 		for _, investigation := range testDetail.Investigations {
@@ -1,6 +1,9 @@
 package structures
 
 import (
+	"time"
+
+	criticalCallStructures "github.com/Orange-Health/citadel/apps/critical_calls/structures"
 	patientDetailStruct "github.com/Orange-Health/citadel/apps/patient_details/structures"
 	commonStructures "github.com/Orange-Health/citadel/common/structures"
 )
@@ -35,6 +38,9 @@ type TaskDetail struct {
 	// The completion time of the task.
 	// example: "2022-01-01T00:00:00Z"
 	CompletedAt string `json:"completed_at"`
+	// The row version of the task, sent back while updating the task details.
+	// example: 3
+	RowVersion uint64 `json:"row_version"`
 	// The details of the patient.
 	PatientDetails patientDetailStruct.PatientDetail `json:"patient_details"`
 	// The Task Metadata.
@@ -89,18 +95,23 @@ type UpdateAllTaskDetailsStruct struct {
 	UserId uint             `json:"user_id"`
 }
 
+// RowVersion on the update structs is the row_version the client last read. When set, the edit is rejected with
+// a conflict if the row was modified since.
 type UpdateTaskStruct struct {
 	Id          uint                      `json:"id"`
+	RowVersion  uint64                    `json:"row_version,omitempty"`
 	TestDetails []UpdateTestDetailsStruct `json:"test_details"`
 }
 
 type UpdateTestDetailsStruct struct {
 	Id             uint                        `json:"id"`
+	RowVersion     uint64                      `json:"row_version,omitempty"`
 	Investigations []UpdateInvestigationStruct `json:"investigations"`
 }
 
 type UpdateInvestigationStruct struct {
 	Id                 uint                     `json:"id"`
+	RowVersion         uint64                   `json:"row_version,omitempty"`
 	Status             string                   `json:"status,omitempty"`
 	Abnormality        string                   `json:"abnormality"`
 	InvestigationValue string                   `json:"investigation_value,omitempty"`
@@ -128,6 +139,43 @@ type UpdateRemarkStructDetails struct {
 }
 
 type TaskCallingDetailsResponse struct {
-	MobileNumber string `json:"mobile_number"`
-	AgentId      string `json:"agent_id"`
+	MobileNumber  string                                `json:"mobile_number"`
+	AgentId       string                                `json:"agent_id"`
+	CriticalCalls []criticalCallStructures.CriticalCall `json:"critical_calls"`
+}
+
+// @swagger:response TaskEditConflict
+type TaskEditConflict struct {
+	// The table of the modified row.
+	// example: "investigation_results"
+	Entity string `json:"entity"`
+	// The ID of the modified row.
+	// example: 1
+	Id uint `json:"id"`
+	// The row version sent by the client.
+	// example: 3
+	ClientRowVersion uint64 `json:"client_row_version"`
+	// The current row version on the server.
+	// example: 4
+	ServerRowVersion uint64 `json:"server_row_version"`
+	// The user who last modified the row.
+	// example: 12
+	ServerUpdatedBy uint `json:"server_updated_by,omitempty"`
+	// The time at which the row was last modified.
+	// example: "2022-10-01T00:00:00Z"
+	ServerUpdatedAt *time.Time `json:"server_updated_at,omitempty"`
+	// The fields whose server values differ from the values sent by the client.
+	Fields []TaskEditConflictField `json:"fields"`
+}
+
+type TaskEditConflictField struct {
+	// The name of the field.
+	// example: "investigation_value"
+	Field string `json:"field"`
+	// The current value on the server.
+	// example: "12.5"
+	ServerValue string `json:"server_value"`
+	// The value sent by the client.
+	// example: "13.1"
+	ClientValue string `json:"client_value"`
 }
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/task_assignment/service"
+)
+
+type TaskAssignment struct {
+	TaskAssignmentService service.TaskAssignmentServiceInterface
+}
+
+func InitTaskAssignmentController() *TaskAssignment {
+	return &TaskAssignment{
+		TaskAssignmentService: service.InitializeTaskAssignmentService(),
+	}
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/task_assignment/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get Task Assignment
+// @Description	Get the pathologist a task is assigned to along with why they were picked
+// @Tags			task-assignments
+// @Produce		json
+// @Param			taskId		path		int								true	"Task ID"
+// @Success		200			{object}	structures.TaskAssignment		"Task Assignment"
+// @Failure		400,404,500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/task-assignments/tasks/{taskId} [get]
+func (taskAssignmentController *TaskAssignment) GetTaskAssignment(c *gin.Context) {
+	taskId := commonUtils.ConvertStringToUint(c.Param("taskId"))
+	if taskId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_TASK_ID)
+		return
+	}
+
+	taskAssignment, cErr := taskAssignmentController.TaskAssignmentService.GetTaskAssignment(taskId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, taskAssignment)
+}
+
+// @Summary		Override Task Assignment
+// @Description	Assign a task to a pathologist of choice, only allowed for lead pathologists
+// @Tags			task-assignments
+// @Accept			json
+// @Produce		json
+// @Param			taskId				path		int										true	"Task ID"
+// @Param			override			body		structures.OverrideTaskAssignmentRequest	true	"Override"
+// @Success		200					{object}	structures.TaskAssignment				"Task Assignment"
+// @Failure		400,403,404,409,500	{object}	structures.CommonAPIResponse			"Common API Response"
+// @Router			/api/v1/task-assignments/tasks/{taskId}/override [post]
+func (taskAssignmentController *TaskAssignment) OverrideTaskAssignment(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	taskId := commonUtils.ConvertStringToUint(c.Param("taskId"))
+	if taskId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_TASK_ID)
+		return
+	}
+
+	overrideRequest := structures.OverrideTaskAssignmentRequest{}
+	if err := c.ShouldBindJSON(&overrideRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	taskAssignment, cErr := taskAssignmentController.TaskAssignmentService.OverrideTaskAssignment(
+		c.Request.Context(), taskId, overrideRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, taskAssignment)
+}
+
+// @Summary		Get Pathologist Worklist
+// @Description	Get the open tasks assigned to a pathologist, the critical ones and the ones closest to their
+// @Description	doctor TAT first. Defaults to the worklist of the logged in user.
+// @Tags			task-assignments
+// @Produce		json
+// @Param			pathologist_id	query		int								false	"Pathologist ID"
+// @Success		200				{object}	[]structures.WorklistTask		"Worklist"
+// @Failure		400,401,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/task-assignments/worklist [get]
+func (taskAssignmentController *TaskAssignment) GetPathologistWorklist(c *gin.Context) {
+	pathologistId := commonUtils.ConvertStringToUint(c.Query("pathologist_id"))
+	if pathologistId == 0 {
+		userId, cErr := commonUtils.GetUserIdFromContext(c)
+		if cErr != nil {
+			commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+			return
+		}
+		pathologistId = userId
+	}
+
+	worklist, cErr := taskAssignmentController.TaskAssignmentService.GetPathologistWorklist(pathologistId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, worklist)
+}
+
+// @Summary		Get Pathologist Profiles
+// @Description	Get the departments, capacity, shift and open load of the pathologists
+// @Tags			task-assignments
+// @Produce		json
+// @Success		200	{object}	[]structures.PathologistProfile	"Pathologist Profiles"
+// @Failure		500	{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/task-assignments/profiles [get]
+func (taskAssignmentController *TaskAssignment) GetPathologistProfiles(c *gin.Context) {
+	profiles, cErr := taskAssignmentController.TaskAssignmentService.GetPathologistProfiles()
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
+
+// @Summary		Upsert Pathologist Profile
+// @Description	Set the departments, capacity and shift of a pathologist, only allowed for lead pathologists
+// @Tags			task-assignments
+// @Accept			json
+// @Produce		json
+// @Param			pathologistId	path		int											true	"Pathologist ID"
+// @Param			profile			body		structures.UpsertPathologistProfileRequest	true	"Profile"
+// @Success		200				{object}	structures.PathologistProfile				"Pathologist Profile"
+// @Failure		400,403,500		{object}	structures.CommonAPIResponse				"Common API Response"
+// @Router			/api/v1/task-assignments/profiles/{pathologistId} [put]
+func (taskAssignmentController *TaskAssignment) UpsertPathologistProfile(c *gin.Context) {
+	userId, cErr := commonUtils.GetUserIdFromContext(c)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	pathologistId := commonUtils.ConvertStringToUint(c.Param("pathologistId"))
+	if pathologistId == 0 {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, commonConstants.ERROR_INVALID_PATHOLOGIST_ID)
+		return
+	}
+
+	upsertRequest := structures.UpsertPathologistProfileRequest{}
+	if err := c.ShouldBindJSON(&upsertRequest); err != nil {
+		commonUtils.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	profile, cErr := taskAssignmentController.TaskAssignmentService.UpsertPathologistProfile(pathologistId,
+		upsertRequest, userId)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
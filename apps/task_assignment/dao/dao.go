@@ -0,0 +1,234 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/apps/task_assignment/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type DataLayer interface {
+	GetTaskById(taskId uint) (commonModels.Task, *commonStructures.CommonError)
+	GetUnassignedTasks(limit int) ([]structures.AssignableTaskDbStruct, *commonStructures.CommonError)
+	GetAssignableTasksByIds(taskIds []uint) ([]structures.AssignableTaskDbStruct, *commonStructures.CommonError)
+	GetTaskDepartments(taskIds []uint) ([]structures.TaskDepartmentDbStruct, *commonStructures.CommonError)
+	GetPathologistProfiles() ([]commonModels.PathologistProfile, *commonStructures.CommonError)
+	GetPathologistProfileByPathologistId(pathologistId uint) (
+		commonModels.PathologistProfile, *commonStructures.CommonError)
+	GetOpenTaskCounts() ([]structures.PathologistLoadDbStruct, *commonStructures.CommonError)
+	GetActiveTaskAssignmentByTaskId(taskId uint) (commonModels.TaskAssignment, *commonStructures.CommonError)
+	GetActiveTaskAssignmentsByTaskIds(taskIds []uint) ([]commonModels.TaskAssignment, *commonStructures.CommonError)
+	GetWorklistByPathologistId(pathologistId uint) ([]structures.WorklistTaskDbStruct, *commonStructures.CommonError)
+
+	ReplaceTaskAssignment(taskAssignment commonModels.TaskAssignment) (
+		commonModels.TaskAssignment, *commonStructures.CommonError)
+	DeactivateTaskAssignments(taskIds []uint, userId uint) *commonStructures.CommonError
+	SavePathologistProfile(profile commonModels.PathologistProfile) (
+		commonModels.PathologistProfile, *commonStructures.CommonError)
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) GetTaskById(taskId uint) (commonModels.Task, *commonStructures.CommonError) {
+	task := commonModels.Task{}
+	if err := taskAssignmentDao.Db.Where("id = ?", taskId).First(&task).Error; err != nil {
+		return task, commonUtils.HandleORMError(err)
+	}
+
+	return task, nil
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) assignableTasksQuery() *gorm.DB {
+	return taskAssignmentDao.Db.Table(commonConstants.TableTasks).
+		Select("tasks.id AS task_id, tasks.doctor_tat, COALESCE(task_metadata.is_critical, FALSE) AS is_critical").
+		Joins("LEFT JOIN task_metadata ON task_metadata.task_id = tasks.id AND task_metadata.deleted_at IS NULL").
+		Where("tasks.deleted_at IS NULL").
+		Where("tasks.status IN ?", commonConstants.TASK_ASSIGNMENT_TASK_STATUSES)
+}
+
+// GetUnassignedTasks returns the tasks waiting on a pathologist that nobody is assigned to, the critical ones
+// first and then the ones closest to breaching their doctor TAT.
+func (taskAssignmentDao *TaskAssignmentDao) GetUnassignedTasks(limit int) (
+	[]structures.AssignableTaskDbStruct, *commonStructures.CommonError) {
+
+	tasks := []structures.AssignableTaskDbStruct{}
+	if err := taskAssignmentDao.assignableTasksQuery().
+		Where("NOT EXISTS (SELECT 1 FROM task_assignments WHERE task_assignments.task_id = tasks.id " +
+			"AND task_assignments.is_active AND task_assignments.deleted_at IS NULL)").
+		Order("is_critical DESC, tasks.doctor_tat ASC NULLS LAST, tasks.id").
+		Limit(limit).Scan(&tasks).Error; err != nil {
+		return tasks, commonUtils.HandleORMError(err)
+	}
+
+	return tasks, nil
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) GetAssignableTasksByIds(taskIds []uint) (
+	[]structures.AssignableTaskDbStruct, *commonStructures.CommonError) {
+
+	tasks := []structures.AssignableTaskDbStruct{}
+	if err := taskAssignmentDao.assignableTasksQuery().Where("tasks.id IN ?", taskIds).
+		Scan(&tasks).Error; err != nil {
+		return tasks, commonUtils.HandleORMError(err)
+	}
+
+	return tasks, nil
+}
+
+// GetTaskDepartments returns the departments of the tests of the tasks that are waiting on a pathologist.
+func (taskAssignmentDao *TaskAssignmentDao) GetTaskDepartments(taskIds []uint) (
+	[]structures.TaskDepartmentDbStruct, *commonStructures.CommonError) {
+
+	taskDepartments := []structures.TaskDepartmentDbStruct{}
+	if err := taskAssignmentDao.Db.Table(commonConstants.TableTestDetails).
+		Distinct("task_id", "department").
+		Where("task_id IN ?", taskIds).
+		Where("status IN ?", commonConstants.TASK_ASSIGNMENT_PENDING_TEST_STATUSES).
+		Where("department IS NOT NULL AND department <> ''").
+		Where("deleted_at IS NULL").
+		Scan(&taskDepartments).Error; err != nil {
+		return taskDepartments, commonUtils.HandleORMError(err)
+	}
+
+	return taskDepartments, nil
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) GetPathologistProfiles() (
+	[]commonModels.PathologistProfile, *commonStructures.CommonError) {
+
+	profiles := []commonModels.PathologistProfile{}
+	if err := taskAssignmentDao.Db.Order("pathologist_id").Find(&profiles).Error; err != nil {
+		return profiles, commonUtils.HandleORMError(err)
+	}
+
+	return profiles, nil
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) GetPathologistProfileByPathologistId(pathologistId uint) (
+	commonModels.PathologistProfile, *commonStructures.CommonError) {
+
+	profile := commonModels.PathologistProfile{}
+	if err := taskAssignmentDao.Db.Where("pathologist_id = ?", pathologistId).First(&profile).Error; err != nil {
+		return profile, commonUtils.HandleORMError(err)
+	}
+
+	return profile, nil
+}
+
+// GetOpenTaskCounts returns the number of tasks that are assigned to each pathologist and not completed yet.
+func (taskAssignmentDao *TaskAssignmentDao) GetOpenTaskCounts() (
+	[]structures.PathologistLoadDbStruct, *commonStructures.CommonError) {
+
+	pathologistLoads := []structures.PathologistLoadDbStruct{}
+	if err := taskAssignmentDao.Db.Table(commonConstants.TableTaskAssignments).
+		Select("task_assignments.pathologist_id, COUNT(*) AS open_tasks").
+		Joins("INNER JOIN tasks ON tasks.id = task_assignments.task_id AND tasks.deleted_at IS NULL").
+		Where("task_assignments.is_active AND task_assignments.deleted_at IS NULL").
+		Where("tasks.status <> ?", commonConstants.TASK_STATUS_COMPLETED).
+		Group("task_assignments.pathologist_id").
+		Scan(&pathologistLoads).Error; err != nil {
+		return pathologistLoads, commonUtils.HandleORMError(err)
+	}
+
+	return pathologistLoads, nil
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) GetActiveTaskAssignmentByTaskId(taskId uint) (
+	commonModels.TaskAssignment, *commonStructures.CommonError) {
+
+	taskAssignment := commonModels.TaskAssignment{}
+	if err := taskAssignmentDao.Db.Where("task_id = ? AND is_active = ?", taskId, true).
+		First(&taskAssignment).Error; err != nil {
+		return taskAssignment, commonUtils.HandleORMError(err)
+	}
+
+	return taskAssignment, nil
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) GetActiveTaskAssignmentsByTaskIds(taskIds []uint) (
+	[]commonModels.TaskAssignment, *commonStructures.CommonError) {
+
+	taskAssignments := []commonModels.TaskAssignment{}
+	if err := taskAssignmentDao.Db.Where("task_id IN ? AND is_active = ?", taskIds, true).
+		Find(&taskAssignments).Error; err != nil {
+		return taskAssignments, commonUtils.HandleORMError(err)
+	}
+
+	return taskAssignments, nil
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) GetWorklistByPathologistId(pathologistId uint) (
+	[]structures.WorklistTaskDbStruct, *commonStructures.CommonError) {
+
+	worklist := []structures.WorklistTaskDbStruct{}
+	if err := taskAssignmentDao.Db.Table(commonConstants.TableTaskAssignments).
+		Select("tasks.id AS task_id, tasks.oms_order_id, tasks.status, tasks.doctor_tat, "+
+			"COALESCE(task_metadata.is_critical, FALSE) AS is_critical, task_assignments.id AS assignment_id, "+
+			"task_assignments.assignment_type, task_assignments.created_at AS assigned_at").
+		Joins("INNER JOIN tasks ON tasks.id = task_assignments.task_id AND tasks.deleted_at IS NULL").
+		Joins("LEFT JOIN task_metadata ON task_metadata.task_id = tasks.id AND task_metadata.deleted_at IS NULL").
+		Where("task_assignments.pathologist_id = ?", pathologistId).
+		Where("task_assignments.is_active AND task_assignments.deleted_at IS NULL").
+		Where("tasks.status <> ?", commonConstants.TASK_STATUS_COMPLETED).
+		Order("is_critical DESC, tasks.doctor_tat ASC NULLS LAST, tasks.id").
+		Scan(&worklist).Error; err != nil {
+		return worklist, commonUtils.HandleORMError(err)
+	}
+
+	return worklist, nil
+}
+
+// ReplaceTaskAssignment deactivates the current assignment of the task, if any, and creates the new one.
+func (taskAssignmentDao *TaskAssignmentDao) ReplaceTaskAssignment(taskAssignment commonModels.TaskAssignment) (
+	commonModels.TaskAssignment, *commonStructures.CommonError) {
+
+	err := taskAssignmentDao.Db.Transaction(func(tx *gorm.DB) error {
+		currentTime := time.Now()
+		if err := tx.Model(&commonModels.TaskAssignment{}).
+			Where("task_id = ? AND is_active = ?", taskAssignment.TaskId, true).
+			Updates(map[string]interface{}{
+				"is_active":  false,
+				"updated_by": taskAssignment.CreatedBy,
+				"updated_at": &currentTime,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&taskAssignment).Error
+	})
+	if err != nil {
+		return taskAssignment, commonUtils.HandleORMError(err)
+	}
+
+	return taskAssignment, nil
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) DeactivateTaskAssignments(taskIds []uint,
+	userId uint) *commonStructures.CommonError {
+
+	currentTime := time.Now()
+	if err := taskAssignmentDao.Db.Model(&commonModels.TaskAssignment{}).
+		Where("task_id IN ? AND is_active = ?", taskIds, true).
+		Updates(map[string]interface{}{
+			"is_active":  false,
+			"updated_by": userId,
+			"updated_at": &currentTime,
+		}).Error; err != nil {
+		return commonUtils.HandleORMError(err)
+	}
+
+	return nil
+}
+
+func (taskAssignmentDao *TaskAssignmentDao) SavePathologistProfile(profile commonModels.PathologistProfile) (
+	commonModels.PathologistProfile, *commonStructures.CommonError) {
+
+	if err := taskAssignmentDao.Db.Save(&profile).Error; err != nil {
+		return profile, commonUtils.HandleORMError(err)
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type TaskAssignmentDao struct {
+	Db *gorm.DB
+}
+
+func InitializeTaskAssignmentDao() DataLayer {
+	return &TaskAssignmentDao{
+		Db: psql.GetDbInstance(),
+	}
+}
@@ -0,0 +1,111 @@
+package mapper
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/Orange-Health/citadel/apps/task_assignment/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func MapTaskAssignment(taskAssignment commonModels.TaskAssignment) structures.TaskAssignment {
+	explanation := structures.AssignmentExplanation{}
+	if taskAssignment.Explanation != "" {
+		_ = json.Unmarshal([]byte(taskAssignment.Explanation), &explanation)
+	}
+
+	return structures.TaskAssignment{
+		Id:             taskAssignment.Id,
+		TaskId:         taskAssignment.TaskId,
+		PathologistId:  taskAssignment.PathologistId,
+		AssignmentType: taskAssignment.AssignmentType,
+		IsActive:       taskAssignment.IsActive,
+		Score:          taskAssignment.Score,
+		Explanation:    explanation,
+		Reason:         taskAssignment.Reason,
+		AssignedBy:     taskAssignment.CreatedBy,
+		CreatedAt:      taskAssignment.CreatedAt,
+	}
+}
+
+func MapNewTaskAssignment(taskId, pathologistId uint, assignmentType string, score float64,
+	explanation structures.AssignmentExplanation, reason string, userId uint) commonModels.TaskAssignment {
+
+	explanationBytes, _ := json.Marshal(explanation)
+	taskAssignment := commonModels.TaskAssignment{
+		TaskId:         taskId,
+		PathologistId:  pathologistId,
+		AssignmentType: assignmentType,
+		IsActive:       true,
+		Score:          score,
+		Explanation:    string(explanationBytes),
+		Reason:         reason,
+	}
+	taskAssignment.CreatedBy = userId
+	taskAssignment.UpdatedBy = userId
+	return taskAssignment
+}
+
+func MapWorklist(worklistDbStructs []structures.WorklistTaskDbStruct) []structures.WorklistTask {
+	worklist := []structures.WorklistTask{}
+	for _, worklistDbStruct := range worklistDbStructs {
+		worklist = append(worklist, structures.WorklistTask{
+			TaskId:         worklistDbStruct.TaskId,
+			OmsOrderId:     worklistDbStruct.OmsOrderId,
+			Status:         worklistDbStruct.Status,
+			DoctorTat:      worklistDbStruct.DoctorTat,
+			IsCritical:     worklistDbStruct.IsCritical,
+			AssignmentId:   worklistDbStruct.AssignmentId,
+			AssignmentType: worklistDbStruct.AssignmentType,
+			AssignedAt:     worklistDbStruct.AssignedAt,
+		})
+	}
+	return worklist
+}
+
+func MapDepartments(departments string) []string {
+	departmentsList := []string{}
+	for _, department := range strings.Split(departments, commonConstants.TASK_ASSIGNMENT_DEPARTMENTS_SEPARATOR) {
+		if department = strings.TrimSpace(department); department != "" {
+			departmentsList = append(departmentsList, department)
+		}
+	}
+	return departmentsList
+}
+
+func MapPathologistProfile(profile commonModels.PathologistProfile, openTasks uint,
+	isOnShift bool) structures.PathologistProfile {
+	return structures.PathologistProfile{
+		PathologistId: profile.PathologistId,
+		Departments:   MapDepartments(profile.Departments),
+		MaxOpenTasks:  profile.MaxOpenTasks,
+		IsOnShift:     isOnShift,
+		IsLead:        profile.IsLead,
+		OpenTasks:     openTasks,
+	}
+}
+
+func MapUpsertPathologistProfileRequest(profile commonModels.PathologistProfile, pathologistId uint,
+	upsertRequest structures.UpsertPathologistProfileRequest, userId uint) commonModels.PathologistProfile {
+
+	departments := []string{}
+	for _, department := range upsertRequest.Departments {
+		if department = strings.TrimSpace(department); department != "" {
+			departments = append(departments, department)
+		}
+	}
+
+	if profile.Id == 0 {
+		profile.CreatedBy = userId
+	}
+	profile.PathologistId = pathologistId
+	profile.Departments = strings.Join(departments, commonConstants.TASK_ASSIGNMENT_DEPARTMENTS_SEPARATOR)
+	profile.MaxOpenTasks = upsertRequest.MaxOpenTasks
+	if profile.MaxOpenTasks == 0 {
+		profile.MaxOpenTasks = commonConstants.TaskAssignmentDefaultMaxOpenTasks
+	}
+	profile.IsLead = upsertRequest.IsLead
+	profile.UpdatedBy = userId
+	return profile
+}
@@ -0,0 +1,17 @@
+package taskAssignment
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/task_assignment/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	taskAssignmentController := controller.InitTaskAssignmentController()
+
+	router.GET("/tasks/:taskId", taskAssignmentController.GetTaskAssignment)
+	router.POST("/tasks/:taskId/override", taskAssignmentController.OverrideTaskAssignment)
+	router.GET("/worklist", taskAssignmentController.GetPathologistWorklist)
+	router.GET("/profiles", taskAssignmentController.GetPathologistProfiles)
+	router.PUT("/profiles/:pathologistId", taskAssignmentController.UpsertPathologistProfile)
+}
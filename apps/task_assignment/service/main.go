@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/cache"
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	rosterService "github.com/Orange-Health/citadel/apps/roster/service"
+	"github.com/Orange-Health/citadel/apps/task_assignment/dao"
+	taskEventsService "github.com/Orange-Health/citadel/apps/task_events/service"
+	userService "github.com/Orange-Health/citadel/apps/users/service"
+)
+
+type TaskAssignmentService struct {
+	TaskAssignmentDao dao.DataLayer
+	Cache             cache.CacheLayer
+	Sentry            sentry.SentryLayer
+	UserService       userService.UserServiceInterface
+	RosterService     rosterService.RosterServiceInterface
+	TaskEventsService taskEventsService.TaskEventsServiceInterface
+}
+
+func InitializeTaskAssignmentService() TaskAssignmentServiceInterface {
+	return &TaskAssignmentService{
+		TaskAssignmentDao: dao.InitializeTaskAssignmentDao(),
+		Cache:             cache.InitializeCache(),
+		Sentry:            sentry.InitializeSentry(),
+		UserService:       userService.InitializeUserService(),
+		RosterService:     rosterService.InitializeRosterService(),
+		TaskEventsService: taskEventsService.InitializeTaskEventsService(),
+	}
+}
@@ -0,0 +1,601 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/task_assignment/mapper"
+	"github.com/Orange-Health/citadel/apps/task_assignment/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+type TaskAssignmentServiceInterface interface {
+	GetTaskAssignment(taskId uint) (structures.TaskAssignment, *commonStructures.CommonError)
+	GetPathologistWorklist(pathologistId uint) ([]structures.WorklistTask, *commonStructures.CommonError)
+	GetPathologistProfiles() ([]structures.PathologistProfile, *commonStructures.CommonError)
+	UpsertPathologistProfile(pathologistId uint, upsertRequest structures.UpsertPathologistProfileRequest,
+		userId uint) (structures.PathologistProfile, *commonStructures.CommonError)
+	OverrideTaskAssignment(ctx context.Context, taskId uint,
+		overrideRequest structures.OverrideTaskAssignmentRequest, userId uint) (
+		structures.TaskAssignment, *commonStructures.CommonError)
+	AutoAssignPendingTasks(ctx context.Context)
+	ReassignStaleTasks(ctx context.Context, stalePathologistIdByTaskId map[uint]uint)
+}
+
+// assignmentPlan is the pathologist picked for a task along with why they were picked.
+type assignmentPlan struct {
+	pathologistId uint
+	score         float64
+	explanation   structures.AssignmentExplanation
+}
+
+func (taskAssignmentService *TaskAssignmentService) GetTaskAssignment(taskId uint) (
+	structures.TaskAssignment, *commonStructures.CommonError) {
+
+	taskAssignment, cErr := taskAssignmentService.TaskAssignmentDao.GetActiveTaskAssignmentByTaskId(taskId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_TASK_ASSIGNMENT_NOT_FOUND
+		}
+		return structures.TaskAssignment{}, cErr
+	}
+
+	return mapper.MapTaskAssignment(taskAssignment), nil
+}
+
+func (taskAssignmentService *TaskAssignmentService) GetPathologistWorklist(pathologistId uint) (
+	[]structures.WorklistTask, *commonStructures.CommonError) {
+
+	worklist, cErr := taskAssignmentService.TaskAssignmentDao.GetWorklistByPathologistId(pathologistId)
+	if cErr != nil {
+		return []structures.WorklistTask{}, cErr
+	}
+
+	return mapper.MapWorklist(worklist), nil
+}
+
+func (taskAssignmentService *TaskAssignmentService) GetPathologistProfiles() (
+	[]structures.PathologistProfile, *commonStructures.CommonError) {
+
+	profiles, cErr := taskAssignmentService.TaskAssignmentDao.GetPathologistProfiles()
+	if cErr != nil {
+		return []structures.PathologistProfile{}, cErr
+	}
+
+	openTasksByPathologistId, cErr := taskAssignmentService.getOpenTasksByPathologistId()
+	if cErr != nil {
+		return []structures.PathologistProfile{}, cErr
+	}
+
+	onDutyUserIds, cErr := taskAssignmentService.RosterService.GetOnDutyUserIds(time.Now())
+	if cErr != nil {
+		return []structures.PathologistProfile{}, cErr
+	}
+
+	profilesResponse := []structures.PathologistProfile{}
+	for _, profile := range profiles {
+		profilesResponse = append(profilesResponse, mapper.MapPathologistProfile(profile,
+			openTasksByPathologistId[profile.PathologistId], onDutyUserIds[profile.PathologistId]))
+	}
+
+	return profilesResponse, nil
+}
+
+// UpsertPathologistProfile sets the departments and capacity of a pathologist, their shifts come from the roster.
+// Only lead pathologists can manage the profiles.
+func (taskAssignmentService *TaskAssignmentService) UpsertPathologistProfile(pathologistId uint,
+	upsertRequest structures.UpsertPathologistProfileRequest, userId uint) (
+	structures.PathologistProfile, *commonStructures.CommonError) {
+
+	if !taskAssignmentService.isLeadPathologist(userId) {
+		return structures.PathologistProfile{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_PATHOLOGIST_PROFILE_UPDATE_NOT_ALLOWED,
+			StatusCode: http.StatusForbidden,
+		}
+	}
+
+	if cErr := taskAssignmentService.validatePathologist(pathologistId); cErr != nil {
+		return structures.PathologistProfile{}, cErr
+	}
+
+	profile, cErr := taskAssignmentService.TaskAssignmentDao.GetPathologistProfileByPathologistId(pathologistId)
+	if cErr != nil && cErr.StatusCode != http.StatusNotFound {
+		return structures.PathologistProfile{}, cErr
+	}
+
+	profile = mapper.MapUpsertPathologistProfileRequest(profile, pathologistId, upsertRequest, userId)
+	profile, cErr = taskAssignmentService.TaskAssignmentDao.SavePathologistProfile(profile)
+	if cErr != nil {
+		return structures.PathologistProfile{}, cErr
+	}
+
+	openTasksByPathologistId, cErr := taskAssignmentService.getOpenTasksByPathologistId()
+	if cErr != nil {
+		return structures.PathologistProfile{}, cErr
+	}
+
+	onDutyUserIds, cErr := taskAssignmentService.RosterService.GetOnDutyUserIds(time.Now())
+	if cErr != nil {
+		return structures.PathologistProfile{}, cErr
+	}
+
+	return mapper.MapPathologistProfile(profile, openTasksByPathologistId[pathologistId],
+		onDutyUserIds[pathologistId]), nil
+}
+
+// OverrideTaskAssignment lets a lead pathologist hand a task to a pathologist of their choice, regardless of
+// the competence and load of the pathologist.
+func (taskAssignmentService *TaskAssignmentService) OverrideTaskAssignment(ctx context.Context, taskId uint,
+	overrideRequest structures.OverrideTaskAssignmentRequest, userId uint) (
+	structures.TaskAssignment, *commonStructures.CommonError) {
+
+	if !taskAssignmentService.isLeadPathologist(userId) {
+		return structures.TaskAssignment{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_TASK_ASSIGNMENT_OVERRIDE_NOT_ALLOWED,
+			StatusCode: http.StatusForbidden,
+		}
+	}
+
+	if cErr := taskAssignmentService.validatePathologist(overrideRequest.PathologistId); cErr != nil {
+		return structures.TaskAssignment{}, cErr
+	}
+
+	task, cErr := taskAssignmentService.TaskAssignmentDao.GetTaskById(taskId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_TASK_NOT_FOUND
+		}
+		return structures.TaskAssignment{}, cErr
+	}
+	if task.Status == commonConstants.TASK_STATUS_COMPLETED {
+		return structures.TaskAssignment{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_TASK_NOT_OPEN_FOR_ASSIGNMENT,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	currentAssignment, cErr := taskAssignmentService.TaskAssignmentDao.GetActiveTaskAssignmentByTaskId(taskId)
+	if cErr != nil && cErr.StatusCode != http.StatusNotFound {
+		return structures.TaskAssignment{}, cErr
+	}
+	if currentAssignment.PathologistId == overrideRequest.PathologistId {
+		return structures.TaskAssignment{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_TASK_ALREADY_ASSIGNED_TO_PATHOLOGIST,
+			StatusCode: http.StatusConflict,
+		}
+	}
+
+	explanation, score, cErr := taskAssignmentService.getOverrideExplanation(task,
+		overrideRequest.PathologistId)
+	if cErr != nil {
+		return structures.TaskAssignment{}, cErr
+	}
+	explanation.PreviousPathologistId = currentAssignment.PathologistId
+
+	taskAssignment, cErr := taskAssignmentService.TaskAssignmentDao.ReplaceTaskAssignment(
+		mapper.MapNewTaskAssignment(taskId, overrideRequest.PathologistId,
+			commonConstants.TASK_ASSIGNMENT_TYPE_OVERRIDE, score, explanation, overrideRequest.Reason, userId))
+	if cErr != nil {
+		return structures.TaskAssignment{}, cErr
+	}
+
+	commonUtils.AddLog(ctx, commonConstants.INFO_LEVEL, commonUtils.GetCurrentFunctionName(), map[string]interface{}{
+		"task_id":                 taskId,
+		"pathologist_id":          overrideRequest.PathologistId,
+		"previous_pathologist_id": currentAssignment.PathologistId,
+		"overridden_by":           userId,
+	}, nil)
+	taskAssignmentService.TaskEventsService.PublishTaskEvent(ctx, commonConstants.TaskEventAssigned, taskId,
+		overrideRequest.PathologistId)
+
+	return mapper.MapTaskAssignment(taskAssignment), nil
+}
+
+// AutoAssignPendingTasks distributes the tasks nobody is assigned to across the pathologists on duty. The
+// critical tasks and the ones closest to their doctor TAT are assigned first.
+func (taskAssignmentService *TaskAssignmentService) AutoAssignPendingTasks(ctx context.Context) {
+	tasks, cErr := taskAssignmentService.TaskAssignmentDao.GetUnassignedTasks(
+		commonConstants.TaskAssignmentBatchSize)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_ASSIGNING_TASKS, nil,
+			errors.New(cErr.Message))
+		return
+	}
+
+	if len(tasks) == 0 {
+		return
+	}
+
+	taskAssignmentService.assignTasks(ctx, tasks, map[uint]uint{}, commonConstants.TASK_ASSIGNMENT_TYPE_AUTO, "")
+}
+
+// ReassignStaleTasks hands the tasks that went stale with a pathologist to another pathologist. If nobody else
+// can take a task, its assignment is dropped so that it is picked up in a later auto assignment run.
+func (taskAssignmentService *TaskAssignmentService) ReassignStaleTasks(ctx context.Context,
+	stalePathologistIdByTaskId map[uint]uint) {
+
+	if len(stalePathologistIdByTaskId) == 0 {
+		return
+	}
+
+	taskIds := []uint{}
+	for taskId := range stalePathologistIdByTaskId {
+		taskIds = append(taskIds, taskId)
+	}
+
+	tasks, cErr := taskAssignmentService.TaskAssignmentDao.GetAssignableTasksByIds(taskIds)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_ASSIGNING_TASKS,
+			map[string]interface{}{
+				"task_ids": taskIds,
+			}, errors.New(cErr.Message))
+		return
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return isMoreUrgent(tasks[i], tasks[j])
+	})
+
+	assignedTaskIds := taskAssignmentService.assignTasks(ctx, tasks, stalePathologistIdByTaskId,
+		commonConstants.TASK_ASSIGNMENT_TYPE_REASSIGN, commonConstants.TASK_ASSIGNMENT_REASON_STALE)
+
+	unassignedTaskIds := []uint{}
+	for _, taskId := range taskIds {
+		if !assignedTaskIds[taskId] {
+			unassignedTaskIds = append(unassignedTaskIds, taskId)
+		}
+	}
+	if len(unassignedTaskIds) == 0 {
+		return
+	}
+
+	if cErr := taskAssignmentService.TaskAssignmentDao.DeactivateTaskAssignments(unassignedTaskIds,
+		commonConstants.CitadelSystemId); cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_ASSIGNING_TASKS,
+			map[string]interface{}{
+				"task_ids": unassignedTaskIds,
+			}, errors.New(cErr.Message))
+	}
+}
+
+// assignTasks assigns the tasks, in the order they are passed in, to the best scoring pathologist that is
+// not excluded for the task. It returns the tasks that got assigned.
+func (taskAssignmentService *TaskAssignmentService) assignTasks(ctx context.Context,
+	tasks []structures.AssignableTaskDbStruct, excludedPathologistIdByTaskId map[uint]uint, assignmentType,
+	reason string) map[uint]bool {
+
+	assignedTaskIds := map[uint]bool{}
+	profiles, cErr := taskAssignmentService.getOnDutyPathologistProfiles()
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_ASSIGNING_TASKS, nil,
+			errors.New(cErr.Message))
+		return assignedTaskIds
+	}
+
+	if len(profiles) == 0 {
+		return assignedTaskIds
+	}
+
+	openTasksByPathologistId, cErr := taskAssignmentService.getOpenTasksByPathologistId()
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_ASSIGNING_TASKS, nil,
+			errors.New(cErr.Message))
+		return assignedTaskIds
+	}
+
+	departmentsByTaskId, cErr := taskAssignmentService.getDepartmentsByTaskId(tasks)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_ASSIGNING_TASKS, nil,
+			errors.New(cErr.Message))
+		return assignedTaskIds
+	}
+
+	currentTime := time.Now()
+	for _, task := range tasks {
+		plan, ok := planTaskAssignment(task, departmentsByTaskId[task.TaskId], profiles, openTasksByPathologistId,
+			excludedPathologistIdByTaskId[task.TaskId], currentTime)
+		if !ok {
+			continue
+		}
+		plan.explanation.PreviousPathologistId = excludedPathologistIdByTaskId[task.TaskId]
+
+		_, cErr := taskAssignmentService.TaskAssignmentDao.ReplaceTaskAssignment(
+			mapper.MapNewTaskAssignment(task.TaskId, plan.pathologistId, assignmentType, plan.score,
+				plan.explanation, reason, commonConstants.CitadelSystemId))
+		if cErr != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_ASSIGNING_TASKS,
+				map[string]interface{}{
+					"task_id":        task.TaskId,
+					"pathologist_id": plan.pathologistId,
+				}, errors.New(cErr.Message))
+			continue
+		}
+
+		openTasksByPathologistId[plan.pathologistId]++
+		assignedTaskIds[task.TaskId] = true
+		taskAssignmentService.TaskEventsService.PublishTaskEvent(ctx, commonConstants.TaskEventAssigned,
+			task.TaskId, plan.pathologistId)
+	}
+
+	return assignedTaskIds
+}
+
+// getOnDutyPathologistProfiles gets the profiles of the pathologists on duty on the roster right now.
+func (taskAssignmentService *TaskAssignmentService) getOnDutyPathologistProfiles() (
+	[]commonModels.PathologistProfile, *commonStructures.CommonError) {
+
+	onDutyProfiles := []commonModels.PathologistProfile{}
+	profiles, cErr := taskAssignmentService.TaskAssignmentDao.GetPathologistProfiles()
+	if cErr != nil {
+		return onDutyProfiles, cErr
+	}
+
+	onDutyUserIds, cErr := taskAssignmentService.RosterService.GetOnDutyUserIds(time.Now())
+	if cErr != nil {
+		return onDutyProfiles, cErr
+	}
+
+	for _, profile := range profiles {
+		if onDutyUserIds[profile.PathologistId] {
+			onDutyProfiles = append(onDutyProfiles, profile)
+		}
+	}
+
+	return onDutyProfiles, nil
+}
+
+func (taskAssignmentService *TaskAssignmentService) getOpenTasksByPathologistId() (
+	map[uint]uint, *commonStructures.CommonError) {
+
+	pathologistLoads, cErr := taskAssignmentService.TaskAssignmentDao.GetOpenTaskCounts()
+	if cErr != nil {
+		return map[uint]uint{}, cErr
+	}
+
+	openTasksByPathologistId := map[uint]uint{}
+	for _, pathologistLoad := range pathologistLoads {
+		openTasksByPathologistId[pathologistLoad.PathologistId] = pathologistLoad.OpenTasks
+	}
+
+	return openTasksByPathologistId, nil
+}
+
+func (taskAssignmentService *TaskAssignmentService) getDepartmentsByTaskId(
+	tasks []structures.AssignableTaskDbStruct) (map[uint][]string, *commonStructures.CommonError) {
+
+	departmentsByTaskId := map[uint][]string{}
+	if len(tasks) == 0 {
+		return departmentsByTaskId, nil
+	}
+
+	taskIds := []uint{}
+	for _, task := range tasks {
+		taskIds = append(taskIds, task.TaskId)
+	}
+
+	taskDepartments, cErr := taskAssignmentService.TaskAssignmentDao.GetTaskDepartments(taskIds)
+	if cErr != nil {
+		return departmentsByTaskId, cErr
+	}
+
+	for _, taskDepartment := range taskDepartments {
+		departmentsByTaskId[taskDepartment.TaskId] = append(departmentsByTaskId[taskDepartment.TaskId],
+			taskDepartment.Department)
+	}
+
+	return departmentsByTaskId, nil
+}
+
+func (taskAssignmentService *TaskAssignmentService) getOverrideExplanation(task commonModels.Task,
+	pathologistId uint) (structures.AssignmentExplanation, float64, *commonStructures.CommonError) {
+
+	explanation := structures.AssignmentExplanation{
+		DoctorTat:  task.DoctorTat,
+		Candidates: []structures.AssignmentCandidate{},
+	}
+
+	tasks, cErr := taskAssignmentService.TaskAssignmentDao.GetAssignableTasksByIds([]uint{task.Id})
+	if cErr != nil {
+		return explanation, 0, cErr
+	}
+	if len(tasks) > 0 {
+		explanation.IsCritical = tasks[0].IsCritical
+		explanation.IsUrgent = isUrgentTask(tasks[0], time.Now())
+	}
+
+	departmentsByTaskId, cErr := taskAssignmentService.getDepartmentsByTaskId(tasks)
+	if cErr != nil {
+		return explanation, 0, cErr
+	}
+	explanation.Departments = departmentsByTaskId[task.Id]
+
+	profile, cErr := taskAssignmentService.TaskAssignmentDao.GetPathologistProfileByPathologistId(pathologistId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			return explanation, 0, nil
+		}
+		return explanation, 0, cErr
+	}
+
+	openTasksByPathologistId, cErr := taskAssignmentService.getOpenTasksByPathologistId()
+	if cErr != nil {
+		return explanation, 0, cErr
+	}
+
+	candidate := scoreCandidate(profile, openTasksByPathologistId[pathologistId], explanation.Departments,
+		explanation.IsUrgent)
+	explanation.Candidates = append(explanation.Candidates, candidate)
+
+	return explanation, candidate.Score, nil
+}
+
+func (taskAssignmentService *TaskAssignmentService) validatePathologist(
+	pathologistId uint) *commonStructures.CommonError {
+
+	pathologist, cErr := taskAssignmentService.UserService.GetUserModel(pathologistId)
+	if cErr != nil {
+		if cErr.StatusCode == http.StatusNotFound {
+			cErr.Message = commonConstants.ERROR_INVALID_PATHOLOGIST_ID
+			cErr.StatusCode = http.StatusBadRequest
+		}
+		return cErr
+	}
+
+	if !commonUtils.SliceContainsString(
+		[]string{commonConstants.USER_TYPE_PATHOLOGIST, commonConstants.USER_TYPE_SUPER_ADMIN},
+		pathologist.UserType) {
+		return &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_USER_IS_NOT_A_PATHOLOGIST,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+func (taskAssignmentService *TaskAssignmentService) isLeadPathologist(userId uint) bool {
+	user, cErr := taskAssignmentService.UserService.GetUserModel(userId)
+	if cErr != nil {
+		return false
+	}
+	if user.UserType == commonConstants.USER_TYPE_SUPER_ADMIN {
+		return true
+	}
+
+	profile, cErr := taskAssignmentService.TaskAssignmentDao.GetPathologistProfileByPathologistId(userId)
+	if cErr != nil {
+		return false
+	}
+
+	return profile.IsLead
+}
+
+// planTaskAssignment scores every pathologist for the task and picks the best eligible one. Ties go to the
+// pathologist with fewer open tasks.
+func planTaskAssignment(task structures.AssignableTaskDbStruct, departments []string,
+	profiles []commonModels.PathologistProfile, openTasksByPathologistId map[uint]uint,
+	excludedPathologistId uint, currentTime time.Time) (assignmentPlan, bool) {
+
+	explanation := structures.AssignmentExplanation{
+		Departments: departments,
+		IsCritical:  task.IsCritical,
+		DoctorTat:   task.DoctorTat,
+		IsUrgent:    isUrgentTask(task, currentTime),
+		Candidates:  []structures.AssignmentCandidate{},
+	}
+
+	for _, profile := range profiles {
+		candidate := scoreCandidate(profile, openTasksByPathologistId[profile.PathologistId], departments,
+			explanation.IsUrgent)
+		if candidate.RejectionReason == "" && profile.PathologistId == excludedPathologistId {
+			candidate.RejectionReason = commonConstants.TASK_ASSIGNMENT_REJECTION_STALE
+		}
+		explanation.Candidates = append(explanation.Candidates, candidate)
+	}
+
+	sort.SliceStable(explanation.Candidates, func(i, j int) bool {
+		first, second := explanation.Candidates[i], explanation.Candidates[j]
+		if (first.RejectionReason == "") != (second.RejectionReason == "") {
+			return first.RejectionReason == ""
+		}
+		if first.Score != second.Score {
+			return first.Score > second.Score
+		}
+		return first.OpenTasks < second.OpenTasks
+	})
+
+	if len(explanation.Candidates) == 0 || explanation.Candidates[0].RejectionReason != "" {
+		return assignmentPlan{}, false
+	}
+
+	return assignmentPlan{
+		pathologistId: explanation.Candidates[0].PathologistId,
+		score:         explanation.Candidates[0].Score,
+		explanation:   explanation,
+	}, true
+}
+
+// scoreCandidate weighs the share of the departments of the task the pathologist is competent in against the
+// share of their capacity that is still free. Urgent tasks also weigh how few open tasks the pathologist has
+// and may take them past their capacity.
+func scoreCandidate(profile commonModels.PathologistProfile, openTasks uint, departments []string,
+	isUrgent bool) structures.AssignmentCandidate {
+
+	maxOpenTasks := profile.MaxOpenTasks
+	if maxOpenTasks == 0 {
+		maxOpenTasks = commonConstants.TaskAssignmentDefaultMaxOpenTasks
+	}
+	capacity := maxOpenTasks
+	if isUrgent {
+		capacity += commonConstants.TaskAssignmentUrgentExtraOpenTasks
+	}
+
+	candidate := structures.AssignmentCandidate{
+		PathologistId:   profile.PathologistId,
+		CompetenceScore: getCompetenceScore(mapper.MapDepartments(profile.Departments), departments),
+		OpenTasks:       openTasks,
+		MaxOpenTasks:    maxOpenTasks,
+	}
+	if openTasks < maxOpenTasks {
+		candidate.LoadScore = 1 - float64(openTasks)/float64(maxOpenTasks)
+	}
+	if isUrgent {
+		candidate.UrgencyScore = 1 / float64(openTasks+1)
+	}
+	candidate.Score = commonConstants.TaskAssignmentCompetenceWeight*candidate.CompetenceScore +
+		commonConstants.TaskAssignmentLoadWeight*candidate.LoadScore +
+		commonConstants.TaskAssignmentUrgencyWeight*candidate.UrgencyScore
+
+	if candidate.CompetenceScore == 0 {
+		candidate.RejectionReason = commonConstants.TASK_ASSIGNMENT_REJECTION_NO_COMPETENCE
+	} else if openTasks >= capacity {
+		candidate.RejectionReason = commonConstants.TASK_ASSIGNMENT_REJECTION_AT_CAPACITY
+	}
+
+	return candidate
+}
+
+func getCompetenceScore(pathologistDepartments, taskDepartments []string) float64 {
+	if len(taskDepartments) == 0 ||
+		commonUtils.SliceContainsString(pathologistDepartments, commonConstants.TASK_ASSIGNMENT_ALL_DEPARTMENTS) {
+		return 1
+	}
+
+	coveredDepartments := 0
+	for _, taskDepartment := range taskDepartments {
+		for _, pathologistDepartment := range pathologistDepartments {
+			if strings.EqualFold(taskDepartment, pathologistDepartment) {
+				coveredDepartments++
+				break
+			}
+		}
+	}
+
+	return float64(coveredDepartments) / float64(len(taskDepartments))
+}
+
+// isUrgentTask tells if a task is critical or its doctor TAT breaches within the near breach window.
+func isUrgentTask(task structures.AssignableTaskDbStruct, currentTime time.Time) bool {
+	if task.IsCritical {
+		return true
+	}
+	return task.DoctorTat != nil && task.DoctorTat.Before(
+		currentTime.Add(commonConstants.TaskAssignmentNearBreachWindowInMins*time.Minute))
+}
+
+func isMoreUrgent(first, second structures.AssignableTaskDbStruct) bool {
+	if first.IsCritical != second.IsCritical {
+		return first.IsCritical
+	}
+	if first.DoctorTat == nil || second.DoctorTat == nil {
+		return first.DoctorTat != nil
+	}
+	return first.DoctorTat.Before(*second.DoctorTat)
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/apps/task_assignment/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonModels "github.com/Orange-Health/citadel/models"
+)
+
+func TestScoreCandidate(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		profile                 commonModels.PathologistProfile
+		openTasks               uint
+		departments             []string
+		isUrgent                bool
+		expectedCompetenceScore float64
+		expectedLoadScore       float64
+		expectedUrgencyScore    float64
+		expectedScore           float64
+		expectedRejectionReason string
+	}{
+		{
+			name:                    "competent in every department",
+			profile:                 commonModels.PathologistProfile{Departments: "*", MaxOpenTasks: 10},
+			openTasks:               5,
+			departments:             []string{"Biochemistry", "Haematology"},
+			expectedCompetenceScore: 1,
+			expectedLoadScore:       0.5,
+			expectedScore:           0.8,
+		},
+		{
+			name:                    "competent in some departments",
+			profile:                 commonModels.PathologistProfile{Departments: "biochemistry", MaxOpenTasks: 10},
+			departments:             []string{"Biochemistry", "Haematology"},
+			expectedCompetenceScore: 0.5,
+			expectedLoadScore:       1,
+			expectedScore:           0.7,
+		},
+		{
+			name:                    "not competent",
+			profile:                 commonModels.PathologistProfile{Departments: "Microbiology", MaxOpenTasks: 10},
+			departments:             []string{"Biochemistry"},
+			expectedLoadScore:       1,
+			expectedScore:           0.4,
+			expectedRejectionReason: commonConstants.TASK_ASSIGNMENT_REJECTION_NO_COMPETENCE,
+		},
+		{
+			name:                    "at capacity",
+			profile:                 commonModels.PathologistProfile{Departments: "*", MaxOpenTasks: 10},
+			openTasks:               10,
+			departments:             []string{"Biochemistry"},
+			expectedCompetenceScore: 1,
+			expectedScore:           0.6,
+			expectedRejectionReason: commonConstants.TASK_ASSIGNMENT_REJECTION_AT_CAPACITY,
+		},
+		{
+			name:                    "default capacity",
+			profile:                 commonModels.PathologistProfile{Departments: "*"},
+			openTasks:               15,
+			departments:             []string{"Biochemistry"},
+			expectedCompetenceScore: 1,
+			expectedLoadScore:       0.25,
+			expectedScore:           0.7,
+		},
+		{
+			name:                    "urgent task",
+			profile:                 commonModels.PathologistProfile{Departments: "*", MaxOpenTasks: 10},
+			openTasks:               4,
+			departments:             []string{"Biochemistry"},
+			isUrgent:                true,
+			expectedCompetenceScore: 1,
+			expectedLoadScore:       0.6,
+			expectedUrgencyScore:    0.2,
+			expectedScore:           0.94,
+		},
+		{
+			name:                    "urgent task past capacity",
+			profile:                 commonModels.PathologistProfile{Departments: "*", MaxOpenTasks: 10},
+			openTasks:               11,
+			departments:             []string{"Biochemistry"},
+			isUrgent:                true,
+			expectedCompetenceScore: 1,
+			expectedUrgencyScore:    1.0 / 12,
+			expectedScore:           0.6 + 0.5/12,
+		},
+		{
+			name:                    "urgent task past extra capacity",
+			profile:                 commonModels.PathologistProfile{Departments: "*", MaxOpenTasks: 10},
+			openTasks:               13,
+			departments:             []string{"Biochemistry"},
+			isUrgent:                true,
+			expectedCompetenceScore: 1,
+			expectedUrgencyScore:    1.0 / 14,
+			expectedScore:           0.6 + 0.5/14,
+			expectedRejectionReason: commonConstants.TASK_ASSIGNMENT_REJECTION_AT_CAPACITY,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			candidate := scoreCandidate(testCase.profile, testCase.openTasks, testCase.departments,
+				testCase.isUrgent)
+			assert.InDelta(t, testCase.expectedCompetenceScore, candidate.CompetenceScore, 1e-9)
+			assert.InDelta(t, testCase.expectedLoadScore, candidate.LoadScore, 1e-9)
+			assert.InDelta(t, testCase.expectedUrgencyScore, candidate.UrgencyScore, 1e-9)
+			assert.InDelta(t, testCase.expectedScore, candidate.Score, 1e-9)
+			assert.Equal(t, testCase.expectedRejectionReason, candidate.RejectionReason)
+		})
+	}
+}
+
+func TestPlanTaskAssignment(t *testing.T) {
+	currentTime := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	nearBreachTat := currentTime.Add(30 * time.Minute)
+	laterTat := currentTime.Add(6 * time.Hour)
+	profiles := []commonModels.PathologistProfile{
+		{PathologistId: 1, Departments: "Biochemistry", MaxOpenTasks: 10},
+		{PathologistId: 2, Departments: "*", MaxOpenTasks: 10},
+		{PathologistId: 3, Departments: "Haematology", MaxOpenTasks: 10},
+	}
+
+	testCases := []struct {
+		name                     string
+		task                     structures.AssignableTaskDbStruct
+		departments              []string
+		profiles                 []commonModels.PathologistProfile
+		openTasksByPathologistId map[uint]uint
+		excludedPathologistId    uint
+		expectedOk               bool
+		expectedPathologistId    uint
+		expectedIsUrgent         bool
+	}{
+		{
+			name:                     "least loaded competent pathologist",
+			task:                     structures.AssignableTaskDbStruct{TaskId: 1, DoctorTat: &laterTat},
+			departments:              []string{"Biochemistry"},
+			profiles:                 profiles,
+			openTasksByPathologistId: map[uint]uint{1: 2, 2: 8},
+			expectedOk:               true,
+			expectedPathologistId:    1,
+		},
+		{
+			name:                     "stale pathologist excluded",
+			task:                     structures.AssignableTaskDbStruct{TaskId: 1, DoctorTat: &laterTat},
+			departments:              []string{"Biochemistry"},
+			profiles:                 profiles,
+			openTasksByPathologistId: map[uint]uint{1: 2, 2: 8},
+			excludedPathologistId:    1,
+			expectedOk:               true,
+			expectedPathologistId:    2,
+		},
+		{
+			name:                     "nobody competent",
+			task:                     structures.AssignableTaskDbStruct{TaskId: 1},
+			departments:              []string{"Microbiology"},
+			profiles:                 []commonModels.PathologistProfile{profiles[0], profiles[2]},
+			openTasksByPathologistId: map[uint]uint{},
+		},
+		{
+			name:                     "everybody at capacity",
+			task:                     structures.AssignableTaskDbStruct{TaskId: 1, DoctorTat: &laterTat},
+			departments:              []string{"Biochemistry"},
+			profiles:                 profiles,
+			openTasksByPathologistId: map[uint]uint{1: 10, 2: 10},
+		},
+		{
+			name:                     "near breach task past capacity",
+			task:                     structures.AssignableTaskDbStruct{TaskId: 1, DoctorTat: &nearBreachTat},
+			departments:              []string{"Biochemistry"},
+			profiles:                 profiles,
+			openTasksByPathologistId: map[uint]uint{1: 11, 2: 10},
+			expectedOk:               true,
+			expectedPathologistId:    2,
+			expectedIsUrgent:         true,
+		},
+		{
+			name: "critical task goes to the least loaded pathologist",
+			task: structures.AssignableTaskDbStruct{TaskId: 1, DoctorTat: &laterTat,
+				IsCritical: true},
+			departments: []string{"Biochemistry"},
+			profiles: []commonModels.PathologistProfile{
+				{PathologistId: 1, Departments: "Biochemistry", MaxOpenTasks: 100},
+				{PathologistId: 2, Departments: "*", MaxOpenTasks: 2},
+			},
+			openTasksByPathologistId: map[uint]uint{1: 10, 2: 1},
+			expectedOk:               true,
+			expectedPathologistId:    2,
+			expectedIsUrgent:         true,
+		},
+		{
+			name:        "same task when not critical goes by free capacity",
+			task:        structures.AssignableTaskDbStruct{TaskId: 1, DoctorTat: &laterTat},
+			departments: []string{"Biochemistry"},
+			profiles: []commonModels.PathologistProfile{
+				{PathologistId: 1, Departments: "Biochemistry", MaxOpenTasks: 100},
+				{PathologistId: 2, Departments: "*", MaxOpenTasks: 2},
+			},
+			openTasksByPathologistId: map[uint]uint{1: 10, 2: 1},
+			expectedOk:               true,
+			expectedPathologistId:    1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			plan, ok := planTaskAssignment(testCase.task, testCase.departments, testCase.profiles,
+				testCase.openTasksByPathologistId, testCase.excludedPathologistId, currentTime)
+			assert.Equal(t, testCase.expectedOk, ok)
+			assert.Equal(t, testCase.expectedPathologistId, plan.pathologistId)
+			if ok {
+				assert.Equal(t, testCase.expectedIsUrgent, plan.explanation.IsUrgent)
+				assert.Len(t, plan.explanation.Candidates, len(testCase.profiles))
+				assert.Equal(t, testCase.expectedPathologistId, plan.explanation.Candidates[0].PathologistId)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package structures
+
+import (
+	"time"
+)
+
+// @swagger:model TaskAssignment
+type TaskAssignment struct {
+	// The task assignment ID.
+	// example: 1
+	Id uint `json:"id"`
+	// example: 1
+	TaskId uint `json:"task_id"`
+	// example: 1
+	PathologistId uint `json:"pathologist_id"`
+	// One of auto, reassign and override.
+	// example: "auto"
+	AssignmentType string `json:"assignment_type"`
+	// example: true
+	IsActive bool `json:"is_active"`
+	// example: 0.85
+	Score float64 `json:"score"`
+	// Why the pathologist was picked over the others.
+	Explanation AssignmentExplanation `json:"explanation"`
+	// example: "Pathologist is on leave"
+	Reason string `json:"reason"`
+	// The user who made the assignment.
+	// example: 1
+	AssignedBy uint `json:"assigned_by"`
+	// example: "2026-10-18T09:00:00Z"
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type AssignmentExplanation struct {
+	// example: ["Biochemistry", "Haematology"]
+	Departments []string `json:"departments"`
+	// example: true
+	IsCritical bool `json:"is_critical"`
+	// example: "2026-10-18T12:00:00Z"
+	DoctorTat *time.Time `json:"doctor_tat"`
+	// Whether the task is critical or about to breach its doctor TAT, which favours the least loaded pathologist.
+	// example: true
+	IsUrgent bool `json:"is_urgent"`
+	// The pathologist the task was taken away from, if any.
+	// example: 2
+	PreviousPathologistId uint `json:"previous_pathologist_id,omitempty"`
+	// Every pathologist on duty on the roster that was considered for the task.
+	Candidates []AssignmentCandidate `json:"candidates"`
+}
+
+type AssignmentCandidate struct {
+	// example: 1
+	PathologistId uint `json:"pathologist_id"`
+	// The share of the departments of the task the pathologist is competent in.
+	// example: 1
+	CompetenceScore float64 `json:"competence_score"`
+	// The share of the capacity of the pathologist that is still free.
+	// example: 0.75
+	LoadScore float64 `json:"load_score"`
+	// How few open tasks the pathologist has, only set for urgent tasks.
+	// example: 0.17
+	UrgencyScore float64 `json:"urgency_score"`
+	// example: 0.9
+	Score float64 `json:"score"`
+	// example: 5
+	OpenTasks uint `json:"open_tasks"`
+	// example: 20
+	MaxOpenTasks uint `json:"max_open_tasks"`
+	// Why the pathologist was not picked, empty for eligible pathologists.
+	// example: "at_capacity"
+	RejectionReason string `json:"rejection_reason,omitempty"`
+}
+
+// @swagger:model WorklistTask
+type WorklistTask struct {
+	// example: 1
+	TaskId uint `json:"task_id"`
+	// example: "ORD123"
+	OmsOrderId string `json:"oms_order_id"`
+	// example: "pending"
+	Status string `json:"status"`
+	// example: "2026-10-18T12:00:00Z"
+	DoctorTat *time.Time `json:"doctor_tat"`
+	// example: false
+	IsCritical bool `json:"is_critical"`
+	// example: 1
+	AssignmentId uint `json:"assignment_id"`
+	// example: "auto"
+	AssignmentType string `json:"assignment_type"`
+	// example: "2026-10-18T09:00:00Z"
+	AssignedAt *time.Time `json:"assigned_at"`
+}
+
+// @swagger:model PathologistProfile
+type PathologistProfile struct {
+	// example: 1
+	PathologistId uint `json:"pathologist_id"`
+	// The departments the pathologist can sign out, * for all of them.
+	// example: ["Biochemistry", "Haematology"]
+	Departments []string `json:"departments"`
+	// example: 20
+	MaxOpenTasks uint `json:"max_open_tasks"`
+	// Whether the pathologist is on duty on the roster right now.
+	// example: true
+	IsOnShift bool `json:"is_on_shift"`
+	// example: false
+	IsLead bool `json:"is_lead"`
+	// The number of open tasks assigned to the pathologist.
+	// example: 5
+	OpenTasks uint `json:"open_tasks"`
+}
+
+type UpsertPathologistProfileRequest struct {
+	Departments  []string `json:"departments" binding:"required"`
+	MaxOpenTasks uint     `json:"max_open_tasks"`
+	IsLead       bool     `json:"is_lead"`
+}
+
+type OverrideTaskAssignmentRequest struct {
+	PathologistId uint   `json:"pathologist_id" binding:"required"`
+	Reason        string `json:"reason" binding:"required"`
+}
+
+type AssignableTaskDbStruct struct {
+	TaskId     uint       `json:"task_id"`
+	DoctorTat  *time.Time `json:"doctor_tat"`
+	IsCritical bool       `json:"is_critical"`
+}
+
+type TaskDepartmentDbStruct struct {
+	TaskId     uint   `json:"task_id"`
+	Department string `json:"department"`
+}
+
+type PathologistLoadDbStruct struct {
+	PathologistId uint `json:"pathologist_id"`
+	OpenTasks     uint `json:"open_tasks"`
+}
+
+type WorklistTaskDbStruct struct {
+	TaskId         uint       `json:"task_id"`
+	OmsOrderId     string     `json:"oms_order_id"`
+	Status         string     `json:"status"`
+	DoctorTat      *time.Time `json:"doctor_tat"`
+	IsCritical     bool       `json:"is_critical"`
+	AssignmentId   uint       `json:"assignment_id"`
+	AssignmentType string     `json:"assignment_type"`
+	AssignedAt     *time.Time `json:"assigned_at"`
+}
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/task_events/service"
+)
+
+type TaskEvents struct {
+	TaskEventsService service.TaskEventsServiceInterface
+}
+
+func InitTaskEventsController() *TaskEvents {
+	return &TaskEvents{
+		TaskEventsService: service.InitializeTaskEventsService(),
+	}
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/task_events/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Stream Task Events
+// @Description	Server-Sent Events stream of task created, claimed, released, completed and critical flagged events of a lab, with a heartbeat event every 25 seconds
+// @Tags			task-events
+// @Produce		text/event-stream
+// @Param			lab_id		query		int								false	"Lab ID, defaults to the current lab of the user"
+// @Param			department	query		string							false	"Comma separated departments, defaults to all"
+// @Success		200			{object}	structures.TaskEvent			"Task Event"
+// @Failure		400,401		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/task-events/stream [get]
+func (taskEventsController *TaskEvents) StreamTaskEvents(c *gin.Context) {
+	if _, cErr := commonUtils.GetUserIdFromContext(c); cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	labId := commonUtils.ConvertStringToUint(c.Query("lab_id"))
+	if labId == 0 {
+		currentLabId, cErr := commonUtils.GetCurrentLabIdFromContext(c)
+		if cErr != nil {
+			commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+			return
+		}
+		labId = currentLabId
+	}
+
+	filter := structures.TaskEventsFilter{LabId: labId}
+	if department := c.Query("department"); department != "" {
+		filter.Departments = strings.Split(department, ",")
+	}
+
+	taskEvents, unsubscribe := taskEventsController.TaskEventsService.SubscribeTaskEvents(filter)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(commonConstants.TaskEventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case taskEvent, ok := <-taskEvents:
+			if !ok {
+				return false
+			}
+			c.SSEvent(taskEvent.EventType, taskEvent)
+			return true
+		case currentTime := <-heartbeat.C:
+			c.SSEvent(commonConstants.TaskEventHeartbeat, currentTime)
+			return true
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package dao
+
+import (
+	"github.com/Orange-Health/citadel/apps/task_events/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+type DataLayer interface {
+	GetTaskEventDetails(taskId uint) (structures.TaskEventDetailsDbStruct, *commonStructures.CommonError)
+	GetDepartmentsByTaskId(taskId uint) ([]string, *commonStructures.CommonError)
+}
+
+func (taskEventsDao *TaskEventsDao) GetTaskEventDetails(taskId uint) (
+	structures.TaskEventDetailsDbStruct, *commonStructures.CommonError) {
+
+	taskEventDetails := structures.TaskEventDetailsDbStruct{}
+	selectStrings := []string{
+		"tasks.id as task_id",
+		"tasks.oms_order_id as oms_order_id",
+		"tasks.lab_id as lab_id",
+		"tasks.status as status",
+		"COALESCE(task_metadata.is_critical, false) as is_critical",
+	}
+
+	if err := taskEventsDao.Db.Table(commonConstants.TableTasks).
+		Joins("LEFT JOIN task_metadata ON task_metadata.task_id = tasks.id AND task_metadata.deleted_at IS NULL").
+		Select(selectStrings).
+		Where("tasks.id = ?", taskId).
+		Where("tasks.deleted_at IS NULL").
+		Take(&taskEventDetails).Error; err != nil {
+		return taskEventDetails, commonUtils.HandleORMError(err)
+	}
+
+	return taskEventDetails, nil
+}
+
+func (taskEventsDao *TaskEventsDao) GetDepartmentsByTaskId(taskId uint) ([]string, *commonStructures.CommonError) {
+	departments := []string{}
+	if err := taskEventsDao.Db.Table(commonConstants.TableTestDetails).
+		Distinct("department").
+		Where("task_id = ?", taskId).
+		Where("department <> ''").
+		Where("deleted_at IS NULL").
+		Pluck("department", &departments).Error; err != nil {
+		return departments, commonUtils.HandleORMError(err)
+	}
+
+	return departments, nil
+}
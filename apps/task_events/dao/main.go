@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type TaskEventsDao struct {
+	Db *gorm.DB
+}
+
+func InitializeTaskEventsDao() DataLayer {
+	return &TaskEventsDao{
+		Db: psql.GetDbInstance(),
+	}
+}
@@ -0,0 +1,13 @@
+package taskEvents
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/task_events/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	taskEventsController := controller.InitTaskEventsController()
+
+	router.GET("/stream", taskEventsController.StreamTaskEvents)
+}
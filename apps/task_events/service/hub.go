@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/Orange-Health/citadel/adapters/cache"
+	"github.com/Orange-Health/citadel/apps/task_events/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// taskEventsHub holds a single redis subscription per process and fans the events out to the connected clients.
+type taskEventsHub struct {
+	startOnce   sync.Once
+	mu          sync.RWMutex
+	subscribers map[*taskEventsSubscriber]struct{}
+}
+
+type taskEventsSubscriber struct {
+	filter structures.TaskEventsFilter
+	events chan structures.TaskEvent
+}
+
+var taskEventsHubInstance = &taskEventsHub{
+	subscribers: map[*taskEventsSubscriber]struct{}{},
+}
+
+func (hub *taskEventsHub) start(cacheLayer cache.CacheLayer) {
+	hub.startOnce.Do(func() {
+		go hub.listen(cacheLayer)
+	})
+}
+
+// listen runs for the lifetime of the process, the redis client reconnects the subscription on failures.
+func (hub *taskEventsHub) listen(cacheLayer cache.CacheLayer) {
+	ctx := context.Background()
+	pubSub := cacheLayer.Subscribe(ctx, commonConstants.TaskEventsChannel)
+	for message := range pubSub.Channel() {
+		taskEvent := structures.TaskEvent{}
+		if err := json.Unmarshal([]byte(message.Payload), &taskEvent); err != nil {
+			commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_DECODING_TASK_EVENT,
+				map[string]interface{}{"payload": message.Payload}, err)
+			continue
+		}
+		hub.broadcast(taskEvent)
+	}
+}
+
+func (hub *taskEventsHub) subscribe(filter structures.TaskEventsFilter) (<-chan structures.TaskEvent, func()) {
+	subscriber := &taskEventsSubscriber{
+		filter: filter,
+		events: make(chan structures.TaskEvent, commonConstants.TaskEventsClientBufferSize),
+	}
+
+	hub.mu.Lock()
+	hub.subscribers[subscriber] = struct{}{}
+	hub.mu.Unlock()
+
+	unsubscribe := func() {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		if _, ok := hub.subscribers[subscriber]; ok {
+			delete(hub.subscribers, subscriber)
+			close(subscriber.events)
+		}
+	}
+
+	return subscriber.events, unsubscribe
+}
+
+// broadcast never blocks on a slow client, the events which do not fit in its buffer are dropped and the client
+// catches up on its next refresh of the worklist.
+func (hub *taskEventsHub) broadcast(taskEvent structures.TaskEvent) {
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+
+	for subscriber := range hub.subscribers {
+		if !isTaskEventForFilter(taskEvent, subscriber.filter) {
+			continue
+		}
+		select {
+		case subscriber.events <- taskEvent:
+		default:
+		}
+	}
+}
+
+func isTaskEventForFilter(taskEvent structures.TaskEvent, filter structures.TaskEventsFilter) bool {
+	if taskEvent.LabId != filter.LabId {
+		return false
+	}
+
+	if len(filter.Departments) == 0 || len(taskEvent.Departments) == 0 {
+		return true
+	}
+
+	for _, department := range taskEvent.Departments {
+		if commonUtils.SliceContainsString(filter.Departments, department) {
+			return true
+		}
+	}
+
+	return false
+}
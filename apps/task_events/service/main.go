@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/adapters/cache"
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/apps/task_events/dao"
+)
+
+type TaskEventsService struct {
+	TaskEventsDao dao.DataLayer
+	Cache         cache.CacheLayer
+	Sentry        sentry.SentryLayer
+}
+
+func InitializeTaskEventsService() TaskEventsServiceInterface {
+	return &TaskEventsService{
+		TaskEventsDao: dao.InitializeTaskEventsDao(),
+		Cache:         cache.InitializeCache(),
+		Sentry:        sentry.InitializeSentry(),
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/task_events/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+type TaskEventsServiceInterface interface {
+	PublishTaskEvent(ctx context.Context, eventType string, taskId, userId uint)
+	SubscribeTaskEvents(filter structures.TaskEventsFilter) (<-chan structures.TaskEvent, func())
+}
+
+// PublishTaskEvent publishes the event with the current lab, departments, status and criticality of the task to
+// every api instance. It is called after the change is committed and failures are only logged so that the task
+// workflows are never blocked on the worklist updates.
+func (taskEventsService *TaskEventsService) PublishTaskEvent(ctx context.Context, eventType string,
+	taskId, userId uint) {
+
+	taskEventDetails, cErr := taskEventsService.TaskEventsDao.GetTaskEventDetails(taskId)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_FETCHING_TASK_EVENT_DETAILS,
+			map[string]interface{}{"task_id": taskId, "event_type": eventType}, errors.New(cErr.Message))
+		return
+	}
+
+	departments, cErr := taskEventsService.TaskEventsDao.GetDepartmentsByTaskId(taskId)
+	if cErr != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_FETCHING_TASK_EVENT_DETAILS,
+			map[string]interface{}{"task_id": taskId, "event_type": eventType}, errors.New(cErr.Message))
+		return
+	}
+
+	taskEvent := structures.TaskEvent{
+		EventType:   eventType,
+		TaskId:      taskEventDetails.TaskId,
+		OmsOrderId:  taskEventDetails.OmsOrderId,
+		LabId:       taskEventDetails.LabId,
+		Departments: departments,
+		Status:      taskEventDetails.Status,
+		IsCritical:  taskEventDetails.IsCritical,
+		UserId:      userId,
+		OccurredAt:  time.Now(),
+	}
+
+	if err := taskEventsService.Cache.Publish(ctx, commonConstants.TaskEventsChannel, taskEvent); err != nil {
+		commonUtils.AddLog(ctx, commonConstants.ERROR_LEVEL, commonConstants.ERROR_WHILE_PUBLISHING_TASK_EVENT,
+			map[string]interface{}{"task_id": taskId, "event_type": eventType}, err)
+	}
+}
+
+// SubscribeTaskEvents returns the events of the lab and departments in the filter along with the function to be
+// called once the client goes away.
+func (taskEventsService *TaskEventsService) SubscribeTaskEvents(filter structures.TaskEventsFilter) (
+	<-chan structures.TaskEvent, func()) {
+
+	taskEventsHubInstance.start(taskEventsService.Cache)
+	return taskEventsHubInstance.subscribe(filter)
+}
@@ -0,0 +1,29 @@
+package structures
+
+import "time"
+
+type TaskEvent struct {
+	EventType   string    `json:"event_type"`
+	TaskId      uint      `json:"task_id"`
+	OmsOrderId  string    `json:"oms_order_id"`
+	LabId       uint      `json:"lab_id"`
+	Departments []string  `json:"departments"`
+	Status      string    `json:"status"`
+	IsCritical  bool      `json:"is_critical"`
+	UserId      uint      `json:"user_id,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+type TaskEventDetailsDbStruct struct {
+	TaskId     uint   `json:"task_id"`
+	OmsOrderId string `json:"oms_order_id"`
+	LabId      uint   `json:"lab_id"`
+	Status     string `json:"status"`
+	IsCritical bool   `json:"is_critical"`
+}
+
+// TaskEventsFilter selects the events streamed to a client. An empty department list streams all departments.
+type TaskEventsFilter struct {
+	LabId       uint
+	Departments []string
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/Orange-Health/citadel/adapters/cache"
 	"github.com/Orange-Health/citadel/adapters/sentry"
+	taskEventsService "github.com/Orange-Health/citadel/apps/task_events/service"
 	"github.com/Orange-Health/citadel/apps/task_pathologist_mapping/dao"
 )
 
@@ -10,6 +11,8 @@ type TaskPathologistMappingService struct {
 	TaskPathDao dao.DataLayer
 	Cache       cache.CacheLayer
 	Sentry      sentry.SentryLayer
+
+	TaskEventsService taskEventsService.TaskEventsServiceInterface
 }
 
 func InitializeTaskPathologistMappingService() TaskPathologistMappingServiceInterface {
@@ -17,5 +20,7 @@ func InitializeTaskPathologistMappingService() TaskPathologistMappingServiceInte
 		TaskPathDao: dao.InitializeTaskPathMapDao(),
 		Cache:       cache.InitializeCache(),
 		Sentry:      sentry.InitializeSentry(),
+
+		TaskEventsService: taskEventsService.InitializeTaskEventsService(),
 	}
 }
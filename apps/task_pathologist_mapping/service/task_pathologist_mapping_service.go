@@ -98,6 +98,7 @@ func (tpmService *TaskPathologistMappingService) createTaskPathMapAfterRedisUpda
 	}
 
 	if isUpdated {
+		tpmService.publishTaskPathMapEvent(ctx, updatedTpm)
 		return mapper.MapTpm(updatedTpm), nil
 	}
 
@@ -123,9 +124,21 @@ func (tpmService *TaskPathologistMappingService) createTaskPathMapAfterRedisUpda
 		return structures.TaskPathologistMapping{}, cErr
 	}
 
+	tpmService.publishTaskPathMapEvent(ctx, createdTpm)
 	return mapper.MapTpm(createdTpm), nil
 }
 
+// publishTaskPathMapEvent streams the claim or release of the task so that other pathologists stop opening it.
+func (tpmService *TaskPathologistMappingService) publishTaskPathMapEvent(ctx context.Context,
+	tpm models.TaskPathologistMapping) {
+
+	eventType := commonConstants.TaskEventReleased
+	if tpm.IsActive {
+		eventType = commonConstants.TaskEventClaimed
+	}
+	tpmService.TaskEventsService.PublishTaskEvent(ctx, eventType, tpm.TaskId, tpm.PathologistId)
+}
+
 func (tpmService *TaskPathologistMappingService) updateIfTpmExists(
 	tpmModel, curTpm *models.TaskPathologistMapping) (bool, models.TaskPathologistMapping, *commonStructures.CommonError) {
 	if curTpm.Id == 0 {
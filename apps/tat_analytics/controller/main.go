@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/Orange-Health/citadel/apps/tat_analytics/service"
+)
+
+type TatAnalytics struct {
+	TatAnalyticsService service.TatAnalyticsServiceInterface
+}
+
+func InitTatAnalyticsController() *TatAnalytics {
+	return &TatAnalytics{
+		TatAnalyticsService: service.InitializeTatAnalyticsService(),
+	}
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/tat_analytics/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+// @Summary		Get TAT Analytics
+// @Description	Get the percentile TATs of each stage from collection to report with the ETA breach counts
+// @Tags			tat-analytics
+// @Produce		json
+// @Param			from_date	query		string							true	"From Date (YYYY-MM-DD)"
+// @Param			to_date		query		string							true	"To Date (YYYY-MM-DD)"
+// @Param			group_by	query		string							false	"Comma separated list of lab, department, test and city"
+// @Param			bucket		query		string							false	"Time Bucket, one of hour, day, week and month"
+// @Param			lab_id		query		int								false	"Lab ID"
+// @Param			department	query		string							false	"Department"
+// @Param			city_code	query		string							false	"City Code"
+// @Param			lis_code	query		string							false	"LIS Code"
+// @Success		200			{object}	structures.TatAnalytics			"TAT Analytics"
+// @Failure		400,500		{object}	structures.CommonAPIResponse	"Common API Response"
+// @Router			/api/v1/tat-analytics [get]
+func (tatAnalyticsController *TatAnalytics) GetTatAnalytics(c *gin.Context) {
+	tatAnalyticsRequest := structures.TatAnalyticsRequest{
+		FromDate:   c.Query("from_date"),
+		ToDate:     c.Query("to_date"),
+		GroupBy:    commonUtils.ConvertStringToStringSlice(c.Query("group_by")),
+		Bucket:     c.Query("bucket"),
+		LabId:      commonUtils.ConvertStringToUint(c.Query("lab_id")),
+		Department: c.Query("department"),
+		CityCode:   c.Query("city_code"),
+		LisCode:    c.Query("lis_code"),
+	}
+
+	tatAnalytics, cErr := tatAnalyticsController.TatAnalyticsService.GetTatAnalytics(tatAnalyticsRequest)
+	if cErr != nil {
+		commonUtils.HandleErrorResponse(c, cErr.StatusCode, cErr.Message)
+		return
+	}
+
+	c.JSON(http.StatusOK, tatAnalytics)
+}
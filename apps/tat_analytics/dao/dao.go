@@ -0,0 +1,189 @@
+package dao
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Orange-Health/citadel/apps/tat_analytics/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+type DataLayer interface {
+	GetTatAnalyticsGroups(filter structures.TatAnalyticsFilter) (
+		[]structures.TatAnalyticsGroupDbResponse, *commonStructures.CommonError)
+	GetTatAnalyticsStages(filter structures.TatAnalyticsFilter) (
+		[]structures.TatAnalyticsStageDbResponse, *commonStructures.CommonError)
+}
+
+func (tatAnalyticsDao *TatAnalyticsDao) GetTatAnalyticsGroups(filter structures.TatAnalyticsFilter) (
+	[]structures.TatAnalyticsGroupDbResponse, *commonStructures.CommonError) {
+
+	groups := []structures.TatAnalyticsGroupDbResponse{}
+	query, args := getTatAnalyticsGroupsQuery(filter)
+	if err := tatAnalyticsDao.Db.Raw(query, args...).Scan(&groups).Error; err != nil {
+		return groups, commonUtils.HandleORMError(err)
+	}
+
+	return groups, nil
+}
+
+func (tatAnalyticsDao *TatAnalyticsDao) GetTatAnalyticsStages(filter structures.TatAnalyticsFilter) (
+	[]structures.TatAnalyticsStageDbResponse, *commonStructures.CommonError) {
+
+	stages := []structures.TatAnalyticsStageDbResponse{}
+	query, args := getTatAnalyticsStagesQuery(filter)
+	if err := tatAnalyticsDao.Db.Raw(query, args...).Scan(&stages).Error; err != nil {
+		return stages, commonUtils.HandleORMError(err)
+	}
+
+	return stages, nil
+}
+
+// getTatAnalyticsGroupsQuery returns the query counting the tests and their ETA breaches per group.
+func getTatAnalyticsGroupsQuery(filter structures.TatAnalyticsFilter) (string, []interface{}) {
+	testTimestampsQuery, args := getTestTimestampsQuery(filter)
+	groupSelects, groupSelectArgs, groupColumns := getGroupSelects(filter)
+	args = append(args, groupSelectArgs...)
+
+	selects := append(groupSelects,
+		"COUNT(*) AS test_count",
+		"COUNT(*) FILTER (WHERE tt.lab_eta IS NOT NULL AND COALESCE(tt.approved_at, NOW()) > tt.lab_eta) "+
+			"AS lab_breach_count",
+		"COUNT(*) FILTER (WHERE tt.report_eta IS NOT NULL AND COALESCE(tt.report_sent_at, NOW()) > tt.report_eta) "+
+			"AS report_breach_count",
+	)
+	query := fmt.Sprintf("WITH tt AS (%s) SELECT %s FROM tt", testTimestampsQuery, strings.Join(selects, ", "))
+	if len(groupColumns) > 0 {
+		query = fmt.Sprintf("%s GROUP BY %s ORDER BY %s", query, strings.Join(groupColumns, ", "),
+			strings.Join(groupColumns, ", "))
+	}
+
+	return query, args
+}
+
+// getTatAnalyticsStagesQuery returns the query of the duration percentiles of every stage per group. Each test
+// is expanded into one row per stage, skipping the stages whose timestamps are missing or out of order.
+func getTatAnalyticsStagesQuery(filter structures.TatAnalyticsFilter) (string, []interface{}) {
+	testTimestampsQuery, args := getTestTimestampsQuery(filter)
+	groupSelects, groupSelectArgs, groupColumns := getGroupSelects(filter)
+	args = append(args, groupSelectArgs...)
+
+	selects := append(groupSelects,
+		"stage.name AS stage",
+		"COUNT(*) AS count",
+		"PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY stage.minutes) AS p50",
+		"PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY stage.minutes) AS p90",
+		"PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY stage.minutes) AS p95",
+		"AVG(stage.minutes) AS average",
+	)
+	stageValues := []string{}
+	for position, stage := range commonConstants.TatStages {
+		stageBounds := tatStageBounds[stage]
+		stageValues = append(stageValues,
+			fmt.Sprintf("(%d, ?, EXTRACT(EPOCH FROM (tt.%s - tt.%s)) / 60)", position, stageBounds[1], stageBounds[0]))
+		args = append(args, stage)
+	}
+	groupColumns = append(groupColumns, "stage.position", "stage.name")
+
+	query := fmt.Sprintf("WITH tt AS (%s) SELECT %s FROM tt "+
+		"CROSS JOIN LATERAL (VALUES %s) AS stage(position, name, minutes) WHERE stage.minutes >= 0 "+
+		"GROUP BY %s ORDER BY %s", testTimestampsQuery, strings.Join(selects, ", "), strings.Join(stageValues, ", "),
+		strings.Join(groupColumns, ", "), strings.Join(groupColumns, ", "))
+
+	return query, args
+}
+
+// tatStageBounds maps each stage to the timestamps it starts and ends at.
+var tatStageBounds = map[string][2]string{
+	commonConstants.TatStageCollectionToReceipt: {"collected_at", "received_at"},
+	commonConstants.TatStageReceiptToSync:       {"received_at", "lis_sync_at"},
+	commonConstants.TatStageSyncToResult:        {"lis_sync_at", "entered_at"},
+	commonConstants.TatStageResultToApproval:    {"entered_at", "approved_at"},
+	commonConstants.TatStageApprovalToReport:    {"approved_at", "report_sent_at"},
+	commonConstants.TatStageCollectionToReport:  {"collected_at", "report_sent_at"},
+}
+
+// getTestTimestampsQuery returns one row per test with the timestamps of every stage. A test is collected when the
+// first of its samples is collected and is received and synced when the last of its samples is, while the result
+// and approval are those of the last investigation entered and approved.
+func getTestTimestampsQuery(filter structures.TatAnalyticsFilter) (string, []interface{}) {
+	conditions := []string{
+		"test_details.deleted_at IS NULL",
+		"test_details.is_duplicate = false",
+		"test_details.status != ?",
+		"test_details.created_at >= ?",
+		"test_details.created_at < ?",
+	}
+	args := []interface{}{commonConstants.TEST_STATUS_REJECTED, filter.From, filter.To}
+
+	if filter.LabId != 0 {
+		conditions = append(conditions, "test_details.lab_id = ?")
+		args = append(args, filter.LabId)
+	}
+	if filter.Department != "" {
+		conditions = append(conditions, "test_details.department = ?")
+		args = append(args, filter.Department)
+	}
+	if filter.CityCode != "" {
+		conditions = append(conditions, "test_details.city_code = ?")
+		args = append(args, filter.CityCode)
+	}
+	if filter.LisCode != "" {
+		conditions = append(conditions, "UPPER(test_details.lis_code) = UPPER(?)")
+		args = append(args, filter.LisCode)
+	}
+
+	query := "SELECT test_details.id, test_details.lab_id, test_details.department, test_details.lis_code, " +
+		"test_details.test_name, test_details.city_code, test_details.created_at, test_details.lab_eta, " +
+		"test_details.report_eta, test_details.report_sent_at, " +
+		"MIN(sample_metadata.collected_at) AS collected_at, MAX(sample_metadata.received_at) AS received_at, " +
+		"MAX(sample_metadata.lis_sync_at) AS lis_sync_at, results.entered_at, results.approved_at " +
+		"FROM test_details " +
+		"LEFT JOIN test_sample_mapping ON test_sample_mapping.oms_order_id = test_details.oms_order_id " +
+		"AND test_sample_mapping.oms_test_id = test_details.central_oms_test_id " +
+		"AND test_sample_mapping.is_rejected = false AND test_sample_mapping.deleted_at IS NULL " +
+		"LEFT JOIN samples ON samples.oms_order_id = test_sample_mapping.oms_order_id " +
+		"AND samples.sample_number = test_sample_mapping.sample_number AND samples.deleted_at IS NULL " +
+		"LEFT JOIN sample_metadata ON sample_metadata.sample_id = samples.id AND sample_metadata.deleted_at IS NULL " +
+		"LEFT JOIN LATERAL (SELECT MAX(investigation_results.entered_at) AS entered_at, " +
+		"MAX(investigation_results.approved_at) AS approved_at FROM investigation_results " +
+		"WHERE investigation_results.test_details_id = test_details.id " +
+		"AND investigation_results.deleted_at IS NULL) AS results ON TRUE " +
+		"WHERE " + strings.Join(conditions, " AND ") + " " +
+		"GROUP BY test_details.id, results.entered_at, results.approved_at"
+
+	return query, args
+}
+
+// getGroupSelects returns the select expressions of the requested group by dimensions along with their arguments
+// and the column names to group by.
+func getGroupSelects(filter structures.TatAnalyticsFilter) ([]string, []interface{}, []string) {
+	selects, args, columns := []string{}, []interface{}{}, []string{}
+	for _, groupBy := range filter.GroupBy {
+		switch groupBy {
+		case commonConstants.TatAnalyticsGroupByLab:
+			selects = append(selects, "tt.lab_id AS lab_id")
+			columns = append(columns, "lab_id")
+		case commonConstants.TatAnalyticsGroupByDepartment:
+			selects = append(selects, "COALESCE(tt.department, '') AS department")
+			columns = append(columns, "department")
+		case commonConstants.TatAnalyticsGroupByTest:
+			selects = append(selects, "tt.lis_code AS lis_code", "tt.test_name AS test_name")
+			columns = append(columns, "lis_code", "test_name")
+		case commonConstants.TatAnalyticsGroupByCity:
+			selects = append(selects, "tt.city_code AS city_code")
+			columns = append(columns, "city_code")
+		}
+	}
+
+	if filter.Bucket != "" {
+		selects = append(selects, "TO_CHAR(DATE_TRUNC(?, tt.created_at AT TIME ZONE ?), 'YYYY-MM-DD HH24:MI:SS') "+
+			"AS bucket")
+		args = append(args, filter.Bucket, commonConstants.LocalTimeZoneLocation)
+		columns = append(columns, "bucket")
+	}
+
+	return selects, args, columns
+}
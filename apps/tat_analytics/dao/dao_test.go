@@ -0,0 +1,161 @@
+package dao
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/apps/tat_analytics/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+)
+
+func TestGetTestTimestampsQuery(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		filter             structures.TatAnalyticsFilter
+		expectedConditions []string
+		expectedArgs       []interface{}
+	}{
+		{
+			name:   "date range only",
+			filter: structures.TatAnalyticsFilter{From: from, To: to},
+			expectedConditions: []string{"test_details.status != ?", "test_details.created_at >= ?",
+				"test_details.created_at < ?"},
+			expectedArgs: []interface{}{commonConstants.TEST_STATUS_REJECTED, from, to},
+		},
+		{
+			name: "every filter",
+			filter: structures.TatAnalyticsFilter{From: from, To: to, LabId: 2, Department: "Biochemistry",
+				CityCode: "BLR", LisCode: "hb"},
+			expectedConditions: []string{"test_details.lab_id = ?", "test_details.department = ?",
+				"test_details.city_code = ?", "UPPER(test_details.lis_code) = UPPER(?)"},
+			expectedArgs: []interface{}{commonConstants.TEST_STATUS_REJECTED, from, to, uint(2), "Biochemistry",
+				"BLR", "hb"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			query, args := getTestTimestampsQuery(testCase.filter)
+			for _, condition := range testCase.expectedConditions {
+				assert.Contains(t, query, condition)
+			}
+			assert.Equal(t, strings.Count(query, "?"), len(args))
+			assert.Equal(t, testCase.expectedArgs, args)
+		})
+	}
+}
+
+func TestGetGroupSelects(t *testing.T) {
+	testCases := []struct {
+		name            string
+		filter          structures.TatAnalyticsFilter
+		expectedSelects []string
+		expectedArgs    []interface{}
+		expectedColumns []string
+	}{
+		{
+			name:            "no grouping",
+			filter:          structures.TatAnalyticsFilter{},
+			expectedSelects: []string{},
+			expectedArgs:    []interface{}{},
+			expectedColumns: []string{},
+		},
+		{
+			name: "every dimension",
+			filter: structures.TatAnalyticsFilter{GroupBy: []string{commonConstants.TatAnalyticsGroupByLab,
+				commonConstants.TatAnalyticsGroupByDepartment, commonConstants.TatAnalyticsGroupByTest,
+				commonConstants.TatAnalyticsGroupByCity}},
+			expectedSelects: []string{"tt.lab_id AS lab_id", "COALESCE(tt.department, '') AS department",
+				"tt.lis_code AS lis_code", "tt.test_name AS test_name", "tt.city_code AS city_code"},
+			expectedArgs:    []interface{}{},
+			expectedColumns: []string{"lab_id", "department", "lis_code", "test_name", "city_code"},
+		},
+		{
+			name: "time bucket",
+			filter: structures.TatAnalyticsFilter{GroupBy: []string{commonConstants.TatAnalyticsGroupByCity},
+				Bucket: commonConstants.TatAnalyticsBucketDay},
+			expectedSelects: []string{"tt.city_code AS city_code",
+				"TO_CHAR(DATE_TRUNC(?, tt.created_at AT TIME ZONE ?), 'YYYY-MM-DD HH24:MI:SS') AS bucket"},
+			expectedArgs: []interface{}{commonConstants.TatAnalyticsBucketDay,
+				commonConstants.LocalTimeZoneLocation},
+			expectedColumns: []string{"city_code", "bucket"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			selects, args, columns := getGroupSelects(testCase.filter)
+			assert.Equal(t, testCase.expectedSelects, selects)
+			assert.Equal(t, testCase.expectedArgs, args)
+			assert.Equal(t, testCase.expectedColumns, columns)
+		})
+	}
+}
+
+func TestGetTatAnalyticsStagesQuery(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		filter           structures.TatAnalyticsFilter
+		expectedGroupBy  string
+		expectedArgsHead []interface{}
+	}{
+		{
+			name:             "no grouping",
+			filter:           structures.TatAnalyticsFilter{From: from, To: to},
+			expectedGroupBy:  "GROUP BY stage.position, stage.name ORDER BY stage.position, stage.name",
+			expectedArgsHead: []interface{}{commonConstants.TEST_STATUS_REJECTED, from, to},
+		},
+		{
+			name: "grouped by lab and bucket",
+			filter: structures.TatAnalyticsFilter{From: from, To: to, LabId: 2,
+				GroupBy: []string{commonConstants.TatAnalyticsGroupByLab},
+				Bucket:  commonConstants.TatAnalyticsBucketWeek},
+			expectedGroupBy: "GROUP BY lab_id, bucket, stage.position, stage.name " +
+				"ORDER BY lab_id, bucket, stage.position, stage.name",
+			expectedArgsHead: []interface{}{commonConstants.TEST_STATUS_REJECTED, from, to, uint(2),
+				commonConstants.TatAnalyticsBucketWeek, commonConstants.LocalTimeZoneLocation},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			query, args := getTatAnalyticsStagesQuery(testCase.filter)
+			assert.True(t, strings.HasSuffix(query, testCase.expectedGroupBy))
+			assert.Equal(t, strings.Count(query, "?"), len(args))
+			assert.Equal(t, testCase.expectedArgsHead, args[:len(testCase.expectedArgsHead)])
+
+			stageArgs := args[len(testCase.expectedArgsHead):]
+			assert.Len(t, stageArgs, len(commonConstants.TatStages))
+			for position, stage := range commonConstants.TatStages {
+				assert.Equal(t, stage, stageArgs[position])
+			}
+			assert.Contains(t, query,
+				"(0, ?, EXTRACT(EPOCH FROM (tt.received_at - tt.collected_at)) / 60)")
+			assert.Contains(t, query,
+				"(3, ?, EXTRACT(EPOCH FROM (tt.approved_at - tt.entered_at)) / 60)")
+			assert.Contains(t, query,
+				"(5, ?, EXTRACT(EPOCH FROM (tt.report_sent_at - tt.collected_at)) / 60)")
+			assert.Contains(t, query, "WHERE stage.minutes >= 0")
+		})
+	}
+}
+
+func TestTatStageBounds(t *testing.T) {
+	for _, stage := range commonConstants.TatStages {
+		t.Run(stage, func(t *testing.T) {
+			stageBounds, ok := tatStageBounds[stage]
+			assert.True(t, ok)
+			assert.NotEmpty(t, stageBounds[0])
+			assert.NotEmpty(t, stageBounds[1])
+		})
+	}
+}
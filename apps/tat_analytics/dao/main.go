@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+)
+
+type TatAnalyticsDao struct {
+	Db *gorm.DB
+}
+
+func InitializeTatAnalyticsDao() DataLayer {
+	return &TatAnalyticsDao{
+		Db: psql.GetDbInstance(),
+	}
+}
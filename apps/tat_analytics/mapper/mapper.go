@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/Orange-Health/citadel/apps/tat_analytics/structures"
+)
+
+func getTatAnalyticsGroupKey(labId uint, department, lisCode, testName, cityCode, bucket string) string {
+	return fmt.Sprintf("%d|%s|%s|%s|%s|%s", labId, department, lisCode, testName, cityCode, bucket)
+}
+
+// MapTatAnalyticsGroups attaches the stage TATs to the groups they were computed for. A group without any test
+// that has completed a stage carries no stages.
+func MapTatAnalyticsGroups(groups []structures.TatAnalyticsGroupDbResponse,
+	stages []structures.TatAnalyticsStageDbResponse) []structures.TatAnalyticsGroup {
+
+	groupKeyToStagesMap := make(map[string][]structures.TatAnalyticsStage)
+	for _, stage := range stages {
+		groupKey := getTatAnalyticsGroupKey(stage.LabId, stage.Department, stage.LisCode, stage.TestName,
+			stage.CityCode, stage.Bucket)
+		groupKeyToStagesMap[groupKey] = append(groupKeyToStagesMap[groupKey], structures.TatAnalyticsStage{
+			Stage:          stage.Stage,
+			Count:          stage.Count,
+			P50Minutes:     stage.P50,
+			P90Minutes:     stage.P90,
+			P95Minutes:     stage.P95,
+			AverageMinutes: stage.Average,
+		})
+	}
+
+	tatAnalyticsGroups := []structures.TatAnalyticsGroup{}
+	for _, group := range groups {
+		groupStages, ok := groupKeyToStagesMap[getTatAnalyticsGroupKey(group.LabId, group.Department, group.LisCode,
+			group.TestName, group.CityCode, group.Bucket)]
+		if !ok {
+			groupStages = []structures.TatAnalyticsStage{}
+		}
+		tatAnalyticsGroups = append(tatAnalyticsGroups, structures.TatAnalyticsGroup{
+			LabId:             group.LabId,
+			Department:        group.Department,
+			LisCode:           group.LisCode,
+			TestName:          group.TestName,
+			CityCode:          group.CityCode,
+			Bucket:            group.Bucket,
+			TestCount:         group.TestCount,
+			LabBreachCount:    group.LabBreachCount,
+			ReportBreachCount: group.ReportBreachCount,
+			Stages:            groupStages,
+		})
+	}
+	return tatAnalyticsGroups
+}
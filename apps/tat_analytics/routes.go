@@ -0,0 +1,13 @@
+package tatAnalytics
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Orange-Health/citadel/apps/tat_analytics/controller"
+)
+
+func RouteHandler(router *gin.RouterGroup) {
+	tatAnalyticsController := controller.InitTatAnalyticsController()
+
+	router.GET("", tatAnalyticsController.GetTatAnalytics)
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"github.com/Orange-Health/citadel/apps/tat_analytics/dao"
+)
+
+type TatAnalyticsService struct {
+	TatAnalyticsDao dao.DataLayer
+}
+
+func InitializeTatAnalyticsService() TatAnalyticsServiceInterface {
+	return &TatAnalyticsService{
+		TatAnalyticsDao: dao.InitializeTatAnalyticsDao(),
+	}
+}
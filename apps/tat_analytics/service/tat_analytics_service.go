@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/apps/tat_analytics/mapper"
+	"github.com/Orange-Health/citadel/apps/tat_analytics/structures"
+	commonConstants "github.com/Orange-Health/citadel/common/constants"
+	commonStructures "github.com/Orange-Health/citadel/common/structures"
+	commonUtils "github.com/Orange-Health/citadel/common/utils"
+)
+
+type TatAnalyticsServiceInterface interface {
+	GetTatAnalytics(tatAnalyticsRequest structures.TatAnalyticsRequest) (
+		structures.TatAnalytics, *commonStructures.CommonError)
+}
+
+// GetTatAnalytics computes the percentile TATs of every stage for the tests created between the requested days,
+// grouped by the requested dimensions and time bucket, along with the lab and report ETA breaches of each group.
+func (tatAnalyticsService *TatAnalyticsService) GetTatAnalytics(tatAnalyticsRequest structures.TatAnalyticsRequest) (
+	structures.TatAnalytics, *commonStructures.CommonError) {
+
+	filter, cErr := getTatAnalyticsFilter(tatAnalyticsRequest)
+	if cErr != nil {
+		return structures.TatAnalytics{}, cErr
+	}
+
+	groups, cErr := tatAnalyticsService.TatAnalyticsDao.GetTatAnalyticsGroups(filter)
+	if cErr != nil {
+		return structures.TatAnalytics{}, cErr
+	}
+
+	stages, cErr := tatAnalyticsService.TatAnalyticsDao.GetTatAnalyticsStages(filter)
+	if cErr != nil {
+		return structures.TatAnalytics{}, cErr
+	}
+
+	return structures.TatAnalytics{
+		FromDate: tatAnalyticsRequest.FromDate,
+		ToDate:   tatAnalyticsRequest.ToDate,
+		GroupBy:  filter.GroupBy,
+		Bucket:   filter.Bucket,
+		Groups:   mapper.MapTatAnalyticsGroups(groups, stages),
+	}, nil
+}
+
+func getTatAnalyticsFilter(tatAnalyticsRequest structures.TatAnalyticsRequest) (
+	structures.TatAnalyticsFilter, *commonStructures.CommonError) {
+
+	location, err := commonUtils.GetLocalLocation()
+	if err != nil {
+		return structures.TatAnalyticsFilter{}, &commonStructures.CommonError{
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	fromDate, fromErr := time.ParseInLocation(commonConstants.DateLayout, tatAnalyticsRequest.FromDate, location)
+	toDate, toErr := time.ParseInLocation(commonConstants.DateLayout, tatAnalyticsRequest.ToDate, location)
+	if fromErr != nil || toErr != nil || fromDate.After(toDate) {
+		return structures.TatAnalyticsFilter{}, &commonStructures.CommonError{
+			Message:    commonConstants.ERROR_INVALID_TAT_ANALYTICS_DATE_RANGE,
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	toDate = toDate.AddDate(0, 0, 1)
+	if toDate.After(fromDate.AddDate(0, 0, commonConstants.TatAnalyticsMaxRangeDays)) {
+		return structures.TatAnalyticsFilter{}, &commonStructures.CommonError{
+			Message: fmt.Sprintf(commonConstants.ERROR_TAT_ANALYTICS_RANGE_TOO_LONG,
+				commonConstants.TatAnalyticsMaxRangeDays),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	groupBy := []string{}
+	groupBy = append(groupBy, commonUtils.CreateUniqueSliceString(tatAnalyticsRequest.GroupBy)...)
+	for _, dimension := range groupBy {
+		if !commonUtils.SliceContainsString(commonConstants.TatAnalyticsGroupByDimensions, dimension) {
+			return structures.TatAnalyticsFilter{}, &commonStructures.CommonError{
+				Message: fmt.Sprintf(commonConstants.ERROR_INVALID_TAT_ANALYTICS_GROUP_BY,
+					strings.Join(commonConstants.TatAnalyticsGroupByDimensions, ", ")),
+				StatusCode: http.StatusBadRequest,
+			}
+		}
+	}
+
+	if tatAnalyticsRequest.Bucket != "" &&
+		!commonUtils.SliceContainsString(commonConstants.TatAnalyticsBuckets, tatAnalyticsRequest.Bucket) {
+		return structures.TatAnalyticsFilter{}, &commonStructures.CommonError{
+			Message: fmt.Sprintf(commonConstants.ERROR_INVALID_TAT_ANALYTICS_BUCKET,
+				strings.Join(commonConstants.TatAnalyticsBuckets, ", ")),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	return structures.TatAnalyticsFilter{
+		From:       fromDate,
+		To:         toDate,
+		GroupBy:    groupBy,
+		Bucket:     tatAnalyticsRequest.Bucket,
+		LabId:      tatAnalyticsRequest.LabId,
+		Department: tatAnalyticsRequest.Department,
+		CityCode:   tatAnalyticsRequest.CityCode,
+		LisCode:    tatAnalyticsRequest.LisCode,
+	}, nil
+}
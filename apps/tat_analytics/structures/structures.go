@@ -0,0 +1,127 @@
+package structures
+
+import "time"
+
+type TatAnalyticsRequest struct {
+	FromDate   string
+	ToDate     string
+	GroupBy    []string
+	Bucket     string
+	LabId      uint
+	Department string
+	CityCode   string
+	LisCode    string
+}
+
+// TatAnalyticsFilter selects the tests created between From and To, with To being exclusive.
+type TatAnalyticsFilter struct {
+	From       time.Time
+	To         time.Time
+	GroupBy    []string
+	Bucket     string
+	LabId      uint
+	Department string
+	CityCode   string
+	LisCode    string
+}
+
+type TatAnalyticsGroupDbResponse struct {
+	LabId             uint   `gorm:"column:lab_id"`
+	Department        string `gorm:"column:department"`
+	LisCode           string `gorm:"column:lis_code"`
+	TestName          string `gorm:"column:test_name"`
+	CityCode          string `gorm:"column:city_code"`
+	Bucket            string `gorm:"column:bucket"`
+	TestCount         uint   `gorm:"column:test_count"`
+	LabBreachCount    uint   `gorm:"column:lab_breach_count"`
+	ReportBreachCount uint   `gorm:"column:report_breach_count"`
+}
+
+type TatAnalyticsStageDbResponse struct {
+	LabId      uint    `gorm:"column:lab_id"`
+	Department string  `gorm:"column:department"`
+	LisCode    string  `gorm:"column:lis_code"`
+	TestName   string  `gorm:"column:test_name"`
+	CityCode   string  `gorm:"column:city_code"`
+	Bucket     string  `gorm:"column:bucket"`
+	Stage      string  `gorm:"column:stage"`
+	Count      uint    `gorm:"column:count"`
+	P50        float64 `gorm:"column:p50"`
+	P90        float64 `gorm:"column:p90"`
+	P95        float64 `gorm:"column:p95"`
+	Average    float64 `gorm:"column:average"`
+}
+
+// @swagger:model TatAnalytics
+type TatAnalytics struct {
+	// The first day of the tests included, in YYYY-MM-DD.
+	// example: "2026-10-01"
+	FromDate string `json:"from_date"`
+	// The last day of the tests included, in YYYY-MM-DD.
+	// example: "2026-10-07"
+	ToDate string `json:"to_date"`
+	// The dimensions the tests are grouped by.
+	// example: ["lab", "department"]
+	GroupBy []string `json:"group_by"`
+	// The time bucket the tests are grouped by, if any.
+	// example: "day"
+	Bucket string `json:"bucket"`
+	// The TATs of each group.
+	Groups []TatAnalyticsGroup `json:"groups"`
+}
+
+// @swagger:model TatAnalyticsGroup
+type TatAnalyticsGroup struct {
+	// The lab ID, when grouped by lab.
+	// example: 1
+	LabId uint `json:"lab_id,omitempty"`
+	// The department, when grouped by department.
+	// example: "Biochemistry"
+	Department string `json:"department,omitempty"`
+	// The LIS code of the test, when grouped by test.
+	// example: "LFT"
+	LisCode string `json:"lis_code,omitempty"`
+	// The name of the test, when grouped by test.
+	// example: "Liver Function Test"
+	TestName string `json:"test_name,omitempty"`
+	// The city code, when grouped by city.
+	// example: "BLR"
+	CityCode string `json:"city_code,omitempty"`
+	// The start of the time bucket in local time, when grouped by a time bucket.
+	// example: "2026-10-01 00:00:00"
+	Bucket string `json:"bucket,omitempty"`
+	// The number of tests in the group.
+	// example: 120
+	TestCount uint `json:"test_count"`
+	// The number of tests approved after the lab ETA, or not yet approved past it.
+	// example: 4
+	LabBreachCount uint `json:"lab_breach_count"`
+	// The number of tests reported after the report ETA, or not yet reported past it.
+	// example: 2
+	ReportBreachCount uint `json:"report_breach_count"`
+	// The TAT of each stage, for the tests that have completed the stage.
+	Stages []TatAnalyticsStage `json:"stages"`
+}
+
+// @swagger:model TatAnalyticsStage
+type TatAnalyticsStage struct {
+	// The stage, one of collection_to_receipt, receipt_to_sync, sync_to_result, result_to_approval,
+	// approval_to_report and collection_to_report.
+	// example: "receipt_to_sync"
+	Stage string `json:"stage"`
+	// The number of tests that have completed the stage.
+	// example: 118
+	Count uint `json:"count"`
+	// The median TAT of the stage in minutes.
+	// example: 42.5
+	P50Minutes float64 `json:"p50_minutes"`
+	// The 90th percentile TAT of the stage in minutes.
+	// example: 95
+	P90Minutes float64 `json:"p90_minutes"`
+	// The 95th percentile TAT of the stage in minutes.
+	// example: 130.2
+	P95Minutes float64 `json:"p95_minutes"`
+	// The average TAT of the stage in minutes.
+	// example: 51.3
+	AverageMinutes float64 `json:"average_minutes"`
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/Orange-Health/citadel/apps/test_detail/mapper"
 	"github.com/Orange-Health/citadel/apps/test_detail/structures"
@@ -58,6 +59,8 @@ type DataLayer interface {
 	UpdateTestDetailsById(testDetails commonModels.TestDetail) *commonStructures.CommonError
 	UpdateTestDetailsWithTx(tx *gorm.DB, testDetails []commonModels.TestDetail) (
 		[]commonModels.TestDetail, *commonStructures.CommonError)
+	GetTestDetailsByIdsForUpdateWithTx(tx *gorm.DB, testDetailIds []uint) (
+		[]commonModels.TestDetail, *commonStructures.CommonError)
 	UpdateTaskIdInTestDetailsWithOmsTestIdWithTx(tx *gorm.DB, omsTestIds []string,
 		taskId uint) *commonStructures.CommonError
 	UpdateTaskIdInTestDetailsWithOmsTestIds(centralOmsTestIds []string, taskId uint) *commonStructures.CommonError
@@ -67,7 +70,8 @@ type DataLayer interface {
 	DeleteTestDetailsByOmsTestIdsWithTx(tx *gorm.DB, omsTestIds []string) *commonStructures.CommonError
 	DeleteTestDetailsByTaskIdAndOmsTestIdWithTx(tx *gorm.DB, taskID uint, omsTestId string) *commonStructures.CommonError
 	DeleteTestDetailsByOmsOrderIdWithTx(tx *gorm.DB, omsOrderId string) *commonStructures.CommonError
-	UpdateReportStatusByOmsTestIds(omsTestIds []string, oldStatus, newStatus string) *commonStructures.CommonError
+	UpdateReportStatusByOmsTestIdsWithTx(tx *gorm.DB, omsTestIds []string,
+		oldStatus, newStatus string) *commonStructures.CommonError
 
 	// Test Details Metadata
 	GetTestDetailsMetadataByTestDetailIds(testDetailsIds []uint) (
@@ -340,6 +344,18 @@ func (testDetailDao *TestDetailDao) UpdateTestDetailsWithTx(tx *gorm.DB,
 	return testDetails, nil
 }
 
+func (testDetailDao *TestDetailDao) GetTestDetailsByIdsForUpdateWithTx(tx *gorm.DB, testDetailIds []uint) (
+	[]commonModels.TestDetail, *commonStructures.CommonError) {
+
+	testDetails := []commonModels.TestDetail{}
+	err := tx.Clauses(clause.Locking{Strength: commonConstants.CLAUSE_UPDATE}).
+		Where("id IN (?)", testDetailIds).Order("id").Find(&testDetails).Error
+	if err != nil {
+		return []commonModels.TestDetail{}, commonUtils.HandleORMError(err)
+	}
+	return testDetails, nil
+}
+
 func (testDetailDao *TestDetailDao) UpdateTaskIdInTestDetailsWithOmsTestIdWithTx(tx *gorm.DB, omsTestIds []string,
 	taskId uint) *commonStructures.CommonError {
 	updatesMap := map[string]interface{}{
@@ -490,7 +506,7 @@ func (testDetailDao *TestDetailDao) DeleteTestDetailsByOmsOrderIdWithTx(tx *gorm
 	return nil
 }
 
-func (testDetailDao *TestDetailDao) UpdateReportStatusByOmsTestIds(omsTestIds []string,
+func (testDetailDao *TestDetailDao) UpdateReportStatusByOmsTestIdsWithTx(tx *gorm.DB, omsTestIds []string,
 	oldStatus, newStatus string) *commonStructures.CommonError {
 
 	updates := map[string]interface{}{
@@ -499,7 +515,7 @@ func (testDetailDao *TestDetailDao) UpdateReportStatusByOmsTestIds(omsTestIds []
 		"updated_by":    commonConstants.CitadelSystemId,
 	}
 
-	if err := testDetailDao.Db.Model(&commonModels.TestDetail{}).
+	if err := tx.Model(&commonModels.TestDetail{}).
 		Where("central_oms_test_id IN (?) AND report_status = ?", omsTestIds, oldStatus).
 		Updates(updates).Error; err != nil {
 		return commonUtils.HandleORMError(err)
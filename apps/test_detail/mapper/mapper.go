@@ -29,6 +29,7 @@ func MapTestDetail(testDetail commonModels.TestDetail) structures.TestDetail {
 	testDetailStruct.LabId = testDetail.LabId
 	testDetailStruct.ProcessingLabId = testDetail.ProcessingLabId
 	testDetailStruct.Department = testDetail.Department
+	testDetailStruct.RowVersion = testDetail.RowVersion
 	return testDetailStruct
 }
 
@@ -54,6 +54,8 @@ type TestDetailServiceInterface interface {
 		[]commonModels.TestDetail, *commonStructures.CommonError)
 	UpdateTestDetailsWithTx(tx *gorm.DB, testDetails []commonModels.TestDetail) (
 		[]commonModels.TestDetail, *commonStructures.CommonError)
+	GetTestDetailsByIdsForUpdateWithTx(tx *gorm.DB, testDetailIds []uint) (
+		[]commonModels.TestDetail, *commonStructures.CommonError)
 	UpdateTaskIdInTestDetailsWithOmsTestIdWithTx(tx *gorm.DB, centralOmsTestIds []string, taskId uint) *commonStructures.CommonError
 	UpdateTaskIdInTestDetailsWithOmsTestIds(centralOmsTestIds []string, taskId uint) *commonStructures.CommonError
 	UpdateTestDetailsById(testDetails commonModels.TestDetail) *commonStructures.CommonError
@@ -63,7 +65,8 @@ type TestDetailServiceInterface interface {
 	DeleteTestDetailsByTaskIdAndOmsTestIdWithTx(tx *gorm.DB,
 		taskID uint, omsTestId string) *commonStructures.CommonError
 	DeleteTestDetailsByOmsOrderIdWithTx(tx *gorm.DB, omsOrderId string) *commonStructures.CommonError
-	UpdateReportStatusByOmsTestIds(omsTestIds []string, oldStatus, newStatus string) *commonStructures.CommonError
+	UpdateReportStatusByOmsTestIdsWithTx(tx *gorm.DB, omsTestIds []string,
+		oldStatus, newStatus string) *commonStructures.CommonError
 
 	// Test Details Metadata
 	GetTestDetailsMetadataByTestDetailIds(testDetailsIds []uint) (
@@ -297,6 +300,14 @@ func (testDetailService *TestDetailService) UpdateTestDetailsWithTx(tx *gorm.DB,
 	return testDetailService.TestDetailDao.UpdateTestDetailsWithTx(tx, testDetails)
 }
 
+func (testDetailService *TestDetailService) GetTestDetailsByIdsForUpdateWithTx(tx *gorm.DB,
+	testDetailIds []uint) ([]commonModels.TestDetail, *commonStructures.CommonError) {
+	if len(testDetailIds) == 0 {
+		return []commonModels.TestDetail{}, nil
+	}
+	return testDetailService.TestDetailDao.GetTestDetailsByIdsForUpdateWithTx(tx, testDetailIds)
+}
+
 func (testDetailService *TestDetailService) UpdateTaskIdInTestDetailsWithOmsTestIdWithTx(tx *gorm.DB, omsTestIds []string,
 	taskId uint) *commonStructures.CommonError {
 	if len(omsTestIds) == 0 {
@@ -400,13 +411,13 @@ func (testDetailService *TestDetailService) DeleteTestDetailsByOmsOrderIdWithTx(
 	return testDetailService.DeleteTestDetailsMetadataByTestDetailsIdsWithTx(tx, testDetailsIds)
 }
 
-func (testDetailService *TestDetailService) UpdateReportStatusByOmsTestIds(omsTestIds []string,
+func (testDetailService *TestDetailService) UpdateReportStatusByOmsTestIdsWithTx(tx *gorm.DB, omsTestIds []string,
 	oldStatus, newStatus string) *commonStructures.CommonError {
 	if len(omsTestIds) == 0 {
 		return nil
 	}
 
-	return testDetailService.TestDetailDao.UpdateReportStatusByOmsTestIds(omsTestIds, oldStatus, newStatus)
+	return testDetailService.TestDetailDao.UpdateReportStatusByOmsTestIdsWithTx(tx, omsTestIds, oldStatus, newStatus)
 }
 
 func (testDetailService *TestDetailService) GetTestDetailsMetadataByTestDetailIds(testDetailsIds []uint) (
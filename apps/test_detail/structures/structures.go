@@ -53,6 +53,9 @@ type TestDetail struct {
 	ReportStatus    string `gorm:"column:report_status;not null" json:"report_status"`
 	// Need to change this to OMS City Code
 	CityCode string `gorm:"column:city_code;not null" json:"city_code"`
+	// The row version of the test, sent back while updating the task details.
+	// example: 3
+	RowVersion uint64 `json:"row_version"`
 }
 
 type TestBasicDetails struct {
@@ -0,0 +1,77 @@
+package astm
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	zl "github.com/rs/zerolog/log"
+
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	astmClient "github.com/Orange-Health/citadel/clients/astm"
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/utils"
+	"github.com/Orange-Health/citadel/worker"
+	workerTasks "github.com/Orange-Health/citadel/worker_tasks"
+)
+
+type AstmServer struct {
+	Context context.Context
+	Sentry  sentry.SentryLayer
+}
+
+// Start listens for analyzer results over ASTM E1381 and hands every sample's results to the worker.
+func Start() {
+	if gin.IsDebugging() {
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	} else {
+		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	}
+
+	ctx := context.Background()
+	sentry.Initialize()
+
+	astmServer := &AstmServer{
+		Context: ctx,
+		Sentry:  sentry.InitializeSentry(),
+	}
+
+	err := astmClient.ListenAndServe(ctx, constants.AstmListenAddress, astmServer.handler)
+	if err != nil {
+		zl.Error().Err(err).Msg(constants.ERROR_WHILE_READING_ASTM_MESSAGE)
+	}
+}
+
+func (astmServer *AstmServer) handler(ctx context.Context, message astmClient.Message) error {
+	deviceSampleResults, err := astmClient.ConvertMessageToDeviceResults(message)
+	if err != nil {
+		return err
+	}
+	if len(deviceSampleResults) == 0 {
+		return nil
+	}
+
+	server, err := worker.StartServer(true)
+	if err != nil {
+		return err
+	}
+
+	for _, sampleResults := range deviceSampleResults {
+		loggingAttributes := map[string]interface{}{
+			"barcode":   sampleResults.Barcode,
+			"device_id": sampleResults.DeviceId,
+			"results":   len(sampleResults.Results),
+		}
+
+		signature, err := workerTasks.DeviceResultsTaskSignature(sampleResults)
+		if err == nil {
+			_, err = server.SendTask(signature)
+		}
+		if err != nil {
+			astmServer.Sentry.LogError(ctx, constants.ERROR_WHILE_INGESTING_DEVICE_RESULTS, err, loggingAttributes)
+			return err
+		}
+		utils.AddLog(ctx, constants.INFO_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes, nil)
+	}
+	return nil
+}
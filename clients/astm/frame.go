@@ -0,0 +1,108 @@
+package astmClient
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+// Frame is one <STX> FN text <ETB|ETX> C1 C2 <CR><LF> block of a transfer.
+type Frame struct {
+	Number  int
+	Text    string
+	IsFinal bool
+}
+
+// Checksum is the modulo 256 sum of every byte from the frame number up to and including the ETB or ETX,
+// as two upper-case hex characters.
+func Checksum(body []byte) string {
+	sum := 0
+	for _, b := range body {
+		sum += int(b)
+	}
+	return fmt.Sprintf("%02X", sum%256)
+}
+
+func (frame Frame) Bytes() []byte {
+	terminator := byte(constants.ASTM_ETB)
+	if frame.IsFinal {
+		terminator = constants.ASTM_ETX
+	}
+
+	body := make([]byte, 0, len(frame.Text)+2)
+	body = append(body, byte('0'+frame.Number%8))
+	body = append(body, frame.Text...)
+	body = append(body, terminator)
+
+	encoded := make([]byte, 0, len(body)+5)
+	encoded = append(encoded, constants.ASTM_STX)
+	encoded = append(encoded, body...)
+	encoded = append(encoded, Checksum(body)...)
+	encoded = append(encoded, constants.ASTM_CR, constants.ASTM_LF)
+	return encoded
+}
+
+// BuildFrames starts every record in a new frame and splits records longer than a frame into intermediate
+// frames. Frame numbers start at 1 and wrap after 7.
+func BuildFrames(message Message) []Frame {
+	frames := []Frame{}
+	for _, record := range message.Records {
+		text := record.String(message.Delimiters) + recordSeparator
+		for len(text) > 0 {
+			chunkLength := len(text)
+			if chunkLength > constants.ASTM_MAX_FRAME_TEXT_LENGTH {
+				chunkLength = constants.ASTM_MAX_FRAME_TEXT_LENGTH
+			}
+			frames = append(frames, Frame{
+				Number:  (len(frames) + 1) % 8,
+				Text:    text[:chunkLength],
+				IsFinal: chunkLength == len(text),
+			})
+			text = text[chunkLength:]
+		}
+	}
+	return frames
+}
+
+// ReadFrame reads the rest of a frame after its STX, up to and including the trailing LF.
+func ReadFrame(reader *bufio.Reader) ([]byte, error) {
+	frame, err := reader.ReadBytes(constants.ASTM_LF)
+	if err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// ParseFrame validates the checksum of a frame read by ReadFrame and returns its contents.
+func ParseFrame(encoded []byte) (Frame, error) {
+	trimmed := strings.TrimRight(string(encoded), "\r\n")
+	// frame number, terminator and two checksum characters at the least
+	if len(trimmed) < 4 {
+		return Frame{}, fmt.Errorf("%s: frame too short", constants.ERROR_INVALID_ASTM_FRAME)
+	}
+
+	body, checksum := trimmed[:len(trimmed)-2], trimmed[len(trimmed)-2:]
+	if !strings.EqualFold(Checksum([]byte(body)), checksum) {
+		return Frame{}, fmt.Errorf("%s: expected %s, got %s", constants.ERROR_ASTM_CHECKSUM_MISMATCH,
+			Checksum([]byte(body)), checksum)
+	}
+
+	frameNumber, err := strconv.Atoi(body[:1])
+	if err != nil || frameNumber > 7 {
+		return Frame{}, fmt.Errorf("%s: invalid frame number %q", constants.ERROR_INVALID_ASTM_FRAME, body[:1])
+	}
+
+	terminator := body[len(body)-1]
+	if terminator != constants.ASTM_ETX && terminator != constants.ASTM_ETB {
+		return Frame{}, fmt.Errorf("%s: missing ETX or ETB", constants.ERROR_INVALID_ASTM_FRAME)
+	}
+
+	return Frame{
+		Number:  frameNumber,
+		Text:    body[1 : len(body)-1],
+		IsFinal: terminator == constants.ASTM_ETX,
+	}, nil
+}
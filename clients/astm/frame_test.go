@@ -0,0 +1,99 @@
+package astmClient
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+func TestChecksum(t *testing.T) {
+	// 0x31 + 0x41 + 0x03
+	assert.Equal(t, "75", Checksum([]byte("1A\x03")))
+	// 0xFF + 0x02 wraps around 256
+	assert.Equal(t, "01", Checksum([]byte{0xff, 0x02}))
+	assert.Equal(t, "00", Checksum(nil))
+}
+
+func TestFrameBytes(t *testing.T) {
+	assert.Equal(t, []byte("\x021A\x0375\r\n"), Frame{Number: 1, Text: "A", IsFinal: true}.Bytes())
+	assert.Equal(t, []byte("\x020A\x1788\r\n"), Frame{Number: 8, Text: "A"}.Bytes())
+}
+
+func TestReadFrameAndParseFrame(t *testing.T) {
+	frame := Frame{Number: 3, Text: "R|1|^^^GLU|98\r", IsFinal: true}
+	reader := bufio.NewReader(bytes.NewReader(frame.Bytes()))
+	stx, err := reader.ReadByte()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(constants.ASTM_STX), stx)
+
+	encoded, err := ReadFrame(reader)
+	assert.NoError(t, err)
+	parsedFrame, err := ParseFrame(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, frame, parsedFrame)
+}
+
+func TestParseFrameErrors(t *testing.T) {
+	_, err := ParseFrame([]byte("1A\x0300\r\n"))
+	assert.ErrorContains(t, err, constants.ERROR_ASTM_CHECKSUM_MISMATCH)
+
+	_, err = ParseFrame([]byte("1\r\n"))
+	assert.ErrorContains(t, err, constants.ERROR_INVALID_ASTM_FRAME)
+
+	_, err = ParseFrame([]byte("8A\x03" + Checksum([]byte("8A\x03")) + "\r\n"))
+	assert.ErrorContains(t, err, constants.ERROR_INVALID_ASTM_FRAME)
+
+	_, err = ParseFrame([]byte("1AB" + Checksum([]byte("1AB")) + "\r\n"))
+	assert.ErrorContains(t, err, constants.ERROR_INVALID_ASTM_FRAME)
+}
+
+func TestParseFrameAcceptsLowerCaseChecksum(t *testing.T) {
+	// 0x31 + 0x7F + 0x03 is 0xB3
+	frame, err := ParseFrame([]byte("1\x7f\x03b3\r\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "\x7f", frame.Text)
+	assert.True(t, frame.IsFinal)
+}
+
+func TestBuildFrames(t *testing.T) {
+	longValue := strings.Repeat("x", constants.ASTM_MAX_FRAME_TEXT_LENGTH)
+	message := Message{
+		Delimiters: DefaultDelimiters,
+		Records: []Record{
+			NewRecord(constants.ASTM_RECORD_HEADER, DefaultDelimiters.String()),
+			NewRecord(constants.ASTM_RECORD_COMMENT, "1", longValue),
+			NewTerminator(),
+		},
+	}
+
+	frames := BuildFrames(message)
+	assert.Len(t, frames, 4)
+	assert.Equal(t, []int{1, 2, 3, 4}, []int{frames[0].Number, frames[1].Number, frames[2].Number, frames[3].Number})
+	assert.Equal(t, []bool{true, false, true, true},
+		[]bool{frames[0].IsFinal, frames[1].IsFinal, frames[2].IsFinal, frames[3].IsFinal})
+	assert.Len(t, frames[1].Text, constants.ASTM_MAX_FRAME_TEXT_LENGTH)
+
+	text := ""
+	for _, frame := range frames {
+		text += frame.Text
+	}
+	assert.Equal(t, message.String(), text)
+}
+
+func TestBuildFramesWrapsFrameNumbers(t *testing.T) {
+	message := Message{Delimiters: DefaultDelimiters}
+	for range 9 {
+		message.Records = append(message.Records, NewRecord(constants.ASTM_RECORD_COMMENT, "1"))
+	}
+
+	frames := BuildFrames(message)
+	assert.Len(t, frames, 9)
+	assert.Equal(t, 7, frames[6].Number)
+	assert.Equal(t, 0, frames[7].Number)
+	assert.Equal(t, 1, frames[8].Number)
+}
@@ -0,0 +1,191 @@
+package astmClient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/utils"
+)
+
+// MessageHandler processes one complete transfer. ASTM has no application level acknowledgement, so errors are
+// only logged.
+type MessageHandler func(ctx context.Context, message Message) error
+
+// ListenAndServe accepts analyzer connections on address and runs the receiver side of the ASTM E1381 link
+// protocol on each of them. It returns when ctx is cancelled.
+func ListenAndServe(ctx context.Context, address string, handler MessageHandler) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	utils.AddLog(ctx, constants.INFO_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
+		"address": address,
+	}, nil)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, err)
+			continue
+		}
+		go serveConnection(ctx, conn, handler)
+	}
+}
+
+// serveConnection acknowledges ENQ, acknowledges every frame with a valid checksum and the expected frame number
+// (re-acknowledging a repeated frame whose ACK was lost) and hands the assembled message to handler on EOT.
+func serveConnection(ctx context.Context, conn net.Conn, handler MessageHandler) {
+	defer conn.Close()
+
+	loggingAttributes := map[string]interface{}{"remote_address": conn.RemoteAddr().String()}
+	reader := bufio.NewReader(conn)
+	inTransfer, expectedFrameNumber := false, 1
+	text := []byte{}
+	for {
+		// an idle analyzer may keep the connection open indefinitely, a transfer must keep moving
+		deadline := time.Time{}
+		if inTransfer {
+			deadline = time.Now().Add(getTimeout(constants.AstmReceiveTimeout))
+		}
+		_ = conn.SetReadDeadline(deadline)
+
+		controlByte, err := reader.ReadByte()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_READING_ASTM_MESSAGE, loggingAttributes, err)
+			}
+			return
+		}
+
+		reply := byte(0)
+		switch controlByte {
+		case constants.ASTM_ENQ:
+			inTransfer, expectedFrameNumber, text = true, 1, []byte{}
+			reply = constants.ASTM_ACK
+		case constants.ASTM_STX:
+			if !inTransfer {
+				continue
+			}
+			reply = constants.ASTM_NAK
+			encoded, err := ReadFrame(reader)
+			if err != nil {
+				utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_READING_ASTM_MESSAGE, loggingAttributes, err)
+				return
+			}
+			frame, err := ParseFrame(encoded)
+			if err != nil {
+				utils.AddLog(ctx, constants.WARN_LEVEL, constants.ERROR_INVALID_ASTM_FRAME, loggingAttributes, err)
+				break
+			}
+			switch frame.Number {
+			case expectedFrameNumber:
+				text = append(text, frame.Text...)
+				expectedFrameNumber = (expectedFrameNumber + 1) % 8
+				reply = constants.ASTM_ACK
+			case (expectedFrameNumber + 7) % 8:
+				reply = constants.ASTM_ACK
+			default:
+				utils.AddLog(ctx, constants.WARN_LEVEL, constants.ERROR_ASTM_FRAME_NUMBER_MISMATCH, loggingAttributes,
+					fmt.Errorf("expected %d, got %d", expectedFrameNumber, frame.Number))
+			}
+		case constants.ASTM_EOT:
+			if inTransfer && len(text) > 0 {
+				handleMessage(ctx, string(text), handler, loggingAttributes)
+			}
+			inTransfer, text = false, []byte{}
+		}
+
+		if reply == 0 {
+			continue
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(getTimeout(constants.AstmReceiveTimeout)))
+		if _, err = conn.Write([]byte{reply}); err != nil {
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes, err)
+			return
+		}
+	}
+}
+
+func handleMessage(ctx context.Context, text string, handler MessageHandler, loggingAttributes map[string]interface{}) {
+	message, err := ParseMessage(text)
+	if err != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_INVALID_ASTM_MESSAGE, loggingAttributes, err)
+		return
+	}
+
+	err = handler(ctx, message)
+	if err != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
+			"sender": message.SenderName(),
+		}, err)
+	}
+}
+
+// SendMessage runs the sender side of the link protocol against address: ENQ, one frame at a time with up to
+// six attempts each, then EOT.
+func SendMessage(ctx context.Context, address string, message Message) error {
+	dialer := net.Dialer{Timeout: getTimeout(constants.AstmReceiveTimeout)}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply, err := exchange(conn, reader, []byte{constants.ASTM_ENQ})
+	if err != nil {
+		return err
+	}
+	if reply != constants.ASTM_ACK {
+		return fmt.Errorf("%s: receiver did not accept ENQ", constants.ERROR_ASTM_MESSAGE_NOT_ACKNOWLEDGED)
+	}
+
+	for _, frame := range BuildFrames(message) {
+		acknowledged := false
+		for attempt := 0; attempt < constants.AstmMaxSendAttempts && !acknowledged; attempt++ {
+			reply, err = exchange(conn, reader, frame.Bytes())
+			if err != nil {
+				return err
+			}
+			acknowledged = reply == constants.ASTM_ACK
+		}
+		if !acknowledged {
+			_, _ = conn.Write([]byte{constants.ASTM_EOT})
+			return fmt.Errorf("%s: frame %d", constants.ERROR_ASTM_MESSAGE_NOT_ACKNOWLEDGED, frame.Number)
+		}
+	}
+
+	_, err = conn.Write([]byte{constants.ASTM_EOT})
+	return err
+}
+
+func exchange(conn net.Conn, reader *bufio.Reader, payload []byte) (byte, error) {
+	timeout := getTimeout(constants.AstmReceiveTimeout)
+	_ = conn.SetWriteDeadline(time.Now().Add(timeout))
+	if _, err := conn.Write(payload); err != nil {
+		return 0, err
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	return reader.ReadByte()
+}
+
+func getTimeout(timeoutInSec int) time.Duration {
+	if timeoutInSec <= 0 {
+		timeoutInSec = constants.AstmDefaultTimeoutInSec
+	}
+	return time.Duration(timeoutInSec) * time.Second
+}
@@ -0,0 +1,140 @@
+package astmClient
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+// startListener runs the receiver on a free local port and returns its address and the messages it handled.
+func startListener(t *testing.T) (string, chan Message) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+
+	messages := make(chan Message, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = ListenAndServe(ctx, address, func(ctx context.Context, message Message) error {
+			messages <- message
+			return nil
+		})
+	}()
+
+	assert.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	return address, messages
+}
+
+func receiveMessage(t *testing.T, messages chan Message) Message {
+	select {
+	case message := <-messages:
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not hand over the message")
+		return Message{}
+	}
+}
+
+func exchangeByte(t *testing.T, conn net.Conn, reader *bufio.Reader, payload []byte) byte {
+	reply, err := exchange(conn, reader, payload)
+	assert.NoError(t, err)
+	return reply
+}
+
+func TestSendMessageToListener(t *testing.T) {
+	address, messages := startListener(t)
+	message, err := ParseMessage(testAstmMessage)
+	assert.NoError(t, err)
+
+	err = SendMessage(context.Background(), address, message)
+	assert.NoError(t, err)
+
+	received := receiveMessage(t, messages)
+	assert.Equal(t, message.Records, received.Records)
+	assert.Equal(t, "ANALYZER-1", received.SenderName())
+}
+
+func TestListenerLinkProtocol(t *testing.T) {
+	address, messages := startListener(t)
+	conn, err := net.Dial("tcp", address)
+	assert.NoError(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	header := Frame{Number: 1, Text: "H|\\^&|||SENDER\r", IsFinal: true}.Bytes()
+	corrupted := append([]byte{}, header...)
+	corrupted[len(corrupted)-3] = '0'
+	terminator := Frame{Number: 2, Text: "L|1|N\r", IsFinal: true}.Bytes()
+
+	// frames outside a transfer are ignored, ENQ opens one
+	_, err = conn.Write(header)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(constants.ASTM_ACK), exchangeByte(t, conn, reader, []byte{constants.ASTM_ENQ}))
+
+	assert.Equal(t, byte(constants.ASTM_NAK), exchangeByte(t, conn, reader, corrupted))
+	assert.Equal(t, byte(constants.ASTM_NAK), exchangeByte(t, conn, reader, terminator))
+	assert.Equal(t, byte(constants.ASTM_ACK), exchangeByte(t, conn, reader, header))
+	// a repeated frame whose ACK was lost is acknowledged again but not appended
+	assert.Equal(t, byte(constants.ASTM_ACK), exchangeByte(t, conn, reader, header))
+	assert.Equal(t, byte(constants.ASTM_ACK), exchangeByte(t, conn, reader, terminator))
+
+	_, err = conn.Write([]byte{constants.ASTM_EOT})
+	assert.NoError(t, err)
+
+	received := receiveMessage(t, messages)
+	assert.Len(t, received.Records, 2)
+	assert.Equal(t, "SENDER", received.SenderName())
+	assert.Equal(t, constants.ASTM_RECORD_TERMINATOR, received.Records[1].Type())
+}
+
+func TestSendMessageWithoutAcknowledgement(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	// a receiver that accepts ENQ and rejects every frame
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			controlByte, err := reader.ReadByte()
+			if err != nil || controlByte == constants.ASTM_EOT {
+				return
+			}
+			reply := byte(constants.ASTM_ACK)
+			if controlByte == constants.ASTM_STX {
+				if _, err = ReadFrame(reader); err != nil {
+					return
+				}
+				reply = constants.ASTM_NAK
+			}
+			if _, err = conn.Write([]byte{reply}); err != nil {
+				return
+			}
+		}
+	}()
+
+	message, err := ParseMessage(testAstmMessage)
+	assert.NoError(t, err)
+	err = SendMessage(context.Background(), listener.Addr().String(), message)
+	assert.ErrorContains(t, err, constants.ERROR_ASTM_MESSAGE_NOT_ACKNOWLEDGED)
+}
@@ -0,0 +1,182 @@
+package astmClient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+const recordSeparator = "\r"
+
+// Delimiters are declared by the sender in H-2, in the order field, repeat, component, escape.
+type Delimiters struct {
+	Field     string
+	Repeat    string
+	Component string
+	Escape    string
+}
+
+var DefaultDelimiters = Delimiters{
+	Field:     "|",
+	Repeat:    `\`,
+	Component: "^",
+	Escape:    "&",
+}
+
+// Record holds the fields of one ASTM record, with the record type at index 0.
+type Record []string
+
+type Message struct {
+	Delimiters Delimiters
+	Records    []Record
+}
+
+func NewRecord(recordType string, fields ...string) Record {
+	return append(Record{recordType}, fields...)
+}
+
+func (record Record) Type() string {
+	if len(record) == 0 {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(record[0]))
+}
+
+// Field returns the raw value of a field using ASTM numbering (R-4 is Field(4)).
+func (record Record) Field(index int) string {
+	if index <= 0 || index > len(record) {
+		return ""
+	}
+	return record[index-1]
+}
+
+// Component returns the unescaped component of the first repetition of a field, numbered from 1.
+func (record Record) Component(delimiters Delimiters, index, component int) string {
+	field := strings.Split(record.Field(index), delimiters.Repeat)[0]
+	components := strings.Split(field, delimiters.Component)
+	if component <= 0 || component > len(components) {
+		return ""
+	}
+	return delimiters.UnescapeText(components[component-1])
+}
+
+// Value returns the first component of a field.
+func (record Record) Value(delimiters Delimiters, index int) string {
+	return strings.TrimSpace(record.Component(delimiters, index, 1))
+}
+
+func (record Record) String(delimiters Delimiters) string {
+	return strings.Join(record, delimiters.Field)
+}
+
+func (delimiters Delimiters) String() string {
+	return delimiters.Repeat + delimiters.Component + delimiters.Escape
+}
+
+func (delimiters Delimiters) EscapeText(value string) string {
+	escape := delimiters.Escape
+	return strings.NewReplacer(
+		escape, escape+"E"+escape,
+		delimiters.Field, escape+"F"+escape,
+		delimiters.Component, escape+"S"+escape,
+		delimiters.Repeat, escape+"R"+escape,
+	).Replace(value)
+}
+
+func (delimiters Delimiters) UnescapeText(value string) string {
+	escape := delimiters.Escape
+	if !strings.Contains(value, escape) {
+		return value
+	}
+	return strings.NewReplacer(
+		escape+"F"+escape, delimiters.Field,
+		escape+"S"+escape, delimiters.Component,
+		escape+"R"+escape, delimiters.Repeat,
+		escape+"E"+escape, escape,
+	).Replace(value)
+}
+
+// NewHeader builds the H record, which carries the delimiter definition in H-2 and the sender in H-5.
+func NewHeader(senderName string) Record {
+	return NewRecord(constants.ASTM_RECORD_HEADER, DefaultDelimiters.String(), "", "", senderName, "", "", "", "",
+		"", "", "P", "1", FormatTime(time.Now()))
+}
+
+func NewTerminator() Record {
+	return NewRecord(constants.ASTM_RECORD_TERMINATOR, "1", "N")
+}
+
+func (message Message) GetRecords(recordType string) []Record {
+	records := []Record{}
+	for _, record := range message.Records {
+		if record.Type() == recordType {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func (message Message) Header() Record {
+	headers := message.GetRecords(constants.ASTM_RECORD_HEADER)
+	if len(headers) == 0 {
+		return Record{}
+	}
+	return headers[0]
+}
+
+// SenderName returns the first component of H-5, which analyzers fill with their model or id.
+func (message Message) SenderName() string {
+	return message.Header().Value(message.Delimiters, 5)
+}
+
+// String returns the records of the message, each terminated by a carriage return.
+func (message Message) String() string {
+	var builder strings.Builder
+	for _, record := range message.Records {
+		builder.WriteString(record.String(message.Delimiters))
+		builder.WriteString(recordSeparator)
+	}
+	return builder.String()
+}
+
+// ParseMessage splits the text of a transfer into records. The delimiters are taken from the H record.
+func ParseMessage(text string) (Message, error) {
+	text = strings.ReplaceAll(text, "\n", "")
+	if !strings.HasPrefix(text, constants.ASTM_RECORD_HEADER) || len(text) < 5 {
+		return Message{}, fmt.Errorf("%s: message does not start with a header record", constants.ERROR_INVALID_ASTM_MESSAGE)
+	}
+
+	delimiters := Delimiters{
+		Field:     text[1:2],
+		Repeat:    text[2:3],
+		Component: text[3:4],
+		Escape:    text[4:5],
+	}
+
+	message := Message{Delimiters: delimiters}
+	for _, line := range strings.Split(text, recordSeparator) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		message.Records = append(message.Records, Record(strings.Split(line, delimiters.Field)))
+	}
+	return message, nil
+}
+
+func FormatTime(t time.Time) string {
+	return t.Format(constants.ASTM_DATE_TIME_LAYOUT)
+}
+
+// ParseTime accepts any prefix of YYYYMMDDHHMMSS down to the date.
+func ParseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > len(constants.ASTM_DATE_TIME_LAYOUT) {
+		value = value[:len(constants.ASTM_DATE_TIME_LAYOUT)]
+	}
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("%s: invalid date time %q", constants.ERROR_INVALID_ASTM_MESSAGE, value)
+	}
+	return time.ParseInLocation(constants.ASTM_DATE_TIME_LAYOUT[:len(value)], value, time.Local)
+}
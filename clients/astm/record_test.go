@@ -0,0 +1,111 @@
+package astmClient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+const testAstmMessage = "H|\\^&|||ANALYZER-1^1.0|||||||P|1|20261018103000\r" +
+	"P|1\r" +
+	"O|1|BC001||^^^GLU\\^^^CREA|R\r" +
+	"R|1|^^^GLU|98|mg/dL||N||F||||20261018101500\r" +
+	"R|2|^^^CREA|1.4|mg/dL||H||P||||20261018101500\r" +
+	"R|3|^^^UREA|30|mg/dL||N||F\r" +
+	"O|2||BC002|^^^HB|R\r" +
+	"R|1|HB|13&F&5|g/dL||N||C\r" +
+	"O|3|BC003||^^^K|R\r" +
+	"R|1|^^^K||||||X\r" +
+	"L|1|N\r"
+
+func TestParseMessage(t *testing.T) {
+	message, err := ParseMessage(testAstmMessage)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultDelimiters, message.Delimiters)
+	assert.Len(t, message.Records, 11)
+	assert.Equal(t, "ANALYZER-1", message.SenderName())
+	assert.Len(t, message.GetRecords(constants.ASTM_RECORD_ORDER), 3)
+
+	order := message.GetRecords(constants.ASTM_RECORD_ORDER)[0]
+	assert.Equal(t, "BC001", order.Value(message.Delimiters, 3))
+	assert.Equal(t, "GLU", order.Component(message.Delimiters, 5, 4))
+	assert.Equal(t, "", order.Field(20))
+}
+
+func TestParseMessageWithCustomDelimiters(t *testing.T) {
+	message, err := ParseMessage("H!@#$!!!SENDER#X\rO!1!BC001!!##ALT@##AST\rL!1\r")
+	assert.NoError(t, err)
+	assert.Equal(t, Delimiters{Field: "!", Repeat: "@", Component: "#", Escape: "$"}, message.Delimiters)
+	assert.Equal(t, "SENDER", message.SenderName())
+	assert.Equal(t, "ALT", message.Records[1].Component(message.Delimiters, 5, 3))
+	assert.Equal(t, "L", message.Records[2].Type())
+}
+
+func TestParseMessageWithoutHeader(t *testing.T) {
+	_, err := ParseMessage("P|1\rL|1\r")
+	assert.ErrorContains(t, err, constants.ERROR_INVALID_ASTM_MESSAGE)
+}
+
+func TestEscapeText(t *testing.T) {
+	value := `a|b\c^d&e`
+	escaped := DefaultDelimiters.EscapeText(value)
+	assert.Equal(t, `a&F&b&R&c&S&d&E&e`, escaped)
+	assert.Equal(t, value, DefaultDelimiters.UnescapeText(escaped))
+}
+
+func TestParseTime(t *testing.T) {
+	parsedTime, err := ParseTime("20261018103015")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 10, 30, 15, 0, time.Local), parsedTime)
+
+	parsedTime, err = ParseTime("20261018")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local), parsedTime)
+
+	_, err = ParseTime("202610")
+	assert.Error(t, err)
+}
+
+func TestConvertMessageToDeviceResults(t *testing.T) {
+	message, err := ParseMessage(testAstmMessage)
+	assert.NoError(t, err)
+
+	deviceSampleResults, err := ConvertMessageToDeviceResults(message)
+	assert.NoError(t, err)
+	assert.Len(t, deviceSampleResults, 2)
+
+	first := deviceSampleResults[0]
+	assert.Equal(t, "BC001", first.Barcode)
+	assert.Equal(t, "ANALYZER-1", first.DeviceId)
+	assert.Len(t, first.Results, 2)
+	assert.Equal(t, "GLU", first.Results[0].TestCode)
+	assert.Equal(t, "98", first.Results[0].Value)
+	assert.Equal(t, "mg/dL", first.Results[0].Unit)
+	assert.Equal(t, "N", first.Results[0].AbnormalFlag)
+	assert.Equal(t, constants.ASTM_RESULT_STATUS_FINAL, first.Results[0].ResultStatus)
+	assert.Equal(t, time.Date(2026, 10, 18, 10, 15, 0, 0, time.Local).Format(time.RFC3339),
+		first.Results[0].CompletedAt)
+	assert.Equal(t, "UREA", first.Results[1].TestCode)
+	assert.Equal(t, "", first.Results[1].CompletedAt)
+
+	second := deviceSampleResults[1]
+	assert.Equal(t, "BC002", second.Barcode)
+	assert.Len(t, second.Results, 1)
+	assert.Equal(t, "HB", second.Results[0].TestCode)
+	assert.Equal(t, "13|5", second.Results[0].Value)
+}
+
+func TestConvertMessageToDeviceResultsErrors(t *testing.T) {
+	message, err := ParseMessage("H|\\^&\rO|1|||^^^GLU\rL|1\r")
+	assert.NoError(t, err)
+	_, err = ConvertMessageToDeviceResults(message)
+	assert.ErrorContains(t, err, constants.ERROR_INVALID_ASTM_MESSAGE)
+
+	message, err = ParseMessage("H|\\^&\rR|1|^^^GLU|98\rL|1\r")
+	assert.NoError(t, err)
+	_, err = ConvertMessageToDeviceResults(message)
+	assert.ErrorContains(t, err, constants.ERROR_INVALID_ASTM_MESSAGE)
+}
@@ -0,0 +1,78 @@
+package astmClient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+	"github.com/Orange-Health/citadel/common/utils"
+)
+
+// ConvertMessageToDeviceResults groups the R records of a message under the specimen of their O record.
+// The specimen id (O-3, falling back to the instrument specimen id in O-4) is the sample barcode.
+// Preliminary and cancelled results are dropped.
+func ConvertMessageToDeviceResults(message Message) ([]structures.DeviceSampleResults, error) {
+	delimiters := message.Delimiters
+	deviceId := message.SenderName()
+
+	deviceSampleResults := []structures.DeviceSampleResults{}
+	var current *structures.DeviceSampleResults
+	for _, record := range message.Records {
+		switch record.Type() {
+		case constants.ASTM_RECORD_ORDER:
+			barcode := utils.GetNonEmptyString(record.Value(delimiters, 3), record.Value(delimiters, 4))
+			if barcode == "" {
+				return nil, fmt.Errorf("%s: order record without specimen id", constants.ERROR_INVALID_ASTM_MESSAGE)
+			}
+			deviceSampleResults = append(deviceSampleResults, structures.DeviceSampleResults{
+				Barcode:  barcode,
+				DeviceId: deviceId,
+				Results:  []structures.DeviceResult{},
+			})
+			current = &deviceSampleResults[len(deviceSampleResults)-1]
+		case constants.ASTM_RECORD_RESULT:
+			if current == nil {
+				return nil, fmt.Errorf("%s: result record before order record", constants.ERROR_INVALID_ASTM_MESSAGE)
+			}
+			deviceResult := convertResultRecord(delimiters, record)
+			if deviceResult.TestCode == "" ||
+				!utils.SliceContainsString(constants.AstmAcceptedResultStatuses, deviceResult.ResultStatus) {
+				continue
+			}
+			current.Results = append(current.Results, deviceResult)
+		}
+	}
+
+	sampleResults := []structures.DeviceSampleResults{}
+	for _, deviceSampleResult := range deviceSampleResults {
+		if len(deviceSampleResult.Results) > 0 {
+			sampleResults = append(sampleResults, deviceSampleResult)
+		}
+	}
+	return sampleResults, nil
+}
+
+// convertResultRecord reads the test code from the manufacturer's local code in R-3 (^^^code), falling back
+// to its first non-empty component.
+func convertResultRecord(delimiters Delimiters, record Record) structures.DeviceResult {
+	testCode := record.Component(delimiters, 3, 4)
+	for component := 1; testCode == "" && component <= 3; component++ {
+		testCode = record.Component(delimiters, 3, component)
+	}
+
+	completedAt := ""
+	if parsedTime, err := ParseTime(record.Value(delimiters, 13)); err == nil {
+		completedAt = parsedTime.Format(time.RFC3339)
+	}
+
+	return structures.DeviceResult{
+		TestCode:     strings.TrimSpace(testCode),
+		Value:        record.Value(delimiters, 4),
+		Unit:         record.Value(delimiters, 5),
+		AbnormalFlag: record.Value(delimiters, 7),
+		ResultStatus: strings.ToUpper(record.Value(delimiters, 9)),
+		CompletedAt:  completedAt,
+	}
+}
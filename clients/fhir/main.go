@@ -0,0 +1,82 @@
+package fhirClient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/clients"
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+	"github.com/Orange-Health/citadel/common/utils"
+)
+
+type FhirClient struct {
+	ApiClient *clients.ApiClient
+	Sentry    sentry.SentryLayer
+	// Endpoints maps a partner id to the base url of its FHIR server.
+	Endpoints map[string]string
+	Tokens    map[string]string
+}
+
+type FhirClientInterface interface {
+	IsPartnerConfigured(partnerId uint) bool
+	PushBundle(ctx context.Context, partnerId uint, bundle structures.FhirBundle) *structures.CommonError
+}
+
+func NewClient() *FhirClient {
+	return &FhirClient{
+		ApiClient: clients.NewClient(),
+		Sentry:    sentry.InitializeSentry(),
+		Endpoints: constants.FhirPartnerEndpoints,
+		Tokens:    constants.FhirPartnerTokens,
+	}
+}
+
+func InitializeFhirClient() FhirClientInterface {
+	return NewClient()
+}
+
+func (fhirClient *FhirClient) IsPartnerConfigured(partnerId uint) bool {
+	return fhirClient.Endpoints[fmt.Sprint(partnerId)] != ""
+}
+
+// PushBundle posts a transaction bundle to the base url of the partner's FHIR server.
+func (fhirClient *FhirClient) PushBundle(ctx context.Context, partnerId uint,
+	bundle structures.FhirBundle) *structures.CommonError {
+
+	endpoint := fhirClient.Endpoints[fmt.Sprint(partnerId)]
+	if endpoint == "" {
+		return &structures.CommonError{
+			Message:    constants.ERROR_FHIR_PARTNER_ENDPOINT_NOT_FOUND,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	headers := map[string]string{
+		"Content-Type": constants.FHIR_CONTENT_TYPE,
+		"Accept":       constants.FHIR_CONTENT_TYPE,
+	}
+	if token := fhirClient.Tokens[fmt.Sprint(partnerId)]; token != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", token)
+	}
+
+	var response interface{}
+	err := fhirClient.ApiClient.Post(ctx, &response, endpoint, nil, bundle, headers, constants.FhirPushRetries,
+		constants.FhirPushDelay)
+	if err != nil {
+		loggingAttributes := map[string]interface{}{
+			"partner_id": partnerId,
+			"endpoint":   endpoint,
+		}
+		utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_PUSHING_FHIR_BUNDLE, loggingAttributes, err)
+		fhirClient.Sentry.LogError(ctx, constants.ERROR_WHILE_PUSHING_FHIR_BUNDLE, err, loggingAttributes)
+		return &structures.CommonError{
+			Message:    constants.ERROR_WHILE_PUSHING_FHIR_BUNDLE,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package hl7Client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+	"github.com/Orange-Health/citadel/common/utils"
+)
+
+type Hl7Client struct {
+	Sentry sentry.SentryLayer
+	// Endpoints maps a lab id to the host:port of its MLLP receiver.
+	Endpoints map[string]string
+}
+
+type Hl7ClientInterface interface {
+	SendMessage(ctx context.Context, labId uint, message Message) *structures.CommonError
+}
+
+func NewClient() *Hl7Client {
+	return &Hl7Client{
+		Sentry:    sentry.InitializeSentry(),
+		Endpoints: constants.Hl7Endpoints,
+	}
+}
+
+func InitializeHl7Client() Hl7ClientInterface {
+	return NewClient()
+}
+
+// SendMessage delivers message to the lab's MLLP endpoint and waits for its ACK. Transport failures and AE
+// acknowledgements are retried, AR acknowledgements are not.
+func (hl7Client *Hl7Client) SendMessage(ctx context.Context, labId uint, message Message) *structures.CommonError {
+	endpoint, ok := hl7Client.Endpoints[fmt.Sprint(labId)]
+	if !ok || endpoint == "" {
+		return &structures.CommonError{
+			Message:    constants.ERROR_HL7_ENDPOINT_NOT_CONFIGURED,
+			StatusCode: http.StatusInternalServerError,
+		}
+	}
+
+	loggingAttributes := map[string]interface{}{
+		"lab_id":       labId,
+		"endpoint":     endpoint,
+		"message_type": message.MessageType(),
+		"control_id":   message.ControlId(),
+	}
+
+	retries := constants.Hl7SendRetries
+	if retries <= 0 {
+		retries = 1
+	}
+
+	var err error
+	for attempt := range retries {
+		if attempt > 0 {
+			time.Sleep(utils.GetExponentialBackoff(attempt-1, constants.Hl7SendBackoffTime))
+		}
+
+		var ack Message
+		ack, err = hl7Client.exchange(ctx, endpoint, message)
+		if err != nil {
+			loggingAttributes["attempt"] = attempt + 1
+			utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_SENDING_HL7_MESSAGE, loggingAttributes, err)
+			continue
+		}
+
+		ackCode := ack.GetSegment("MSA").Value(1)
+		ackText := ack.GetSegment("MSA").Value(3)
+		switch ackCode {
+		case constants.HL7_ACK_ACCEPT:
+			utils.AddLog(ctx, constants.DEBUG_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes, nil)
+			return nil
+		case constants.HL7_ACK_REJECT:
+			loggingAttributes["ack_text"] = ackText
+			hl7Client.Sentry.LogError(ctx, constants.ERROR_HL7_MESSAGE_REJECTED, nil, loggingAttributes)
+			return &structures.CommonError{
+				Message:    constants.ERROR_HL7_MESSAGE_REJECTED,
+				StatusCode: http.StatusUnprocessableEntity,
+			}
+		default:
+			err = fmt.Errorf("%s: %s %s", constants.ERROR_HL7_MESSAGE_REJECTED, ackCode, ackText)
+			loggingAttributes["attempt"] = attempt + 1
+			utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_SENDING_HL7_MESSAGE, loggingAttributes, err)
+		}
+	}
+
+	hl7Client.Sentry.LogError(ctx, constants.ERROR_WHILE_SENDING_HL7_MESSAGE, err, loggingAttributes)
+	return &structures.CommonError{
+		Message:    constants.ERROR_WHILE_SENDING_HL7_MESSAGE,
+		StatusCode: http.StatusInternalServerError,
+	}
+}
+
+func (hl7Client *Hl7Client) exchange(ctx context.Context, endpoint string, message Message) (Message, error) {
+	dialer := net.Dialer{Timeout: getTimeout(constants.Hl7ConnectTimeout)}
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return Message{}, err
+	}
+	defer conn.Close()
+
+	err = conn.SetDeadline(time.Now().Add(getTimeout(constants.Hl7AckTimeout)))
+	if err != nil {
+		return Message{}, err
+	}
+
+	err = WriteFrame(conn, []byte(message.String()))
+	if err != nil {
+		return Message{}, err
+	}
+
+	payload, err := ReadFrame(bufio.NewReader(conn))
+	if err != nil {
+		return Message{}, err
+	}
+
+	ack, err := ParseMessage(string(payload))
+	if err != nil {
+		return Message{}, err
+	}
+	if acknowledgedControlId := ack.GetSegment("MSA").Value(2); acknowledgedControlId != message.ControlId() {
+		return Message{}, errors.New(constants.ERROR_INVALID_HL7_MESSAGE + ": ack control id " +
+			acknowledgedControlId + " does not match " + message.ControlId())
+	}
+	return ack, nil
+}
@@ -0,0 +1,195 @@
+package hl7Client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+const (
+	segmentSeparator    = "\r"
+	fieldSeparator      = "|"
+	componentSeparator  = "^"
+	repetitionSeparator = "~"
+	escapeCharacter     = `\`
+	encodingCharacters  = `^~\&`
+)
+
+var (
+	hl7Escaper = strings.NewReplacer(
+		`\`, `\E\`,
+		"|", `\F\`,
+		"^", `\S\`,
+		"&", `\T\`,
+		"~", `\R\`,
+	)
+	hl7Unescaper = strings.NewReplacer(
+		`\F\`, "|",
+		`\S\`, "^",
+		`\T\`, "&",
+		`\R\`, "~",
+		`\E\`, `\`,
+		`\.br\`, "\n",
+	)
+)
+
+// Segment holds the fields of one HL7 segment, with the segment name at index 0.
+type Segment []string
+
+type Message struct {
+	Segments []Segment
+}
+
+func NewSegment(name string, fields ...string) Segment {
+	return append(Segment{name}, fields...)
+}
+
+func (segment Segment) Name() string {
+	if len(segment) == 0 {
+		return ""
+	}
+	return segment[0]
+}
+
+// Field returns the raw value of a field using HL7 numbering (PID-3 is Field(3)).
+// MSH-1 is the field separator itself, so MSH fields are shifted by one.
+func (segment Segment) Field(index int) string {
+	if segment.Name() == "MSH" {
+		index--
+	}
+	if index <= 0 || index >= len(segment) {
+		return ""
+	}
+	return segment[index]
+}
+
+// Component returns the unescaped component of the first repetition of a field, numbered from 1.
+func (segment Segment) Component(index, component int) string {
+	field := strings.Split(segment.Field(index), repetitionSeparator)[0]
+	components := strings.Split(field, componentSeparator)
+	if component <= 0 || component > len(components) {
+		return ""
+	}
+	return Unescape(components[component-1])
+}
+
+// Value returns the first component of a field.
+func (segment Segment) Value(index int) string {
+	return segment.Component(index, 1)
+}
+
+func (message Message) GetSegment(name string) Segment {
+	for _, segment := range message.Segments {
+		if segment.Name() == name {
+			return segment
+		}
+	}
+	return Segment{}
+}
+
+func (message Message) Header() Segment {
+	return message.GetSegment("MSH")
+}
+
+// MessageType returns MSH-9 as "type^trigger", e.g. "ORU^R01".
+func (message Message) MessageType() string {
+	header := message.Header()
+	messageType := header.Component(9, 1)
+	if trigger := header.Component(9, 2); trigger != "" {
+		messageType = messageType + componentSeparator + trigger
+	}
+	return messageType
+}
+
+func (message Message) ControlId() string {
+	return message.Header().Value(10)
+}
+
+func (message Message) String() string {
+	segments := make([]string, 0, len(message.Segments))
+	for _, segment := range message.Segments {
+		segments = append(segments, strings.Join(segment, fieldSeparator))
+	}
+	return strings.Join(segments, segmentSeparator) + segmentSeparator
+}
+
+func ParseMessage(rawMessage string) (Message, error) {
+	rawMessage = strings.ReplaceAll(rawMessage, "\r\n", segmentSeparator)
+	rawMessage = strings.ReplaceAll(rawMessage, "\n", segmentSeparator)
+
+	message := Message{}
+	for _, rawSegment := range strings.Split(rawMessage, segmentSeparator) {
+		rawSegment = strings.TrimSpace(rawSegment)
+		if rawSegment == "" {
+			continue
+		}
+		message.Segments = append(message.Segments, strings.Split(rawSegment, fieldSeparator))
+	}
+
+	if len(message.Segments) == 0 || message.Segments[0].Name() != "MSH" {
+		return Message{}, fmt.Errorf("%s: message must start with MSH", constants.ERROR_INVALID_HL7_MESSAGE)
+	}
+	if message.Segments[0].Field(2) != encodingCharacters {
+		return Message{}, fmt.Errorf("%s: unsupported encoding characters %q", constants.ERROR_INVALID_HL7_MESSAGE,
+			message.Segments[0].Field(2))
+	}
+	return message, nil
+}
+
+func Escape(value string) string {
+	return hl7Escaper.Replace(value)
+}
+
+func Unescape(value string) string {
+	if !strings.Contains(value, escapeCharacter) {
+		return value
+	}
+	return hl7Unescaper.Replace(value)
+}
+
+// NewHeader builds an MSH segment addressed to the given receiver.
+func NewHeader(messageType, receivingApplication, receivingFacility string) Segment {
+	return NewSegment("MSH",
+		encodingCharacters,
+		Escape(constants.Hl7SendingApplication),
+		Escape(constants.Hl7SendingFacility),
+		receivingApplication,
+		receivingFacility,
+		FormatTime(time.Now()),
+		"",
+		messageType,
+		NewControlId(),
+		constants.HL7_PROCESSING_ID,
+		constants.HL7_VERSION,
+	)
+}
+
+func NewControlId() string {
+	return strings.ReplaceAll(uuid.NewString(), "-", "")[:20]
+}
+
+func FormatTime(value time.Time) string {
+	loc, _ := time.LoadLocation(constants.LocalTimeZoneLocation)
+	return value.In(loc).Format(constants.HL7_DATE_TIME_LAYOUT)
+}
+
+// ParseTime parses an HL7 TS value. Precision below seconds and timezone offsets are ignored.
+func ParseTime(value string) *time.Time {
+	if len(value) > len(constants.HL7_DATE_TIME_LAYOUT) {
+		value = value[:len(constants.HL7_DATE_TIME_LAYOUT)]
+	}
+	if len(value) == 0 {
+		return nil
+	}
+	layout := constants.HL7_DATE_TIME_LAYOUT[:len(value)]
+	loc, _ := time.LoadLocation(constants.LocalTimeZoneLocation)
+	parsedTime, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return nil
+	}
+	return &parsedTime
+}
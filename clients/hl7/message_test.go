@@ -0,0 +1,51 @@
+package hl7Client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+func TestParseMessage(t *testing.T) {
+	message, err := ParseMessage("MSH|^~\\&|LIS|LAB|||20261018103000||ORU^R01|CTRL0001|P|2.5.1\n" +
+		"PID|1||P001||DOE^JANE\r\n" +
+		"OBX|1|ST|GLU^Glucose^L||9\\S\\8\r")
+	assert.NoError(t, err)
+	assert.Len(t, message.Segments, 3)
+	assert.Equal(t, constants.HL7_MESSAGE_TYPE_ORU_R01, message.MessageType())
+	assert.Equal(t, "CTRL0001", message.ControlId())
+	assert.Equal(t, "LIS", message.Header().Field(3))
+	assert.Equal(t, "JANE", message.GetSegment("PID").Component(5, 2))
+	assert.Equal(t, "Glucose", message.GetSegment("OBX").Component(3, 2))
+	assert.Equal(t, "9^8", message.GetSegment("OBX").Value(5))
+	assert.Equal(t, "", message.GetSegment("OBX").Field(20))
+}
+
+func TestParseMessageWithoutHeader(t *testing.T) {
+	_, err := ParseMessage("PID|1||P001\r")
+	assert.ErrorContains(t, err, constants.ERROR_INVALID_HL7_MESSAGE)
+
+	_, err = ParseMessage("MSH|^~|LIS\r")
+	assert.ErrorContains(t, err, constants.ERROR_INVALID_HL7_MESSAGE)
+}
+
+func TestEscape(t *testing.T) {
+	value := `a|b^c&d~e\f`
+	assert.Equal(t, `a\F\b\S\c\T\d\R\e\E\f`, Escape(value))
+	assert.Equal(t, value, Unescape(Escape(value)))
+}
+
+func TestParseTime(t *testing.T) {
+	parsedTime := ParseTime("20261018103000.123+0530")
+	assert.NotNil(t, parsedTime)
+	assert.Equal(t, "20261018103000", FormatTime(*parsedTime))
+
+	parsedTime = ParseTime("20261018")
+	assert.NotNil(t, parsedTime)
+	assert.Equal(t, 18, parsedTime.Day())
+
+	assert.Nil(t, ParseTime(""))
+	assert.Nil(t, ParseTime("2026AB"))
+}
@@ -0,0 +1,153 @@
+package hl7Client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/utils"
+)
+
+// MLLP frames every message as <VT> message <FS><CR>.
+const (
+	mllpStartBlock     = byte(0x0b)
+	mllpEndBlock       = byte(0x1c)
+	mllpCarriageReturn = byte(0x0d)
+)
+
+// MessageHandler processes one inbound message. A nil error is acknowledged with AA, an error with AE.
+type MessageHandler func(ctx context.Context, message Message) error
+
+func WriteFrame(writer io.Writer, payload []byte) error {
+	frame := make([]byte, 0, len(payload)+3)
+	frame = append(frame, mllpStartBlock)
+	frame = append(frame, payload...)
+	frame = append(frame, mllpEndBlock, mllpCarriageReturn)
+	_, err := writer.Write(frame)
+	return err
+}
+
+func ReadFrame(reader *bufio.Reader) ([]byte, error) {
+	for {
+		startByte, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if startByte == mllpStartBlock {
+			break
+		}
+	}
+
+	payload, err := reader.ReadBytes(mllpEndBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	trailer, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if trailer != mllpCarriageReturn {
+		return nil, fmt.Errorf("%s: missing MLLP trailer", constants.ERROR_INVALID_HL7_MESSAGE)
+	}
+	return payload[:len(payload)-1], nil
+}
+
+// ListenAndServe accepts MLLP connections on address and acknowledges every frame after passing it to handler.
+// It returns when ctx is cancelled.
+func ListenAndServe(ctx context.Context, address string, handler MessageHandler) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	utils.AddLog(ctx, constants.INFO_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
+		"address": address,
+	}, nil)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, err)
+			continue
+		}
+		go serveConnection(ctx, conn, handler)
+	}
+}
+
+func serveConnection(ctx context.Context, conn net.Conn, handler MessageHandler) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		payload, err := ReadFrame(reader)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_READING_HL7_MESSAGE,
+					map[string]interface{}{"remote_address": conn.RemoteAddr().String()}, err)
+			}
+			return
+		}
+
+		ack := handleFrame(ctx, payload, handler)
+		_ = conn.SetWriteDeadline(time.Now().Add(getTimeout(constants.Hl7AckTimeout)))
+		err = WriteFrame(conn, []byte(ack.String()))
+		if err != nil {
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, err)
+			return
+		}
+	}
+}
+
+func handleFrame(ctx context.Context, payload []byte, handler MessageHandler) Message {
+	message, err := ParseMessage(string(payload))
+	if err != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_INVALID_HL7_MESSAGE, nil, err)
+		return BuildAck(message, constants.HL7_ACK_REJECT, err.Error())
+	}
+
+	err = handler(ctx, message)
+	if err != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
+			"message_type": message.MessageType(),
+			"control_id":   message.ControlId(),
+		}, err)
+		return BuildAck(message, constants.HL7_ACK_ERROR, err.Error())
+	}
+	return BuildAck(message, constants.HL7_ACK_ACCEPT, "")
+}
+
+// BuildAck acknowledges message. The original sender becomes the receiver of the ACK.
+func BuildAck(message Message, ackCode, text string) Message {
+	header := message.Header()
+	messageType := constants.HL7_MESSAGE_TYPE_ACK
+	if trigger := header.Component(9, 2); trigger != "" {
+		messageType = messageType + componentSeparator + trigger
+	}
+
+	return Message{
+		Segments: []Segment{
+			NewHeader(messageType, header.Field(3), header.Field(4)),
+			NewSegment("MSA", ackCode, header.Field(10), Escape(text)),
+		},
+	}
+}
+
+func getTimeout(timeoutInSec int) time.Duration {
+	if timeoutInSec <= 0 {
+		timeoutInSec = constants.Hl7DefaultTimeoutInSec
+	}
+	return time.Duration(timeoutInSec) * time.Second
+}
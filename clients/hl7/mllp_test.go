@@ -0,0 +1,72 @@
+package hl7Client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+const testOrmMessage = "MSH|^~\\&|LIS|LAB|CITADEL|ORANGEHEALTH|20261018103000||ORM^O01|CTRL0001|P|2.5.1\r" +
+	"PID|1||P001||DOE^JANE\r"
+
+func TestWriteFrameAndReadFrame(t *testing.T) {
+	buffer := bytes.Buffer{}
+	assert.NoError(t, WriteFrame(&buffer, []byte("first")))
+	assert.NoError(t, WriteFrame(&buffer, []byte("second")))
+	assert.Equal(t, []byte("\x0bfirst\x1c\r\x0bsecond\x1c\r"), buffer.Bytes())
+
+	reader := bufio.NewReader(&buffer)
+	payload, err := ReadFrame(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", string(payload))
+	payload, err = ReadFrame(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(payload))
+}
+
+func TestReadFrameSkipsBytesBeforeStartBlock(t *testing.T) {
+	payload, err := ReadFrame(bufio.NewReader(strings.NewReader("\r\nnoise\x0bpayload\x1c\r")))
+	assert.NoError(t, err)
+	assert.Equal(t, "payload", string(payload))
+}
+
+func TestReadFrameWithoutTrailer(t *testing.T) {
+	_, err := ReadFrame(bufio.NewReader(strings.NewReader("\x0bpayload\x1cX")))
+	assert.ErrorContains(t, err, constants.ERROR_INVALID_HL7_MESSAGE)
+}
+
+func TestBuildAck(t *testing.T) {
+	message, err := ParseMessage(testOrmMessage)
+	assert.NoError(t, err)
+
+	ack := BuildAck(message, constants.HL7_ACK_ERROR, "bad|value")
+	assert.Equal(t, "ACK^O01", ack.MessageType())
+	assert.Equal(t, "LIS", ack.Header().Field(5))
+	assert.Equal(t, "LAB", ack.Header().Field(6))
+	assert.Equal(t, constants.HL7_ACK_ERROR, ack.GetSegment("MSA").Value(1))
+	assert.Equal(t, "CTRL0001", ack.GetSegment("MSA").Value(2))
+	assert.Equal(t, "bad|value", ack.GetSegment("MSA").Value(3))
+}
+
+func TestHandleFrame(t *testing.T) {
+	accept := func(ctx context.Context, message Message) error { return nil }
+	reject := func(ctx context.Context, message Message) error { return errors.New("handler failed") }
+
+	ack := handleFrame(context.Background(), []byte(testOrmMessage), accept)
+	assert.Equal(t, constants.HL7_ACK_ACCEPT, ack.GetSegment("MSA").Value(1))
+	assert.Equal(t, "CTRL0001", ack.GetSegment("MSA").Value(2))
+
+	ack = handleFrame(context.Background(), []byte(testOrmMessage), reject)
+	assert.Equal(t, constants.HL7_ACK_ERROR, ack.GetSegment("MSA").Value(1))
+	assert.Equal(t, "handler failed", ack.GetSegment("MSA").Value(3))
+
+	ack = handleFrame(context.Background(), []byte("PID|1"), accept)
+	assert.Equal(t, constants.HL7_ACK_REJECT, ack.GetSegment("MSA").Value(1))
+}
@@ -0,0 +1,94 @@
+package hl7Client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+)
+
+const (
+	hl7CodingSystemLocal   = "L"
+	hl7PatientClassOutside = "O"
+	hl7DateLayout          = "20060102"
+)
+
+// BuildOrmO01 converts a LIS order request into an ORM^O01 message with one ORC/OBR pair per test.
+// The LIS visit id is sent as the placer order number so that results can be matched back to the visit.
+func BuildOrmO01(orderControl string, request structures.LisOrderRequest) Message {
+	now := FormatTime(time.Now())
+	message := Message{
+		Segments: []Segment{
+			NewHeader(constants.HL7_MESSAGE_TYPE_ORM_O01, "", ""),
+			NewSegment("PID",
+				"1",
+				"",
+				Escape(request.PatientId),
+				"",
+				Escape(request.PatientName),
+				"",
+				getHl7Date(request.PatientDob),
+				getHl7Gender(request.PatientGender),
+			),
+			NewSegment("PV1",
+				"1",
+				hl7PatientClassOutside,
+				"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
+				Escape(request.VisitId),
+			),
+		},
+	}
+
+	collectedAt := ""
+	if request.CollectedAt != nil {
+		collectedAt = FormatTime(*request.CollectedAt)
+	}
+	for index, test := range request.Tests {
+		barcode := ""
+		if len(test.Barcodes) > 0 {
+			barcode = test.Barcodes[0]
+		}
+		universalServiceId := strings.Join([]string{Escape(test.TestCode), Escape(test.TestName),
+			hl7CodingSystemLocal}, componentSeparator)
+
+		message.Segments = append(message.Segments,
+			NewSegment("ORC",
+				orderControl,
+				Escape(request.VisitId),
+				Escape(barcode),
+				"", "", "", "", "",
+				now,
+			),
+			NewSegment("OBR",
+				fmt.Sprint(index+1),
+				Escape(request.VisitId),
+				Escape(barcode),
+				universalServiceId,
+				"", "",
+				collectedAt,
+			),
+		)
+	}
+	return message
+}
+
+func getHl7Gender(gender string) string {
+	switch strings.ToLower(gender) {
+	case "male":
+		return "M"
+	case "female":
+		return "F"
+	default:
+		return "U"
+	}
+}
+
+func getHl7Date(dob *time.Time) string {
+	if dob == nil {
+		return ""
+	}
+	loc, _ := time.LoadLocation(constants.LocalTimeZoneLocation)
+	return dob.In(loc).Format(hl7DateLayout)
+}
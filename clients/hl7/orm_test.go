@@ -0,0 +1,61 @@
+package hl7Client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+)
+
+func TestBuildOrmO01(t *testing.T) {
+	dob := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	collectedAt := time.Date(2026, 10, 18, 4, 30, 0, 0, time.UTC)
+	request := structures.LisOrderRequest{
+		VisitId:       "VISIT-1",
+		PatientId:     "P001",
+		PatientName:   "Doe^Jane",
+		PatientGender: "Female",
+		PatientDob:    &dob,
+		CollectedAt:   &collectedAt,
+		Tests: []structures.LisOrderTest{
+			{TestCode: "GLU", TestName: "Glucose", Barcodes: []string{"BC001"}},
+			{TestCode: "LFT", TestName: "Liver Function Test"},
+		},
+	}
+
+	message, err := ParseMessage(BuildOrmO01(constants.HL7_ORDER_CONTROL_NEW, request).String())
+	assert.NoError(t, err)
+	assert.Equal(t, constants.HL7_MESSAGE_TYPE_ORM_O01, message.MessageType())
+	assert.NotEmpty(t, message.ControlId())
+
+	pid := message.GetSegment("PID")
+	assert.Equal(t, "P001", pid.Value(3))
+	assert.Equal(t, "Doe^Jane", pid.Value(5))
+	assert.Equal(t, "F", pid.Value(8))
+	assert.Equal(t, "VISIT-1", message.GetSegment("PV1").Value(19))
+
+	orcSegments, obrSegments := []Segment{}, []Segment{}
+	for _, segment := range message.Segments {
+		switch segment.Name() {
+		case "ORC":
+			orcSegments = append(orcSegments, segment)
+		case "OBR":
+			obrSegments = append(obrSegments, segment)
+		}
+	}
+	assert.Len(t, orcSegments, 2)
+	assert.Len(t, obrSegments, 2)
+
+	assert.Equal(t, constants.HL7_ORDER_CONTROL_NEW, orcSegments[0].Value(1))
+	assert.Equal(t, "VISIT-1", orcSegments[0].Value(2))
+	assert.Equal(t, "BC001", obrSegments[0].Value(3))
+	assert.Equal(t, "GLU", obrSegments[0].Component(4, 1))
+	assert.Equal(t, "Glucose", obrSegments[0].Component(4, 2))
+	assert.Equal(t, FormatTime(collectedAt), obrSegments[0].Value(7))
+	assert.Equal(t, "2", obrSegments[1].Value(1))
+	assert.Equal(t, "", obrSegments[1].Value(3))
+	assert.Equal(t, "LFT", obrSegments[1].Component(4, 1))
+}
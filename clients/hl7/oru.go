@@ -0,0 +1,173 @@
+package hl7Client
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+)
+
+type oruObservation struct {
+	valueType string
+	result    structures.LisTestResult
+}
+
+type oruOrder struct {
+	visitId      string
+	barcode      string
+	testCode     string
+	testName     string
+	resultStatus string
+	observations []oruObservation
+}
+
+// ConvertOruR01ToLisOrders maps ORU^R01 results to LIS orders, keyed by LIS visit id
+// (the placer order number). OBX-3 becomes the investigation LisCode, OBX-6 the unit, OBX-7 the reference
+// range and OBX-8 the abnormality. Encapsulated PDF observations become the report PDF of the visit.
+// Preliminary results are dropped.
+func ConvertOruR01ToLisOrders(message Message) (map[string]structures.LisOrder, error) {
+	if message.MessageType() != constants.HL7_MESSAGE_TYPE_ORU_R01 {
+		return nil, errors.New(constants.ERROR_UNSUPPORTED_HL7_MESSAGE_TYPE + ": " + message.MessageType())
+	}
+
+	orders := []*oruOrder{}
+	var currentOrder *oruOrder
+	placerOrderNumber := ""
+	for _, segment := range message.Segments {
+		switch segment.Name() {
+		case "ORC":
+			placerOrderNumber = segment.Value(2)
+		case "OBR":
+			currentOrder = &oruOrder{
+				visitId:      getNonEmptyValue(segment.Value(2), placerOrderNumber),
+				barcode:      segment.Value(3),
+				testCode:     segment.Component(4, 1),
+				testName:     segment.Component(4, 2),
+				resultStatus: segment.Value(25),
+			}
+			if currentOrder.visitId == "" {
+				return nil, errors.New(constants.ERROR_HL7_PLACER_ORDER_NUMBER_EMPTY)
+			}
+			orders = append(orders, currentOrder)
+		case "OBX":
+			if currentOrder == nil {
+				return nil, errors.New(constants.ERROR_INVALID_HL7_MESSAGE + ": OBX before OBR")
+			}
+			currentOrder.observations = append(currentOrder.observations, getOruObservation(segment,
+				currentOrder.barcode))
+		case "NTE":
+			if currentOrder == nil || len(currentOrder.observations) == 0 {
+				continue
+			}
+			lastObservation := &currentOrder.observations[len(currentOrder.observations)-1].result
+			lastObservation.TechnicalRemarks = strings.TrimSpace(lastObservation.TechnicalRemarks + " " +
+				Unescape(segment.Field(3)))
+		}
+	}
+
+	lisOrders := map[string]structures.LisOrder{}
+	for _, order := range orders {
+		lisOrder, ok := lisOrders[order.visitId]
+		if !ok {
+			lisOrder = structures.LisOrder{VisitId: order.visitId}
+		}
+
+		results := []structures.LisTestResult{}
+		for _, observation := range order.observations {
+			if observation.valueType == constants.HL7_VALUE_TYPE_ENCAPSULATED_DATA {
+				lisOrder.ReportPdf = observation.result.TestValue
+				continue
+			}
+			results = append(results, observation.result)
+		}
+
+		testStatus := getTestStatus(order.resultStatus)
+		if testStatus != "" && len(results) > 0 {
+			lisOrder.Tests = append(lisOrder.Tests, getTestResult(order, results, testStatus))
+		}
+		lisOrders[order.visitId] = lisOrder
+	}
+	return lisOrders, nil
+}
+
+func getOruObservation(segment Segment, barcode string) oruObservation {
+	valueType := segment.Value(2)
+	value := Unescape(segment.Field(5))
+	if valueType == constants.HL7_VALUE_TYPE_ENCAPSULATED_DATA {
+		// ED is <source>^<type>^<subtype>^<encoding>^<data>
+		value = segment.Component(5, 5)
+	}
+
+	return oruObservation{
+		valueType: valueType,
+		result: structures.LisTestResult{
+			TestCode:         segment.Component(3, 1),
+			TestName:         segment.Component(3, 2),
+			TestType:         constants.InvestigationShortHand,
+			TestValue:        value,
+			Unit:             segment.Component(6, 1),
+			ReferenceRange:   Unescape(segment.Field(7)),
+			LisAbnormality:   constants.Hl7AbnormalFlagToAbnormality[strings.ToUpper(segment.Value(8))],
+			TestStatus:       getTestStatus(segment.Value(11)),
+			ResultCapturedAt: getResultCapturedAt(segment.Value(14)),
+			MethodName:       segment.Component(17, 2),
+			DeviceId:         segment.Value(18),
+			Barcode:          barcode,
+		},
+	}
+}
+
+// getTestResult returns a single investigation test when the only observation carries the ordered code,
+// otherwise a group whose observations are the panel parameters.
+func getTestResult(order *oruOrder, results []structures.LisTestResult,
+	testStatus string) structures.LisTestResult {
+	if len(results) == 1 && results[0].TestCode == order.testCode {
+		result := results[0]
+		result.TestName = getNonEmptyValue(order.testName, result.TestName)
+		result.Barcode = order.barcode
+		result.TestStatus = testStatus
+		return result
+	}
+
+	for index := range results {
+		if results[index].TestStatus == "" {
+			results[index].TestStatus = testStatus
+		}
+	}
+	return structures.LisTestResult{
+		TestCode:   order.testCode,
+		TestName:   order.testName,
+		TestType:   constants.GroupShortHand,
+		Barcode:    order.barcode,
+		TestStatus: testStatus,
+		Parameters: results,
+	}
+}
+
+func getTestStatus(resultStatus string) string {
+	switch resultStatus {
+	case constants.HL7_RESULT_STATUS_FINAL, constants.HL7_RESULT_STATUS_CORRECTED:
+		return constants.LIS_TEST_STATUS_COMPLETED
+	case constants.HL7_RESULT_STATUS_CANCELLED:
+		return constants.LIS_TEST_STATUS_CANCELLED
+	default:
+		return ""
+	}
+}
+
+func getResultCapturedAt(observedAt string) string {
+	parsedTime := ParseTime(observedAt)
+	if parsedTime == nil {
+		return time.Now().UTC().Format(constants.DateTimeUTCLayoutWithoutTZOffset)
+	}
+	return parsedTime.UTC().Format(constants.DateTimeUTCLayoutWithoutTZOffset)
+}
+
+func getNonEmptyValue(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
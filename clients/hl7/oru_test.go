@@ -0,0 +1,75 @@
+package hl7Client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+const testOruMessage = "MSH|^~\\&|LIS|LAB|CITADEL|ORANGEHEALTH|20261018103000||ORU^R01|CTRL0002|P|2.5.1\r" +
+	"PID|1||P001||DOE^JANE\r" +
+	"ORC|RE|VISIT-1|BC001\r" +
+	"OBR|1||BC001|GLU^Glucose^L|||||||||||||||||||||F\r" +
+	"OBX|1|NM|GLU^Glucose^L||98|mg/dL|70-100|N|||F|||20261018101500\r" +
+	"NTE|1||fasting sample\r" +
+	"OBR|2|VISIT-1|BC002|LFT^Liver Function Test^L|||||||||||||||||||||F\r" +
+	"OBX|1|NM|ALT^Alanine Transaminase^L||62|U/L|0-45|H|||F\r" +
+	"OBX|2|NM|AST^Aspartate Transaminase^L||30|U/L|0-35|N\r" +
+	"OBX|3|ED|PDF^Report^L||^AP^PDF^Base64^JVBERi0=\r" +
+	"OBR|3|VISIT-1|BC002|TSH^Thyroid Stimulating Hormone^L|||||||||||||||||||||P\r" +
+	"OBX|1|NM|TSH^Thyroid Stimulating Hormone^L||2.1|uIU/mL||||P\r"
+
+func TestConvertOruR01ToLisOrders(t *testing.T) {
+	message, err := ParseMessage(testOruMessage)
+	assert.NoError(t, err)
+
+	lisOrders, err := ConvertOruR01ToLisOrders(message)
+	assert.NoError(t, err)
+	assert.Len(t, lisOrders, 1)
+
+	lisOrder := lisOrders["VISIT-1"]
+	assert.Equal(t, "VISIT-1", lisOrder.VisitId)
+	assert.Equal(t, "JVBERi0=", lisOrder.ReportPdf)
+	assert.Len(t, lisOrder.Tests, 2)
+
+	glucose := lisOrder.Tests[0]
+	assert.Equal(t, "GLU", glucose.TestCode)
+	assert.Equal(t, constants.InvestigationShortHand, glucose.TestType)
+	assert.Equal(t, "98", glucose.TestValue)
+	assert.Equal(t, "mg/dL", glucose.Unit)
+	assert.Equal(t, "70-100", glucose.ReferenceRange)
+	assert.Equal(t, constants.ABNORMALITY_NORMAL, glucose.LisAbnormality)
+	assert.Equal(t, "BC001", glucose.Barcode)
+	assert.Equal(t, "fasting sample", glucose.TechnicalRemarks)
+	assert.Equal(t, constants.LIS_TEST_STATUS_COMPLETED, glucose.TestStatus)
+	// OBX-14 is local time, results are captured in UTC
+	assert.Equal(t, "2026-10-18T04:45:00", glucose.ResultCapturedAt[:19])
+
+	liverPanel := lisOrder.Tests[1]
+	assert.Equal(t, "LFT", liverPanel.TestCode)
+	assert.Equal(t, constants.GroupShortHand, liverPanel.TestType)
+	assert.Equal(t, "BC002", liverPanel.Barcode)
+	assert.Len(t, liverPanel.Parameters, 2)
+	assert.Equal(t, "ALT", liverPanel.Parameters[0].TestCode)
+	assert.Equal(t, constants.ABNORMALITY_UPPER_ABNORMAL, liverPanel.Parameters[0].LisAbnormality)
+	assert.Equal(t, constants.LIS_TEST_STATUS_COMPLETED, liverPanel.Parameters[1].TestStatus)
+}
+
+func TestConvertOruR01ToLisOrdersWithUnsupportedMessage(t *testing.T) {
+	message, err := ParseMessage(testOrmMessage)
+	assert.NoError(t, err)
+
+	_, err = ConvertOruR01ToLisOrders(message)
+	assert.ErrorContains(t, err, constants.ERROR_UNSUPPORTED_HL7_MESSAGE_TYPE)
+}
+
+func TestConvertOruR01ToLisOrdersWithoutPlacerOrderNumber(t *testing.T) {
+	message, err := ParseMessage("MSH|^~\\&|LIS|LAB|||20261018103000||ORU^R01|CTRL0003|P|2.5.1\r" +
+		"OBR|1||BC001|GLU^Glucose^L\r")
+	assert.NoError(t, err)
+
+	_, err = ConvertOruR01ToLisOrders(message)
+	assert.ErrorContains(t, err, constants.ERROR_HL7_PLACER_ORDER_NUMBER_EMPTY)
+}
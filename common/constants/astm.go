@@ -0,0 +1,56 @@
+package constants
+
+var (
+	AstmListenAddress       = Config.GetString("astm.listen_address")
+	AstmReceiveTimeout      = Config.GetInt("astm.receive_timeout")
+	AstmDefaultTimeoutInSec = 30
+	AstmMaxSendAttempts     = 6
+)
+
+// ASTM E1381 / LIS1-A Control Characters
+const (
+	ASTM_STX = 0x02
+	ASTM_ETX = 0x03
+	ASTM_EOT = 0x04
+	ASTM_ENQ = 0x05
+	ASTM_ACK = 0x06
+	ASTM_LF  = 0x0a
+	ASTM_CR  = 0x0d
+	ASTM_NAK = 0x15
+	ASTM_ETB = 0x17
+)
+
+// ASTM_MAX_FRAME_TEXT_LENGTH is the text a frame can carry, 247 characters less the frame overhead.
+const ASTM_MAX_FRAME_TEXT_LENGTH = 240
+
+// ASTM E1394 / LIS2-A2 Record Types
+const (
+	ASTM_RECORD_HEADER     = "H"
+	ASTM_RECORD_PATIENT    = "P"
+	ASTM_RECORD_ORDER      = "O"
+	ASTM_RECORD_RESULT     = "R"
+	ASTM_RECORD_COMMENT    = "C"
+	ASTM_RECORD_QUERY      = "Q"
+	ASTM_RECORD_TERMINATOR = "L"
+)
+
+const (
+	ASTM_DEFAULT_DELIMITERS = `|\^&`
+	ASTM_DATE_TIME_LAYOUT   = "20060102150405"
+)
+
+// ASTM Result Statuses (R-9)
+const (
+	ASTM_RESULT_STATUS_FINAL       = "F"
+	ASTM_RESULT_STATUS_CORRECTION  = "C"
+	ASTM_RESULT_STATUS_PRELIMINARY = "P"
+	ASTM_RESULT_STATUS_RERUN       = "R"
+	ASTM_RESULT_STATUS_CANCELLED   = "X"
+)
+
+var AstmAcceptedResultStatuses = []string{
+	"",
+	ASTM_RESULT_STATUS_FINAL,
+	ASTM_RESULT_STATUS_CORRECTION,
+	ASTM_RESULT_STATUS_RERUN,
+}
@@ -9,6 +9,7 @@ const (
 	AttachmentTypeVisitDocument  = "visit_document"
 	AttachmentTypeTestDocument   = "test_document"
 	AttachmentTypeCpDocument     = "cp_document"
+	AttachmentTypeAmendedReport  = "amended_report"
 )
 
 const (
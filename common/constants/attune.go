@@ -45,6 +45,42 @@ const (
 	AttuneTestStatusOrdered   = "Ordered"
 )
 
+// AttuneTestStatusToLisTestStatus maps the test statuses sent by Attune to LIS test statuses.
+var AttuneTestStatusToLisTestStatus = map[string]string{
+	AttuneTestStatusOrdered:    LIS_TEST_STATUS_ORDERED,
+	AttuneTestStatusCompleted:  LIS_TEST_STATUS_COMPLETED,
+	AttuneTestStatusApprove:    LIS_TEST_STATUS_APPROVED,
+	AttuneTestStatusCancel:     LIS_TEST_STATUS_CANCELLED,
+	AttuneTestStatusRetest:     LIS_TEST_STATUS_RETEST,
+	AttuneTestStatusRerun:      LIS_TEST_STATUS_RERUN,
+	ATTUNE_TEST_STATUS_RECHECK: LIS_TEST_STATUS_RECHECK,
+}
+
+// LisTestStatusToAttuneTestStatus maps LIS test statuses to the test statuses Attune takes.
+var LisTestStatusToAttuneTestStatus = map[string]string{
+	LIS_TEST_STATUS_ORDERED:   AttuneTestStatusOrdered,
+	LIS_TEST_STATUS_COMPLETED: AttuneTestStatusCompleted,
+	LIS_TEST_STATUS_APPROVED:  AttuneTestStatusApprove,
+	LIS_TEST_STATUS_CANCELLED: AttuneTestStatusCancel,
+	LIS_TEST_STATUS_RETEST:    AttuneTestStatusRetest,
+	LIS_TEST_STATUS_RERUN:     AttuneTestStatusRerun,
+	LIS_TEST_STATUS_RECHECK:   ATTUNE_TEST_STATUS_RECHECK,
+}
+
+// LisReportFormatToAttuneReportFormat maps LIS report formats to the report PDF formats of Attune.
+var LisReportFormatToAttuneReportFormat = map[string]string{
+	LIS_REPORT_FORMAT_BRANDED:    AttuneReportWithStationery,
+	LIS_REPORT_FORMAT_PLAIN:      AttuneReportWithoutStationery,
+	LIS_REPORT_FORMAT_CO_BRANDED: AttuneReportWithCobrandStationery,
+}
+
+// AttuneReportFormatToLisReportFormat maps the report PDF formats of Attune to LIS report formats.
+var AttuneReportFormatToLisReportFormat = map[string]string{
+	AttuneReportWithStationery:        LIS_REPORT_FORMAT_BRANDED,
+	AttuneReportWithoutStationery:     LIS_REPORT_FORMAT_PLAIN,
+	AttuneReportWithCobrandStationery: LIS_REPORT_FORMAT_CO_BRANDED,
+}
+
 const (
 	AttuneAddressLengthLimit    = 250
 	LisMissingValuesMaxRetries  = 3
@@ -0,0 +1,57 @@
+package constants
+
+// Auto Verification Rule Types
+const (
+	AutoVerificationRuleTypeRange            = "range"
+	AutoVerificationRuleTypeDeltaCheck       = "delta_check"
+	AutoVerificationRuleTypeCritical         = "critical"
+	AutoVerificationRuleTypeInstrumentFlag   = "instrument_flag"
+	AutoVerificationRuleTypeDependentSum     = "dependent_sum"
+	AutoVerificationRuleTypePatientCondition = "patient_condition"
+)
+
+var AutoVerificationRuleTypes = []string{
+	AutoVerificationRuleTypeRange,
+	AutoVerificationRuleTypeDeltaCheck,
+	AutoVerificationRuleTypeCritical,
+	AutoVerificationRuleTypeInstrumentFlag,
+	AutoVerificationRuleTypeDependentSum,
+	AutoVerificationRuleTypePatientCondition,
+}
+
+// Auto Verification Rule Evaluation Statuses
+const (
+	AutoVerificationStatusPassed  = "passed"
+	AutoVerificationStatusFailed  = "failed"
+	AutoVerificationStatusSkipped = "skipped"
+)
+
+// Auto Verification Remarks
+const (
+	AutoVerificationRemarkDefaultRule            = "default rule"
+	AutoVerificationRemarkWithinRange            = "value within auto approval range"
+	AutoVerificationRemarkOutsideRange           = "value outside auto approval range"
+	AutoVerificationRemarkOutsideConfiguredRange = "value %v outside range [%s, %s]"
+	AutoVerificationRemarkNonNumericValue        = "investigation value is not numeric"
+	AutoVerificationRemarkImDeviceVerified       = "verified by the IM device"
+	AutoVerificationRemarkImDeviceNotVerified    = "not verified by the IM device"
+	AutoVerificationRemarkManualInput            = "value entered manually"
+	AutoVerificationRemarkInstrumentFlagsClear   = "no instrument flags"
+	AutoVerificationRemarkCritical               = "value is critical"
+	AutoVerificationRemarkNotCritical            = "value is not critical"
+	AutoVerificationRemarkDependentValueMissing  = "value of %s is missing or not numeric"
+	AutoVerificationRemarkDependentSumMismatch   = "sum %v of %s differs from %v by more than %v"
+	AutoVerificationRemarkDependentSumMatch      = "sum %v of %s matches %v"
+	AutoVerificationRemarkPatientAgeUnknown      = "patient age is unknown"
+	AutoVerificationRemarkPatientAgeOutside      = "patient age %d days outside [%s, %s]"
+	AutoVerificationRemarkPatientGenderOutside   = "patient gender %s not in %s"
+	AutoVerificationRemarkPatientMatches         = "patient matches the conditions"
+)
+
+// Auto Verification Rule Scope, 0 matches any lab, test or investigation
+const (
+	AutoVerificationRuleScopeAny = 0
+)
+
+// AutoVerificationGenders are the genders a patient condition rule can be restricted to, as in GetGenderConstant.
+var AutoVerificationGenders = []string{"M", "F", "O"}
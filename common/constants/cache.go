@@ -56,6 +56,10 @@ const (
 	CacheKeyReceiveAndSync          = "receive_and_sync:%s"
 	CacheKeySrfOrderIds             = "srf_order_ids:%s"
 	CacheKeySrfOrderIdsAll          = "srf_order_ids:all"
+	CacheKeyDeltaCheckRulesAll      = "delta_check_rules:all"
+	CacheKeyAutoVerificationRules   = "auto_verification_rules:all"
+	CacheKeyReflexRulesAll          = "reflex_rules:all"
+	CacheKeySampleStabilityRulesAll = "sample_stability_rules:all"
 )
 
 // Cache Expiry Time Duration
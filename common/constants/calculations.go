@@ -0,0 +1,63 @@
+package constants
+
+// Formula Variables
+const (
+	FormulaVariableAgeYears = "age_years"
+	FormulaVariableAgeDays  = "age_days"
+	FormulaVariableGender   = "gender"
+	FormulaVariableWeightKg = "weight_kg"
+	FormulaVariableHeightCm = "height_cm"
+)
+
+var FormulaVariables = []string{
+	FormulaVariableAgeYears,
+	FormulaVariableAgeDays,
+	FormulaVariableGender,
+	FormulaVariableWeightKg,
+	FormulaVariableHeightCm,
+}
+
+// Formula Functions
+const (
+	FormulaFunctionLog   = "log"
+	FormulaFunctionLog10 = "log10"
+	FormulaFunctionExp   = "exp"
+	FormulaFunctionPow   = "pow"
+	FormulaFunctionSqrt  = "sqrt"
+	FormulaFunctionAbs   = "abs"
+	FormulaFunctionMin   = "min"
+	FormulaFunctionMax   = "max"
+	FormulaFunctionRound = "round"
+	FormulaFunctionIf    = "if"
+	FormulaFunctionNa    = "na"
+)
+
+const (
+	FormulaDependentParameterPrefix = "dependent_"
+	FormulaMaxDependencyLookups     = 100
+)
+
+// FormulaConcentrationUnits holds the factor of each unit to the base unit of its dimension, g/L for mass and
+// mol/L for molar concentrations. Units are lower case with the micro sign written as u.
+var FormulaConcentrationUnits = map[string]map[string]float64{
+	"mass": {
+		"g/l":   1,
+		"g/dl":  10,
+		"mg/ml": 1,
+		"mg/dl": 0.01,
+		"mg/l":  0.001,
+		"ug/ml": 0.001,
+		"ug/dl": 0.00001,
+		"ug/l":  0.000001,
+		"ng/ml": 0.000001,
+		"ng/dl": 0.00000001,
+		"pg/ml": 0.000000001,
+	},
+	"molar": {
+		"mol/l":  1,
+		"mmol/l": 0.001,
+		"umol/l": 0.000001,
+		"nmol/l": 0.000000001,
+		"pmol/l": 0.000000000001,
+	},
+}
@@ -0,0 +1,24 @@
+package constants
+
+// Critical Call Statuses
+const (
+	CRITICAL_CALL_STATUS_OPEN         = "open"
+	CRITICAL_CALL_STATUS_ESCALATED    = "escalated"
+	CRITICAL_CALL_STATUS_ACKNOWLEDGED = "acknowledged"
+)
+
+// CRITICAL_CALL_OPEN_STATUSES are the statuses of critical calls that still hold back the report release.
+var CRITICAL_CALL_OPEN_STATUSES = []string{
+	CRITICAL_CALL_STATUS_OPEN,
+	CRITICAL_CALL_STATUS_ESCALATED,
+}
+
+var (
+	// CriticalCallEscalationWindowMinutes is the time a critical call can stay unacknowledged before it is
+	// escalated, and again between escalations.
+	CriticalCallEscalationWindowMinutes = Config.GetInt("critical_calls.escalation_window_minutes")
+)
+
+const (
+	CriticalCallDefaultEscalationWindowMinutes = 30
+)
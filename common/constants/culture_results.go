@@ -0,0 +1,38 @@
+package constants
+
+// Susceptibility interpretations
+const (
+	SUSCEPTIBILITY_SUSCEPTIBLE  = "S"
+	SUSCEPTIBILITY_INTERMEDIATE = "I"
+	SUSCEPTIBILITY_RESISTANT    = "R"
+)
+
+var SusceptibilityInterpretations = []string{
+	SUSCEPTIBILITY_SUSCEPTIBLE,
+	SUSCEPTIBILITY_INTERMEDIATE,
+	SUSCEPTIBILITY_RESISTANT,
+}
+
+// Attune reports the sensitivity as free text, keys are lower-cased.
+var AttuneSensitivityToInterpretation = map[string]string{
+	"s":            SUSCEPTIBILITY_SUSCEPTIBLE,
+	"sensitive":    SUSCEPTIBILITY_SUSCEPTIBLE,
+	"susceptible":  SUSCEPTIBILITY_SUSCEPTIBLE,
+	"i":            SUSCEPTIBILITY_INTERMEDIATE,
+	"intermediate": SUSCEPTIBILITY_INTERMEDIATE,
+	"r":            SUSCEPTIBILITY_RESISTANT,
+	"resistant":    SUSCEPTIBILITY_RESISTANT,
+}
+
+// Breakpoint standards
+const (
+	BREAKPOINT_STANDARD_CLSI   = "CLSI"
+	BREAKPOINT_STANDARD_EUCAST = "EUCAST"
+
+	DefaultBreakpointStandard = BREAKPOINT_STANDARD_CLSI
+)
+
+var BreakpointStandards = []string{
+	BREAKPOINT_STANDARD_CLSI,
+	BREAKPOINT_STANDARD_EUCAST,
+}
@@ -2,16 +2,38 @@ package constants
 
 // DB Table Names
 const (
+	TableAntimicrobialBreakpoints  = "antimicrobial_breakpoints"
 	TableAttachment                = "attachments"
 	TableAuditLogs                 = "audit_logs"
+	TableAutoVerificationRules     = "auto_verification_rules"
 	TableCoAuthorizedPathologists  = "co_authorized_pathologists"
+	TableCriticalCalls             = "critical_calls"
+	TableCultureOrganisms          = "culture_organisms"
+	TableCultureResults            = "culture_results"
+	TableCultureSusceptibilities   = "culture_susceptibilities"
+	TableDeltaCheckRules           = "delta_check_rules"
+	TableDeadLetterEvents          = "dead_letter_events"
 	TableInvestigationData         = "investigation_data"
 	TableInvestigationResults      = "investigation_results"
+	TableOutboxMessages            = "outbox_messages"
+	TablePathologistProfiles       = "pathologist_profiles"
+	TableProcessedEvents           = "processed_events"
 	TablePatientDetails            = "patient_details"
+	TableQcResults                 = "qc_results"
+	TableQcTargets                 = "qc_targets"
+	TableReflexRules               = "reflex_rules"
+	TableReflexTestTriggers        = "reflex_test_triggers"
 	TableRemarks                   = "remarks"
+	TableReportAmendments          = "report_amendments"
 	TableRerunInvestigationResults = "rerun_investigation_results"
+	TableRosterOverrides           = "roster_overrides"
+	TableRosterShifts              = "roster_shifts"
+	TableSampleAliquots            = "sample_aliquots"
+	TableSampleStabilityRules      = "sample_stability_rules"
 	TableTasks                     = "tasks"
 	TableTaskMetadata              = "task_metadata"
+	TableTaskAssignments           = "task_assignments"
+	TableTaskListViews             = "task_list_views"
 	TableTaskPathologistMapping    = "task_pathologist_mapping"
 	TableTaskVisitMapping          = "task_visit_mapping"
 	TableTemplates                 = "templates"
@@ -26,5 +48,7 @@ const (
 
 // DB Table Struct Names
 const (
-	PatientDetails = "PatientDetail"
+	PatientDetails          = "PatientDetail"
+	CultureOrganisms        = "Organisms"
+	CultureSusceptibilities = "Organisms.Susceptibilities"
 )
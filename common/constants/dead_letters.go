@@ -0,0 +1,20 @@
+package constants
+
+// Dead Letter Event Statuses
+const (
+	DEAD_LETTER_STATUS_FAILED = "failed"
+	// DEAD_LETTER_STATUS_REPLAYING marks an event that is queued on the worker to be processed again.
+	DEAD_LETTER_STATUS_REPLAYING = "replaying"
+	DEAD_LETTER_STATUS_REPLAYED  = "replayed"
+)
+
+var DEAD_LETTER_STATUSES = []string{
+	DEAD_LETTER_STATUS_FAILED,
+	DEAD_LETTER_STATUS_REPLAYING,
+	DEAD_LETTER_STATUS_REPLAYED,
+}
+
+const (
+	DeadLetterDefaultListLimit = 100
+	DeadLetterUnknownEventType = "unknown event type"
+)
@@ -4,6 +4,8 @@ const (
 	DEFAULT_RERUN_REASON = "Rerun Reason"
 	DEFAULT_RERUN_REMARK = "Rerun Remark"
 	RERUN_REASON_QC_FAIL = "qc_fail"
+
+	RERUN_REMARKS_WESTGARD_QC_FAIL = "qc_fail: westgard rules %s violated"
 )
 
 const (
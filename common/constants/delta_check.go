@@ -0,0 +1,35 @@
+package constants
+
+// Delta Check Rule Types
+const (
+	DeltaCheckRuleTypeAbsolute   = "absolute"
+	DeltaCheckRuleTypePercentage = "percentage"
+	DeltaCheckRuleTypeRatePerDay = "rate_per_day"
+)
+
+var DeltaCheckRuleTypes = []string{
+	DeltaCheckRuleTypeAbsolute,
+	DeltaCheckRuleTypePercentage,
+	DeltaCheckRuleTypeRatePerDay,
+}
+
+// Delta Check Statuses
+const (
+	DeltaCheckStatusPassed  = "passed"
+	DeltaCheckStatusFailed  = "failed"
+	DeltaCheckStatusSkipped = "skipped"
+)
+
+// Delta Check Remarks
+const (
+	DeltaCheckRemarkEmptyValue         = "investigation value is empty"
+	DeltaCheckRemarkWhitelisted        = "investigation is whitelisted for delta check"
+	DeltaCheckRemarkEnteredTimeMissing = "entered time is missing"
+	DeltaCheckRemarkNoPastValue        = "no past value found"
+	DeltaCheckRemarkNoApplicableRules  = "no applicable delta check rules"
+	DeltaCheckRemarkNonNumericValue    = "investigation value is not numeric"
+	DeltaCheckRemarkInvalidPastValue   = "past value is not numeric"
+	DeltaCheckRemarkWithinLimits       = "delta within configured limits"
+	DeltaCheckRemarkRuleViolated       = "%s delta %v outside limits [%v, %v]"
+	DeltaCheckRemarkDeltaNotComputable = "%s delta cannot be computed against the past value"
+)
@@ -153,6 +153,284 @@ const (
 	ERROR_IN_GETTING_QC_FAILED_RERUN_DATA     = "Error in getting QC failed rerun data"
 )
 
+// Calculation Error Messages
+const (
+	ERROR_INVALID_FORMULA_SYNTAX            = "invalid formula syntax"
+	ERROR_UNKNOWN_FORMULA_VARIABLE          = "unknown formula variable %s"
+	ERROR_MISSING_FORMULA_VARIABLE          = "patient %s is needed to calculate the result"
+	ERROR_MISSING_DEPENDENT_VALUE           = "value of investigation %d is needed to calculate the result"
+	ERROR_NON_NUMERIC_DEPENDENT_VALUE       = "value of investigation %d is not numeric"
+	ERROR_QUALIFIED_DEPENDENT_VALUE         = "value of investigation %d is qualified with %s and cannot be used in a calculation"
+	ERROR_INCOMPATIBLE_FORMULA_UNIT         = "unit %s of investigation %d cannot be converted to %s"
+	ERROR_UNKNOWN_FORMULA_UNIT              = "unknown formula unit %s"
+	ERROR_FORMULA_DEPENDENCY_CYCLE          = "formula creates a dependency cycle through investigation %d"
+	ERROR_FORMULA_SELF_DEPENDENCY           = "formula cannot depend on its own investigation"
+	ERROR_FORMULA_FUNCTION_ARGUMENTS        = "function %s needs %s arguments"
+	ERROR_FORMULA_NON_NUMERIC_ARGUMENT      = "function %s needs numeric arguments"
+	ERROR_FORMULA_NON_BOOLEAN_CONDITION     = "if needs a condition as its first argument"
+	ERROR_IN_GETTING_FORMULA_DEPENDENCIES   = "Error in getting formula dependencies"
+	ERROR_INVALID_FORMULA_DEPENDENCY_LOOKUP = "lab id and city code are needed to check dependency cycles"
+)
+
+// Delta Check Error Messages
+const (
+	ERROR_INVALID_DELTA_CHECK_RULE_TYPE      = "invalid delta check rule type"
+	ERROR_INVALID_DELTA_CHECK_RULE_LIMITS    = "positive limit must be >= 0, negative limit must be <= 0 and both cannot be 0"
+	ERROR_DELTA_CHECK_RULE_ALREADY_EXISTS    = "delta check rule already exists for this investigation and rule type"
+	ERROR_DELTA_CHECK_RULE_NOT_FOUND         = "delta check rule not found"
+	ERROR_INVALID_DELTA_CHECK_RULE_ID        = "invalid delta check rule id"
+	ERROR_WHILE_FETCHING_DELTA_CHECK_RULES   = "error while fetching delta check rules"
+	ERROR_WHILE_EVALUATING_DELTA_CHECK       = "error while evaluating delta check"
+	ERROR_WHILE_INVALIDATING_DELTA_CHECK_KEY = "error while invalidating delta check rules cache"
+)
+
+// Auto Verification Error Messages
+const (
+	ERROR_INVALID_AUTO_VERIFICATION_RULE_TYPE       = "invalid auto verification rule type"
+	ERROR_INVALID_AUTO_VERIFICATION_RULE_PARAMETERS = "invalid auto verification rule parameters"
+	ERROR_INVALID_AUTO_VERIFICATION_RULE_ID         = "invalid auto verification rule id"
+	ERROR_AUTO_VERIFICATION_RULE_NOT_FOUND          = "auto verification rule not found"
+	ERROR_INVALID_AUTO_VERIFICATION_RANGE           = "range rule min must be <= max"
+	ERROR_INVALID_AUTO_VERIFICATION_DEPENDENT_SUM   = "dependent sum rule needs at least 2 lis codes, an expected sum and a tolerance >= 0"
+	ERROR_INVALID_AUTO_VERIFICATION_PATIENT_RULE    = "patient condition rule needs an age range with min <= max or genders among M, F and O"
+	ERROR_WHILE_FETCHING_AUTO_VERIFICATION_RULES    = "error while fetching auto verification rules"
+	ERROR_WHILE_INVALIDATING_AUTO_VERIFICATION_KEY  = "error while invalidating auto verification rules cache"
+	ERROR_WHILE_MARSHALLING_AUTO_VERIFICATION       = "error while marshalling auto verification evaluations"
+	ERROR_NO_INVESTIGATIONS_FOR_DRY_RUN             = "no investigations with values found for the task"
+)
+
+// QC Error Messages
+const (
+	ERROR_INVALID_QC_TARGET_ID         = "invalid qc target id"
+	ERROR_INVALID_QC_TARGET_SD         = "qc target sd must be greater than 0"
+	ERROR_INVALID_QC_VALUE             = "qc value is not numeric"
+	ERROR_INVALID_QC_LAB_ID            = "invalid lab id"
+	ERROR_INVALID_QC_RESULT_ID         = "invalid qc result id"
+	ERROR_QC_RESULT_NOT_FOUND          = "qc result not found"
+	ERROR_QC_TARGET_ALREADY_EXISTS     = "qc target already exists for this lab, lot number and lis code"
+	ERROR_QC_TARGET_NOT_FOUND          = "qc target not found"
+	ERROR_WHILE_EVALUATING_WESTGARD_QC = "error while evaluating westgard qc"
+	ERROR_WHILE_FETCHING_QC_HISTORY    = "error while fetching qc history"
+	ERROR_WHILE_SAVING_QC_RESULT       = "error while saving qc result"
+)
+
+// Critical Call Error Messages
+const (
+	ERROR_INVALID_CRITICAL_CALL_ID           = "invalid critical call id"
+	ERROR_CRITICAL_CALL_NOT_FOUND            = "critical call not found"
+	ERROR_CRITICAL_CALL_ALREADY_ACKNOWLEDGED = "critical call is already acknowledged"
+	ERROR_CRITICAL_CALL_READ_BACK_REQUIRED   = "critical value must be read back and confirmed by the recipient"
+	ERROR_WHILE_CREATING_CRITICAL_CALLS      = "error while creating critical calls"
+	ERROR_WHILE_ESCALATING_CRITICAL_CALLS    = "error while escalating critical calls"
+)
+
+// Task Assignment Error Messages
+const (
+	ERROR_INVALID_PATHOLOGIST_ID                 = "invalid pathologist id"
+	ERROR_USER_IS_NOT_A_PATHOLOGIST              = "user is not a pathologist"
+	ERROR_TASK_ASSIGNMENT_NOT_FOUND              = "task assignment not found"
+	ERROR_TASK_ASSIGNMENT_OVERRIDE_NOT_ALLOWED   = "only a lead pathologist can override task assignments"
+	ERROR_PATHOLOGIST_PROFILE_UPDATE_NOT_ALLOWED = "only a lead pathologist can manage pathologist profiles"
+	ERROR_TASK_NOT_OPEN_FOR_ASSIGNMENT           = "task is not open for assignment"
+	ERROR_TASK_ALREADY_ASSIGNED_TO_PATHOLOGIST   = "task is already assigned to the pathologist"
+	ERROR_WHILE_ASSIGNING_TASKS                  = "error while assigning tasks"
+)
+
+// Roster Error Messages
+const (
+	ERROR_INVALID_ROSTER_SHIFT_ID    = "invalid roster shift id"
+	ERROR_INVALID_ROSTER_OVERRIDE_ID = "invalid roster override id"
+	ERROR_ROSTER_SHIFT_NOT_FOUND     = "roster shift not found"
+	ERROR_ROSTER_OVERRIDE_NOT_FOUND  = "roster override not found"
+	ERROR_INVALID_WEEKDAY            = "invalid weekday"
+	ERROR_INVALID_SHIFT_TIME         = "shift times must be in HH:MM format"
+	ERROR_INVALID_OVERRIDE_TYPE      = "invalid override type"
+	ERROR_INVALID_OVERRIDE_DATE      = "override date must be in YYYY-MM-DD format"
+	ERROR_CITY_CODE_REQUIRED         = "city code is required"
+	ERROR_ROSTER_SHIFT_OVERLAPS      = "shift overlaps with an existing shift"
+	ERROR_ROSTER_OVERRIDE_OVERLAPS   = "override overlaps with an existing override"
+	ERROR_NO_DOCTOR_ON_DUTY          = "no doctor is on duty"
+	ERROR_INVALID_ON_DUTY_AT         = "on duty time must be in RFC 3339 format"
+	ERROR_ROSTER_COVERAGE_GAP        = "roster has no doctor on duty"
+)
+
+// Outbox Error Messages
+const (
+	ERROR_INVALID_OUTBOX_MESSAGE_ID        = "invalid outbox message id"
+	ERROR_OUTBOX_MESSAGE_NOT_FOUND         = "outbox message not found"
+	ERROR_INVALID_OUTBOX_STATUS            = "invalid outbox status"
+	ERROR_OUTBOX_MESSAGE_ALREADY_PUBLISHED = "outbox message is already published"
+	ERROR_OUTBOX_REPLAY_NOT_ALLOWED        = "only super admins can replay outbox messages"
+	ERROR_WHILE_WRITING_OUTBOX_MESSAGE     = "error while writing outbox message"
+	ERROR_WHILE_RELAYING_OUTBOX_MESSAGES   = "error while relaying outbox messages"
+	ERROR_WHILE_PUBLISHING_OUTBOX_MESSAGE  = "error while publishing outbox message"
+)
+
+// Dead Letter Error Messages
+const (
+	ERROR_INVALID_DEAD_LETTER_EVENT_ID      = "invalid dead letter event id"
+	ERROR_DEAD_LETTER_EVENT_NOT_FOUND       = "dead letter event not found"
+	ERROR_INVALID_DEAD_LETTER_STATUS        = "invalid dead letter status"
+	ERROR_DEAD_LETTER_EVENT_NOT_FAILED      = "only failed dead letter events can be edited or replayed"
+	ERROR_DEAD_LETTER_EVENT_PAYLOAD_EMPTY   = "dead letter event payload cannot be empty"
+	ERROR_DEAD_LETTER_NOT_ALLOWED           = "only super admins can edit or replay dead letter events"
+	ERROR_WHILE_WRITING_DEAD_LETTER_EVENT   = "error while writing dead letter event"
+	ERROR_WHILE_REPLAYING_DEAD_LETTER_EVENT = "error while replaying dead letter event"
+	ERROR_WHILE_QUEUEING_DEAD_LETTER_REPLAY = "error while queueing dead letter event replay"
+)
+
+// Event Ledger Error Messages
+const (
+	ERROR_INVALID_EVENT_LEDGER_DECISION     = "invalid event ledger decision"
+	ERROR_WHILE_CHECKING_EVENT_LEDGER       = "error while checking event ledger"
+	ERROR_WHILE_RELEASING_EVENT_LEDGER      = "error while releasing event claim in ledger"
+	ERROR_WHILE_COMPLETING_EVENT_LEDGER     = "error while completing event claim in ledger"
+	ERROR_EVENT_LEDGER_BUSINESS_ID_REQUIRED = "business id is required"
+)
+
+// LIS Error Messages
+const (
+	ERROR_UNSUPPORTED_LIS_VENDOR = "unsupported lis vendor configured for lab"
+)
+
+// HL7 Error Messages
+const (
+	ERROR_HL7_ENDPOINT_NOT_CONFIGURED   = "hl7 endpoint not configured for lab"
+	ERROR_WHILE_SENDING_HL7_MESSAGE     = "error while sending hl7 message"
+	ERROR_WHILE_READING_HL7_MESSAGE     = "error while reading hl7 message"
+	ERROR_INVALID_HL7_MESSAGE           = "invalid hl7 message"
+	ERROR_HL7_MESSAGE_REJECTED          = "hl7 message rejected by receiver"
+	ERROR_UNSUPPORTED_HL7_MESSAGE_TYPE  = "unsupported hl7 message type"
+	ERROR_HL7_PLACER_ORDER_NUMBER_EMPTY = "hl7 placer order number is empty"
+	ERROR_HL7_RESULTS_NOT_FOUND         = "hl7 results not found for visit"
+	ERROR_WHILE_INGESTING_HL7_RESULTS   = "error while ingesting hl7 results"
+)
+
+// FHIR Error Messages
+const (
+	ERROR_FHIR_ORDER_ID_REQUIRED          = "order_id is required"
+	ERROR_FHIR_NO_APPROVED_RESULTS        = "no approved results found for order"
+	ERROR_FHIR_RESOURCE_NOT_FOUND         = "fhir resource not found"
+	ERROR_FHIR_PARTNER_ENDPOINT_NOT_FOUND = "fhir endpoint not configured for partner"
+	ERROR_WHILE_PUSHING_FHIR_BUNDLE       = "error while pushing fhir bundle"
+)
+
+// ASTM Error Messages
+const (
+	ERROR_INVALID_ASTM_FRAME              = "invalid astm frame"
+	ERROR_ASTM_CHECKSUM_MISMATCH          = "astm frame checksum mismatch"
+	ERROR_ASTM_FRAME_NUMBER_MISMATCH      = "astm frame number out of sequence"
+	ERROR_INVALID_ASTM_MESSAGE            = "invalid astm message"
+	ERROR_WHILE_READING_ASTM_MESSAGE      = "error while reading astm message"
+	ERROR_WHILE_SENDING_ASTM_MESSAGE      = "error while sending astm message"
+	ERROR_ASTM_MESSAGE_NOT_ACKNOWLEDGED   = "astm message not acknowledged by receiver"
+	ERROR_DEVICE_RESULTS_SAMPLE_NOT_FOUND = "sample not found for device results barcode"
+	ERROR_WHILE_INGESTING_DEVICE_RESULTS  = "error while ingesting device results"
+)
+
+// Culture Results Error Messages
+const (
+	ERROR_CULTURE_RESULT_NOT_FOUND                 = "culture result not found"
+	ERROR_CULTURE_RESULT_ALREADY_APPROVED          = "culture result of an approved investigation cannot be edited"
+	ERROR_ONLY_PATHOLOGISTS_CAN_EDIT_CULTURE       = "only pathologists can edit culture results"
+	ERROR_INVALID_SUSCEPTIBILITY_INTERPRETATION    = "susceptibility interpretation must be one of S, I or R"
+	ERROR_INVALID_BREAKPOINT_STANDARD              = "breakpoint standard must be one of CLSI or EUCAST"
+	ERROR_INVALID_MIC_VALUE                        = "invalid mic value"
+	ERROR_INTERPRETATION_DOES_NOT_MATCH_BREAKPOINT = "susceptibility interpretation does not match the breakpoint"
+	ERROR_INVALID_ANTIMICROBIAL_BREAKPOINT         = "susceptible breakpoint must be lower than or equal to the resistant breakpoint"
+	ERROR_ANTIMICROBIAL_BREAKPOINT_ALREADY_EXISTS  = "breakpoint already exists for this standard, organism and antimicrobial"
+	ERROR_WHILE_SYNCING_CULTURE_RESULTS            = "error while syncing culture results"
+)
+
+// Reflex Rules Error Messages
+const (
+	ERROR_INVALID_REFLEX_RULE_ID              = "invalid reflex rule id"
+	ERROR_REFLEX_RULE_NOT_FOUND               = "reflex rule not found"
+	ERROR_INVALID_REFLEX_RULE_OPERATOR        = "reflex rule operator must be one of abnormal, critical, eq, gt, gte, lt or lte"
+	ERROR_REFLEX_RULE_VALUE_REQUIRED          = "reflex rule value is required for this operator"
+	ERROR_REFLEX_RULE_VALUE_NOT_NUMERIC       = "reflex rule value must be numeric for this operator"
+	ERROR_REFLEX_RULE_ALREADY_EXISTS          = "reflex rule already exists for this trigger, condition and reflex test"
+	ERROR_WHILE_FETCHING_REFLEX_RULES         = "error while fetching reflex rules"
+	ERROR_WHILE_EVALUATING_REFLEX_RULES       = "error while evaluating reflex rules"
+	ERROR_WHILE_INVALIDATING_REFLEX_RULES_KEY = "error while invalidating reflex rules cache"
+)
+
+// Sample Stability Error Messages
+const (
+	ERROR_INVALID_SAMPLE_STABILITY_RULE_ID              = "invalid sample stability rule id"
+	ERROR_SAMPLE_STABILITY_RULE_NOT_FOUND               = "sample stability rule not found"
+	ERROR_INVALID_STORAGE_CONDITION                     = "storage condition must be one of ambient, refrigerated or frozen"
+	ERROR_INVALID_SAMPLE_STABILITY_ACTION               = "sample stability action must be one of reject or flag"
+	ERROR_SAMPLE_STABILITY_HOURS_REQUIRED               = "stability hours must be greater than 0"
+	ERROR_SAMPLE_STABILITY_NEAR_EXPIRY_HOURS_INVALID    = "near expiry hours must be less than the stability hours"
+	ERROR_SAMPLE_STABILITY_RULE_ALREADY_EXISTS          = "sample stability rule already exists for this lab, vial type, test and storage condition"
+	ERROR_WHILE_FETCHING_SAMPLE_STABILITY_RULES         = "error while fetching sample stability rules"
+	ERROR_WHILE_INVALIDATING_SAMPLE_STABILITY_RULES_KEY = "error while invalidating sample stability rules cache"
+	ERROR_WHILE_REJECTING_UNSTABLE_SAMPLE               = "error while rejecting sample beyond stability"
+)
+
+// Sample Aliquots Error Messages
+const (
+	ERROR_ALIQUOTS_REQUIRED                  = "at least one aliquot is required"
+	ERROR_ALIQUOT_BARCODE_REQUIRED           = "barcode is required for every aliquot"
+	ERROR_ALIQUOT_BARCODE_REPEATED           = "barcode %s cannot be used for more than one aliquot"
+	ERROR_ALIQUOT_VOLUME_REQUIRED            = "volume must be greater than 0 for every aliquot"
+	ERROR_ALIQUOT_TEST_IDS_REQUIRED          = "at least one test id is required for every aliquot"
+	ERROR_SAMPLE_NOT_ELIGIBLE_FOR_ALIQUOTING = "sample with status %s cannot be aliquoted"
+	ERROR_TEST_NOT_MAPPED_TO_SAMPLE          = "test %s is not mapped to the sample"
+	ERROR_TEST_MAPPED_TO_MULTIPLE_ALIQUOTS   = "test %s cannot be moved to more than one aliquot"
+	ERROR_ALIQUOT_VOLUME_EXCEEDED            = "aliquot volume %d exceeds the remaining sample volume %d"
+	ERROR_WHILE_FETCHING_SAMPLE_ALIQUOTS     = "error while fetching sample aliquots"
+	ERROR_WHILE_SYNCING_SAMPLE_ALIQUOT       = "error while moving aliquot tests on the lis visit"
+)
+
+// Task List Views Error Messages
+const (
+	ERROR_INVALID_TASK_LIST_VIEW_ID      = "invalid task list view id"
+	ERROR_TASK_LIST_VIEW_NOT_FOUND       = "task list view not found"
+	ERROR_TASK_LIST_VIEW_NAME_REQUIRED   = "task list view name is required"
+	ERROR_TASK_LIST_VIEW_ALREADY_EXISTS  = "task list view with this name already exists"
+	ERROR_INVALID_TASK_LIST_SORT_BY      = "sort_by must be one of doctor_tat or created_at"
+	ERROR_INVALID_TASK_LIST_SORT_ORDER   = "sort_order must be one of asc or desc"
+	ERROR_INVALID_TASK_LIST_CURSOR       = "invalid cursor"
+	ERROR_CURSOR_WITH_MULTIPLE_TASK_TYPE = "cursor can only be used with a single task type"
+	ERROR_TASK_SEARCH_QUERY_TOO_SHORT    = "search query must be at least %d characters"
+	ERROR_WHILE_FETCHING_TASK_LIST_VIEWS = "error while fetching task list views"
+)
+
+// Task Events Error Messages
+const (
+	ERROR_WHILE_FETCHING_TASK_EVENT_DETAILS = "error while fetching task event details"
+	ERROR_WHILE_PUBLISHING_TASK_EVENT       = "error while publishing task event"
+	ERROR_WHILE_DECODING_TASK_EVENT         = "error while decoding task event"
+)
+
+// Task Edit Conflicts Error Messages
+const (
+	ERROR_TASK_EDIT_CONFLICT = "task was modified by someone else, please review the latest values and retry"
+)
+
+// Report Amendments Error Messages
+const (
+	ERROR_INVALID_REPORT_AMENDMENT_ID          = "invalid report amendment id"
+	ERROR_INVALID_REPORT_AMENDMENT_REASON_CODE = "reason_code must be one of %s"
+	ERROR_REPORT_AMENDMENT_JUSTIFICATION       = "justification is required to amend a report"
+	ERROR_REPORT_AMENDMENT_TASK_NOT_COMPLETED  = "only released reports can be amended"
+	ERROR_REPORT_AMENDMENT_ALREADY_OPEN        = "an amendment is already open for this task"
+	ERROR_REPORT_AMENDMENT_NOT_OPEN            = "report amendment is not open"
+	ERROR_REPORT_AMENDMENT_NO_CHANGES          = "no investigation values have changed since the amendment was opened"
+	ERROR_REPORT_AMENDMENT_APPROVER            = "only a pathologist can approve a report amendment"
+	ERROR_WHILE_DECODING_REPORT_AMENDMENT      = "error while decoding report amendment values"
+)
+
+// TAT Analytics Error Messages
+const (
+	ERROR_INVALID_TAT_ANALYTICS_DATE_RANGE = "from_date and to_date are required in YYYY-MM-DD format and from_date cannot be after to_date"
+	ERROR_TAT_ANALYTICS_RANGE_TOO_LONG     = "date range cannot exceed %d days"
+	ERROR_INVALID_TAT_ANALYTICS_GROUP_BY   = "group_by must be a comma separated list of %s"
+	ERROR_INVALID_TAT_ANALYTICS_BUCKET     = "bucket must be one of %s"
+)
+
 // Templates Error Messages
 const (
 	ERROR_INVALID_TEMPLATE_TYPE = "invalid template type"
@@ -29,11 +29,13 @@ var (
 	DefaultRetryTimout               = 10
 	OmsOrderCreateUpdateRetryTimeout = 3
 	OmsCollectionRetryTimeout        = 10
+	LisEventRetryTimeout             = 10
 )
 
 var (
 	DefaultRetryCount       = 3
 	OmsCollectionRetryCount = 5
+	LisEventRetryCount      = 3
 )
 
 var EventNameGroupIdMap = map[string]string{
@@ -0,0 +1,51 @@
+package constants
+
+import "time"
+
+// Event Ledger Decisions
+const (
+	// EVENT_LEDGER_DECISION_IN_PROGRESS marks an event claimed for processing, the claim lapses when its lease
+	// expires so that the redelivery of an event whose processing never finished is processed.
+	EVENT_LEDGER_DECISION_IN_PROGRESS = "in_progress"
+	// EVENT_LEDGER_DECISION_PROCESSED marks an event whose handler succeeded. Redeliveries are told apart by the
+	// payload and sent time, so the redelivery of an event without a sent time is processed again unless its
+	// payload is still the last processed one.
+	EVENT_LEDGER_DECISION_PROCESSED = "processed"
+	// EVENT_LEDGER_DECISION_DUPLICATE marks a redelivery of an event that was already processed.
+	EVENT_LEDGER_DECISION_DUPLICATE = "duplicate"
+	// EVENT_LEDGER_DECISION_STALE marks an event that was sent before the last processed event of its entity.
+	EVENT_LEDGER_DECISION_STALE = "stale"
+)
+
+var EVENT_LEDGER_DECISIONS = []string{
+	EVENT_LEDGER_DECISION_IN_PROGRESS,
+	EVENT_LEDGER_DECISION_PROCESSED,
+	EVENT_LEDGER_DECISION_DUPLICATE,
+	EVENT_LEDGER_DECISION_STALE,
+}
+
+// EventLedgerStreams groups the event types that carry the state of the same entity, an event is compared with
+// the events of its stream. Events missing here are not checked against the ledger.
+var EventLedgerStreams = map[string][]string{
+	OmsOrderCreatedEvent:     {OmsOrderCreatedEvent, OmsOrderUpdatedEvent},
+	OmsOrderUpdatedEvent:     {OmsOrderCreatedEvent, OmsOrderUpdatedEvent},
+	LisEvent:                 {LisEvent},
+	OrderReportPdfReadyEvent: {OrderReportPdfReadyEvent},
+}
+
+// EventLedgerOrderedEventTypes are the events that carry a full snapshot of their entity, so an event sent
+// before the last processed one is outdated and ignored.
+var EventLedgerOrderedEventTypes = []string{
+	OmsOrderCreatedEvent,
+	OmsOrderUpdatedEvent,
+	LisEvent,
+}
+
+const (
+	EventLedgerDefaultListLimit = 100
+	// EventLedgerClaimLease is how long an event claimed for processing is held before another delivery may
+	// claim it, it outlasts the visibility timeout of the queues.
+	EventLedgerClaimLease = 30 * time.Minute
+	// EventSentAtHeader carries the time the event was sent to the queue, in unix milliseconds, on the task.
+	EventSentAtHeader = "event_sent_at"
+)
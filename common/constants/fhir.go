@@ -0,0 +1,118 @@
+package constants
+
+import "time"
+
+var (
+	FhirBaseUrl          = Config.GetString("fhir.base_url")
+	FhirPartnerEndpoints = Config.GetStringMapString("fhir.partner_endpoints")
+	FhirPartnerTokens    = Config.GetStringMapString("fhir.partner_tokens")
+	FhirPushRetries      = 3
+	FhirPushDelay        = 500 * time.Millisecond
+)
+
+const (
+	FHIR_CONTENT_TYPE = "application/fhir+json"
+	FHIR_DATE_LAYOUT  = "2006-01-02"
+)
+
+// FHIR Resource Types
+const (
+	FHIR_RESOURCE_TYPE_BUNDLE            = "Bundle"
+	FHIR_RESOURCE_TYPE_DIAGNOSTIC_REPORT = "DiagnosticReport"
+	FHIR_RESOURCE_TYPE_OBSERVATION       = "Observation"
+	FHIR_RESOURCE_TYPE_PATIENT           = "Patient"
+	FHIR_RESOURCE_TYPE_PRACTITIONER      = "Practitioner"
+)
+
+// FHIR Bundle Types
+const (
+	FHIR_BUNDLE_TYPE_SEARCHSET   = "searchset"
+	FHIR_BUNDLE_TYPE_TRANSACTION = "transaction"
+)
+
+// FHIR Code Systems
+const (
+	FHIR_SYSTEM_LOINC                = "http://loinc.org"
+	FHIR_SYSTEM_UCUM                 = "http://unitsofmeasure.org"
+	FHIR_SYSTEM_INTERPRETATION       = "http://terminology.hl7.org/CodeSystem/v3-ObservationInterpretation"
+	FHIR_SYSTEM_OBSERVATION_CATEGORY = "http://terminology.hl7.org/CodeSystem/observation-category"
+	FHIR_SYSTEM_DIAGNOSTIC_SERVICE   = "http://terminology.hl7.org/CodeSystem/v2-0074"
+	FHIR_SYSTEM_LIS_CODE             = "urn:orangehealth:lis-code"
+	FHIR_SYSTEM_OMS_ORDER_ID         = "urn:orangehealth:oms-order-id"
+	FHIR_SYSTEM_OMS_TEST_ID          = "urn:orangehealth:oms-test-id"
+	FHIR_SYSTEM_SYSTEM_PATIENT_ID    = "urn:orangehealth:patient-id"
+	FHIR_SYSTEM_SYSTEM_USER_ID       = "urn:orangehealth:user-id"
+)
+
+const (
+	FHIR_STATUS_FINAL                     = "final"
+	FHIR_OBSERVATION_CATEGORY_LAB         = "laboratory"
+	FHIR_DIAGNOSTIC_SERVICE_LAB           = "LAB"
+	FHIR_HTTP_METHOD_PUT                  = "PUT"
+	FHIR_SEARCH_MODE_MATCH                = "match"
+	FHIR_SEARCH_MODE_INCLUDE              = "include"
+	FHIR_GENDER_UNKNOWN                   = "unknown"
+	FHIR_GENDER_OTHER                     = "other"
+	FHIR_INTERPRETATION_NORMAL            = "N"
+	FHIR_INTERPRETATION_ABNORMAL          = "A"
+	FHIR_INTERPRETATION_LOW               = "L"
+	FHIR_INTERPRETATION_HIGH              = "H"
+	FHIR_INTERPRETATION_CRITICAL          = "AA"
+	FHIR_INTERPRETATION_OFF_SCALE         = "IE"
+	FHIR_OBSERVATION_CATEGORY_LAB_DISPLAY = "Laboratory"
+)
+
+// AbnormalityToFhirInterpretation maps in-house abnormality values to v3 ObservationInterpretation codes.
+var AbnormalityToFhirInterpretation = map[string]string{
+	ABNORMALITY_NORMAL:         FHIR_INTERPRETATION_NORMAL,
+	ABNORMALITY_LOWER_ABNORMAL: FHIR_INTERPRETATION_LOW,
+	ABNORMALITY_UPPER_ABNORMAL: FHIR_INTERPRETATION_HIGH,
+	ABNORMALITY_CRITICAL:       FHIR_INTERPRETATION_CRITICAL,
+	ABNORMALITY_IMPROBABLE:     FHIR_INTERPRETATION_OFF_SCALE,
+}
+
+// FhirInterpretationDisplay holds the display text of the interpretation codes we emit.
+var FhirInterpretationDisplay = map[string]string{
+	FHIR_INTERPRETATION_NORMAL:    "Normal",
+	FHIR_INTERPRETATION_ABNORMAL:  "Abnormal",
+	FHIR_INTERPRETATION_LOW:       "Low",
+	FHIR_INTERPRETATION_HIGH:      "High",
+	FHIR_INTERPRETATION_CRITICAL:  "Critical abnormal",
+	FHIR_INTERPRETATION_OFF_SCALE: "Insufficient evidence",
+}
+
+// UomToUcum maps the lower-cased units used on reports to UCUM codes. Units missing here are
+// passed through as-is since most of them are already valid UCUM.
+var UomToUcum = map[string]string{
+	"cells/cumm":    "/uL",
+	"cells/µl":      "/uL",
+	"/cumm":         "/uL",
+	"thou/cumm":     "10*3/uL",
+	"10^3/µl":       "10*3/uL",
+	"10^3/ul":       "10*3/uL",
+	"lakhs/cumm":    "10*5/uL",
+	"mill/cumm":     "10*6/uL",
+	"million/cumm":  "10*6/uL",
+	"millions/cumm": "10*6/uL",
+	"10^6/µl":       "10*6/uL",
+	"10^6/ul":       "10*6/uL",
+	"µg/dl":         "ug/dL",
+	"ug/dl":         "ug/dL",
+	"µg/l":          "ug/L",
+	"µiu/ml":        "u[IU]/mL",
+	"uiu/ml":        "u[IU]/mL",
+	"miu/l":         "m[IU]/L",
+	"miu/ml":        "m[IU]/mL",
+	"iu/l":          "[IU]/L",
+	"iu/ml":         "[IU]/mL",
+	"meq/l":         "meq/L",
+	"mm/hr":         "mm/h",
+	"mm/1st hr":     "mm/h",
+	"sec":           "s",
+	"secs":          "s",
+	"seconds":       "s",
+	"mins":          "min",
+	"minutes":       "min",
+	"ratio":         "{ratio}",
+	"index":         "{index}",
+}
@@ -59,7 +59,8 @@ const (
 
 	CultureInvValueTextPrefix = "<InvestigationResults>"
 
-	InvestigationValue = "InvestigationValue"
+	InvestigationValue        = "InvestigationValue"
+	CultureInvestigationValue = "CultureInvestigationValue"
 )
 
 // Service Names
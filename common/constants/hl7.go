@@ -0,0 +1,62 @@
+package constants
+
+import "time"
+
+var (
+	Hl7ListenAddress       = Config.GetString("hl7.listen_address")
+	Hl7Endpoints           = Config.GetStringMapString("hl7.endpoints")
+	Hl7SendingApplication  = Config.GetString("hl7.sending_application")
+	Hl7SendingFacility     = Config.GetString("hl7.sending_facility")
+	Hl7ConnectTimeout      = Config.GetInt("hl7.connect_timeout")
+	Hl7AckTimeout          = Config.GetInt("hl7.ack_timeout")
+	Hl7SendRetries         = Config.GetInt("hl7.send_retries")
+	Hl7DefaultTimeoutInSec = 10
+	Hl7SendBackoffTime     = 500 * time.Millisecond
+)
+
+const (
+	HL7_VERSION          = "2.5.1"
+	HL7_PROCESSING_ID    = "P"
+	HL7_DATE_TIME_LAYOUT = "20060102150405"
+)
+
+// HL7 Message Types
+const (
+	HL7_MESSAGE_TYPE_ORM_O01 = "ORM^O01"
+	HL7_MESSAGE_TYPE_ORU_R01 = "ORU^R01"
+	HL7_MESSAGE_TYPE_ACK     = "ACK"
+)
+
+// HL7 Order Control Codes (ORC-1)
+const (
+	HL7_ORDER_CONTROL_NEW    = "NW"
+	HL7_ORDER_CONTROL_CANCEL = "CA"
+)
+
+// HL7 Acknowledgment Codes (MSA-1)
+const (
+	HL7_ACK_ACCEPT = "AA"
+	HL7_ACK_ERROR  = "AE"
+	HL7_ACK_REJECT = "AR"
+)
+
+// HL7 Result Statuses (OBR-25 / OBX-11)
+const (
+	HL7_RESULT_STATUS_FINAL       = "F"
+	HL7_RESULT_STATUS_CORRECTED   = "C"
+	HL7_RESULT_STATUS_PRELIMINARY = "P"
+	HL7_RESULT_STATUS_CANCELLED   = "X"
+)
+
+const HL7_VALUE_TYPE_ENCAPSULATED_DATA = "ED"
+
+// Hl7AbnormalFlagToAbnormality maps OBX-8 abnormal flags to in-house abnormality values.
+var Hl7AbnormalFlagToAbnormality = map[string]string{
+	"N":  ABNORMALITY_NORMAL,
+	"L":  ABNORMALITY_LOWER_ABNORMAL,
+	"H":  ABNORMALITY_UPPER_ABNORMAL,
+	"LL": ABNORMALITY_CRITICAL,
+	"HH": ABNORMALITY_CRITICAL,
+	"<":  ABNORMALITY_IMPROBABLE,
+	">":  ABNORMALITY_IMPROBABLE,
+}
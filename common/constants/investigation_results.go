@@ -104,6 +104,14 @@ const (
 	AUTO_APPROVAL_FAIL_REASON_IM_DEVICE                   = "im_device"
 	AUTO_APPROVAL_FAIL_REASON_MANUAL_INPUT                = "manual_input"
 	AUTO_APPROVAL_FAIL_REASON_QC_FAILED                   = "qc_failed"
+	AUTO_APPROVAL_FAIL_REASON_CRITICAL                    = "critical"
+	AUTO_APPROVAL_FAIL_REASON_DEPENDENT_ANALYTE           = "dependent_analyte"
+	AUTO_APPROVAL_FAIL_REASON_PATIENT_CONDITION           = "patient_condition"
+
+	// Separates the failure reason from its detail, e.g. past_record|percentage
+	AUTO_APPROVAL_FAIL_REASON_SEPARATOR = "|"
+	// Separates the failure reasons of every failed auto verification rule, e.g. ref_range;past_record|percentage
+	AUTO_APPROVAL_FAIL_REASONS_SEPARATOR = ";"
 )
 
 var (
@@ -123,6 +131,14 @@ const (
 	ReferenceRangeLabelTextual = "textual"
 )
 
+// Reference Range Age Units
+const (
+	ReferenceRangeAgeUomDays   = "days"
+	ReferenceRangeAgeUomWeeks  = "weeks"
+	ReferenceRangeAgeUomMonths = "months"
+	ReferenceRangeAgeUomYears  = "years"
+)
+
 // Abnormality Statuses
 var (
 	OhAbnormalityStringSlice = []string{
@@ -0,0 +1,29 @@
+package constants
+
+// LIS Vendors
+const (
+	LIS_VENDOR_ATTUNE = "attune"
+	LIS_VENDOR_HL7    = "hl7"
+)
+
+// DefaultLisVendor is used for labs whose CDS master data does not name a LIS vendor.
+const DefaultLisVendor = LIS_VENDOR_ATTUNE
+
+// LIS Test Statuses. Adapters translate vendor statuses to these. The values are the ones ETS already receives
+// as the LIS status of a test.
+const (
+	LIS_TEST_STATUS_ORDERED   = "Ordered"
+	LIS_TEST_STATUS_COMPLETED = "Completed"
+	LIS_TEST_STATUS_APPROVED  = "Approve"
+	LIS_TEST_STATUS_CANCELLED = "Cancel"
+	LIS_TEST_STATUS_RETEST    = "Retest"
+	LIS_TEST_STATUS_RERUN     = "Rerun"
+	LIS_TEST_STATUS_RECHECK   = "Recheck"
+)
+
+// LIS Report Formats
+const (
+	LIS_REPORT_FORMAT_BRANDED    = "branded"    // with branding headers
+	LIS_REPORT_FORMAT_PLAIN      = "plain"      // without branding headers
+	LIS_REPORT_FORMAT_CO_BRANDED = "co_branded" // with co-branding headers
+)
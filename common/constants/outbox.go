@@ -0,0 +1,38 @@
+package constants
+
+// Outbox Message Statuses
+const (
+	OUTBOX_STATUS_PENDING   = "pending"
+	OUTBOX_STATUS_PUBLISHED = "published"
+	// OUTBOX_STATUS_FAILED marks a message that ran out of attempts, it is only published again when replayed.
+	OUTBOX_STATUS_FAILED = "failed"
+)
+
+var OUTBOX_STATUSES = []string{
+	OUTBOX_STATUS_PENDING,
+	OUTBOX_STATUS_PUBLISHED,
+	OUTBOX_STATUS_FAILED,
+}
+
+var (
+	// OutboxMaxAttempts is the number of times the relay tries to publish a message before marking it failed.
+	OutboxMaxAttempts = Config.GetInt("outbox.max_attempts")
+	// OutboxRetryBaseDelaySeconds is the wait before the first retry, doubling with every attempt.
+	OutboxRetryBaseDelaySeconds = Config.GetInt("outbox.retry_base_delay_seconds")
+	// OutboxStuckAfterMinutes is how long a message can wait to be published before it is reported as stuck.
+	OutboxStuckAfterMinutes = Config.GetInt("outbox.stuck_after_minutes")
+)
+
+const (
+	OutboxDefaultMaxAttempts           = 10
+	OutboxDefaultRetryBaseDelaySeconds = 30
+	OutboxMaxRetryDelaySeconds         = 60 * 60
+	OutboxDefaultStuckAfterMinutes     = 15
+	OutboxRelayBatchSize               = 500
+	OutboxDefaultListLimit             = 100
+	// OutboxRelayLockKey is the postgres advisory lock that keeps two relays from claiming messages at once.
+	OutboxRelayLockKey = 7331001
+	// OutboxRelayClaimSeconds is how long claimed messages are held back from other relays while they are
+	// published. The messages of a relay that stops midway become due again once it runs out.
+	OutboxRelayClaimSeconds = 5 * 60
+)
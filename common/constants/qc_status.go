@@ -5,4 +5,48 @@ const (
 	QCStatusFail             = "Fail"
 	QCStatusPass             = "Pass"
 	QCStatusPassWithWarnings = "PassWithWarnings"
+	QCStatusNotEvaluated     = "NotEvaluated"
 )
+
+// Westgard Rules
+const (
+	Westgard12s = "1-2s"
+	Westgard13s = "1-3s"
+	Westgard22s = "2-2s"
+	WestgardR4s = "R-4s"
+	Westgard41s = "4-1s"
+	Westgard10x = "10x"
+)
+
+// WestgardRejectionRules are the rules whose violation fails the QC run. 1-2s is only a warning.
+var WestgardRejectionRules = []string{
+	Westgard13s,
+	Westgard22s,
+	WestgardR4s,
+	Westgard41s,
+	Westgard10x,
+}
+
+// QC Result Sources
+const (
+	QcResultSourceLis = "lis"
+	QcResultSourceApi = "api"
+)
+
+const (
+	WESTGARD_VIOLATIONS_SEPARATOR = ","
+	// QcHistoryLimit is the number of past QC results fetched per lot and analyte, enough for the 10x rule
+	// and for deriving the mean and SD when no target is configured.
+	QcHistoryLimit = 20
+	// QcMinimumPointsForStatistics is the minimum number of past QC results needed to derive the mean and SD.
+	QcMinimumPointsForStatistics = 20
+	QcResultsDefaultLimit        = 50
+	QcResultsMaxLimit            = 500
+)
+
+// QcRerunCandidateInvestigationStatuses are the statuses of the patient results proposed for rerun on a QC failure.
+var QcRerunCandidateInvestigationStatuses = []string{
+	INVESTIGATION_STATUS_PENDING,
+	INVESTIGATION_STATUS_WITHHELD,
+	INVESTIGATION_STATUS_CO_AUTHORIZE,
+}
@@ -0,0 +1,57 @@
+package constants
+
+// Reflex Rule Operators
+const (
+	ReflexOperatorAbnormal = "abnormal"
+	ReflexOperatorCritical = "critical"
+	ReflexOperatorEqual    = "eq"
+	ReflexOperatorGt       = "gt"
+	ReflexOperatorGte      = "gte"
+	ReflexOperatorLt       = "lt"
+	ReflexOperatorLte      = "lte"
+)
+
+var ReflexOperators = []string{
+	ReflexOperatorAbnormal,
+	ReflexOperatorCritical,
+	ReflexOperatorEqual,
+	ReflexOperatorGt,
+	ReflexOperatorGte,
+	ReflexOperatorLt,
+	ReflexOperatorLte,
+}
+
+var ReflexNumericOperators = []string{
+	ReflexOperatorGt,
+	ReflexOperatorGte,
+	ReflexOperatorLt,
+	ReflexOperatorLte,
+}
+
+// Reflex Test Trigger Statuses
+const (
+	ReflexTriggerStatusOrdered = "ordered"
+	ReflexTriggerStatusSkipped = "skipped"
+)
+
+// ReflexTestIdPrefix prefixes the central test id generated for a reflex test, which OMS is expected to use for
+// the test it adds to the order.
+const ReflexTestIdPrefix = "RFX"
+
+// ReflexEligibleSampleStatuses are the statuses of samples which are in the lab and can be reused for a reflex test.
+var ReflexEligibleSampleStatuses = []string{
+	SampleReceived,
+	SamplePartiallyRejected,
+	SampleSynced,
+	SampleAccessioned,
+}
+
+// Reflex Trigger Reasons
+const (
+	ReflexReasonMatched               = "%s %s matched %s"
+	ReflexReasonTestAlreadyOrdered    = "reflex test is already ordered"
+	ReflexReasonNoEligibleSample      = "no received sample linked to the triggering test"
+	ReflexReasonCollectionTimeMissing = "sample collection time is missing"
+	ReflexReasonSampleNotStable       = "sample collected %s ago is beyond the stability of %d hours"
+	ReflexReasonInsufficientVolume    = "remaining sample volume %d is less than the required %d"
+)
@@ -0,0 +1,37 @@
+package constants
+
+// Report Amendment Statuses
+const (
+	ReportAmendmentStatusOpen      = "open"
+	ReportAmendmentStatusApproved  = "approved"
+	ReportAmendmentStatusCancelled = "cancelled"
+)
+
+// Report Amendment Reason Codes
+const (
+	ReportAmendmentReasonTranscriptionError  = "transcription_error"
+	ReportAmendmentReasonInstrumentError     = "instrument_error"
+	ReportAmendmentReasonSampleMixUp         = "sample_mix_up"
+	ReportAmendmentReasonRerunResult         = "rerun_result"
+	ReportAmendmentReasonClinicalCorrelation = "clinical_correlation"
+	ReportAmendmentReasonOther               = "other"
+)
+
+var ReportAmendmentReasonCodes = []string{
+	ReportAmendmentReasonTranscriptionError,
+	ReportAmendmentReasonInstrumentError,
+	ReportAmendmentReasonSampleMixUp,
+	ReportAmendmentReasonRerunResult,
+	ReportAmendmentReasonClinicalCorrelation,
+	ReportAmendmentReasonOther,
+}
+
+// ReportAmendmentInitialReportVersion is the version of a report released without any amendment.
+const ReportAmendmentInitialReportVersion = 1
+
+// ReportAmendmentRetainedAttachmentTypes are the report attachments copied as amended reports when an amendment is
+// approved, so the previous version stays available after the report is regenerated.
+var ReportAmendmentRetainedAttachmentTypes = []string{
+	AttachmentTypeReport,
+	AttachmentTypeMergedOhReport,
+}
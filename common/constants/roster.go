@@ -0,0 +1,36 @@
+package constants
+
+// Roster Weekdays, in the order of time.Weekday
+var ROSTER_WEEKDAYS = []string{
+	"sunday",
+	"monday",
+	"tuesday",
+	"wednesday",
+	"thursday",
+	"friday",
+	"saturday",
+}
+
+// Roster Override Types
+const (
+	ROSTER_OVERRIDE_TYPE_LEAVE   = "leave"
+	ROSTER_OVERRIDE_TYPE_HOLIDAY = "holiday"
+	ROSTER_OVERRIDE_TYPE_SWAP    = "swap"
+)
+
+var ROSTER_OVERRIDE_TYPES = []string{
+	ROSTER_OVERRIDE_TYPE_LEAVE,
+	ROSTER_OVERRIDE_TYPE_HOLIDAY,
+	ROSTER_OVERRIDE_TYPE_SWAP,
+}
+
+// Roster Duty Sources tell whether the doctor on duty comes from the weekly shifts or a date specific override
+const (
+	ROSTER_DUTY_SOURCE_SHIFT    = "shift"
+	ROSTER_DUTY_SOURCE_OVERRIDE = "override"
+)
+
+const (
+	RosterMinutesInDay  = 24 * 60
+	RosterMinutesInWeek = 7 * RosterMinutesInDay
+)
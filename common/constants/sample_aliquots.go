@@ -0,0 +1,16 @@
+package constants
+
+// AliquotEligibleSampleStatuses are the statuses of samples which are in the lab and can be split into aliquots.
+var AliquotEligibleSampleStatuses = []string{
+	SampleReceived,
+	SamplePartiallyRejected,
+	SampleSynced,
+	SampleAccessioned,
+}
+
+// Sample Lineage Types
+const (
+	SampleLineagePrimary  = "primary"
+	SampleLineageAliquot  = "aliquot"
+	SampleLineageTransfer = "transfer"
+)
@@ -0,0 +1,41 @@
+package constants
+
+// Sample Storage Conditions
+const (
+	StorageConditionAmbient      = "ambient"
+	StorageConditionRefrigerated = "refrigerated"
+	StorageConditionFrozen       = "frozen"
+)
+
+var StorageConditions = []string{
+	StorageConditionAmbient,
+	StorageConditionRefrigerated,
+	StorageConditionFrozen,
+}
+
+// Sample Stability Actions
+const (
+	SampleStabilityActionReject = "reject"
+	SampleStabilityActionFlag   = "flag"
+)
+
+var SampleStabilityActions = []string{
+	SampleStabilityActionReject,
+	SampleStabilityActionFlag,
+}
+
+// SampleStabilityReportStatuses are the statuses of samples which are still in the lab waiting to be processed and
+// are listed on the near expiry report.
+var SampleStabilityReportStatuses = []string{
+	SampleReceived,
+	SamplePartiallyRejected,
+	SampleSynced,
+	SampleAccessioned,
+}
+
+const (
+	SampleStabilityDefaultNearExpiryHours uint = 2
+
+	SampleStabilityRejectionReason = "Sample stability exceeded (%s, %d hrs)"
+	SampleStabilityFlagReason      = "sample collected %s ago is beyond the %s stability of %d hours"
+)
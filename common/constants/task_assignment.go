@@ -0,0 +1,52 @@
+package constants
+
+// Task Assignment Types
+const (
+	TASK_ASSIGNMENT_TYPE_AUTO     = "auto"
+	TASK_ASSIGNMENT_TYPE_REASSIGN = "reassign"
+	TASK_ASSIGNMENT_TYPE_OVERRIDE = "override"
+)
+
+// Task Assignment Rejection Reasons explain why a pathologist was not picked for a task
+const (
+	TASK_ASSIGNMENT_REJECTION_NO_COMPETENCE = "no_department_competence"
+	TASK_ASSIGNMENT_REJECTION_AT_CAPACITY   = "at_capacity"
+	TASK_ASSIGNMENT_REJECTION_STALE         = "task_went_stale_with_pathologist"
+)
+
+const (
+	TASK_ASSIGNMENT_REASON_STALE = "task went stale with the previous pathologist"
+)
+
+// TASK_ASSIGNMENT_TASK_STATUSES are the statuses in which a task waits on a pathologist to pick it up
+var TASK_ASSIGNMENT_TASK_STATUSES = []string{
+	TASK_STATUS_PENDING,
+	TASK_STATUS_WITHHELD_APPROVAL,
+}
+
+// TASK_ASSIGNMENT_PENDING_TEST_STATUSES are the statuses of the tests a pathologist has to act upon, whose
+// departments decide who the task can be assigned to.
+var TASK_ASSIGNMENT_PENDING_TEST_STATUSES = []string{
+	TEST_STATUS_RESULT_SAVED,
+	TEST_STATUS_RERUN_RESULT_SAVED,
+	TEST_STATUS_WITHHELD,
+	TEST_STATUS_CO_AUTHORIZE,
+}
+
+const (
+	TASK_ASSIGNMENT_DEPARTMENTS_SEPARATOR = ","
+	// TASK_ASSIGNMENT_ALL_DEPARTMENTS marks a pathologist competent in every department
+	TASK_ASSIGNMENT_ALL_DEPARTMENTS = "*"
+	// TaskAssignmentCompetenceWeight and TaskAssignmentLoadWeight weigh the share of the departments of the task
+	// a pathologist is competent in against their free capacity.
+	TaskAssignmentCompetenceWeight    = 0.6
+	TaskAssignmentLoadWeight          = 0.4
+	TaskAssignmentDefaultMaxOpenTasks = 20
+	TaskAssignmentBatchSize           = 200
+	// TaskAssignmentUrgencyWeight weighs how few open tasks a pathologist has for the critical tasks and the
+	// ones about to breach their doctor TAT, so that they go to the least loaded competent pathologist. Such
+	// tasks may take a pathologist TaskAssignmentUrgentExtraOpenTasks past their capacity.
+	TaskAssignmentUrgencyWeight          = 0.5
+	TaskAssignmentUrgentExtraOpenTasks   = 3
+	TaskAssignmentNearBreachWindowInMins = 60
+)
@@ -0,0 +1,10 @@
+package constants
+
+// Fields compared between the server and the client when a task edit is rejected for a stale row version.
+const (
+	TaskEditConflictFieldStatus             = "status"
+	TaskEditConflictFieldAbnormality        = "abnormality"
+	TaskEditConflictFieldInvestigationValue = "investigation_value"
+	TaskEditConflictFieldInvestigationData  = "investigation_data"
+	TaskEditConflictFieldApprovalSource     = "approval_source"
+)
@@ -0,0 +1,23 @@
+package constants
+
+import "time"
+
+// Task Event Types streamed to the pathologist worklists. TaskEventCreated is published whenever saved results
+// put a task on the worklists, clients refresh the task instead of assuming it is new.
+const (
+	TaskEventCreated         = "task_created"
+	TaskEventClaimed         = "task_claimed"
+	TaskEventReleased        = "task_released"
+	TaskEventAssigned        = "task_assigned"
+	TaskEventCompleted       = "task_completed"
+	TaskEventCriticalFlagged = "task_critical_flagged"
+	TaskEventHeartbeat       = "heartbeat"
+)
+
+// TaskEventsChannel is the redis pub/sub channel shared by the api, consumer and worker processes.
+const TaskEventsChannel = "task_events"
+
+const (
+	TaskEventsHeartbeatInterval = 25 * time.Second
+	TaskEventsClientBufferSize  = 64
+)
@@ -0,0 +1,53 @@
+package constants
+
+// TAT Analytics Group By Dimensions
+const (
+	TatAnalyticsGroupByLab        = "lab"
+	TatAnalyticsGroupByDepartment = "department"
+	TatAnalyticsGroupByTest       = "test"
+	TatAnalyticsGroupByCity       = "city"
+)
+
+var TatAnalyticsGroupByDimensions = []string{
+	TatAnalyticsGroupByLab,
+	TatAnalyticsGroupByDepartment,
+	TatAnalyticsGroupByTest,
+	TatAnalyticsGroupByCity,
+}
+
+// TAT Analytics Time Buckets
+const (
+	TatAnalyticsBucketHour  = "hour"
+	TatAnalyticsBucketDay   = "day"
+	TatAnalyticsBucketWeek  = "week"
+	TatAnalyticsBucketMonth = "month"
+)
+
+var TatAnalyticsBuckets = []string{
+	TatAnalyticsBucketHour,
+	TatAnalyticsBucketDay,
+	TatAnalyticsBucketWeek,
+	TatAnalyticsBucketMonth,
+}
+
+// TAT Stages, in the order a test moves through them
+const (
+	TatStageCollectionToReceipt = "collection_to_receipt"
+	TatStageReceiptToSync       = "receipt_to_sync"
+	TatStageSyncToResult        = "sync_to_result"
+	TatStageResultToApproval    = "result_to_approval"
+	TatStageApprovalToReport    = "approval_to_report"
+	TatStageCollectionToReport  = "collection_to_report"
+)
+
+var TatStages = []string{
+	TatStageCollectionToReceipt,
+	TatStageReceiptToSync,
+	TatStageSyncToResult,
+	TatStageResultToApproval,
+	TatStageApprovalToReport,
+	TatStageCollectionToReport,
+}
+
+// TatAnalyticsMaxRangeDays bounds the number of days of tests a single TAT analytics request aggregates.
+const TatAnalyticsMaxRangeDays = 92
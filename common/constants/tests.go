@@ -160,6 +160,5 @@ var (
 )
 
 var (
-	DutyDoctorMap        = Config.GetStringMap("duty_doctor")
 	Covid19MasterTestIds = Config.GetIntSlice("master_tests.covid_rtpcr_ids")
 )
@@ -18,11 +18,16 @@ const (
 	SampleRejectionTask              = "sample_reject_task"
 	UpdateTaskPostReceivingTask      = "update_task_post_receiving_task"
 	CreateUpdateTaskByOmsOrderIdTask = "create_update_task_by_oms_order_id_task"
+	ReplayDeadLetterEventTask        = "replay_dead_letter_event_task"
+	DeviceResultsTask                = "device_results_task"
 
 	// Periodic Tasks
 	StaleTasksPeriodicTask                        = "stale_tasks_periodic_task"
 	EtsTatBreachedPeriodicTaskName                = "ets_tat_breached_periodic_task"
 	SlackAlertForReverseLogisticsPeriodicTaskName = "slack_alert_for_reverse_logistics_periodic_task"
+	CriticalCallEscalationPeriodicTaskName        = "critical_call_escalation_periodic_task"
+	TaskAutoAssignmentPeriodicTaskName            = "task_auto_assignment_periodic_task"
+	OutboxRelayPeriodicTaskName                   = "outbox_relay_periodic_task"
 )
 
 // periodic tasks frequency
@@ -30,6 +35,9 @@ var (
 	StaleTasksPeriodicTaskFrequency                    = Config.GetString("cron_frequencies.stale_tasks_periodic_task")
 	EtsTatBreachedPeriodicTaskFrequency                = Config.GetString("cron_frequencies.ets_tat_breached_frequency")
 	SlackAlertForReverseLogisticsPeriodicTaskFrequency = Config.GetString("cron_frequencies.slack_alert_for_reverse_logistics_frequency")
+	CriticalCallEscalationPeriodicTaskFrequency        = Config.GetString("cron_frequencies.critical_call_escalation_frequency")
+	TaskAutoAssignmentPeriodicTaskFrequency            = Config.GetString("cron_frequencies.task_auto_assignment_frequency")
+	OutboxRelayPeriodicTaskFrequency                   = Config.GetString("cron_frequencies.outbox_relay_frequency")
 )
 
 var (
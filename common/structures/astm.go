@@ -0,0 +1,16 @@
+package structures
+
+type DeviceResult struct {
+	TestCode     string `json:"test_code"`
+	Value        string `json:"value"`
+	Unit         string `json:"unit"`
+	AbnormalFlag string `json:"abnormal_flag"`
+	ResultStatus string `json:"result_status"`
+	CompletedAt  string `json:"completed_at"`
+}
+
+type DeviceSampleResults struct {
+	Barcode  string         `json:"barcode"`
+	DeviceId string         `json:"device_id"`
+	Results  []DeviceResult `json:"results"`
+}
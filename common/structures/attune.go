@@ -151,6 +151,7 @@ type AttuneOrderInfo struct {
 	QcValue              string                       `json:"QcValue"`
 	QcWestGardWarning    string                       `json:"QcWestGardWarning"`
 	QcStatus             string                       `json:"QcStatus"`
+	LisAbnormality       string                       `json:"LisAbnormality,omitempty"`
 }
 
 type AttuneOrderContentListInfo struct {
@@ -199,6 +200,7 @@ type AttuneOrderContentListInfo struct {
 	QcValue             string                       `json:"QcValue"`
 	QcWestGardWarning   string                       `json:"QcWestGardWarning"`
 	QcStatus            string                       `json:"QcStatus"`
+	LisAbnormality      string                       `json:"LisAbnormality,omitempty"`
 }
 
 type AttuneSyncResponse struct {
@@ -215,20 +217,6 @@ type VisitDocumentInfo struct {
 	VisitDocument string `json:"VisitDocument"`
 }
 
-type LisOrderUpdateDetails struct {
-	LisVisitId        string                                  `json:"LisVisitId"`
-	OrderInfo         map[string]map[string]LisTestUpdateInfo `json:"OrderInfo"`
-	PdfResult         string                                  `json:"PdfResult"`
-	ReportPdfFormat   string                                  `json:"ReportPDFFormat"`
-	VisitDocumentInfo []VisitDocumentInfo                     `json:"VisitDocumentinfo"`
-}
-
-type LisTestUpdateInfo struct {
-	TestCode string          `json:"TestCode"`
-	TestName string          `json:"TestName"`
-	MetaData AttuneOrderInfo `json:"MetaData"`
-}
-
 type AttuneTestDocumentInfo struct {
 	TestDocument string
 }
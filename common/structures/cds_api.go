@@ -16,6 +16,7 @@ type Lab struct {
 	Inhouse              bool                   `json:"inhouse,omitempty"`
 	City                 string                 `json:"city,omitempty"`
 	InterlabTransferMeta []InterlabTransferMeta `json:"interlabTransferMeta,omitempty"`
+	LisVendor            string                 `json:"lisVendor,omitempty"`
 }
 
 type InterlabTransferMeta struct {
@@ -0,0 +1,16 @@
+package structures
+
+import (
+	"time"
+)
+
+type CriticalCallEscalatedEvent struct {
+	CriticalCallId     uint
+	OmsOrderId         string
+	InvestigationName  string
+	InvestigationValue string
+	Uom                string
+	EscalationCount    uint
+	OpenSince          *time.Time
+	CityCode           string
+}
@@ -7,6 +7,8 @@ type EventPayload struct {
 	Contains     string
 	RedisKey     string
 	GroupId      string
+	// SentAt is the time the message was sent to the queue, in unix milliseconds.
+	SentAt string
 }
 
 type ReportReadyEvent struct {
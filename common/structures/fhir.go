@@ -0,0 +1,117 @@
+package structures
+
+type FhirCoding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+type FhirCodeableConcept struct {
+	Coding []FhirCoding `json:"coding,omitempty"`
+	Text   string       `json:"text,omitempty"`
+}
+
+type FhirIdentifier struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+type FhirReference struct {
+	Reference string `json:"reference,omitempty"`
+	Display   string `json:"display,omitempty"`
+}
+
+type FhirQuantity struct {
+	Value  *float64 `json:"value,omitempty"`
+	Unit   string   `json:"unit,omitempty"`
+	System string   `json:"system,omitempty"`
+	Code   string   `json:"code,omitempty"`
+}
+
+type FhirHumanName struct {
+	Text string `json:"text,omitempty"`
+}
+
+type FhirContactPoint struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+type FhirObservationReferenceRange struct {
+	Low  *FhirQuantity `json:"low,omitempty"`
+	High *FhirQuantity `json:"high,omitempty"`
+	Text string        `json:"text,omitempty"`
+}
+
+type FhirPatient struct {
+	ResourceType string             `json:"resourceType"`
+	Id           string             `json:"id"`
+	Identifier   []FhirIdentifier   `json:"identifier,omitempty"`
+	Name         []FhirHumanName    `json:"name,omitempty"`
+	Telecom      []FhirContactPoint `json:"telecom,omitempty"`
+	Gender       string             `json:"gender,omitempty"`
+	BirthDate    string             `json:"birthDate,omitempty"`
+}
+
+type FhirPractitioner struct {
+	ResourceType string             `json:"resourceType"`
+	Id           string             `json:"id"`
+	Identifier   []FhirIdentifier   `json:"identifier,omitempty"`
+	Name         []FhirHumanName    `json:"name,omitempty"`
+	Telecom      []FhirContactPoint `json:"telecom,omitempty"`
+}
+
+type FhirObservation struct {
+	ResourceType   string                          `json:"resourceType"`
+	Id             string                          `json:"id"`
+	Status         string                          `json:"status"`
+	Category       []FhirCodeableConcept           `json:"category,omitempty"`
+	Code           FhirCodeableConcept             `json:"code"`
+	Subject        *FhirReference                  `json:"subject,omitempty"`
+	Issued         string                          `json:"issued,omitempty"`
+	Performer      []FhirReference                 `json:"performer,omitempty"`
+	ValueQuantity  *FhirQuantity                   `json:"valueQuantity,omitempty"`
+	ValueString    string                          `json:"valueString,omitempty"`
+	Interpretation []FhirCodeableConcept           `json:"interpretation,omitempty"`
+	Method         *FhirCodeableConcept            `json:"method,omitempty"`
+	ReferenceRange []FhirObservationReferenceRange `json:"referenceRange,omitempty"`
+}
+
+type FhirDiagnosticReport struct {
+	ResourceType       string                `json:"resourceType"`
+	Id                 string                `json:"id"`
+	Identifier         []FhirIdentifier      `json:"identifier,omitempty"`
+	Status             string                `json:"status"`
+	Category           []FhirCodeableConcept `json:"category,omitempty"`
+	Code               FhirCodeableConcept   `json:"code"`
+	Subject            *FhirReference        `json:"subject,omitempty"`
+	EffectiveDateTime  string                `json:"effectiveDateTime,omitempty"`
+	Issued             string                `json:"issued,omitempty"`
+	Performer          []FhirReference       `json:"performer,omitempty"`
+	ResultsInterpreter []FhirReference       `json:"resultsInterpreter,omitempty"`
+	Result             []FhirReference       `json:"result,omitempty"`
+}
+
+type FhirBundleEntrySearch struct {
+	Mode string `json:"mode"`
+}
+
+type FhirBundleEntryRequest struct {
+	Method string `json:"method"`
+	Url    string `json:"url"`
+}
+
+type FhirBundleEntry struct {
+	FullUrl  string                  `json:"fullUrl,omitempty"`
+	Resource interface{}             `json:"resource"`
+	Search   *FhirBundleEntrySearch  `json:"search,omitempty"`
+	Request  *FhirBundleEntryRequest `json:"request,omitempty"`
+}
+
+type FhirBundle struct {
+	ResourceType string            `json:"resourceType"`
+	Type         string            `json:"type"`
+	Timestamp    string            `json:"timestamp,omitempty"`
+	Total        *int              `json:"total,omitempty"`
+	Entry        []FhirBundleEntry `json:"entry"`
+}
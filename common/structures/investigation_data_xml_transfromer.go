@@ -77,6 +77,7 @@ type Sensitivity struct {
 	Sensitivity   string `json:"sensitivity"`
 	Level         string `json:"level"`
 	MicValue      int    `json:"mic_value"`
+	MicValueText  string `json:"mic_value_text"`
 	DisplayOrder  int    `json:"display_order"`
 }
 
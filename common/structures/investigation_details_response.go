@@ -1,5 +1,7 @@
 package structures
 
+import "time"
+
 type MasterInvestigationResponse struct {
 	InvestigationDetails InvestigationDetails `json:"investigation_details"`
 }
@@ -10,21 +12,22 @@ type InvestigationDetails struct {
 }
 
 type Investigation struct {
-	InvestigationId              uint                 `json:"investigation_id,omitempty"`
-	InvestigationName            string               `json:"investigation_name,omitempty"`
-	InvestigationMethodMappingId uint                 `json:"investigation_method_mapping_id,omitempty"`
-	LisCode                      string               `json:"lis_code,omitempty"`
-	Status                       string               `json:"status,omitempty"`
-	DepartmentName               string               `json:"department_name,omitempty"`
-	Method                       string               `json:"method,omitempty"`
-	Unit                         string               `json:"unit,omitempty"`
-	ResultRepresentationType     string               `json:"result_representation_type,omitempty"`
-	IsNablAccredited             bool                 `json:"is_nabl_accredited,omitempty"`
-	ReferenceRange               MasterReferenceRange `json:"reference_range,omitempty"`
-	ResultType                   string               `json:"result_type,omitempty"`
-	RcvPositive                  float64              `json:"rcv_positive,omitempty"`
-	RcvNegative                  float64              `json:"rcv_negative,omitempty"`
-	PastValueThresholdDays       uint                 `json:"past_value_threshold_days,omitempty"`
+	InvestigationId              uint                       `json:"investigation_id,omitempty"`
+	InvestigationName            string                     `json:"investigation_name,omitempty"`
+	InvestigationMethodMappingId uint                       `json:"investigation_method_mapping_id,omitempty"`
+	LisCode                      string                     `json:"lis_code,omitempty"`
+	Status                       string                     `json:"status,omitempty"`
+	DepartmentName               string                     `json:"department_name,omitempty"`
+	Method                       string                     `json:"method,omitempty"`
+	Unit                         string                     `json:"unit,omitempty"`
+	ResultRepresentationType     string                     `json:"result_representation_type,omitempty"`
+	IsNablAccredited             bool                       `json:"is_nabl_accredited,omitempty"`
+	ReferenceRange               MasterReferenceRange       `json:"reference_range,omitempty"`
+	ReferenceRanges              []StratifiedReferenceRange `json:"reference_ranges,omitempty"`
+	ResultType                   string                     `json:"result_type,omitempty"`
+	RcvPositive                  float64                    `json:"rcv_positive,omitempty"`
+	RcvNegative                  float64                    `json:"rcv_negative,omitempty"`
+	PastValueThresholdDays       uint                       `json:"past_value_threshold_days,omitempty"`
 }
 
 type Panel struct {
@@ -50,6 +53,26 @@ type MasterReferenceRange struct {
 	ReferenceLabel    string `json:"reference_label,omitempty"`
 }
 
+// StratifiedReferenceRange is a reference range applicable to the patients of an age band, gender and
+// pregnancy status. MaxAge is exclusive and zero values leave the stratum open.
+type StratifiedReferenceRange struct {
+	MinAge         uint                 `json:"min_age,omitempty"`
+	MaxAge         uint                 `json:"max_age,omitempty"`
+	AgeUom         string               `json:"age_uom,omitempty"`
+	Gender         string               `json:"gender,omitempty"`
+	IsPregnant     *bool                `json:"is_pregnant,omitempty"`
+	Trimester      uint                 `json:"trimester,omitempty"`
+	ReferenceRange MasterReferenceRange `json:"reference_range"`
+}
+
+// ReferenceRangePatientDetails are the patient details a stratified reference range is selected with.
+type ReferenceRangePatientDetails struct {
+	Dob        *time.Time
+	Gender     string
+	IsPregnant bool
+	Trimester  uint
+}
+
 type Range struct {
 	MinAge             uint   `json:"min_age,omitempty"`
 	MaxAge             uint   `json:"max_age,omitempty"`
@@ -67,9 +90,10 @@ type DependentInvestigationsApiResponse struct {
 }
 
 type DependentInvestigationResponse struct {
-	InvestigationId           uint                 `json:"investigation_id,omitempty"`
-	DependentInvestigationIds []uint               `json:"dependent_investigation_ids,omitempty"`
-	Formula                   string               `json:"formula,omitempty"`
-	Decimals                  uint                 `json:"decimals,omitempty"`
-	ReferenceRange            MasterReferenceRange `json:"reference_range,omitempty" gorm:"-"`
+	InvestigationId           uint                       `json:"investigation_id,omitempty"`
+	DependentInvestigationIds []uint                     `json:"dependent_investigation_ids,omitempty"`
+	Formula                   string                     `json:"formula,omitempty"`
+	Decimals                  uint                       `json:"decimals,omitempty"`
+	ReferenceRange            MasterReferenceRange       `json:"reference_range,omitempty" gorm:"-"`
+	ReferenceRanges           []StratifiedReferenceRange `json:"reference_ranges,omitempty" gorm:"-"`
 }
@@ -0,0 +1,89 @@
+package structures
+
+import (
+	"time"
+)
+
+// LisOrder is a visit as held by a LIS, with the results of its tests. LIS adapters translate vendor payloads
+// to and from it, so the LIS event flow, approvals and reruns do not depend on a vendor format.
+type LisOrder struct {
+	VisitId            string              `json:"visit_id"`
+	OverallStatus      string              `json:"overall_status"`
+	Tests              []LisTestResult     `json:"tests"`
+	VisitDocuments     []VisitDocumentInfo `json:"visit_documents"`
+	ReportPdf          string              `json:"report_pdf"`
+	OutsourceReportPdf string              `json:"outsource_report_pdf"`
+	ReportPdfFormat    string              `json:"report_pdf_format"`
+}
+
+// LisTestResult is the result of a test, or of a parameter of a panel. Panels hold their parameters, which can
+// be panels themselves, in Parameters. TestStatus is one of the LIS_TEST_STATUS constants.
+type LisTestResult struct {
+	TestId            string          `json:"test_id"`
+	TestCode          string          `json:"test_code"`
+	TestName          string          `json:"test_name"`
+	TestType          string          `json:"test_type"`
+	TestStatus        string          `json:"test_status"`
+	TestValue         string          `json:"test_value"`
+	DeviceActualValue string          `json:"device_actual_value"`
+	Unit              string          `json:"unit"`
+	MethodName        string          `json:"method_name"`
+	DepartmentName    string          `json:"department_name"`
+	ReferenceRange    string          `json:"reference_range"`
+	LisAbnormality    string          `json:"lis_abnormality"`
+	DeviceId          string          `json:"device_id"`
+	IMDevice          string          `json:"im_device"`
+	IMDeviceFlag      string          `json:"im_device_flag"`
+	MedicalRemarks    string          `json:"medical_remarks"`
+	TechnicalRemarks  string          `json:"technical_remarks"`
+	RerunReason       string          `json:"rerun_reason"`
+	RerunRemarks      string          `json:"rerun_remarks"`
+	RerunTime         string          `json:"rerun_time"`
+	ResultCapturedAt  string          `json:"result_captured_at"`
+	ResultCapturedBy  int             `json:"result_captured_by"`
+	ResultApprovedAt  *string         `json:"result_approved_at"`
+	ResultApprovedBy  int             `json:"result_approved_by"`
+	LisUserId         int             `json:"lis_user_id"`
+	SampleName        string          `json:"sample_name"`
+	Barcode           string          `json:"barcode"`
+	QcFlag            string          `json:"qc_flag"`
+	QcLotNumber       string          `json:"qc_lot_number"`
+	QcValue           string          `json:"qc_value"`
+	QcWestGardWarning string          `json:"qc_west_gard_warning"`
+	QcStatus          string          `json:"qc_status"`
+	TestDocuments     []string        `json:"test_documents"`
+	Parameters        []LisTestResult `json:"parameters"`
+}
+
+// LisOrderRequest is an order to register with a LIS: the visit, its patient and the tests with their barcodes.
+type LisOrderRequest struct {
+	VisitId       string         `json:"visit_id"`
+	PatientId     string         `json:"patient_id"`
+	PatientName   string         `json:"patient_name"`
+	PatientGender string         `json:"patient_gender"`
+	PatientDob    *time.Time     `json:"patient_dob"`
+	CollectedAt   *time.Time     `json:"collected_at"`
+	Tests         []LisOrderTest `json:"tests"`
+}
+
+type LisOrderTest struct {
+	TestId   uint     `json:"test_id"`
+	TestCode string   `json:"test_code"`
+	TestName string   `json:"test_name"`
+	TestType string   `json:"test_type"`
+	Barcodes []string `json:"barcodes"`
+}
+
+type LisOrderUpdateDetails struct {
+	LisVisitId        string                                  `json:"LisVisitId"`
+	OrderInfo         map[string]map[string]LisTestUpdateInfo `json:"OrderInfo"`
+	PdfResult         string                                  `json:"PdfResult"`
+	ReportPdfFormat   string                                  `json:"ReportPDFFormat"`
+	VisitDocumentInfo []VisitDocumentInfo                     `json:"VisitDocumentinfo"`
+}
+
+type LisTestUpdateInfo struct {
+	TestCode string        `json:"TestCode"`
+	TestName string        `json:"TestName"`
+	MetaData LisTestResult `json:"MetaData"`
+}
@@ -46,6 +46,8 @@ type OmsPatientDetails struct {
 	PatientId   string     `json:"patient_id"`
 	ExpectedDob *time.Time `json:"expected_dob"`
 	Dob         *time.Time `json:"dob"`
+	IsPregnant  bool       `json:"is_pregnant"`
+	Trimester   uint       `json:"trimester"`
 }
 
 type OmsTestStruct struct {
@@ -147,6 +149,7 @@ type AttuneOrderContentListInfoEvent struct {
 	QcWestGardWarning string                   `json:"QcWestGardWarning"`
 	QcStatus          string                   `json:"QcStatus"`
 	TestDocumentInfo  []AttuneTestDocumentInfo `json:"TestDocumentInfo"`
+	LisAbnormality    string                   `json:"LisAbnormality,omitempty"`
 }
 
 type InitialTestDetails struct {
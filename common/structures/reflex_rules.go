@@ -0,0 +1,20 @@
+package structures
+
+type ReflexTestAddedEvent struct {
+	OmsOrderId string
+	CityCode   string
+	Tests      []ReflexTestAddedEventTest
+}
+
+type ReflexTestAddedEventTest struct {
+	TestId            string `json:"test_id"`
+	MasterTestId      uint   `json:"master_test_id"`
+	TestCode          string `json:"test_code"`
+	TestName          string `json:"test_name"`
+	LabId             uint   `json:"lab_id"`
+	SampleNumber      uint   `json:"sample_number"`
+	VialTypeId        uint   `json:"vial_type_id"`
+	TriggeredByTestId string `json:"triggered_by_test_id"`
+	ReflexRuleId      uint   `json:"reflex_rule_id"`
+	Reason            string `json:"reason"`
+}
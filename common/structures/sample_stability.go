@@ -0,0 +1,16 @@
+package structures
+
+import "time"
+
+// SampleStabilityCheck is the outcome of the stability rules for one sample.
+type SampleStabilityCheck struct {
+	SampleId              uint
+	SampleStabilityRuleId uint
+	StorageCondition      string
+	StabilityHours        uint
+	NearExpiryHours       uint
+	Action                string
+	ExpiresAt             *time.Time
+	IsExpired             bool
+	Reason                string
+}
@@ -35,6 +35,9 @@ type SampleInfo struct {
 	OutsourcedAt                *time.Time `json:"outsourced_at,omitempty"`
 	RejectingLab                uint       `json:"rejecting_lab,omitempty"`
 	CollectedVolume             uint       `json:"collected_volume,omitempty"`
+	StorageCondition            string     `json:"storage_condition,omitempty"`
+	StabilityFlaggedAt          *time.Time `json:"stability_flagged_at,omitempty"`
+	StabilityFlagReason         string     `json:"stability_flag_reason,omitempty"`
 	TransferredSampleReceivedAt *time.Time `json:"transferred_sample_received_at,omitempty"`
 	CreatedAt                   *time.Time `json:"created_at"`
 	CreatedBy                   uint       `json:"created_by"`
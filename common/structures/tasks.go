@@ -5,6 +5,7 @@ import "time"
 type TaskIdPreviousStateStruct struct {
 	TaskId         uint
 	PreviousStatus string
+	PathologistId  uint
 }
 
 type VisitDetailsForTask struct {
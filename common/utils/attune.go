@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/Orange-Health/citadel/common/constants"
@@ -17,10 +16,10 @@ func ValidatePayload(lisOrderUpdateDetails structures.LisOrderUpdateDetails,
 
 	valueMap, testCodePresentMap, testCodeNameMap, doctorDetailsMissingTests :=
 		createValueMapAndTestCodeMapForInvestigations(lisOrderUpdateDetails, omsTestDetails,
-			constants.AttuneTestStatusApprove, valueMap, testCodePresentMap, testCodeNameMap, attunePathologistsUserIds)
+			constants.LIS_TEST_STATUS_APPROVED, valueMap, testCodePresentMap, testCodeNameMap, attunePathologistsUserIds)
 	valueMap, testCodePresentMap, testCodeNameMap, _ =
 		createValueMapAndTestCodeMapForInvestigations(lisOrderUpdateDetails, omsTestDetails,
-			constants.AttuneTestStatusCompleted, valueMap, testCodePresentMap, testCodeNameMap, attunePathologistsUserIds)
+			constants.LIS_TEST_STATUS_COMPLETED, valueMap, testCodePresentMap, testCodeNameMap, attunePathologistsUserIds)
 
 	// Missing Parameters
 	missingTestsInCds, missingTestsInLis := checkForMissingParameters(testCodePresentMap, testCodeMimmMap, mimmTestCodeMap)
@@ -93,12 +92,12 @@ func checkIfTestValueHasIncorrectResultType(testValue string, resultType string)
 }
 
 func createValueMapAndTestCodeMapForInvestigations(lisOrderUpdateDetails structures.LisOrderUpdateDetails,
-	testDetails []structures.TestDetailsForLisEvent, attuneStatus string, valueMap map[string]string,
+	testDetails []structures.TestDetailsForLisEvent, lisStatus string, valueMap map[string]string,
 	testCodePresentMap map[string]bool, testCodeNameMap map[string]string, attunePathologistsUserIds []string) (
 	map[string]string, map[string]bool, map[string]string, []string) {
 
 	doctorDetailsMissingTests := []string{}
-	orderInfo := lisOrderUpdateDetails.OrderInfo[attuneStatus]
+	orderInfo := lisOrderUpdateDetails.OrderInfo[lisStatus]
 
 	for _, test := range testDetails {
 		if testResults, keyExists := orderInfo[test.TestCode]; keyExists {
@@ -111,44 +110,27 @@ func createValueMapAndTestCodeMapForInvestigations(lisOrderUpdateDetails structu
 				valueMap[orderInfo.TestCode] = orderInfo.TestValue
 				testCodePresentMap[orderInfo.TestCode] = true
 				testCodeNameMap[orderInfo.TestCode] = orderInfo.TestName
-				if attuneStatus == constants.AttuneTestStatusApprove {
+				if lisStatus == constants.LIS_TEST_STATUS_APPROVED {
 					if resultApprovedBy == 0 || !SliceContainsString(attunePathologistsUserIds, resultApprovedByString) {
 						doctorDetailsMissingTests = append(doctorDetailsMissingTests, orderInfo.TestName)
 					}
 				}
 			} else if orderInfo.TestType == constants.GroupShortHand {
-				index, queue := 0, []interface{}{}
-				queue = append(queue, orderInfo.OrderContentListInfo)
-
-				if attuneStatus == constants.AttuneTestStatusApprove {
+				if lisStatus == constants.LIS_TEST_STATUS_APPROVED {
 					if resultApprovedBy == 0 || !SliceContainsString(attunePathologistsUserIds, resultApprovedByString) {
 						doctorDetailsMissingTests = append(doctorDetailsMissingTests, orderInfo.TestName)
 					}
 				}
 
-				for index < len(queue) {
-					currentNode := queue[index]
-					index += 1
-					marshalledNode, err := json.Marshal(currentNode)
-					if err != nil {
-						continue
-					}
-
-					attuneOrderContentListInfo := []structures.AttuneOrderContentListInfo{}
-					err = json.Unmarshal(marshalledNode, &attuneOrderContentListInfo)
-					if err != nil {
-						continue
-					}
-
-					for _, orderInfo := range attuneOrderContentListInfo {
-						switch orderInfo.TestType {
-						case constants.InvestigationShortHand:
-							valueMap[orderInfo.TestCode] = orderInfo.TestValue
-							testCodePresentMap[orderInfo.TestCode] = true
-							testCodeNameMap[orderInfo.TestCode] = orderInfo.TestName
-						case constants.GroupShortHand:
-							queue = append(queue, orderInfo.ParameterListInfo)
-						}
+				queue := append([]structures.LisTestResult{}, orderInfo.Parameters...)
+				for index := 0; index < len(queue); index++ {
+					switch queue[index].TestType {
+					case constants.InvestigationShortHand:
+						valueMap[queue[index].TestCode] = queue[index].TestValue
+						testCodePresentMap[queue[index].TestCode] = true
+						testCodeNameMap[queue[index].TestCode] = queue[index].TestName
+					case constants.GroupShortHand:
+						queue = append(queue, queue[index].Parameters...)
 					}
 				}
 			}
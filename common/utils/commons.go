@@ -118,6 +118,16 @@ func GetAgeYearsMonthsAndDaysFromDob(dob time.Time) (uint, uint, uint) {
 	return uint(ageYears), uint(ageMonths), uint(ageDays)
 }
 
+func GetAgeDaysFromDob(dob time.Time) uint {
+	currentTime := time.Now()
+	dayStartingTime := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 0, 0, 0, 0, currentTime.Location())
+
+	if dob.After(dayStartingTime) {
+		return 0
+	}
+	return uint(dayStartingTime.Sub(dob).Hours() / 24)
+}
+
 func GetAgeYearsFromDob(dob time.Time) uint {
 	currentTime := time.Now()
 	dayStartingTime := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 0, 0, 0, 0, currentTime.Location())
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Orange-Health/citadel/common/constants"
@@ -35,3 +36,63 @@ func GetAttuneOrgCodeByLabId(labId uint) string {
 func IsQcEnabledForLab(labId uint) bool {
 	return SliceContainsInt(constants.QcEnabledLabIds, int(labId))
 }
+
+// GetAutoApprovalFailureReasonCode strips the detail from the first failure reason,
+// e.g. past_record|percentage;ref_range -> past_record
+func GetAutoApprovalFailureReasonCode(failureReason string) string {
+	firstFailureReason := strings.SplitN(failureReason, constants.AUTO_APPROVAL_FAIL_REASONS_SEPARATOR, 2)[0]
+	return strings.SplitN(firstFailureReason, constants.AUTO_APPROVAL_FAIL_REASON_SEPARATOR, 2)[0]
+}
+
+// GetAutoApprovalFailureReasonDetail returns the detail of the first failure reason,
+// e.g. past_record|percentage -> percentage
+func GetAutoApprovalFailureReasonDetail(failureReason string) string {
+	firstFailureReason := strings.SplitN(failureReason, constants.AUTO_APPROVAL_FAIL_REASONS_SEPARATOR, 2)[0]
+	reasonParts := strings.SplitN(firstFailureReason, constants.AUTO_APPROVAL_FAIL_REASON_SEPARATOR, 2)
+	if len(reasonParts) < 2 {
+		return ""
+	}
+	return reasonParts[1]
+}
+
+// GetAutoApprovalFailureReasons splits the failure reasons of every failed auto verification rule.
+func GetAutoApprovalFailureReasons(failureReason string) []string {
+	failureReasons := []string{}
+	for _, reason := range strings.Split(failureReason, constants.AUTO_APPROVAL_FAIL_REASONS_SEPARATOR) {
+		if reason != "" && reason != constants.AUTO_APPROVAL_FAIL_REASON_NA {
+			failureReasons = append(failureReasons, reason)
+		}
+	}
+	return failureReasons
+}
+
+func HasAutoApprovalFailureReasonCode(failureReason, failureReasonCode string) bool {
+	for _, reason := range GetAutoApprovalFailureReasons(failureReason) {
+		if GetAutoApprovalFailureReasonCode(reason) == failureReasonCode {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaceAutoApprovalFailureReason replaces the failure reasons with the given code by the new failure reason,
+// which is dropped if empty. AUTO_APPROVAL_FAIL_REASON_NA is returned if no failure reason is left.
+func ReplaceAutoApprovalFailureReason(failureReason, failureReasonCode, newFailureReason string) string {
+	failureReasons := []string{}
+	for _, reason := range GetAutoApprovalFailureReasons(failureReason) {
+		if GetAutoApprovalFailureReasonCode(reason) != failureReasonCode {
+			failureReasons = append(failureReasons, reason)
+		}
+	}
+	if newFailureReason != "" {
+		failureReasons = append(failureReasons, newFailureReason)
+	}
+	return JoinAutoApprovalFailureReasons(failureReasons)
+}
+
+func JoinAutoApprovalFailureReasons(failureReasons []string) string {
+	if len(failureReasons) == 0 {
+		return constants.AUTO_APPROVAL_FAIL_REASON_NA
+	}
+	return strings.Join(CreateUniqueSliceString(failureReasons), constants.AUTO_APPROVAL_FAIL_REASONS_SEPARATOR)
+}
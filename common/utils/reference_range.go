@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+)
+
+func GetReferenceRangePatientDetails(dob, expectedDob *time.Time, gender string, isPregnant bool,
+	trimester uint) structures.ReferenceRangePatientDetails {
+
+	patientDob := dob
+	if patientDob == nil {
+		patientDob = expectedDob
+	}
+	return structures.ReferenceRangePatientDetails{
+		Dob:        patientDob,
+		Gender:     GetGenderConstant(gender),
+		IsPregnant: isPregnant,
+		Trimester:  trimester,
+	}
+}
+
+// ResolveReferenceRange selects the stratified reference range of the patient. When several strata match, the
+// most specific one wins and the first of them in order of the list on a tie. The default range is returned
+// when no stratum matches.
+func ResolveReferenceRange(defaultReferenceRange structures.MasterReferenceRange,
+	stratifiedReferenceRanges []structures.StratifiedReferenceRange,
+	patientDetails structures.ReferenceRangePatientDetails) structures.MasterReferenceRange {
+
+	referenceRange, bestSpecificity := defaultReferenceRange, -1
+	for _, stratifiedReferenceRange := range stratifiedReferenceRanges {
+		if !isReferenceRangeApplicable(stratifiedReferenceRange, patientDetails) {
+			continue
+		}
+		specificity := getReferenceRangeSpecificity(stratifiedReferenceRange)
+		if specificity > bestSpecificity {
+			referenceRange, bestSpecificity = stratifiedReferenceRange.ReferenceRange, specificity
+		}
+	}
+
+	return referenceRange
+}
+
+func ResolveInvestigationReferenceRanges(investigations []structures.Investigation,
+	patientDetails structures.ReferenceRangePatientDetails) []structures.Investigation {
+
+	for index, investigation := range investigations {
+		investigations[index].ReferenceRange = ResolveReferenceRange(investigation.ReferenceRange,
+			investigation.ReferenceRanges, patientDetails)
+	}
+	return investigations
+}
+
+func ResolveDependentInvestigationReferenceRanges(
+	dependentInvestigations structures.DependentInvestigationsApiResponse,
+	patientDetails structures.ReferenceRangePatientDetails) structures.DependentInvestigationsApiResponse {
+
+	dependentInvestigations.Investigation.ReferenceRange = ResolveReferenceRange(
+		dependentInvestigations.Investigation.ReferenceRange, dependentInvestigations.Investigation.ReferenceRanges,
+		patientDetails)
+	for index, derivedInvestigation := range dependentInvestigations.DerivedInvestigations {
+		dependentInvestigations.DerivedInvestigations[index].ReferenceRange = ResolveReferenceRange(
+			derivedInvestigation.ReferenceRange, derivedInvestigation.ReferenceRanges, patientDetails)
+	}
+	return dependentInvestigations
+}
+
+func isReferenceRangeApplicable(stratifiedReferenceRange structures.StratifiedReferenceRange,
+	patientDetails structures.ReferenceRangePatientDetails) bool {
+
+	if stratifiedReferenceRange.Gender != "" &&
+		getReferenceRangeGender(stratifiedReferenceRange.Gender) != patientDetails.Gender {
+		return false
+	}
+	if stratifiedReferenceRange.IsPregnant != nil && *stratifiedReferenceRange.IsPregnant != patientDetails.IsPregnant {
+		return false
+	}
+	if stratifiedReferenceRange.Trimester != 0 && stratifiedReferenceRange.Trimester != patientDetails.Trimester {
+		return false
+	}
+
+	if stratifiedReferenceRange.MinAge == 0 && stratifiedReferenceRange.MaxAge == 0 {
+		return true
+	}
+	if patientDetails.Dob == nil {
+		return false
+	}
+	age, ok := getAgeInUom(*patientDetails.Dob, stratifiedReferenceRange.AgeUom)
+	if !ok {
+		return false
+	}
+	if age < stratifiedReferenceRange.MinAge {
+		return false
+	}
+	if stratifiedReferenceRange.MaxAge != 0 && age >= stratifiedReferenceRange.MaxAge {
+		return false
+	}
+	return true
+}
+
+func getReferenceRangeSpecificity(stratifiedReferenceRange structures.StratifiedReferenceRange) int {
+	specificity := 0
+	if stratifiedReferenceRange.Gender != "" {
+		specificity++
+	}
+	if stratifiedReferenceRange.IsPregnant != nil {
+		specificity += 2
+	}
+	if stratifiedReferenceRange.Trimester != 0 {
+		specificity++
+	}
+	if stratifiedReferenceRange.MinAge != 0 {
+		specificity++
+	}
+	if stratifiedReferenceRange.MaxAge != 0 {
+		specificity++
+	}
+	return specificity
+}
+
+func getReferenceRangeGender(gender string) string {
+	if len(gender) == 1 {
+		return strings.ToUpper(gender)
+	}
+	return GetGenderConstant(gender)
+}
+
+func getAgeInUom(dob time.Time, ageUom string) (uint, bool) {
+	if dob.After(time.Now()) {
+		return 0, true
+	}
+	switch strings.ToLower(strings.TrimSpace(ageUom)) {
+	case constants.ReferenceRangeAgeUomDays:
+		return GetAgeDaysFromDob(dob), true
+	case constants.ReferenceRangeAgeUomWeeks:
+		return GetAgeDaysFromDob(dob) / 7, true
+	case constants.ReferenceRangeAgeUomMonths:
+		ageYears, ageMonths, _ := GetAgeYearsMonthsAndDaysFromDob(dob)
+		return ageYears*12 + ageMonths, true
+	case constants.ReferenceRangeAgeUomYears, "":
+		return GetAgeYearsFromDob(dob), true
+	}
+	return 0, false
+}
@@ -6,4 +6,6 @@ var (
 	NumericResultTypeRegex          = regexp.MustCompile(`^\d+(\.\d+)?$`)
 	TextualResultTypeRegex          = regexp.MustCompile(`^[a-zA-Z0-9\(\)\.\+\-:&_,%"<>=\s,/;#@\[\]{}!?’‘“”'&nbsp;]+$`)
 	SemiQuantitativeResultTypeRegex = regexp.MustCompile(`^(?:[<>]=?|=)?\s*\d+(?:\.\d+)?(?:[:-]\d+)?$`)
+	QualifiedNumericValueRegex      = regexp.MustCompile(`^\s*([<>]=?)\s*(-?\d+(?:\.\d+)?)\s*$`)
+	FormulaPlaceholderRegex         = regexp.MustCompile(`\{\{\s*(\d+)\s*(?:\|\s*([^{}]+?)\s*)?\}\}`)
 )
@@ -151,7 +151,7 @@ func GetSlackMessageForDoctorDetailsMissing(patientName, visitId, cityCode strin
 }
 
 func getAlertMessageForFailureReason(failureReason string) string {
-	switch failureReason {
+	switch GetAutoApprovalFailureReasonCode(failureReason) {
 	case constants.AUTO_APPROVAL_FAIL_REASON_PAST_RECORD:
 		return constants.AA_FAIL_ALERT_PAST_RECORD
 	case constants.AUTO_APPROVAL_FAIL_REASON_REF_RANGE:
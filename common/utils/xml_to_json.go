@@ -2,10 +2,8 @@ package utils
 
 import (
 	"context"
-	"encoding/json"
 	"encoding/xml"
 	"errors"
-	"fmt"
 	"strconv"
 	"strings"
 
@@ -13,10 +11,12 @@ import (
 	"github.com/Orange-Health/citadel/common/structures"
 )
 
-func ParseXMLToJSON(ctx context.Context, data string) (string, error) {
+// ParseCultureResultsXML converts an Attune culture result, stored as XML in the test value, into its
+// structured form. ok is false when data is not a culture result or cannot be parsed.
+func ParseCultureResultsXML(ctx context.Context, data string) (structures.TransformedInvestigationResults, bool) {
 	if !strings.HasPrefix(data, constants.CultureInvValueTextPrefix) {
 		AddLog(ctx, constants.DEBUG_LEVEL, GetCurrentFunctionName(), nil, errors.New("data does not start with expected struct"))
-		return data, nil
+		return structures.TransformedInvestigationResults{}, false
 	}
 
 	xmlBytes := []byte(data)
@@ -25,75 +25,66 @@ func ParseXMLToJSON(ctx context.Context, data string) (string, error) {
 	err := xml.Unmarshal(xmlBytes, &investigationResults)
 	if err != nil {
 		AddLog(ctx, constants.DEBUG_LEVEL, GetCurrentFunctionName(), nil, err)
-		return data, err
+		return structures.TransformedInvestigationResults{}, false
 	}
 
 	if len(investigationResults.InvestigationDetails) == 0 {
 		AddLog(ctx, constants.DEBUG_LEVEL, GetCurrentFunctionName(), nil, errors.New("no investigation details found"))
-		return data, nil
+		return structures.TransformedInvestigationResults{}, false
 	}
 
-	jsonInvestigationResults := structures.TransformedInvestigationResults{
-		ReportStatus:       investigationResults.InvestigationDetails[0].ReportStatus,
-		ClinicalHistory:    investigationResults.InvestigationDetails[0].ClinicalHistory,
-		Gross:              investigationResults.InvestigationDetails[0].Gross,
-		SampleType:         investigationResults.InvestigationDetails[0].SampleName,
-		IncubationPeriod:   investigationResults.InvestigationDetails[0].InCubPeriod,
-		CultureStainResult: investigationResults.InvestigationDetails[0].CultureStainType,
-		CultureReport:      investigationResults.InvestigationDetails[0].CultureReport,
-		ColonyCount:        investigationResults.InvestigationDetails[0].Colonycount,
-		ResistanceDetected: investigationResults.InvestigationDetails[0].ResistanceDetected,
+	investigationDetails := investigationResults.InvestigationDetails[0]
+	cultureResults := structures.TransformedInvestigationResults{
+		ReportStatus:       investigationDetails.ReportStatus,
+		ClinicalHistory:    investigationDetails.ClinicalHistory,
+		Gross:              investigationDetails.Gross,
+		SampleType:         investigationDetails.SampleName,
+		IncubationPeriod:   investigationDetails.InCubPeriod,
+		CultureStainResult: investigationDetails.CultureStainType,
+		CultureReport:      investigationDetails.CultureReport,
+		ColonyCount:        investigationDetails.Colonycount,
+		ResistanceDetected: investigationDetails.ResistanceDetected,
 		StainResultDetails: []structures.StainResultDetail{},
 		GrowthData:         []structures.MicrorganismGrowthData{},
 	}
 
-	for _, stainDetail := range investigationResults.InvestigationDetails[0].StainDetails[0].Stain {
-		jsonInvestigationResults.StainResultDetails = append(jsonInvestigationResults.StainResultDetails,
-			structures.StainResultDetail{
-				Type:   stainDetail.Type,
-				Result: stainDetail.Result,
-			})
+	for _, stainDetails := range investigationDetails.StainDetails {
+		for _, stainDetail := range stainDetails.Stain {
+			cultureResults.StainResultDetails = append(cultureResults.StainResultDetails,
+				structures.StainResultDetail{
+					Type:   stainDetail.Type,
+					Result: stainDetail.Result,
+				})
+		}
 	}
 
-	microOrganismDataMap := map[string]structures.MicrorganismGrowthData{}
-
-	for _, organDetail := range investigationResults.InvestigationDetails[0].OrganDetails[0].Organ {
-		if _, ok := microOrganismDataMap[organDetail.Name]; !ok {
-			microOrganismDataMap[organDetail.Name] = structures.MicrorganismGrowthData{
-				Microorganism:      organDetail.Name,
-				ColonyCount:        organDetail.ColonyCount,
-				Family:             organDetail.Family,
-				FamilyDisplayOrder: nil,
-				Sensitivity:        []structures.Sensitivity{},
+	// organisms are kept in the order they were first reported in
+	microorganismIndexMap := map[string]int{}
+	for _, organDetails := range investigationDetails.OrganDetails {
+		for _, organDetail := range organDetails.Organ {
+			if _, ok := microorganismIndexMap[organDetail.Name]; !ok {
+				microorganismIndexMap[organDetail.Name] = len(cultureResults.GrowthData)
+				cultureResults.GrowthData = append(cultureResults.GrowthData, structures.MicrorganismGrowthData{
+					Microorganism:      organDetail.Name,
+					ColonyCount:        organDetail.ColonyCount,
+					Family:             organDetail.Family,
+					FamilyDisplayOrder: nil,
+					Sensitivity:        []structures.Sensitivity{},
+				})
 			}
+			micValue, _ := strconv.Atoi(organDetail.Zone)
+			displayOrder, _ := strconv.Atoi(organDetail.NameSeq)
+			growthData := &cultureResults.GrowthData[microorganismIndexMap[organDetail.Name]]
+			growthData.Sensitivity = append(growthData.Sensitivity, structures.Sensitivity{
+				Antimicrobial: organDetail.DrugName,
+				Sensitivity:   organDetail.Sensitivity,
+				Level:         organDetail.Level,
+				MicValue:      micValue,
+				MicValueText:  strings.TrimSpace(organDetail.Zone),
+				DisplayOrder:  displayOrder,
+			})
 		}
-		micValue, _ := strconv.Atoi(organDetail.Zone)
-		displayOrder, _ := strconv.Atoi(organDetail.NameSeq)
-		sensitivity := structures.Sensitivity{
-			Antimicrobial: organDetail.DrugName,
-			Sensitivity:   organDetail.Sensitivity,
-			Level:         organDetail.Level,
-			MicValue:      micValue,
-			DisplayOrder:  displayOrder,
-		}
-		temp := microOrganismDataMap[organDetail.Name]
-		temp.Sensitivity = append(microOrganismDataMap[organDetail.Name].Sensitivity, sensitivity)
-		microOrganismDataMap[organDetail.Name] = temp
-	}
-	growthData := []structures.MicrorganismGrowthData{}
-	for _, value := range microOrganismDataMap {
-		growthData = append(growthData, value)
-	}
-
-	jsonInvestigationResults.GrowthData = growthData
-
-	jsonBytes, err := json.Marshal(jsonInvestigationResults)
-	if err != nil {
-		AddLog(ctx, constants.ERROR_LEVEL, GetCurrentFunctionName(), map[string]interface{}{
-			"investigation_details": fmt.Sprintf("%+v", investigationResults),
-		}, err)
-		return data, err
 	}
 
-	return string(jsonBytes), nil
+	return cultureResults, true
 }
@@ -46,6 +46,11 @@ func (ctp *CommonTaskProcessor) CheckForTaskCompletion(ctx context.Context, task
 		_, cErr = ctp.TaskService.UpdateTask(task)
 		if cErr != nil {
 			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, errors.New(cErr.Message))
+			return
+		}
+
+		if task.PreviousStatus != constants.TASK_STATUS_COMPLETED {
+			ctp.TaskEventsService.PublishTaskEvent(ctx, constants.TaskEventCompleted, task.Id, 0)
 		}
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"gorm.io/gorm"
+
 	"github.com/Orange-Health/citadel/common/constants"
 	"github.com/Orange-Health/citadel/common/structures"
 	"github.com/Orange-Health/citadel/common/utils"
@@ -11,7 +13,7 @@ import (
 )
 
 func (ctp *CommonTaskProcessor) ReleaseReportTask(ctx context.Context, taskId uint, forcefulUpdate bool) error {
-	reportReleaseTestIds, attuneTestIds := []string{}, []uint{}
+	reportReleaseTestIds, reportReleaseTestDetailsIds, attuneTestIds := []string{}, []uint{}, []uint{}
 	attuneTestDetails := []models.TestDetail{}
 
 	task, cErr := ctp.TaskService.GetTaskModelById(taskId)
@@ -24,9 +26,18 @@ func (ctp *CommonTaskProcessor) ReleaseReportTask(ctx context.Context, taskId ui
 		return errors.New(cErr.Message)
 	}
 
+	blockedTestDetailsIds, err := ctp.getTestDetailsIdsWithOpenCriticalCalls(testDetails)
+	if err != nil {
+		return err
+	}
+
 	for _, testDetail := range testDetails {
 		if testDetail.Status == constants.TEST_STATUS_APPROVE {
+			if utils.SliceContainsUint(blockedTestDetailsIds, testDetail.Id) {
+				continue
+			}
 			reportReleaseTestIds = append(reportReleaseTestIds, testDetail.CentralOmsTestId)
+			reportReleaseTestDetailsIds = append(reportReleaseTestDetailsIds, testDetail.Id)
 			if !testDetail.CpEnabled {
 				continue
 			}
@@ -89,63 +100,121 @@ func (ctp *CommonTaskProcessor) ReleaseReportTask(ctx context.Context, taskId ui
 		return errors.New(cErr.Message)
 	}
 
-	visitIdToAttuneResponseMap, cErr := ctp.TaskService.FetchAttuneDataForApprovingTests(ctx,
+	visitIdToLisOrderMap, cErr := ctp.TaskService.FetchLisDataForApprovingTests(ctx,
 		task, attuneTestDetails, investigationResults, medicalRemarks, approvedByUsers)
 	if cErr != nil {
 		return errors.New(cErr.Message)
 	}
 
-	for _, attuneResponse := range visitIdToAttuneResponseMap {
-		for index := range attuneResponse.OrderInfo {
-			newAttuneResponse := structures.AttuneOrderResponse{
-				OrderId: attuneResponse.OrderId,
-				OrgCode: attuneResponse.OrgCode,
-				OrderInfo: []structures.AttuneOrderInfo{
-					attuneResponse.OrderInfo[index],
-				},
+	for visitId, lisOrder := range visitIdToLisOrderMap {
+		for index := range lisOrder.Tests {
+			testLisOrder := structures.LisOrder{
+				VisitId: lisOrder.VisitId,
+				Tests:   []structures.LisTestResult{lisOrder.Tests[index]},
 			}
-			cErr = ctp.AttuneClient.InsertTestDataToAttune(ctx, newAttuneResponse)
+			cErr = ctp.LisService.PushApprovals(ctx, visitId, testLisOrder)
 			if cErr != nil {
 				utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, errors.New(cErr.Message))
 			}
 		}
 	}
 
-	cErr = ctp.ReportGenerationService.TriggerReportGenerationEvent(ctx, task.OmsOrderId, reportReleaseTestIds)
-	if cErr != nil {
-		return errors.New(cErr.Message)
-	}
-
 	currentTime := utils.GetCurrentTime()
 	for index := range attuneTestDetails {
 		attuneTestDetails[index].ReportSentAt = currentTime
 		attuneTestDetails[index].UpdatedBy = constants.CitadelSystemId
 		attuneTestDetails[index].ReportStatus = constants.TEST_REPORT_STATUS_QUEUED
 	}
-	_, cErr = ctp.TestDetailService.UpdateTestDetails(attuneTestDetails)
+
+	var reportGenerationErr *structures.CommonError
+	err = ctp.Db.Transaction(func(tx *gorm.DB) error {
+		cErr := ctp.ReportGenerationService.TriggerReportGenerationEventWithTx(ctx, tx, task.OmsOrderId,
+			reportReleaseTestIds)
+		if cErr != nil {
+			if cErr.Message == constants.ERROR_NO_INHOUSE_VISIT_TESTS_FOUND {
+				reportGenerationErr = cErr
+				return nil
+			}
+			return errors.New(cErr.Message)
+		}
+
+		_, cErr = ctp.TestDetailService.UpdateTestDetailsWithTx(tx, attuneTestDetails)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if reportGenerationErr != nil {
+		return errors.New(reportGenerationErr.Message)
+	}
+
+	cErr = ctp.FhirService.PushApprovedResults(ctx, task.OmsOrderId, reportReleaseTestDetailsIds)
 	if cErr != nil {
-		return errors.New(cErr.Message)
+		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
+			"omsOrderId": task.OmsOrderId,
+		}, errors.New(cErr.Message))
 	}
 
 	return nil
 }
 
-func (ctp *CommonTaskProcessor) ReleaseReportByOmsOrderIdPostManualUploadReportTask(ctx context.Context, omsOrderId string,
-	omsTestIds []string) error {
+// getTestDetailsIdsWithOpenCriticalCalls returns the approved tests that have to be held back until every
+// critical value in them is acknowledged. The critical calls are opened when the critical results are saved.
+func (ctp *CommonTaskProcessor) getTestDetailsIdsWithOpenCriticalCalls(testDetails []models.TestDetail) (
+	[]uint, error) {
 
-	cErr := ctp.ReportGenerationService.TriggerReportGenerationEvent(ctx, omsOrderId, []string{})
-	if cErr != nil {
-		return errors.New(cErr.Message)
+	approvedTestDetailsIds := []uint{}
+	for _, testDetail := range testDetails {
+		if testDetail.Status == constants.TEST_STATUS_APPROVE {
+			approvedTestDetailsIds = append(approvedTestDetailsIds, testDetail.Id)
+		}
+	}
+
+	if len(approvedTestDetailsIds) == 0 {
+		return []uint{}, nil
 	}
 
-	cErr = ctp.TestDetailService.UpdateReportStatusByOmsTestIds(omsTestIds, constants.TEST_REPORT_STATUS_NOT_READY,
-		constants.TEST_REPORT_STATUS_QUEUED)
+	blockedTestDetailsIds, cErr := ctp.CriticalCallService.GetTestDetailsIdsWithOpenCriticalCalls(approvedTestDetailsIds)
 	if cErr != nil {
-		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
-			"omsTestIds": omsTestIds,
-			"omsOrderId": omsOrderId,
-		}, errors.New(cErr.Message))
-		return errors.New(cErr.Message)
+		return []uint{}, errors.New(cErr.Message)
+	}
+
+	return blockedTestDetailsIds, nil
+}
+
+func (ctp *CommonTaskProcessor) ReleaseReportByOmsOrderIdPostManualUploadReportTask(ctx context.Context, omsOrderId string,
+	omsTestIds []string) error {
+
+	var reportGenerationErr *structures.CommonError
+	err := ctp.Db.Transaction(func(tx *gorm.DB) error {
+		cErr := ctp.ReportGenerationService.TriggerReportGenerationEventWithTx(ctx, tx, omsOrderId, []string{})
+		if cErr != nil {
+			if cErr.Message == constants.ERROR_NO_INHOUSE_VISIT_TESTS_FOUND {
+				reportGenerationErr = cErr
+				return nil
+			}
+			return errors.New(cErr.Message)
+		}
+
+		cErr = ctp.TestDetailService.UpdateReportStatusByOmsTestIdsWithTx(tx, omsTestIds,
+			constants.TEST_REPORT_STATUS_NOT_READY, constants.TEST_REPORT_STATUS_QUEUED)
+		if cErr != nil {
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
+				"omsTestIds": omsTestIds,
+				"omsOrderId": omsOrderId,
+			}, errors.New(cErr.Message))
+			return errors.New(cErr.Message)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if reportGenerationErr != nil {
+		return errors.New(reportGenerationErr.Message)
 	}
 
 	return nil
@@ -2,6 +2,7 @@ package conf
 
 import (
 	"log"
+	"testing"
 
 	"github.com/spf13/viper"
 )
@@ -19,7 +20,8 @@ func init() {
 
 	err = v.ReadInConfig()
 
-	if err != nil {
+	// package tests run from their own directory and use the zero values of the settings
+	if err != nil && !testing.Testing() {
 		log.Fatal("error on parsing configuration file ", err.Error())
 	}
 	config = v
@@ -82,6 +82,9 @@ func (consumerServer *ConsumerServer) handler(message *sqs.Message) error {
 	if _, ok := messageAttributes["redis_key"]; ok {
 		event.RedisKey = messageAttributes["redis_key"].(string)
 	}
+	if sentTimestamp, ok := message.Attributes["SentTimestamp"]; ok && sentTimestamp != nil {
+		event.SentAt = *sentTimestamp
+	}
 
 	return worker.SendEventHandlerToWorker(consumerServer.Context, event)
 }
@@ -98,6 +101,9 @@ func (consumerServer *ConsumerServer) rawMessageHandler(message *sqs.Message) er
 	if _, ok := message.MessageAttributes["redis_key"]; ok {
 		event.RedisKey = *message.MessageAttributes["redis_key"].StringValue
 	}
+	if sentTimestamp, ok := message.Attributes["SentTimestamp"]; ok && sentTimestamp != nil {
+		event.SentAt = *sentTimestamp
+	}
 	return worker.SendEventHandlerToWorker(consumerServer.Context, event)
 }
 
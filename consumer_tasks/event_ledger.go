@@ -0,0 +1,82 @@
+package consumerTasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+	"github.com/Orange-Health/citadel/common/utils"
+)
+
+// ProcessWithEventLedger runs the handler of an event unless the event ledger finds it a duplicate or stale. The
+// event is claimed in the ledger as in progress before the handler runs, it is recorded as processed when the
+// handler succeeds and the claim is released when the handler fails. A claim left behind by a processing that
+// never finished lapses with its lease. A ledger that cannot be read does not hold the event back, the handlers
+// still guard against concurrent processing on their own.
+func (eventProcessor *EventProcessor) ProcessWithEventLedger(ctx context.Context, eventPayload string,
+	handler func(ctx context.Context, eventPayload string) error) error {
+
+	businessId := getEventLedgerBusinessId(eventProcessor.EventType, eventPayload)
+	loggingAttributes := map[string]interface{}{
+		"event_type":    eventProcessor.EventType,
+		"business_id":   businessId,
+		"event_sent_at": eventProcessor.EventSentAt,
+	}
+
+	decision, processedEventId, cErr := eventProcessor.EventLedgerService.CheckEvent(eventProcessor.EventType, businessId,
+		eventPayload, eventProcessor.EventSentAt)
+	if cErr != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_CHECKING_EVENT_LEDGER, loggingAttributes,
+			errors.New(cErr.Message))
+	} else if decision != constants.EVENT_LEDGER_DECISION_IN_PROGRESS {
+		loggingAttributes["decision"] = decision
+		utils.AddLog(ctx, constants.INFO_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes, nil)
+		return nil
+	}
+
+	if err := handler(ctx, eventPayload); err != nil {
+		if cErr := eventProcessor.EventLedgerService.ReleaseEvent(processedEventId); cErr != nil {
+			utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_RELEASING_EVENT_LEDGER,
+				loggingAttributes, errors.New(cErr.Message))
+		}
+		return err
+	}
+
+	if cErr := eventProcessor.EventLedgerService.CompleteEvent(processedEventId); cErr != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_COMPLETING_EVENT_LEDGER, loggingAttributes,
+			errors.New(cErr.Message))
+	}
+
+	return nil
+}
+
+// getEventLedgerBusinessId gets the entity an event is about. An event whose entity cannot be read is not
+// checked against the ledger, its handler deals with the bad payload.
+func getEventLedgerBusinessId(eventType, eventPayload string) string {
+	switch eventType {
+	case constants.OmsOrderCreatedEvent, constants.OmsOrderUpdatedEvent:
+		omsOrderCreateUpdateEvent := structures.OmsOrderCreateUpdateEvent{}
+		if err := json.Unmarshal([]byte(eventPayload), &omsOrderCreateUpdateEvent); err != nil {
+			return ""
+		}
+		return omsOrderCreateUpdateEvent.Order.AlnumOrderId
+	case constants.LisEvent:
+		lisEvent := structures.LisEvent{}
+		if err := json.Unmarshal([]byte(eventPayload), &lisEvent); err != nil {
+			return ""
+		}
+		return lisEvent.EntityID
+	case constants.OrderReportPdfReadyEvent:
+		reportReadyEvent := structures.ReportReadyEvent{}
+		if err := json.Unmarshal([]byte(eventPayload), &reportReadyEvent); err != nil ||
+			reportReadyEvent.ReportPdfEvent.OrderID == "" {
+			return ""
+		}
+		return fmt.Sprintf("%s:%s", reportReadyEvent.ReportPdfEvent.OrderID,
+			reportReadyEvent.ReportPdfEvent.CityCode)
+	}
+	return ""
+}
@@ -2,7 +2,6 @@ package consumerTasks
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -52,12 +51,22 @@ func (eventProcessor *EventProcessor) LisEventTask(ctx context.Context, eventPay
 	}()
 
 	task := models.Task{}
-	lisOrderUpdateDetailsEvent, cErr := GetLisVisitDataEventFromBase64Data(lisEvent.EntityID, lisEvent.WebhookData)
+	sample, cErr := eventProcessor.LisService.GetSampleByVisitId(lisEvent.EntityID)
 	if cErr != nil {
 		return errors.New(cErr.Message)
 	}
+	if sample.Id == 0 {
+		return nil
+	}
+
+	lisOrder, cErr := eventProcessor.LisService.DecodeResultsEvent(ctx, sample.LabId, lisEvent.WebhookData)
+	if cErr != nil {
+		return errors.New(cErr.Message)
+	}
+	lisOrder.VisitId = lisEvent.EntityID
+	lisOrderUpdateDetailsEvent := GetLisVisitDataFromLisOrder(lisOrder)
 
-	if lisOrderUpdateDetailsEvent.ReportPdfFormat == constants.AttuneReportWithoutStationery {
+	if lisOrderUpdateDetailsEvent.ReportPdfFormat == constants.LIS_REPORT_FORMAT_PLAIN {
 		utils.AddLog(ctx, constants.INFO_LEVEL, utils.GetCurrentFunctionName(), nil,
 			errors.New(constants.ERROR_REPORT_PDF_FORMAT_IGNORED))
 		return nil
@@ -69,14 +78,6 @@ func (eventProcessor *EventProcessor) LisEventTask(ctx context.Context, eventPay
 		"lis_order_details": lisOrderUpdateDetailsEvent,
 	}, nil)
 
-	sample, cErr := eventProcessor.AttuneService.GetSampleByVisitId(lisEvent.EntityID)
-	if cErr != nil {
-		return errors.New(cErr.Message)
-	}
-	if sample.Id == 0 {
-		return nil
-	}
-
 	orderDetails, cErr := eventProcessor.OrderDetailsService.GetOrderDetailsByOmsOrderId(sample.OmsOrderId)
 	if cErr != nil {
 		return errors.New(cErr.Message)
@@ -119,15 +120,15 @@ func (eventProcessor *EventProcessor) LisEventTask(ctx context.Context, eventPay
 		testDetails, orderDetails, patientDetails, pathologistsList, sample.LabId)
 
 	// Trigger Attune Report Generation
-	if _, ok := lisOrderUpdateDetailsEvent.OrderInfo[constants.ATTUNE_TEST_STATUS_APPROVED]; ok {
+	if _, ok := lisOrderUpdateDetailsEvent.OrderInfo[constants.LIS_TEST_STATUS_APPROVED]; ok {
 		go eventProcessor.SaveReportFromLis(orderDetails, patientDetails, lisOrderUpdateDetailsEvent, testDetails,
 			sample.LabId)
 	}
 	testDocumentMap := map[string][]structures.TestDocumentInfoResponse{}
 	labIdLabMap := eventProcessor.CdsService.GetLabIdLabMap(ctx)
-	if _, ok := lisOrderUpdateDetailsEvent.OrderInfo[constants.ATTUNE_TEST_STATUS_APPROVED]; ok {
+	if _, ok := lisOrderUpdateDetailsEvent.OrderInfo[constants.LIS_TEST_STATUS_APPROVED]; ok {
 		omsApprovedEvent, cErr := eventProcessor.GetLisEventDetails(ctx, orderDetails, patientDetails,
-			constants.ATTUNE_TEST_STATUS_APPROVED, lisOrderUpdateDetailsEvent, testDetails)
+			constants.LIS_TEST_STATUS_APPROVED, lisOrderUpdateDetailsEvent, testDetails)
 		if cErr != nil {
 			return errors.New(cErr.Message)
 		}
@@ -137,9 +138,9 @@ func (eventProcessor *EventProcessor) LisEventTask(ctx context.Context, eventPay
 			return errors.New(cErr.Message)
 		}
 	}
-	if _, ok := lisOrderUpdateDetailsEvent.OrderInfo[constants.ATTUNE_TEST_STATUS_COMPLETED]; ok {
+	if _, ok := lisOrderUpdateDetailsEvent.OrderInfo[constants.LIS_TEST_STATUS_COMPLETED]; ok {
 		omsCompletedEvent, cErr := eventProcessor.GetLisEventDetails(ctx, orderDetails, patientDetails,
-			constants.ATTUNE_TEST_STATUS_COMPLETED, lisOrderUpdateDetailsEvent, testDetails)
+			constants.LIS_TEST_STATUS_COMPLETED, lisOrderUpdateDetailsEvent, testDetails)
 		if cErr != nil {
 			return errors.New(cErr.Message)
 		}
@@ -149,7 +150,7 @@ func (eventProcessor *EventProcessor) LisEventTask(ctx context.Context, eventPay
 			return errors.New(cErr.Message)
 		}
 	}
-	if _, ok := lisOrderUpdateDetailsEvent.OrderInfo[constants.ATTUNE_TEST_STATUS_RERUN]; ok {
+	if _, ok := lisOrderUpdateDetailsEvent.OrderInfo[constants.LIS_TEST_STATUS_RERUN]; ok {
 		omsRerunEvent, cErr := eventProcessor.GetLisRerunEventDetails(ctx, orderDetails,
 			lisOrderUpdateDetailsEvent, testDetails)
 		if cErr != nil {
@@ -203,95 +204,46 @@ func (eventProcessor *EventProcessor) LisEventTask(ctx context.Context, eventPay
 	}
 
 	if len(approvedTestIds) > 0 {
-		eventProcessor.EtsService.GetAndPublishEtsTestEventForLisWebhook(ctx, approvedTestIds, constants.AttuneTestStatusApprove)
+		eventProcessor.EtsService.GetAndPublishEtsTestEventForLisWebhook(ctx, approvedTestIds, constants.LIS_TEST_STATUS_APPROVED)
 	}
 	if len(resultSavedTestIds) > 0 {
 		eventProcessor.EtsService.GetAndPublishEtsTestEventForLisWebhook(ctx, resultSavedTestIds,
-			constants.AttuneTestStatusCompleted)
+			constants.LIS_TEST_STATUS_COMPLETED)
 	}
 
 	return nil
 }
 
-func GetLisVisitDataEventFromBase64Data(lisOrderId string, lisPayload string) (structures.LisOrderUpdateDetails,
-	*structures.CommonError) {
-	lisOrderInfoEvent := structures.LisOrderInfo{}
-
-	lisOrderInfoEventByte, err := base64.StdEncoding.DecodeString(lisPayload)
-	if err != nil {
-		return structures.LisOrderUpdateDetails{}, &structures.CommonError{
-			Message:    constants.ERROR_FAILED_TO_DECODE_BASE64_STRING,
-			StatusCode: http.StatusInternalServerError,
-		}
-	}
-
-	err = json.Unmarshal(lisOrderInfoEventByte, &lisOrderInfoEvent)
-	if err != nil {
-		return structures.LisOrderUpdateDetails{}, &structures.CommonError{
-			Message:    constants.ERROR_FAILED_TO_UNMARSHAL_JSON,
-			StatusCode: http.StatusInternalServerError,
-		}
-	}
-
-	lisOrderUpdateDetails := structures.LisOrderUpdateDetails{}
-	lisOrderUpdateDetails.LisVisitId = lisOrderId
-	allOrderInfo := lisOrderInfoEvent.OrderInfo
-	lisOrderUpdateDetails.OrderInfo = map[string]map[string]structures.LisTestUpdateInfo{}
-
-	for _, orderInfo := range allOrderInfo {
-		testStatus := orderInfo.TestStatus
-		testCode := orderInfo.TestCode
-		_, ok := lisOrderUpdateDetails.OrderInfo[testStatus]
-		if !ok {
-			lisOrderUpdateDetails.OrderInfo[testStatus] = make(map[string]structures.LisTestUpdateInfo)
-		}
-		lisOrderUpdateDetails.OrderInfo[testStatus][testCode] = structures.LisTestUpdateInfo{
-			TestCode: testCode,
-			TestName: orderInfo.TestName,
-			MetaData: orderInfo,
-		}
-	}
-
-	lisOrderUpdateDetails.PdfResult = lisOrderInfoEvent.ResultAsPdf
-	lisOrderUpdateDetails.ReportPdfFormat = lisOrderInfoEvent.ReportPdfFormat
-	lisOrderUpdateDetails.VisitDocumentInfo = lisOrderInfoEvent.VisitDocumentinfo
-
-	return lisOrderUpdateDetails, nil
-}
-
-func GetLisVisitDataFromAttuneOrderResponse(orderResponse structures.AttuneOrderResponse,
-	reportPdfFormat string) structures.LisOrderUpdateDetails {
+// GetLisVisitDataFromLisOrder groups the tests of the order by LIS test status and test code.
+func GetLisVisitDataFromLisOrder(lisOrder structures.LisOrder) structures.LisOrderUpdateDetails {
 	lisOrderUpdateDetails := structures.LisOrderUpdateDetails{}
-	lisOrderUpdateDetails.LisVisitId = orderResponse.OrderId
-	allOrderInfo := orderResponse.OrderInfo
+	lisOrderUpdateDetails.LisVisitId = lisOrder.VisitId
 	lisOrderUpdateDetails.OrderInfo = map[string]map[string]structures.LisTestUpdateInfo{}
 
-	for _, orderInfo := range allOrderInfo {
-		testStatus := orderInfo.TestStatus
-		testCode := orderInfo.TestCode
-		_, ok := lisOrderUpdateDetails.OrderInfo[testStatus]
+	for _, test := range lisOrder.Tests {
+		_, ok := lisOrderUpdateDetails.OrderInfo[test.TestStatus]
 		if !ok {
-			lisOrderUpdateDetails.OrderInfo[testStatus] = make(map[string]structures.LisTestUpdateInfo)
+			lisOrderUpdateDetails.OrderInfo[test.TestStatus] = make(map[string]structures.LisTestUpdateInfo)
 		}
-		lisOrderUpdateDetails.OrderInfo[testStatus][testCode] = structures.LisTestUpdateInfo{
-			TestCode: testCode,
-			TestName: orderInfo.TestName,
-			MetaData: orderInfo,
+		lisOrderUpdateDetails.OrderInfo[test.TestStatus][test.TestCode] = structures.LisTestUpdateInfo{
+			TestCode: test.TestCode,
+			TestName: test.TestName,
+			MetaData: test,
 		}
 	}
 
-	lisOrderUpdateDetails.PdfResult = orderResponse.ResultAsPdf
-	lisOrderUpdateDetails.ReportPdfFormat = reportPdfFormat
-	lisOrderUpdateDetails.VisitDocumentInfo = orderResponse.VisitDocumentinfo
+	lisOrderUpdateDetails.PdfResult = lisOrder.ReportPdf
+	lisOrderUpdateDetails.ReportPdfFormat = lisOrder.ReportPdfFormat
+	lisOrderUpdateDetails.VisitDocumentInfo = lisOrder.VisitDocuments
 
 	return lisOrderUpdateDetails
 }
 
 func fetchAllTestCodesFromMetadata(orderInfo map[string]map[string]structures.LisTestUpdateInfo) []string {
 	testCodes := []string{}
-	for attuneStatus, testResults := range orderInfo {
-		if attuneStatus == constants.AttuneTestStatusCompleted ||
-			attuneStatus == constants.AttuneTestStatusApprove {
+	for lisStatus, testResults := range orderInfo {
+		if lisStatus == constants.LIS_TEST_STATUS_COMPLETED ||
+			lisStatus == constants.LIS_TEST_STATUS_APPROVED {
 			for testCode := range testResults {
 				if utils.SliceContainsString(constants.TestCodesToBeSkippedInRetry, testCode) {
 					continue
@@ -434,14 +386,13 @@ func (eventProcessor *EventProcessor) ResyncLisOrderDataInCaseOfAnomaly(ctx cont
 		"doctor_details_missing_tests": doctorDetailsMissingTests,
 	}, nil)
 	for attempt := range maxRetries {
-		attuneOrderResponse, cErr := eventProcessor.AttuneClient.GetPatientVisitDetailsbyVisitNo(ctx,
-			newLisOrderUpdateDetails.LisVisitId, constants.AttuneReportWithStationery, sampleLabId)
+		lisOrder, cErr := eventProcessor.LisService.FetchResults(ctx, newLisOrderUpdateDetails.LisVisitId,
+			sampleLabId)
 		if cErr != nil {
 			return lisOrderUpdateDetails
 		}
 
-		newLisOrderUpdateDetails = GetLisVisitDataFromAttuneOrderResponse(attuneOrderResponse,
-			constants.AttuneReportWithStationery)
+		newLisOrderUpdateDetails = GetLisVisitDataFromLisOrder(lisOrder)
 		lisOrderUpdateDetails = newLisOrderUpdateDetails
 		testCodes := fetchAllTestCodesFromMetadata(lisOrderUpdateDetails.OrderInfo)
 		cdsTestDetails, err := eventProcessor.CdsClient.GetPanelDetails(ctx, testCodes, nil, orderDetails.CityCode,
@@ -525,7 +476,7 @@ func (eventProcessor *EventProcessor) GetLisRerunEventDetails(ctx context.Contex
 
 	omsRerunEvent.CityCode = servicingLab.City
 
-	omsRerunEvent.Tests.OrderInfo = lisOrderUpdateDetails.OrderInfo[constants.ATTUNE_TEST_STATUS_RERUN]
+	omsRerunEvent.Tests.OrderInfo = lisOrderUpdateDetails.OrderInfo[constants.LIS_TEST_STATUS_RERUN]
 
 	testDetailList := []structures.OmsTestStruct{}
 	for _, testDetail := range testDetails {
@@ -633,7 +584,7 @@ func (eventProcessor *EventProcessor) processLisApprovedEvent(ctx context.Contex
 		investigationCodeMedicalRemarkMap, investigationCodeTechnicianRemarkMap, _, testDocumentMap :=
 		eventProcessor.createFlattenedInvestigationResultsAndInitialTestDetailsMap(ctx, constants.OmsApprovedEvent,
 			omsApprovedEvent.Order.CityCode, omsApprovedEvent.Tests, masterInvestigationDetailsMap, usersList,
-			systemPatientId, sampleLab.Id)
+			systemPatientId, sampleLab.Id, omsApprovedEvent.Patient)
 	task, cErr = eventProcessor.TaskService.GetTaskByOmsOrderId(omsApprovedEvent.Order.AlnumOrderId)
 	if task.Id != 0 {
 		task, cErr = eventProcessor.createUpdateData(ctx, omsApprovedEvent, task,
@@ -685,7 +636,7 @@ func (eventProcessor *EventProcessor) processLisCompletedEvent(ctx context.Conte
 		investigationCodeInvestigationDataMap, investigationCodeMedicalRemarkMap, investigationCodeTechnicianRemarkMap,
 		qcFailedOmsTestIds, testDocumentMap := eventProcessor.createFlattenedInvestigationResultsAndInitialTestDetailsMap(ctx,
 		constants.OmsCompletedEvent, omsCompletedEvent.Order.CityCode, omsCompletedEvent.Tests,
-		masterInvestigationDetailsMap, usersList, systemPatientId, sampleLab.Id)
+		masterInvestigationDetailsMap, usersList, systemPatientId, sampleLab.Id, omsCompletedEvent.Patient)
 	task, cErr = eventProcessor.TaskService.GetTaskByOmsOrderId(omsCompletedEvent.Order.AlnumOrderId)
 	if task.Id != 0 {
 		task, cErr = eventProcessor.createUpdateData(ctx, omsCompletedEvent, task,
@@ -826,7 +777,7 @@ func (eventProcessor *EventProcessor) processLisRerunEventTask(ctx context.Conte
 	}
 
 	if len(omsRerunTestIds) != 0 {
-		go eventProcessor.EtsService.GetAndPublishEtsTestEventForLisWebhook(context.Background(), omsRerunTestIds, constants.AttuneTestStatusRerun)
+		go eventProcessor.EtsService.GetAndPublishEtsTestEventForLisWebhook(context.Background(), omsRerunTestIds, constants.LIS_TEST_STATUS_RERUN)
 	}
 	return nil
 }
@@ -897,7 +848,7 @@ func createFlattenedRerunInvestigationResults(ctx context.Context, investigation
 			}
 			rerunDetails = append(rerunDetails, rerunDetail)
 		case constants.GroupShortHand:
-			panelRerunDetails, panleInvestigationIds := createRerunInvestigationsForPanel(ctx, lisOrderInfo,
+			panelRerunDetails, panleInvestigationIds := createRerunInvestigationsForPanel(lisOrderInfo,
 				investigationCodeToInvestigationMap)
 			rerunDetails = append(rerunDetails, panelRerunDetails...)
 			investigationIds = append(investigationIds, panleInvestigationIds...)
@@ -907,7 +858,7 @@ func createFlattenedRerunInvestigationResults(ctx context.Context, investigation
 	return
 }
 
-func createRerunInvestigationsForInvestigation(lisOrderInfo structures.AttuneOrderInfo,
+func createRerunInvestigationsForInvestigation(lisOrderInfo structures.LisTestResult,
 	investigationCodeToInvestigationMap map[string]models.InvestigationResult) (
 	rerunDetail models.RerunInvestigationResult, investigationId uint) {
 	investigation := investigationCodeToInvestigationMap[lisOrderInfo.TestCode]
@@ -938,64 +889,46 @@ func createRerunInvestigationsForInvestigation(lisOrderInfo structures.AttuneOrd
 	return
 }
 
-func createRerunInvestigationsForPanel(ctx context.Context, lisOrderInfo structures.AttuneOrderInfo,
+func createRerunInvestigationsForPanel(lisOrderInfo structures.LisTestResult,
 	investigationCodeToInvestigationMap map[string]models.InvestigationResult) (
 	rerunDetails []models.RerunInvestigationResult, investigationIds []uint) {
 
-	rerunDetails, investigationIds, queue, index :=
-		[]models.RerunInvestigationResult{}, []uint{}, []interface{}{}, 0
-
-	queue = append(queue, lisOrderInfo.OrderContentListInfo)
-
-	for index < len(queue) {
-		currentNode := queue[index]
-		index++
-		marshalledNode, err := json.Marshal(currentNode)
-		if err != nil {
-			utils.AddLog(ctx, constants.DEBUG_LEVEL, utils.GetCurrentFunctionName(), nil, err)
-			continue
-		}
+	rerunDetails, investigationIds = []models.RerunInvestigationResult{}, []uint{}
+	queue := append([]structures.LisTestResult{}, lisOrderInfo.Parameters...)
 
-		attuneOrderContentListInfo := []structures.AttuneOrderContentListInfoEvent{}
-		err = json.Unmarshal(marshalledNode, &attuneOrderContentListInfo)
-		if err != nil {
-			utils.AddLog(ctx, constants.DEBUG_LEVEL, utils.GetCurrentFunctionName(), nil, err)
-			continue
-		}
-
-		for _, attuneOrderContentListInfo := range attuneOrderContentListInfo {
-			switch attuneOrderContentListInfo.TestType {
-			case constants.InvestigationShortHand:
-				if attuneOrderContentListInfo.TestStatus != constants.ATTUNE_TEST_STATUS_RERUN {
-					continue
-				}
-				investigation := investigationCodeToInvestigationMap[attuneOrderContentListInfo.TestCode]
-				rerunTriggerTime := utils.GetTimeFromString(attuneOrderContentListInfo.RerunTime)
-				if rerunTriggerTime == nil {
-					rerunTriggerTime = utils.GetCurrentTime()
-				}
-				rerunDetail := models.RerunInvestigationResult{
-					TestDetailsId:            investigation.TestDetailsId,
-					MasterInvestigationId:    investigation.MasterInvestigationId,
-					InvestigationName:        investigation.InvestigationName,
-					InvestigationValue:       investigation.InvestigationValue,
-					DeviceValue:              investigation.DeviceValue,
-					ResultRepresentationType: investigation.ResultRepresentationType,
-					LisCode:                  investigation.LisCode,
-					RerunReason:              attuneOrderContentListInfo.RerunReason,
-					RerunRemarks:             attuneOrderContentListInfo.RerunRemarks,
-					RerunTriggeredAt:         rerunTriggerTime,
-					RerunTriggeredBy:         constants.LisSystemId,
-				}
-				rerunDetail.CreatedBy = constants.CitadelSystemId
-				rerunDetail.UpdatedBy = constants.CitadelSystemId
-				rerunDetails = append(rerunDetails, rerunDetail)
-				if !utils.SliceContainsString(constants.INVESTIGATION_STATUSES_RERUN, investigation.InvestigationStatus) {
-					investigationIds = append(investigationIds, investigation.Id)
-				}
-			case constants.GroupShortHand:
-				queue = append(queue, attuneOrderContentListInfo.ParameterListInfo)
+	for index := 0; index < len(queue); index++ {
+		parameter := queue[index]
+		switch parameter.TestType {
+		case constants.InvestigationShortHand:
+			if parameter.TestStatus != constants.LIS_TEST_STATUS_RERUN {
+				continue
+			}
+			investigation := investigationCodeToInvestigationMap[parameter.TestCode]
+			rerunTriggerTime := utils.GetTimeFromString(parameter.RerunTime)
+			if rerunTriggerTime == nil {
+				rerunTriggerTime = utils.GetCurrentTime()
 			}
+			rerunDetail := models.RerunInvestigationResult{
+				TestDetailsId:            investigation.TestDetailsId,
+				MasterInvestigationId:    investigation.MasterInvestigationId,
+				InvestigationName:        investigation.InvestigationName,
+				InvestigationValue:       investigation.InvestigationValue,
+				DeviceValue:              investigation.DeviceValue,
+				ResultRepresentationType: investigation.ResultRepresentationType,
+				LisCode:                  investigation.LisCode,
+				RerunReason:              parameter.RerunReason,
+				RerunRemarks:             parameter.RerunRemarks,
+				RerunTriggeredAt:         rerunTriggerTime,
+				RerunTriggeredBy:         constants.LisSystemId,
+			}
+			rerunDetail.CreatedBy = constants.CitadelSystemId
+			rerunDetail.UpdatedBy = constants.CitadelSystemId
+			rerunDetails = append(rerunDetails, rerunDetail)
+			if !utils.SliceContainsString(constants.INVESTIGATION_STATUSES_RERUN, investigation.InvestigationStatus) {
+				investigationIds = append(investigationIds, investigation.Id)
+			}
+		case constants.GroupShortHand:
+			queue = append(queue, parameter.Parameters...)
 		}
 	}
 
@@ -1028,9 +961,9 @@ func (eventProcessor *EventProcessor) fetchAttuneEventWithRetry(ctx context.Cont
 	lisOrderUpdateEvent structures.LisOrderUpdateDetails, sampleLabId uint) structures.LisOrderUpdateDetails {
 	lisOrderUpdateEvent.ReportPdfFormat = reportPdfFormat
 	for attempt := range constants.LisMissingPdfMaxRetries {
-		attuneResponse, _ := eventProcessor.AttuneClient.GetPatientVisitDetailsbyVisitNo(ctx, lisOrderUpdateEvent.LisVisitId,
+		lisOrder, _ := eventProcessor.LisService.FetchReportPdf(ctx, lisOrderUpdateEvent.LisVisitId,
 			reportPdfFormat, sampleLabId)
-		if attuneResponse.ResultAsPdf == "" {
+		if lisOrder.ReportPdf == "" {
 			sleepTime := utils.GetExponentialBackoff(attempt, constants.LisMissingPdfBackoffTime)
 			time.Sleep(sleepTime)
 			utils.AddLog(ctx, constants.DEBUG_LEVEL, utils.GetCurrentFunctionName(),
@@ -1042,7 +975,7 @@ func (eventProcessor *EventProcessor) fetchAttuneEventWithRetry(ctx context.Cont
 				errors.New(constants.ERROR_REPORT_PDF_NOT_FOUND))
 			continue
 		}
-		return GetLisVisitDataFromAttuneOrderResponse(attuneResponse, reportPdfFormat)
+		return GetLisVisitDataFromLisOrder(lisOrder)
 	}
 	return lisOrderUpdateEvent
 }
@@ -1054,27 +987,26 @@ func (eventProcessor *EventProcessor) SaveReportFromLis(orderDetails models.Orde
 	cobrandedFilePath := ""
 	omsTestIds := []string{}
 	for _, testDetail := range testDetails {
-		if _, keyExists := stationaryLisOrderUpdateDetailsEvent.OrderInfo[constants.ATTUNE_TEST_STATUS_APPROVED][testDetail.TestCode]; keyExists {
+		if _, keyExists := stationaryLisOrderUpdateDetailsEvent.OrderInfo[constants.LIS_TEST_STATUS_APPROVED][testDetail.TestCode]; keyExists {
 			omsTestIds = append(omsTestIds, testDetail.TestId)
 		}
 	}
 
-	attuneResponse, cErr := eventProcessor.AttuneClient.GetPatientVisitDetailsbyVisitNo(ctx,
-		stationaryLisOrderUpdateDetailsEvent.LisVisitId, constants.AttuneReportWithoutStationery, sampleLabId)
+	lisOrder, cErr := eventProcessor.LisService.FetchReportPdf(ctx,
+		stationaryLisOrderUpdateDetailsEvent.LisVisitId, constants.LIS_REPORT_FORMAT_PLAIN, sampleLabId)
 	if cErr != nil {
 		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, errors.New(cErr.Message))
 		return
 	}
-	nonStationaryLisOrderUpdateDetailsEvent := GetLisVisitDataFromAttuneOrderResponse(attuneResponse,
-		constants.AttuneReportWithoutStationery)
+	nonStationaryLisOrderUpdateDetailsEvent := GetLisVisitDataFromLisOrder(lisOrder)
 
 	if stationaryLisOrderUpdateDetailsEvent.PdfResult == "" {
 		stationaryLisOrderUpdateDetailsEvent = eventProcessor.fetchAttuneEventWithRetry(ctx,
-			constants.AttuneReportWithStationery, stationaryLisOrderUpdateDetailsEvent, sampleLabId)
+			constants.LIS_REPORT_FORMAT_BRANDED, stationaryLisOrderUpdateDetailsEvent, sampleLabId)
 	}
 	if nonStationaryLisOrderUpdateDetailsEvent.PdfResult == "" {
 		nonStationaryLisOrderUpdateDetailsEvent = eventProcessor.fetchAttuneEventWithRetry(ctx,
-			constants.AttuneReportWithoutStationery, nonStationaryLisOrderUpdateDetailsEvent, sampleLabId)
+			constants.LIS_REPORT_FORMAT_PLAIN, nonStationaryLisOrderUpdateDetailsEvent, sampleLabId)
 	}
 
 	if stationaryLisOrderUpdateDetailsEvent.PdfResult == "" || nonStationaryLisOrderUpdateDetailsEvent.PdfResult == "" {
@@ -1088,9 +1020,9 @@ func (eventProcessor *EventProcessor) SaveReportFromLis(orderDetails models.Orde
 	}
 
 	brandedFilePath, _ := eventProcessor.UpsertReportFromBase64String(ctx, orderDetails.OmsOrderId, patientDetails.Name,
-		stationaryLisOrderUpdateDetailsEvent.PdfResult, constants.AttuneReportWithStationery)
+		stationaryLisOrderUpdateDetailsEvent.PdfResult, constants.LIS_REPORT_FORMAT_BRANDED)
 	nonbrandedFilePath, _ := eventProcessor.UpsertReportFromBase64String(ctx, orderDetails.OmsOrderId, patientDetails.Name,
-		nonStationaryLisOrderUpdateDetailsEvent.PdfResult, constants.AttuneReportWithoutStationery)
+		nonStationaryLisOrderUpdateDetailsEvent.PdfResult, constants.LIS_REPORT_FORMAT_PLAIN)
 
 	if orderDetails.PartnerId != 0 {
 		partner, err := eventProcessor.PartnerApiClient.GetPartnerById(ctx, orderDetails.PartnerId)
@@ -1104,7 +1036,7 @@ func (eventProcessor *EventProcessor) SaveReportFromLis(orderDetails models.Orde
 			}
 
 			cobrandedFilePath, _ = eventProcessor.UpsertReportFromBase64String(ctx,
-				orderDetails.OmsOrderId, patientDetails.Name, cobrandedReportUrl, constants.AttuneReportWithCobrandStationery)
+				orderDetails.OmsOrderId, patientDetails.Name, cobrandedReportUrl, constants.LIS_REPORT_FORMAT_CO_BRANDED)
 		}
 	}
 
@@ -1135,7 +1067,7 @@ func (eventProcessor *EventProcessor) SaveReportFromLis(orderDetails models.Orde
 	}
 
 	messageBody, messageAttributes := eventProcessor.PubsubService.GetReportReadyEvent(ctx, reportReadyEvent)
-	cErr = eventProcessor.SnsClient.PublishTo(ctx, messageBody, messageAttributes, fmt.Sprint(orderDetails.OmsOrderId),
+	cErr = eventProcessor.OutboxService.WriteMessage(ctx, messageBody, messageAttributes, fmt.Sprint(orderDetails.OmsOrderId),
 		constants.ReportReadyTopicArn, fmt.Sprintf("%v_%v", orderDetails.OmsOrderId, utils.GetCurrentTimeInMilliseconds()))
 	if cErr != nil {
 		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, errors.New(cErr.Message))
@@ -1197,10 +1129,12 @@ func (eventProcessor *EventProcessor) findAndNotifyAutoApprovalFailedTests(ctx c
 	deltaCheckFailedInvs := []models.InvestigationResult{}
 	refRangeFailedInvs := []models.InvestigationResult{}
 	for _, invResult := range invResults {
-		switch invResult.AutoApprovalFailureReason {
-		case constants.AUTO_APPROVAL_FAIL_REASON_PAST_RECORD:
+		if utils.HasAutoApprovalFailureReasonCode(invResult.AutoApprovalFailureReason,
+			constants.AUTO_APPROVAL_FAIL_REASON_PAST_RECORD) {
 			deltaCheckFailedInvs = append(deltaCheckFailedInvs, invResult)
-		case constants.AUTO_APPROVAL_FAIL_REASON_REF_RANGE:
+		}
+		if utils.HasAutoApprovalFailureReasonCode(invResult.AutoApprovalFailureReason,
+			constants.AUTO_APPROVAL_FAIL_REASON_REF_RANGE) {
 			refRangeFailedInvs = append(refRangeFailedInvs, invResult)
 		}
 	}
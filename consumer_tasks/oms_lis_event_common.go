@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -13,6 +12,12 @@ import (
 
 	"gorm.io/gorm"
 
+	autoVerificationMapper "github.com/Orange-Health/citadel/apps/auto_verification/mapper"
+	autoVerificationStructures "github.com/Orange-Health/citadel/apps/auto_verification/structures"
+	deltaCheckMapper "github.com/Orange-Health/citadel/apps/delta_check/mapper"
+	deltaCheckStructures "github.com/Orange-Health/citadel/apps/delta_check/structures"
+	qcMapper "github.com/Orange-Health/citadel/apps/qc/mapper"
+	qcStructures "github.com/Orange-Health/citadel/apps/qc/structures"
 	"github.com/Orange-Health/citadel/common/constants"
 	"github.com/Orange-Health/citadel/common/structures"
 	"github.com/Orange-Health/citadel/common/utils"
@@ -136,33 +141,20 @@ func getMethodTypeForInvestigation(deviceId, methodName string) string {
 func fetchInvestigationCodesFromAttunePayload(tests structures.OmsTestDetails) []string {
 	investigationCodes := []string{}
 
-	queue, index := []interface{}{}, 0
+	queue := []structures.LisTestResult{}
 	for _, lisTestUpdateInfo := range tests.OrderInfo {
 		if lisTestUpdateInfo.MetaData.TestType == constants.InvestigationShortHand {
 			investigationCodes = append(investigationCodes, lisTestUpdateInfo.TestCode)
 		} else {
-			for _, orderContentListInfo := range lisTestUpdateInfo.MetaData.OrderContentListInfo {
-				queue = append(queue, orderContentListInfo)
-			}
+			queue = append(queue, lisTestUpdateInfo.MetaData.Parameters...)
 		}
 	}
 
-	for index < len(queue) {
-		currentNode := queue[index]
-		index += 1
-		marshalledNode, err := json.Marshal(currentNode)
-		if err != nil {
-			continue
-		}
-		attuneOrderContentListInfo := structures.AttuneOrderContentListInfoEvent{}
-		err = json.Unmarshal(marshalledNode, &attuneOrderContentListInfo)
-		if err != nil {
-			continue
-		}
-		if attuneOrderContentListInfo.TestType == constants.InvestigationShortHand {
-			investigationCodes = append(investigationCodes, attuneOrderContentListInfo.TestCode)
+	for index := 0; index < len(queue); index++ {
+		if queue[index].TestType == constants.InvestigationShortHand {
+			investigationCodes = append(investigationCodes, queue[index].TestCode)
 		} else {
-			queue = append(queue, attuneOrderContentListInfo.ParameterListInfo...)
+			queue = append(queue, queue[index].Parameters...)
 		}
 	}
 
@@ -192,6 +184,8 @@ func createPatientDetailsDto(omsLisEvent structures.OmsLisEvent) models.PatientD
 		Gender:          omsPatientDetails.Gender,
 		Number:          omsPatientDetails.Number,
 		SystemPatientId: omsPatientDetails.PatientId,
+		IsPregnant:      omsPatientDetails.IsPregnant,
+		Trimester:       omsPatientDetails.Trimester,
 	}
 	patientDetails.CreatedBy = constants.CitadelSystemId
 	patientDetails.UpdatedBy = constants.CitadelSystemId
@@ -375,6 +369,27 @@ func createInvestigationDataDto(investigationResults []models.InvestigationResul
 	return investigationData
 }
 
+// createInvestigationDataModel stores culture results converted from Attune's XML under their own data type, so
+// that they can be synced into the culture tables. Any other value, or XML that cannot be parsed, is kept as is.
+func createInvestigationDataModel(ctx context.Context, testValue string) models.InvestigationData {
+	investigationData := models.InvestigationData{
+		Data:     testValue,
+		DataType: constants.InvestigationValue,
+	}
+	if cultureResults, ok := utils.ParseCultureResultsXML(ctx, testValue); ok {
+		data, err := json.Marshal(cultureResults)
+		if err != nil {
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, err)
+		} else {
+			investigationData.Data = string(data)
+			investigationData.DataType = constants.CultureInvestigationValue
+		}
+	}
+	investigationData.CreatedBy = constants.CitadelSystemId
+	investigationData.UpdatedBy = constants.CitadelSystemId
+	return investigationData
+}
+
 func createRemarksDto(investigationResults []models.InvestigationResult,
 	investigationCodeMedicalRemarkMap map[string]models.Remark,
 	investigationCodeTechnicianRemarkMap map[string]models.Remark,
@@ -454,6 +469,8 @@ func createUpdatePatientDetailsDto(omsLisEvent structures.OmsLisEvent,
 	patientDetails.Name = omsPatientDetails.Name
 	patientDetails.Number = omsPatientDetails.Number
 	patientDetails.SystemPatientId = omsPatientDetails.PatientId
+	patientDetails.IsPregnant = omsPatientDetails.IsPregnant
+	patientDetails.Trimester = omsPatientDetails.Trimester
 	patientDetails.UpdatedBy = constants.CitadelSystemId
 
 	return patientDetails
@@ -995,125 +1012,91 @@ func (eventProcessor *EventProcessor) getInvestigationInhouseAbnormality(ctx con
 	return abnormality
 }
 
-func (eventProcessor *EventProcessor) getInvestigationInhouseAutoApprovalStatus(ctx context.Context,
-	investigationValue string, investigation structures.Investigation) bool {
-	autoApprovalStatus := eventProcessor.AbnormalityService.GetInvestigationAutoApprovalStatus(ctx,
-		investigationValue, investigation)
-	return autoApprovalStatus
+func (eventProcessor *EventProcessor) getInvestigationAutoVerificationResult(ctx context.Context,
+	investigationValue, methodType string, imDevice, imDeviceFlag bool, abnormality string,
+	investigation structures.Investigation, deltaCheckResult deltaCheckStructures.DeltaCheckResult,
+	qcFailedTestCodes []string,
+	autoVerificationContext autoVerificationStructures.AutoVerificationContext) autoVerificationStructures.AutoVerificationResult {
+
+	return eventProcessor.AutoVerificationService.EvaluateAutoVerification(ctx,
+		autoVerificationStructures.AutoVerificationRequest{
+			InvestigationValue: investigationValue,
+			MethodType:         methodType,
+			ImDevice:           imDevice,
+			ImDeviceFlag:       imDeviceFlag,
+			IsQcFailed:         utils.SliceContainsString(qcFailedTestCodes, investigation.LisCode),
+			Abnormality:        abnormality,
+			Investigation:      investigation,
+			DeltaCheckResult:   deltaCheckResult,
+			Context:            autoVerificationContext,
+		})
 }
 
-func (eventProcessor *EventProcessor) getInvestigationAutoApprovalStatus(ctx context.Context,
-	investigationValue, entryTime, methodType string, imDevice, imDeviceFlag bool,
-	investigation structures.Investigation, latestPastValueMap map[uint]structures.DeltaValuesStructResponse,
-	qcFailedTestCodes []string) (bool, string, string) {
-
-	// Failing auto approval for QC failed tests
-	if utils.SliceContainsString(qcFailedTestCodes, investigation.LisCode) {
-		return false, constants.APPROVAL_SOURCE_NA, constants.AUTO_APPROVAL_FAIL_REASON_QC_FAILED
-	}
-	if investigationValue == "" || utils.ConvertStringToFloat32ForAbnormality(investigationValue) < 0 || investigationValue[0] == '-' {
-		return false, constants.APPROVAL_SOURCE_NA, constants.AUTO_APPROVAL_FAIL_REASON_INVALID_INVESTIGATION_VALUE
-	}
+func (eventProcessor *EventProcessor) getAutoVerificationContext(ctx context.Context, labId uint,
+	tests structures.OmsTestDetails, patientDetails structures.OmsPatientDetails) autoVerificationStructures.AutoVerificationContext {
 
-	// Approving default auto approval codes
-	if utils.SliceContainsString(constants.DefaultAutoApprovalCodes, investigation.LisCode) {
-		return true, constants.APPROVAL_SOURCE_OH, constants.AUTO_APPROVAL_FAIL_REASON_NA
+	var patientAgeDays *uint
+	if patientDetails.Dob != nil {
+		ageDays := utils.GetAgeDaysFromDob(*patientDetails.Dob)
+		patientAgeDays = &ageDays
+	} else if patientDetails.ExpectedDob != nil {
+		ageDays := utils.GetAgeDaysFromDob(*patientDetails.ExpectedDob)
+		patientAgeDays = &ageDays
+	} else if patientDetails.AgeYears > 0 || patientDetails.AgeMonths > 0 || patientDetails.AgeDays > 0 {
+		ageDays := utils.GetAgeDaysFromDob(utils.GetDobByYearsMonthsDays(int(patientDetails.AgeYears),
+			int(patientDetails.AgeMonths), int(patientDetails.AgeDays)))
+		patientAgeDays = &ageDays
 	}
 
-	// Preventing approval based on delta check
-	pastValueApprovalStatus := eventProcessor.getApprovalStatusBasedOnPastInvestigationResults(ctx, investigationValue,
-		investigation, entryTime, latestPastValueMap)
-	if !pastValueApprovalStatus {
-		return false, constants.APPROVAL_SOURCE_NA, constants.AUTO_APPROVAL_FAIL_REASON_PAST_RECORD
+	return autoVerificationStructures.AutoVerificationContext{
+		LabId:           labId,
+		PatientAgeDays:  patientAgeDays,
+		PatientGender:   utils.GetGenderConstant(patientDetails.Gender),
+		LisCodeValueMap: getLisCodeValueMap(tests.OrderInfo),
+		Rules:           eventProcessor.AutoVerificationService.GetActiveAutoVerificationRules(ctx),
 	}
+}
 
-	// Checking auto approval based on imDevice flag
-	if imDevice {
-		if imDeviceFlag {
-			return true, constants.APPROVAL_SOURCE_IM, constants.AUTO_APPROVAL_FAIL_REASON_NA
+func getLisCodeValueMap(orderInfo map[string]structures.LisTestUpdateInfo) map[string]string {
+	lisCodeValueMap, queue := map[string]string{}, []structures.LisTestResult{}
+	for _, lisTestInfo := range orderInfo {
+		switch lisTestInfo.MetaData.TestType {
+		case constants.InvestigationShortHand:
+			lisCodeValueMap[lisTestInfo.MetaData.TestCode] = lisTestInfo.MetaData.TestValue
+		case constants.GroupShortHand:
+			queue = append(queue, lisTestInfo.MetaData.Parameters...)
 		}
-		return false, constants.APPROVAL_SOURCE_NA, constants.AUTO_APPROVAL_FAIL_REASON_IM_DEVICE
 	}
 
-	// Preventing auto approval for manual input method type
-	if methodType == constants.METHOD_TYPE_MANUAL {
-		return false, constants.APPROVAL_SOURCE_NA, constants.AUTO_APPROVAL_FAIL_REASON_MANUAL_INPUT
-	}
-
-	inHouseAutoApproved := eventProcessor.getInvestigationInhouseAutoApprovalStatus(ctx, investigationValue, investigation)
-
-	// Prevent auto approval if value lies outside the reference range
-	if !inHouseAutoApproved {
-		return false, constants.APPROVAL_SOURCE_NA, constants.AUTO_APPROVAL_FAIL_REASON_REF_RANGE
+	for index := 0; index < len(queue); index++ {
+		switch queue[index].TestType {
+		case constants.InvestigationShortHand:
+			lisCodeValueMap[queue[index].TestCode] = queue[index].TestValue
+		case constants.GroupShortHand:
+			queue = append(queue, queue[index].Parameters...)
+		}
 	}
 
-	return true, constants.APPROVAL_SOURCE_OH, constants.AUTO_APPROVAL_FAIL_REASON_NA
+	return lisCodeValueMap
 }
 
-func (eventProcessor *EventProcessor) getApprovalStatusBasedOnPastInvestigationResults(ctx context.Context,
-	investigationValue string, investigation structures.Investigation,
-	enteredTime string, pastResultsMap map[uint]structures.DeltaValuesStructResponse) bool {
-	if investigationValue == "" {
-		return false
-	}
-
-	if utils.SliceContainsInt(constants.DeltaCheckWhitelistedMasterInvIds, int(investigation.InvestigationId)) {
-		utils.AddLog(ctx, constants.DEBUG_LEVEL, fmt.Sprintf("Skipping delta check for whitelisted investigation id: %d",
-			investigation.InvestigationId), nil, nil)
-		return true
-	}
+func (eventProcessor *EventProcessor) getDeltaCheckResult(ctx context.Context, investigationValue string,
+	enteredAt *time.Time, investigation structures.Investigation,
+	pastResultsMap map[uint]structures.DeltaValuesStructResponse,
+	deltaCheckRulesMap map[uint][]models.DeltaCheckRule) deltaCheckStructures.DeltaCheckResult {
 
-	if enteredTime == "" {
-		utils.AddLog(ctx, constants.ERROR_LEVEL, constants.INVALID_INVESTIGATION_ENTERED_TIME, nil,
-			fmt.Errorf(constants.EMPTY_ENTERED_TIME, investigation.LisCode))
-		return false
+	var pastValue *structures.DeltaValuesStructResponse
+	if lastInvestigation, ok := pastResultsMap[investigation.InvestigationId]; ok {
+		pastValue = &lastInvestigation
 	}
-	enteredTimeUtc := utils.GetTimeFromString(enteredTime)
-	if lastInvestigation, ok := pastResultsMap[investigation.InvestigationId]; !ok {
-		// Approve the investigation if there is no last result for the investigation value.
-		return true
-	} else {
-		utils.AddLog(ctx, constants.DEBUG_LEVEL, fmt.Sprintf(constants.LAST_INVESTIGATION_RESULT,
-			investigation.InvestigationName, lastInvestigation), nil, nil)
 
-		// Approve the investigation if the last result is older than the past value threshold days.
-		diffLastInvestigationTime := utils.GetDaysBetweenTimes(*lastInvestigation.ApprovedAt, *enteredTimeUtc)
-		utils.AddLog(ctx, constants.DEBUG_LEVEL, fmt.Sprintf(constants.DAYS_DIFF_LAST_INV_CURRENT_INV,
-			diffLastInvestigationTime), nil, nil)
-		if investigation.PastValueThresholdDays != 0 && diffLastInvestigationTime > int(investigation.PastValueThresholdDays) {
-			utils.AddLog(ctx, constants.DEBUG_LEVEL, fmt.Sprintf(constants.SKIP_DELTA_CHECK_OLD_PAST_VALUE,
-				investigation.InvestigationId), nil, nil)
-			return true
-		}
-
-		floatInvValue, err := strconv.ParseFloat(investigationValue, 64)
-		if err != nil {
-			return true
-		}
-
-		// Approve if the investigation doesn't have RCV values.
-		if investigation.RcvPositive == 0 && investigation.RcvNegative == 0 {
-			return true
-		}
-
-		floatPastInvValue, err := strconv.ParseFloat(lastInvestigation.InvestigationValue, 64)
-		if err != nil {
-			utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_PARSING_FLOAT_VALUE, nil, err)
-			return false
-		}
-		// Rounding off to 5 decimal places to avoid floating number precision issues.
-		percentDiff := (floatInvValue - floatPastInvValue) / floatPastInvValue * 100
-		percentDiff = math.Round(percentDiff*1e5) / 1e5
-
-		if percentDiff > 0 && percentDiff <= investigation.RcvPositive {
-			return true
-		} else if percentDiff < 0 && percentDiff >= investigation.RcvNegative {
-			return true
-		} else if percentDiff == 0 {
-			return true
-		}
-		return false
-	}
+	return eventProcessor.DeltaCheckService.EvaluateDeltaCheck(ctx, deltaCheckStructures.DeltaCheckRequest{
+		InvestigationValue: investigationValue,
+		EnteredAt:          enteredAt,
+		Investigation:      investigation,
+		PastValue:          pastValue,
+		Rules:              deltaCheckRulesMap[investigation.InvestigationId],
+	})
 }
 
 func (eventProcessor *EventProcessor) fetchInvestigationDetailsFromCds(ctx context.Context,
@@ -1140,6 +1123,15 @@ func (eventProcessor *EventProcessor) fetchInvestigationDetailsFromCds(ctx conte
 		return map[string]structures.Investigation{}, err
 	}
 
+	// The DOB worked out above covers the patients known only by their age, who have neither DOB.
+	var referenceRangeDob *time.Time
+	if parsedDob, err := time.Parse(constants.DateLayout, patientDob); err == nil {
+		referenceRangeDob = &parsedDob
+	}
+	masterInvestigations = utils.ResolveInvestigationReferenceRanges(masterInvestigations,
+		utils.GetReferenceRangePatientDetails(referenceRangeDob, nil, patientDetails.Gender,
+			patientDetails.IsPregnant, patientDetails.Trimester))
+
 	investigationDetailsMap := map[string]structures.Investigation{}
 	for _, investigation := range masterInvestigations {
 		investigationDetailsMap[investigation.LisCode] = investigation
@@ -1157,10 +1149,13 @@ func (eventProcessor *EventProcessor) fetchMasterTestDepartmentMap(ctx context.C
 }
 
 func (eventProcessor *EventProcessor) createInvestigationResultDetailsForInvestigation(
-	ctx context.Context, orderInfo structures.AttuneOrderInfo,
+	ctx context.Context, orderInfo structures.LisTestResult,
 	masterInvestigationDetailsMap map[string]structures.Investigation,
 	latestPastValueMap map[uint]structures.DeltaValuesStructResponse,
-	qcFailedTestCodes []string) (models.InvestigationResult, models.InvestigationResultMetadata) {
+	deltaCheckRulesMap map[uint][]models.DeltaCheckRule,
+	qcFailedTestCodes []string,
+	autoVerificationContext autoVerificationStructures.AutoVerificationContext) (models.InvestigationResult,
+	models.InvestigationResultMetadata) {
 
 	masterInvestigationId := masterInvestigationDetailsMap[orderInfo.TestCode].InvestigationId
 
@@ -1175,13 +1170,13 @@ func (eventProcessor *EventProcessor) createInvestigationResultDetailsForInvesti
 		utils.ConvertStringToCamelCase(masterInvestigationDetailsMap[orderInfo.TestCode].DepartmentName),
 		utils.ConvertStringToCamelCase(orderInfo.DepartmentName))
 
-	uom := utils.GetNonEmptyString(masterInvestigationDetailsMap[orderInfo.TestCode].Unit, orderInfo.UOMCode)
+	uom := utils.GetNonEmptyString(masterInvestigationDetailsMap[orderInfo.TestCode].Unit, orderInfo.Unit)
 
 	methodName := utils.GetNonEmptyString(
 		strings.TrimSpace(masterInvestigationDetailsMap[orderInfo.TestCode].Method),
 		strings.TrimSpace(orderInfo.MethodName))
 
-	methodType := getMethodTypeForInvestigation(orderInfo.DeviceID, orderInfo.MethodName)
+	methodType := getMethodTypeForInvestigation(orderInfo.DeviceId, orderInfo.MethodName)
 
 	referenceRangeText := getReferenceRangeText(masterInvestigationDetailsMap[orderInfo.TestCode].ReferenceRange,
 		orderInfo.ReferenceRange)
@@ -1190,13 +1185,16 @@ func (eventProcessor *EventProcessor) createInvestigationResultDetailsForInvesti
 
 	imDeviceFlag := utils.StringsEqualIgnoreCase(orderInfo.IMDeviceFlag, constants.AutoVerified)
 
-	ohAbnormality := eventProcessor.getInvestigationInhouseAbnormality(ctx, orderInfo.TestValue,
-		masterInvestigationDetailsMap[orderInfo.TestCode])
+	ohAbnormality := utils.GetNonEmptyString(eventProcessor.getInvestigationInhouseAbnormality(ctx,
+		orderInfo.TestValue, masterInvestigationDetailsMap[orderInfo.TestCode]), orderInfo.LisAbnormality)
+
+	deltaCheckResult := eventProcessor.getDeltaCheckResult(ctx, orderInfo.TestValue,
+		utils.GetEnteredAtTime(orderInfo.ResultCapturedAt), masterInvestigationDetailsMap[orderInfo.TestCode],
+		latestPastValueMap, deltaCheckRulesMap)
 
-	isInvestigationAutoApproved, approvalSource, approvalFailureReason :=
-		eventProcessor.getInvestigationAutoApprovalStatus(ctx, orderInfo.TestValue, orderInfo.ResultCapturedAt,
-			methodType, imDevice, imDeviceFlag, masterInvestigationDetailsMap[orderInfo.TestCode],
-			latestPastValueMap, qcFailedTestCodes)
+	autoVerificationResult := eventProcessor.getInvestigationAutoVerificationResult(ctx, orderInfo.TestValue,
+		methodType, imDevice, imDeviceFlag, ohAbnormality, masterInvestigationDetailsMap[orderInfo.TestCode],
+		deltaCheckResult, qcFailedTestCodes, autoVerificationContext)
 
 	isInvestigationCritical := isInvestigationCritical(ohAbnormality)
 
@@ -1221,12 +1219,12 @@ func (eventProcessor *EventProcessor) createInvestigationResultDetailsForInvesti
 		EnteredBy:                          uint(resultCapturedBy),
 		EnteredAt:                          utils.GetEnteredAtTime(orderInfo.ResultCapturedAt),
 		InvestigationStatus:                constants.INVESTIGATION_STATUS_PENDING,
-		IsAutoApproved:                     isInvestigationAutoApproved,
+		IsAutoApproved:                     autoVerificationResult.IsAutoApproved,
 		IsNablApproved:                     masterInvestigationDetailsMap[orderInfo.TestCode].IsNablAccredited,
 		Source:                             constants.SourceAttune,
 		IsCritical:                         isInvestigationCritical,
-		ApprovalSource:                     approvalSource,
-		AutoApprovalFailureReason:          approvalFailureReason,
+		ApprovalSource:                     autoVerificationResult.ApprovalSource,
+		AutoApprovalFailureReason:          autoVerificationResult.AutoApprovalFailureReason,
 	}
 
 	investigationResult.CreatedBy = constants.CitadelSystemId
@@ -1239,6 +1237,10 @@ func (eventProcessor *EventProcessor) createInvestigationResultDetailsForInvesti
 		QcWestGardWarning: orderInfo.QcWestGardWarning,
 		QcStatus:          orderInfo.QcStatus,
 	}
+	investigationResultMetadata = deltaCheckMapper.MapDeltaCheckResultToMetadata(deltaCheckResult,
+		investigationResultMetadata)
+	investigationResultMetadata = autoVerificationMapper.MapAutoVerificationResultToMetadata(autoVerificationResult,
+		investigationResultMetadata)
 	investigationResultMetadata.CreatedBy = constants.CitadelSystemId
 	investigationResultMetadata.UpdatedBy = constants.CitadelSystemId
 
@@ -1247,7 +1249,7 @@ func (eventProcessor *EventProcessor) createInvestigationResultDetailsForInvesti
 
 func (eventProcessor *EventProcessor) createInvestigationResultsDetailsForPanel(ctx context.Context,
 	masterInvestigationDetailsMap map[string]structures.Investigation,
-	orderInfo structures.AttuneOrderContentListInfoEvent) (models.InvestigationResult,
+	orderInfo structures.LisTestResult) (models.InvestigationResult,
 	models.InvestigationResultMetadata) {
 
 	masterInvestigationId := masterInvestigationDetailsMap[orderInfo.TestCode].InvestigationId
@@ -1263,19 +1265,19 @@ func (eventProcessor *EventProcessor) createInvestigationResultsDetailsForPanel(
 		utils.ConvertStringToCamelCase(masterInvestigationDetailsMap[orderInfo.TestCode].DepartmentName),
 		utils.ConvertStringToCamelCase(orderInfo.DepartmentName))
 
-	uom := utils.GetNonEmptyString(masterInvestigationDetailsMap[orderInfo.TestCode].Unit, orderInfo.UOMCode)
+	uom := utils.GetNonEmptyString(masterInvestigationDetailsMap[orderInfo.TestCode].Unit, orderInfo.Unit)
 
 	methodName := utils.GetNonEmptyString(
 		strings.TrimSpace(masterInvestigationDetailsMap[orderInfo.TestCode].Method),
 		strings.TrimSpace(orderInfo.MethodName))
 
-	methodType := getMethodTypeForInvestigation(orderInfo.DeviceID, methodName)
+	methodType := getMethodTypeForInvestigation(orderInfo.DeviceId, methodName)
 
 	referenceRangeText := getReferenceRangeText(
 		masterInvestigationDetailsMap[orderInfo.TestCode].ReferenceRange, orderInfo.ReferenceRange)
 
-	ohAbnormality := eventProcessor.getInvestigationInhouseAbnormality(ctx, orderInfo.TestValue,
-		masterInvestigationDetailsMap[orderInfo.TestCode])
+	ohAbnormality := utils.GetNonEmptyString(eventProcessor.getInvestigationInhouseAbnormality(ctx,
+		orderInfo.TestValue, masterInvestigationDetailsMap[orderInfo.TestCode]), orderInfo.LisAbnormality)
 
 	isInvestigationCritical := isInvestigationCritical(ohAbnormality)
 
@@ -1319,7 +1321,7 @@ func (eventProcessor *EventProcessor) createInvestigationResultsDetailsForPanel(
 func (eventProcessor *EventProcessor) createFlattenedInvestigationResultsAndInitialTestDetailsMap(ctx context.Context,
 	eventType, cityCode string, tests structures.OmsTestDetails,
 	masterInvestigationDetailsMap map[string]structures.Investigation, usersList []models.User, patientId string,
-	labId uint) (
+	labId uint, patientDetails structures.OmsPatientDetails) (
 	map[string][]models.InvestigationResult, map[uint]models.InvestigationResultMetadata, map[string]structures.InitialTestDetails,
 	map[string]models.InvestigationData, map[string]models.Remark, map[string]models.Remark, []string, map[string][]structures.TestDocumentInfoResponse) {
 
@@ -1330,12 +1332,16 @@ func (eventProcessor *EventProcessor) createFlattenedInvestigationResultsAndInit
 	investigationCodeMedicalRemarkMap := map[string]models.Remark{}
 	investigationCodeTechnicianRemarkMap := map[string]models.Remark{}
 	testCodeTestIdMap := map[string]string{}
+	testCodeMasterTestIdMap := map[string]uint{}
 	testDocumentMap := map[string][]structures.TestDocumentInfoResponse{}
 
 	for _, testDetails := range tests.TestDetails {
 		testCodeTestIdMap[testDetails.TestCode] = testDetails.TestId
+		testCodeMasterTestIdMap[testDetails.TestCode] = testDetails.MasterTestId
 	}
-	qcFailedOmsTestIds, qcFailedTestCodes := getQcFailedOmsTestIds(tests.OrderInfo, testCodeTestIdMap, labId)
+	lisCodeQcResultMap := eventProcessor.QcService.EvaluateLisQcResults(ctx, labId, tests.OrderInfo)
+	qcFailedOmsTestIds, qcFailedTestCodes := getQcFailedOmsTestIds(tests.OrderInfo, testCodeTestIdMap, labId,
+		lisCodeQcResultMap)
 
 	masterInvestigationIds := []uint{}
 	for _, invResults := range masterInvestigationDetailsMap {
@@ -1352,6 +1358,9 @@ func (eventProcessor *EventProcessor) createFlattenedInvestigationResultsAndInit
 		}
 	}
 
+	deltaCheckRulesMap := eventProcessor.DeltaCheckService.GetActiveDeltaCheckRulesMap(ctx)
+	autoVerificationContext := eventProcessor.getAutoVerificationContext(ctx, labId, tests, patientDetails)
+
 	attuneUserIdToUserIdMap := map[int]uint{}
 	userIdToAttuneUserIdMap := map[uint]int{}
 	for _, user := range usersList {
@@ -1362,6 +1371,7 @@ func (eventProcessor *EventProcessor) createFlattenedInvestigationResultsAndInit
 
 	for _, lisTestInfo := range tests.OrderInfo {
 		lisOrderInfo := lisTestInfo.MetaData
+		autoVerificationContext.MasterTestId = testCodeMasterTestIdMap[lisOrderInfo.TestCode]
 
 		investigationResults, initialTestDetails := []models.InvestigationResult{}, structures.InitialTestDetails{}
 		switch lisOrderInfo.TestType {
@@ -1371,26 +1381,31 @@ func (eventProcessor *EventProcessor) createFlattenedInvestigationResultsAndInit
 				eventProcessor.createInvestigationResultsAndInitialTestDetailsForInvestigation(ctx, eventType, cityCode,
 					lisOrderInfo, attuneUserIdToUserIdMap, masterInvestigationIdInvestigationResultMetadataMap, masterInvestigationDetailsMap,
 					investigationCodeInvestigationDataMap, investigationCodeMedicalRemarkMap,
-					investigationCodeTechnicianRemarkMap, pastResultsMap, qcFailedTestCodes, testDocumentMap)
+					investigationCodeTechnicianRemarkMap, pastResultsMap, deltaCheckRulesMap, qcFailedTestCodes,
+					autoVerificationContext, testDocumentMap)
 		case constants.GroupShortHand:
 			investigationResults, initialTestDetails, investigationCodeInvestigationDataMap,
 				investigationCodeMedicalRemarkMap, investigationCodeTechnicianRemarkMap, testDocumentMap =
 				eventProcessor.createInvestigationResultsAndInitialTestDetailsForPanel(ctx, eventType, cityCode,
 					lisOrderInfo, attuneUserIdToUserIdMap, masterInvestigationIdInvestigationResultMetadataMap, masterInvestigationDetailsMap,
 					investigationCodeInvestigationDataMap, investigationCodeMedicalRemarkMap,
-					investigationCodeTechnicianRemarkMap, pastResultsMap, qcFailedTestCodes, testDocumentMap)
+					investigationCodeTechnicianRemarkMap, pastResultsMap, deltaCheckRulesMap, qcFailedTestCodes,
+					autoVerificationContext, testDocumentMap)
 		}
 		testIdInitialTestDetailsMap[testCodeTestIdMap[lisOrderInfo.TestCode]] = initialTestDetails
 		testIdInvestigationResultsMap[testCodeTestIdMap[lisOrderInfo.TestCode]] = investigationResults
 	}
 
+	applyWestgardQcResults(lisCodeQcResultMap, masterInvestigationDetailsMap, testIdInvestigationResultsMap,
+		masterInvestigationIdInvestigationResultMetadataMap)
+
 	return testIdInvestigationResultsMap, masterInvestigationIdInvestigationResultMetadataMap, testIdInitialTestDetailsMap,
 		investigationCodeInvestigationDataMap, investigationCodeMedicalRemarkMap, investigationCodeTechnicianRemarkMap,
 		qcFailedOmsTestIds, testDocumentMap
 }
 
 func (eventProcessor *EventProcessor) createInvestigationResultsAndInitialTestDetailsForInvestigation(
-	ctx context.Context, eventType, cityCode string, lisOrderInfo structures.AttuneOrderInfo,
+	ctx context.Context, eventType, cityCode string, lisOrderInfo structures.LisTestResult,
 	attuneUserIdToUserIdMap map[int]uint,
 	masterInvestigationIdInvestigationResultMetadataMap map[uint]models.InvestigationResultMetadata,
 	masterInvestigationDetailsMap map[string]structures.Investigation,
@@ -1398,7 +1413,9 @@ func (eventProcessor *EventProcessor) createInvestigationResultsAndInitialTestDe
 	investigationCodeMedicalRemarkMap map[string]models.Remark,
 	investigationCodeTechnicianRemarkMap map[string]models.Remark,
 	latestPastValueMap map[uint]structures.DeltaValuesStructResponse,
+	deltaCheckRulesMap map[uint][]models.DeltaCheckRule,
 	qcFailedTestCodes []string,
+	autoVerificationContext autoVerificationStructures.AutoVerificationContext,
 	testDocumentMap map[string][]structures.TestDocumentInfoResponse,
 ) ([]models.InvestigationResult, structures.InitialTestDetails,
 	map[string]models.InvestigationData, map[string]models.Remark, map[string]models.Remark, map[string][]structures.TestDocumentInfoResponse) {
@@ -1410,7 +1427,8 @@ func (eventProcessor *EventProcessor) createInvestigationResultsAndInitialTestDe
 	currentTime := utils.GetCurrentTime()
 
 	investigationResult, investigationResultMetadata := eventProcessor.createInvestigationResultDetailsForInvestigation(ctx,
-		orderInfo, masterInvestigationDetailsMap, latestPastValueMap, qcFailedTestCodes)
+		orderInfo, masterInvestigationDetailsMap, latestPastValueMap, deltaCheckRulesMap, qcFailedTestCodes,
+		autoVerificationContext)
 
 	if investigationResult.IsCritical {
 		isTestCritical = true
@@ -1434,14 +1452,7 @@ func (eventProcessor *EventProcessor) createInvestigationResultsAndInitialTestDe
 		investigationResult.InvestigationValue = orderInfo.TestValue
 		investigationResult.DeviceValue = orderInfo.DeviceActualValue
 	} else {
-		data, _ := utils.ParseXMLToJSON(ctx, orderInfo.TestValue)
-		investigationData := models.InvestigationData{
-			Data:     data,
-			DataType: constants.InvestigationValue,
-		}
-		investigationData.CreatedBy = constants.CitadelSystemId
-		investigationData.UpdatedBy = constants.CitadelSystemId
-		investigationCodeInvestigationDataMap[orderInfo.TestCode] = investigationData
+		investigationCodeInvestigationDataMap[orderInfo.TestCode] = createInvestigationDataModel(ctx, orderInfo.TestValue)
 	}
 
 	if orderInfo.MedicalRemarks != "" {
@@ -1473,115 +1484,100 @@ func (eventProcessor *EventProcessor) createInvestigationResultsAndInitialTestDe
 }
 
 func (eventProcessor *EventProcessor) createInvestigationResultsAndInitialTestDetailsForPanel(ctx context.Context,
-	eventType, cityCode string, lisOrderInfo structures.AttuneOrderInfo, attuneUserIdToUserIdMap map[int]uint,
+	eventType, cityCode string, lisOrderInfo structures.LisTestResult, attuneUserIdToUserIdMap map[int]uint,
 	masterInvestigationIdInvestigationResultMetadataMap map[uint]models.InvestigationResultMetadata,
 	masterInvestigationDetailsMap map[string]structures.Investigation,
 	investigationCodeInvestigationDataMap map[string]models.InvestigationData,
 	investigationCodeMedicalRemarkMap map[string]models.Remark,
 	investigationCodeTechnicianRemarkMap map[string]models.Remark,
 	pastResultsMap map[uint]structures.DeltaValuesStructResponse,
+	deltaCheckRulesMap map[uint][]models.DeltaCheckRule,
 	qcFailedTestCodes []string,
+	autoVerificationContext autoVerificationStructures.AutoVerificationContext,
 	testDocumentMap map[string][]structures.TestDocumentInfoResponse,
 ) ([]models.InvestigationResult, structures.InitialTestDetails,
 	map[string]models.InvestigationData, map[string]models.Remark, map[string]models.Remark,
 	map[string][]structures.TestDocumentInfoResponse) {
 
-	investigationResults, queue, index := []models.InvestigationResult{}, []interface{}{}, 0
+	investigationResults := []models.InvestigationResult{}
 	isTestAutoApproved, isTestCritical, areAttuneStatusesApproved := true, false, true
 	imDevice, imDeviceFlag := false, true
 
 	autoApproveId, _ := strconv.ParseUint(constants.AutoApprovalIdsMap[strings.ToLower(cityCode)], 10, 64)
 	currentTime := utils.GetCurrentTime()
 
-	queue = append(queue, lisOrderInfo.OrderContentListInfo)
-
-	for index < len(queue) {
-		currentNode := queue[index]
-		index += 1
-		marshalledNode, err := json.Marshal(currentNode)
-		if err != nil {
-			utils.AddLog(ctx, constants.DEBUG_LEVEL, utils.GetCurrentFunctionName(), nil, err)
-			continue
-		}
+	queue := append([]structures.LisTestResult{}, lisOrderInfo.Parameters...)
 
-		attuneOrderContentListInfo := []structures.AttuneOrderContentListInfoEvent{}
-		err = json.Unmarshal(marshalledNode, &attuneOrderContentListInfo)
-		if err != nil {
-			utils.AddLog(ctx, constants.DEBUG_LEVEL, utils.GetCurrentFunctionName(), nil, err)
-			continue
-		}
-
-		for _, orderInfo := range attuneOrderContentListInfo {
-			switch orderInfo.TestType {
-			case constants.InvestigationShortHand:
-				investigationResult, investigationResultMetadata := eventProcessor.createInvestigationResultsDetailsForPanel(ctx,
-					masterInvestigationDetailsMap, orderInfo)
-				if len(orderInfo.TestDocumentInfo) > 0 {
-					for _, testDocument := range orderInfo.TestDocumentInfo {
-						testDocumentMap[orderInfo.TestCode] = append(testDocumentMap[orderInfo.TestCode], structures.TestDocumentInfoResponse{
-							TestCode:     orderInfo.TestCode,
-							TestDocument: testDocument.TestDocument,
-						})
-					}
-				}
+	for index := 0; index < len(queue); index++ {
+		orderInfo := queue[index]
+		switch orderInfo.TestType {
+		case constants.InvestigationShortHand:
+			investigationResult, investigationResultMetadata := eventProcessor.createInvestigationResultsDetailsForPanel(ctx,
+				masterInvestigationDetailsMap, orderInfo)
+			for _, testDocument := range orderInfo.TestDocuments {
+				testDocumentMap[orderInfo.TestCode] = append(testDocumentMap[orderInfo.TestCode], structures.TestDocumentInfoResponse{
+					TestCode:     orderInfo.TestCode,
+					TestDocument: testDocument,
+				})
+			}
 
-				if investigationResult.IsCritical {
-					isTestCritical = true
-				}
+			if investigationResult.IsCritical {
+				isTestCritical = true
+			}
 
-				if eventType == constants.OmsApprovedEvent && orderInfo.TestStatus != constants.AttuneTestStatusApprove {
-					// If any of the investigation is not approved, then the test is not approved
-					areAttuneStatusesApproved = false
-				}
+			if eventType == constants.OmsApprovedEvent && orderInfo.TestStatus != constants.LIS_TEST_STATUS_APPROVED {
+				// If any of the investigation is not approved, then the test is not approved
+				areAttuneStatusesApproved = false
+			}
 
-				if masterInvestigationDetailsMap[orderInfo.TestCode].ResultRepresentationType ==
-					constants.BiopatternRepresentationType {
-					investigationResult.InvestigationValue = orderInfo.TestValue
-					investigationResult.DeviceValue = orderInfo.DeviceActualValue
-				} else {
-					data, _ := utils.ParseXMLToJSON(ctx, orderInfo.TestValue)
-					investigationData := models.InvestigationData{
-						Data:     data,
-						DataType: constants.InvestigationValue,
-					}
-					investigationData.CreatedBy = constants.CitadelSystemId
-					investigationData.UpdatedBy = constants.CitadelSystemId
-					investigationCodeInvestigationDataMap[orderInfo.TestCode] = investigationData
-				}
+			if masterInvestigationDetailsMap[orderInfo.TestCode].ResultRepresentationType ==
+				constants.BiopatternRepresentationType {
+				investigationResult.InvestigationValue = orderInfo.TestValue
+				investigationResult.DeviceValue = orderInfo.DeviceActualValue
+			} else {
+				investigationCodeInvestigationDataMap[orderInfo.TestCode] =
+					createInvestigationDataModel(ctx, orderInfo.TestValue)
+			}
 
-				if orderInfo.MedicalRemarks != "" {
-					investigationCodeMedicalRemarkMap[orderInfo.TestCode] = createInitialRemarkModel(
-						constants.REMARK_TYPE_MEDICAL_REMARK, orderInfo.MedicalRemarks)
-				}
-				if orderInfo.TechnicalRemarks != "" {
-					investigationCodeTechnicianRemarkMap[orderInfo.TestCode] = createInitialRemarkModel(
-						constants.REMARK_TYPE_TECHINICIAN_REMARK, orderInfo.TechnicalRemarks)
-				}
+			if orderInfo.MedicalRemarks != "" {
+				investigationCodeMedicalRemarkMap[orderInfo.TestCode] = createInitialRemarkModel(
+					constants.REMARK_TYPE_MEDICAL_REMARK, orderInfo.MedicalRemarks)
+			}
+			if orderInfo.TechnicalRemarks != "" {
+				investigationCodeTechnicianRemarkMap[orderInfo.TestCode] = createInitialRemarkModel(
+					constants.REMARK_TYPE_TECHINICIAN_REMARK, orderInfo.TechnicalRemarks)
+			}
 
-				if utils.StringsEqualIgnoreCase(orderInfo.IMDevice, constants.SmallYes) {
-					imDevice = true
-				}
+			if utils.StringsEqualIgnoreCase(orderInfo.IMDevice, constants.SmallYes) {
+				imDevice = true
+			}
 
-				investigationResults = append(investigationResults, investigationResult)
-				masterInvestigationIdInvestigationResultMetadataMap[investigationResult.MasterInvestigationId] = investigationResultMetadata
-				if !utils.StringsEqualIgnoreCase(orderInfo.IMDeviceFlag, constants.AutoVerified) {
-					imDeviceFlag = false
-				}
-			case constants.GroupShortHand:
-				queue = append(queue, orderInfo.ParameterListInfo)
+			investigationResults = append(investigationResults, investigationResult)
+			masterInvestigationIdInvestigationResultMetadataMap[investigationResult.MasterInvestigationId] = investigationResultMetadata
+			if !utils.StringsEqualIgnoreCase(orderInfo.IMDeviceFlag, constants.AutoVerified) {
+				imDeviceFlag = false
 			}
+		case constants.GroupShortHand:
+			queue = append(queue, orderInfo.Parameters...)
 		}
 	}
 
 	for index, investigationResult := range investigationResults {
-		isInvestigationAutoApproved, approvalSource, approvalFailureReason :=
-			eventProcessor.getInvestigationAutoApprovalStatus(ctx, investigationResult.InvestigationValue,
-				utils.GetTimeInString(investigationResult.EnteredAt), investigationResult.MethodType,
-				imDevice, imDeviceFlag, masterInvestigationDetailsMap[investigationResult.LisCode],
-				pastResultsMap, qcFailedTestCodes)
-		investigationResult.IsAutoApproved = isInvestigationAutoApproved
-		investigationResult.ApprovalSource = approvalSource
-		investigationResult.AutoApprovalFailureReason = approvalFailureReason
+		deltaCheckResult := eventProcessor.getDeltaCheckResult(ctx, investigationResult.InvestigationValue,
+			investigationResult.EnteredAt, masterInvestigationDetailsMap[investigationResult.LisCode],
+			pastResultsMap, deltaCheckRulesMap)
+		autoVerificationResult := eventProcessor.getInvestigationAutoVerificationResult(ctx,
+			investigationResult.InvestigationValue, investigationResult.MethodType, imDevice, imDeviceFlag,
+			investigationResult.Abnormality, masterInvestigationDetailsMap[investigationResult.LisCode],
+			deltaCheckResult, qcFailedTestCodes, autoVerificationContext)
+		investigationResultMetadata := deltaCheckMapper.MapDeltaCheckResultToMetadata(deltaCheckResult,
+			masterInvestigationIdInvestigationResultMetadataMap[investigationResult.MasterInvestigationId])
+		masterInvestigationIdInvestigationResultMetadataMap[investigationResult.MasterInvestigationId] =
+			autoVerificationMapper.MapAutoVerificationResultToMetadata(autoVerificationResult, investigationResultMetadata)
+
+		investigationResult.IsAutoApproved = autoVerificationResult.IsAutoApproved
+		investigationResult.ApprovalSource = autoVerificationResult.ApprovalSource
+		investigationResult.AutoApprovalFailureReason = autoVerificationResult.AutoApprovalFailureReason
 		investigationResults[index] = investigationResult
 	}
 
@@ -1702,12 +1698,31 @@ func (eventProcessor *EventProcessor) createData(ctx context.Context,
 		}
 
 		// Create InvestigationData
-		_, cErr = eventProcessor.InvestigationResultsService.CreateInvestigationDataWithTx(tx,
+		investigationData, cErr := eventProcessor.InvestigationResultsService.CreateInvestigationDataWithTx(tx,
 			createInvestigationDataDto(investigationResults, investigationCodeInvestigationDataMap))
 		if cErr != nil {
 			return errors.New(cErr.Message)
 		}
 
+		// Create CultureResults
+		cErr = eventProcessor.CultureResultsService.SyncCultureResultsWithTx(ctx, tx, investigationData)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
+		// Order reflex tests for the saved results
+		cErr = eventProcessor.ReflexRulesService.EvaluateReflexRulesWithTx(ctx, tx, testDetails, investigationResults,
+			constants.CitadelSystemId)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
+		// Open critical calls for the critical results
+		cErr = eventProcessor.CriticalCallService.CreateCriticalCallsWithTx(ctx, tx, testDetails, investigationResults)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
 		// Map test documents to investigation IDs
 		getInvestigationIdForTestDocumentMap(investigationResults, testDocumentMap)
 
@@ -1731,7 +1746,7 @@ func (eventProcessor *EventProcessor) createData(ctx context.Context,
 		}
 
 		// Handle rerun data and sync to attune steps
-		rerunDetails, visitIdToAttuneOrderMap, cErr := eventProcessor.getQcFailedRerunData(ctx, qcFailedOmsTestIds,
+		rerunDetails, visitIdToLisOrderMap, cErr := eventProcessor.getQcFailedRerunData(ctx, qcFailedOmsTestIds,
 			omsTestIdTestDetailMap, omsLisEvent.Order.CityCode, investigationResults, []models.RerunInvestigationResult{}, userIdToAttuneUserIdMap)
 		if cErr != nil {
 			return errors.New(cErr.Message)
@@ -1742,8 +1757,8 @@ func (eventProcessor *EventProcessor) createData(ctx context.Context,
 			return errors.New(cErr.Message)
 		}
 
-		for _, attuneResponse := range visitIdToAttuneOrderMap {
-			cErr = eventProcessor.AttuneClient.InsertTestDataToAttune(ctx, attuneResponse)
+		for visitId, lisOrder := range visitIdToLisOrderMap {
+			cErr = eventProcessor.LisService.PushApprovals(ctx, visitId, lisOrder)
 			if cErr != nil {
 				return errors.New(cErr.Message)
 			}
@@ -1880,10 +1895,31 @@ func (eventProcessor *EventProcessor) createUpdateData(ctx context.Context,
 			return errors.New(cErr.Message)
 		}
 
+		// Create/Update CultureResults
+		cErr = eventProcessor.CultureResultsService.SyncCultureResultsWithTx(ctx, tx,
+			append(createInvestigationData, updateInvestigationData...))
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
 		// Map test documents to investigation IDs
 		combinedInvestigationResults := append(createInvestigationResults, updateInvestigationResults...)
 		getInvestigationIdForTestDocumentMap(combinedInvestigationResults, testDocumentMap)
 
+		// Order reflex tests for the saved results
+		cErr = eventProcessor.ReflexRulesService.EvaluateReflexRulesWithTx(ctx, tx,
+			append(createTestDetails, updateTestDetails...), combinedInvestigationResults, constants.CitadelSystemId)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
+		// Open critical calls for the critical results
+		cErr = eventProcessor.CriticalCallService.CreateCriticalCallsWithTx(ctx, tx,
+			append(createTestDetails, updateTestDetails...), combinedInvestigationResults)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
 		// Create/Update Remarks
 		createRemarks, updateRemarks := getCreateUpdateRemarksDto(createInvestigationResults, updateInvestigationResults,
 			medicalRemarks, technicianRemarks, investigationCodeMedicalRemarkMap, investigationCodeTechnicianRemarkMap)
@@ -1913,7 +1949,7 @@ func (eventProcessor *EventProcessor) createUpdateData(ctx context.Context,
 		// Handle rerun data and sync to attune steps
 		allInvestigationResults := createInvestigationResults
 		allInvestigationResults = append(allInvestigationResults, updateInvestigationResults...)
-		rerunDetails, visitIdToAttuneOrderMap, cErr := eventProcessor.getQcFailedRerunData(ctx,
+		rerunDetails, visitIdToLisOrderMap, cErr := eventProcessor.getQcFailedRerunData(ctx,
 			qcFailedOmsTestIds, omsTestIdTestDetailMap, omsLisEvent.Order.CityCode, allInvestigationResults,
 			rerunInvResults, userIdToAttuneUserIdMap)
 		if cErr != nil {
@@ -1925,8 +1961,8 @@ func (eventProcessor *EventProcessor) createUpdateData(ctx context.Context,
 			return errors.New(cErr.Message)
 		}
 
-		for _, attuneResponse := range visitIdToAttuneOrderMap {
-			cErr = eventProcessor.AttuneClient.InsertTestDataToAttune(ctx, attuneResponse)
+		for visitId, lisOrder := range visitIdToLisOrderMap {
+			cErr = eventProcessor.LisService.PushApprovals(ctx, visitId, lisOrder)
 			if cErr != nil {
 				return errors.New(cErr.Message)
 			}
@@ -2062,21 +2098,27 @@ func (eventProcessor *EventProcessor) ReleaseReport(ctx context.Context, taskId
 	return nil
 }
 
+// getQcFailedOmsTestIds returns the tests and investigations failing QC, either as flagged by Attune or as
+// evaluated by the Westgard rules in Citadel.
 func getQcFailedOmsTestIds(testUpdates map[string]structures.LisTestUpdateInfo, testCodeTestIdMap map[string]string,
-	labId uint) ([]string, []string) {
+	labId uint, lisCodeQcResultMap map[string]qcStructures.QcResult) ([]string, []string) {
 	qcFailedTestIds, qcFailedTestCodes := []string{}, []string{}
 	if !utils.IsQcEnabledForLab(labId) {
 		return qcFailedTestIds, qcFailedTestCodes
 	}
+	isQcFailed := func(lisCode, upstreamQcStatus string) bool {
+		return upstreamQcStatus == constants.QCStatusFail ||
+			lisCodeQcResultMap[lisCode].QcStatus == constants.QCStatusFail
+	}
 	for _, testDetails := range testUpdates {
-		if testDetails.MetaData.QcStatus == constants.QCStatusFail {
+		if isQcFailed(testDetails.TestCode, testDetails.MetaData.QcStatus) {
 			qcFailedTestCodes = append(qcFailedTestCodes, testDetails.TestCode)
 			testId := testCodeTestIdMap[testDetails.TestCode]
 			qcFailedTestIds = append(qcFailedTestIds, testId)
 		}
-		if len(testDetails.MetaData.OrderContentListInfo) > 0 {
-			for _, orderContent := range testDetails.MetaData.OrderContentListInfo {
-				if orderContent.QcStatus == constants.QCStatusFail {
+		if len(testDetails.MetaData.Parameters) > 0 {
+			for _, orderContent := range testDetails.MetaData.Parameters {
+				if isQcFailed(orderContent.TestCode, orderContent.QcStatus) {
 					qcFailedTestCodes = append(qcFailedTestCodes, orderContent.TestCode)
 					qcFailedTestCodes = append(qcFailedTestCodes, testDetails.TestCode)
 					testId := testCodeTestIdMap[testDetails.TestCode]
@@ -2088,11 +2130,39 @@ func getQcFailedOmsTestIds(testUpdates map[string]structures.LisTestUpdateInfo,
 	return utils.CreateUniqueSliceString(qcFailedTestIds), utils.CreateUniqueSliceString(qcFailedTestCodes)
 }
 
+// applyWestgardQcResults records the Westgard evaluation on the investigation result metadata and adds the
+// violated rules to the failure reason of the investigations held for QC failure.
+func applyWestgardQcResults(lisCodeQcResultMap map[string]qcStructures.QcResult,
+	masterInvestigationDetailsMap map[string]structures.Investigation,
+	testIdInvestigationResultsMap map[string][]models.InvestigationResult,
+	masterInvestigationIdInvestigationResultMetadataMap map[uint]models.InvestigationResultMetadata) {
+
+	for lisCode, qcResult := range lisCodeQcResultMap {
+		masterInvestigationId := masterInvestigationDetailsMap[lisCode].InvestigationId
+		if metadata, exists := masterInvestigationIdInvestigationResultMetadataMap[masterInvestigationId]; exists {
+			masterInvestigationIdInvestigationResultMetadataMap[masterInvestigationId] =
+				qcMapper.MapQcResultToMetadata(qcResult, metadata)
+		}
+	}
+
+	for _, investigationResults := range testIdInvestigationResultsMap {
+		for i, investigationResult := range investigationResults {
+			if investigationResult.AutoApprovalFailureReason != constants.AUTO_APPROVAL_FAIL_REASON_QC_FAILED {
+				continue
+			}
+			if failureReason := qcMapper.GetAutoApprovalFailureReason(
+				lisCodeQcResultMap[investigationResult.LisCode]); failureReason != "" {
+				investigationResults[i].AutoApprovalFailureReason = failureReason
+			}
+		}
+	}
+}
+
 func (eventProcessor *EventProcessor) getQcFailedRerunData(ctx context.Context,
 	qcFailedOmsTestIds []string, testIdTestDetailsMap map[string]models.TestDetail, cityCode string,
 	invResults []models.InvestigationResult, rerunInvResult []models.RerunInvestigationResult,
 	userIdToAttuneUserId map[uint]int) (
-	[]models.RerunInvestigationResult, map[string]structures.AttuneOrderResponse, *structures.CommonError) {
+	[]models.RerunInvestigationResult, map[string]structures.LisOrder, *structures.CommonError) {
 	qcFailedTestDetailIds := []uint{}
 	qcFailedTestDetails := []models.TestDetail{}
 	qcFailedInvResults := []models.InvestigationResult{}
@@ -2117,7 +2187,7 @@ func (eventProcessor *EventProcessor) getQcFailedRerunData(ctx context.Context,
 		}
 	}
 	if len(qcFailedTestDetailIds) > 0 {
-		rerunDetails, visitIdToAttuneOrder, cErr := eventProcessor.TaskService.GetQcFailedTestDataToRerun(ctx,
+		rerunDetails, visitIdToLisOrder, cErr := eventProcessor.TaskService.GetQcFailedTestDataToRerun(ctx,
 			constants.CitadelSystemId, qcFailedTestDetailIds, cityCode, qcFailedTestDetails, qcFailedInvResults,
 			userIdToAttuneUserId)
 		if cErr != nil {
@@ -2132,7 +2202,7 @@ func (eventProcessor *EventProcessor) getQcFailedRerunData(ctx context.Context,
 				}
 			}
 		}
-		return rerunDetails, visitIdToAttuneOrder, nil
+		return rerunDetails, visitIdToLisOrder, nil
 	}
-	return []models.RerunInvestigationResult{}, map[string]structures.AttuneOrderResponse{}, nil
+	return []models.RerunInvestigationResult{}, map[string]structures.LisOrder{}, nil
 }
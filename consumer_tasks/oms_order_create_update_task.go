@@ -612,6 +612,14 @@ func (eventProcessor *EventProcessor) createUpdateOrderDetailsData(ctx context.C
 			if cErr != nil {
 				return errors.New(cErr.Message)
 			}
+
+			for omsTestId, lisSyncAtTime := range testIdLisSyncAtMap {
+				cErr = eventProcessor.SampleService.WriteLabEtaUpdateEventWithTx(ctx, tx, omsOrderId,
+					[]string{omsTestId}, lisSyncAtTime, orderDetails.CityCode)
+				if cErr != nil {
+					return errors.New(cErr.Message)
+				}
+			}
 		}
 
 		if !isSampleCollected || omsOrderEvent.IsPartialCancellationFlow {
@@ -662,11 +670,8 @@ func (eventProcessor *EventProcessor) createUpdateOrderDetailsData(ctx context.C
 	if isSampleCollected {
 		if len(testIdLisSyncAtMap) > 0 {
 			centralOmsTestIds := []string{}
-			for omsTestId, lisSyncAtTime := range testIdLisSyncAtMap {
+			for omsTestId := range testIdLisSyncAtMap {
 				centralOmsTestIds = append(centralOmsTestIds, omsTestId)
-				messageBody, messageAttributes := eventProcessor.PubsubService.GetLabEtaUpdateEvent(omsOrderId,
-					[]string{omsTestId}, lisSyncAtTime, orderDetails.CityCode)
-				eventProcessor.SnsClient.PublishTo(ctx, messageBody, messageAttributes, "", constants.OmsUpdatesTopicArn, "")
 			}
 
 			cErr = eventProcessor.TestDetailService.UpdateTaskIdInTestDetailsWithOmsTestIds(centralOmsTestIds, task.Id)
@@ -682,7 +687,7 @@ func (eventProcessor *EventProcessor) createUpdateOrderDetailsData(ctx context.C
 		}
 
 		if !recollectionPendingPresent {
-			go eventProcessor.SampleService.PublishRemoveSampleRejectedTagEvent(orderDetails.OmsRequestId,
+			eventProcessor.SampleService.WriteRemoveSampleRejectedTagEvent(ctx, orderDetails.OmsRequestId,
 				[]string{orderDetails.OmsOrderId}, !recollectionPendingPresent, orderDetails.CityCode)
 		}
 	}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 
+	"gorm.io/gorm"
+
 	"github.com/Orange-Health/citadel/common/constants"
 	"github.com/Orange-Health/citadel/common/structures"
 	"github.com/Orange-Health/citadel/common/utils"
@@ -68,27 +70,23 @@ func (eventProcessor *EventProcessor) OrderReportPdfReadyEventTask(ctx context.C
 		return nil
 	}
 
-	cErr := eventProcessor.TestDetailService.UpdateReportStatusByOmsTestIds(reportReadyEvent.ReportPdfEvent.TestIds,
-		constants.TEST_REPORT_STATUS_QUEUED, constants.TEST_REPORT_STATUS_CREATED)
-	if cErr != nil {
-		return errors.New(cErr.Message)
-	}
-
 	reportReadyEvent.ServicingCityCode = reportReadyEvent.ReportPdfEvent.CityCode
 	messageBody, messageAttributes := eventProcessor.PubsubService.GetReportReadyEvent(ctx, reportReadyEvent)
-	cErr = eventProcessor.SnsClient.PublishTo(ctx, messageBody, messageAttributes,
-		fmt.Sprint(reportReadyEvent.ReportPdfEvent.OrderID), constants.ReportReadyTopicArn,
-		reportReadyEvent.ReportPdfEvent.JobUUID)
-	if cErr != nil {
-		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, errors.New(cErr.Message))
-		return errors.New(cErr.Message)
-	}
-
-	cErr = eventProcessor.TestDetailService.UpdateReportStatusByOmsTestIds(reportReadyEvent.ReportPdfEvent.TestIds,
-		constants.TEST_REPORT_STATUS_CREATED, constants.TEST_REPORT_STATUS_SYNCED)
-	if cErr != nil {
-		return errors.New(cErr.Message)
-	}
+	return eventProcessor.Db.Transaction(func(tx *gorm.DB) error {
+		cErr := eventProcessor.OutboxService.WriteMessageWithTx(ctx, tx, messageBody, messageAttributes,
+			fmt.Sprint(reportReadyEvent.ReportPdfEvent.OrderID), constants.ReportReadyTopicArn,
+			reportReadyEvent.ReportPdfEvent.JobUUID)
+		if cErr != nil {
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, errors.New(cErr.Message))
+			return errors.New(cErr.Message)
+		}
 
-	return nil
+		cErr = eventProcessor.TestDetailService.UpdateReportStatusByOmsTestIdsWithTx(tx,
+			reportReadyEvent.ReportPdfEvent.TestIds, constants.TEST_REPORT_STATUS_QUEUED,
+			constants.TEST_REPORT_STATUS_SYNCED)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+		return nil
+	})
 }
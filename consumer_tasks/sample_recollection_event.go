@@ -101,6 +101,14 @@ func (eventProcessor *EventProcessor) SampleRecollectionEventTask(ctx context.Co
 		}
 	}
 
+	omsOrderIdToTestIdToTestStatusMap := map[string]map[string]string{}
+	for _, testDetail := range collectLaterTestDetails {
+		if _, ok := omsOrderIdToTestIdToTestStatusMap[testDetail.OmsOrderId]; !ok {
+			omsOrderIdToTestIdToTestStatusMap[testDetail.OmsOrderId] = map[string]string{}
+		}
+		omsOrderIdToTestIdToTestStatusMap[testDetail.OmsOrderId][testDetail.CentralOmsTestId] = constants.TEST_STATUS_REQUESTED
+	}
+
 	txErr := eventProcessor.Db.Transaction(func(tx *gorm.DB) error {
 		for omsOrderId, orderDetails := range omsOrderIdToOrderDetailsMap {
 			cErr := eventProcessor.SampleService.CreateSamplesForRecollectionWithTx(ctx, tx, orderDetails,
@@ -141,6 +149,14 @@ func (eventProcessor *EventProcessor) SampleRecollectionEventTask(ctx context.Co
 				return errors.New(cErr.Message)
 			}
 		}
+
+		for omsOrderId, testIdToTestStatus := range omsOrderIdToTestIdToTestStatusMap {
+			cErr := eventProcessor.SampleService.WriteUpdateTestStatusEventWithTx(ctx, tx, testIdToTestStatus,
+				omsOrderId, true, orderDetails[0].CityCode)
+			if cErr != nil {
+				return errors.New(cErr.Message)
+			}
+		}
 		return nil
 	})
 	if txErr != nil {
@@ -153,21 +169,8 @@ func (eventProcessor *EventProcessor) SampleRecollectionEventTask(ctx context.Co
 		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, errors.New(cErr.Message))
 	}
 
-	omsOrderIdToTestIdToTestStatusMap := map[string]map[string]string{}
-	for _, testDetail := range collectLaterTestDetails {
-		if _, ok := omsOrderIdToTestIdToTestStatusMap[testDetail.OmsOrderId]; !ok {
-			omsOrderIdToTestIdToTestStatusMap[testDetail.OmsOrderId] = map[string]string{}
-		}
-		omsOrderIdToTestIdToTestStatusMap[testDetail.OmsOrderId][testDetail.CentralOmsTestId] = constants.TEST_STATUS_REQUESTED
-	}
-
-	for omsOrderId, testIdToTestStatus := range omsOrderIdToTestIdToTestStatusMap {
-		go eventProcessor.SampleService.PublishUpdateTestStatusEvent(testIdToTestStatus, omsOrderId, true,
-			orderDetails[0].CityCode)
-	}
-
-	go eventProcessor.SampleService.PublishRemoveSampleRejectedTagEvent(sampleRecollectionPayload.RequestId, omsOrderIds,
-		!recollectionPendingPresent, orderDetails[0].CityCode)
+	eventProcessor.SampleService.WriteRemoveSampleRejectedTagEvent(ctx, sampleRecollectionPayload.RequestId,
+		omsOrderIds, !recollectionPendingPresent, orderDetails[0].CityCode)
 
 	return nil
 }
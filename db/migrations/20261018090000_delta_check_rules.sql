@@ -0,0 +1,47 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "delta_check_rules" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "master_investigation_id" BIGINT NOT NULL,
+        "rule_type" VARCHAR (20) NOT NULL,
+        "positive_limit" DOUBLE PRECISION NOT NULL DEFAULT 0,
+        "negative_limit" DOUBLE PRECISION NOT NULL DEFAULT 0,
+        "threshold_days" INT NOT NULL DEFAULT 0,
+        "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_delta_check_rules_master_investigation_id_rule_type"
+    ON "delta_check_rules" ("master_investigation_id", "rule_type") WHERE "deleted_at" IS NULL;
+
+ALTER TABLE "investigation_results_metadata"
+    ADD COLUMN "delta_check_status" VARCHAR (20) DEFAULT NULL,
+    ADD COLUMN "delta_previous_value" VARCHAR (100) DEFAULT NULL,
+    ADD COLUMN "delta_previous_approved_at" TIMESTAMPTZ DEFAULT NULL,
+    ADD COLUMN "delta_absolute" DOUBLE PRECISION DEFAULT NULL,
+    ADD COLUMN "delta_percentage" DOUBLE PRECISION DEFAULT NULL,
+    ADD COLUMN "delta_rate_per_day" DOUBLE PRECISION DEFAULT NULL,
+    ADD COLUMN "delta_violated_rule" VARCHAR (20) DEFAULT NULL,
+    ADD COLUMN "delta_check_remarks" VARCHAR (255) DEFAULT NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+ALTER TABLE "investigation_results_metadata"
+    DROP COLUMN IF EXISTS "delta_check_status",
+    DROP COLUMN IF EXISTS "delta_previous_value",
+    DROP COLUMN IF EXISTS "delta_previous_approved_at",
+    DROP COLUMN IF EXISTS "delta_absolute",
+    DROP COLUMN IF EXISTS "delta_percentage",
+    DROP COLUMN IF EXISTS "delta_rate_per_day",
+    DROP COLUMN IF EXISTS "delta_violated_rule",
+    DROP COLUMN IF EXISTS "delta_check_remarks";
+
+DROP TABLE IF EXISTS "delta_check_rules";
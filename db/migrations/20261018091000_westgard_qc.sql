@@ -0,0 +1,68 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "qc_targets" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "lab_id" BIGINT NOT NULL,
+        "lot_number" VARCHAR (100) NOT NULL,
+        "lis_code" VARCHAR (100) NOT NULL,
+        "mean" DOUBLE PRECISION NOT NULL,
+        "sd" DOUBLE PRECISION NOT NULL,
+        "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_qc_targets_lab_id_lot_number_lis_code"
+    ON "qc_targets" ("lab_id", "lot_number", "lis_code") WHERE "deleted_at" IS NULL;
+
+CREATE TABLE
+    IF NOT EXISTS "qc_results" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "lab_id" BIGINT NOT NULL,
+        "lot_number" VARCHAR (100) NOT NULL,
+        "lis_code" VARCHAR (100) NOT NULL,
+        "qc_value" DOUBLE PRECISION NOT NULL,
+        "run_at" TIMESTAMPTZ NOT NULL,
+        "z_score" DOUBLE PRECISION DEFAULT NULL,
+        "westgard_violations" VARCHAR (100) NOT NULL DEFAULT '',
+        "qc_status" VARCHAR (20) NOT NULL DEFAULT '',
+        "source" VARCHAR (20) NOT NULL DEFAULT '',
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_qc_results_lab_id_lot_number_lis_code_run_at"
+    ON "qc_results" ("lab_id", "lot_number", "lis_code", "run_at" DESC);
+
+ALTER TABLE "investigation_results_metadata"
+    ALTER COLUMN "qc_lot_number" SET DEFAULT '',
+    ALTER COLUMN "qc_value" SET DEFAULT '',
+    ALTER COLUMN "qc_west_gard_warning" SET DEFAULT '',
+    ALTER COLUMN "qc_status" SET DEFAULT '',
+    ADD COLUMN "westgard_status" VARCHAR (20) DEFAULT NULL,
+    ADD COLUMN "westgard_violations" VARCHAR (100) DEFAULT NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+ALTER TABLE "investigation_results_metadata"
+    ALTER COLUMN "qc_lot_number" DROP DEFAULT,
+    ALTER COLUMN "qc_value" DROP DEFAULT,
+    ALTER COLUMN "qc_west_gard_warning" DROP DEFAULT,
+    ALTER COLUMN "qc_status" DROP DEFAULT,
+    DROP COLUMN IF EXISTS "westgard_status",
+    DROP COLUMN IF EXISTS "westgard_violations";
+
+DROP TABLE IF EXISTS "qc_results";
+
+DROP TABLE IF EXISTS "qc_targets";
@@ -0,0 +1,34 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "auto_verification_rules" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "name" VARCHAR (100) NOT NULL,
+        "rule_type" VARCHAR (30) NOT NULL,
+        "lab_id" BIGINT NOT NULL DEFAULT 0,
+        "master_test_id" BIGINT NOT NULL DEFAULT 0,
+        "master_investigation_id" BIGINT NOT NULL DEFAULT 0,
+        "parameters" TEXT NOT NULL DEFAULT '',
+        "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_auto_verification_rules_master_investigation_id"
+    ON "auto_verification_rules" ("master_investigation_id") WHERE "deleted_at" IS NULL;
+
+ALTER TABLE "investigation_results_metadata"
+    ADD COLUMN "auto_verification_evaluations" TEXT DEFAULT NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+ALTER TABLE "investigation_results_metadata"
+    DROP COLUMN IF EXISTS "auto_verification_evaluations";
+
+DROP TABLE IF EXISTS "auto_verification_rules";
@@ -0,0 +1,13 @@
+-- migrate:up
+-- write statements below this line
+
+ALTER TABLE "patient_details"
+    ADD COLUMN "is_pregnant" BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN "trimester" SMALLINT NOT NULL DEFAULT 0;
+
+-- migrate:down
+-- write rollback statements below this line
+
+ALTER TABLE "patient_details"
+    DROP COLUMN IF EXISTS "trimester",
+    DROP COLUMN IF EXISTS "is_pregnant";
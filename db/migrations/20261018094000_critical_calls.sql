@@ -0,0 +1,44 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "critical_calls" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "task_id" BIGINT NOT NULL,
+        "test_details_id" BIGINT NOT NULL,
+        "investigation_result_id" BIGINT NOT NULL,
+        "master_investigation_id" BIGINT NOT NULL,
+        "investigation_name" VARCHAR (100) NOT NULL DEFAULT '',
+        "investigation_value" VARCHAR (100) NOT NULL DEFAULT '',
+        "uom" VARCHAR (100) NOT NULL DEFAULT '',
+        "status" VARCHAR (20) NOT NULL,
+        "called_by" BIGINT DEFAULT NULL,
+        "called_to_type" VARCHAR (20) NOT NULL DEFAULT '',
+        "called_to_name" VARCHAR (100) NOT NULL DEFAULT '',
+        "called_to_number" VARCHAR (20) NOT NULL DEFAULT '',
+        "read_back_value" VARCHAR (100) NOT NULL DEFAULT '',
+        "read_back_confirmed" BOOLEAN NOT NULL DEFAULT FALSE,
+        "remarks" VARCHAR (500) NOT NULL DEFAULT '',
+        "acknowledged_at" TIMESTAMPTZ DEFAULT NULL,
+        "escalation_count" INTEGER NOT NULL DEFAULT 0,
+        "last_escalated_at" TIMESTAMPTZ DEFAULT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_critical_calls_investigation_result_id"
+    ON "critical_calls" ("investigation_result_id") WHERE "deleted_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_critical_calls_task_id" ON "critical_calls" ("task_id");
+
+CREATE INDEX IF NOT EXISTS "idx_critical_calls_status" ON "critical_calls" ("status")
+    WHERE "status" <> 'acknowledged';
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "critical_calls";
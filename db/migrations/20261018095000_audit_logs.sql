@@ -0,0 +1,109 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    "log_id" BIGSERIAL NOT NULL PRIMARY KEY,
+    "log_action" VARCHAR(3) NOT NULL,
+    "log_timestamp" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "entity_type" VARCHAR(50) NOT NULL,
+    "entity_id" BIGINT NOT NULL,
+    "oms_order_id" VARCHAR(30) DEFAULT NULL,
+    "user_id" BIGINT DEFAULT NULL,
+    "row_data" JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "audit_logs_oms_order_id_idx" ON "audit_logs" ("oms_order_id");
+CREATE INDEX IF NOT EXISTS "audit_logs_entity_type_entity_id_idx" ON "audit_logs" ("entity_type", "entity_id");
+
+-- audit_logs_function stores a snapshot of the changed row along with the order it belongs to. Rows are stored
+-- as JSONB so that columns added to the audited tables later are captured without changing the trigger.
+CREATE OR REPLACE FUNCTION audit_logs_function()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_row_data JSONB;
+    v_log_action VARCHAR(3);
+    v_log_timestamp TIMESTAMPTZ;
+    v_user_id BIGINT;
+    v_oms_order_id VARCHAR(30);
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        v_row_data := to_jsonb(OLD);
+        v_log_action := 'DEL';
+        v_log_timestamp := (v_row_data->>'deleted_at')::TIMESTAMPTZ;
+        v_user_id := COALESCE((v_row_data->>'deleted_by')::BIGINT, (v_row_data->>'updated_by')::BIGINT);
+    ELSE
+        v_row_data := to_jsonb(NEW);
+        v_log_action := CASE WHEN TG_OP = 'INSERT' THEN 'INS' ELSE 'UPD' END;
+        v_log_timestamp := (v_row_data->>'updated_at')::TIMESTAMPTZ;
+        v_user_id := (v_row_data->>'updated_by')::BIGINT;
+    END IF;
+
+    IF TG_TABLE_NAME = 'tasks' THEN
+        v_oms_order_id := v_row_data->>'oms_order_id';
+    ELSIF TG_TABLE_NAME = 'test_details' THEN
+        SELECT COALESCE(v_row_data->>'oms_order_id', t.oms_order_id) INTO v_oms_order_id
+        FROM tasks t WHERE t.id = (v_row_data->>'task_id')::BIGINT;
+    ELSIF TG_TABLE_NAME = 'investigation_results' THEN
+        SELECT COALESCE(td.oms_order_id, v_row_data->>'oms_order_id') INTO v_oms_order_id
+        FROM test_details td WHERE td.id = (v_row_data->>'test_details_id')::BIGINT;
+    ELSIF TG_TABLE_NAME = 'remarks' THEN
+        SELECT td.oms_order_id INTO v_oms_order_id
+        FROM investigation_results ir INNER JOIN test_details td ON td.id = ir.test_details_id
+        WHERE ir.id = (v_row_data->>'investigation_result_id')::BIGINT;
+    ELSIF TG_TABLE_NAME = 'co_authorized_pathologists' THEN
+        SELECT t.oms_order_id INTO v_oms_order_id
+        FROM tasks t WHERE t.id = (v_row_data->>'task_id')::BIGINT;
+    END IF;
+
+    INSERT INTO audit_logs(
+        log_action, log_timestamp, entity_type, entity_id, oms_order_id, user_id, row_data
+    )
+    VALUES (
+        v_log_action, COALESCE(v_log_timestamp, CURRENT_TIMESTAMP), TG_TABLE_NAME,
+        (v_row_data->>'id')::BIGINT, v_oms_order_id, v_user_id, v_row_data
+    );
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "tasks_audit_logs_trigger"
+AFTER INSERT OR UPDATE OR DELETE ON "tasks"
+FOR EACH ROW
+EXECUTE FUNCTION audit_logs_function();
+
+CREATE TRIGGER "test_details_audit_logs_trigger"
+AFTER INSERT OR UPDATE OR DELETE ON "test_details"
+FOR EACH ROW
+EXECUTE FUNCTION audit_logs_function();
+
+CREATE TRIGGER "investigation_results_audit_logs_trigger"
+AFTER INSERT OR UPDATE OR DELETE ON "investigation_results"
+FOR EACH ROW
+EXECUTE FUNCTION audit_logs_function();
+
+CREATE TRIGGER "remarks_audit_logs_trigger"
+AFTER INSERT OR UPDATE OR DELETE ON "remarks"
+FOR EACH ROW
+EXECUTE FUNCTION audit_logs_function();
+
+CREATE TRIGGER "co_authorized_pathologists_audit_logs_trigger"
+AFTER INSERT OR UPDATE OR DELETE ON "co_authorized_pathologists"
+FOR EACH ROW
+EXECUTE FUNCTION audit_logs_function();
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TRIGGER IF EXISTS "co_authorized_pathologists_audit_logs_trigger" ON "co_authorized_pathologists";
+DROP TRIGGER IF EXISTS "remarks_audit_logs_trigger" ON "remarks";
+DROP TRIGGER IF EXISTS "investigation_results_audit_logs_trigger" ON "investigation_results";
+DROP TRIGGER IF EXISTS "test_details_audit_logs_trigger" ON "test_details";
+DROP TRIGGER IF EXISTS "tasks_audit_logs_trigger" ON "tasks";
+
+DROP FUNCTION IF EXISTS audit_logs_function();
+
+DROP TABLE IF EXISTS "audit_logs";
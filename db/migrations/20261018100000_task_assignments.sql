@@ -0,0 +1,52 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "pathologist_profiles" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "pathologist_id" BIGINT NOT NULL,
+        "departments" VARCHAR (1000) NOT NULL DEFAULT '',
+        "max_open_tasks" INTEGER NOT NULL,
+        "is_on_shift" BOOLEAN NOT NULL DEFAULT FALSE,
+        "is_lead" BOOLEAN NOT NULL DEFAULT FALSE,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_pathologist_profiles_pathologist_id"
+    ON "pathologist_profiles" ("pathologist_id") WHERE "deleted_at" IS NULL;
+
+CREATE TABLE
+    IF NOT EXISTS "task_assignments" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "task_id" BIGINT NOT NULL,
+        "pathologist_id" BIGINT NOT NULL,
+        "assignment_type" VARCHAR (20) NOT NULL,
+        "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+        "score" DOUBLE PRECISION DEFAULT NULL,
+        "explanation" JSONB DEFAULT NULL,
+        "reason" VARCHAR (255) NOT NULL DEFAULT '',
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_task_assignments_task_id_active"
+    ON "task_assignments" ("task_id") WHERE "is_active" AND "deleted_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_task_assignments_pathologist_id_active"
+    ON "task_assignments" ("pathologist_id") WHERE "is_active" AND "deleted_at" IS NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "task_assignments";
+
+DROP TABLE IF EXISTS "pathologist_profiles";
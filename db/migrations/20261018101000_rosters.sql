@@ -0,0 +1,52 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "roster_shifts" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "city_code" VARCHAR (10) NOT NULL,
+        "lab_id" BIGINT NOT NULL DEFAULT 0,
+        "weekday" VARCHAR (10) NOT NULL,
+        "start_time" VARCHAR (5) NOT NULL,
+        "end_time" VARCHAR (5) NOT NULL,
+        "user_id" BIGINT NOT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_roster_shifts_city_code_weekday"
+    ON "roster_shifts" ("city_code", "weekday") WHERE "deleted_at" IS NULL;
+
+CREATE TABLE
+    IF NOT EXISTS "roster_overrides" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "city_code" VARCHAR (10) NOT NULL,
+        "lab_id" BIGINT NOT NULL DEFAULT 0,
+        "override_date" DATE NOT NULL,
+        "start_time" VARCHAR (5) NOT NULL,
+        "end_time" VARCHAR (5) NOT NULL,
+        "user_id" BIGINT NOT NULL,
+        "replaced_user_id" BIGINT DEFAULT NULL,
+        "override_type" VARCHAR (20) NOT NULL,
+        "reason" VARCHAR (255) NOT NULL DEFAULT '',
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_roster_overrides_city_code_override_date"
+    ON "roster_overrides" ("city_code", "override_date") WHERE "deleted_at" IS NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "roster_overrides";
+
+DROP TABLE IF EXISTS "roster_shifts";
@@ -0,0 +1,35 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "outbox_messages" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "topic" VARCHAR (255) NOT NULL,
+        "message_group_id" VARCHAR (128) NOT NULL DEFAULT '',
+        "message_deduplication_id" VARCHAR (128) NOT NULL DEFAULT '',
+        "message_body" JSONB NOT NULL,
+        "message_attributes" JSONB NOT NULL,
+        "event_type" VARCHAR (100) NOT NULL DEFAULT '',
+        "status" VARCHAR (20) NOT NULL,
+        "attempts" INTEGER NOT NULL DEFAULT 0,
+        "next_attempt_at" TIMESTAMPTZ NOT NULL,
+        "last_error" TEXT NOT NULL DEFAULT '',
+        "published_at" TIMESTAMPTZ DEFAULT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_status_next_attempt_at"
+    ON "outbox_messages" ("status", "next_attempt_at") WHERE "status" <> 'published';
+
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_message_group_id"
+    ON "outbox_messages" ("message_group_id", "id") WHERE "status" <> 'published';
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "outbox_messages";
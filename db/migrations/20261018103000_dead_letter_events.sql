@@ -0,0 +1,34 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "dead_letter_events" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "event_type" VARCHAR (100) NOT NULL,
+        "trace_id" VARCHAR (128) NOT NULL DEFAULT '',
+        "contains" VARCHAR (100) NOT NULL DEFAULT '',
+        "event_key" VARCHAR (255) NOT NULL DEFAULT '',
+        "event_payload" TEXT NOT NULL DEFAULT '',
+        "error" TEXT NOT NULL DEFAULT '',
+        "attempts" INTEGER NOT NULL DEFAULT 0,
+        "status" VARCHAR (20) NOT NULL,
+        "replayed_at" TIMESTAMPTZ DEFAULT NULL,
+        "replayed_by" BIGINT DEFAULT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_dead_letter_events_status_event_type"
+    ON "dead_letter_events" ("status", "event_type");
+
+CREATE INDEX IF NOT EXISTS "idx_dead_letter_events_trace_id"
+    ON "dead_letter_events" ("trace_id");
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "dead_letter_events";
@@ -0,0 +1,30 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "processed_events" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "event_type" VARCHAR (100) NOT NULL,
+        "business_id" VARCHAR (255) NOT NULL,
+        "payload_hash" VARCHAR (64) NOT NULL,
+        "event_sent_at" TIMESTAMPTZ DEFAULT NULL,
+        "decision" VARCHAR (20) NOT NULL,
+        "processed_event_id" BIGINT DEFAULT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_processed_events_business_id_event_type"
+    ON "processed_events" ("business_id", "event_type", "id") WHERE "decision" = 'processed';
+
+CREATE INDEX IF NOT EXISTS "idx_processed_events_business_id_created_at"
+    ON "processed_events" ("business_id", "created_at");
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "processed_events";
@@ -0,0 +1,96 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "culture_results" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "investigation_result_id" BIGINT NOT NULL,
+        "report_status" VARCHAR (100) NOT NULL DEFAULT '',
+        "sample_type" VARCHAR (255) NOT NULL DEFAULT '',
+        "incubation_period" VARCHAR (100) NOT NULL DEFAULT '',
+        "stain_result" TEXT NOT NULL DEFAULT '',
+        "stain_details" JSONB DEFAULT NULL,
+        "culture_report" TEXT NOT NULL DEFAULT '',
+        "colony_count" VARCHAR (255) NOT NULL DEFAULT '',
+        "resistance_detected" TEXT NOT NULL DEFAULT '',
+        "clinical_history" TEXT NOT NULL DEFAULT '',
+        "gross" TEXT NOT NULL DEFAULT '',
+        "breakpoint_standard" VARCHAR (20) NOT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_culture_results_investigation_result_id"
+    ON "culture_results" ("investigation_result_id") WHERE "deleted_at" IS NULL;
+
+CREATE TABLE
+    IF NOT EXISTS "culture_organisms" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "culture_result_id" BIGINT NOT NULL REFERENCES "culture_results" ("id"),
+        "name" VARCHAR (255) NOT NULL,
+        "family" VARCHAR (255) NOT NULL DEFAULT '',
+        "colony_count" VARCHAR (255) NOT NULL DEFAULT '',
+        "display_order" INTEGER NOT NULL DEFAULT 0,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_culture_organisms_culture_result_id"
+    ON "culture_organisms" ("culture_result_id");
+
+CREATE TABLE
+    IF NOT EXISTS "culture_susceptibilities" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "culture_organism_id" BIGINT NOT NULL REFERENCES "culture_organisms" ("id"),
+        "antimicrobial" VARCHAR (255) NOT NULL,
+        "mic_value" VARCHAR (50) NOT NULL DEFAULT '',
+        "interpretation" VARCHAR (5) NOT NULL DEFAULT '',
+        "breakpoint_standard" VARCHAR (20) NOT NULL,
+        "display_order" INTEGER NOT NULL DEFAULT 0,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_culture_susceptibilities_culture_organism_id"
+    ON "culture_susceptibilities" ("culture_organism_id");
+
+CREATE TABLE
+    IF NOT EXISTS "antimicrobial_breakpoints" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "standard" VARCHAR (20) NOT NULL,
+        "version" VARCHAR (50) NOT NULL DEFAULT '',
+        "organism" VARCHAR (255) NOT NULL,
+        "antimicrobial" VARCHAR (255) NOT NULL,
+        "susceptible_max_mic" DOUBLE PRECISION NOT NULL,
+        "resistant_min_mic" DOUBLE PRECISION NOT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_antimicrobial_breakpoints_standard_organism_antimicrobial"
+    ON "antimicrobial_breakpoints" ("standard", LOWER("organism"), LOWER("antimicrobial"))
+    WHERE "deleted_at" IS NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "antimicrobial_breakpoints";
+DROP TABLE IF EXISTS "culture_susceptibilities";
+DROP TABLE IF EXISTS "culture_organisms";
+DROP TABLE IF EXISTS "culture_results";
@@ -0,0 +1,70 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "reflex_rules" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "name" VARCHAR (255) NOT NULL,
+        "lab_id" BIGINT NOT NULL DEFAULT 0,
+        "trigger_lis_code" VARCHAR (100) NOT NULL,
+        "operator" VARCHAR (20) NOT NULL,
+        "value" VARCHAR (100) NOT NULL DEFAULT '',
+        "reflex_master_test_id" BIGINT NOT NULL,
+        "reflex_lis_code" VARCHAR (20) NOT NULL,
+        "reflex_test_name" VARCHAR (255) NOT NULL,
+        "reflex_test_type" VARCHAR (10) NOT NULL,
+        "reflex_department" VARCHAR (100) DEFAULT NULL,
+        "vial_type_id" BIGINT NOT NULL DEFAULT 0,
+        "required_volume" INTEGER NOT NULL DEFAULT 0,
+        "stability_hours" INTEGER NOT NULL DEFAULT 0,
+        "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_reflex_rules_trigger_lis_code"
+    ON "reflex_rules" ("trigger_lis_code") WHERE "deleted_at" IS NULL;
+
+CREATE TABLE
+    IF NOT EXISTS "reflex_test_triggers" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "reflex_rule_id" BIGINT NOT NULL REFERENCES "reflex_rules" ("id"),
+        "task_id" BIGINT NOT NULL,
+        "oms_order_id" VARCHAR (50) NOT NULL,
+        "trigger_test_details_id" BIGINT NOT NULL,
+        "trigger_investigation_result_id" BIGINT NOT NULL,
+        "trigger_value" VARCHAR (100) NOT NULL DEFAULT '',
+        "trigger_abnormality" VARCHAR (10) DEFAULT NULL,
+        "sample_id" BIGINT DEFAULT NULL,
+        "reflex_test_details_id" BIGINT DEFAULT NULL,
+        "reflex_central_oms_test_id" VARCHAR (50) DEFAULT NULL,
+        "required_volume" INTEGER NOT NULL DEFAULT 0,
+        "status" VARCHAR (20) NOT NULL,
+        "reason" TEXT NOT NULL DEFAULT '',
+        "triggered_at" TIMESTAMPTZ NOT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_reflex_test_triggers_rule_investigation"
+    ON "reflex_test_triggers" ("reflex_rule_id", "trigger_investigation_result_id") WHERE "deleted_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_reflex_test_triggers_task_id"
+    ON "reflex_test_triggers" ("task_id") WHERE "deleted_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_reflex_test_triggers_sample_id"
+    ON "reflex_test_triggers" ("sample_id") WHERE "deleted_at" IS NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "reflex_test_triggers";
+DROP TABLE IF EXISTS "reflex_rules";
@@ -0,0 +1,38 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "sample_stability_rules" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "lab_id" BIGINT NOT NULL DEFAULT 0,
+        "vial_type_id" BIGINT NOT NULL DEFAULT 0,
+        "master_test_id" BIGINT NOT NULL DEFAULT 0,
+        "storage_condition" VARCHAR (20) NOT NULL,
+        "stability_hours" INTEGER NOT NULL,
+        "near_expiry_hours" INTEGER NOT NULL DEFAULT 0,
+        "action" VARCHAR (20) NOT NULL,
+        "is_active" BOOLEAN NOT NULL DEFAULT TRUE,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_sample_stability_rules_scope"
+    ON "sample_stability_rules" ("lab_id", "vial_type_id", "master_test_id", "storage_condition")
+    WHERE "deleted_at" IS NULL;
+
+ALTER TABLE sample_metadata ADD COLUMN storage_condition VARCHAR(20) DEFAULT NULL;
+ALTER TABLE sample_metadata ADD COLUMN stability_flagged_at TIMESTAMPTZ DEFAULT NULL;
+ALTER TABLE sample_metadata ADD COLUMN stability_flag_reason TEXT DEFAULT NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+ALTER TABLE sample_metadata DROP COLUMN stability_flag_reason;
+ALTER TABLE sample_metadata DROP COLUMN stability_flagged_at;
+ALTER TABLE sample_metadata DROP COLUMN storage_condition;
+
+DROP TABLE IF EXISTS "sample_stability_rules";
@@ -0,0 +1,31 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "sample_aliquots" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "oms_order_id" VARCHAR(30) NOT NULL,
+        "parent_sample_id" BIGINT NOT NULL,
+        "aliquot_sample_id" BIGINT NOT NULL,
+        "volume" INTEGER NOT NULL DEFAULT 0,
+        "department" VARCHAR(100) DEFAULT NULL,
+        "destination_lab_id" BIGINT NOT NULL DEFAULT 0,
+        "aliquoted_at" TIMESTAMPTZ NOT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS "idx_sample_aliquots_oms_order_id" ON "sample_aliquots" ("oms_order_id");
+CREATE INDEX IF NOT EXISTS "idx_sample_aliquots_parent_sample_id" ON "sample_aliquots" ("parent_sample_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_sample_aliquots_aliquot_sample_id"
+    ON "sample_aliquots" ("aliquot_sample_id")
+    WHERE "deleted_at" IS NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "sample_aliquots";
@@ -0,0 +1,52 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "task_list_views" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "user_id" BIGINT NOT NULL,
+        "name" VARCHAR(100) NOT NULL,
+        "filters" JSONB NOT NULL DEFAULT '{}',
+        "sort_by" VARCHAR(20) NOT NULL,
+        "sort_order" VARCHAR(4) NOT NULL,
+        "is_default" BOOLEAN NOT NULL DEFAULT FALSE,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_task_list_views_user_id_name"
+    ON "task_list_views" ("user_id", lower("name"))
+    WHERE "deleted_at" IS NULL;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS "idx_patient_details_name_trgm"
+    ON "patient_details" USING GIN (lower("name") gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "idx_patient_details_number_trgm"
+    ON "patient_details" USING GIN ("number" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "idx_test_details_test_name_trgm"
+    ON "test_details" USING GIN (lower("test_name") gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "idx_task_metadata_partner_name_trgm"
+    ON "task_metadata" USING GIN (lower("partner_name") gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "idx_task_metadata_doctor_name_trgm"
+    ON "task_metadata" USING GIN (lower("doctor_name") gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "idx_tasks_doctor_tat_id" ON "tasks" ("doctor_tat", "id");
+CREATE INDEX IF NOT EXISTS "idx_tasks_created_at_id" ON "tasks" ("created_at", "id");
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP INDEX IF EXISTS "idx_tasks_created_at_id";
+DROP INDEX IF EXISTS "idx_tasks_doctor_tat_id";
+DROP INDEX IF EXISTS "idx_task_metadata_doctor_name_trgm";
+DROP INDEX IF EXISTS "idx_task_metadata_partner_name_trgm";
+DROP INDEX IF EXISTS "idx_test_details_test_name_trgm";
+DROP INDEX IF EXISTS "idx_patient_details_number_trgm";
+DROP INDEX IF EXISTS "idx_patient_details_name_trgm";
+
+DROP TABLE IF EXISTS "task_list_views";
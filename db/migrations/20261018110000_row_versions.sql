@@ -0,0 +1,44 @@
+-- migrate:up
+-- write statements below this line
+
+ALTER TABLE "tasks" ADD COLUMN IF NOT EXISTS "row_version" BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE "test_details" ADD COLUMN IF NOT EXISTS "row_version" BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE "investigation_results" ADD COLUMN IF NOT EXISTS "row_version" BIGINT NOT NULL DEFAULT 1;
+
+-- row_version_function bumps the row version on every update so that edits made through any path, including
+-- bulk updates that do not go through the models, invalidate the version held by the clients.
+CREATE OR REPLACE FUNCTION row_version_function()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.row_version := OLD.row_version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "tasks_row_version_trigger"
+BEFORE UPDATE ON "tasks"
+FOR EACH ROW
+EXECUTE FUNCTION row_version_function();
+
+CREATE TRIGGER "test_details_row_version_trigger"
+BEFORE UPDATE ON "test_details"
+FOR EACH ROW
+EXECUTE FUNCTION row_version_function();
+
+CREATE TRIGGER "investigation_results_row_version_trigger"
+BEFORE UPDATE ON "investigation_results"
+FOR EACH ROW
+EXECUTE FUNCTION row_version_function();
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TRIGGER IF EXISTS "investigation_results_row_version_trigger" ON "investigation_results";
+DROP TRIGGER IF EXISTS "test_details_row_version_trigger" ON "test_details";
+DROP TRIGGER IF EXISTS "tasks_row_version_trigger" ON "tasks";
+
+DROP FUNCTION IF EXISTS row_version_function();
+
+ALTER TABLE "investigation_results" DROP COLUMN IF EXISTS "row_version";
+ALTER TABLE "test_details" DROP COLUMN IF EXISTS "row_version";
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS "row_version";
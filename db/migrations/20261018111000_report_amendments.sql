@@ -0,0 +1,39 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "report_amendments" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "task_id" BIGINT NOT NULL,
+        "oms_order_id" VARCHAR(50) NOT NULL,
+        "status" VARCHAR(20) NOT NULL,
+        "reason_code" VARCHAR(50) NOT NULL,
+        "justification" TEXT NOT NULL,
+        "report_version" INTEGER NOT NULL DEFAULT 0,
+        "requested_by" BIGINT NOT NULL,
+        "approved_by" BIGINT DEFAULT NULL,
+        "approved_at" TIMESTAMPTZ DEFAULT NULL,
+        "previous_values" JSONB NOT NULL DEFAULT '[]',
+        "investigation_diff" JSONB NOT NULL DEFAULT '[]',
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_report_amendments_open_task_id"
+    ON "report_amendments" ("task_id")
+    WHERE "status" = 'open' AND "deleted_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_report_amendments_task_id"
+    ON "report_amendments" ("task_id") WHERE "deleted_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_report_amendments_oms_order_id"
+    ON "report_amendments" ("oms_order_id") WHERE "deleted_at" IS NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "report_amendments";
@@ -0,0 +1,9 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE INDEX IF NOT EXISTS test_details_created_at_idx ON test_details(created_at);
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP INDEX IF EXISTS test_details_created_at_idx;
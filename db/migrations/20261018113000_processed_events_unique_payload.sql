@@ -0,0 +1,11 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_processed_events_event_type_business_id_payload_hash_sent_at"
+    ON "processed_events" ("event_type", "business_id", "payload_hash", "event_sent_at")
+    WHERE "decision" = 'processed';
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP INDEX IF EXISTS "idx_processed_events_event_type_business_id_payload_hash_sent_at";
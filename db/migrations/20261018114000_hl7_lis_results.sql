@@ -0,0 +1,23 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE TABLE
+    IF NOT EXISTS "hl7_lis_results" (
+        "id" BIGSERIAL NOT NULL PRIMARY KEY,
+        "visit_id" VARCHAR (100) NOT NULL,
+        "lis_order" JSONB NOT NULL,
+        "control_id" VARCHAR (100) DEFAULT NULL,
+        "created_by" BIGINT NOT NULL,
+        "updated_by" BIGINT NOT NULL,
+        "deleted_by" BIGINT DEFAULT NULL,
+        "created_at" TIMESTAMPTZ NOT NULL,
+        "updated_at" TIMESTAMPTZ NOT NULL,
+        "deleted_at" TIMESTAMPTZ DEFAULT NULL
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_hl7_lis_results_visit_id" ON "hl7_lis_results" ("visit_id");
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP TABLE IF EXISTS "hl7_lis_results";
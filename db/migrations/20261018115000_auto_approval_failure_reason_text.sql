@@ -0,0 +1,12 @@
+-- migrate:up
+-- write statements below this line
+
+ALTER TABLE "investigation_results"
+    ALTER COLUMN "auto_approval_failure_reason" TYPE TEXT;
+
+-- migrate:down
+-- write rollback statements below this line
+
+ALTER TABLE "investigation_results"
+    ALTER COLUMN "auto_approval_failure_reason" TYPE VARCHAR (50)
+    USING LEFT("auto_approval_failure_reason", 50);
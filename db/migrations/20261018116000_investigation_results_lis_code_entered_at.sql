@@ -0,0 +1,10 @@
+-- migrate:up
+-- write statements below this line
+
+CREATE INDEX IF NOT EXISTS "idx_investigation_results_lis_code_entered_at"
+    ON "investigation_results" ("lis_code", "entered_at") WHERE "deleted_at" IS NULL;
+
+-- migrate:down
+-- write rollback statements below this line
+
+DROP INDEX IF EXISTS "idx_investigation_results_lis_code_entered_at";
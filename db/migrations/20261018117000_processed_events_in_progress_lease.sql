@@ -0,0 +1,23 @@
+-- migrate:up
+-- write statements below this line
+
+ALTER TABLE "processed_events" ADD COLUMN IF NOT EXISTS "lease_expires_at" TIMESTAMPTZ DEFAULT NULL;
+
+DROP INDEX IF EXISTS "idx_processed_events_event_type_business_id_payload_hash_sent_at";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_processed_events_event_type_business_id_payload_hash_sent_at"
+    ON "processed_events" ("event_type", "business_id", "payload_hash", "event_sent_at")
+    WHERE "decision" IN ('in_progress', 'processed');
+
+-- migrate:down
+-- write rollback statements below this line
+
+DELETE FROM "processed_events" WHERE "decision" = 'in_progress';
+
+DROP INDEX IF EXISTS "idx_processed_events_event_type_business_id_payload_hash_sent_at";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_processed_events_event_type_business_id_payload_hash_sent_at"
+    ON "processed_events" ("event_type", "business_id", "payload_hash", "event_sent_at")
+    WHERE "decision" = 'processed';
+
+ALTER TABLE "processed_events" DROP COLUMN IF EXISTS "lease_expires_at";
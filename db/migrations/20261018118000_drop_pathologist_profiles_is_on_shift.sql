@@ -0,0 +1,9 @@
+-- migrate:up
+-- write statements below this line
+
+ALTER TABLE "pathologist_profiles" DROP COLUMN IF EXISTS "is_on_shift";
+
+-- migrate:down
+-- write rollback statements below this line
+
+ALTER TABLE "pathologist_profiles" ADD COLUMN IF NOT EXISTS "is_on_shift" BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,168 @@
+package hl7
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	zl "github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"github.com/Orange-Health/citadel/adapters/psql"
+	"github.com/Orange-Health/citadel/adapters/sentry"
+	lisDao "github.com/Orange-Health/citadel/apps/lis/dao"
+	hl7Client "github.com/Orange-Health/citadel/clients/hl7"
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+	"github.com/Orange-Health/citadel/common/utils"
+	"github.com/Orange-Health/citadel/worker"
+)
+
+type Hl7Server struct {
+	Context context.Context
+	Db      *gorm.DB
+	Sentry  sentry.SentryLayer
+	LisDao  lisDao.DataLayer
+}
+
+// Start listens for ORU^R01 results over MLLP and hands them to the worker as LIS events.
+func Start() {
+	if gin.IsDebugging() {
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	} else {
+		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	}
+
+	ctx := context.Background()
+	psql.Initialize()
+	sentry.Initialize()
+
+	hl7Server := &Hl7Server{
+		Context: ctx,
+		Db:      psql.GetDbInstance(),
+		Sentry:  sentry.InitializeSentry(),
+		LisDao:  lisDao.InitializeLisDao(),
+	}
+
+	err := hl7Client.ListenAndServe(ctx, constants.Hl7ListenAddress, hl7Server.handler)
+	if err != nil {
+		zl.Error().Err(err).Msg(constants.ERROR_WHILE_READING_HL7_MESSAGE)
+	}
+}
+
+func (hl7Server *Hl7Server) handler(ctx context.Context, message hl7Client.Message) error {
+	lisOrders, err := hl7Client.ConvertOruR01ToLisOrders(message)
+	if err != nil {
+		return err
+	}
+
+	for visitId, lisOrder := range lisOrders {
+		err = hl7Server.ingestLisOrder(ctx, message, visitId, lisOrder)
+		if err != nil {
+			hl7Server.Sentry.LogError(ctx, constants.ERROR_WHILE_INGESTING_HL7_RESULTS, err, map[string]interface{}{
+				"visit_id":   visitId,
+				"control_id": message.ControlId(),
+			})
+			return err
+		}
+	}
+	return nil
+}
+
+// ingestLisOrder merges the results into the ones already stored for the visit for the HL7 LIS adapter and
+// raises a LIS event so the regular LIS flow writes them to investigation results. An error is returned, and
+// the message is not acknowledged, unless the results were stored.
+func (hl7Server *Hl7Server) ingestLisOrder(ctx context.Context, message hl7Client.Message, visitId string,
+	lisOrder structures.LisOrder) error {
+	lisOrder, err := hl7Server.saveLisOrder(message, visitId, lisOrder)
+	if err != nil {
+		return err
+	}
+
+	lisOrderInfo, err := json.Marshal(lisOrder)
+	if err != nil {
+		return err
+	}
+
+	lisEvent, err := json.Marshal(structures.LisEvent{
+		ContentType: constants.ContentTypeJson,
+		EntityID:    visitId,
+		EntityName:  constants.LIS_VENDOR_HL7,
+		WebhookData: base64.StdEncoding.EncodeToString(lisOrderInfo),
+	})
+	if err != nil {
+		return err
+	}
+
+	utils.AddLog(ctx, constants.INFO_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
+		"visit_id":   visitId,
+		"control_id": message.ControlId(),
+		"tests":      len(lisOrder.Tests),
+	}, nil)
+	return worker.SendEventHandlerToWorker(ctx, structures.EventPayload{
+		EventType:    constants.LisEvent,
+		EventPayload: string(lisEvent),
+		TraceID:      message.ControlId(),
+		Contains:     message.MessageType(),
+		SentAt:       fmt.Sprint(time.Now().UnixMilli()),
+	})
+}
+
+// saveLisOrder merges the results into the stored ones while holding the row of the visit locked.
+func (hl7Server *Hl7Server) saveLisOrder(message hl7Client.Message, visitId string,
+	lisOrder structures.LisOrder) (structures.LisOrder, error) {
+	err := hl7Server.Db.Transaction(func(tx *gorm.DB) error {
+		hl7LisResult, cErr := hl7Server.LisDao.GetHl7LisResultForUpdateWithTx(tx, visitId)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
+		storedLisOrder := structures.LisOrder{}
+		err := json.Unmarshal([]byte(hl7LisResult.LisOrder), &storedLisOrder)
+		if err != nil {
+			return err
+		}
+		lisOrder = mergeLisOrders(storedLisOrder, lisOrder)
+		lisOrder.VisitId = visitId
+		lisOrder.ReportPdfFormat = constants.LIS_REPORT_FORMAT_BRANDED
+
+		marshalledLisOrder, err := json.Marshal(lisOrder)
+		if err != nil {
+			return err
+		}
+		hl7LisResult.LisOrder = string(marshalledLisOrder)
+		hl7LisResult.ControlId = message.ControlId()
+		_, cErr = hl7Server.LisDao.UpdateHl7LisResultWithTx(tx, hl7LisResult)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+		return nil
+	})
+	return lisOrder, err
+}
+
+// mergeLisOrders overlays newer test results on the stored ones for the same visit.
+func mergeLisOrders(cachedLisOrder, lisOrder structures.LisOrder) structures.LisOrder {
+	testCodeIndexMap := map[string]int{}
+	for index, test := range cachedLisOrder.Tests {
+		testCodeIndexMap[test.TestCode] = index
+	}
+
+	for _, test := range lisOrder.Tests {
+		if index, ok := testCodeIndexMap[test.TestCode]; ok {
+			cachedLisOrder.Tests[index] = test
+			continue
+		}
+		cachedLisOrder.Tests = append(cachedLisOrder.Tests, test)
+	}
+
+	if lisOrder.ReportPdf != "" {
+		cachedLisOrder.ReportPdf = lisOrder.ReportPdf
+	}
+	return cachedLisOrder
+}
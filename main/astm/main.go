@@ -0,0 +1,7 @@
+package main
+
+import "github.com/Orange-Health/citadel/astm"
+
+func main() {
+	astm.Start()
+}
@@ -0,0 +1,76 @@
+// Command astm_simulator plays an analyzer against the ASTM listener. It sends one sample's results, given as
+// -results "GLU=98:mg/dL,CREA=1.1:mg/dL:H", and exits once the transfer has been acknowledged.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	astmClient "github.com/Orange-Health/citadel/clients/astm"
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+func main() {
+	address := flag.String("address", "localhost:2580", "host:port of the ASTM listener")
+	device := flag.String("device", "SIMULATOR", "sender name reported in the header record")
+	barcode := flag.String("barcode", "", "sample barcode reported as the specimen id")
+	results := flag.String("results", "", "comma separated CODE=value[:unit[:flag]] results")
+	flag.Parse()
+
+	if *barcode == "" || *results == "" {
+		log.Fatal("-barcode and -results are required")
+	}
+
+	message, err := buildMessage(*device, *barcode, *results)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(strings.ReplaceAll(message.String(), "\r", "\n"))
+
+	err = astmClient.SendMessage(context.Background(), *address, message)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("sent %d records to %s", len(message.Records), *address)
+}
+
+func buildMessage(device, barcode, results string) (astmClient.Message, error) {
+	delimiters := astmClient.DefaultDelimiters
+	completedAt := astmClient.FormatTime(time.Now())
+
+	resultRecords, testIds := []astmClient.Record{}, []string{}
+	for index, result := range strings.Split(results, ",") {
+		code, value, found := strings.Cut(strings.TrimSpace(result), "=")
+		if !found || code == "" {
+			return astmClient.Message{}, fmt.Errorf("invalid result %q", result)
+		}
+		parts := strings.SplitN(value, ":", 3)
+		for len(parts) < 3 {
+			parts = append(parts, "")
+		}
+
+		testId := "^^^" + delimiters.EscapeText(code)
+		testIds = append(testIds, testId)
+		resultRecords = append(resultRecords, astmClient.NewRecord(constants.ASTM_RECORD_RESULT,
+			fmt.Sprint(index+1), testId, delimiters.EscapeText(parts[0]), delimiters.EscapeText(parts[1]), "",
+			parts[2], "", constants.ASTM_RESULT_STATUS_FINAL, "", "", "", completedAt, device))
+	}
+
+	records := []astmClient.Record{
+		astmClient.NewHeader(device),
+		astmClient.NewRecord(constants.ASTM_RECORD_PATIENT, "1"),
+		astmClient.NewRecord(constants.ASTM_RECORD_ORDER, "1", delimiters.EscapeText(barcode), "",
+			strings.Join(testIds, delimiters.Repeat), "R"),
+	}
+	records = append(records, resultRecords...)
+	records = append(records, astmClient.NewTerminator())
+
+	return astmClient.Message{
+		Delimiters: delimiters,
+		Records:    records,
+	}, nil
+}
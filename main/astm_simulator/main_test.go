@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	astmClient "github.com/Orange-Health/citadel/clients/astm"
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+)
+
+func TestBuildMessage(t *testing.T) {
+	message, err := buildMessage("SIMULATOR", "BC|001", "GLU=98:mg/dL, CREA=1.4:mg/dL:H")
+	assert.NoError(t, err)
+	assert.Equal(t, "SIMULATOR", message.SenderName())
+
+	order := message.GetRecords(constants.ASTM_RECORD_ORDER)[0]
+	assert.Equal(t, "BC|001", order.Value(message.Delimiters, 3))
+	assert.Len(t, message.GetRecords(constants.ASTM_RECORD_RESULT), 2)
+	assert.Equal(t, constants.ASTM_RECORD_TERMINATOR, message.Records[len(message.Records)-1].Type())
+
+	_, err = buildMessage("SIMULATOR", "BC001", "GLU")
+	assert.Error(t, err)
+	_, err = buildMessage("SIMULATOR", "BC001", "=98")
+	assert.Error(t, err)
+}
+
+func TestSendMessageToListener(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+
+	received := make(chan []structures.DeviceSampleResults, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = astmClient.ListenAndServe(ctx, address, func(ctx context.Context, message astmClient.Message) error {
+			deviceSampleResults, err := astmClient.ConvertMessageToDeviceResults(message)
+			received <- deviceSampleResults
+			return err
+		})
+	}()
+
+	message, err := buildMessage("SIMULATOR", "BC001", "GLU=98:mg/dL,CREA=1.4:mg/dL:H")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return astmClient.SendMessage(context.Background(), address, message) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var deviceSampleResults []structures.DeviceSampleResults
+	select {
+	case deviceSampleResults = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not hand over the message")
+	}
+
+	assert.Len(t, deviceSampleResults, 1)
+	assert.Equal(t, "BC001", deviceSampleResults[0].Barcode)
+	assert.Equal(t, "SIMULATOR", deviceSampleResults[0].DeviceId)
+	assert.Len(t, deviceSampleResults[0].Results, 2)
+	assert.Equal(t, structures.DeviceResult{
+		TestCode:     "CREA",
+		Value:        "1.4",
+		Unit:         "mg/dL",
+		AbnormalFlag: "H",
+		ResultStatus: constants.ASTM_RESULT_STATUS_FINAL,
+		CompletedAt:  deviceSampleResults[0].Results[1].CompletedAt,
+	}, deviceSampleResults[0].Results[1])
+	assert.NotEmpty(t, deviceSampleResults[0].Results[1].CompletedAt)
+}
@@ -0,0 +1,7 @@
+package main
+
+import "github.com/Orange-Health/citadel/hl7"
+
+func main() {
+	hl7.Start()
+}
@@ -0,0 +1,38 @@
+// Command mllp_stub is a local MLLP receiver for exercising the HL7 LIS adapter. It prints every message it
+// receives and acknowledges it with AA, or with AE when started with -fail.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	hl7Client "github.com/Orange-Health/citadel/clients/hl7"
+)
+
+func main() {
+	address := flag.String("address", "localhost:2576", "host:port to listen on")
+	fail := flag.Bool("fail", false, "acknowledge every message with AE")
+	flag.Parse()
+
+	log.Printf("mllp stub listening on %s", *address)
+	err := hl7Client.ListenAndServe(context.Background(), *address, newHandler(os.Stdout, *fail))
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newHandler(output io.Writer, fail bool) hl7Client.MessageHandler {
+	return func(ctx context.Context, message hl7Client.Message) error {
+		fmt.Fprintln(output, strings.ReplaceAll(message.String(), "\r", "\n"))
+		if fail {
+			return errors.New("rejected by mllp stub")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	hl7Client "github.com/Orange-Health/citadel/clients/hl7"
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+	sentryMocks "github.com/Orange-Health/citadel/mocks/sentry"
+)
+
+const testLabId = 1
+
+// startStub runs the stub handler behind the MLLP listener on a free local port and returns its address.
+func startStub(t *testing.T, output *bytes.Buffer, fail bool) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = hl7Client.ListenAndServe(ctx, address, newHandler(output, fail))
+	}()
+
+	assert.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	return address
+}
+
+func getTestOrmMessage() hl7Client.Message {
+	return hl7Client.BuildOrmO01(constants.HL7_ORDER_CONTROL_NEW, structures.LisOrderRequest{
+		VisitId:     "VISIT-1",
+		PatientId:   "P001",
+		PatientName: "Jane Doe",
+		Tests: []structures.LisOrderTest{
+			{TestCode: "GLU", TestName: "Glucose", Barcodes: []string{"BC001"}},
+		},
+	})
+}
+
+func TestSendMessageToStub(t *testing.T) {
+	output := bytes.Buffer{}
+	address := startStub(t, &output, false)
+	client := &hl7Client.Hl7Client{
+		Sentry:    &sentryMocks.MockSentryDependency{},
+		Endpoints: map[string]string{"1": address},
+	}
+
+	message := getTestOrmMessage()
+	cErr := client.SendMessage(context.Background(), testLabId, message)
+	assert.Nil(t, cErr)
+	assert.Contains(t, output.String(), strings.ReplaceAll(message.String(), "\r", "\n"))
+}
+
+func TestSendMessageToFailingStub(t *testing.T) {
+	output := bytes.Buffer{}
+	address := startStub(t, &output, true)
+	sentry := &sentryMocks.MockSentryDependency{}
+	sentry.On("LogError", constants.ERROR_WHILE_SENDING_HL7_MESSAGE, mock.Anything, mock.Anything).Return()
+	client := &hl7Client.Hl7Client{
+		Sentry:    sentry,
+		Endpoints: map[string]string{"1": address},
+	}
+
+	cErr := client.SendMessage(context.Background(), testLabId, getTestOrmMessage())
+	assert.NotNil(t, cErr)
+	assert.Equal(t, constants.ERROR_WHILE_SENDING_HL7_MESSAGE, cErr.Message)
+	sentry.AssertExpectations(t)
+}
@@ -30,6 +30,11 @@ func (w bodyLogWriter) WriteString(s string) (int, error) {
 
 func Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Skipped before wrapping the writer so that long lived streams are not buffered
+		if isUrlSkippable(c.Request.Method, c.Request.RequestURI) {
+			c.Next()
+			return
+		}
 		start := time.Now()
 		bodyLogWriter := &bodyLogWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
 		c.Writer = bodyLogWriter
@@ -39,9 +44,6 @@ func Middleware() gin.HandlerFunc {
 		if responsePayload["response_body"] == "" || responsePayload["response_body"] == nil {
 			return
 		}
-		if isUrlSkippable(c.Request.Method, c.Request.RequestURI) {
-			return
-		}
 		logMap := map[string]interface{}{}
 		if traceID, ok := c.Get("trace_id"); ok {
 			logMap["trace_id"] = traceID
@@ -128,6 +130,7 @@ func isUrlSkippable(method, url string) bool {
 	skipUrls := []string{
 		"/ping",
 		"/api/v1/search/tasks",
+		"/api/v1/task-events/stream",
 	}
 
 	for _, skipUrl := range skipUrls {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -50,3 +51,13 @@ func (m *MockCacheDependency) HGetAll(ctx context.Context, key string) (map[stri
 	args := m.Called(ctx, key)
 	return args.Get(0).(map[string]interface{}), args.Error(1)
 }
+
+func (m *MockCacheDependency) Publish(ctx context.Context, channel string, message interface{}) error {
+	args := m.Called(ctx, channel, message)
+	return args.Error(0)
+}
+
+func (m *MockCacheDependency) Subscribe(ctx context.Context, channel string) *redis.PubSub {
+	args := m.Called(ctx, channel)
+	return args.Get(0).(*redis.PubSub)
+}
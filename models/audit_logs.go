@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AuditLog is a snapshot of a row of an audited table, written by the audit_logs_function trigger.
+type AuditLog struct {
+	LogID        uint      `gorm:"column:log_id;primaryKey" json:"log_id"`
+	LogAction    string    `gorm:"column:log_action" json:"log_action"`
+	LogTimestamp time.Time `gorm:"column:log_timestamp" json:"log_timestamp"`
+	EntityType   string    `gorm:"column:entity_type" json:"entity_type"`
+	EntityId     uint      `gorm:"column:entity_id" json:"entity_id"`
+	OmsOrderId   string    `gorm:"column:oms_order_id" json:"oms_order_id"`
+	UserId       uint      `gorm:"column:user_id" json:"user_id"`
+	RowData      string    `gorm:"column:row_data;type:jsonb" json:"row_data"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -0,0 +1,16 @@
+package models
+
+type AutoVerificationRule struct {
+	BaseModel
+	Name                  string `gorm:"column:name;not null;type:varchar(100)" json:"name"`
+	RuleType              string `gorm:"column:rule_type;not null;type:varchar(30)" json:"rule_type"`
+	LabId                 uint   `gorm:"column:lab_id;not null" json:"lab_id"`
+	MasterTestId          uint   `gorm:"column:master_test_id;not null" json:"master_test_id"`
+	MasterInvestigationId uint   `gorm:"column:master_investigation_id;not null" json:"master_investigation_id"`
+	Parameters            string `gorm:"column:parameters;type:text" json:"parameters"`
+	IsActive              bool   `gorm:"column:is_active;not null" json:"is_active"`
+}
+
+func (AutoVerificationRule) TableName() string {
+	return "auto_verification_rules"
+}
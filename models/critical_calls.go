@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type CriticalCall struct {
+	BaseModel
+	TaskId                uint       `gorm:"column:task_id;not null" json:"task_id"`
+	TestDetailsId         uint       `gorm:"column:test_details_id;not null" json:"test_details_id"`
+	InvestigationResultId uint       `gorm:"column:investigation_result_id;not null" json:"investigation_result_id"`
+	MasterInvestigationId uint       `gorm:"column:master_investigation_id;not null" json:"master_investigation_id"`
+	InvestigationName     string     `gorm:"column:investigation_name;type:varchar(100)" json:"investigation_name"`
+	InvestigationValue    string     `gorm:"column:investigation_value;type:varchar(100)" json:"investigation_value"`
+	Uom                   string     `gorm:"column:uom;type:varchar(100)" json:"uom"`
+	Status                string     `gorm:"column:status;not null;type:varchar(20)" json:"status"`
+	CalledBy              uint       `gorm:"column:called_by" json:"called_by"`
+	CalledToType          string     `gorm:"column:called_to_type;type:varchar(20)" json:"called_to_type"`
+	CalledToName          string     `gorm:"column:called_to_name;type:varchar(100)" json:"called_to_name"`
+	CalledToNumber        string     `gorm:"column:called_to_number;type:varchar(20)" json:"called_to_number"`
+	ReadBackValue         string     `gorm:"column:read_back_value;type:varchar(100)" json:"read_back_value"`
+	ReadBackConfirmed     bool       `gorm:"column:read_back_confirmed;not null" json:"read_back_confirmed"`
+	Remarks               string     `gorm:"column:remarks;type:varchar(500)" json:"remarks"`
+	AcknowledgedAt        *time.Time `gorm:"column:acknowledged_at" json:"acknowledged_at"`
+	EscalationCount       uint       `gorm:"column:escalation_count;not null" json:"escalation_count"`
+	LastEscalatedAt       *time.Time `gorm:"column:last_escalated_at" json:"last_escalated_at"`
+}
+
+func (CriticalCall) TableName() string {
+	return "critical_calls"
+}
@@ -0,0 +1,67 @@
+package models
+
+type CultureResult struct {
+	BaseModel
+	InvestigationResultId uint              `gorm:"column:investigation_result_id;not null" json:"investigation_result_id"`
+	ReportStatus          string            `gorm:"column:report_status;type:varchar(100)" json:"report_status"`
+	SampleType            string            `gorm:"column:sample_type;type:varchar(255)" json:"sample_type"`
+	IncubationPeriod      string            `gorm:"column:incubation_period;type:varchar(100)" json:"incubation_period"`
+	StainResult           string            `gorm:"column:stain_result;type:text" json:"stain_result"`
+	StainDetails          string            `gorm:"column:stain_details;type:jsonb" json:"stain_details"`
+	CultureReport         string            `gorm:"column:culture_report;type:text" json:"culture_report"`
+	ColonyCount           string            `gorm:"column:colony_count;type:varchar(255)" json:"colony_count"`
+	ResistanceDetected    string            `gorm:"column:resistance_detected;type:text" json:"resistance_detected"`
+	ClinicalHistory       string            `gorm:"column:clinical_history;type:text" json:"clinical_history"`
+	Gross                 string            `gorm:"column:gross;type:text" json:"gross"`
+	BreakpointStandard    string            `gorm:"column:breakpoint_standard;not null;type:varchar(20)" json:"breakpoint_standard"`
+	Organisms             []CultureOrganism `gorm:"foreignKey:CultureResultId;references:Id" json:"organisms"`
+}
+
+func (CultureResult) TableName() string {
+	return "culture_results"
+}
+
+type CultureOrganism struct {
+	BaseModel
+	CultureResultId  uint                    `gorm:"column:culture_result_id;not null" json:"culture_result_id"`
+	Name             string                  `gorm:"column:name;not null;type:varchar(255)" json:"name"`
+	Family           string                  `gorm:"column:family;type:varchar(255)" json:"family"`
+	ColonyCount      string                  `gorm:"column:colony_count;type:varchar(255)" json:"colony_count"`
+	DisplayOrder     int                     `gorm:"column:display_order;not null" json:"display_order"`
+	Susceptibilities []CultureSusceptibility `gorm:"foreignKey:CultureOrganismId;references:Id" json:"susceptibilities"`
+}
+
+func (CultureOrganism) TableName() string {
+	return "culture_organisms"
+}
+
+type CultureSusceptibility struct {
+	BaseModel
+	CultureOrganismId  uint   `gorm:"column:culture_organism_id;not null" json:"culture_organism_id"`
+	Antimicrobial      string `gorm:"column:antimicrobial;not null;type:varchar(255)" json:"antimicrobial"`
+	MicValue           string `gorm:"column:mic_value;type:varchar(50)" json:"mic_value"`
+	Interpretation     string `gorm:"column:interpretation;type:varchar(5)" json:"interpretation"`
+	BreakpointStandard string `gorm:"column:breakpoint_standard;not null;type:varchar(20)" json:"breakpoint_standard"`
+	DisplayOrder       int    `gorm:"column:display_order;not null" json:"display_order"`
+}
+
+func (CultureSusceptibility) TableName() string {
+	return "culture_susceptibilities"
+}
+
+// AntimicrobialBreakpoint holds the MIC breakpoints of an antimicrobial for an organism, or for every organism of a
+// family, under a standard. A MIC at or below SusceptibleMaxMic is S, at or above ResistantMinMic is R and I in
+// between.
+type AntimicrobialBreakpoint struct {
+	BaseModel
+	Standard          string  `gorm:"column:standard;not null;type:varchar(20)" json:"standard"`
+	Version           string  `gorm:"column:version;type:varchar(50)" json:"version"`
+	Organism          string  `gorm:"column:organism;not null;type:varchar(255)" json:"organism"`
+	Antimicrobial     string  `gorm:"column:antimicrobial;not null;type:varchar(255)" json:"antimicrobial"`
+	SusceptibleMaxMic float64 `gorm:"column:susceptible_max_mic;not null" json:"susceptible_max_mic"`
+	ResistantMinMic   float64 `gorm:"column:resistant_min_mic;not null" json:"resistant_min_mic"`
+}
+
+func (AntimicrobialBreakpoint) TableName() string {
+	return "antimicrobial_breakpoints"
+}
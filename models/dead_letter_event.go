@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+type DeadLetterEvent struct {
+	BaseModel
+	EventType    string     `gorm:"column:event_type;not null;type:varchar(100)" json:"event_type"`
+	TraceId      string     `gorm:"column:trace_id;type:varchar(128)" json:"trace_id"`
+	Contains     string     `gorm:"column:contains;type:varchar(100)" json:"contains"`
+	EventKey     string     `gorm:"column:event_key;type:varchar(255)" json:"event_key"`
+	EventPayload string     `gorm:"column:event_payload;type:text" json:"event_payload"`
+	Error        string     `gorm:"column:error;type:text" json:"error"`
+	Attempts     uint       `gorm:"column:attempts;not null" json:"attempts"`
+	Status       string     `gorm:"column:status;not null;type:varchar(20)" json:"status"`
+	ReplayedAt   *time.Time `gorm:"column:replayed_at" json:"replayed_at"`
+	ReplayedBy   uint       `gorm:"column:replayed_by" json:"replayed_by"`
+}
+
+func (DeadLetterEvent) TableName() string {
+	return "dead_letter_events"
+}
@@ -0,0 +1,15 @@
+package models
+
+type DeltaCheckRule struct {
+	BaseModel
+	MasterInvestigationId uint    `gorm:"column:master_investigation_id;not null" json:"master_investigation_id"`
+	RuleType              string  `gorm:"column:rule_type;not null;type:varchar(20)" json:"rule_type"`
+	PositiveLimit         float64 `gorm:"column:positive_limit;not null" json:"positive_limit"`
+	NegativeLimit         float64 `gorm:"column:negative_limit;not null" json:"negative_limit"`
+	ThresholdDays         uint    `gorm:"column:threshold_days;not null" json:"threshold_days"`
+	IsActive              bool    `gorm:"column:is_active;not null" json:"is_active"`
+}
+
+func (DeltaCheckRule) TableName() string {
+	return "delta_check_rules"
+}
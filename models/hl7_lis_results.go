@@ -0,0 +1,13 @@
+package models
+
+// Hl7LisResult holds the results received over ORU^R01 for a visit, merged across messages.
+type Hl7LisResult struct {
+	BaseModel
+	VisitId   string `gorm:"column:visit_id;not null;type:varchar(100)" json:"visit_id"`
+	LisOrder  string `gorm:"column:lis_order;not null;type:jsonb" json:"lis_order"`
+	ControlId string `gorm:"column:control_id;type:varchar(100)" json:"control_id"`
+}
+
+func (Hl7LisResult) TableName() string {
+	return "hl7_lis_results"
+}
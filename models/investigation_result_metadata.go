@@ -1,13 +1,26 @@
 package models
 
+import "time"
+
 type InvestigationResultMetadata struct {
 	BaseModel
-	InvestigationResultId uint   `gorm:"column:investigation_result_id" json:"investigation_result_id"`
-	QcFlag                string `gorm:"column:qc_flag" json:"qc_flag"`
-	QcLotNumber           string `gorm:"column:qc_lot_number" json:"qc_lot_number"`
-	QcValue               string `gorm:"column:qc_value" json:"qc_value"`
-	QcWestGardWarning     string `gorm:"column:qc_west_gard_warning" json:"qc_west_gard_warning"`
-	QcStatus              string `gorm:"column:qc_status" json:"qc_status"`
+	InvestigationResultId       uint       `gorm:"column:investigation_result_id" json:"investigation_result_id"`
+	QcFlag                      string     `gorm:"column:qc_flag" json:"qc_flag"`
+	QcLotNumber                 string     `gorm:"column:qc_lot_number" json:"qc_lot_number"`
+	QcValue                     string     `gorm:"column:qc_value" json:"qc_value"`
+	QcWestGardWarning           string     `gorm:"column:qc_west_gard_warning" json:"qc_west_gard_warning"`
+	QcStatus                    string     `gorm:"column:qc_status" json:"qc_status"`
+	DeltaCheckStatus            string     `gorm:"column:delta_check_status" json:"delta_check_status"`
+	DeltaPreviousValue          string     `gorm:"column:delta_previous_value" json:"delta_previous_value"`
+	DeltaPreviousApprovedAt     *time.Time `gorm:"column:delta_previous_approved_at" json:"delta_previous_approved_at"`
+	DeltaAbsolute               *float64   `gorm:"column:delta_absolute" json:"delta_absolute"`
+	DeltaPercentage             *float64   `gorm:"column:delta_percentage" json:"delta_percentage"`
+	DeltaRatePerDay             *float64   `gorm:"column:delta_rate_per_day" json:"delta_rate_per_day"`
+	DeltaViolatedRule           string     `gorm:"column:delta_violated_rule" json:"delta_violated_rule"`
+	DeltaCheckRemarks           string     `gorm:"column:delta_check_remarks" json:"delta_check_remarks"`
+	WestgardStatus              string     `gorm:"column:westgard_status" json:"westgard_status"`
+	WestgardViolations          string     `gorm:"column:westgard_violations" json:"westgard_violations"`
+	AutoVerificationEvaluations string     `gorm:"column:auto_verification_evaluations" json:"auto_verification_evaluations"`
 }
 
 func (InvestigationResultMetadata) TableName() string {
@@ -33,7 +33,8 @@ type InvestigationResult struct {
 	Source                             string     `gorm:"column:source;type:varchar(50)" json:"source"`
 	IsCritical                         bool       `gorm:"column:is_critical" json:"is_critical"`
 	ApprovalSource                     string     `gorm:"column:approval_source;type:varchar(20)" json:"approval_source"`
-	AutoApprovalFailureReason          string     `gorm:"column:auto_approval_failure_reason;type:varchar(255)" json:"auto_approval_failure_reason"`
+	AutoApprovalFailureReason          string     `gorm:"column:auto_approval_failure_reason;type:text" json:"auto_approval_failure_reason"`
+	RowVersion                         uint64     `gorm:"column:row_version;->" json:"row_version"`
 }
 
 func (InvestigationResult) TableName() string {
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+type OutboxMessage struct {
+	BaseModel
+	Topic                  string     `gorm:"column:topic;not null;type:varchar(255)" json:"topic"`
+	MessageGroupId         string     `gorm:"column:message_group_id;type:varchar(128)" json:"message_group_id"`
+	MessageDeduplicationId string     `gorm:"column:message_deduplication_id;type:varchar(128)" json:"message_deduplication_id"`
+	MessageBody            string     `gorm:"column:message_body;not null;type:jsonb" json:"message_body"`
+	MessageAttributes      string     `gorm:"column:message_attributes;not null;type:jsonb" json:"message_attributes"`
+	EventType              string     `gorm:"column:event_type;type:varchar(100)" json:"event_type"`
+	Status                 string     `gorm:"column:status;not null;type:varchar(20)" json:"status"`
+	Attempts               uint       `gorm:"column:attempts;not null" json:"attempts"`
+	NextAttemptAt          *time.Time `gorm:"column:next_attempt_at;not null" json:"next_attempt_at"`
+	LastError              string     `gorm:"column:last_error;type:text" json:"last_error"`
+	PublishedAt            *time.Time `gorm:"column:published_at" json:"published_at"`
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox_messages"
+}
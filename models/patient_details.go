@@ -12,6 +12,8 @@ type PatientDetail struct {
 	Gender          string     `gorm:"column:gender;not null;type:varchar(10)" json:"gender"`
 	Number          string     `gorm:"column:number;not null;type:varchar(20)" json:"number"`
 	SystemPatientId string     `gorm:"column:system_patient_id;type:varchar(50)" json:"system_patient_id"`
+	IsPregnant      bool       `gorm:"column:is_pregnant;not null;default:false" json:"is_pregnant"`
+	Trimester       uint       `gorm:"column:trimester;not null;default:0" json:"trimester"`
 }
 
 func (PatientDetail) TableName() string {
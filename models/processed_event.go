@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+type ProcessedEvent struct {
+	BaseModel
+	EventType   string     `gorm:"column:event_type;not null;type:varchar(100)" json:"event_type"`
+	BusinessId  string     `gorm:"column:business_id;not null;type:varchar(255)" json:"business_id"`
+	PayloadHash string     `gorm:"column:payload_hash;not null;type:varchar(64)" json:"payload_hash"`
+	EventSentAt *time.Time `gorm:"column:event_sent_at" json:"event_sent_at"`
+	Decision    string     `gorm:"column:decision;not null;type:varchar(20)" json:"decision"`
+	// ProcessedEventId is the processed event that a duplicate or stale event was compared with.
+	ProcessedEventId uint `gorm:"column:processed_event_id" json:"processed_event_id"`
+	// LeaseExpiresAt is the time an in progress claim lapses at.
+	LeaseExpiresAt *time.Time `gorm:"column:lease_expires_at" json:"lease_expires_at"`
+}
+
+func (ProcessedEvent) TableName() string {
+	return "processed_events"
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type QcTarget struct {
+	BaseModel
+	LabId     uint    `gorm:"column:lab_id;not null" json:"lab_id"`
+	LotNumber string  `gorm:"column:lot_number;not null;type:varchar(100)" json:"lot_number"`
+	LisCode   string  `gorm:"column:lis_code;not null;type:varchar(100)" json:"lis_code"`
+	Mean      float64 `gorm:"column:mean;not null" json:"mean"`
+	Sd        float64 `gorm:"column:sd;not null" json:"sd"`
+	IsActive  bool    `gorm:"column:is_active;not null" json:"is_active"`
+}
+
+func (QcTarget) TableName() string {
+	return "qc_targets"
+}
+
+type QcResult struct {
+	BaseModel
+	LabId              uint       `gorm:"column:lab_id;not null" json:"lab_id"`
+	LotNumber          string     `gorm:"column:lot_number;not null;type:varchar(100)" json:"lot_number"`
+	LisCode            string     `gorm:"column:lis_code;not null;type:varchar(100)" json:"lis_code"`
+	QcValue            float64    `gorm:"column:qc_value;not null" json:"qc_value"`
+	RunAt              *time.Time `gorm:"column:run_at;not null" json:"run_at"`
+	ZScore             *float64   `gorm:"column:z_score" json:"z_score"`
+	WestgardViolations string     `gorm:"column:westgard_violations;type:varchar(100)" json:"westgard_violations"`
+	QcStatus           string     `gorm:"column:qc_status;type:varchar(20)" json:"qc_status"`
+	Source             string     `gorm:"column:source;type:varchar(20)" json:"source"`
+}
+
+func (QcResult) TableName() string {
+	return "qc_results"
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type ReflexRule struct {
+	BaseModel
+	Name               string `gorm:"column:name;not null;type:varchar(255)" json:"name"`
+	LabId              uint   `gorm:"column:lab_id;not null" json:"lab_id"`
+	TriggerLisCode     string `gorm:"column:trigger_lis_code;not null;type:varchar(100)" json:"trigger_lis_code"`
+	Operator           string `gorm:"column:operator;not null;type:varchar(20)" json:"operator"`
+	Value              string `gorm:"column:value;not null;type:varchar(100)" json:"value"`
+	ReflexMasterTestId uint   `gorm:"column:reflex_master_test_id;not null" json:"reflex_master_test_id"`
+	ReflexLisCode      string `gorm:"column:reflex_lis_code;not null;type:varchar(20)" json:"reflex_lis_code"`
+	ReflexTestName     string `gorm:"column:reflex_test_name;not null;type:varchar(255)" json:"reflex_test_name"`
+	ReflexTestType     string `gorm:"column:reflex_test_type;not null;type:varchar(10)" json:"reflex_test_type"`
+	ReflexDepartment   string `gorm:"column:reflex_department;type:varchar(100)" json:"reflex_department"`
+	VialTypeId         uint   `gorm:"column:vial_type_id;not null" json:"vial_type_id"`
+	RequiredVolume     uint   `gorm:"column:required_volume;not null" json:"required_volume"`
+	StabilityHours     uint   `gorm:"column:stability_hours;not null" json:"stability_hours"`
+	IsActive           bool   `gorm:"column:is_active;not null" json:"is_active"`
+}
+
+func (ReflexRule) TableName() string {
+	return "reflex_rules"
+}
+
+type ReflexTestTrigger struct {
+	BaseModel
+	ReflexRuleId                 uint       `gorm:"column:reflex_rule_id;not null" json:"reflex_rule_id"`
+	TaskId                       uint       `gorm:"column:task_id;not null" json:"task_id"`
+	OmsOrderId                   string     `gorm:"column:oms_order_id;not null" json:"oms_order_id"`
+	TriggerTestDetailsId         uint       `gorm:"column:trigger_test_details_id;not null" json:"trigger_test_details_id"`
+	TriggerInvestigationResultId uint       `gorm:"column:trigger_investigation_result_id;not null" json:"trigger_investigation_result_id"`
+	TriggerValue                 string     `gorm:"column:trigger_value;not null;type:varchar(100)" json:"trigger_value"`
+	TriggerAbnormality           string     `gorm:"column:trigger_abnormality;type:varchar(10)" json:"trigger_abnormality"`
+	SampleId                     uint       `gorm:"column:sample_id" json:"sample_id"`
+	ReflexTestDetailsId          uint       `gorm:"column:reflex_test_details_id" json:"reflex_test_details_id"`
+	ReflexCentralOmsTestId       string     `gorm:"column:reflex_central_oms_test_id;type:varchar(50)" json:"reflex_central_oms_test_id"`
+	RequiredVolume               uint       `gorm:"column:required_volume;not null" json:"required_volume"`
+	Status                       string     `gorm:"column:status;not null;type:varchar(20)" json:"status"`
+	Reason                       string     `gorm:"column:reason;not null" json:"reason"`
+	TriggeredAt                  *time.Time `gorm:"column:triggered_at;not null" json:"triggered_at"`
+}
+
+func (ReflexTestTrigger) TableName() string {
+	return "reflex_test_triggers"
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type ReportAmendment struct {
+	BaseModel
+	TaskId            uint       `gorm:"column:task_id;not null" json:"task_id"`
+	OmsOrderId        string     `gorm:"column:oms_order_id;not null;type:varchar(50)" json:"oms_order_id"`
+	Status            string     `gorm:"column:status;not null;type:varchar(20)" json:"status"`
+	ReasonCode        string     `gorm:"column:reason_code;not null;type:varchar(50)" json:"reason_code"`
+	Justification     string     `gorm:"column:justification;not null" json:"justification"`
+	ReportVersion     uint       `gorm:"column:report_version;not null" json:"report_version"`
+	RequestedBy       uint       `gorm:"column:requested_by;not null" json:"requested_by"`
+	ApprovedBy        uint       `gorm:"column:approved_by" json:"approved_by"`
+	ApprovedAt        *time.Time `gorm:"column:approved_at" json:"approved_at"`
+	PreviousValues    string     `gorm:"column:previous_values;not null;type:jsonb" json:"previous_values"`
+	InvestigationDiff string     `gorm:"column:investigation_diff;not null;type:jsonb" json:"investigation_diff"`
+}
+
+func (ReportAmendment) TableName() string {
+	return "report_amendments"
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+type RosterShift struct {
+	BaseModel
+	CityCode  string `gorm:"column:city_code;not null;type:varchar(10)" json:"city_code"`
+	LabId     uint   `gorm:"column:lab_id;not null" json:"lab_id"`
+	Weekday   string `gorm:"column:weekday;not null;type:varchar(10)" json:"weekday"`
+	StartTime string `gorm:"column:start_time;not null;type:varchar(5)" json:"start_time"`
+	EndTime   string `gorm:"column:end_time;not null;type:varchar(5)" json:"end_time"`
+	UserId    uint   `gorm:"column:user_id;not null" json:"user_id"`
+}
+
+func (RosterShift) TableName() string {
+	return "roster_shifts"
+}
+
+type RosterOverride struct {
+	BaseModel
+	CityCode       string     `gorm:"column:city_code;not null;type:varchar(10)" json:"city_code"`
+	LabId          uint       `gorm:"column:lab_id;not null" json:"lab_id"`
+	OverrideDate   *time.Time `gorm:"column:override_date;not null;type:date" json:"override_date"`
+	StartTime      string     `gorm:"column:start_time;not null;type:varchar(5)" json:"start_time"`
+	EndTime        string     `gorm:"column:end_time;not null;type:varchar(5)" json:"end_time"`
+	UserId         uint       `gorm:"column:user_id;not null" json:"user_id"`
+	ReplacedUserId *uint      `gorm:"column:replaced_user_id" json:"replaced_user_id"`
+	OverrideType   string     `gorm:"column:override_type;not null;type:varchar(20)" json:"override_type"`
+	Reason         string     `gorm:"column:reason;type:varchar(255)" json:"reason"`
+}
+
+func (RosterOverride) TableName() string {
+	return "roster_overrides"
+}
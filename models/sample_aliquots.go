@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type SampleAliquot struct {
+	BaseModel
+	OmsOrderId       string     `gorm:"column:oms_order_id;not null" json:"oms_order_id"`
+	ParentSampleId   uint       `gorm:"column:parent_sample_id;not null" json:"parent_sample_id"`
+	AliquotSampleId  uint       `gorm:"column:aliquot_sample_id;not null" json:"aliquot_sample_id"`
+	Volume           uint       `gorm:"column:volume;not null" json:"volume"`
+	Department       string     `gorm:"column:department;type:varchar(100)" json:"department"`
+	DestinationLabId uint       `gorm:"column:destination_lab_id;not null" json:"destination_lab_id"`
+	AliquotedAt      *time.Time `gorm:"column:aliquoted_at;not null" json:"aliquoted_at"`
+}
+
+func (SampleAliquot) TableName() string {
+	return "sample_aliquots"
+}
@@ -24,6 +24,9 @@ type SampleMetadata struct {
 	CollectLaterReason       string     `gorm:"column:collect_later_reason" json:"collect_later_reason,omitempty"`
 	RejectingLab             uint       `gorm:"column:rejecting_lab" json:"rejecting_lab,omitempty"`
 	CollectedVolume          uint       `gorm:"column:collected_volume" json:"collected_volume,omitempty"`
+	StorageCondition         string     `gorm:"column:storage_condition" json:"storage_condition,omitempty"`
+	StabilityFlaggedAt       *time.Time `gorm:"column:stability_flagged_at" json:"stability_flagged_at,omitempty"`
+	StabilityFlagReason      string     `gorm:"column:stability_flag_reason" json:"stability_flag_reason,omitempty"`
 }
 
 func (SampleMetadata) TableName() string {
@@ -0,0 +1,17 @@
+package models
+
+type SampleStabilityRule struct {
+	BaseModel
+	LabId            uint   `gorm:"column:lab_id;not null" json:"lab_id"`
+	VialTypeId       uint   `gorm:"column:vial_type_id;not null" json:"vial_type_id"`
+	MasterTestId     uint   `gorm:"column:master_test_id;not null" json:"master_test_id"`
+	StorageCondition string `gorm:"column:storage_condition;not null;type:varchar(20)" json:"storage_condition"`
+	StabilityHours   uint   `gorm:"column:stability_hours;not null" json:"stability_hours"`
+	NearExpiryHours  uint   `gorm:"column:near_expiry_hours;not null" json:"near_expiry_hours"`
+	Action           string `gorm:"column:action;not null;type:varchar(20)" json:"action"`
+	IsActive         bool   `gorm:"column:is_active;not null" json:"is_active"`
+}
+
+func (SampleStabilityRule) TableName() string {
+	return "sample_stability_rules"
+}
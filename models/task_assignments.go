@@ -0,0 +1,28 @@
+package models
+
+type PathologistProfile struct {
+	BaseModel
+	PathologistId uint   `gorm:"column:pathologist_id;not null" json:"pathologist_id"`
+	Departments   string `gorm:"column:departments;type:varchar(1000)" json:"departments"`
+	MaxOpenTasks  uint   `gorm:"column:max_open_tasks;not null" json:"max_open_tasks"`
+	IsLead        bool   `gorm:"column:is_lead;not null" json:"is_lead"`
+}
+
+func (PathologistProfile) TableName() string {
+	return "pathologist_profiles"
+}
+
+type TaskAssignment struct {
+	BaseModel
+	TaskId         uint    `gorm:"column:task_id;not null" json:"task_id"`
+	PathologistId  uint    `gorm:"column:pathologist_id;not null" json:"pathologist_id"`
+	AssignmentType string  `gorm:"column:assignment_type;not null;type:varchar(20)" json:"assignment_type"`
+	IsActive       bool    `gorm:"column:is_active;not null" json:"is_active"`
+	Score          float64 `gorm:"column:score" json:"score"`
+	Explanation    string  `gorm:"column:explanation;type:jsonb" json:"explanation"`
+	Reason         string  `gorm:"column:reason;type:varchar(255)" json:"reason"`
+}
+
+func (TaskAssignment) TableName() string {
+	return "task_assignments"
+}
@@ -0,0 +1,15 @@
+package models
+
+type TaskListView struct {
+	BaseModel
+	UserId    uint   `gorm:"column:user_id;not null" json:"user_id"`
+	Name      string `gorm:"column:name;not null;type:varchar(100)" json:"name"`
+	Filters   string `gorm:"column:filters;not null;type:jsonb" json:"filters"`
+	SortBy    string `gorm:"column:sort_by;not null;type:varchar(20)" json:"sort_by"`
+	SortOrder string `gorm:"column:sort_order;not null;type:varchar(4)" json:"sort_order"`
+	IsDefault bool   `gorm:"column:is_default;not null" json:"is_default"`
+}
+
+func (TaskListView) TableName() string {
+	return "task_list_views"
+}
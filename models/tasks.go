@@ -20,6 +20,7 @@ type Task struct {
 	DoctorTat        *time.Time    `gorm:"column:doctor_tat;type:timestamp" json:"doctor_tat"`
 	IsActive         bool          `gorm:"column:is_active;not null" json:"is_active"` // TODO: Drop this column
 	CompletedAt      *time.Time    `gorm:"column:completed_at;type:timestamp" json:"completed_at"`
+	RowVersion       uint64        `gorm:"column:row_version;->" json:"row_version"`
 }
 
 func (Task) TableName() string {
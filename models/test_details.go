@@ -32,6 +32,7 @@ type TestDetail struct {
 	ReportEta            *time.Time `gorm:"column:report_eta" json:"report_eta"`
 	ReportStatus         string     `gorm:"column:report_status;not null;type:varchar(25)" json:"report_status"`
 	CpEnabled            bool       `gorm:"column:cp_enabled;not null" json:"cp_enabled"`
+	RowVersion           uint64     `gorm:"column:row_version;->" json:"row_version"`
 }
 
 func (TestDetail) TableName() string {
@@ -7,17 +7,34 @@ import (
 
 	attachments "github.com/Orange-Health/citadel/apps/attachments"
 	auditLog "github.com/Orange-Health/citadel/apps/audit_log"
+	autoVerification "github.com/Orange-Health/citadel/apps/auto_verification"
+	calculations "github.com/Orange-Health/citadel/apps/calculations"
+	criticalCalls "github.com/Orange-Health/citadel/apps/critical_calls"
+	cultureResults "github.com/Orange-Health/citadel/apps/culture_results"
+	deadLetters "github.com/Orange-Health/citadel/apps/dead_letters"
+	deltaCheck "github.com/Orange-Health/citadel/apps/delta_check"
+	eventLedger "github.com/Orange-Health/citadel/apps/event_ledger"
 	externalInvestigationResults "github.com/Orange-Health/citadel/apps/external_investigation_results"
+	fhir "github.com/Orange-Health/citadel/apps/fhir"
 	health "github.com/Orange-Health/citadel/apps/health"
 	investigationResults "github.com/Orange-Health/citadel/apps/investigation_results"
+	outbox "github.com/Orange-Health/citadel/apps/outbox"
 	patientDetails "github.com/Orange-Health/citadel/apps/patient_details"
+	qc "github.com/Orange-Health/citadel/apps/qc"
 	receivingDesk "github.com/Orange-Health/citadel/apps/receiving_desk"
+	reflexRules "github.com/Orange-Health/citadel/apps/reflex_rules"
+	reportAmendments "github.com/Orange-Health/citadel/apps/report_amendments"
 	reportGeneration "github.com/Orange-Health/citadel/apps/report_generation"
+	roster "github.com/Orange-Health/citadel/apps/roster"
+	sampleStability "github.com/Orange-Health/citadel/apps/sample_stability"
 	samples "github.com/Orange-Health/citadel/apps/samples"
 	search "github.com/Orange-Health/citadel/apps/search"
 	task "github.com/Orange-Health/citadel/apps/task"
+	taskAssignment "github.com/Orange-Health/citadel/apps/task_assignment"
+	taskEvents "github.com/Orange-Health/citadel/apps/task_events"
 	taskMetaData "github.com/Orange-Health/citadel/apps/task_metadata"
 	taskPathologistMapping "github.com/Orange-Health/citadel/apps/task_pathologist_mapping"
+	tatAnalytics "github.com/Orange-Health/citadel/apps/tat_analytics"
 	template "github.com/Orange-Health/citadel/apps/templates"
 	testDetail "github.com/Orange-Health/citadel/apps/test_detail"
 	users "github.com/Orange-Health/citadel/apps/users"
@@ -43,6 +60,23 @@ func initialiseRoutes(router *gin.Engine) {
 	receivingDesk.RouteHandler(router.Group("/api/v1/receiving-desk"))
 	externalInvestigationResults.RouteHandler(router.Group("/api/v1/external-investigation-results"))
 	samples.RouteHandler(router.Group("/api/v1/samples"))
+	deltaCheck.RouteHandler(router.Group("/api/v1/delta-check"))
+	qc.RouteHandler(router.Group("/api/v1/qc"))
+	autoVerification.RouteHandler(router.Group("/api/v1/auto-verification"))
+	calculations.RouteHandler(router.Group("/api/v1/calculations"))
+	criticalCalls.RouteHandler(router.Group("/api/v1/critical-calls"))
+	taskAssignment.RouteHandler(router.Group("/api/v1/task-assignments"))
+	roster.RouteHandler(router.Group("/api/v1/rosters"))
+	outbox.RouteHandler(router.Group("/api/v1/outbox"))
+	deadLetters.RouteHandler(router.Group("/api/v1/dead-letters"))
+	eventLedger.RouteHandler(router.Group("/api/v1/event-ledger"))
+	fhir.RouteHandler(router.Group("/api/v1/fhir"))
+	cultureResults.RouteHandler(router.Group("/api/v1/culture-results"))
+	reflexRules.RouteHandler(router.Group("/api/v1/reflex-rules"))
+	sampleStability.RouteHandler(router.Group("/api/v1/sample-stability"))
+	taskEvents.RouteHandler(router.Group("/api/v1/task-events"))
+	reportAmendments.RouteHandler(router.Group("/api/v1/report-amendments"))
+	tatAnalytics.RouteHandler(router.Group("/api/v1/tat-analytics"))
 
 	if gin.IsDebugging() {
 		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
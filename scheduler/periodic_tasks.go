@@ -15,6 +15,9 @@ func HandlePeriodicTasks(server *machinery.Server) {
 	RegisterStaleTasksPeriodicTask(server, c)
 	RegisterEtsTatBreachedPeriodicTask(server, c)
 	RegisterReverseLogisticsSlackAlertPeriodicTask(server, c)
+	RegisterCriticalCallEscalationPeriodicTask(server, c)
+	RegisterTaskAutoAssignmentPeriodicTask(server, c)
+	RegisterOutboxRelayPeriodicTask(server, c)
 
 	c.Start()
 }
@@ -68,3 +71,54 @@ func RegisterReverseLogisticsSlackAlertPeriodicTask(server *machinery.Server, c
 		log.ERROR.Printf("error while adding reverse logistics slack alert periodic task: %v", err.Error())
 	}
 }
+
+func RegisterCriticalCallEscalationPeriodicTask(server *machinery.Server, c *cron.Cron) {
+	log.INFO.Printf("RegisterCriticalCallEscalationPeriodicTask")
+
+	signature := workerPeriodicTasks.CriticalCallEscalationPeriodicTaskSignature()
+	_, err := c.AddFunc(constants.CriticalCallEscalationPeriodicTaskFrequency, func() {
+		log.INFO.Println("Sending CriticalCallEscalationPeriodicTask")
+		_, err := server.SendTask(signature)
+		if err != nil {
+			log.ERROR.Printf("error while sending periodic task: %v", err.Error())
+		}
+	})
+
+	if err != nil {
+		log.ERROR.Printf("error while adding critical call escalation periodic task: %v", err.Error())
+	}
+}
+
+func RegisterTaskAutoAssignmentPeriodicTask(server *machinery.Server, c *cron.Cron) {
+	log.INFO.Printf("RegisterTaskAutoAssignmentPeriodicTask")
+
+	signature := workerPeriodicTasks.TaskAutoAssignmentPeriodicTaskSignature()
+	_, err := c.AddFunc(constants.TaskAutoAssignmentPeriodicTaskFrequency, func() {
+		log.INFO.Println("Sending TaskAutoAssignmentPeriodicTask")
+		_, err := server.SendTask(signature)
+		if err != nil {
+			log.ERROR.Printf("error while sending periodic task: %v", err.Error())
+		}
+	})
+
+	if err != nil {
+		log.ERROR.Printf("error while adding task auto assignment periodic task: %v", err.Error())
+	}
+}
+
+func RegisterOutboxRelayPeriodicTask(server *machinery.Server, c *cron.Cron) {
+	log.INFO.Printf("RegisterOutboxRelayPeriodicTask")
+
+	signature := workerPeriodicTasks.OutboxRelayPeriodicTaskSignature()
+	_, err := c.AddFunc(constants.OutboxRelayPeriodicTaskFrequency, func() {
+		log.INFO.Println("Sending OutboxRelayPeriodicTask")
+		_, err := server.SendTask(signature)
+		if err != nil {
+			log.ERROR.Printf("error while sending periodic task: %v", err.Error())
+		}
+	})
+
+	if err != nil {
+		log.ERROR.Printf("error while adding outbox relay periodic task: %v", err.Error())
+	}
+}
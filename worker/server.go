@@ -16,25 +16,36 @@ import (
 	"github.com/Orange-Health/citadel/adapters/sqs"
 	abnormalityService "github.com/Orange-Health/citadel/apps/abnormality/service"
 	attachmentsService "github.com/Orange-Health/citadel/apps/attachments/service"
-	attuneService "github.com/Orange-Health/citadel/apps/attune/service"
+	autoVerificationService "github.com/Orange-Health/citadel/apps/auto_verification/service"
 	cdsService "github.com/Orange-Health/citadel/apps/cds/service"
 	contactService "github.com/Orange-Health/citadel/apps/contact/service"
+	criticalCallService "github.com/Orange-Health/citadel/apps/critical_calls/service"
+	cultureResultsService "github.com/Orange-Health/citadel/apps/culture_results/service"
+	deadLetterService "github.com/Orange-Health/citadel/apps/dead_letters/service"
+	deltaCheckService "github.com/Orange-Health/citadel/apps/delta_check/service"
 	etsService "github.com/Orange-Health/citadel/apps/ets/service"
+	eventLedgerService "github.com/Orange-Health/citadel/apps/event_ledger/service"
+	fhirService "github.com/Orange-Health/citadel/apps/fhir/service"
 	investigationResultsService "github.com/Orange-Health/citadel/apps/investigation_results/service"
+	lisService "github.com/Orange-Health/citadel/apps/lis/service"
 	orderDetailsService "github.com/Orange-Health/citadel/apps/order_details/service"
+	outboxService "github.com/Orange-Health/citadel/apps/outbox/service"
 	patientDetailService "github.com/Orange-Health/citadel/apps/patient_details/service"
 	pubsubService "github.com/Orange-Health/citadel/apps/pubsub/service"
+	qcService "github.com/Orange-Health/citadel/apps/qc/service"
 	receivingDeskService "github.com/Orange-Health/citadel/apps/receiving_desk/service"
+	reflexRulesService "github.com/Orange-Health/citadel/apps/reflex_rules/service"
 	remarksService "github.com/Orange-Health/citadel/apps/remarks/service"
 	reportGenerationService "github.com/Orange-Health/citadel/apps/report_generation/service"
 	rerunService "github.com/Orange-Health/citadel/apps/rerun/service"
 	sampleService "github.com/Orange-Health/citadel/apps/samples/service"
 	taskService "github.com/Orange-Health/citadel/apps/task/service"
+	taskAssignmentService "github.com/Orange-Health/citadel/apps/task_assignment/service"
+	taskEventsService "github.com/Orange-Health/citadel/apps/task_events/service"
 	taskPathMappingService "github.com/Orange-Health/citadel/apps/task_pathologist_mapping/service"
 	testDetailService "github.com/Orange-Health/citadel/apps/test_detail/service"
 	testSampleMappingService "github.com/Orange-Health/citadel/apps/test_sample_mapping/service"
 	userService "github.com/Orange-Health/citadel/apps/users/service"
-	attuneClient "github.com/Orange-Health/citadel/clients/attune"
 	cdsClient "github.com/Orange-Health/citadel/clients/cds"
 	healthApiClient "github.com/Orange-Health/citadel/clients/health_api"
 	omsClient "github.com/Orange-Health/citadel/clients/oms"
@@ -43,7 +54,6 @@ import (
 	s3Client "github.com/Orange-Health/citadel/clients/s3"
 	s3wrapperClient "github.com/Orange-Health/citadel/clients/s3wrapper"
 	slackClient "github.com/Orange-Health/citadel/clients/slack"
-	snsClient "github.com/Orange-Health/citadel/clients/sns"
 	"github.com/Orange-Health/citadel/common/constants"
 	commonTasks "github.com/Orange-Health/citadel/common_tasks"
 	workerPeriodicTasks "github.com/Orange-Health/citadel/worker_periodic_tasks"
@@ -153,7 +163,7 @@ func Start() error {
 	orderDetailsLayer := orderDetailsService.InitializeOrderDetailsService()
 	sampleServiceLayer := sampleService.InitializeSampleService()
 	testSampleMappingServiceLayer := testSampleMappingService.InitializeTestSampleMappingService()
-	attuneServiceLayer := attuneService.InitializeAttuneService()
+	lisServiceLayer := lisService.InitializeLisService()
 	taskServiceLayer := taskService.InitializeTaskService()
 	taskPathMappingServiceLayer := taskPathMappingService.InitializeTaskPathologistMappingService()
 	patientServiceLayer := patientDetailService.InitializePatientDetailService()
@@ -170,7 +180,18 @@ func Start() error {
 	etsServiceLayer := etsService.InitializeEtsService()
 	cdsServiceLayer := cdsService.InitializeCdsService()
 	pubsubServiceLayer := pubsubService.InitializePubsubService()
-	attuneClientLayer := attuneClient.InitializeAttuneClient()
+	deltaCheckServiceLayer := deltaCheckService.InitializeDeltaCheckService()
+	qcServiceLayer := qcService.InitializeQcService()
+	autoVerificationServiceLayer := autoVerificationService.InitializeAutoVerificationService()
+	criticalCallServiceLayer := criticalCallService.InitializeCriticalCallService()
+	fhirServiceLayer := fhirService.InitializeFhirService()
+	taskAssignmentServiceLayer := taskAssignmentService.InitializeTaskAssignmentService()
+	outboxServiceLayer := outboxService.InitializeOutboxService()
+	deadLetterServiceLayer := deadLetterService.InitializeDeadLetterService()
+	eventLedgerServiceLayer := eventLedgerService.InitializeEventLedgerService()
+	cultureResultsServiceLayer := cultureResultsService.InitializeCultureResultsService()
+	reflexRulesServiceLayer := reflexRulesService.InitializeReflexRulesService()
+	taskEventsServiceLayer := taskEventsService.InitializeTaskEventsService()
 	cdsClientLayer := cdsClient.InitializeCdsClient()
 	omsClientLayer := omsClient.InitializeOmsClient()
 	reportRebrandingClientLayer := reportRebrandingClient.InitializeReportRebrandingClient()
@@ -178,7 +199,6 @@ func Start() error {
 	s3ClientLayer := s3Client.InitializeS3Client()
 	partnerApiClientLayer := partnerApiClient.InitializePartnerApiClient()
 	healthApiClientLayer := healthApiClient.InitializeHealthApiClient()
-	snsClientLayer := snsClient.InitializeSnsClient()
 	slackClientLayer := slackClient.InitializeSlackClient()
 
 	commonTasksProcessor := commonTasks.CommonTaskProcessor{
@@ -187,7 +207,7 @@ func Start() error {
 		Sentry:                      sentryLayer,
 		OrderDetailsService:         orderDetailsLayer,
 		SampleService:               sampleServiceLayer,
-		AttuneService:               attuneServiceLayer,
+		LisService:                  lisServiceLayer,
 		TaskService:                 taskServiceLayer,
 		TestDetailService:           testServiceLayer,
 		InvestigationResultsService: investigationResultsServiceLayer,
@@ -196,7 +216,9 @@ func Start() error {
 		UserService:                 userServiceLayer,
 		CdsService:                  cdsServiceLayer,
 		ReportGenerationService:     reportGenerationServiceLayer,
-		AttuneClient:                attuneClientLayer,
+		CriticalCallService:         criticalCallServiceLayer,
+		FhirService:                 fhirServiceLayer,
+		TaskEventsService:           taskEventsServiceLayer,
 		S3Client:                    s3ClientLayer,
 		S3wrapperClient:             s3wrapperClientLayer,
 		ReportRebrandingClient:      reportRebrandingClientLayer,
@@ -210,7 +232,7 @@ func Start() error {
 		SampleService:               sampleServiceLayer,
 		ReceivingDeskService:        receivingDeskServiceLayer,
 		TestSampleMappingService:    testSampleMappingServiceLayer,
-		AttuneService:               attuneServiceLayer,
+		LisService:                  lisServiceLayer,
 		TaskService:                 taskServiceLayer,
 		TaskPathMappingService:      taskPathMappingServiceLayer,
 		PatientDetailService:        patientServiceLayer,
@@ -226,13 +248,21 @@ func Start() error {
 		EtsService:                  etsServiceLayer,
 		CdsService:                  cdsServiceLayer,
 		PubsubService:               pubsubServiceLayer,
-		AttuneClient:                attuneClientLayer,
+		DeltaCheckService:           deltaCheckServiceLayer,
+		QcService:                   qcServiceLayer,
+		AutoVerificationService:     autoVerificationServiceLayer,
+		DeadLetterService:           deadLetterServiceLayer,
+		EventLedgerService:          eventLedgerServiceLayer,
+		CultureResultsService:       cultureResultsServiceLayer,
+		ReflexRulesService:          reflexRulesServiceLayer,
+		CriticalCallService:         criticalCallServiceLayer,
+		TaskEventsService:           taskEventsServiceLayer,
+		OutboxService:               outboxServiceLayer,
 		CdsClient:                   cdsClientLayer,
 		OmsClient:                   omsClientLayer,
 		ReportRebrandingClient:      reportRebrandingClientLayer,
 		S3wrapperClient:             s3wrapperClientLayer,
 		S3Client:                    s3ClientLayer,
-		SnsClient:                   snsClientLayer,
 		PartnerApiClient:            partnerApiClientLayer,
 		HealthApiClient:             healthApiClientLayer,
 		SlackClient:                 slackClientLayer,
@@ -243,8 +273,12 @@ func Start() error {
 		Cache:  cacheLayer,
 		Sentry: sentryLayer,
 
-		EtsService:    etsServiceLayer,
-		SampleService: sampleServiceLayer,
+		EtsService:            etsServiceLayer,
+		SampleService:         sampleServiceLayer,
+		CriticalCallService:   criticalCallServiceLayer,
+		TaskAssignmentService: taskAssignmentServiceLayer,
+		OutboxService:         outboxServiceLayer,
+		TaskEventsService:     taskEventsServiceLayer,
 	}
 
 	// Register tasks
@@ -255,11 +289,16 @@ func Start() error {
 		constants.SampleRejectionTask:              wtService.SampleRejectionTask,
 		constants.UpdateTaskPostReceivingTask:      wtService.UpdateTaskPostReceivingTask,
 		constants.CreateUpdateTaskByOmsOrderIdTask: wtService.CreateUpdateTaskByOmsOrderIdTask,
+		constants.ReplayDeadLetterEventTask:        wtService.ReplayDeadLetterEventTask,
+		constants.DeviceResultsTask:                wtService.DeviceResultsTask,
 
 		// Periodic tasks
 		constants.StaleTasksPeriodicTask:                        wtpService.StaleTasksPeriodicTask,
 		constants.EtsTatBreachedPeriodicTaskName:                wtpService.EtsTatBreachedPeriodicTask,
 		constants.SlackAlertForReverseLogisticsPeriodicTaskName: wtpService.SlackAlertForDelayedReverseLogisticsPeriodicTask,
+		constants.CriticalCallEscalationPeriodicTaskName:        wtpService.CriticalCallEscalationPeriodicTask,
+		constants.TaskAutoAssignmentPeriodicTaskName:            wtpService.TaskAutoAssignmentPeriodicTask,
+		constants.OutboxRelayPeriodicTaskName:                   wtpService.OutboxRelayPeriodicTask,
 	}
 
 	err = taskServer.RegisterTasks(tasksToBeRegistered)
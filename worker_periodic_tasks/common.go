@@ -5,8 +5,12 @@ import (
 
 	"github.com/Orange-Health/citadel/adapters/cache"
 	"github.com/Orange-Health/citadel/adapters/sentry"
+	criticalCallService "github.com/Orange-Health/citadel/apps/critical_calls/service"
 	etsService "github.com/Orange-Health/citadel/apps/ets/service"
+	outboxService "github.com/Orange-Health/citadel/apps/outbox/service"
 	sampleService "github.com/Orange-Health/citadel/apps/samples/service"
+	taskAssignmentService "github.com/Orange-Health/citadel/apps/task_assignment/service"
+	taskEventsService "github.com/Orange-Health/citadel/apps/task_events/service"
 )
 
 type WorkerPeriodicTaskService struct {
@@ -14,6 +18,10 @@ type WorkerPeriodicTaskService struct {
 	Cache  cache.CacheLayer
 	Sentry sentry.SentryLayer
 
-	EtsService    etsService.EtsServiceInterface
-	SampleService sampleService.SampleServiceInterface
+	EtsService            etsService.EtsServiceInterface
+	SampleService         sampleService.SampleServiceInterface
+	CriticalCallService   criticalCallService.CriticalCallServiceInterface
+	TaskAssignmentService taskAssignmentService.TaskAssignmentServiceInterface
+	OutboxService         outboxService.OutboxServiceInterface
+	TaskEventsService     taskEventsService.TaskEventsServiceInterface
 }
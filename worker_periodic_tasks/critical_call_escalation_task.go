@@ -0,0 +1,27 @@
+package workerPeriodicTasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+func (s *WorkerPeriodicTaskService) CriticalCallEscalationPeriodicTask() error {
+	ctx := context.Background()
+	s.CriticalCallService.EscalateOverdueCriticalCalls(ctx)
+	return nil
+}
+
+func CriticalCallEscalationPeriodicTaskSignature() *tasks.Signature {
+	groupId := fmt.Sprintf("%s:%v", constants.CriticalCallEscalationPeriodicTaskName, time.Now().Unix())
+	return &tasks.Signature{
+		Name:                 constants.CriticalCallEscalationPeriodicTaskName,
+		Args:                 nil,
+		RoutingKey:           constants.WorkerDefaultQueue,
+		BrokerMessageGroupId: groupId,
+	}
+}
@@ -0,0 +1,27 @@
+package workerPeriodicTasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+func (s *WorkerPeriodicTaskService) OutboxRelayPeriodicTask() error {
+	ctx := context.Background()
+	s.OutboxService.RelayPendingMessages(ctx)
+	return nil
+}
+
+func OutboxRelayPeriodicTaskSignature() *tasks.Signature {
+	groupId := fmt.Sprintf("%s:%v", constants.OutboxRelayPeriodicTaskName, time.Now().Unix())
+	return &tasks.Signature{
+		Name:                 constants.OutboxRelayPeriodicTaskName,
+		Args:                 nil,
+		RoutingKey:           constants.WorkerDefaultQueue,
+		BrokerMessageGroupId: groupId,
+	}
+}
@@ -19,9 +19,11 @@ func (s *WorkerPeriodicTaskService) StaleTasksPeriodicTask() error {
 	taskIdPreviousStateMap := []structures.TaskIdPreviousStateStruct{}
 	s.Db.WithContext(ctx).Table(constants.TableTasks).
 		Joins("INNER JOIN task_metadata ON tasks.id = task_metadata.task_id").
+		Joins("LEFT JOIN task_pathologist_mapping ON tasks.id = task_pathologist_mapping.task_id AND "+
+			"task_pathologist_mapping.is_active AND task_pathologist_mapping.deleted_at IS NULL").
 		Where("tasks.status = ?", constants.TASK_STATUS_IN_PROGRESS).
 		Where("task_metadata.last_event_sent_at < ?", time.Now().Add(time.Duration(-1*constants.StaleTaskDuration)*time.Minute)).
-		Select("tasks.id as task_id, tasks.previous_status").
+		Select("tasks.id as task_id, tasks.previous_status, task_pathologist_mapping.pathologist_id").
 		Find(&taskIdPreviousStateMap)
 
 	currentTime := time.Now()
@@ -37,14 +39,14 @@ func (s *WorkerPeriodicTaskService) StaleTasksPeriodicTask() error {
 		for _, taskStruct := range taskIdPreviousStateMap {
 			if taskStruct.PreviousStatus != "" {
 				taskUpdates["status"] = taskStruct.PreviousStatus
-				err := s.Db.WithContext(ctx).Table(constants.TableTasks).
+				err := tx.WithContext(ctx).Table(constants.TableTasks).
 					Where("id = ?", taskStruct.TaskId).
 					Updates(taskUpdates).Error
 				if err != nil {
 					return err
 				}
 
-				err = s.Db.WithContext(ctx).Table(constants.TableTaskPathologistMapping).
+				err = tx.WithContext(ctx).Table(constants.TableTaskPathologistMapping).
 					Where("task_id = ?", taskStruct.TaskId).
 					Updates(taskPathologistUpdates).Error
 				if err != nil {
@@ -60,6 +62,17 @@ func (s *WorkerPeriodicTaskService) StaleTasksPeriodicTask() error {
 		return err
 	}
 
+	// Hand the reverted tasks to another pathologist instead of waiting for them to be picked up again
+	stalePathologistIdByTaskId := map[uint]uint{}
+	for _, taskStruct := range taskIdPreviousStateMap {
+		if taskStruct.PreviousStatus != "" {
+			stalePathologistIdByTaskId[taskStruct.TaskId] = taskStruct.PathologistId
+			s.TaskEventsService.PublishTaskEvent(ctx, constants.TaskEventReleased, taskStruct.TaskId,
+				taskStruct.PathologistId)
+		}
+	}
+	s.TaskAssignmentService.ReassignStaleTasks(ctx, stalePathologistIdByTaskId)
+
 	return nil
 }
 
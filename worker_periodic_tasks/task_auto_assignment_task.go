@@ -0,0 +1,27 @@
+package workerPeriodicTasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+
+	"github.com/Orange-Health/citadel/common/constants"
+)
+
+func (s *WorkerPeriodicTaskService) TaskAutoAssignmentPeriodicTask() error {
+	ctx := context.Background()
+	s.TaskAssignmentService.AutoAssignPendingTasks(ctx)
+	return nil
+}
+
+func TaskAutoAssignmentPeriodicTaskSignature() *tasks.Signature {
+	groupId := fmt.Sprintf("%s:%v", constants.TaskAutoAssignmentPeriodicTaskName, time.Now().Unix())
+	return &tasks.Signature{
+		Name:                 constants.TaskAutoAssignmentPeriodicTaskName,
+		Args:                 nil,
+		RoutingKey:           constants.WorkerDefaultQueue,
+		BrokerMessageGroupId: groupId,
+	}
+}
@@ -7,25 +7,34 @@ import (
 	"github.com/Orange-Health/citadel/adapters/sentry"
 	abnormalityService "github.com/Orange-Health/citadel/apps/abnormality/service"
 	attachmentsService "github.com/Orange-Health/citadel/apps/attachments/service"
-	attuneService "github.com/Orange-Health/citadel/apps/attune/service"
+	autoVerificationService "github.com/Orange-Health/citadel/apps/auto_verification/service"
 	cdsService "github.com/Orange-Health/citadel/apps/cds/service"
 	contactService "github.com/Orange-Health/citadel/apps/contact/service"
+	criticalCallService "github.com/Orange-Health/citadel/apps/critical_calls/service"
+	cultureResultsService "github.com/Orange-Health/citadel/apps/culture_results/service"
+	deadLetterService "github.com/Orange-Health/citadel/apps/dead_letters/service"
+	deltaCheckService "github.com/Orange-Health/citadel/apps/delta_check/service"
 	etsService "github.com/Orange-Health/citadel/apps/ets/service"
+	eventLedgerService "github.com/Orange-Health/citadel/apps/event_ledger/service"
 	investigationResultsService "github.com/Orange-Health/citadel/apps/investigation_results/service"
+	lisService "github.com/Orange-Health/citadel/apps/lis/service"
 	orderDetailsService "github.com/Orange-Health/citadel/apps/order_details/service"
+	outboxService "github.com/Orange-Health/citadel/apps/outbox/service"
 	patientDetailService "github.com/Orange-Health/citadel/apps/patient_details/service"
 	pubsubService "github.com/Orange-Health/citadel/apps/pubsub/service"
+	qcService "github.com/Orange-Health/citadel/apps/qc/service"
 	receivingDeskService "github.com/Orange-Health/citadel/apps/receiving_desk/service"
+	reflexRulesService "github.com/Orange-Health/citadel/apps/reflex_rules/service"
 	remarksService "github.com/Orange-Health/citadel/apps/remarks/service"
 	reportGenerationService "github.com/Orange-Health/citadel/apps/report_generation/service"
 	rerunService "github.com/Orange-Health/citadel/apps/rerun/service"
 	sampleService "github.com/Orange-Health/citadel/apps/samples/service"
 	taskService "github.com/Orange-Health/citadel/apps/task/service"
+	taskEventsService "github.com/Orange-Health/citadel/apps/task_events/service"
 	taskPathMappingService "github.com/Orange-Health/citadel/apps/task_pathologist_mapping/service"
 	testDetailService "github.com/Orange-Health/citadel/apps/test_detail/service"
 	testSampleMappingService "github.com/Orange-Health/citadel/apps/test_sample_mapping/service"
 	userService "github.com/Orange-Health/citadel/apps/users/service"
-	attuneClient "github.com/Orange-Health/citadel/clients/attune"
 	cdsClient "github.com/Orange-Health/citadel/clients/cds"
 	healthApiClient "github.com/Orange-Health/citadel/clients/health_api"
 	omsClient "github.com/Orange-Health/citadel/clients/oms"
@@ -34,7 +43,6 @@ import (
 	s3Client "github.com/Orange-Health/citadel/clients/s3"
 	s3wrapperClient "github.com/Orange-Health/citadel/clients/s3wrapper"
 	slackClient "github.com/Orange-Health/citadel/clients/slack"
-	snsClient "github.com/Orange-Health/citadel/clients/sns"
 	commonTasks "github.com/Orange-Health/citadel/common_tasks"
 )
 
@@ -49,7 +57,7 @@ type WorkerTaskService struct {
 	SampleService               sampleService.SampleServiceInterface
 	ReceivingDeskService        receivingDeskService.ReceivingDeskServiceInterface
 	TestSampleMappingService    testSampleMappingService.TestSampleMappingServiceInterface
-	AttuneService               attuneService.AttuneServiceInterface
+	LisService                  lisService.LisServiceInterface
 	TaskService                 taskService.TaskServiceInterface
 	TaskPathMappingService      taskPathMappingService.TaskPathologistMappingServiceInterface
 	PatientDetailService        patientDetailService.PatientDetailServiceInterface
@@ -65,15 +73,23 @@ type WorkerTaskService struct {
 	EtsService                  etsService.EtsServiceInterface
 	CdsService                  cdsService.CdsServiceInterface
 	PubsubService               pubsubService.PubsubInterface
+	DeltaCheckService           deltaCheckService.DeltaCheckServiceInterface
+	QcService                   qcService.QcServiceInterface
+	AutoVerificationService     autoVerificationService.AutoVerificationServiceInterface
+	DeadLetterService           deadLetterService.DeadLetterServiceInterface
+	EventLedgerService          eventLedgerService.EventLedgerServiceInterface
+	CultureResultsService       cultureResultsService.CultureResultsServiceInterface
+	ReflexRulesService          reflexRulesService.ReflexRulesServiceInterface
+	CriticalCallService         criticalCallService.CriticalCallServiceInterface
+	TaskEventsService           taskEventsService.TaskEventsServiceInterface
+	OutboxService               outboxService.OutboxServiceInterface
 
 	// Clients
-	AttuneClient           attuneClient.AttuneClientInterface
 	CdsClient              cdsClient.CdsClientInterface
 	OmsClient              omsClient.OmsClientInterface
 	ReportRebrandingClient reportRebrandingClient.ReportRebrandingClientInterface
 	S3wrapperClient        s3wrapperClient.S3wrapperInterface
 	S3Client               s3Client.S3ClientInterface
-	SnsClient              snsClient.SnsClientInterface
 	PartnerApiClient       partnerApiClient.PartnerApiClientInterface
 	HealthApiClient        healthApiClient.HealthApiClientInterface
 	SlackClient            slackClient.SlackClientInterface
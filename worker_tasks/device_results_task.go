@@ -0,0 +1,371 @@
+package workerTasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+	"gorm.io/gorm"
+
+	autoVerificationMapper "github.com/Orange-Health/citadel/apps/auto_verification/mapper"
+	autoVerificationStructures "github.com/Orange-Health/citadel/apps/auto_verification/structures"
+	deltaCheckMapper "github.com/Orange-Health/citadel/apps/delta_check/mapper"
+	deltaCheckStructures "github.com/Orange-Health/citadel/apps/delta_check/structures"
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/structures"
+	"github.com/Orange-Health/citadel/common/utils"
+	"github.com/Orange-Health/citadel/models"
+)
+
+// DeviceResultsTask writes the values an analyzer reported for a sample into the pending investigation results
+// of the tests on that sample. Investigations are matched on their LIS code and anything already entered
+// manually, approved or sent for rerun is left untouched. The saved results go through criticality, delta
+// checks and auto verification like manual and LIS entries, and the tasks are then updated post saving.
+func (wt *WorkerTaskService) DeviceResultsTask(deviceSampleResultsPayload string) error {
+	ctx := context.Background()
+
+	deviceSampleResults := structures.DeviceSampleResults{}
+	err := json.Unmarshal([]byte(deviceSampleResultsPayload), &deviceSampleResults)
+	if err != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, err)
+		return nil
+	}
+
+	loggingAttributes := map[string]interface{}{
+		"barcode":   deviceSampleResults.Barcode,
+		"device_id": deviceSampleResults.DeviceId,
+	}
+
+	sample, cErr := wt.SampleService.GetSampleByBarcodeForReceiving(deviceSampleResults.Barcode)
+	if cErr != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes,
+			errors.New(cErr.Message))
+		return errors.New(cErr.Message)
+	}
+	if sample.Id == 0 {
+		utils.AddLog(ctx, constants.WARN_LEVEL, constants.ERROR_DEVICE_RESULTS_SAMPLE_NOT_FOUND, loggingAttributes, nil)
+		return nil
+	}
+
+	testDetails, cErr := wt.SampleService.GetTestDetailsBySampleIds([]uint{sample.Id})
+	if cErr != nil {
+		return errors.New(cErr.Message)
+	}
+
+	testDetailsIds, testDetailsIdToTaskIdMap := []uint{}, map[uint]uint{}
+	for _, testDetail := range testDetails {
+		testDetailsIds = append(testDetailsIds, testDetail.Id)
+		testDetailsIdToTaskIdMap[testDetail.Id] = testDetail.TaskId
+	}
+	if len(testDetailsIds) == 0 {
+		return nil
+	}
+
+	investigationResults, cErr := wt.InvestigationResultsService.GetInvestigationResultsByTestDetailsIds(testDetailsIds)
+	if cErr != nil {
+		return errors.New(cErr.Message)
+	}
+
+	testCodeToDeviceResultMap := map[string]structures.DeviceResult{}
+	for _, deviceResult := range deviceSampleResults.Results {
+		testCodeToDeviceResultMap[strings.ToUpper(deviceResult.TestCode)] = deviceResult
+	}
+
+	currentTime := utils.GetCurrentTime()
+	investigationResultsToBeUpdated := []models.InvestigationResult{}
+	for _, investigationResult := range investigationResults {
+		deviceResult, ok := testCodeToDeviceResultMap[strings.ToUpper(investigationResult.LisCode)]
+		if !ok || deviceResult.Value == "" {
+			continue
+		}
+		if !utils.SliceContainsString(constants.INVESTIGATION_STATUSES_PENDING, investigationResult.InvestigationStatus) {
+			continue
+		}
+		if investigationResult.InvestigationValue != "" &&
+			investigationResult.MethodType != constants.METHOD_TYPE_DEVICE_MEASURED {
+			continue
+		}
+
+		investigationResult.DeviceValue = deviceResult.Value
+		investigationResult.InvestigationValue = deviceResult.Value
+		investigationResult.MethodType = constants.METHOD_TYPE_DEVICE_MEASURED
+		investigationResult.Uom = utils.GetNonEmptyString(investigationResult.Uom, deviceResult.Unit)
+		investigationResult.EnteredBy = constants.CitadelSystemId
+		investigationResult.EnteredAt = currentTime
+		investigationResult.UpdatedBy = constants.CitadelSystemId
+
+		abnormality, cErr := wt.InvestigationResultsService.GetInvestigationAbnormality(ctx,
+			testDetailsIdToTaskIdMap[investigationResult.TestDetailsId], investigationResult.LisCode, deviceResult.Value)
+		if cErr != nil {
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes,
+				errors.New(cErr.Message))
+			// The flags of the previous value no longer apply to the new one.
+			investigationResult.Abnormality = ""
+			investigationResult.IsAbnormal = false
+			investigationResult.IsCritical = false
+		} else {
+			investigationResult.Abnormality = abnormality
+			investigationResult.IsAbnormal = utils.SliceContainsString(constants.OhAbnormalityStringSlice, abnormality)
+			investigationResult.IsCritical = utils.SliceContainsString(constants.OhCriticalityStringSlice, abnormality)
+		}
+
+		investigationResultsToBeUpdated = append(investigationResultsToBeUpdated, investigationResult)
+	}
+
+	if len(investigationResultsToBeUpdated) == 0 {
+		return nil
+	}
+
+	investigationResultsToBeUpdated, createInvestigationsMetadata, updateInvestigationsMetadata,
+		existingMetadataMap := wt.evaluateDeviceResults(ctx, investigationResultsToBeUpdated, testDetailsIdToTaskIdMap,
+		loggingAttributes)
+
+	criticalTestDetailsMetadata, criticalTasksMetadata, cErr := wt.getDeviceResultsCriticalMetadata(
+		investigationResults, investigationResultsToBeUpdated, testDetailsIdToTaskIdMap)
+	if cErr != nil {
+		return errors.New(cErr.Message)
+	}
+
+	err = wt.Db.Transaction(func(tx *gorm.DB) error {
+		_, cErr := wt.InvestigationResultsService.UpdateInvestigationResultsWithTx(tx, investigationResultsToBeUpdated)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
+		_, cErr = wt.InvestigationResultsService.CreateInvestigationResultsMetadataWithTx(ctx, tx,
+			createInvestigationsMetadata)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
+		_, cErr = wt.InvestigationResultsService.UpdateInvestigationResultsMetadataWithTx(ctx, tx,
+			updateInvestigationsMetadata, existingMetadataMap)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
+		if len(criticalTestDetailsMetadata) > 0 {
+			_, cErr = wt.TestDetailService.UpdateTestDetailsMetadataWithTx(tx, criticalTestDetailsMetadata)
+			if cErr != nil {
+				return errors.New(cErr.Message)
+			}
+		}
+
+		for _, taskMetadata := range criticalTasksMetadata {
+			_, cErr = wt.TaskService.UpdateTaskMetadataWithTx(tx, taskMetadata)
+			if cErr != nil {
+				return errors.New(cErr.Message)
+			}
+		}
+
+		cErr = wt.ReflexRulesService.EvaluateReflexRulesWithTx(ctx, tx, testDetails, investigationResultsToBeUpdated,
+			constants.CitadelSystemId)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+
+		cErr = wt.CriticalCallService.CreateCriticalCallsWithTx(ctx, tx, testDetails, investigationResultsToBeUpdated)
+		if cErr != nil {
+			return errors.New(cErr.Message)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, constants.ERROR_WHILE_INGESTING_DEVICE_RESULTS, loggingAttributes, err)
+		return err
+	}
+
+	taskIds := []uint{}
+	for _, investigationResult := range investigationResultsToBeUpdated {
+		taskIds = append(taskIds, testDetailsIdToTaskIdMap[investigationResult.TestDetailsId])
+	}
+	for _, taskId := range utils.CreateUniqueSliceUint(taskIds) {
+		if err := wt.UpdateTaskPostSavingTask(taskId); err != nil {
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes, err)
+		}
+	}
+
+	return nil
+}
+
+// evaluateDeviceResults runs the delta checks and auto verification for the device results of every task and
+// returns them with the investigation result metadata to be created and updated. Failures are only logged, the
+// results are then saved without being auto approved.
+func (wt *WorkerTaskService) evaluateDeviceResults(ctx context.Context,
+	investigationResults []models.InvestigationResult, testDetailsIdToTaskIdMap map[uint]uint,
+	loggingAttributes map[string]interface{}) ([]models.InvestigationResult, []models.InvestigationResultMetadata,
+	[]models.InvestigationResultMetadata, map[uint]models.InvestigationResultMetadata) {
+
+	createInvestigationsMetadata, updateInvestigationsMetadata :=
+		[]models.InvestigationResultMetadata{}, []models.InvestigationResultMetadata{}
+
+	taskIdInvestigationResultsMap, investigationResultIds := map[uint][]models.InvestigationResult{}, []uint{}
+	for _, investigationResult := range investigationResults {
+		taskId := testDetailsIdToTaskIdMap[investigationResult.TestDetailsId]
+		taskIdInvestigationResultsMap[taskId] = append(taskIdInvestigationResultsMap[taskId], investigationResult)
+		investigationResultIds = append(investigationResultIds, investigationResult.Id)
+	}
+
+	existingMetadataMap, cErr := wt.InvestigationResultsService.GetInvestigationResultsMetadataByInvestigationResultIds(
+		ctx, investigationResultIds)
+	if cErr != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes,
+			errors.New(cErr.Message))
+		return investigationResults, createInvestigationsMetadata, updateInvestigationsMetadata,
+			map[uint]models.InvestigationResultMetadata{}
+	}
+
+	autoVerificationResults := map[uint]autoVerificationStructures.AutoVerificationResult{}
+	deltaCheckResults := map[uint]deltaCheckStructures.DeltaCheckResult{}
+	for taskId, taskInvestigationResults := range taskIdInvestigationResultsMap {
+		taskAutoVerificationResults, taskDeltaCheckResults, cErr :=
+			wt.AutoVerificationService.EvaluateAutoVerificationForTask(ctx, taskId, taskInvestigationResults)
+		if cErr != nil {
+			utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes,
+				errors.New(cErr.Message))
+			continue
+		}
+		for investigationResultId, autoVerificationResult := range taskAutoVerificationResults {
+			autoVerificationResults[investigationResultId] = autoVerificationResult
+		}
+		for investigationResultId, deltaCheckResult := range taskDeltaCheckResults {
+			deltaCheckResults[investigationResultId] = deltaCheckResult
+		}
+	}
+
+	for index, investigationResult := range investigationResults {
+		autoVerificationResult, ok := autoVerificationResults[investigationResult.Id]
+		if !ok {
+			continue
+		}
+		investigationResults[index].IsAutoApproved = autoVerificationResult.IsAutoApproved
+		investigationResults[index].ApprovalSource = autoVerificationResult.ApprovalSource
+		investigationResults[index].AutoApprovalFailureReason = autoVerificationResult.AutoApprovalFailureReason
+
+		investigationMetadata, exists := existingMetadataMap[investigationResult.Id]
+		investigationMetadata = deltaCheckMapper.MapDeltaCheckResultToMetadata(
+			deltaCheckResults[investigationResult.Id], investigationMetadata)
+		investigationMetadata = autoVerificationMapper.MapAutoVerificationResultToMetadata(autoVerificationResult,
+			investigationMetadata)
+		investigationMetadata.UpdatedBy = constants.CitadelSystemId
+		if exists {
+			updateInvestigationsMetadata = append(updateInvestigationsMetadata, investigationMetadata)
+			continue
+		}
+		investigationMetadata.InvestigationResultId = investigationResult.Id
+		investigationMetadata.CreatedBy = constants.CitadelSystemId
+		createInvestigationsMetadata = append(createInvestigationsMetadata, investigationMetadata)
+	}
+
+	return investigationResults, createInvestigationsMetadata, updateInvestigationsMetadata, existingMetadataMap
+}
+
+// getDeviceResultsCriticalMetadata recomputes the criticality of the tests and tasks of the device results, from
+// all their investigations with the device results applied, and returns the metadata whose criticality changed.
+func (wt *WorkerTaskService) getDeviceResultsCriticalMetadata(investigationResults,
+	updatedInvestigationResults []models.InvestigationResult, testDetailsIdToTaskIdMap map[uint]uint) (
+	[]models.TestDetailsMetadata, []models.TaskMetadata, *structures.CommonError) {
+
+	testDetailsMetadataToBeUpdated, tasksMetadataToBeUpdated := []models.TestDetailsMetadata{}, []models.TaskMetadata{}
+
+	updatedInvestigationResultsMap := map[uint]models.InvestigationResult{}
+	testDetailsIds, taskIds := []uint{}, []uint{}
+	for _, investigationResult := range updatedInvestigationResults {
+		updatedInvestigationResultsMap[investigationResult.Id] = investigationResult
+		testDetailsIds = append(testDetailsIds, investigationResult.TestDetailsId)
+		taskIds = append(taskIds, testDetailsIdToTaskIdMap[investigationResult.TestDetailsId])
+	}
+	if len(testDetailsIds) == 0 {
+		return testDetailsMetadataToBeUpdated, tasksMetadataToBeUpdated, nil
+	}
+
+	testDetailsIdCriticalityMap := map[uint]bool{}
+	for _, investigationResult := range investigationResults {
+		if updatedInvestigationResult, ok := updatedInvestigationResultsMap[investigationResult.Id]; ok {
+			investigationResult = updatedInvestigationResult
+		}
+		testDetailsIdCriticalityMap[investigationResult.TestDetailsId] =
+			testDetailsIdCriticalityMap[investigationResult.TestDetailsId] || investigationResult.IsCritical
+	}
+
+	testDetailsMetadata, cErr := wt.TestDetailService.GetTestDetailsMetadataByTestDetailIds(
+		utils.CreateUniqueSliceUint(testDetailsIds))
+	if cErr != nil {
+		return testDetailsMetadataToBeUpdated, tasksMetadataToBeUpdated, cErr
+	}
+	for _, testDetailMetadata := range testDetailsMetadata {
+		isCritical := testDetailsIdCriticalityMap[testDetailMetadata.TestDetailsId]
+		if testDetailMetadata.IsCritical == isCritical {
+			continue
+		}
+		testDetailMetadata.IsCritical = isCritical
+		testDetailMetadata.UpdatedBy = constants.CitadelSystemId
+		testDetailsMetadataToBeUpdated = append(testDetailsMetadataToBeUpdated, testDetailMetadata)
+	}
+
+	for _, taskId := range utils.CreateUniqueSliceUint(taskIds) {
+		taskTestDetails, cErr := wt.TestDetailService.GetTestDetailsByTaskId(taskId)
+		if cErr != nil {
+			return testDetailsMetadataToBeUpdated, tasksMetadataToBeUpdated, cErr
+		}
+		taskTestDetailsIds := []uint{}
+		for _, testDetail := range taskTestDetails {
+			taskTestDetailsIds = append(taskTestDetailsIds, testDetail.Id)
+		}
+		taskTestDetailsMetadata, cErr := wt.TestDetailService.GetTestDetailsMetadataByTestDetailIds(
+			taskTestDetailsIds)
+		if cErr != nil {
+			return testDetailsMetadataToBeUpdated, tasksMetadataToBeUpdated, cErr
+		}
+
+		isTaskCritical := false
+		for _, testDetailMetadata := range taskTestDetailsMetadata {
+			isCritical, ok := testDetailsIdCriticalityMap[testDetailMetadata.TestDetailsId]
+			if !ok {
+				isCritical = testDetailMetadata.IsCritical
+			}
+			if isCritical {
+				isTaskCritical = true
+				break
+			}
+		}
+
+		taskMetadata, cErr := wt.TaskService.GetTaskMetadataByTaskId(taskId)
+		if cErr != nil {
+			return testDetailsMetadataToBeUpdated, tasksMetadataToBeUpdated, cErr
+		}
+		if taskMetadata.IsCritical == isTaskCritical {
+			continue
+		}
+		taskMetadata.IsCritical = isTaskCritical
+		taskMetadata.UpdatedBy = constants.CitadelSystemId
+		tasksMetadataToBeUpdated = append(tasksMetadataToBeUpdated, taskMetadata)
+	}
+
+	return testDetailsMetadataToBeUpdated, tasksMetadataToBeUpdated, nil
+}
+
+func DeviceResultsTaskSignature(deviceSampleResults structures.DeviceSampleResults) (*tasks.Signature, error) {
+	deviceSampleResultsPayload, err := json.Marshal(deviceSampleResults)
+	if err != nil {
+		return nil, err
+	}
+
+	groupId := fmt.Sprintf("%s:%s:%v", constants.DeviceResultsTask, deviceSampleResults.Barcode, time.Now().Unix())
+	return &tasks.Signature{
+		Name: constants.DeviceResultsTask,
+		Args: []tasks.Arg{
+			{
+				Type:  "string",
+				Value: string(deviceSampleResultsPayload),
+				Name:  "deviceSampleResultsPayload",
+			},
+		},
+		RoutingKey:           constants.WorkerDefaultQueue,
+		BrokerMessageGroupId: groupId,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/RichardKnop/machinery/v1/tasks"
@@ -17,14 +18,21 @@ import (
 	consumerTasks "github.com/Orange-Health/citadel/consumer_tasks"
 )
 
-func (wt *WorkerTaskService) EventHandlerTask(eventType, traceId, contains, eventKey string) error {
-	ctx := context.Background()
+// errUnknownEventType is returned for the events that have no handler, they are not retried.
+var errUnknownEventType = errors.New(constants.DeadLetterUnknownEventType)
+
+func (wt *WorkerTaskService) EventHandlerTask(ctx context.Context, eventType, traceId, contains,
+	eventKey string) error {
 	startTime := time.Now()
 
 	var eventPayloadInterface interface{}
 	err := wt.Cache.Get(ctx, eventKey, &eventPayloadInterface)
 	if err != nil {
 		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(), nil, err)
+		if attempts, isLastAttempt := getEventAttempts(ctx, eventType); isLastAttempt {
+			wt.DeadLetterService.RecordDeadLetterEvent(ctx, eventType, traceId, contains, eventKey, "", attempts,
+				err)
+		}
 		return err
 	}
 	eventPayload := eventPayloadInterface.(string)
@@ -36,9 +44,49 @@ func (wt *WorkerTaskService) EventHandlerTask(eventType, traceId, contains, even
 	}
 	utils.AddLog(ctx, constants.DEBUG_LEVEL, utils.GetCurrentFunctionName(), loggingAttributes, nil)
 
-	sentryInstance := sentry.InitializeSentry()
+	err = wt.processEvent(ctx, eventType, eventPayload, getEventSentAt(ctx))
+	if errors.Is(err, errUnknownEventType) {
+		sentryInstance := sentry.InitializeSentry()
+		sentryInstance.LogError(ctx, "Unknown event type", nil, loggingAttributes)
+		wt.DeadLetterService.RecordDeadLetterEvent(ctx, eventType, traceId, contains, eventKey, eventPayload, 1,
+			err)
+		_ = wt.Cache.Delete(ctx, eventKey)
+		return nil
+	}
+
+	if err != nil {
+		utils.AddLog(ctx, constants.DEBUG_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
+			"event_type":    eventType,
+			"event_payload": eventPayload,
+			"trace_id":      traceId,
+			"contains":      contains,
+			"redis_key":     eventKey,
+		}, err)
+		if attempts, isLastAttempt := getEventAttempts(ctx, eventType); isLastAttempt {
+			wt.DeadLetterService.RecordDeadLetterEvent(ctx, eventType, traceId, contains, eventKey, eventPayload,
+				attempts, err)
+		}
+		return err
+	}
+
+	_ = wt.Cache.Delete(ctx, eventKey)
+	utils.AddLog(ctx, constants.INFO_LEVEL, utils.GetCurrentFunctionName(),
+		map[string]interface{}{
+			"total_time_taken": time.Since(startTime).String(),
+			"event_type":       eventType,
+		}, nil)
 
+	return nil
+}
+
+// processEvent hands the payload to the handler of its event type. The events tracked in the event ledger are
+// checked against it first, so that redelivered and outdated events are not processed again.
+func (wt *WorkerTaskService) processEvent(ctx context.Context, eventType, eventPayload string,
+	eventSentAt *time.Time) error {
 	eventProcessor := consumerTasks.EventProcessor{
+		EventType:   eventType,
+		EventSentAt: eventSentAt,
+
 		Db:                          wt.Db,
 		Cache:                       wt.Cache,
 		Sentry:                      wt.Sentry,
@@ -46,7 +94,7 @@ func (wt *WorkerTaskService) EventHandlerTask(eventType, traceId, contains, even
 		SampleService:               wt.SampleService,
 		ReceivingDeskService:        wt.ReceivingDeskService,
 		TestSampleMappingService:    wt.TestSampleMappingService,
-		AttuneService:               wt.AttuneService,
+		LisService:                  wt.LisService,
 		OrderDetailsService:         wt.OrderDetailsService,
 		TaskPathMappingService:      wt.TaskPathMappingService,
 		PatientDetailService:        wt.PatientDetailService,
@@ -62,12 +110,18 @@ func (wt *WorkerTaskService) EventHandlerTask(eventType, traceId, contains, even
 		EtsService:                  wt.EtsService,
 		CdsService:                  wt.CdsService,
 		PubsubService:               wt.PubsubService,
-		AttuneClient:                wt.AttuneClient,
+		DeltaCheckService:           wt.DeltaCheckService,
+		QcService:                   wt.QcService,
+		AutoVerificationService:     wt.AutoVerificationService,
+		EventLedgerService:          wt.EventLedgerService,
+		CultureResultsService:       wt.CultureResultsService,
+		ReflexRulesService:          wt.ReflexRulesService,
+		CriticalCallService:         wt.CriticalCallService,
+		OutboxService:               wt.OutboxService,
 		CdsClient:                   wt.CdsClient,
 		ReportRebrandingClient:      wt.ReportRebrandingClient,
 		S3wrapperClient:             wt.S3wrapperClient,
 		S3Client:                    wt.S3Client,
-		SnsClient:                   wt.SnsClient,
 		PartnerApiClient:            wt.PartnerApiClient,
 		HealthApiClient:             wt.HealthApiClient,
 		SlackClient:                 wt.SlackClient,
@@ -76,60 +130,85 @@ func (wt *WorkerTaskService) EventHandlerTask(eventType, traceId, contains, even
 
 	switch eventType {
 	case constants.OmsAttachmentEvent:
-		err = eventProcessor.OmsAttachmentEventTask(ctx, eventPayload)
+		return eventProcessor.OmsAttachmentEventTask(ctx, eventPayload)
 	case constants.OmsManualReportUploadEvent:
-		err = eventProcessor.OmsManualReportUploadEventTask(ctx, eventPayload)
+		return eventProcessor.OmsManualReportUploadEventTask(ctx, eventPayload)
 	case constants.OmsTestDeleteEvent:
-		err = eventProcessor.OmsTestDeleteEventTask(ctx, eventPayload)
+		return eventProcessor.OmsTestDeleteEventTask(ctx, eventPayload)
 	case constants.OmsOrderCreatedEvent:
-		err = eventProcessor.OmsOrderCreateUpdateEventTask(ctx, eventPayload)
+		return eventProcessor.ProcessWithEventLedger(ctx, eventPayload, eventProcessor.OmsOrderCreateUpdateEventTask)
 	case constants.OmsOrderUpdatedEvent:
-		err = eventProcessor.OmsOrderCreateUpdateEventTask(ctx, eventPayload)
+		return eventProcessor.ProcessWithEventLedger(ctx, eventPayload, eventProcessor.OmsOrderCreateUpdateEventTask)
 	case constants.OmsOrderCompletedEvent:
-		err = eventProcessor.OmsOrderCompletedEventTask(ctx, eventPayload)
+		return eventProcessor.OmsOrderCompletedEventTask(ctx, eventPayload)
 	case constants.OmsOrderCancelledEvent:
-		err = eventProcessor.OmsOrderCancelledEventTask(ctx, eventPayload)
+		return eventProcessor.OmsOrderCancelledEventTask(ctx, eventPayload)
 	case constants.MergeContactEvent:
-		err = eventProcessor.MergeContactEventTask(ctx, eventPayload)
+		return eventProcessor.MergeContactEventTask(ctx, eventPayload)
 	case constants.SampleCollectedEvent:
-		err = eventProcessor.SampleCollectedEventTask(ctx, eventPayload)
+		return eventProcessor.SampleCollectedEventTask(ctx, eventPayload)
 	case constants.LisEvent:
-		err = eventProcessor.LisEventTask(ctx, eventPayload)
+		return eventProcessor.ProcessWithEventLedger(ctx, eventPayload, eventProcessor.LisEventTask)
 	case constants.OrderReportPdfReadyEvent:
-		err = eventProcessor.OrderReportPdfReadyEventTask(ctx, eventPayload)
+		return eventProcessor.ProcessWithEventLedger(ctx, eventPayload, eventProcessor.OrderReportPdfReadyEventTask)
 	case constants.SampleRecollectionEvent:
-		err = eventProcessor.SampleRecollectionEventTask(ctx, eventPayload)
+		return eventProcessor.SampleRecollectionEventTask(ctx, eventPayload)
 	case constants.TestDetailsEvent:
-		err = eventProcessor.TestDetailsEventTask(ctx, eventPayload)
+		return eventProcessor.TestDetailsEventTask(ctx, eventPayload)
 	case constants.MarkSampleSnrEvent:
-		err = eventProcessor.MarkSampleSnrEventTask(ctx, eventPayload)
+		return eventProcessor.MarkSampleSnrEventTask(ctx, eventPayload)
 	case constants.UpdateTaskSequence:
-		err = eventProcessor.UpdateTaskSequenceEventTask(ctx, eventPayload)
+		return eventProcessor.UpdateTaskSequenceEventTask(ctx, eventPayload)
 	case constants.UpdateSrfIdToLisEvent:
-		err = eventProcessor.UpdateSrfIdToLisEventTask(ctx, eventPayload)
+		return eventProcessor.UpdateSrfIdToLisEventTask(ctx, eventPayload)
 	default:
-		sentryInstance.LogError(ctx, "Unknown event type", nil, loggingAttributes)
+		return errUnknownEventType
 	}
+}
 
-	if err != nil {
-		utils.AddLog(ctx, constants.DEBUG_LEVEL, utils.GetCurrentFunctionName(), map[string]interface{}{
-			"event_type":    eventType,
-			"event_payload": eventPayload,
-			"trace_id":      traceId,
-			"contains":      contains,
-			"redis_key":     eventKey,
-		}, err)
-		return err
+// getEventSentAt gets the time the running event task was sent to the queue, from the header set on its
+// signature. It is nil for the tasks queued before the header was introduced.
+func getEventSentAt(ctx context.Context) *time.Time {
+	signature := tasks.SignatureFromContext(ctx)
+	if signature == nil {
+		return nil
 	}
 
-	_ = wt.Cache.Delete(ctx, eventKey)
-	utils.AddLog(ctx, constants.INFO_LEVEL, utils.GetCurrentFunctionName(),
-		map[string]interface{}{
-			"total_time_taken": time.Since(startTime).String(),
-			"event_type":       eventType,
-		}, nil)
+	sentAtMilliseconds, err := strconv.ParseInt(fmt.Sprint(signature.Headers[constants.EventSentAtHeader]), 10, 64)
+	if err != nil || sentAtMilliseconds <= 0 {
+		return nil
+	}
+	sentAt := time.UnixMilli(sentAtMilliseconds)
+	return &sentAt
+}
 
-	return nil
+// getEventRetryPolicy gets the number of retries and the initial wait between them for an event type.
+func getEventRetryPolicy(eventType string) (int, int) {
+	switch eventType {
+	case constants.OmsOrderCreatedEvent, constants.OmsOrderUpdatedEvent, constants.OmsTestDeleteEvent:
+		return constants.DefaultRetryCount, constants.OmsOrderCreateUpdateRetryTimeout
+	case constants.SampleCollectedEvent:
+		return constants.OmsCollectionRetryCount, constants.OmsCollectionRetryTimeout
+	case constants.LisEvent:
+		return constants.LisEventRetryCount, constants.LisEventRetryTimeout
+	}
+	return constants.DefaultRetryCount, constants.DefaultRetryTimout
+}
+
+// getEventAttempts gets the number of times the running event task has been attempted, using the retries left
+// on its signature, and whether this is its last attempt.
+func getEventAttempts(ctx context.Context, eventType string) (uint, bool) {
+	signature := tasks.SignatureFromContext(ctx)
+	if signature == nil {
+		return 1, true
+	}
+
+	retryCount, _ := getEventRetryPolicy(eventType)
+	attempts := retryCount - signature.RetryCount + 1
+	if attempts < 1 {
+		attempts = 1
+	}
+	return uint(attempts), signature.RetryCount <= 0
 }
 
 func getGroupIdByEventType(eventType, groupId string) string {
@@ -197,15 +276,7 @@ func CreateEventHandlerSignature(ctx context.Context, event structures.EventPayl
 		return nil, err
 	}
 
-	retryTimeout := constants.DefaultRetryTimout
-	retryCount := constants.DefaultRetryCount
-	switch event.EventType {
-	case constants.OmsOrderCreatedEvent, constants.OmsOrderUpdatedEvent, constants.OmsTestDeleteEvent:
-		retryTimeout = constants.OmsOrderCreateUpdateRetryTimeout
-	case constants.SampleCollectedEvent:
-		retryTimeout = constants.OmsCollectionRetryTimeout
-		retryCount = constants.OmsCollectionRetryCount
-	}
+	retryCount, retryTimeout := getEventRetryPolicy(event.EventType)
 
 	scheduleTask := &tasks.Signature{
 		Name: constants.ConsumerEvents,
@@ -231,6 +302,9 @@ func CreateEventHandlerSignature(ctx context.Context, event structures.EventPayl
 				Name:  "event_key",
 			},
 		},
+		Headers: tasks.Headers{
+			constants.EventSentAtHeader: event.SentAt,
+		},
 		RoutingKey:           constants.WorkerDefaultQueue,
 		RetryCount:           retryCount,
 		RetryTimeout:         retryTimeout,
@@ -312,9 +386,12 @@ func CreateEventHandlerSignatureForRawMessage(ctx context.Context, event structu
 				Name:  "event_key",
 			},
 		},
+		Headers: tasks.Headers{
+			constants.EventSentAtHeader: event.SentAt,
+		},
 		RoutingKey:           constants.WorkerDefaultQueue,
-		RetryCount:           3,
-		RetryTimeout:         10,
+		RetryCount:           constants.LisEventRetryCount,
+		RetryTimeout:         constants.LisEventRetryTimeout,
 		BrokerMessageGroupId: groupId,
 	}
 	return scheduleTask, nil
@@ -0,0 +1,58 @@
+package workerTasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RichardKnop/machinery/v1/tasks"
+
+	"github.com/Orange-Health/citadel/common/constants"
+	"github.com/Orange-Health/citadel/common/utils"
+)
+
+// ReplayDeadLetterEventTask processes a dead letter event again through the handler of its event type and
+// records the outcome on the event. It is not retried, a failed replay puts the event back to failed.
+func (wt *WorkerTaskService) ReplayDeadLetterEventTask(deadLetterEventId uint) error {
+	ctx := context.Background()
+
+	deadLetterEvent, cErr := wt.DeadLetterService.GetDeadLetterEvent(deadLetterEventId)
+	if cErr != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(),
+			map[string]interface{}{
+				"dead_letter_event_id": deadLetterEventId,
+			}, errors.New(cErr.Message))
+		return nil
+	}
+	if deadLetterEvent.Status != constants.DEAD_LETTER_STATUS_REPLAYING {
+		return nil
+	}
+
+	err := wt.processEvent(ctx, deadLetterEvent.EventType, deadLetterEvent.EventPayload, nil)
+	cErr = wt.DeadLetterService.FinishDeadLetterEventReplay(ctx, deadLetterEventId, err)
+	if cErr != nil {
+		utils.AddLog(ctx, constants.ERROR_LEVEL, utils.GetCurrentFunctionName(),
+			map[string]interface{}{
+				"dead_letter_event_id": deadLetterEventId,
+			}, errors.New(cErr.Message))
+	}
+
+	return nil
+}
+
+func ReplayDeadLetterEventTaskSignature(deadLetterEventId uint) *tasks.Signature {
+	groupId := fmt.Sprintf("%s:%v", constants.ReplayDeadLetterEventTask, time.Now().Unix())
+	return &tasks.Signature{
+		Name: constants.ReplayDeadLetterEventTask,
+		Args: []tasks.Arg{
+			{
+				Type:  "uint",
+				Value: deadLetterEventId,
+				Name:  "deadLetterEventId",
+			},
+		},
+		RoutingKey:           constants.WorkerDefaultQueue,
+		BrokerMessageGroupId: groupId,
+	}
+}
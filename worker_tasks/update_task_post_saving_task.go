@@ -70,6 +70,7 @@ func (wt *WorkerTaskService) UpdateTaskPostSavingTask(taskId uint) error {
 		}
 	}
 
+	wasTaskCritical := taskMetadata.IsCritical
 	taskMetadata.IsCritical = isTaskCritical
 
 	taskDoctorTat := task.DoctorTat
@@ -153,6 +154,11 @@ func (wt *WorkerTaskService) UpdateTaskPostSavingTask(taskId uint) error {
 		return err
 	}
 
+	wt.TaskEventsService.PublishTaskEvent(ctx, constants.TaskEventCreated, taskId, 0)
+	if isTaskCritical && !wasTaskCritical {
+		wt.TaskEventsService.PublishTaskEvent(ctx, constants.TaskEventCriticalFlagged, taskId, 0)
+	}
+
 	wt.CommonTaskProcessor.CheckForTaskCompletion(ctx, taskId)
 
 	log.INFO.Print("UpdateTaskPostSavingTask :: Ended")